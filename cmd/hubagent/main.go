@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"math"
@@ -24,7 +25,9 @@ import (
 	"strings"
 	"sync"
 
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -32,6 +35,7 @@ import (
 	clusterinventory "sigs.k8s.io/cluster-inventory-api/apis/v1alpha1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -41,6 +45,7 @@ import (
 	fleetnetworkingv1alpha1 "go.goms.io/fleet-networking/api/v1alpha1"
 
 	clusterv1beta1 "github.com/kubefleet-dev/kubefleet/apis/cluster/v1beta1"
+	placementv1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1"
 	placementv1alpha1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1alpha1"
 	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
 	"github.com/kubefleet-dev/kubefleet/cmd/hubagent/options"
@@ -66,7 +71,6 @@ var (
 
 const (
 	FleetWebhookCertDir = "/tmp/k8s-webhook-server/serving-certs"
-	FleetWebhookPort    = 9443
 )
 
 func init() {
@@ -76,6 +80,7 @@ func init() {
 	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
 	utilruntime.Must(fleetnetworkingv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(placementv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(placementv1.AddToScheme(scheme))
 	utilruntime.Must(clusterinventory.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 	klog.InitFlags(nil)
@@ -105,6 +110,20 @@ func main() {
 	config := ctrl.GetConfigOrDie()
 	config.QPS, config.Burst = float32(opts.HubQPS), opts.HubBurst
 
+	if opts.CleanupWebhookConfigurations {
+		k8Client, err := client.New(config, client.Options{Scheme: scheme})
+		if err != nil {
+			klog.ErrorS(err, "unable to create the client to clean up the webhook configurations")
+			exitWithErrorFunc()
+		}
+		if err := webhook.CleanupWebhookConfigurations(ctrl.SetupSignalHandler(), k8Client, opts.WebhookNameSuffix); err != nil {
+			klog.ErrorS(err, "unable to clean up the webhook configurations")
+			exitWithErrorFunc()
+		}
+		klog.InfoS("cleaned up the webhook configurations")
+		return
+	}
+
 	mgrOpts := ctrl.Options{
 		Scheme: scheme,
 		Cache: cache.Options{
@@ -120,10 +139,20 @@ func main() {
 			BindAddress: opts.MetricsBindAddress,
 		},
 		WebhookServer: ctrlwebhook.NewServer(ctrlwebhook.Options{
-			Port:    FleetWebhookPort,
+			Port:    opts.WebhookTargetPort,
 			CertDir: FleetWebhookCertDir,
 		}),
 	}
+	if opts.EnableWebhook && opts.WebhookConfigMapName != "" {
+		// The webhook config ConfigMap is the only ConfigMap read through the cache, so the informer watches it alone
+		// instead of every ConfigMap in the hub cluster.
+		mgrOpts.Cache.ByObject = map[client.Object]cache.ByObject{
+			&corev1.ConfigMap{}: {
+				Namespaces: map[string]cache.Config{os.Getenv("POD_NAMESPACE"): {}},
+				Field:      fields.OneTermEqualSelector("metadata.name", opts.WebhookConfigMapName),
+			},
+		}
+	}
 	if opts.EnablePprof {
 		mgrOpts.PprofBindAddress = fmt.Sprintf(":%d", opts.PprofPort)
 	}
@@ -156,16 +185,14 @@ func main() {
 		exitWithErrorFunc()
 	}
 
+	ctx := ctrl.SetupSignalHandler()
 	if opts.EnableWebhook {
-		whiteListedUsers := strings.Split(opts.WhiteListedUsers, ",")
-		if err := SetupWebhook(mgr, options.WebhookClientConnectionType(opts.WebhookClientConnectionType), opts.WebhookServiceName, whiteListedUsers,
-			opts.EnableGuardRail, opts.EnableV1Beta1APIs, opts.DenyModifyMemberClusterLabels, opts.EnableWorkload, opts.NetworkingAgentsEnabled); err != nil {
+		if err := SetupWebhook(ctx, mgr, opts); err != nil {
 			klog.ErrorS(err, "unable to set up webhook")
 			exitWithErrorFunc()
 		}
 	}
 
-	ctx := ctrl.SetupSignalHandler()
 	if err := workload.SetupControllers(ctx, &wg, mgr, config, opts); err != nil {
 		klog.ErrorS(err, "unable to set up controllers")
 		exitWithErrorFunc()
@@ -201,21 +228,150 @@ func main() {
 }
 
 // SetupWebhook generates the webhook cert and then set up the webhook configurator.
-func SetupWebhook(mgr manager.Manager, webhookClientConnectionType options.WebhookClientConnectionType, webhookServiceName string,
-	whiteListedUsers []string, enableGuardRail, isFleetV1Beta1API bool, denyModifyMemberClusterLabels bool, enableWorkload bool, networkingAgentsEnabled bool) error {
+func SetupWebhook(ctx context.Context, mgr manager.Manager, opts *options.Options) error {
+	webhookOpts, err := newWebhookOptions(opts)
+	if err != nil {
+		klog.ErrorS(err, "invalid webhook options")
+		return err
+	}
+	var integrity *webhook.WebhookConfigIntegrity
+	if opts.WebhookIntegrityCheckInterval.Duration > 0 {
+		integrity = webhook.NewWebhookConfigIntegrity(mgr.GetClient(), mgr.GetEventRecorderFor(webhook.WebhookConfigIntegrityEventSource), opts.WebhookIntegrityCheckInterval.Duration)
+		webhookOpts.ConfigIntegrity = integrity
+	}
 	// Generate self-signed key and crt files in FleetWebhookCertDir for the webhook server to start.
-	w, err := webhook.NewWebhookConfig(mgr, webhookServiceName, FleetWebhookPort, &webhookClientConnectionType, FleetWebhookCertDir, enableGuardRail, denyModifyMemberClusterLabels, enableWorkload)
+	w, err := webhook.NewWebhookConfig(mgr, webhookOpts)
 	if err != nil {
 		klog.ErrorS(err, "fail to generate WebhookConfig")
 		return err
 	}
+	if err = w.Validate(); err != nil {
+		klog.ErrorS(err, "invalid webhook config")
+		return err
+	}
+	if integrity != nil {
+		if err = mgr.Add(integrity); err != nil {
+			klog.ErrorS(err, "unable to add the webhook configuration integrity checker")
+			return err
+		}
+		if err = mgr.AddMetricsServerExtraHandler(webhook.IntegrityStatusPath, integrity.StatusHandler()); err != nil {
+			klog.ErrorS(err, "unable to add the webhook configuration integrity status handler")
+			return err
+		}
+	}
 	if err = mgr.Add(w); err != nil {
 		klog.ErrorS(err, "unable to add WebhookConfig")
 		return err
 	}
-	if err = webhook.AddToManager(mgr, whiteListedUsers, denyModifyMemberClusterLabels, networkingAgentsEnabled); err != nil {
+	if err = mgr.AddReadyzCheck("webhook-configurations", w.ReadinessChecker()); err != nil {
+		klog.ErrorS(err, "unable to add the webhook configurations readiness check")
+		return err
+	}
+	if err = mgr.AddMetricsServerExtraHandler(webhook.DebugPath, w.DebugHandler()); err != nil {
+		klog.ErrorS(err, "unable to add the webhook debug handler")
+		return err
+	}
+	var auditLogger webhook.CloudAuditLogger
+	if opts.EnablePlacementAuditLog {
+		auditLogger = webhook.NewJSONAuditLogger(os.Stdout)
+	}
+	var admissionHistory *webhook.AdmissionRequestStore
+	if opts.AdmissionHistoryTokenFile != "" {
+		content, err := os.ReadFile(opts.AdmissionHistoryTokenFile)
+		if err != nil {
+			klog.ErrorS(err, "unable to read the admission history token", "file", opts.AdmissionHistoryTokenFile)
+			return err
+		}
+		token := strings.TrimSpace(string(content))
+		if token == "" {
+			err = fmt.Errorf("the admission history token file %s is empty", opts.AdmissionHistoryTokenFile)
+			klog.ErrorS(err, "invalid admission history token")
+			return err
+		}
+		admissionHistory = webhook.NewAdmissionRequestStore(opts.AdmissionHistorySize)
+		mgr.GetWebhookServer().Register(webhook.AdmissionHistoryPath, admissionHistory.Handler(token))
+	}
+	if err = w.AddToManager(mgr, auditLogger, admissionHistory); err != nil {
 		klog.ErrorS(err, "unable to register webhooks to the manager")
 		return err
 	}
+	if opts.WebhookConfigMapName != "" {
+		// The webhook config has made sure the Pod namespace is set.
+		if err = w.StartConfigMapWatcher(ctx, mgr.GetClient(), opts.WebhookConfigMapName, os.Getenv("POD_NAMESPACE")); err != nil {
+			klog.ErrorS(err, "unable to watch the webhook config ConfigMap", "configMap", opts.WebhookConfigMapName)
+			return err
+		}
+	}
 	return nil
 }
+
+// newWebhookOptions returns the settings of the webhook config derived from the hub agent options, which have been
+// validated. It returns an error if any of the webhook CA bundle files cannot be read.
+func newWebhookOptions(opts *options.Options) (webhook.Options, error) {
+	var trustedServiceAccounts []string
+	if opts.WebhookTrustedServiceAccounts != "" {
+		trustedServiceAccounts = strings.Split(opts.WebhookTrustedServiceAccounts, ",")
+	}
+	// The webhook role, service names and fleet RBAC and snapshot writer patterns are validated together with the other options.
+	fleetRBACWriterPatterns, _ := options.ParseFleetRBACWriterPatterns(opts.FleetRBACWriterPatterns)
+	fleetSnapshotWriterPatterns, _ := options.ParseFleetRBACWriterPatterns(opts.FleetSnapshotWriterPatterns)
+	webhookRole, _ := options.ParseWebhookRole(opts.WebhookRole)
+	webhookServiceNames, _ := options.ParseWebhookServiceNames(opts.WebhookServiceNames)
+	shadowValidationRules, _ := options.ParseShadowValidationRules(opts.ShadowValidationRules)
+	evictionTargetValidation, _ := options.ParseEvictionTargetValidation(opts.EvictionTargetValidation)
+	placementClusterNamesValidation, _ := options.ParsePlacementClusterNamesValidation(opts.PlacementClusterNamesValidation)
+	placementNumberOfClustersValidation, _ := options.ParsePlacementNumberOfClustersValidation(opts.PlacementNumberOfClustersValidation)
+	placementPickAllFleetSizeValidation, _ := options.ParsePlacementPickAllFleetSizeValidation(opts.PlacementPickAllFleetSizeValidation)
+	lookupFailurePolicies, _ := options.ParseLookupFailurePolicies(opts.WebhookLookupFailurePolicies)
+	var caBundle []byte
+	if opts.WebhookCABundleFiles != "" {
+		for _, file := range strings.Split(opts.WebhookCABundleFiles, ",") {
+			file = strings.TrimSpace(file)
+			content, err := os.ReadFile(file)
+			if err != nil {
+				return webhook.Options{}, fmt.Errorf("unable to read the webhook CA bundle %s: %w", file, err)
+			}
+			caBundle = append(append(caBundle, content...), '\n')
+		}
+	}
+	clientConnectionType := options.WebhookClientConnectionType(opts.WebhookClientConnectionType)
+	return webhook.Options{
+		ServiceName:                          opts.WebhookServiceName,
+		ServicePort:                          int32(opts.WebhookServicePort),
+		TargetPort:                           int32(opts.WebhookTargetPort),
+		ClientConnectionType:                 &clientConnectionType,
+		CertDir:                              FleetWebhookCertDir,
+		Role:                                 webhookRole,
+		ServiceNames:                         webhookServiceNames,
+		AdditionalCABundle:                   caBundle,
+		WebhookNameSuffix:                    opts.WebhookNameSuffix,
+		EnableWebhookConfigurationAnchor:     opts.EnableWebhookConfigurationAnchor,
+		DisallowPrivilegedPorts:              opts.DisallowPrivilegedWebhookPorts,
+		EnableGuardRail:                      opts.EnableGuardRail,
+		EnableWorkload:                       opts.EnableWorkload,
+		DenyModifyMemberClusterLabels:        opts.DenyModifyMemberClusterLabels,
+		NetworkingAgentsEnabled:              opts.NetworkingAgentsEnabled,
+		WhiteListedUsers:                     strings.Split(opts.WhiteListedUsers, ","),
+		FleetRBACWriterPatterns:              fleetRBACWriterPatterns,
+		FleetSnapshotWriterPatterns:          fleetSnapshotWriterPatterns,
+		WebhookCertSecretName:                opts.WebhookCertSecretName,
+		LogRequestContext:                    opts.LogWebhookRequestContext,
+		TrustedServiceAccounts:               trustedServiceAccounts,
+		ShadowValidationRules:                shadowValidationRules,
+		DenyPlacementNameCollisions:          opts.DenyPlacementNameCollisions,
+		DenyPlacementUpdatesDuringUpdateRuns: opts.DenyPlacementUpdatesDuringUpdateRuns,
+		RequireSecretPropagationOptIn:        opts.RequireSecretPropagationOptIn,
+		DenyCRDCoSelection:                   opts.DenyCRDCoSelection,
+		DenyPlacementOverrideConflicts:       opts.DenyPlacementOverrideConflicts,
+		AllowUnknownKinds:                    opts.AllowUnknownWebhookKinds,
+		IncidentWindowConfigMapName:          opts.IncidentWindowConfigMapName,
+		LogDeniedUpdateDiffs:                 opts.LogDeniedUpdateDiffs,
+		EvictionTargetValidation:             evictionTargetValidation,
+		PlacementClusterNamesValidation:      placementClusterNamesValidation,
+		PlacementNumberOfClustersValidation:  placementNumberOfClustersValidation,
+		PlacementPickAllFleetSizeValidation:  placementPickAllFleetSizeValidation,
+		PlacementPickAllFleetSizeThreshold:   opts.PlacementPickAllFleetSizeThreshold,
+		LookupBudget:                         opts.WebhookLookupBudget.Duration,
+		LookupFailurePolicies:                lookupFailurePolicies,
+	}, nil
+}
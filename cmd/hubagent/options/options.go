@@ -25,6 +25,7 @@ import (
 	componentbaseconfig "k8s.io/component-base/config"
 
 	"github.com/kubefleet-dev/kubefleet/pkg/utils"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
 )
 
 // Options contains everything necessary to create and run controller-manager.
@@ -48,12 +49,116 @@ type Options struct {
 	EnableWebhook bool
 	// Webhook service name
 	WebhookServiceName string
+	// WebhookServicePort is the port the webhook service exposes, which the webhook configurations point at.
+	WebhookServicePort int
+	// WebhookTargetPort is the port the webhook server listens on in the hub agent container.
+	WebhookTargetPort int
 	// EnableGuardRail indicates if we will enable fleet guard rail webhook configurations.
 	EnableGuardRail bool
 	// WhiteListedUsers indicates the list of user who are allowed to modify fleet resources
 	WhiteListedUsers string
+	// FleetRBACWriterPatterns is the comma-separated list of user name patterns, e.g. the hub agent and member agent
+	// service accounts, which are allowed to modify the fleet-managed Roles and RoleBindings in fleet member namespaces.
+	FleetRBACWriterPatterns string
+	// FleetSnapshotWriterPatterns is the comma-separated list of user name patterns, e.g. the hub agent service
+	// account, which are allowed to modify the ClusterResourceSnapshots and ClusterSchedulingPolicySnapshots and to
+	// remove the fleet finalizers of the ClusterResourcePlacements.
+	FleetSnapshotWriterPatterns string
+	// WebhookTrustedServiceAccounts is the comma-separated list of service accounts whose requests skip
+	// the advisory placement validations.
+	WebhookTrustedServiceAccounts string
 	// Sets the connection type for the webhook.
 	WebhookClientConnectionType string
+	// WebhookRole is the group of fleet webhooks this hub agent serves: all, placement, guardrail or workload.
+	WebhookRole string
+	// WebhookServiceNames is the comma separated list of <group>=<service name> pairs which name the service
+	// serving each webhook group; groups not listed are served by WebhookServiceName.
+	WebhookServiceNames string
+	// WebhookConfigMapName is the name of the ConfigMap in the hub agent namespace whose data overrides the
+	// placement validation settings at runtime; the ConfigMap is not watched if it is empty.
+	WebhookConfigMapName string
+	// ShadowValidationRules is the comma-separated list of placement validation rules which run in shadow mode,
+	// i.e., their failures are recorded and returned as warnings but never deny the request.
+	ShadowValidationRules string
+	// DenyPlacementNameCollisions indicates if the webhook denies the creation of a ClusterResourcePlacement or
+	// ResourcePlacement whose name is used by a placement of the other scope.
+	DenyPlacementNameCollisions bool
+	// DenyPlacementUpdatesDuringUpdateRuns indicates if the webhook denies the spec updates of a placement while
+	// any staged update run which references the placement has not finished.
+	DenyPlacementUpdatesDuringUpdateRuns bool
+	// RequireSecretPropagationOptIn indicates if the webhook denies the placements which select Secrets unless they
+	// carry the kubefleet.io/allow-secret-propagation: "true" annotation, instead of only warning about them.
+	RequireSecretPropagationOptIn bool
+	// DenyCRDCoSelection indicates if the webhook denies the placements which select CustomResourceDefinitions
+	// together with custom resources of the groups they may serve, instead of only warning about them.
+	DenyCRDCoSelection bool
+	// DenyPlacementOverrideConflicts indicates if the webhook denies a ClusterResourcePlacement which places the
+	// resources of another ClusterResourcePlacement on the same clusters with different ClusterResourceOverrides.
+	DenyPlacementOverrideConflicts bool
+	// AllowUnknownWebhookKinds indicates if the webhooks allow the requests of the kinds they do not accept, e.g., a
+	// version served after a CRD upgrade, with a warning instead of failing them.
+	AllowUnknownWebhookKinds bool
+	// IncidentWindowConfigMapName is the name of the ConfigMap, in the namespace of the hub agent, declaring the
+	// incidents and scheduling the maintenance windows during which the ClusterResourcePlacement spec updates are
+	// denied. The updates are not checked if it is empty.
+	IncidentWindowConfigMapName string
+	// LogDeniedUpdateDiffs indicates if the webhook logs the redacted diff between the old and new objects of every
+	// denied placement update.
+	LogDeniedUpdateDiffs bool
+	// EvictionTargetValidation is how the webhook handles a ClusterResourcePlacementEviction targeting a cluster which
+	// is not selected by the latest scheduling decision of its placement: disabled, warn or enforce.
+	EvictionTargetValidation string
+	// PlacementClusterNamesValidation is how the webhook handles a PickFixed ClusterResourcePlacement naming clusters
+	// which are not found as MemberClusters or are leaving or have left the fleet: disabled, warn or enforce.
+	PlacementClusterNamesValidation string
+	// PlacementNumberOfClustersValidation is how the webhook handles a PickN ClusterResourcePlacement requesting more
+	// clusters than the MemberClusters which have joined or are joining the fleet: disabled, warn or enforce.
+	PlacementNumberOfClustersValidation string
+	// PlacementPickAllFleetSizeValidation is how the webhook handles a PickAll ClusterResourcePlacement without
+	// required cluster affinity in a fleet with more member clusters than PlacementPickAllFleetSizeThreshold.
+	PlacementPickAllFleetSizeValidation string
+	// PlacementPickAllFleetSizeThreshold is the number of member clusters above which the PickAll
+	// ClusterResourcePlacements without required cluster affinity are validated.
+	PlacementPickAllFleetSizeThreshold int
+	// WebhookIntegrityCheckInterval is how often the webhook configurations applied by the hub agent are compared
+	// against the applied ones to detect modifications; they are not checked if it is 0.
+	WebhookIntegrityCheckInterval metav1.Duration
+	// EnableWebhookConfigurationAnchor indicates if the webhook configurations are owned by a fleet-owned cluster
+	// scoped anchor object, so that deleting the anchor garbage collects all of them at once.
+	EnableWebhookConfigurationAnchor bool
+	// DisallowPrivilegedWebhookPorts indicates if a webhook service port below 1024 other than 443 is rejected, as
+	// it requires root privileges.
+	DisallowPrivilegedWebhookPorts bool
+	// CleanupWebhookConfigurations indicates if the hub agent deletes all the webhook configurations it generated
+	// and exits, instead of running.
+	CleanupWebhookConfigurations bool
+	// WebhookNameSuffix is appended to the names of the webhook configurations and the webhooks generated by the
+	// hub agent, so that the hubs sharing a cluster do not manage the webhook configurations of one another.
+	WebhookNameSuffix string
+	// WebhookCertSecretName is the name of the Secret, in the namespace of the hub agent, holding the webhook serving
+	// certificate, which the guard rail protects from the users other than the hub controllers and the white listed ones.
+	WebhookCertSecretName string
+	// WebhookCABundleFiles is the comma-separated list of the files holding the PEM encoded CA certificates, e.g., the
+	// root and intermediate CAs of an issuer chain, published in the caBundle of the webhooks besides the generated CA.
+	WebhookCABundleFiles string
+	// WebhookLookupBudget is the time the client-backed placement checks of an admission request can spend reading
+	// the hub cluster.
+	WebhookLookupBudget metav1.Duration
+	// WebhookLookupFailurePolicies is the comma separated <check>=<policy> pairs setting how each client-backed
+	// placement check handles an exhausted lookup budget: failOpen or failClosed.
+	WebhookLookupFailurePolicies string
+	// EnablePlacementAuditLog indicates if every ClusterResourcePlacement mutation admitted by the webhook is written
+	// to the standard output as a structured JSON audit entry.
+	EnablePlacementAuditLog bool
+	// AdmissionHistorySize is the number of the last denied ClusterResourcePlacement admission requests kept for
+	// replaying them when debugging.
+	AdmissionHistorySize int
+	// AdmissionHistoryTokenFile is the path of the file holding the bearer token which protects the admission history
+	// endpoint of the webhook server; the admission history is disabled if it is empty.
+	AdmissionHistoryTokenFile string
+	// LogWebhookRequestContext indicates if every line logged by the CRP, RP and guard rail webhook handlers carries
+	// the UID, user and operation of the admission request and the webhook path.
+	LogWebhookRequestContext bool
 	// NetworkingAgentsEnabled indicates if we enable network agents
 	NetworkingAgentsEnabled bool
 	// ClusterUnhealthyThreshold is the duration of failure for the cluster to be considered unhealthy.
@@ -151,9 +256,44 @@ func (o *Options) AddFlags(flags *flag.FlagSet) {
 	flag.BoolVar(&o.EnableWebhook, "enable-webhook", true, "If set, the fleet webhook is enabled.")
 	// set a default value 'fleetwebhook' for webhook service name for backward compatibility. The service name was hard coded to 'fleetwebhook' in the past.
 	flag.StringVar(&o.WebhookServiceName, "webhook-service-name", "fleetwebhook", "Fleet webhook service name.")
+	flag.IntVar(&o.WebhookServicePort, "webhook-service-port", 9443, "The port the webhook service exposes, which the webhook configurations point at.")
+	flag.IntVar(&o.WebhookTargetPort, "webhook-target-port", 9443, "The port the webhook server listens on, which the webhook service forwards requests to.")
 	flag.BoolVar(&o.EnableGuardRail, "enable-guard-rail", false, "If set, the fleet guard rail webhook configurations are enabled.")
 	flag.StringVar(&o.WhiteListedUsers, "whitelisted-users", "", "If set, white listed users can modify fleet related resources.")
+	flag.StringVar(&o.FleetRBACWriterPatterns, "fleet-rbac-writer-patterns", "system:serviceaccount:fleet-system:*", "Comma-separated user name patterns (e.g. system:serviceaccount:fleet-system:*), in the syntax of shell file name patterns, matching the hub agent and member agent identities. Besides the white listed users, only the matching users can modify the fleet-managed Roles and RoleBindings in fleet member namespaces when the guard rail is enabled.")
+	flag.StringVar(&o.FleetSnapshotWriterPatterns, "fleet-snapshot-writer-patterns", "system:serviceaccount:fleet-system:*", "Comma-separated user name patterns (e.g. system:serviceaccount:fleet-system:*), in the syntax of shell file name patterns, matching the hub controller identities. Besides the white listed users, only the matching users can create, update or delete the ClusterResourceSnapshots and ClusterSchedulingPolicySnapshots when the guard rail is enabled, as any other edit corrupts the rollout history. They are also the only users besides the white listed ones allowed to remove the kubernetes-fleet.io/ prefixed finalizers of the ClusterResourcePlacements.")
+	flag.StringVar(&o.WebhookTrustedServiceAccounts, "webhook-trusted-service-accounts", "", "Comma-separated service accounts, in the form of system:serviceaccount:<namespace>:<name>, whose requests skip the advisory placement validations. Correctness validations are always enforced.")
 	flag.StringVar(&o.WebhookClientConnectionType, "webhook-client-connection-type", "url", "Sets the connection type used by the webhook client. Only URL or Service is valid.")
+	flag.StringVar(&o.WebhookRole, "webhook-role", "all", "The group of fleet webhooks this hub agent serves. Only all, placement, guardrail or workload is valid.")
+	flag.StringVar(&o.WebhookServiceNames, "webhook-service-names", "", "Comma separated <group>=<service name> pairs (e.g. placement=fleetwebhook-placement,guardrail=fleetwebhook-guardrail) naming the service which serves each webhook group. Groups not listed are served by the webhook-service-name service.")
+	flag.StringVar(&o.WebhookConfigMapName, "webhook-config-map-name", "", "The name of the ConfigMap in the hub agent namespace whose data (trustedServiceAccounts, shadowValidationRules, maxClusterNames, metadataSizeSoftLimitBytes, metadataSizeHardLimitBytes, maxTolerations, maxTopologySpreadConstraints, maxAffinityTerms, maxPlacementsPerTeam, maxRevisionHistoryLimitReductionPercent, maxDiffLogBytes, maxWorkManifests, maxWorkManifestSizeBytes, maxWorkSizeBytes, hubAgentIdentities, hubAgentMaxWorkManifests, hubAgentMaxWorkManifestSizeBytes, hubAgentMaxWorkSizeBytes, requiredLabels, a JSON object mapping the label keys every ClusterResourcePlacement must carry to the regular expressions their values must match, and memberClusterLabelSchemas, a JSON object mapping the MemberCluster label keys to their allowedValues, maxLength and regex) overrides the placement and work validation settings at runtime. The ConfigMap is not watched if it is not set.")
+	flag.StringVar(&o.ShadowValidationRules, "shadow-validation-rules", "", "Comma-separated names of the placement validation rules (e.g. MetadataSize) which run in shadow mode. The failures of a shadow rule are recorded in metrics and returned as warnings but never deny the request.")
+	flag.BoolVar(&o.DenyPlacementNameCollisions, "deny-placement-name-collisions", false, "If set, the webhook denies the creation of a ClusterResourcePlacement or ResourcePlacement whose name is used by a placement of the other scope, as the objects they generate on the member clusters could collide.")
+	flag.BoolVar(&o.DenyPlacementUpdatesDuringUpdateRuns, "deny-placement-updates-during-update-runs", false, "If set, the webhook denies the spec updates of a ClusterResourcePlacement or ResourcePlacement while any staged update run which references it has not succeeded or failed, as the run would keep rolling out a stale snapshot. Metadata only updates are allowed.")
+	flag.BoolVar(&o.RequireSecretPropagationOptIn, "require-secret-propagation-opt-in", false, "If set, the webhook denies a ClusterResourcePlacement or ResourcePlacement which selects Secrets, by kind or through the namespaces selected with all their resources, unless it carries the kubefleet.io/allow-secret-propagation: \"true\" annotation. Such placements are only warned about otherwise.")
+	flag.BoolVar(&o.DenyCRDCoSelection, "deny-crd-co-selection", false, "If set, the webhook denies a ClusterResourcePlacement which selects CustomResourceDefinitions together with custom resources of the groups they may serve, as the custom resources fail to apply on the member clusters where they are applied before the CustomResourceDefinitions are established. Such placements are only warned about otherwise.")
+	flag.BoolVar(&o.DenyPlacementOverrideConflicts, "deny-placement-override-conflicts", false, "If set, the webhook denies the creation, or the selector and policy updates, of a ClusterResourcePlacement which places the resources of another ClusterResourcePlacement on the same clusters with different ClusterResourceOverrides. The check lists the MemberClusters, ClusterResourcePlacements and ClusterResourceOverrides of the hub cluster.")
+	flag.BoolVar(&o.AllowUnknownWebhookKinds, "allow-unknown-webhook-kinds", false, "If set, the placement and guard rail webhooks allow the requests of the kinds they do not accept, e.g., a version served after a CRD upgrade, with a warning instead of failing them. The requests of such kinds are counted by the fleet_webhook_unknown_kind_total metric either way.")
+	flag.StringVar(&o.IncidentWindowConfigMapName, "incident-window-configmap-name", "", "The name of the ConfigMap, in the namespace of the hub agent, declaring an incident under its incident key or scheduling maintenance windows as a JSON list of {start, end, reason} under its windows key. The webhook denies the ClusterResourcePlacement spec updates during these windows unless they add the fleet.azure.com/incident-bypass: \"true\" annotation; the annotation left by an earlier update is not honored. The updates are not checked if it is empty.")
+	flag.BoolVar(&o.LogDeniedUpdateDiffs, "log-denied-update-diffs", false, "If set, the webhook logs the diff between the old and new objects of every denied ClusterResourcePlacement or ResourcePlacement update, with the values of the fields named like credentials redacted. The diffs are also logged at verbosity 4 or above.")
+	flag.StringVar(&o.EvictionTargetValidation, "eviction-target-validation", "disabled", "How the webhook handles a ClusterResourcePlacementEviction targeting a cluster which is not selected by the latest scheduling decision of its placement, as the eviction would have no effect. Only disabled, warn (allow with a warning, which tolerates races with the scheduler) or enforce (deny) is valid.")
+	flag.StringVar(&o.PlacementClusterNamesValidation, "placement-cluster-names-validation", "disabled", "How the webhook handles a PickFixed ClusterResourcePlacement naming clusters which are not found as MemberClusters or are leaving or have left the fleet, as the resources are never placed on them. Only disabled, warn (allow with a warning) or enforce (deny) is valid. Only the cluster names added by an update are checked.")
+	flag.StringVar(&o.PlacementNumberOfClustersValidation, "placement-number-of-clusters-validation", "disabled", "How the webhook handles a PickN ClusterResourcePlacement whose numberOfClusters is greater than the number of MemberClusters which have joined or are joining the fleet, as the placement can never be fully scheduled. Only disabled, warn (allow with a warning) or enforce (deny) is valid. An update is only checked if it raises the numberOfClusters.")
+	flag.StringVar(&o.PlacementPickAllFleetSizeValidation, "placement-pick-all-fleet-size-validation", "disabled", "How the webhook handles a PickAll ClusterResourcePlacement, explicit or defaulted, without any required cluster affinity when more MemberClusters than the placement-pick-all-fleet-size-threshold have joined or are joining the fleet, as it ships the selected resources to every cluster. Only disabled, warn (allow with a warning) or enforce (deny unless the placement carries the kubefleet.io/confirm-pick-all: \"true\" annotation) is valid. An update is only checked if it makes the placement pick all the clusters.")
+	flag.IntVar(&o.PlacementPickAllFleetSizeThreshold, "placement-pick-all-fleet-size-threshold", validator.DefaultPickAllFleetSizeThreshold, "The number of MemberClusters above which the webhook validates the PickAll ClusterResourcePlacements without required cluster affinity, see placement-pick-all-fleet-size-validation. It must be greater than 0.")
+	flags.DurationVar(&o.WebhookIntegrityCheckInterval.Duration, "webhook-integrity-check-interval", 5*time.Minute, "How often the webhook configurations applied by the hub agent are re-read and compared against the applied ones. A Warning event is emitted on a modified configuration and the result of the last check is served at /integrity-status on the metrics server. The configurations are not checked if it is 0.")
+	flag.BoolVar(&o.EnableWebhookConfigurationAnchor, "enable-webhook-configuration-anchor", false, "If set, the webhook configurations are owned by the fleet-webhook-configuration-anchor ClusterRole, which is owned by the fleet-system namespace, so that deleting the anchor garbage collects all the webhook configurations at once.")
+	flag.BoolVar(&o.DisallowPrivilegedWebhookPorts, "disallow-privileged-webhook-ports", false, "If set, the hub agent fails to start if the webhook service port is below 1024 and not 443, as privileged ports require root privileges which a security-hardened deployment should not use. A privileged port is logged regardless.")
+	flag.BoolVar(&o.CleanupWebhookConfigurations, "cleanup-webhook-configurations", false, "If set, the hub agent deletes all the webhook configurations labeled as generated by fleet, and the webhook configuration anchor, then exits. It is meant to be run once when fleet is uninstalled, as the fail closed validating webhook configurations left behind block unrelated writes.")
+	flag.StringVar(&o.WebhookCertSecretName, "webhook-cert-secret-name", "", "The name of the Secret, in the namespace of the hub agent, holding the webhook serving certificate, e.g. fleet-webhook-server-cert, which must be a DNS-1123 subdomain. When the guard rail is enabled, only the white listed users and the users matching the fleet-snapshot-writer-patterns, i.e. the hub agent, can update or delete it, as corrupting it takes down the admission of the fleet. The Secret is not protected if it is empty.")
+	flag.StringVar(&o.WebhookCABundleFiles, "webhook-ca-bundle-files", "", "Comma-separated list of the files holding PEM encoded CA certificates, each of which may hold several of them, e.g. the root and intermediate CAs of an issuer chain. They are published in the caBundle of the webhooks besides the CA generated for the webhook serving certificate, with the duplicates dropped and the root CAs ordered before the intermediate ones, so that the API server keeps trusting the webhooks when an intermediate CA rotates. Every certificate must be a CA.")
+	flag.StringVar(&o.WebhookNameSuffix, "webhook-name-suffix", "", "The suffix appended, after a dash, to the names of the webhook configurations, the webhooks and the webhook configuration anchor generated by the hub agent, which must be a DNS-1123 label. The hub agent only manages, and cleans up, the webhook configurations generated with the same suffix, so that multiple hubs sharing a cluster do not manage the webhook configurations of one another.")
+	flags.DurationVar(&o.WebhookLookupBudget.Duration, "webhook-lookup-budget", validator.DefaultLookupBudget, "The time the client-backed checks of a ClusterResourcePlacement or ResourcePlacement admission request can spend reading the hub cluster, so that a slow or partitioned cache cannot stall the admission. The exhaustions are counted per check in the fleet_webhook_lookup_budget_exhausted_total metric.")
+	flag.StringVar(&o.WebhookLookupFailurePolicies, "webhook-lookup-failure-policies", "", "Comma separated <check>=<policy> pairs (e.g. ClusterNames=failClosed,TeamQuota=failOpen) setting how the request is handled once a client-backed check exhausts the lookup budget: failOpen (allow with a warning) or failClosed (deny with the ValidationUnavailable reason). The checks are ClusterNames, PlacementNameCollision, UpdateRuns, TeamQuota, OwnedPolicySnapshots, PolicySimulation, PlacementNamespace, NumberOfClusters and OverrideConflicts; the advisory ones fail open and the others fail closed by default.")
+	flag.IntVar(&o.AdmissionHistorySize, "admission-history-size", 100, "The number of the last denied ClusterResourcePlacement admission requests kept in memory and served at /debug/admission-history on the webhook server. The default is used if it is not positive.")
+	flag.StringVar(&o.AdmissionHistoryTokenFile, "admission-history-token-file", "", "The path of the file holding the bearer token the clients of /debug/admission-history on the webhook server must present. The denied admission requests are neither kept nor served if it is empty.")
+	flag.BoolVar(&o.LogWebhookRequestContext, "log-webhook-request-context", false, "If set, every line logged by the ClusterResourcePlacement, ResourcePlacement and guard rail webhook handlers carries the requestUID, user and operation of the admission request and the webhook path, so that it can be correlated with the API server audit entries. The verbosity of the lines is unchanged.")
+	flag.BoolVar(&o.EnablePlacementAuditLog, "enable-placement-audit-log", false, "If set, every ClusterResourcePlacement create and update handled by the mutating webhook is written to the standard output as a structured JSON audit entry, for the cloud logging agent to ship to the audit log service.")
 	flag.BoolVar(&o.NetworkingAgentsEnabled, "networking-agents-enabled", false, "Whether the networking agents are enabled or not.")
 	flags.DurationVar(&o.ClusterUnhealthyThreshold.Duration, "cluster-unhealthy-threshold", 60*time.Second, "The duration for a member cluster to be in a degraded state before considered unhealthy.")
 	flags.DurationVar(&o.WorkPendingGracePeriod.Duration, "work-pending-grace-period", 15*time.Second,
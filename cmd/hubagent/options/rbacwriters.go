@@ -0,0 +1,40 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// ParseFleetRBACWriterPatterns parses a comma separated list of user name patterns, in the syntax of
+// shell file name patterns, which match the users allowed to modify the fleet-managed RBAC resources or the
+// controller-owned snapshots.
+func ParseFleetRBACWriterPatterns(str string) ([]string, error) {
+	if str == "" {
+		return nil, nil
+	}
+	var patterns []string
+	for _, pattern := range strings.Split(str, ",") {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
@@ -17,6 +17,9 @@ limitations under the License.
 package options
 
 import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/kubefleet-dev/kubefleet/pkg/utils"
@@ -48,15 +51,90 @@ func (o *Options) Validate() field.ErrorList {
 		errs = append(errs, field.Invalid(newPath.Child("WorkPendingGracePeriod"), o.WorkPendingGracePeriod, "Must be greater than 0"))
 	}
 
+	if o.WebhookIntegrityCheckInterval.Duration < 0 {
+		errs = append(errs, field.Invalid(newPath.Child("WebhookIntegrityCheckInterval"), o.WebhookIntegrityCheckInterval, "Must be greater than or equal to 0"))
+	}
+
+	if o.WebhookLookupBudget.Duration <= 0 {
+		errs = append(errs, field.Invalid(newPath.Child("WebhookLookupBudget"), o.WebhookLookupBudget, "Must be greater than 0"))
+	}
+
 	if o.EnableWebhook && o.WebhookServiceName == "" {
 		errs = append(errs, field.Invalid(newPath.Child("WebhookServiceName"), o.WebhookServiceName, "Webhook service name is required when webhook is enabled"))
 	}
 
+	if o.EnableWebhook {
+		for _, errMsg := range validation.IsValidPortNum(o.WebhookServicePort) {
+			errs = append(errs, field.Invalid(newPath.Child("WebhookServicePort"), o.WebhookServicePort, errMsg))
+		}
+		for _, errMsg := range validation.IsValidPortNum(o.WebhookTargetPort) {
+			errs = append(errs, field.Invalid(newPath.Child("WebhookTargetPort"), o.WebhookTargetPort, errMsg))
+		}
+	}
+
 	connectionType := o.WebhookClientConnectionType
 	if _, err := parseWebhookClientConnectionString(connectionType); err != nil {
 		errs = append(errs, field.Invalid(newPath.Child("WebhookClientConnectionType"), o.WebhookClientConnectionType, err.Error()))
 	}
 
+	if _, err := ParseWebhookRole(o.WebhookRole); err != nil {
+		errs = append(errs, field.Invalid(newPath.Child("WebhookRole"), o.WebhookRole, err.Error()))
+	}
+	if _, err := ParseWebhookServiceNames(o.WebhookServiceNames); err != nil {
+		errs = append(errs, field.Invalid(newPath.Child("WebhookServiceNames"), o.WebhookServiceNames, err.Error()))
+	}
+	if o.WebhookNameSuffix != "" {
+		if msgs := validation.IsDNS1123Label(o.WebhookNameSuffix); len(msgs) > 0 {
+			errs = append(errs, field.Invalid(newPath.Child("WebhookNameSuffix"), o.WebhookNameSuffix, strings.Join(msgs, "; ")))
+		}
+	}
+	if o.WebhookCABundleFiles != "" {
+		for _, file := range strings.Split(o.WebhookCABundleFiles, ",") {
+			if strings.TrimSpace(file) == "" {
+				errs = append(errs, field.Invalid(newPath.Child("WebhookCABundleFiles"), o.WebhookCABundleFiles, "the list cannot contain an empty file name"))
+				break
+			}
+		}
+	}
+	if o.WebhookCertSecretName != "" {
+		if msgs := validation.IsDNS1123Subdomain(o.WebhookCertSecretName); len(msgs) > 0 {
+			errs = append(errs, field.Invalid(newPath.Child("WebhookCertSecretName"), o.WebhookCertSecretName, strings.Join(msgs, "; ")))
+		}
+	}
+
+	if _, err := ParseFleetRBACWriterPatterns(o.FleetRBACWriterPatterns); err != nil {
+		errs = append(errs, field.Invalid(newPath.Child("FleetRBACWriterPatterns"), o.FleetRBACWriterPatterns, err.Error()))
+	}
+	if _, err := ParseFleetRBACWriterPatterns(o.FleetSnapshotWriterPatterns); err != nil {
+		errs = append(errs, field.Invalid(newPath.Child("FleetSnapshotWriterPatterns"), o.FleetSnapshotWriterPatterns, err.Error()))
+	}
+
+	if _, err := ParseEvictionTargetValidation(o.EvictionTargetValidation); err != nil {
+		errs = append(errs, field.Invalid(newPath.Child("EvictionTargetValidation"), o.EvictionTargetValidation, err.Error()))
+	}
+
+	if _, err := ParsePlacementClusterNamesValidation(o.PlacementClusterNamesValidation); err != nil {
+		errs = append(errs, field.Invalid(newPath.Child("PlacementClusterNamesValidation"), o.PlacementClusterNamesValidation, err.Error()))
+	}
+
+	if _, err := ParsePlacementNumberOfClustersValidation(o.PlacementNumberOfClustersValidation); err != nil {
+		errs = append(errs, field.Invalid(newPath.Child("PlacementNumberOfClustersValidation"), o.PlacementNumberOfClustersValidation, err.Error()))
+	}
+	if _, err := ParsePlacementPickAllFleetSizeValidation(o.PlacementPickAllFleetSizeValidation); err != nil {
+		errs = append(errs, field.Invalid(newPath.Child("PlacementPickAllFleetSizeValidation"), o.PlacementPickAllFleetSizeValidation, err.Error()))
+	}
+	if o.PlacementPickAllFleetSizeThreshold <= 0 {
+		errs = append(errs, field.Invalid(newPath.Child("PlacementPickAllFleetSizeThreshold"), o.PlacementPickAllFleetSizeThreshold, "Must be greater than 0"))
+	}
+
+	if _, err := ParseLookupFailurePolicies(o.WebhookLookupFailurePolicies); err != nil {
+		errs = append(errs, field.Invalid(newPath.Child("WebhookLookupFailurePolicies"), o.WebhookLookupFailurePolicies, err.Error()))
+	}
+
+	if _, err := ParseShadowValidationRules(o.ShadowValidationRules); err != nil {
+		errs = append(errs, field.Invalid(newPath.Child("ShadowValidationRules"), o.ShadowValidationRules, err.Error()))
+	}
+
 	if !o.EnableV1Alpha1APIs && !o.EnableV1Beta1APIs {
 		errs = append(errs, field.Required(newPath.Child("EnableV1Alpha1APIs"), "Either EnableV1Alpha1APIs or EnableV1Beta1APIs is required"))
 	}
@@ -18,13 +18,17 @@ package options
 
 import (
 	"flag"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
 )
 
 // a callback function to modify options
@@ -33,11 +37,16 @@ type ModifyOptions func(option *Options)
 // newTestOptions creates an Options with default parameters.
 func newTestOptions(modifyOptions ModifyOptions) Options {
 	option := Options{
-		SkippedPropagatingAPIs:      "fleet.azure.com;multicluster.x-k8s.io",
-		WorkPendingGracePeriod:      metav1.Duration{Duration: 10 * time.Second},
-		ClusterUnhealthyThreshold:   metav1.Duration{Duration: 60 * time.Second},
-		WebhookClientConnectionType: "url",
-		EnableV1Alpha1APIs:          true,
+		SkippedPropagatingAPIs:             "fleet.azure.com;multicluster.x-k8s.io",
+		WorkPendingGracePeriod:             metav1.Duration{Duration: 10 * time.Second},
+		ClusterUnhealthyThreshold:          metav1.Duration{Duration: 60 * time.Second},
+		WebhookLookupBudget:                metav1.Duration{Duration: 2 * time.Second},
+		WebhookClientConnectionType:        "url",
+		WebhookRole:                        "all",
+		WebhookServicePort:                 9443,
+		WebhookTargetPort:                  9443,
+		PlacementPickAllFleetSizeThreshold: 100,
+		EnableV1Alpha1APIs:                 true,
 	}
 
 	if modifyOptions != nil {
@@ -86,6 +95,226 @@ func TestValidateControllerManagerConfiguration(t *testing.T) {
 			}),
 			want: field.ErrorList{field.Invalid(newPath.Child("WebhookClientConnectionType"), "invalid", `must be "service" or "url"`)},
 		},
+		"valid sharded webhook": {
+			opt: newTestOptions(func(option *Options) {
+				option.WebhookRole = "guardrail"
+				option.WebhookServiceNames = "placement=fleetwebhook-placement,guardrail=fleetwebhook-guardrail"
+			}),
+			want: field.ErrorList{},
+		},
+		"invalid WebhookRole": {
+			opt: newTestOptions(func(option *Options) {
+				option.WebhookRole = "invalid"
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("WebhookRole"), "invalid", `must be "all", "placement", "guardrail" or "workload"`)},
+		},
+		"invalid WebhookServiceNames group": {
+			opt: newTestOptions(func(option *Options) {
+				option.WebhookServiceNames = "all=fleetwebhook"
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("WebhookServiceNames"), "all=fleetwebhook", `invalid group "all", must be "placement", "guardrail" or "workload"`)},
+		},
+		"invalid WebhookServiceNames pair": {
+			opt: newTestOptions(func(option *Options) {
+				option.WebhookServiceNames = "placement"
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("WebhookServiceNames"), "placement", `invalid pair "placement", must be in the form of <group>=<service name>`)},
+		},
+		"duplicate WebhookServiceNames group": {
+			opt: newTestOptions(func(option *Options) {
+				option.WebhookServiceNames = "workload=a,workload=b"
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("WebhookServiceNames"), "workload=a,workload=b", `duplicate group "workload"`)},
+		},
+		"valid WebhookNameSuffix": {
+			opt: newTestOptions(func(option *Options) {
+				option.WebhookNameSuffix = "hub-1"
+			}),
+			want: field.ErrorList{},
+		},
+		"invalid WebhookNameSuffix": {
+			opt: newTestOptions(func(option *Options) {
+				option.WebhookNameSuffix = "Hub.1"
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("WebhookNameSuffix"), "Hub.1", strings.Join(validation.IsDNS1123Label("Hub.1"), "; "))},
+		},
+		"valid WebhookCABundleFiles": {
+			opt: newTestOptions(func(option *Options) {
+				option.WebhookCABundleFiles = "/etc/fleet/ca/root.crt, /etc/fleet/ca/ca.crt"
+			}),
+			want: field.ErrorList{},
+		},
+		"WebhookCABundleFiles with an empty file name": {
+			opt: newTestOptions(func(option *Options) {
+				option.WebhookCABundleFiles = "/etc/fleet/ca/root.crt,,/etc/fleet/ca/ca.crt"
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("WebhookCABundleFiles"), "/etc/fleet/ca/root.crt,,/etc/fleet/ca/ca.crt", "the list cannot contain an empty file name")},
+		},
+		"valid WebhookCertSecretName": {
+			opt: newTestOptions(func(option *Options) {
+				option.WebhookCertSecretName = "fleet-webhook-server-cert"
+			}),
+			want: field.ErrorList{},
+		},
+		"invalid WebhookCertSecretName": {
+			opt: newTestOptions(func(option *Options) {
+				option.WebhookCertSecretName = "Fleet_Cert"
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("WebhookCertSecretName"), "Fleet_Cert", strings.Join(validation.IsDNS1123Subdomain("Fleet_Cert"), "; "))},
+		},
+		"valid FleetRBACWriterPatterns": {
+			opt: newTestOptions(func(option *Options) {
+				option.FleetRBACWriterPatterns = "system:serviceaccount:fleet-system:hub-agent-sa,system:serviceaccount:fleet-member-*:*"
+			}),
+			want: field.ErrorList{},
+		},
+		"invalid FleetRBACWriterPatterns": {
+			opt: newTestOptions(func(option *Options) {
+				option.FleetRBACWriterPatterns = "system:serviceaccount:fleet-system:[hub"
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("FleetRBACWriterPatterns"), "system:serviceaccount:fleet-system:[hub", `invalid pattern "system:serviceaccount:fleet-system:[hub": syntax error in pattern`)},
+		},
+		"invalid FleetSnapshotWriterPatterns": {
+			opt: newTestOptions(func(option *Options) {
+				option.FleetSnapshotWriterPatterns = "system:serviceaccount:fleet-system:[hub"
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("FleetSnapshotWriterPatterns"), "system:serviceaccount:fleet-system:[hub", `invalid pattern "system:serviceaccount:fleet-system:[hub": syntax error in pattern`)},
+		},
+		"valid EvictionTargetValidation": {
+			opt: newTestOptions(func(option *Options) {
+				option.EvictionTargetValidation = "warn"
+			}),
+			want: field.ErrorList{},
+		},
+		"invalid EvictionTargetValidation": {
+			opt: newTestOptions(func(option *Options) {
+				option.EvictionTargetValidation = "Enforce"
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("EvictionTargetValidation"), "Enforce", `must be "disabled", "warn" or "enforce"`)},
+		},
+		"valid PlacementClusterNamesValidation": {
+			opt: newTestOptions(func(option *Options) {
+				option.PlacementClusterNamesValidation = "enforce"
+			}),
+			want: field.ErrorList{},
+		},
+		"invalid PlacementClusterNamesValidation": {
+			opt: newTestOptions(func(option *Options) {
+				option.PlacementClusterNamesValidation = "deny"
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("PlacementClusterNamesValidation"), "deny", `must be "disabled", "warn" or "enforce"`)},
+		},
+		"valid PlacementNumberOfClustersValidation": {
+			opt: newTestOptions(func(option *Options) {
+				option.PlacementNumberOfClustersValidation = "warn"
+			}),
+			want: field.ErrorList{},
+		},
+		"invalid PlacementNumberOfClustersValidation": {
+			opt: newTestOptions(func(option *Options) {
+				option.PlacementNumberOfClustersValidation = "strict"
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("PlacementNumberOfClustersValidation"), "strict", `must be "disabled", "warn" or "enforce"`)},
+		},
+		"valid PlacementPickAllFleetSizeValidation": {
+			opt: newTestOptions(func(option *Options) {
+				option.PlacementPickAllFleetSizeValidation = "enforce"
+			}),
+			want: field.ErrorList{},
+		},
+		"invalid PlacementPickAllFleetSizeValidation": {
+			opt: newTestOptions(func(option *Options) {
+				option.PlacementPickAllFleetSizeValidation = "strict"
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("PlacementPickAllFleetSizeValidation"), "strict", `must be "disabled", "warn" or "enforce"`)},
+		},
+		"zero PlacementPickAllFleetSizeThreshold": {
+			opt: newTestOptions(func(option *Options) {
+				option.PlacementPickAllFleetSizeThreshold = 0
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("PlacementPickAllFleetSizeThreshold"), 0, "Must be greater than 0")},
+		},
+		"valid ShadowValidationRules": {
+			opt: newTestOptions(func(option *Options) {
+				option.ShadowValidationRules = "MetadataSize,StrategyTypeTransition"
+			}),
+			want: field.ErrorList{},
+		},
+		"unknown ShadowValidationRules": {
+			opt: newTestOptions(func(option *Options) {
+				option.ShadowValidationRules = "MetadataSize,Unknown"
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("ShadowValidationRules"), "MetadataSize,Unknown", `unknown placement validation rule "Unknown", must be one of `+strings.Join(validator.PlacementValidationRuleNames(), ", "))},
+		},
+		"disabled WebhookIntegrityCheckInterval": {
+			opt: newTestOptions(func(option *Options) {
+				option.WebhookIntegrityCheckInterval = metav1.Duration{}
+			}),
+			want: field.ErrorList{},
+		},
+		"negative WebhookIntegrityCheckInterval": {
+			opt: newTestOptions(func(option *Options) {
+				option.WebhookIntegrityCheckInterval = metav1.Duration{Duration: -time.Minute}
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("WebhookIntegrityCheckInterval"), metav1.Duration{Duration: -time.Minute}, "Must be greater than or equal to 0")},
+		},
+		"zero WebhookLookupBudget": {
+			opt: newTestOptions(func(option *Options) {
+				option.WebhookLookupBudget = metav1.Duration{}
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("WebhookLookupBudget"), metav1.Duration{}, "Must be greater than 0")},
+		},
+		"valid WebhookLookupFailurePolicies": {
+			opt: newTestOptions(func(option *Options) {
+				option.WebhookLookupFailurePolicies = "ClusterNames=failClosed,TeamQuota=failOpen"
+			}),
+			want: field.ErrorList{},
+		},
+		"WebhookLookupFailurePolicies with an unknown check": {
+			opt: newTestOptions(func(option *Options) {
+				option.WebhookLookupFailurePolicies = "Unknown=failOpen"
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("WebhookLookupFailurePolicies"), "Unknown=failOpen", `unknown client-backed check "Unknown", must be one of `+strings.Join(validator.LookupChecks(), ", "))},
+		},
+		"WebhookLookupFailurePolicies with an invalid policy": {
+			opt: newTestOptions(func(option *Options) {
+				option.WebhookLookupFailurePolicies = "TeamQuota=ignore"
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("WebhookLookupFailurePolicies"), "TeamQuota=ignore", `invalid lookup failure policy "ignore" of check "TeamQuota", must be failOpen or failClosed`)},
+		},
+		"WebhookLookupFailurePolicies with a duplicate check": {
+			opt: newTestOptions(func(option *Options) {
+				option.WebhookLookupFailurePolicies = "TeamQuota=failOpen,TeamQuota=failClosed"
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("WebhookLookupFailurePolicies"), "TeamQuota=failOpen,TeamQuota=failClosed", `duplicate lookup failure policy of check "TeamQuota"`)},
+		},
+		"WebhookLookupFailurePolicies without a policy": {
+			opt: newTestOptions(func(option *Options) {
+				option.WebhookLookupFailurePolicies = "TeamQuota"
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("WebhookLookupFailurePolicies"), "TeamQuota", `invalid lookup failure policy "TeamQuota", must be in the form of <check>=<policy>`)},
+		},
+		"valid webhook ports": {
+			opt: newTestOptions(func(option *Options) {
+				option.EnableWebhook = true
+				option.WebhookServiceName = "fleetwebhook"
+				option.WebhookServicePort = 443
+				option.WebhookTargetPort = 65535
+			}),
+			want: field.ErrorList{},
+		},
+		"webhook ports out of range": {
+			opt: newTestOptions(func(option *Options) {
+				option.EnableWebhook = true
+				option.WebhookServiceName = "fleetwebhook"
+				option.WebhookServicePort = 0
+				option.WebhookTargetPort = 65536
+			}),
+			want: field.ErrorList{
+				field.Invalid(newPath.Child("WebhookServicePort"), 0, "must be between 1 and 65535, inclusive"),
+				field.Invalid(newPath.Child("WebhookTargetPort"), 65536, "must be between 1 and 65535, inclusive"),
+			},
+		},
 		"WebhookServiceName is empty": {
 			opt: newTestOptions(func(option *Options) {
 				option.EnableWebhook = true
@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
+)
+
+// ParseShadowValidationRules parses a comma separated list of placement validation rule names which run
+// in shadow mode.
+func ParseShadowValidationRules(str string) ([]string, error) {
+	if str == "" {
+		return nil, nil
+	}
+	known := validator.PlacementValidationRuleNames()
+	var rules []string
+	for _, name := range strings.Split(str, ",") {
+		if !slices.Contains(known, name) {
+			return nil, fmt.Errorf("unknown placement validation rule %q, must be one of %s", name, strings.Join(known, ", "))
+		}
+		if !slices.Contains(rules, name) {
+			rules = append(rules, name)
+		}
+	}
+	return rules, nil
+}
+
+// ParseEvictionTargetValidation parses the eviction target validation mode; the target is not validated if the
+// mode is empty.
+func ParseEvictionTargetValidation(str string) (validator.EvictionTargetValidationMode, error) {
+	if str == "" {
+		return validator.EvictionTargetValidationDisabled, nil
+	}
+	mode := validator.EvictionTargetValidationMode(str)
+	if !slices.Contains(validator.EvictionTargetValidationModes, mode) {
+		return "", errors.New(`must be "disabled", "warn" or "enforce"`)
+	}
+	return mode, nil
+}
+
+// ParsePlacementClusterNamesValidation parses the placement cluster names validation mode; the cluster names are
+// not validated if the mode is empty.
+func ParsePlacementClusterNamesValidation(str string) (validator.ClusterNamesValidationMode, error) {
+	if str == "" {
+		return validator.ClusterNamesValidationDisabled, nil
+	}
+	mode := validator.ClusterNamesValidationMode(str)
+	if !slices.Contains(validator.ClusterNamesValidationModes, mode) {
+		return "", errors.New(`must be "disabled", "warn" or "enforce"`)
+	}
+	return mode, nil
+}
+
+// ParsePlacementNumberOfClustersValidation parses the placement number of clusters validation mode; the number of
+// clusters is not validated if the mode is empty.
+func ParsePlacementNumberOfClustersValidation(str string) (validator.NumberOfClustersValidationMode, error) {
+	if str == "" {
+		return validator.NumberOfClustersValidationDisabled, nil
+	}
+	mode := validator.NumberOfClustersValidationMode(str)
+	if !slices.Contains(validator.NumberOfClustersValidationModes, mode) {
+		return "", errors.New(`must be "disabled", "warn" or "enforce"`)
+	}
+	return mode, nil
+}
+
+// ParsePlacementPickAllFleetSizeValidation parses the placement PickAll fleet size validation mode; the PickAll
+// placements are not validated if the mode is empty.
+func ParsePlacementPickAllFleetSizeValidation(str string) (validator.PickAllFleetSizeValidationMode, error) {
+	if str == "" {
+		return validator.PickAllFleetSizeValidationDisabled, nil
+	}
+	mode := validator.PickAllFleetSizeValidationMode(str)
+	if !slices.Contains(validator.PickAllFleetSizeValidationModes, mode) {
+		return "", errors.New(`must be "disabled", "warn" or "enforce"`)
+	}
+	return mode, nil
+}
+
+// ParseLookupFailurePolicies parses comma separated <check>=<policy> pairs setting the lookup failure policy of the
+// client-backed checks; the checks not listed keep their default policies.
+func ParseLookupFailurePolicies(str string) (map[string]validator.LookupFailurePolicy, error) {
+	if str == "" {
+		return nil, nil
+	}
+	checks := validator.LookupChecks()
+	policies := make(map[string]validator.LookupFailurePolicy)
+	for _, pair := range strings.Split(str, ",") {
+		check, policy, found := strings.Cut(pair, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid lookup failure policy %q, must be in the form of <check>=<policy>", pair)
+		}
+		if !slices.Contains(checks, check) {
+			return nil, fmt.Errorf("unknown client-backed check %q, must be one of %s", check, strings.Join(checks, ", "))
+		}
+		if !slices.Contains(validator.LookupFailurePolicies, validator.LookupFailurePolicy(policy)) {
+			return nil, fmt.Errorf("invalid lookup failure policy %q of check %q, must be failOpen or failClosed", policy, check)
+		}
+		if _, ok := policies[check]; ok {
+			return nil, fmt.Errorf("duplicate lookup failure policy of check %q", check)
+		}
+		policies[check] = validator.LookupFailurePolicy(policy)
+	}
+	return policies, nil
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// WebhookRole is the group of fleet webhooks a hub agent serves.
+type WebhookRole string
+
+const (
+	// WebhookRoleAll serves all the fleet webhooks.
+	WebhookRoleAll WebhookRole = "all"
+	// WebhookRolePlacement serves the mutating and validating webhooks of the fleet APIs.
+	WebhookRolePlacement WebhookRole = "placement"
+	// WebhookRoleGuardRail serves the guard rail webhooks.
+	WebhookRoleGuardRail WebhookRole = "guardrail"
+	// WebhookRoleWorkload serves the pod and replicaset webhooks.
+	WebhookRoleWorkload WebhookRole = "workload"
+)
+
+var (
+	// WebhookGroups are the groups of fleet webhooks which can be served by separate hub agents.
+	WebhookGroups = []WebhookRole{WebhookRolePlacement, WebhookRoleGuardRail, WebhookRoleWorkload}
+
+	webhookRolesMap = map[string]WebhookRole{
+		"all":       WebhookRoleAll,
+		"placement": WebhookRolePlacement,
+		"guardrail": WebhookRoleGuardRail,
+		"workload":  WebhookRoleWorkload,
+	}
+)
+
+// Serves returns true if a hub agent started with the role serves the webhooks of the group.
+// An empty role serves all the webhooks.
+func (r WebhookRole) Serves(group WebhookRole) bool {
+	return r == "" || r == WebhookRoleAll || r == group
+}
+
+// ParseWebhookRole parses the argued string into a WebhookRole.
+func ParseWebhookRole(str string) (WebhookRole, error) {
+	r, ok := webhookRolesMap[strings.ToLower(str)]
+	if !ok {
+		return "", errors.New("must be \"all\", \"placement\", \"guardrail\" or \"workload\"")
+	}
+	return r, nil
+}
+
+// ParseWebhookServiceNames parses a comma separated list of <group>=<service name> pairs into
+// a map from the webhook group to the name of the service serving it.
+func ParseWebhookServiceNames(str string) (map[WebhookRole]string, error) {
+	serviceNames := make(map[WebhookRole]string)
+	if str == "" {
+		return serviceNames, nil
+	}
+	for _, pair := range strings.Split(str, ",") {
+		group, serviceName, found := strings.Cut(pair, "=")
+		if !found || serviceName == "" {
+			return nil, fmt.Errorf("invalid pair %q, must be in the form of <group>=<service name>", pair)
+		}
+		role, err := ParseWebhookRole(group)
+		if err != nil || role == WebhookRoleAll {
+			return nil, fmt.Errorf("invalid group %q, must be \"placement\", \"guardrail\" or \"workload\"", group)
+		}
+		if _, ok := serviceNames[role]; ok {
+			return nil, fmt.Errorf("duplicate group %q", group)
+		}
+		serviceNames[role] = serviceName
+	}
+	return serviceNames, nil
+}
@@ -155,6 +155,10 @@ func SetupControllers(ctx context.Context, wg *sync.WaitGroup, mgr ctrl.Manager,
 	dynamicInformerManager := informer.NewInformerManager(dynamicClient, opts.ResyncPeriod.Duration, ctx.Done())
 	validator.ResourceInformer = dynamicInformerManager // webhook needs this to check resource scope
 	validator.RestMapper = mgr.GetRESTMapper()          // webhook needs this to validate GVK of resource selector
+	if opts.EnableStagedUpdateRunAPIs {
+		// webhook needs this to check for the update runs of a placement when its rollout strategy type changes
+		validator.UpdateRunReader = mgr.GetClient()
+	}
 
 	// Set up  a custom controller to reconcile placement objects
 	pc := &placement.Reconciler{
@@ -374,6 +374,7 @@ func (r *Reconciler) syncRole(ctx context.Context, mc *clusterv1beta1.MemberClus
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            roleName,
 			Namespace:       namespaceName,
+			Labels:          map[string]string{utils.LabelFleetObj: utils.LabelFleetObjValue},
 			OwnerReferences: []metav1.OwnerReference{*toOwnerReference(mc)},
 		},
 		Rules: []rbacv1.PolicyRule{utils.FleetClusterRule, utils.FleetPlacementRule, utils.FleetNetworkRule, utils.EventRule},
@@ -417,6 +418,7 @@ func (r *Reconciler) syncRoleBinding(ctx context.Context, mc *clusterv1beta1.Mem
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            roleBindingName,
 			Namespace:       namespaceName,
+			Labels:          map[string]string{utils.LabelFleetObj: utils.LabelFleetObjValue},
 			OwnerReferences: []metav1.OwnerReference{*toOwnerReference(mc)},
 		},
 		Subjects: []rbacv1.Subject{mc.Spec.Identity},
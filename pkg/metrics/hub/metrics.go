@@ -44,6 +44,67 @@ var (
 	}, []string{"namespace", "name", "generation", "condition", "status", "reason"})
 )
 
+// The webhook related metrics.
+var (
+	// FleetShadowPlacementValidationFailuresTotal is a prometheus metric which counts the placement requests
+	// which failed a placement validation rule running in shadow mode and would have been denied otherwise.
+	FleetShadowPlacementValidationFailuresTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "fleet_shadow_placement_validation_failures_total",
+		Help: "Total number of placement requests which failed a shadow placement validation rule",
+	}, []string{"rule", "resourceType"})
+
+	// FleetPlacementValidationRuleDurationSeconds is a prometheus metric which tracks how long each placement
+	// validation rule takes, labeled by the rule name and the kind of the placement, e.g., CRP or RP.
+	FleetPlacementValidationRuleDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "fleet_placement_validation_rule_duration_seconds",
+		Help:    "The duration of the placement validation rules in seconds",
+		Buckets: []float64{0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 2.5},
+	}, []string{"rule", "resourceType"})
+
+	// FleetWebhookConfigurationApplied is a prometheus metric which reports whether the hub agent applied each
+	// webhook configuration (1) or failed to apply it (0) in its last attempt.
+	FleetWebhookConfigurationApplied = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "fleet_webhook_configuration_applied",
+		Help: "Whether the webhook configuration is applied by the hub agent in its last attempt",
+	}, []string{"kind", "name"})
+
+	// FleetWebhookConfigurationHash is a prometheus metric which reports the hash of the desired configuration each
+	// webhook configuration was last applied from, labeled by the version of the hub agent which applied it.
+	FleetWebhookConfigurationHash = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "fleet_webhook_configuration_hash_info",
+		Help: "The hash of the desired webhook configuration last applied by the hub agent, the value is always 1",
+	}, []string{"kind", "name", "hash", "version"})
+
+	// FleetWebhookLookupBudgetExhaustedTotal is a prometheus metric which counts the client-backed webhook checks
+	// which could not read the hub cluster within their lookup budget.
+	FleetWebhookLookupBudgetExhaustedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "fleet_webhook_lookup_budget_exhausted_total",
+		Help: "Total number of client-backed webhook checks which exhausted their lookup budget",
+	}, []string{"check"})
+
+	// FleetWebhookUnknownKindTotal is a prometheus metric which counts the requests whose kind is not accepted by the
+	// webhook they are routed to, e.g., after a CRD upgrade serves a new version, labeled by the webhook and the kind.
+	FleetWebhookUnknownKindTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "fleet_webhook_unknown_kind_total",
+		Help: "Total number of webhook requests of a kind the webhook does not accept",
+	}, []string{"webhook", "kind"})
+
+	// FleetGuardRailDelegationRequestsTotal is a prometheus metric which counts the guard rail decisions delegated to
+	// the external authorizer, labeled by the outcome: allow, deny, timeout or error.
+	FleetGuardRailDelegationRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "fleet_guard_rail_delegation_requests_total",
+		Help: "Total number of guard rail decisions delegated to the external authorizer",
+	}, []string{"outcome"})
+
+	// FleetGuardRailDelegationDurationSeconds is a prometheus metric which tracks how long the external authorizer
+	// takes to respond to the delegated guard rail decisions.
+	FleetGuardRailDelegationDurationSeconds = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "fleet_guard_rail_delegation_duration_seconds",
+		Help:    "The duration of the guard rail decisions delegated to the external authorizer in seconds",
+		Buckets: []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5},
+	})
+)
+
 // The scheduler related metrics.
 var (
 	// SchedulingCycleDurationMilliseconds is a Fleet scheduler metric that tracks how long it
@@ -76,5 +137,13 @@ func init() {
 		FleetUpdateRunStatusLastTimestampSeconds,
 		SchedulingCycleDurationMilliseconds,
 		SchedulerActiveWorkers,
+		FleetShadowPlacementValidationFailuresTotal,
+		FleetPlacementValidationRuleDurationSeconds,
+		FleetWebhookConfigurationApplied,
+		FleetWebhookConfigurationHash,
+		FleetWebhookLookupBudgetExhaustedTotal,
+		FleetWebhookUnknownKindTotal,
+		FleetGuardRailDelegationRequestsTotal,
+		FleetGuardRailDelegationDurationSeconds,
 	)
 }
@@ -156,6 +156,24 @@ var (
 		Kind:    placementv1beta1.ClusterResourcePlacementKind,
 	}
 
+	ResourcePlacementMetaGVK = metav1.GroupVersionKind{
+		Group:   placementv1beta1.GroupVersion.Group,
+		Version: placementv1beta1.GroupVersion.Version,
+		Kind:    placementv1beta1.ResourcePlacementKind,
+	}
+
+	ClusterResourceSnapshotMetaGVK = metav1.GroupVersionKind{
+		Group:   placementv1beta1.GroupVersion.Group,
+		Version: placementv1beta1.GroupVersion.Version,
+		Kind:    placementv1beta1.ClusterResourceSnapshotKind,
+	}
+
+	ClusterSchedulingPolicySnapshotMetaGVK = metav1.GroupVersionKind{
+		Group:   placementv1beta1.GroupVersion.Group,
+		Version: placementv1beta1.GroupVersion.Version,
+		Kind:    placementv1beta1.ClusterSchedulingPolicySnapshotKind,
+	}
+
 	ClusterResourcePlacementDisruptionBudgetMetaGVK = metav1.GroupVersionKind{
 		Group:   placementv1beta1.GroupVersion.Group,
 		Version: placementv1beta1.GroupVersion.Version,
@@ -324,6 +342,12 @@ var (
 		Kind:    "RoleBinding",
 	}
 
+	SecretMetaGVK = metav1.GroupVersionKind{
+		Group:   corev1.SchemeGroupVersion.Group,
+		Version: corev1.SchemeGroupVersion.Version,
+		Kind:    "Secret",
+	}
+
 	ServiceGVR = schema.GroupVersionResource{
 		Group:    corev1.GroupName,
 		Version:  corev1.SchemeGroupVersion.Version,
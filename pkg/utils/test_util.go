@@ -168,6 +168,13 @@ func (m TestMapper) RESTMapping(gk schema.GroupKind, _ ...string) (*meta.RESTMap
 			Scope:            nil,
 		}, nil
 	}
+	if gk.Kind == "Namespace" {
+		return &meta.RESTMapping{
+			Resource:         NamespaceGVR,
+			GroupVersionKind: NamespaceGVK,
+			Scope:            nil,
+		}, nil
+	}
 	if gk.Kind == "Deployment" {
 		return &meta.RESTMapping{
 			Resource:         DeploymentGVR,
@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	clusterv1beta1 "github.com/kubefleet-dev/kubefleet/apis/cluster/v1beta1"
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+// ClusterNamesValidationMode is how the cluster names of a PickFixed placement which are unknown MemberClusters, or
+// whose MemberClusters are leaving or have left the fleet, are handled, as the resources are never placed on them.
+type ClusterNamesValidationMode string
+
+const (
+	// ClusterNamesValidationDisabled does not validate the cluster names of a placement.
+	ClusterNamesValidationDisabled ClusterNamesValidationMode = "disabled"
+	// ClusterNamesValidationWarn allows the placement with a warning, as the clusters may join the fleet later.
+	ClusterNamesValidationWarn ClusterNamesValidationMode = "warn"
+	// ClusterNamesValidationEnforce denies the placement.
+	ClusterNamesValidationEnforce ClusterNamesValidationMode = "enforce"
+)
+
+// ClusterNamesValidationModes are the valid cluster names validation modes.
+var ClusterNamesValidationModes = []ClusterNamesValidationMode{ClusterNamesValidationDisabled, ClusterNamesValidationWarn, ClusterNamesValidationEnforce}
+
+// ValidatePlacementClusterNames looks up the cluster names of a PickFixed placement allowed by resp as MemberClusters
+// and warns about, or denies in the enforce mode, the names which are not found or whose MemberClusters are leaving
+// or have left the fleet. Only the names added by an update are checked so that the placements naming a cluster
+// which has left since can still be updated; oldPlacement is nil on creation. The check is skipped unless
+// Config.ClusterNamesValidation is warn or enforce. The lookups are bounded by the lookup budget.
+func (c Config) ValidatePlacementClusterNames(ctx context.Context, reader client.Reader, placement, oldPlacement placementv1beta1.PlacementObj, resp admission.Response) admission.Response {
+	mode := c.ClusterNamesValidation
+	if (mode != ClusterNamesValidationWarn && mode != ClusterNamesValidationEnforce) || reader == nil {
+		return resp
+	}
+	names := newClusterNames(placement, oldPlacement)
+	if len(names) == 0 {
+		return resp
+	}
+	ctx, cancel := c.WithLookupBudget(ctx, 0)
+	defer cancel()
+	notFound, notJoined, err := findUnavailableClusters(ctx, reader, names)
+	if err != nil {
+		if exhausted := c.LookupBudgetExhausted(ctx, ClusterNamesLookupCheck, err); exhausted != nil {
+			return LookupUnavailableResponse(exhausted, resp)
+		}
+		klog.ErrorS(err, "Failed to look up the member clusters named by the placement", "placement", klog.KObj(placement))
+		if mode == ClusterNamesValidationEnforce {
+			return admission.Errored(http.StatusInternalServerError, fmt.Errorf("failed to look up the member clusters in spec.policy.clusterNames, please retry the request: %w", err))
+		}
+		return resp.WithWarnings(fmt.Sprintf("failed to look up the member clusters in spec.policy.clusterNames: %v", err))
+	}
+	var msgs []string
+	if len(notFound) > 0 {
+		msgs = append(msgs, fmt.Sprintf("member cluster(s) %s in spec.policy.clusterNames are not found", strings.Join(notFound, ", ")))
+	}
+	if len(notJoined) > 0 {
+		msgs = append(msgs, fmt.Sprintf("member cluster(s) %s in spec.policy.clusterNames are leaving or have left the fleet", strings.Join(notJoined, ", ")))
+	}
+	if len(msgs) == 0 {
+		return resp
+	}
+	if mode == ClusterNamesValidationEnforce {
+		klog.V(2).InfoS("Placement names unavailable member clusters, request is denied", "placement", klog.KObj(placement), "notFound", notFound, "notJoined", notJoined)
+		return admission.Denied(c.DenialMessage(PlacementClusterNamesUnavailableMessageID, map[string]any{"reasons": strings.Join(msgs, "; ")}))
+	}
+	klog.V(2).InfoS("Placement names unavailable member clusters, allowing the request with a warning", "placement", klog.KObj(placement), "notFound", notFound, "notJoined", notJoined)
+	for i := range msgs {
+		msgs[i] += ", the resources will not be placed on them until they join the fleet"
+	}
+	return resp.WithWarnings(msgs...)
+}
+
+// newClusterNames returns the sorted cluster names of the PickFixed placement which are not in the PickFixed policy
+// of the old placement.
+func newClusterNames(placement, oldPlacement placementv1beta1.PlacementObj) []string {
+	policy := placement.GetPlacementSpec().Policy
+	if policy == nil || policy.PlacementType != placementv1beta1.PickFixedPlacementType {
+		return nil
+	}
+	names := sets.New(policy.ClusterNames...)
+	if oldPlacement != nil {
+		if oldPolicy := oldPlacement.GetPlacementSpec().Policy; oldPolicy != nil && oldPolicy.PlacementType == placementv1beta1.PickFixedPlacementType {
+			names.Delete(oldPolicy.ClusterNames...)
+		}
+	}
+	return sets.List(names)
+}
+
+// findUnavailableClusters returns the sorted names which are not found as MemberClusters and the sorted names whose
+// MemberClusters are leaving, i.e., being deleted, or have left the fleet, i.e., are no longer joined.
+func findUnavailableClusters(ctx context.Context, c client.Reader, names []string) (notFound, notJoined []string, err error) {
+	for _, name := range names {
+		mc := &clusterv1beta1.MemberCluster{}
+		if err := c.Get(ctx, types.NamespacedName{Name: name}, mc); err != nil {
+			if apierrors.IsNotFound(err) {
+				notFound = append(notFound, name)
+				continue
+			}
+			return nil, nil, err
+		}
+		joinedCond := meta.FindStatusCondition(mc.Status.Conditions, string(clusterv1beta1.ConditionTypeMemberClusterJoined))
+		if mc.DeletionTimestamp != nil || (joinedCond != nil && joinedCond.Status == metav1.ConditionFalse) {
+			notJoined = append(notJoined, name)
+		}
+	}
+	return notFound, notJoined, nil
+}
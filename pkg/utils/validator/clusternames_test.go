@@ -0,0 +1,170 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	clusterv1beta1 "github.com/kubefleet-dev/kubefleet/apis/cluster/v1beta1"
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+func TestValidatePlacementClusterNames(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clusterv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() = %v, want no error", err)
+	}
+	newCRP := func(placementType placementv1beta1.PlacementType, clusterNames ...string) *placementv1beta1.ClusterResourcePlacement {
+		return &placementv1beta1.ClusterResourcePlacement{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-crp"},
+			Spec: placementv1beta1.PlacementSpec{
+				Policy: &placementv1beta1.PlacementPolicy{PlacementType: placementType, ClusterNames: clusterNames},
+			},
+		}
+	}
+	joinedCluster := &clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "member-1"},
+		Status: clusterv1beta1.MemberClusterStatus{
+			Conditions: []metav1.Condition{{Type: string(clusterv1beta1.ConditionTypeMemberClusterJoined), Status: metav1.ConditionTrue}},
+		},
+	}
+	leavingCluster := &clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "member-2",
+			DeletionTimestamp: &metav1.Time{Time: time.Now()},
+			Finalizers:        []string{"test-finalizer"},
+		},
+	}
+	leftCluster := &clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "member-3"},
+		Status: clusterv1beta1.MemberClusterStatus{
+			Conditions: []metav1.Condition{{Type: string(clusterv1beta1.ConditionTypeMemberClusterJoined), Status: metav1.ConditionFalse}},
+		},
+	}
+	existing := []client.Object{joinedCluster, leavingCluster, leftCluster}
+
+	testCases := map[string]struct {
+		mode              ClusterNamesValidationMode
+		lookupErr         error
+		placement         placementv1beta1.PlacementObj
+		oldPlacement      placementv1beta1.PlacementObj
+		wantDeniedMessage string
+		wantErrored       bool
+		wantWarnings      []string
+	}{
+		"joined cluster": {
+			mode:      ClusterNamesValidationEnforce,
+			placement: newCRP(placementv1beta1.PickFixedPlacementType, "member-1"),
+		},
+		"unknown clusters are warned about": {
+			mode:         ClusterNamesValidationWarn,
+			placement:    newCRP(placementv1beta1.PickFixedPlacementType, "member-1", "renamed", "absent"),
+			wantWarnings: []string{"member cluster(s) absent, renamed in spec.policy.clusterNames are not found, the resources will not be placed on them until they join the fleet"},
+		},
+		"leaving and left clusters are warned about": {
+			mode:         ClusterNamesValidationWarn,
+			placement:    newCRP(placementv1beta1.PickFixedPlacementType, "member-3", "member-2"),
+			wantWarnings: []string{"member cluster(s) member-2, member-3 in spec.policy.clusterNames are leaving or have left the fleet, the resources will not be placed on them until they join the fleet"},
+		},
+		"unknown and leaving clusters are denied in the enforce mode": {
+			mode:              ClusterNamesValidationEnforce,
+			placement:         newCRP(placementv1beta1.PickFixedPlacementType, "member-1", "member-2", "absent"),
+			wantDeniedMessage: "member cluster(s) absent in spec.policy.clusterNames are not found; member cluster(s) member-2 in spec.policy.clusterNames are leaving or have left the fleet, the resources would never be placed on them",
+		},
+		"only the added cluster names are checked on update": {
+			mode:              ClusterNamesValidationEnforce,
+			placement:         newCRP(placementv1beta1.PickFixedPlacementType, "member-1", "member-3", "absent"),
+			oldPlacement:      newCRP(placementv1beta1.PickFixedPlacementType, "member-3"),
+			wantDeniedMessage: "member cluster(s) absent in spec.policy.clusterNames are not found, the resources would never be placed on them",
+		},
+		"unchanged cluster names are not checked on update": {
+			mode:         ClusterNamesValidationEnforce,
+			placement:    newCRP(placementv1beta1.PickFixedPlacementType, "member-3", "absent"),
+			oldPlacement: newCRP(placementv1beta1.PickFixedPlacementType, "absent", "member-3"),
+		},
+		"cluster names of the old PickAll policy are checked on update": {
+			mode:         ClusterNamesValidationWarn,
+			placement:    newCRP(placementv1beta1.PickFixedPlacementType, "absent"),
+			oldPlacement: newCRP(placementv1beta1.PickAllPlacementType),
+			wantWarnings: []string{"member cluster(s) absent in spec.policy.clusterNames are not found, the resources will not be placed on them until they join the fleet"},
+		},
+		"PickAll placement": {
+			mode:      ClusterNamesValidationEnforce,
+			placement: newCRP(placementv1beta1.PickAllPlacementType),
+		},
+		"validation is disabled": {
+			mode:      ClusterNamesValidationDisabled,
+			placement: newCRP(placementv1beta1.PickFixedPlacementType, "absent"),
+		},
+		"lookup failure is warned about": {
+			mode:         ClusterNamesValidationWarn,
+			lookupErr:    errors.New("lookup failed"),
+			placement:    newCRP(placementv1beta1.PickFixedPlacementType, "member-1"),
+			wantWarnings: []string{"failed to look up the member clusters in spec.policy.clusterNames: lookup failed"},
+		},
+		"lookup failure is an error in the enforce mode": {
+			mode:        ClusterNamesValidationEnforce,
+			lookupErr:   errors.New("lookup failed"),
+			placement:   newCRP(placementv1beta1.PickFixedPlacementType, "member-1"),
+			wantErrored: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := Config{ClusterNamesValidation: tc.mode}
+			builder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing...)
+			if tc.lookupErr != nil {
+				builder = builder.WithInterceptorFuncs(interceptor.Funcs{
+					Get: func(context.Context, client.WithWatch, client.ObjectKey, client.Object, ...client.GetOption) error {
+						return tc.lookupErr
+					},
+				})
+			}
+
+			resp := config.ValidatePlacementClusterNames(context.Background(), builder.Build(), tc.placement, tc.oldPlacement, admission.Allowed("allowed"))
+			switch {
+			case tc.wantDeniedMessage != "":
+				if resp.Allowed || resp.Result.Message != tc.wantDeniedMessage {
+					t.Errorf("ValidatePlacementClusterNames() = %+v, want denied with message %q", resp.Result, tc.wantDeniedMessage)
+				}
+			case tc.wantErrored:
+				if resp.Allowed || resp.Result.Code != http.StatusInternalServerError {
+					t.Errorf("ValidatePlacementClusterNames() = %+v, want an internal server error", resp.Result)
+				}
+			default:
+				if !resp.Allowed {
+					t.Errorf("ValidatePlacementClusterNames() = %+v, want allowed", resp.Result)
+				}
+				if diff := cmp.Diff(tc.wantWarnings, []string(resp.Warnings)); diff != "" {
+					t.Errorf("ValidatePlacementClusterNames() warnings mismatch (-want, +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
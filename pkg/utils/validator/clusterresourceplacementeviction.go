@@ -18,11 +18,15 @@ limitations under the License.
 package validator
 
 import (
+	"context"
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	fleetv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/controller"
 )
 
 // ValidateClusterResourcePlacementForEviction validates cluster resource placement fields for eviction and returns error.
@@ -43,3 +47,37 @@ func ValidateClusterResourcePlacementForEviction(crp fleetv1beta1.ClusterResourc
 
 	return errors.NewAggregate(allErr)
 }
+
+// EvictionTargetValidationMode is how an eviction targeting a cluster which is not selected by the latest scheduling
+// decision of its placement is handled, as such an eviction has no effect.
+type EvictionTargetValidationMode string
+
+const (
+	// EvictionTargetValidationDisabled does not validate the target cluster of an eviction.
+	EvictionTargetValidationDisabled EvictionTargetValidationMode = "disabled"
+	// EvictionTargetValidationWarn allows the eviction with a warning, which tolerates the races between the
+	// eviction and the scheduler.
+	EvictionTargetValidationWarn EvictionTargetValidationMode = "warn"
+	// EvictionTargetValidationEnforce denies the eviction.
+	EvictionTargetValidationEnforce EvictionTargetValidationMode = "enforce"
+)
+
+// EvictionTargetValidationModes are the valid eviction target validation modes.
+var EvictionTargetValidationModes = []EvictionTargetValidationMode{EvictionTargetValidationDisabled, EvictionTargetValidationWarn, EvictionTargetValidationEnforce}
+
+// IsClusterSelectedByPlacement returns true if the cluster is selected by the latest scheduling decision of the
+// placement, i.e., its latest policy snapshot. The cluster is not selected if the placement is not scheduled yet.
+func IsClusterSelectedByPlacement(ctx context.Context, c client.Reader, placementKey types.NamespacedName, clusterName string) (bool, error) {
+	policySnapshotList, err := controller.FetchLatestPolicySnapshot(ctx, c, placementKey)
+	if err != nil {
+		return false, err
+	}
+	for _, snapshot := range policySnapshotList.GetPolicySnapshotObjs() {
+		for _, decision := range snapshot.GetPolicySnapshotStatus().ClusterDecisions {
+			if decision.ClusterName == clusterName && decision.Selected {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,432 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"regexp"
+	"slices"
+	"sync"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+
+	"github.com/kubefleet-dev/kubefleet/pkg/utils"
+)
+
+const (
+	// DefaultMaxClusterNames is the default maximum number of cluster names in a PickFixed placement policy.
+	DefaultMaxClusterNames = 100
+
+	// DefaultMetadataSizeSoftLimitBytes is the default total size of the labels and annotations of a placement
+	// above which a warning is returned.
+	DefaultMetadataSizeSoftLimitBytes = 32 * 1024
+
+	// DefaultMetadataSizeHardLimitBytes is the default total size of the labels and annotations of a placement
+	// above which the request is denied.
+	DefaultMetadataSizeHardLimitBytes = 128 * 1024
+
+	// DefaultMaxTolerations is the default maximum number of tolerations in a placement policy.
+	DefaultMaxTolerations = 64
+
+	// DefaultMaxTopologySpreadConstraints is the default maximum number of topology spread constraints in a
+	// placement policy.
+	DefaultMaxTopologySpreadConstraints = 16
+
+	// DefaultMaxAffinityTerms is the default maximum number of required or preferred cluster affinity terms in a
+	// placement policy.
+	DefaultMaxAffinityTerms = 32
+
+	// DefaultMaxRevisionHistoryLimitReductionPercent is the default maximum percentage by which a single update
+	// can reduce the revision history limit of a placement.
+	DefaultMaxRevisionHistoryLimitReductionPercent = 50
+
+	// DefaultMaxDiffLogBytes is the default size above which the logged diff of a denied update is truncated.
+	DefaultMaxDiffLogBytes = 4 * 1024
+
+	// DefaultMaxWorkManifests is the default maximum number of manifests embedded in a Work.
+	DefaultMaxWorkManifests = 100
+
+	// DefaultMaxWorkManifestSizeBytes is the default maximum size of a single manifest embedded in a Work.
+	DefaultMaxWorkManifestSizeBytes = 1024 * 1024
+
+	// DefaultMaxWorkSizeBytes is the default maximum size of a Work object, below the 1.5MiB request limit of etcd.
+	DefaultMaxWorkSizeBytes = 1024 * 1024 * 3 / 2
+
+	// DefaultLookupBudget is the default time the client-backed checks of a placement request can spend reading
+	// the hub cluster, well within the timeout of the placement validating webhooks.
+	DefaultLookupBudget = 2 * time.Second
+
+	// DefaultPickAllFleetSizeThreshold is the default number of member clusters above which a PickAll placement
+	// without required cluster affinity is warned about or denied.
+	DefaultPickAllFleetSizeThreshold = 100
+
+	// DefaultSlowValidationThreshold is the default time the validation of a placement request can take before
+	// its slowest rule is logged.
+	DefaultSlowValidationThreshold = 500 * time.Millisecond
+)
+
+// Config holds the tunable settings of the fleet validators.
+type Config struct {
+	// TrustedServiceAccounts is the list of service account usernames, in the form of
+	// system:serviceaccount:<namespace>:<name>, whose requests skip the advisory validations.
+	TrustedServiceAccounts []string
+
+	// MaxClusterNames is the maximum number of cluster names in a PickFixed placement policy.
+	// DefaultMaxClusterNames is used if it is not positive.
+	MaxClusterNames int
+
+	// MetadataSizeSoftLimitBytes is the total size of the labels and annotations of a placement above which
+	// a warning is returned. DefaultMetadataSizeSoftLimitBytes is used if it is not positive.
+	MetadataSizeSoftLimitBytes int
+
+	// MetadataSizeHardLimitBytes is the total size of the labels and annotations of a placement above which
+	// the request is denied. DefaultMetadataSizeHardLimitBytes is used if it is not positive.
+	MetadataSizeHardLimitBytes int
+
+	// MaxTolerations is the maximum number of tolerations in a placement policy.
+	// DefaultMaxTolerations is used if it is not positive.
+	MaxTolerations int
+
+	// MaxTopologySpreadConstraints is the maximum number of topology spread constraints in a placement policy.
+	// DefaultMaxTopologySpreadConstraints is used if it is not positive.
+	MaxTopologySpreadConstraints int
+
+	// MaxAffinityTerms is the maximum number of required or preferred cluster affinity terms in a placement
+	// policy. DefaultMaxAffinityTerms is used if it is not positive.
+	MaxAffinityTerms int
+
+	// MaxRevisionHistoryLimitReductionPercent is the maximum percentage by which a single update can reduce the
+	// revision history limit of a placement, as the snapshots beyond the new limit are deleted all at once.
+	// DefaultMaxRevisionHistoryLimitReductionPercent is used if it is not positive.
+	MaxRevisionHistoryLimitReductionPercent int
+
+	// ShadowValidationRules is the list of placement validation rule names which run in shadow mode, i.e.,
+	// their failures are recorded and returned as warnings but never deny the request.
+	ShadowValidationRules []string
+
+	// FleetNamespace is the namespace fleet runs in, which placements cannot select as it holds fleet's internal
+	// state. utils.FleetSystemNamespace is used if it is empty.
+	FleetNamespace string
+
+	// RequiredLabels maps the label keys every ClusterResourcePlacement must carry on creation to the optional
+	// regular expression their values must match; a nil regular expression only requires the key to be present.
+	RequiredLabels map[string]*regexp.Regexp
+
+	// LabelSchemas maps the label keys of MemberClusters to the format their values must have, e.g., the set of
+	// known regions. The values are checked when the labels are added or updated.
+	LabelSchemas map[string]*LabelSchema
+
+	// DenyPlacementNameCollisions denies the creation of a placement whose name is used by a placement of the
+	// other scope.
+	DenyPlacementNameCollisions bool
+
+	// DenySpecUpdatesDuringUpdateRuns denies the spec updates of a placement while any staged update run which
+	// references the placement has not finished, as the run would keep rolling out a stale snapshot.
+	DenySpecUpdatesDuringUpdateRuns bool
+
+	// RequireSecretPropagationOptIn denies the placements which select Secrets, by kind or through the namespaces
+	// selected with all their resources, unless they carry the AllowSecretPropagationAnnotation with the value
+	// "true". Such placements are only warned about if it is not set.
+	RequireSecretPropagationOptIn bool
+
+	// DenyCRDCoSelection denies the placements which select CustomResourceDefinitions together with custom resources
+	// of the groups they may serve, as the custom resources fail to apply on the member clusters where they are
+	// applied before the CustomResourceDefinitions are established. Such placements are only warned about if it is
+	// not set.
+	DenyCRDCoSelection bool
+
+	// DenyPlacementOverrideConflicts denies a ClusterResourcePlacement which places the resources of another
+	// ClusterResourcePlacement on the same clusters with different ClusterResourceOverrides. It is read when the
+	// ClusterResourcePlacement validating webhook is registered, whose conflict detector is left nil if it is not set.
+	DenyPlacementOverrideConflicts bool
+
+	// AllowUnknownKinds allows the requests of the kinds the webhooks do not accept, e.g., a new version served after
+	// a CRD upgrade, with a warning instead of failing to decode them. The requests are still validated strictly,
+	// i.e., they fail if they cannot be decoded, if it is not set. See HandleUnknownKind.
+	AllowUnknownKinds bool
+
+	// LogDeniedUpdateDiffs logs the redacted diff between the old and new objects of every denied update. The diffs
+	// are also logged if the klog verbosity is at least 4.
+	LogDeniedUpdateDiffs bool
+
+	// MaxDiffLogBytes is the size above which the logged diff of a denied update is truncated.
+	// DefaultMaxDiffLogBytes is used if it is not positive.
+	MaxDiffLogBytes int
+
+	// MaxPlacementsPerTeam is the maximum number of active ClusterResourcePlacements carrying the same team label.
+	// The quota is not enforced if it is not positive.
+	MaxPlacementsPerTeam int
+
+	// EvictionTargetValidation is how an eviction targeting a cluster which is not selected by the latest
+	// scheduling decision of its placement is handled. The target is not validated if it is empty.
+	EvictionTargetValidation EvictionTargetValidationMode
+
+	// ClusterNamesValidation is how the cluster names of a PickFixed placement which are unknown MemberClusters, or
+	// whose MemberClusters are leaving or have left the fleet, are handled. The names are not validated if it is empty.
+	ClusterNamesValidation ClusterNamesValidationMode
+
+	// NumberOfClustersValidation is how a PickN placement requesting more clusters than the MemberClusters which have
+	// joined or are joining the fleet is handled. The number of clusters is not validated if it is empty.
+	NumberOfClustersValidation NumberOfClustersValidationMode
+	// PickAllFleetSizeValidation is how a PickAll placement without required cluster affinity is handled once the
+	// fleet has more member clusters than PickAllFleetSizeThreshold. The placements are not validated if it is empty.
+	PickAllFleetSizeValidation PickAllFleetSizeValidationMode
+	// PickAllFleetSizeThreshold is the number of member clusters above which the PickAll placements without required
+	// cluster affinity are validated. DefaultPickAllFleetSizeThreshold is used if it is not positive.
+	PickAllFleetSizeThreshold int
+
+	// StripAnnotationPrefixes are the prefixes of the annotation keys which are removed from every
+	// ClusterResourcePlacement on create and update. The annotations owned by fleet are never removed.
+	StripAnnotationPrefixes []string
+
+	// ResourcePlacementDeniedTolerationKeyPrefixes are the prefixes of the taint keys reserved for the fleet
+	// administrators, e.g., "fleet.io/", which the tolerations of a ResourcePlacement cannot use. The tolerations
+	// of ClusterResourcePlacements are not restricted.
+	ResourcePlacementDeniedTolerationKeyPrefixes []string
+
+	// DenialMessageTemplates maps the IDs of the denial messages, e.g., PlacementTypeImmutableMessageID, to the
+	// text/template strings which replace their default text. The templates are rendered with a map[string]any
+	// holding the data documented on each ID. See DenialMessage.
+	DenialMessageTemplates map[string]string
+
+	// LookupBudget is the time the client-backed checks can spend reading the hub cluster, see WithLookupBudget.
+	// DefaultLookupBudget is used if it is not positive.
+	LookupBudget time.Duration
+
+	// LookupFailurePolicies maps the names of the client-backed checks, e.g., ClusterNamesLookupCheck, to how the
+	// requests are handled once the checks exhaust the lookup budget. The checks not set use their defaults.
+	LookupFailurePolicies map[string]LookupFailurePolicy
+
+	// SlowValidationThreshold is the time the validation of a placement request can take before its slowest rule
+	// is logged at verbosity 3. DefaultSlowValidationThreshold is used if it is not positive.
+	SlowValidationThreshold time.Duration
+
+	// WorkLimits are the limits of the manifests embedded in a Work. The defaults are used for the limits which
+	// are not positive.
+	WorkLimits WorkLimits
+
+	// HubAgentWorkLimits are the limits of the manifests embedded in a Work created or updated by one of the
+	// HubAgentIdentities, which usually are higher than WorkLimits as the hub agent generates the Works of every
+	// placement. WorkLimits are used for the limits which are not positive.
+	HubAgentWorkLimits WorkLimits
+
+	// HubAgentIdentities are the usernames of the hub agent, in the form of
+	// system:serviceaccount:<namespace>:<name>, whose Works are checked against HubAgentWorkLimits.
+	HubAgentIdentities []string
+
+	// IncidentWindowChecker tells if the fleet is in an incident or maintenance window, during which the spec updates
+	// of the ClusterResourcePlacements are denied unless they carry the IncidentBypassAnnotation. The updates are not
+	// checked if it is nil. See ValidatePlacementIncidentWindow.
+	IncidentWindowChecker IncidentWindowChecker
+
+	// WebhookCertSecretName is the name of the Secret in FleetNamespace holding the serving certificate of the fleet
+	// webhooks, which only the hub controllers and the white listed users can update or delete. The Secret is not
+	// guarded if it is empty. See IsWebhookCertSecret.
+	WebhookCertSecretName string
+}
+
+// WorkLimits are the limits of the manifests embedded in a Work.
+type WorkLimits struct {
+	// MaxManifests is the maximum number of manifests in a Work.
+	MaxManifests int
+	// MaxManifestSizeBytes is the maximum size of a single manifest in a Work.
+	MaxManifestSizeBytes int
+	// MaxSizeBytes is the maximum size of the Work object.
+	MaxSizeBytes int
+}
+
+// withDefaults returns the limits with the ones which are not positive replaced by those of defaults.
+func (l WorkLimits) withDefaults(defaults WorkLimits) WorkLimits {
+	if l.MaxManifests <= 0 {
+		l.MaxManifests = defaults.MaxManifests
+	}
+	if l.MaxManifestSizeBytes <= 0 {
+		l.MaxManifestSizeBytes = defaults.MaxManifestSizeBytes
+	}
+	if l.MaxSizeBytes <= 0 {
+		l.MaxSizeBytes = defaults.MaxSizeBytes
+	}
+	return l
+}
+
+// maxClusterNames returns the maximum number of cluster names allowed in a PickFixed placement policy.
+func (c Config) maxClusterNames() int {
+	if c.MaxClusterNames <= 0 {
+		return DefaultMaxClusterNames
+	}
+	return c.MaxClusterNames
+}
+
+// metadataSizeSoftLimitBytes returns the total metadata size of a placement above which a warning is returned.
+func (c Config) metadataSizeSoftLimitBytes() int {
+	if c.MetadataSizeSoftLimitBytes <= 0 {
+		return DefaultMetadataSizeSoftLimitBytes
+	}
+	return c.MetadataSizeSoftLimitBytes
+}
+
+// metadataSizeHardLimitBytes returns the total metadata size of a placement above which the request is denied.
+func (c Config) metadataSizeHardLimitBytes() int {
+	if c.MetadataSizeHardLimitBytes <= 0 {
+		return DefaultMetadataSizeHardLimitBytes
+	}
+	return c.MetadataSizeHardLimitBytes
+}
+
+// pickAllFleetSizeThreshold returns the number of member clusters above which the PickAll placements without
+// required cluster affinity are validated.
+func (c Config) pickAllFleetSizeThreshold() int {
+	if c.PickAllFleetSizeThreshold <= 0 {
+		return DefaultPickAllFleetSizeThreshold
+	}
+	return c.PickAllFleetSizeThreshold
+}
+
+// maxTolerations returns the maximum number of tolerations allowed in a placement policy.
+func (c Config) maxTolerations() int {
+	if c.MaxTolerations <= 0 {
+		return DefaultMaxTolerations
+	}
+	return c.MaxTolerations
+}
+
+// maxTopologySpreadConstraints returns the maximum number of topology spread constraints allowed in a placement policy.
+func (c Config) maxTopologySpreadConstraints() int {
+	if c.MaxTopologySpreadConstraints <= 0 {
+		return DefaultMaxTopologySpreadConstraints
+	}
+	return c.MaxTopologySpreadConstraints
+}
+
+// maxAffinityTerms returns the maximum number of required or preferred cluster affinity terms allowed in a placement policy.
+func (c Config) maxAffinityTerms() int {
+	if c.MaxAffinityTerms <= 0 {
+		return DefaultMaxAffinityTerms
+	}
+	return c.MaxAffinityTerms
+}
+
+// maxDiffLogBytes returns the size above which the logged diff of a denied update is truncated.
+func (c Config) maxDiffLogBytes() int {
+	if c.MaxDiffLogBytes <= 0 {
+		return DefaultMaxDiffLogBytes
+	}
+	return c.MaxDiffLogBytes
+}
+
+// maxRevisionHistoryLimitReductionPercent returns the maximum percentage by which a single update can reduce the
+// revision history limit of a placement.
+func (c Config) maxRevisionHistoryLimitReductionPercent() int {
+	if c.MaxRevisionHistoryLimitReductionPercent <= 0 {
+		return DefaultMaxRevisionHistoryLimitReductionPercent
+	}
+	return c.MaxRevisionHistoryLimitReductionPercent
+}
+
+// lookupBudget returns the time the client-backed checks can spend reading the hub cluster.
+func (c Config) lookupBudget() time.Duration {
+	if c.LookupBudget <= 0 {
+		return DefaultLookupBudget
+	}
+	return c.LookupBudget
+}
+
+// slowValidationThreshold returns the time the validation of a placement request can take before its slowest rule
+// is logged.
+func (c Config) slowValidationThreshold() time.Duration {
+	if c.SlowValidationThreshold <= 0 {
+		return DefaultSlowValidationThreshold
+	}
+	return c.SlowValidationThreshold
+}
+
+// lookupFailurePolicy returns how the requests are handled once the client-backed check exhausts the lookup budget.
+func (c Config) lookupFailurePolicy(check string) LookupFailurePolicy {
+	if policy, ok := c.LookupFailurePolicies[check]; ok {
+		return policy
+	}
+	if policy, ok := defaultLookupFailurePolicies[check]; ok {
+		return policy
+	}
+	return LookupFailClosed
+}
+
+// workLimits returns the limits of the manifests embedded in a Work created or updated by the user.
+func (c Config) workLimits(userInfo authenticationv1.UserInfo) WorkLimits {
+	limits := c.WorkLimits.withDefaults(WorkLimits{
+		MaxManifests:         DefaultMaxWorkManifests,
+		MaxManifestSizeBytes: DefaultMaxWorkManifestSizeBytes,
+		MaxSizeBytes:         DefaultMaxWorkSizeBytes,
+	})
+	if userInfo.Username != "" && slices.Contains(c.HubAgentIdentities, userInfo.Username) {
+		return c.HubAgentWorkLimits.withDefaults(limits)
+	}
+	return limits
+}
+
+// fleetNamespace returns the namespace fleet runs in.
+func (c Config) fleetNamespace() string {
+	if c.FleetNamespace == "" {
+		return utils.FleetSystemNamespace
+	}
+	return c.FleetNamespace
+}
+
+// IsWebhookCertSecret returns true if the Secret of namespace and name holds the serving certificate of the fleet
+// webhooks, i.e., it is named WebhookCertSecretName and lives in the fleet namespace.
+func (c Config) IsWebhookCertSecret(namespace, name string) bool {
+	return c.WebhookCertSecretName != "" && name == c.WebhookCertSecretName && namespace == c.fleetNamespace()
+}
+
+// isShadowValidationRule returns true if the placement validation rule is configured to run in shadow mode.
+func (c Config) isShadowValidationRule(name string) bool {
+	return slices.Contains(c.ShadowValidationRules, name)
+}
+
+// IsTrustedIdentity returns true if the user is one of the configured trusted service accounts.
+func (c Config) IsTrustedIdentity(userInfo authenticationv1.UserInfo) bool {
+	return userInfo.Username != "" && slices.Contains(c.TrustedServiceAccounts, userInfo.Username)
+}
+
+// ConfigStore holds the validator settings the webhook handlers read on every request, which the webhook config
+// ConfigMap can replace at runtime. A nil ConfigStore holds the zero Config, i.e., the default settings.
+type ConfigStore struct {
+	mu     sync.RWMutex
+	config Config
+}
+
+// NewConfigStore returns a ConfigStore holding the validator settings.
+func NewConfigStore(c Config) *ConfigStore {
+	return &ConfigStore{config: c}
+}
+
+// Load returns the current validator settings.
+func (s *ConfigStore) Load() Config {
+	if s == nil {
+		return Config{}
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.config
+}
+
+// Store replaces the validator settings.
+func (s *ConfigStore) Store(c Config) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.config = c
+}
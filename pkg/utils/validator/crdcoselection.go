@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+const (
+	// crdGroup and crdKind identify the resource selectors selecting CustomResourceDefinitions.
+	crdGroup = "apiextensions.k8s.io"
+	crdKind  = "CustomResourceDefinition"
+)
+
+// isCustomResourceGroup returns true if the API group follows the naming of the groups served by
+// CustomResourceDefinitions, i.e., it is a DNS subdomain outside of the k8s.io and kubernetes.io domains reserved
+// for the built-in APIs. The group is checked statically so that no cluster read is needed.
+func isCustomResourceGroup(group string) bool {
+	if !strings.Contains(group, ".") {
+		return false
+	}
+	for _, reserved := range []string{"k8s.io", "kubernetes.io"} {
+		if group == reserved || strings.HasSuffix(group, "."+reserved) {
+			return false
+		}
+	}
+	return true
+}
+
+// crdCoSelections describes the pairs of resource selectors of the placement where one selects
+// CustomResourceDefinitions and the other selects custom resources which the CustomResourceDefinitions may serve,
+// in the order of the selectors. A selector naming a CustomResourceDefinition, whose name is <plural>.<group>, is
+// only paired with the selectors of its group. It returns nil if no such pair is found.
+func crdCoSelections(placement placementv1beta1.PlacementObj) []string {
+	selectors := placement.GetPlacementSpec().ResourceSelectors
+	var selections []string
+	for i, crdSelector := range selectors {
+		if crdSelector.Group != crdGroup || !strings.EqualFold(crdSelector.Kind, crdKind) {
+			continue
+		}
+		crdName := "all the CustomResourceDefinitions"
+		if crdSelector.LabelSelector != nil {
+			crdName = "the CustomResourceDefinitions matching a label selector"
+		}
+		servedGroup := ""
+		if crdSelector.Name != "" {
+			crdName = "CustomResourceDefinition " + crdSelector.Name
+			if _, group, found := strings.Cut(crdSelector.Name, "."); found {
+				servedGroup = group
+			}
+		}
+		for j, crSelector := range selectors {
+			if !isCustomResourceGroup(crSelector.Group) || (servedGroup != "" && crSelector.Group != servedGroup) {
+				continue
+			}
+			selections = append(selections, fmt.Sprintf("%s (resourceSelectors[%d]) and %s resources of group %s (resourceSelectors[%d])",
+				crdName, i, crSelector.Kind, crSelector.Group, j))
+		}
+	}
+	return selections
+}
+
+// validateCRDCoSelection denies a placement which selects CustomResourceDefinitions together with their custom
+// resources if Config.DenyCRDCoSelection is set.
+func validateCRDCoSelection(_ context.Context, config Config, _ admission.Request, placement, _ placementv1beta1.PlacementObj) error {
+	if !config.DenyCRDCoSelection {
+		return nil
+	}
+	selections := crdCoSelections(placement)
+	if len(selections) == 0 {
+		return nil
+	}
+	return errors.New(config.DenialMessage(CRDCoSelectionMessageID, map[string]any{
+		"selections": strings.Join(selections, "; "),
+	}))
+}
+
+// warnCRDCoSelection warns about a placement which selects CustomResourceDefinitions together with their custom
+// resources, as the custom resources fail to apply on the member clusters where they are applied before the
+// CustomResourceDefinitions are established. The placement is denied by validateCRDCoSelection instead if
+// Config.DenyCRDCoSelection is set.
+func warnCRDCoSelection(config Config, _ admission.Request, placement, _ placementv1beta1.PlacementObj) []string {
+	if config.DenyCRDCoSelection {
+		return nil
+	}
+	var warnings []string
+	for _, selection := range crdCoSelections(placement) {
+		warnings = append(warnings, fmt.Sprintf("the placement selects %s, which may fail to apply on the member clusters if the custom resources are applied "+
+			"before the CustomResourceDefinition is established; select the CustomResourceDefinition in a separate placement which is rolled out first", selection))
+	}
+	return warnings
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+func TestCRDCoSelection(t *testing.T) {
+	newCRP := func(selectors ...placementv1beta1.ResourceSelectorTerm) *placementv1beta1.ClusterResourcePlacement {
+		return &placementv1beta1.ClusterResourcePlacement{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-crp"},
+			Spec:       placementv1beta1.PlacementSpec{ResourceSelectors: selectors},
+		}
+	}
+	namedCRD := placementv1beta1.ResourceSelectorTerm{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition", Name: "widgets.example.com"}
+	labeledCRDs := placementv1beta1.ResourceSelectorTerm{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition", LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "widget"}}}
+	widgets := placementv1beta1.ResourceSelectorTerm{Group: "example.com", Version: "v1", Kind: "Widget"}
+	gadgets := placementv1beta1.ResourceSelectorTerm{Group: "other.example.org", Version: "v1", Kind: "Gadget", Name: "gadget"}
+	clusterRole := placementv1beta1.ResourceSelectorTerm{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole", Name: "widget-reader"}
+	namespace := placementv1beta1.ResourceSelectorTerm{Version: "v1", Kind: "Namespace", Name: "app"}
+
+	testCases := map[string]struct {
+		placement    placementv1beta1.PlacementObj
+		strict       bool
+		wantErr      string
+		wantWarnings []string
+	}{
+		"named CRD with its custom resources in warn mode": {
+			placement: newCRP(namedCRD, clusterRole, widgets),
+			wantWarnings: []string{"the placement selects CustomResourceDefinition widgets.example.com (resourceSelectors[0]) and Widget resources of group example.com (resourceSelectors[2]), " +
+				"which may fail to apply on the member clusters if the custom resources are applied before the CustomResourceDefinition is established; " +
+				"select the CustomResourceDefinition in a separate placement which is rolled out first"},
+		},
+		"named CRD with custom resources of another group": {
+			placement: newCRP(namedCRD, gadgets),
+		},
+		"labeled CRDs with custom resources of any group": {
+			placement: newCRP(gadgets, labeledCRDs, widgets),
+			wantWarnings: []string{
+				"the placement selects the CustomResourceDefinitions matching a label selector (resourceSelectors[1]) and Gadget resources of group other.example.org (resourceSelectors[0]), " +
+					"which may fail to apply on the member clusters if the custom resources are applied before the CustomResourceDefinition is established; " +
+					"select the CustomResourceDefinition in a separate placement which is rolled out first",
+				"the placement selects the CustomResourceDefinitions matching a label selector (resourceSelectors[1]) and Widget resources of group example.com (resourceSelectors[2]), " +
+					"which may fail to apply on the member clusters if the custom resources are applied before the CustomResourceDefinition is established; " +
+					"select the CustomResourceDefinition in a separate placement which is rolled out first",
+			},
+		},
+		"CRD only": {
+			placement: newCRP(namedCRD, labeledCRDs, clusterRole, namespace),
+		},
+		"CRD only in strict mode": {
+			placement: newCRP(namedCRD, labeledCRDs, clusterRole, namespace),
+			strict:    true,
+		},
+		"custom resources only": {
+			placement: newCRP(widgets, gadgets, namespace),
+		},
+		"custom resources only in strict mode": {
+			placement: newCRP(widgets, gadgets, namespace),
+			strict:    true,
+		},
+		"named CRD with its custom resources in strict mode": {
+			placement: newCRP(namedCRD, widgets),
+			strict:    true,
+			wantErr: "the placement selects CustomResourceDefinition widgets.example.com (resourceSelectors[0]) and Widget resources of group example.com (resourceSelectors[1]), " +
+				"whose custom resources fail to apply on the member clusters where they are applied before the CustomResourceDefinition is established; " +
+				"please select the CustomResourceDefinition in a separate placement which is rolled out first",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := Config{DenyCRDCoSelection: tc.strict}
+			err := validateCRDCoSelection(context.Background(), config, admission.Request{}, tc.placement, nil)
+			gotErr := ""
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if gotErr != tc.wantErr {
+				t.Errorf("validateCRDCoSelection() = %q, want %q", gotErr, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.wantWarnings, warnCRDCoSelection(config, admission.Request{}, tc.placement, nil)); diff != "" {
+				t.Errorf("warnCRDCoSelection() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsCustomResourceGroup(t *testing.T) {
+	testCases := map[string]bool{
+		"":                              false,
+		"apps":                          false,
+		"k8s.io":                        false,
+		"rbac.authorization.k8s.io":     false,
+		"metrics.kubernetes.io":         false,
+		"example.com":                   true,
+		"placement.kubernetes-fleet.io": true,
+	}
+	for group, want := range testCases {
+		if got := isCustomResourceGroup(group); got != want {
+			t.Errorf("isCustomResourceGroup(%q) = %t, want %t", group, got, want)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// DeniedWithCauses returns a response denying the request with the message and, if there are any, the causes in
+// the details of its status, so that clients such as kubectl can show the offending fields.
+func DeniedWithCauses(message string, causes []metav1.StatusCause) admission.Response {
+	resp := admission.Denied(message)
+	if len(causes) > 0 {
+		resp.Result.Details = &metav1.StatusDetails{Causes: causes}
+	}
+	return resp
+}
+
+// FieldErrorCauses returns the status causes of the field errors in the error, which may hold them in aggregates,
+// e.g., the ones of field.ErrorList.ToAggregate, nested in other aggregates or wrapped. The other errors have no
+// cause as they do not name a field.
+func FieldErrorCauses(err error) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	var collect func(err error)
+	collect = func(err error) {
+		var agg utilerrors.Aggregate
+		if errors.As(err, &agg) {
+			for _, e := range agg.Errors() {
+				collect(e)
+			}
+			return
+		}
+		var fieldErr *field.Error
+		if errors.As(err, &fieldErr) {
+			// The same conversion as the API server uses for the Invalid status.
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseType(fieldErr.Type),
+				Message: fieldErr.ErrorBody(),
+				Field:   fieldErr.Field,
+			})
+		}
+	}
+	if err != nil {
+		collect(err)
+	}
+	return causes
+}
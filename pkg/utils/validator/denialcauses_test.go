@@ -0,0 +1,147 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+func TestFieldErrorCauses(t *testing.T) {
+	duplicate := field.Duplicate(field.NewPath("spec", "policy", "clusterNames").Index(1), "member-1")
+	forbidden := field.Forbidden(field.NewPath("spec", "policy", "affinity"), "affinity must be nil")
+	duplicateCause := metav1.StatusCause{Type: metav1.CauseTypeFieldValueDuplicate, Message: `Duplicate value: "member-1"`, Field: "spec.policy.clusterNames[1]"}
+	forbiddenCause := metav1.StatusCause{Type: metav1.CauseType(field.ErrorTypeForbidden), Message: "Forbidden: affinity must be nil", Field: "spec.policy.affinity"}
+	testCases := map[string]struct {
+		err  error
+		want []metav1.StatusCause
+	}{
+		"no error": {},
+		"error without field": {
+			err: errors.New("cluster names cannot be empty"),
+		},
+		"field error list": {
+			err:  field.ErrorList{duplicate, forbidden}.ToAggregate(),
+			want: []metav1.StatusCause{duplicateCause, forbiddenCause},
+		},
+		"field errors in nested and wrapped aggregates": {
+			err: utilerrors.NewAggregate([]error{
+				errors.New("cluster names cannot be empty"),
+				fmt.Errorf("invalid policy: %w", field.ErrorList{forbidden}.ToAggregate()),
+				utilerrors.NewAggregate([]error{field.ErrorList{duplicate}.ToAggregate()}),
+			}),
+			want: []metav1.StatusCause{forbiddenCause, duplicateCause},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, FieldErrorCauses(tc.err)); diff != "" {
+				t.Errorf("FieldErrorCauses() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDeniedWithCauses(t *testing.T) {
+	causes := []metav1.StatusCause{{Type: metav1.CauseTypeFieldValueInvalid, Message: "Invalid value: -1", Field: "spec.revisionHistoryLimit"}}
+	testCases := map[string]struct {
+		causes      []metav1.StatusCause
+		wantDetails *metav1.StatusDetails
+	}{
+		"with causes": {
+			causes:      causes,
+			wantDetails: &metav1.StatusDetails{Causes: causes},
+		},
+		"without causes": {},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := DeniedWithCauses("the placement is invalid", tc.causes)
+			if resp.Allowed {
+				t.Fatalf("DeniedWithCauses() allowed = true, want false")
+			}
+			if resp.Result.Message != "the placement is invalid" || resp.Result.Code != http.StatusForbidden {
+				t.Errorf("DeniedWithCauses() message/code = %q/%d, want %q/%d", resp.Result.Message, resp.Result.Code, "the placement is invalid", http.StatusForbidden)
+			}
+			if diff := cmp.Diff(tc.wantDetails, resp.Result.Details); diff != "" {
+				t.Errorf("DeniedWithCauses() details mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHandlePlacementValidationCauses(t *testing.T) {
+	config := Config{MaxTolerations: 1}
+
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() = %v, want no error", err)
+	}
+	decoder := admission.NewDecoder(scheme)
+	duplicate := field.Duplicate(field.NewPath("spec", "policy", "clusterNames").Index(1), "member-1")
+
+	testCases := map[string]struct {
+		crp          *placementv1beta1.ClusterResourcePlacement
+		validateFunc func(placementv1beta1.PlacementObj) error
+		want         []metav1.StatusCause
+	}{
+		"failed validation rule": {
+			crp:          newCRPWithPolicyLists(2, 0, 0, 0),
+			validateFunc: func(placementv1beta1.PlacementObj) error { return nil },
+			want: []metav1.StatusCause{
+				{Type: metav1.CauseTypeTooMany, Message: "Too many: 2: must have at most 1 item", Field: "spec.policy.tolerations"},
+			},
+		},
+		"invalid fields": {
+			crp: newCRPWithPolicyLists(0, 0, 0, 0),
+			validateFunc: func(placementv1beta1.PlacementObj) error {
+				return utilerrors.NewAggregate([]error{errors.New("invalid strategy"), field.ErrorList{duplicate}.ToAggregate()})
+			},
+			want: []metav1.StatusCause{
+				{Type: metav1.CauseTypeFieldValueDuplicate, Message: `Duplicate value: "member-1"`, Field: "spec.policy.clusterNames[1]"},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := buildPlacementRequest(t, admissionv1.Create, untrustedServiceAccount, tc.crp, nil)
+			resp := config.HandlePlacementValidation(context.Background(), req, decoder, "CRP", nil, decodeCRP, decodeOldCRP, tc.validateFunc)
+			if resp.Allowed {
+				t.Fatalf("HandlePlacementValidation() allowed = true, want false")
+			}
+			if resp.Result.Details == nil {
+				t.Fatalf("HandlePlacementValidation() details = nil, want the causes %v", tc.want)
+			}
+			if diff := cmp.Diff(tc.want, resp.Result.Details.Causes); diff != "" {
+				t.Errorf("HandlePlacementValidation() causes mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"k8s.io/klog/v2"
+)
+
+// The IDs of the denial messages whose text can be customized through Config.DenialMessageTemplates.
+const (
+	// PlacementTypeImmutableMessageID denies changing the placement type of a placement.
+	PlacementTypeImmutableMessageID = "placement-type-immutable"
+	// PlacementInvalidFieldsMessageID denies creating or updating a placement with invalid fields.
+	// Data: resourceType, error.
+	PlacementInvalidFieldsMessageID = "placement-invalid-fields"
+	// PlacementOldInvalidFieldsMessageID denies updating the spec of a stored placement with invalid fields.
+	// Data: resourceType, error.
+	PlacementOldInvalidFieldsMessageID = "placement-old-invalid-fields"
+	// PlacementNameCollisionMessageID denies creating a placement whose name is used by a placement of the other
+	// scope. Data: name, conflicts.
+	PlacementNameCollisionMessageID = "placement-name-collision"
+	// PlacementClusterNamesUnavailableMessageID denies a PickFixed placement naming unavailable member clusters.
+	// Data: reasons.
+	PlacementClusterNamesUnavailableMessageID = "placement-cluster-names-unavailable"
+	// PlacementNumberOfClustersUnschedulableMessageID denies a PickN placement requesting more clusters than the fleet
+	// has. Data: requested, available.
+	PlacementNumberOfClustersUnschedulableMessageID = "placement-number-of-clusters-unschedulable"
+	// PlacementPickAllFleetSizeMessageID denies a PickAll placement without required cluster affinity in a fleet
+	// above the size threshold. Data: clusters, threshold, annotation.
+	PlacementPickAllFleetSizeMessageID = "placement-pick-all-fleet-size"
+	// PlacementOverrideConflictMessageID denies a CRP which places the resources of another CRP on the same clusters
+	// with different ClusterResourceOverrides. Data: conflicts.
+	PlacementOverrideConflictMessageID = "placement-override-conflict"
+	// PlacementRollbackRevisionNotFoundMessageID denies rolling back a CRP to a policy snapshot which does not exist.
+	// Data: revision, name, revisions.
+	PlacementRollbackRevisionNotFoundMessageID = "placement-rollback-revision-not-found"
+	// PlacementRolloutPausedMessageID denies updating the spec of a placement whose rollout is paused. Data: annotation.
+	PlacementRolloutPausedMessageID = "placement-rollout-paused"
+	// PlacementIncidentWindowMessageID denies updating the spec of a placement during an incident window.
+	// Data: reason, annotation.
+	PlacementIncidentWindowMessageID = "placement-incident-window"
+	// SecretPropagationOptInMessageID denies a placement which selects Secrets without acknowledging it.
+	// Data: selections, annotation.
+	SecretPropagationOptInMessageID = "secret-propagation-opt-in"
+	// CRDCoSelectionMessageID denies a placement which selects CustomResourceDefinitions together with their custom
+	// resources. Data: selections.
+	CRDCoSelectionMessageID = "crd-co-selection"
+	// TeamQuotaExhaustedMessageID denies creating a CRP whose team has no quota left. Data: team, label, remaining.
+	TeamQuotaExhaustedMessageID = "team-quota-exhausted"
+	// OwnedPolicySnapshotsMessageID denies creating a CRP which would adopt the policy snapshots of a previously
+	// deleted CRP of the same name. Data: snapshots, name.
+	OwnedPolicySnapshotsMessageID = "owned-policy-snapshots"
+	// NamespaceTerminatingMessageID denies creating an RP in a terminating namespace. Data: namespace.
+	NamespaceTerminatingMessageID = "namespace-terminating"
+	// ClusterResourceOverrideLimitMessageID denies creating a ClusterResourceOverride above the limit. Data: limit.
+	ClusterResourceOverrideLimitMessageID = "cluster-resource-override-limit"
+	// ResourceOverrideLimitMessageID denies creating a ResourceOverride above the limit. Data: limit.
+	ResourceOverrideLimitMessageID = "resource-override-limit"
+	// EvictionSpecImmutableMessageID denies updating the spec of a ClusterResourcePlacementEviction.
+	EvictionSpecImmutableMessageID = "eviction-spec-immutable"
+	// EvictionClusterNotSelectedMessageID denies an eviction targeting a cluster which is not selected by its
+	// placement. Data: cluster, placement.
+	EvictionClusterNotSelectedMessageID = "eviction-cluster-not-selected"
+	// MemberClusterServiceExportMessageID denies the leave of a member cluster which still exports services.
+	// Data: serviceExport.
+	MemberClusterServiceExportMessageID = "member-cluster-service-export"
+	// MemberClusterSelectedMessageID denies the leave of a member cluster which is still selected by placements.
+	// Data: memberCluster, count, placements, more, forceDeleteAnnotation.
+	MemberClusterSelectedMessageID = "member-cluster-selected"
+	// ResourceDeniedMessageID denies a user modifying a fleet guarded resource.
+	// Data: user, groups, operation, kind, subResource, namespacedName.
+	ResourceDeniedMessageID = "resource-denied"
+	// AddFleetAnnotationMessageID denies adding a fleet prefixed annotation to an upstream member cluster.
+	AddFleetAnnotationMessageID = "add-fleet-annotation"
+	// RemoveFleetAnnotationMessageID denies removing all the fleet prefixed annotations from a fleet member cluster.
+	RemoveFleetAnnotationMessageID = "remove-fleet-annotation"
+	// RemoveFleetFinalizerMessageID denies a user removing the fleet finalizers of a ClusterResourcePlacement.
+	// Data: user, groups, finalizers, name.
+	RemoveFleetFinalizerMessageID = "remove-fleet-finalizer"
+	// ResourcePlacementReachMessageID denies a user creating or updating a ResourcePlacement whose selectors reach
+	// beyond the namespace of the request. Data: user, groups, namespace, name, reaches.
+	ResourcePlacementReachMessageID = "resource-placement-reach"
+	// ModifyMemberClusterLabelsMessageID denies modifying the labels of a member cluster through the hub cluster.
+	ModifyMemberClusterLabelsMessageID = "modify-member-cluster-labels"
+	// PodCreationMessageID denies creating a pod in the hub cluster. Data: namespace, name.
+	PodCreationMessageID = "pod-creation"
+	// ReplicaSetCreationMessageID denies creating a ReplicaSet in the hub cluster. Data: namespace, name.
+	ReplicaSetCreationMessageID = "replicaset-creation"
+)
+
+// defaultDenialMessageTemplates are the templates used for the denial messages which are not customized.
+var defaultDenialMessageTemplates = map[string]string{
+	PlacementTypeImmutableMessageID:    "placement type is immutable",
+	PlacementInvalidFieldsMessageID:    "deny create/update v1beta1 {{.resourceType}} has invalid fields {{.error}}",
+	PlacementOldInvalidFieldsMessageID: "deny update on old invalid v1beta1 {{.resourceType}} with DeletionTimestamp not set {{.error}}",
+	PlacementNameCollisionMessageID: "the name {{printf \"%q\" .name}} is already used by {{.conflicts}}; a ClusterResourcePlacement and a ResourcePlacement cannot share a name " +
+		"as the objects they generate on the member clusters could collide. If both are created at the same time, the ClusterResourcePlacement takes precedence and the ResourcePlacement must be deleted",
+	PlacementClusterNamesUnavailableMessageID: "{{.reasons}}, the resources would never be placed on them",
+	PlacementNumberOfClustersUnschedulableMessageID: "spec.policy.numberOfClusters requests {{.requested}} cluster(s) but only {{.available}} member cluster(s) " +
+		"have joined or are joining the fleet, the placement would never be fully scheduled",
+	PlacementPickAllFleetSizeMessageID: "the PickAll placement without a required cluster affinity would select all the {{.clusters}} member clusters of the fleet, above the threshold of {{.threshold}}; " +
+		"use a PickN policy or a required cluster affinity to scope it, or add the annotation {{.annotation}}: \"true\" to confirm",
+	PlacementOverrideConflictMessageID: "the placement selects the same resources on the same clusters as other clusterResourcePlacement(s) with different clusterResourceOverrides, " +
+		"which conflict when the resources are applied: {{.conflicts}}",
+	PlacementRollbackRevisionNotFoundMessageID: "revision {{.revision}} of clusterResourcePlacement {{.name}} is not found, the available revisions are [{{.revisions}}]; " +
+		"please annotate the CRP with one of them to roll back",
+	PlacementRolloutPausedMessageID: "the placement spec cannot be updated while its rollout is paused by the annotation {{.annotation}}: \"true\", as the change would be rolled out on unpause; " +
+		"please unpause the rollout first by removing the annotation, or remove it in the same update",
+	PlacementIncidentWindowMessageID: "the placement spec cannot be updated during the incident window: {{.reason}}; " +
+		"for an emergency change, add the annotation {{.annotation}}: \"true\" in the same update, after removing the one left by an earlier bypass",
+	SecretPropagationOptInMessageID: "the placement selects {{.selections}}, which propagates Secrets to the member clusters; " +
+		"add the annotation {{.annotation}}: \"true\" to allow the propagation of Secrets",
+	CRDCoSelectionMessageID: "the placement selects {{.selections}}, whose custom resources fail to apply on the member clusters where they are applied " +
+		"before the CustomResourceDefinition is established; please select the CustomResourceDefinition in a separate placement which is rolled out first",
+	TeamQuotaExhaustedMessageID: "the CRP quota of team {{printf \"%q\" .team}} (label {{.label}}) is exhausted with {{.remaining}} CRP(s) remaining, " +
+		"please delete the unused CRPs of the team or ask the fleet administrator to raise the quota",
+	OwnedPolicySnapshotsMessageID: "clusterSchedulingPolicySnapshot(s) {{.snapshots}} are still owned by a previously deleted clusterResourcePlacement named {{.name}}; " +
+		"please wait for them to be cleaned up or delete them before creating the placement",
+	NamespaceTerminatingMessageID:         "the RP cannot be created in namespace {{.namespace}} as the namespace is terminating",
+	ClusterResourceOverrideLimitMessageID: "clusterResourceOverride limit has been reached: at most {{.limit}} cluster resources can be created.",
+	ResourceOverrideLimitMessageID:        "resourceOverride limit has been reached: at most {{.limit}} resources can be created.",
+	EvictionSpecImmutableMessageID:        "the spec of clusterResourcePlacementEviction is immutable",
+	EvictionClusterNotSelectedMessageID:   "cluster {{.cluster}} is not selected by the latest scheduling decision of clusterResourcePlacement {{.placement}}, the eviction would have no effect",
+	MemberClusterServiceExportMessageID:   "Please delete serviceExport {{.serviceExport}} in the member cluster before leaving, request is denied",
+	MemberClusterSelectedMessageID: "member cluster {{.memberCluster}} is still selected by {{.count}} clusterResourcePlacement(s): {{.placements}}{{if .more}} and {{.more}} more{{end}}; " +
+		"please update the placements before leaving, or set the annotation {{.forceDeleteAnnotation}}=true to force the deletion, request is denied",
+	RemoveFleetFinalizerMessageID: "user: '{{.user}}' in '{{.groups}}' is not allowed to remove the fleet finalizer(s) {{.finalizers}} from clusterResourcePlacement {{.name}}; " +
+		"they are removed by the fleet controllers once the placed resources are cleaned up from the member clusters",
+	ResourcePlacementReachMessageID: "user: '{{.user}}' in '{{.groups}}' is not allowed to place resources outside of namespace {{.namespace}} with resourcePlacement {{.name}}: {{.reaches}}; " +
+		"only the fleet administrators can select cluster-scoped resources or the resources of other namespaces",
+	ResourceDeniedMessageID:            "user: '{{.user}}' in '{{.groups}}' is not allowed to {{.operation}} resource {{.kind}}/{{.subResource}}: {{.namespacedName}}",
+	AddFleetAnnotationMessageID:        "no user is allowed to add a fleet pre-fixed annotation to an upstream member cluster",
+	RemoveFleetAnnotationMessageID:     "no user is allowed to remove all fleet pre-fixed annotations from a fleet member cluster",
+	ModifyMemberClusterLabelsMessageID: "users are not allowed to modify labels through hub cluster directly",
+	PodCreationMessageID:               "Pod {{.namespace}}/{{.name}} creation is disallowed in the fleet hub cluster",
+	ReplicaSetCreationMessageID:        "ReplicaSet {{.namespace}}/{{.name}} creation is disallowed in the fleet hub cluster.",
+}
+
+// parsedDefaultDenialMessageTemplates are the parsed defaultDenialMessageTemplates.
+var parsedDefaultDenialMessageTemplates = func() map[string]*template.Template {
+	parsed := make(map[string]*template.Template, len(defaultDenialMessageTemplates))
+	for id, text := range defaultDenialMessageTemplates {
+		parsed[id] = template.Must(parseDenialMessageTemplate(id, text))
+	}
+	return parsed
+}()
+
+// DefaultDenialMessageTemplate returns the default template of the denial message with the given ID.
+func DefaultDenialMessageTemplate(id string) (string, bool) {
+	text, ok := defaultDenialMessageTemplates[id]
+	return text, ok
+}
+
+// ValidateDenialMessageTemplate returns an error if the ID is not a known denial message or the text is not a
+// valid template.
+func ValidateDenialMessageTemplate(id, text string) error {
+	if _, ok := defaultDenialMessageTemplates[id]; !ok {
+		return fmt.Errorf("unknown denial message ID %q", id)
+	}
+	if _, err := parseDenialMessageTemplate(id, text); err != nil {
+		return fmt.Errorf("invalid template of denial message %q: %w", id, err)
+	}
+	return nil
+}
+
+// DenialMessage renders the denial message with the given ID. The template in DenialMessageTemplates is used if it
+// is set; the default template is used otherwise, or if the custom one fails to render, e.g., as it refers to a key
+// missing from the data.
+func (c Config) DenialMessage(id string, data map[string]any) string {
+	if text, ok := c.DenialMessageTemplates[id]; ok {
+		msg, err := renderCustomDenialMessage(id, text, data)
+		if err == nil {
+			return msg
+		}
+		klog.ErrorS(err, "Failed to render the custom denial message template, using the default one", "messageID", id)
+	}
+	tmpl, ok := parsedDefaultDenialMessageTemplates[id]
+	if !ok {
+		// Never happens as every ID used by the handlers has a default template.
+		klog.ErrorS(fmt.Errorf("unknown denial message ID %q", id), "Failed to find the denial message template")
+		return id
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		klog.ErrorS(err, "Failed to render the default denial message template", "messageID", id)
+		return id
+	}
+	return b.String()
+}
+
+// renderCustomDenialMessage renders a custom denial message template.
+func renderCustomDenialMessage(id, text string, data map[string]any) (string, error) {
+	tmpl, err := parseDenialMessageTemplate(id, text)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// parseDenialMessageTemplate parses a denial message template. Referring to a key missing from the data is an
+// error so that a typo in a custom template falls back to the default message instead of rendering "<no value>".
+func parseDenialMessageTemplate(id, text string) (*template.Template, error) {
+	return template.New(id).Option("missingkey=error").Parse(text)
+}
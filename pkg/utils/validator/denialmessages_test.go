@@ -0,0 +1,133 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+func TestDenialMessage(t *testing.T) {
+	data := map[string]any{"namespace": "test-ns", "name": "test-pod"}
+	testCases := map[string]struct {
+		templates map[string]string
+		id        string
+		data      map[string]any
+		want      string
+	}{
+		"default template without data": {
+			id:   PlacementTypeImmutableMessageID,
+			want: "placement type is immutable",
+		},
+		"default template with data": {
+			id:   PodCreationMessageID,
+			data: data,
+			want: "Pod test-ns/test-pod creation is disallowed in the fleet hub cluster",
+		},
+		"default template when only other messages are customized": {
+			templates: map[string]string{ReplicaSetCreationMessageID: "no ReplicaSets here"},
+			id:        PodCreationMessageID,
+			data:      data,
+			want:      "Pod test-ns/test-pod creation is disallowed in the fleet hub cluster",
+		},
+		"custom template": {
+			templates: map[string]string{PodCreationMessageID: "pods such as {{.name}} belong to the member clusters, see https://example.com/docs"},
+			id:        PodCreationMessageID,
+			data:      data,
+			want:      "pods such as test-pod belong to the member clusters, see https://example.com/docs",
+		},
+		"custom template with an optional section": {
+			templates: map[string]string{MemberClusterSelectedMessageID: "{{.memberCluster}} is used by {{.placements}}{{if .more}} (+{{.more}}){{end}}"},
+			id:        MemberClusterSelectedMessageID,
+			data:      map[string]any{"memberCluster": "member-1", "placements": "crp-1", "more": 0},
+			want:      "member-1 is used by crp-1",
+		},
+		"custom template which fails to parse falls back to the default": {
+			templates: map[string]string{PodCreationMessageID: "{{.name"},
+			id:        PodCreationMessageID,
+			data:      data,
+			want:      "Pod test-ns/test-pod creation is disallowed in the fleet hub cluster",
+		},
+		"custom template referring to a missing key falls back to the default": {
+			templates: map[string]string{PodCreationMessageID: "pod {{.podName}} is denied"},
+			id:        PodCreationMessageID,
+			data:      data,
+			want:      "Pod test-ns/test-pod creation is disallowed in the fleet hub cluster",
+		},
+		"unknown ID": {
+			id:   "unknown",
+			want: "unknown",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := Config{DenialMessageTemplates: tc.templates}
+			if got := config.DenialMessage(tc.id, tc.data); got != tc.want {
+				t.Errorf("DenialMessage() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateDenialMessageTemplate(t *testing.T) {
+	testCases := map[string]struct {
+		id      string
+		text    string
+		wantErr bool
+	}{
+		"valid template": {
+			id:   PlacementTypeImmutableMessageID,
+			text: "the placement type of {{.name}} cannot change",
+		},
+		"unknown ID": {
+			id:      "unknown",
+			text:    "denied",
+			wantErr: true,
+		},
+		"invalid template": {
+			id:      PlacementTypeImmutableMessageID,
+			text:    "{{if .name}}",
+			wantErr: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if err := ValidateDenialMessageTemplate(tc.id, tc.text); (err != nil) != tc.wantErr {
+				t.Errorf("ValidateDenialMessageTemplate() = %v, want error %t", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidatePlacementTypeImmutableDenialMessage(t *testing.T) {
+	oldCRP := &placementv1beta1.ClusterResourcePlacement{
+		Spec: placementv1beta1.PlacementSpec{Policy: &placementv1beta1.PlacementPolicy{PlacementType: placementv1beta1.PickAllPlacementType}},
+	}
+	crp := oldCRP.DeepCopy()
+	crp.Spec.Policy = &placementv1beta1.PlacementPolicy{PlacementType: placementv1beta1.PickNPlacementType, NumberOfClusters: ptr.To[int32](1)}
+
+	config := Config{DenialMessageTemplates: map[string]string{PlacementTypeImmutableMessageID: "delete and recreate the placement to change its type"}}
+	want := "delete and recreate the placement to change its type"
+	if err := validatePlacementTypeImmutable(context.Background(), config, admission.Request{}, crp, oldCRP); err == nil || err.Error() != want {
+		t.Errorf("validatePlacementTypeImmutable() = %v, want %s", err, want)
+	}
+}
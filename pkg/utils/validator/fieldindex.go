@@ -0,0 +1,139 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+// The keys of the field indexes which the client-backed checks look objects up by, so that a lookup reads only the
+// objects which reference the given name instead of listing every object of the kind in the admission path. The
+// webhooks register the indexes with the manager from their Add functions.
+const (
+	// UpdateRunPlacementNameIndexKey indexes the staged update runs by the name of the placement they reference.
+	UpdateRunPlacementNameIndexKey = "spec.placementName"
+	// EvictionPlacementClusterIndexKey indexes the evictions by the placement and the cluster they target, see
+	// EvictionPlacementClusterIndexValue.
+	EvictionPlacementClusterIndexKey = "spec.placementName.clusterName"
+	// PolicySnapshotPlacementIndexKey indexes the scheduling policy snapshots by the name of the placement they track.
+	PolicySnapshotPlacementIndexKey = "metadata.labels.placementTracking"
+	// PlacementNameIndexKey indexes the resource placements by their names, which are only unique per namespace.
+	PlacementNameIndexKey = "metadata.name"
+)
+
+// IndexUnavailableError is the error of a lookup which cannot be served from a field index, e.g., the cache has not
+// been started yet or the index is not registered.
+type IndexUnavailableError struct {
+	// Index is the key of the field index, e.g., UpdateRunPlacementNameIndexKey.
+	Index string
+	// Err is the error returned by the client.
+	Err error
+}
+
+func (e *IndexUnavailableError) Error() string {
+	return fmt.Sprintf("validation unavailable: the field index %s cannot be read, please retry the request: %v", e.Index, e.Err)
+}
+
+func (e *IndexUnavailableError) Unwrap() error {
+	return e.Err
+}
+
+// ListByIndex lists the objects whose field index matches the value into list. A *IndexUnavailableError is returned
+// if the index cannot be read, instead of falling back to listing every object of the kind.
+func ListByIndex(ctx context.Context, c client.Reader, list client.ObjectList, index, value string, opts ...client.ListOption) error {
+	opts = append(opts, client.MatchingFields{index: value})
+	if err := c.List(ctx, list, opts...); err != nil {
+		if isIndexUnavailable(err) {
+			return &IndexUnavailableError{Index: index, Err: err}
+		}
+		return err
+	}
+	return nil
+}
+
+// isIndexUnavailable returns true if the list failed because the cache has not been started or the index is not
+// registered. The client returns no typed error for the latter, so it is told by the message, which both the cache
+// and the fake client phrase as "index with name ...".
+func isIndexUnavailable(err error) bool {
+	var notStarted *cache.ErrCacheNotStarted
+	if errors.As(err, &notStarted) {
+		return true
+	}
+	return strings.Contains(err.Error(), "index with name")
+}
+
+// IndexUpdateRunsByPlacementName registers UpdateRunPlacementNameIndexKey for the kind of the staged update run with
+// the field indexer. The index is skipped if the API of the kind is not installed, in which case there is no update
+// run to look up.
+func IndexUpdateRunsByPlacementName(ctx context.Context, indexer client.FieldIndexer, updateRun client.Object) error {
+	if err := indexer.IndexField(ctx, updateRun, UpdateRunPlacementNameIndexKey, UpdateRunPlacementNameIndexer); err != nil {
+		if meta.IsNoMatchError(err) {
+			klog.V(2).InfoS("Skipping the placement name index of the staged update runs as their API is not installed", "kind", fmt.Sprintf("%T", updateRun))
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// UpdateRunPlacementNameIndexer returns the name of the placement a staged update run references.
+func UpdateRunPlacementNameIndexer(obj client.Object) []string {
+	updateRun, ok := obj.(placementv1beta1.UpdateRunObj)
+	if !ok || updateRun.GetUpdateRunSpec().PlacementName == "" {
+		return nil
+	}
+	return []string{updateRun.GetUpdateRunSpec().PlacementName}
+}
+
+// EvictionPlacementClusterIndexValue returns the value of EvictionPlacementClusterIndexKey of the evictions which
+// target the cluster of the placement.
+func EvictionPlacementClusterIndexValue(placementName, clusterName string) string {
+	return placementName + "/" + clusterName
+}
+
+// EvictionPlacementClusterIndexer returns the placement and the cluster a cluster resource placement eviction targets.
+func EvictionPlacementClusterIndexer(obj client.Object) []string {
+	eviction, ok := obj.(*placementv1beta1.ClusterResourcePlacementEviction)
+	if !ok {
+		return nil
+	}
+	return []string{EvictionPlacementClusterIndexValue(eviction.Spec.PlacementName, eviction.Spec.ClusterName)}
+}
+
+// PolicySnapshotPlacementIndexer returns the name of the placement a scheduling policy snapshot tracks.
+func PolicySnapshotPlacementIndexer(obj client.Object) []string {
+	placementName := obj.GetLabels()[placementv1beta1.PlacementTrackingLabel]
+	if placementName == "" {
+		return nil
+	}
+	return []string{placementName}
+}
+
+// PlacementNameIndexer returns the name of a placement.
+func PlacementNameIndexer(obj client.Object) []string {
+	return []string{obj.GetName()}
+}
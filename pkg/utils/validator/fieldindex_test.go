@@ -0,0 +1,233 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+// newIndexedClientBuilder returns a fake client builder with the scheme and the field indexes of the validator.
+func newIndexedClientBuilder(scheme *runtime.Scheme) *fake.ClientBuilder {
+	return fake.NewClientBuilder().WithScheme(scheme).
+		WithIndex(&placementv1beta1.ClusterStagedUpdateRun{}, UpdateRunPlacementNameIndexKey, UpdateRunPlacementNameIndexer).
+		WithIndex(&placementv1beta1.StagedUpdateRun{}, UpdateRunPlacementNameIndexKey, UpdateRunPlacementNameIndexer).
+		WithIndex(&placementv1beta1.ClusterResourcePlacementEviction{}, EvictionPlacementClusterIndexKey, EvictionPlacementClusterIndexer).
+		WithIndex(&placementv1beta1.ClusterSchedulingPolicySnapshot{}, PolicySnapshotPlacementIndexKey, PolicySnapshotPlacementIndexer).
+		WithIndex(&placementv1beta1.ResourcePlacement{}, PlacementNameIndexKey, PlacementNameIndexer)
+}
+
+func TestListByIndex(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() = %v, want no error", err)
+	}
+	newEviction := func(name, placementName, clusterName string) *placementv1beta1.ClusterResourcePlacementEviction {
+		return &placementv1beta1.ClusterResourcePlacementEviction{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       placementv1beta1.PlacementEvictionSpec{PlacementName: placementName, ClusterName: clusterName},
+		}
+	}
+	evictions := []client.Object{
+		newEviction("evict-1", "crp-1", "member-1"),
+		newEviction("evict-2", "crp-1", "member-2"),
+		newEviction("evict-3", "crp-2", "member-1"),
+		newEviction("evict-4", "crp-1", "member-1"),
+	}
+	listErr := errors.New("list failed")
+
+	testCases := map[string]struct {
+		builder         *fake.ClientBuilder
+		wantNames       []string
+		wantUnavailable bool
+		wantErr         error
+	}{
+		"lookup by the index": {
+			builder:   newIndexedClientBuilder(scheme).WithObjects(evictions...),
+			wantNames: []string{"evict-1", "evict-4"},
+		},
+		"no object matches the index": {
+			builder: newIndexedClientBuilder(scheme),
+		},
+		"index is not registered": {
+			builder:         fake.NewClientBuilder().WithScheme(scheme).WithObjects(evictions...),
+			wantUnavailable: true,
+		},
+		"cache is not started": {
+			builder: newIndexedClientBuilder(scheme).WithInterceptorFuncs(interceptor.Funcs{
+				List: func(context.Context, client.WithWatch, client.ObjectList, ...client.ListOption) error {
+					return &cache.ErrCacheNotStarted{}
+				},
+			}),
+			wantUnavailable: true,
+		},
+		"list fails": {
+			builder: newIndexedClientBuilder(scheme).WithInterceptorFuncs(interceptor.Funcs{
+				List: func(context.Context, client.WithWatch, client.ObjectList, ...client.ListOption) error {
+					return listErr
+				},
+			}),
+			wantErr: listErr,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			list := &placementv1beta1.ClusterResourcePlacementEvictionList{}
+			err := ListByIndex(context.Background(), tc.builder.Build(), list, EvictionPlacementClusterIndexKey, EvictionPlacementClusterIndexValue("crp-1", "member-1"))
+			var unavailable *IndexUnavailableError
+			if gotUnavailable := errors.As(err, &unavailable); gotUnavailable != tc.wantUnavailable {
+				t.Fatalf("ListByIndex() = %v, want an IndexUnavailableError: %t", err, tc.wantUnavailable)
+			}
+			if tc.wantUnavailable {
+				if unavailable.Index != EvictionPlacementClusterIndexKey {
+					t.Errorf("ListByIndex() index = %s, want %s", unavailable.Index, EvictionPlacementClusterIndexKey)
+				}
+				return
+			}
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("ListByIndex() = %v, want %v", err, tc.wantErr)
+			}
+			var gotNames []string
+			for i := range list.Items {
+				gotNames = append(gotNames, list.Items[i].Name)
+			}
+			if diff := cmp.Diff(tc.wantNames, gotNames); diff != "" {
+				t.Errorf("ListByIndex() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFieldIndexers(t *testing.T) {
+	testCases := map[string]struct {
+		indexer client.IndexerFunc
+		obj     client.Object
+		want    []string
+	}{
+		"cluster staged update run": {
+			indexer: UpdateRunPlacementNameIndexer,
+			obj:     &placementv1beta1.ClusterStagedUpdateRun{Spec: placementv1beta1.UpdateRunSpec{PlacementName: "crp-1"}},
+			want:    []string{"crp-1"},
+		},
+		"staged update run": {
+			indexer: UpdateRunPlacementNameIndexer,
+			obj:     &placementv1beta1.StagedUpdateRun{Spec: placementv1beta1.UpdateRunSpec{PlacementName: "rp-1"}},
+			want:    []string{"rp-1"},
+		},
+		"update run without a placement": {
+			indexer: UpdateRunPlacementNameIndexer,
+			obj:     &placementv1beta1.ClusterStagedUpdateRun{},
+		},
+		"update run indexer on another kind": {
+			indexer: UpdateRunPlacementNameIndexer,
+			obj:     &placementv1beta1.ClusterResourcePlacement{},
+		},
+		"eviction": {
+			indexer: EvictionPlacementClusterIndexer,
+			obj: &placementv1beta1.ClusterResourcePlacementEviction{
+				Spec: placementv1beta1.PlacementEvictionSpec{PlacementName: "crp-1", ClusterName: "member-1"},
+			},
+			want: []string{"crp-1/member-1"},
+		},
+		"eviction indexer on another kind": {
+			indexer: EvictionPlacementClusterIndexer,
+			obj:     &placementv1beta1.ClusterResourcePlacement{},
+		},
+		"tracked policy snapshot": {
+			indexer: PolicySnapshotPlacementIndexer,
+			obj: &placementv1beta1.ClusterSchedulingPolicySnapshot{ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{placementv1beta1.PlacementTrackingLabel: "crp-1"},
+			}},
+			want: []string{"crp-1"},
+		},
+		"untracked policy snapshot": {
+			indexer: PolicySnapshotPlacementIndexer,
+			obj:     &placementv1beta1.ClusterSchedulingPolicySnapshot{},
+		},
+		"placement name": {
+			indexer: PlacementNameIndexer,
+			obj:     &placementv1beta1.ResourcePlacement{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "apps"}},
+			want:    []string{"web"},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.indexer(tc.obj)); diff != "" {
+				t.Errorf("indexer() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// fakeFieldIndexer records the registered indexes, or fails with the error if it is set.
+type fakeFieldIndexer struct {
+	err     error
+	indexed []string
+}
+
+func (f *fakeFieldIndexer) IndexField(_ context.Context, obj client.Object, field string, _ client.IndexerFunc) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.indexed = append(f.indexed, field)
+	return nil
+}
+
+func TestIndexUpdateRunsByPlacementName(t *testing.T) {
+	indexErr := errors.New("index failed")
+	testCases := map[string]struct {
+		indexer     *fakeFieldIndexer
+		wantIndexed []string
+		wantErr     error
+	}{
+		"index is registered": {
+			indexer:     &fakeFieldIndexer{},
+			wantIndexed: []string{UpdateRunPlacementNameIndexKey},
+		},
+		"update run API is not installed": {
+			indexer: &fakeFieldIndexer{err: &meta.NoKindMatchError{GroupKind: schema.GroupKind{Group: placementv1beta1.GroupVersion.Group, Kind: "ClusterStagedUpdateRun"}}},
+		},
+		"registration fails": {
+			indexer: &fakeFieldIndexer{err: indexErr},
+			wantErr: indexErr,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := IndexUpdateRunsByPlacementName(context.Background(), tc.indexer, &placementv1beta1.ClusterStagedUpdateRun{})
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("IndexUpdateRunsByPlacementName() = %v, want %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.wantIndexed, tc.indexer.indexed); diff != "" {
+				t.Errorf("IndexUpdateRunsByPlacementName() indexes mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
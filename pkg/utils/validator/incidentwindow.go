@@ -0,0 +1,154 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils"
+)
+
+// IncidentBypassAnnotation is the annotation which, set to "true" by an update, allows that emergency spec update of
+// a placement during an incident window. The annotation left on the placement by an earlier update is not honored,
+// so that a single bypass does not exempt the placement from every later incident window.
+const IncidentBypassAnnotation = utils.FleetAnnotationPrefix + "/incident-bypass"
+
+// The keys of the ConfigMap data read by the ConfigMapIncidentWindowChecker.
+const (
+	// IncidentConfigKey is the reason of a declared incident; the fleet is in an incident window while it is set.
+	IncidentConfigKey = "incident"
+	// IncidentWindowsConfigKey is the JSON list of the scheduled IncidentWindows.
+	IncidentWindowsConfigKey = "windows"
+)
+
+// IncidentWindowChecker tells if the fleet is in an incident or maintenance window.
+type IncidentWindowChecker interface {
+	// IsInIncidentWindow returns true and the reason of the window if the fleet is in an incident window.
+	IsInIncidentWindow(ctx context.Context) (bool, string, error)
+}
+
+// ValidatePlacementIncidentWindow denies the spec updates of a placement allowed by resp while
+// Config.IncidentWindowChecker reports an incident window, unless the update sets the IncidentBypassAnnotation to
+// "true", i.e., the new placement carries it but the old one does not, in which case the bypass is logged and warned
+// about. The updates which leave the spec semantically unchanged, e.g., the finalizer removals of the controllers,
+// and the updates of a placement being deleted are allowed; oldPlacement is nil on creation, which is not checked.
+// The lookup is bounded by the lookup budget.
+func (c Config) ValidatePlacementIncidentWindow(ctx context.Context, placement, oldPlacement placementv1beta1.PlacementObj, resp admission.Response) admission.Response {
+	checker := c.IncidentWindowChecker
+	if checker == nil || oldPlacement == nil || !resp.Allowed || placement.GetDeletionTimestamp() != nil {
+		return resp
+	}
+	if unchanged, _ := PlacementSpecSemanticEqual(oldPlacement, placement); unchanged {
+		return resp
+	}
+	ctx, cancel := c.WithLookupBudget(ctx, 0)
+	defer cancel()
+	inWindow, reason, err := checker.IsInIncidentWindow(ctx)
+	if err != nil {
+		if exhausted := c.LookupBudgetExhausted(ctx, IncidentWindowLookupCheck, err); exhausted != nil {
+			return LookupUnavailableResponse(exhausted, resp)
+		}
+		klog.ErrorS(err, "Failed to check the incident window for the placement", "placement", klog.KObj(placement))
+		return admission.Errored(http.StatusInternalServerError, fmt.Errorf("failed to check the incident window, please retry the request: %w", err))
+	}
+	if !inWindow {
+		return resp
+	}
+	if placement.GetAnnotations()[IncidentBypassAnnotation] == "true" && oldPlacement.GetAnnotations()[IncidentBypassAnnotation] != "true" {
+		klog.InfoS("Placement spec update bypasses the incident window", "placement", klog.KObj(placement), "reason", reason)
+		return resp.WithWarnings(fmt.Sprintf("the placement spec is updated during the incident window (%s) through the annotation %s", reason, IncidentBypassAnnotation))
+	}
+	klog.V(2).InfoS("Placement spec update during the incident window, request is denied", "placement", klog.KObj(placement), "reason", reason)
+	return admission.Denied(c.DenialMessage(PlacementIncidentWindowMessageID, map[string]any{"reason": reason, "annotation": IncidentBypassAnnotation}))
+}
+
+// IncidentWindow is a scheduled window, e.g., a maintenance, during which the placement spec updates are denied.
+type IncidentWindow struct {
+	// Start is the RFC 3339 time the window starts at.
+	Start time.Time `json:"start"`
+	// End is the RFC 3339 time the window ends at, which is excluded.
+	End time.Time `json:"end"`
+	// Reason is the reason of the window returned to the users.
+	Reason string `json:"reason,omitempty"`
+}
+
+// ConfigMapIncidentWindowChecker reads the incident windows from a ConfigMap. The fleet is in an incident window
+// while the IncidentConfigKey is set, or during any of the IncidentWindows listed under the
+// IncidentWindowsConfigKey, e.g., [{"start": "2026-01-01T00:00:00Z", "end": "2026-01-01T04:00:00Z",
+// "reason": "hub upgrade"}]. The fleet is never in an incident window if the ConfigMap does not exist.
+type ConfigMapIncidentWindowChecker struct {
+	reader client.Reader
+	key    types.NamespacedName
+	// now returns the current time.
+	now func() time.Time
+}
+
+// NewConfigMapIncidentWindowChecker returns an IncidentWindowChecker reading the named ConfigMap.
+func NewConfigMapIncidentWindowChecker(reader client.Reader, namespace, name string) *ConfigMapIncidentWindowChecker {
+	return &ConfigMapIncidentWindowChecker{
+		reader: reader,
+		key:    types.NamespacedName{Namespace: namespace, Name: name},
+		now:    time.Now,
+	}
+}
+
+// IsInIncidentWindow returns true and the reason of the window if an incident is declared or a scheduled window
+// is ongoing. An invalid list of windows is an error, so that a mistyped schedule is not silently ignored.
+func (c *ConfigMapIncidentWindowChecker) IsInIncidentWindow(ctx context.Context) (bool, string, error) {
+	var cm corev1.ConfigMap
+	if err := c.reader.Get(ctx, c.key, &cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, "", nil
+		}
+		return false, "", fmt.Errorf("failed to get the incident window ConfigMap %s: %w", c.key, err)
+	}
+	if reason := strings.TrimSpace(cm.Data[IncidentConfigKey]); reason != "" {
+		return true, reason, nil
+	}
+	raw := strings.TrimSpace(cm.Data[IncidentWindowsConfigKey])
+	if raw == "" {
+		return false, "", nil
+	}
+	var windows []IncidentWindow
+	if err := json.Unmarshal([]byte(raw), &windows); err != nil {
+		return false, "", fmt.Errorf("invalid %s of the incident window ConfigMap %s: %w", IncidentWindowsConfigKey, c.key, err)
+	}
+	now := c.now()
+	for _, window := range windows {
+		if now.Before(window.Start) || !now.Before(window.End) {
+			continue
+		}
+		if window.Reason != "" {
+			return true, window.Reason, nil
+		}
+		return true, fmt.Sprintf("scheduled window from %s to %s", window.Start.Format(time.RFC3339), window.End.Format(time.RFC3339)), nil
+	}
+	return false, "", nil
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+func TestConfigMapIncidentWindowChecker(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() = %v, want no error", err)
+	}
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	newConfigMap := func(data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "fleet-system", Name: "incident-windows"}, Data: data}
+	}
+	testCases := map[string]struct {
+		configMap    *corev1.ConfigMap
+		getErr       error
+		wantInWindow bool
+		wantReason   string
+		wantErr      bool
+	}{
+		"no ConfigMap": {},
+		"no incident nor window": {
+			configMap: newConfigMap(nil),
+		},
+		"declared incident": {
+			configMap:    newConfigMap(map[string]string{IncidentConfigKey: " region outage "}),
+			wantInWindow: true,
+			wantReason:   "region outage",
+		},
+		"declared incident takes precedence over the windows": {
+			configMap:    newConfigMap(map[string]string{IncidentConfigKey: "region outage", IncidentWindowsConfigKey: "invalid"}),
+			wantInWindow: true,
+			wantReason:   "region outage",
+		},
+		"ongoing window": {
+			configMap: newConfigMap(map[string]string{IncidentWindowsConfigKey: `[
+				{"start": "2026-10-15T00:00:00Z", "end": "2026-10-15T04:00:00Z", "reason": "past upgrade"},
+				{"start": "2026-10-16T10:00:00Z", "end": "2026-10-16T14:00:00Z", "reason": "hub upgrade"}
+			]`}),
+			wantInWindow: true,
+			wantReason:   "hub upgrade",
+		},
+		"ongoing window without a reason": {
+			configMap:    newConfigMap(map[string]string{IncidentWindowsConfigKey: `[{"start": "2026-10-16T12:00:00Z", "end": "2026-10-16T14:00:00Z"}]`}),
+			wantInWindow: true,
+			wantReason:   "scheduled window from 2026-10-16T12:00:00Z to 2026-10-16T14:00:00Z",
+		},
+		"window ending now": {
+			configMap: newConfigMap(map[string]string{IncidentWindowsConfigKey: `[{"start": "2026-10-16T10:00:00Z", "end": "2026-10-16T12:00:00Z"}]`}),
+		},
+		"future window": {
+			configMap: newConfigMap(map[string]string{IncidentWindowsConfigKey: `[{"start": "2026-10-17T00:00:00Z", "end": "2026-10-17T04:00:00Z"}]`}),
+		},
+		"invalid windows": {
+			configMap: newConfigMap(map[string]string{IncidentWindowsConfigKey: `[{"start": "tomorrow"}]`}),
+			wantErr:   true,
+		},
+		"ConfigMap lookup failure": {
+			getErr:  errors.New("lookup failed"),
+			wantErr: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			if tc.configMap != nil {
+				builder = builder.WithObjects(tc.configMap)
+			}
+			if tc.getErr != nil {
+				builder = builder.WithInterceptorFuncs(interceptor.Funcs{
+					Get: func(context.Context, client.WithWatch, client.ObjectKey, client.Object, ...client.GetOption) error {
+						return tc.getErr
+					},
+				})
+			}
+			checker := NewConfigMapIncidentWindowChecker(builder.Build(), "fleet-system", "incident-windows")
+			checker.now = func() time.Time { return now }
+			inWindow, reason, err := checker.IsInIncidentWindow(context.Background())
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("IsInIncidentWindow() error = %v, want error %t", err, tc.wantErr)
+			}
+			if inWindow != tc.wantInWindow || reason != tc.wantReason {
+				t.Errorf("IsInIncidentWindow() = (%t, %q), want (%t, %q)", inWindow, reason, tc.wantInWindow, tc.wantReason)
+			}
+		})
+	}
+}
+
+// blockingIncidentWindowChecker blocks until the context is done.
+type blockingIncidentWindowChecker struct{}
+
+func (blockingIncidentWindowChecker) IsInIncidentWindow(ctx context.Context) (bool, string, error) {
+	<-ctx.Done()
+	return false, "", ctx.Err()
+}
+
+func TestValidatePlacementIncidentWindowLookupBudget(t *testing.T) {
+	newCRP := func(numberOfClusters int32) *placementv1beta1.ClusterResourcePlacement {
+		return &placementv1beta1.ClusterResourcePlacement{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-crp"},
+			Spec: placementv1beta1.PlacementSpec{Policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:    placementv1beta1.PickNPlacementType,
+				NumberOfClusters: &numberOfClusters,
+			}},
+		}
+	}
+	testCases := map[string]struct {
+		policy       LookupFailurePolicy
+		wantAllowed  bool
+		wantWarnings bool
+	}{
+		"fails closed by default": {},
+		"fails open": {
+			policy:       LookupFailOpen,
+			wantAllowed:  true,
+			wantWarnings: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := Config{IncidentWindowChecker: blockingIncidentWindowChecker{}, LookupBudget: 10 * time.Millisecond}
+			if tc.policy != "" {
+				config.LookupFailurePolicies = map[string]LookupFailurePolicy{IncidentWindowLookupCheck: tc.policy}
+			}
+			resp := config.ValidatePlacementIncidentWindow(context.Background(), newCRP(3), newCRP(2), admission.Allowed(""))
+			if resp.Allowed != tc.wantAllowed {
+				t.Errorf("ValidatePlacementIncidentWindow() allowed = %t, want %t", resp.Allowed, tc.wantAllowed)
+			}
+			if diff := cmp.Diff(tc.wantWarnings, len(resp.Warnings) > 0); diff != "" {
+				t.Errorf("ValidatePlacementIncidentWindow() warnings %v mismatch (-want, +got):\n%s", resp.Warnings, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	apiErrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// LabelSchema is the format the value of a label must have. The empty fields are not checked.
+type LabelSchema struct {
+	// AllowedValues is the list of values the label can have.
+	AllowedValues []string
+	// MaxLength is the maximum number of characters of the label value.
+	MaxLength int
+	// Regex is the regular expression the label value must match.
+	Regex *regexp.Regexp
+}
+
+// labelSchemaJSON is the JSON form of a LabelSchema accepted by ParseLabelSchemas.
+type labelSchemaJSON struct {
+	AllowedValues []string `json:"allowedValues,omitempty"`
+	MaxLength     int      `json:"maxLength,omitempty"`
+	Regex         string   `json:"regex,omitempty"`
+}
+
+// ParseLabelSchemas parses a JSON object mapping the label keys of MemberClusters to their schemas, e.g.,
+// {"region": {"allowedValues": ["eastus", "westus"]}, "tier": {"maxLength": 10, "regex": "^tier-[0-9]+$"}}.
+// No label is checked if the string is empty.
+func ParseLabelSchemas(str string) (map[string]*LabelSchema, error) {
+	if str == "" {
+		return nil, nil
+	}
+	var parsed map[string]labelSchemaJSON
+	if err := json.Unmarshal([]byte(str), &parsed); err != nil {
+		return nil, fmt.Errorf("invalid label schemas, must be a JSON object mapping label keys to schemas: %w", err)
+	}
+	schemas := make(map[string]*LabelSchema, len(parsed))
+	for k, s := range parsed {
+		if errs := validation.IsQualifiedName(k); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid label key %q of the label schemas: %s", k, strings.Join(errs, "; "))
+		}
+		if s.MaxLength < 0 {
+			return nil, fmt.Errorf("invalid max length %d of the schema of label %q, it must not be negative", s.MaxLength, k)
+		}
+		schema := &LabelSchema{AllowedValues: s.AllowedValues, MaxLength: s.MaxLength}
+		if s.Regex != "" {
+			re, err := regexp.Compile(s.Regex)
+			if err != nil {
+				return nil, fmt.Errorf("invalid regular expression of the schema of label %q: %w", k, err)
+			}
+			schema.Regex = re
+		}
+		schemas[k] = schema
+	}
+	return schemas, nil
+}
+
+// ValidateLabelSchemas checks that the labels added or updated from oldLabels match their schemas in
+// LabelSchemas, so that the existing labels which no longer match their schemas do not block unrelated
+// updates. All the labels are checked if oldLabels is nil, e.g., on create.
+func (c Config) ValidateLabelSchemas(labels, oldLabels map[string]string) error {
+	schemas := c.LabelSchemas
+	keys := make([]string, 0, len(labels))
+	for k, v := range labels {
+		if _, ok := schemas[k]; !ok {
+			continue
+		}
+		if oldValue, ok := oldLabels[k]; ok && oldValue == v {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	allErr := make([]error, 0)
+	for _, k := range keys {
+		allErr = append(allErr, schemas[k].validate(k, labels[k])...)
+	}
+	return apiErrors.NewAggregate(allErr)
+}
+
+// validate returns the ways the value of the label does not match the schema.
+func (s *LabelSchema) validate(key, value string) []error {
+	if s == nil {
+		return nil
+	}
+	var errs []error
+	if len(s.AllowedValues) > 0 && !slices.Contains(s.AllowedValues, value) {
+		errs = append(errs, fmt.Errorf("the value %q of label %q is not allowed, it must be one of [%s]", value, key, strings.Join(s.AllowedValues, ", ")))
+	}
+	if length := utf8.RuneCountInString(value); s.MaxLength > 0 && length > s.MaxLength {
+		errs = append(errs, fmt.Errorf("the value %q of label %q has %d characters, it must have at most %d characters", value, key, length, s.MaxLength))
+	}
+	if s.Regex != nil && !s.Regex.MatchString(value) {
+		errs = append(errs, fmt.Errorf("the value %q of label %q does not match the expected format %q", value, key, s.Regex.String()))
+	}
+	return errs
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestValidateLabelSchemas(t *testing.T) {
+	config := Config{
+		LabelSchemas: map[string]*LabelSchema{
+			"region": {AllowedValues: []string{"eastus", "westus"}},
+			"owner":  {MaxLength: 5},
+			"tier":   {Regex: regexp.MustCompile(`^tier-[0-9]+$`)},
+			"zone":   {AllowedValues: []string{"zone-1", "zone-100"}, MaxLength: 6, Regex: regexp.MustCompile(`^zone-[0-9]+$`)},
+		},
+	}
+	testCases := map[string]struct {
+		labels    map[string]string
+		oldLabels map[string]string
+		wantErrs  []string
+	}{
+		"labels matching their schemas": {
+			labels: map[string]string{"region": "eastus", "owner": "team", "tier": "tier-1", "zone": "zone-1"},
+		},
+		"labels without a schema": {
+			labels: map[string]string{"anything": strings.Repeat("x", 100)},
+		},
+		"value not in the allowed values": {
+			labels:   map[string]string{"region": "centralus"},
+			wantErrs: []string{`the value "centralus" of label "region" is not allowed, it must be one of [eastus, westus]`},
+		},
+		"value longer than the max length": {
+			labels:   map[string]string{"owner": "team-a"},
+			wantErrs: []string{`the value "team-a" of label "owner" has 6 characters, it must have at most 5 characters`},
+		},
+		"max length counts characters instead of bytes": {
+			labels: map[string]string{"owner": "ééééé"},
+		},
+		"value not matching the regex": {
+			labels:   map[string]string{"tier": "gold"},
+			wantErrs: []string{`the value "gold" of label "tier" does not match the expected format "^tier-[0-9]+$"`},
+		},
+		"all the mismatches of a label are reported": {
+			labels: map[string]string{"zone": "zone-1000"},
+			wantErrs: []string{
+				`the value "zone-1000" of label "zone" is not allowed, it must be one of [zone-1, zone-100]`,
+				`the value "zone-1000" of label "zone" has 9 characters, it must have at most 6 characters`,
+			},
+		},
+		"mismatches of several labels are reported": {
+			labels: map[string]string{"region": "centralus", "tier": "gold"},
+			wantErrs: []string{
+				`label "region"`,
+				`label "tier"`,
+			},
+		},
+		"unchanged label not matching its schema is not checked": {
+			labels:    map[string]string{"region": "centralus", "tier": "tier-2"},
+			oldLabels: map[string]string{"region": "centralus", "tier": "tier-1"},
+		},
+		"updated label not matching its schema": {
+			labels:    map[string]string{"region": "centralus"},
+			oldLabels: map[string]string{"region": "eastus"},
+			wantErrs:  []string{`the value "centralus" of label "region" is not allowed`},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := config.ValidateLabelSchemas(tc.labels, tc.oldLabels)
+			if len(tc.wantErrs) == 0 {
+				if err != nil {
+					t.Fatalf("ValidateLabelSchemas() = %v, want no error", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("ValidateLabelSchemas() = nil, want error containing %v", tc.wantErrs)
+			}
+			for _, want := range tc.wantErrs {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("ValidateLabelSchemas() = %v, want error containing %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseLabelSchemas(t *testing.T) {
+	testCases := map[string]struct {
+		str        string
+		want       map[string]*LabelSchema
+		wantErrMsg string
+	}{
+		"empty": {},
+		"all schema fields": {
+			str: `{"region": {"allowedValues": ["eastus", "westus"]}, "tier": {"maxLength": 10, "regex": "^tier-[0-9]+$"}}`,
+			want: map[string]*LabelSchema{
+				"region": {AllowedValues: []string{"eastus", "westus"}},
+				"tier":   {MaxLength: 10, Regex: regexp.MustCompile(`^tier-[0-9]+$`)},
+			},
+		},
+		"not a JSON object": {
+			str:        "region=eastus",
+			wantErrMsg: "invalid label schemas, must be a JSON object mapping label keys to schemas",
+		},
+		"invalid label key": {
+			str:        `{"region!": {}}`,
+			wantErrMsg: `invalid label key "region!" of the label schemas`,
+		},
+		"negative max length": {
+			str:        `{"owner": {"maxLength": -1}}`,
+			wantErrMsg: `invalid max length -1 of the schema of label "owner"`,
+		},
+		"invalid regular expression": {
+			str:        `{"tier": {"regex": "^tier-[0-9+$"}}`,
+			wantErrMsg: `invalid regular expression of the schema of label "tier"`,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseLabelSchemas(tc.str)
+			if tc.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErrMsg) {
+					t.Fatalf("ParseLabelSchemas() = %v, want error containing %q", err, tc.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseLabelSchemas() = %v, want no error", err)
+			}
+			regexpComparer := cmp.Comparer(func(a, b *regexp.Regexp) bool {
+				if a == nil || b == nil {
+					return a == b
+				}
+				return a.String() == b.String()
+			})
+			if diff := cmp.Diff(tc.want, got, regexpComparer); diff != "" {
+				t.Errorf("ParseLabelSchemas() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
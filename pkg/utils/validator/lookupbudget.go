@@ -0,0 +1,171 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	hubmetrics "github.com/kubefleet-dev/kubefleet/pkg/metrics/hub"
+)
+
+// LookupFailurePolicy is how a client-backed check whose lookup exhausts the lookup budget is handled.
+type LookupFailurePolicy string
+
+const (
+	// LookupFailOpen allows the request with a warning.
+	LookupFailOpen LookupFailurePolicy = "failOpen"
+	// LookupFailClosed denies the request with the LookupUnavailableReason.
+	LookupFailClosed LookupFailurePolicy = "failClosed"
+)
+
+// LookupFailurePolicies are the valid lookup failure policies.
+var LookupFailurePolicies = []LookupFailurePolicy{LookupFailOpen, LookupFailClosed}
+
+// The names of the client-backed checks, which label the budget exhaustion metric and key
+// Config.LookupFailurePolicies.
+const (
+	// ClusterNamesLookupCheck looks up the MemberClusters named by a PickFixed placement.
+	ClusterNamesLookupCheck = "ClusterNames"
+	// PlacementNameCollisionLookupCheck looks up the placements of the other scope with the same name.
+	PlacementNameCollisionLookupCheck = "PlacementNameCollision"
+	// UpdateRunsLookupCheck looks up the staged update runs which reference a placement.
+	UpdateRunsLookupCheck = "UpdateRuns"
+	// TeamQuotaLookupCheck counts the ClusterResourcePlacements of a team.
+	TeamQuotaLookupCheck = "TeamQuota"
+	// OwnedPolicySnapshotsLookupCheck looks up the policy snapshots left behind by a deleted ClusterResourcePlacement.
+	OwnedPolicySnapshotsLookupCheck = "OwnedPolicySnapshots"
+	// PolicySimulationLookupCheck looks up the MemberClusters the policy of a new ClusterResourcePlacement matches.
+	PolicySimulationLookupCheck = "PolicySimulation"
+	// PlacementNamespaceLookupCheck looks up the namespace of a ResourcePlacement.
+	PlacementNamespaceLookupCheck = "PlacementNamespace"
+	// NumberOfClustersLookupCheck counts the MemberClusters available to a PickN placement.
+	NumberOfClustersLookupCheck = "NumberOfClusters"
+	// OverrideConflictsLookupCheck looks up the ClusterResourcePlacements and overrides which conflict with a
+	// ClusterResourcePlacement.
+	OverrideConflictsLookupCheck = "OverrideConflicts"
+	// PickAllFleetSizeLookupCheck counts the MemberClusters a PickAll placement without required affinity selects.
+	PickAllFleetSizeLookupCheck = "PickAllFleetSize"
+	// IncidentWindowLookupCheck looks up whether the fleet is in an incident window.
+	IncidentWindowLookupCheck = "IncidentWindow"
+)
+
+// defaultLookupFailurePolicies are the failure policies of the checks which Config.LookupFailurePolicies does not
+// set. They match how each check handles the other lookup errors: the advisory checks fail open and the others fail
+// closed, which is also the verdict a hanging lookup used to get from the Fail failure policy of the webhooks.
+var defaultLookupFailurePolicies = map[string]LookupFailurePolicy{
+	ClusterNamesLookupCheck:           LookupFailOpen,
+	PlacementNameCollisionLookupCheck: LookupFailOpen,
+	UpdateRunsLookupCheck:             LookupFailClosed,
+	TeamQuotaLookupCheck:              LookupFailClosed,
+	OwnedPolicySnapshotsLookupCheck:   LookupFailClosed,
+	PolicySimulationLookupCheck:       LookupFailOpen,
+	PlacementNamespaceLookupCheck:     LookupFailClosed,
+	NumberOfClustersLookupCheck:       LookupFailOpen,
+	OverrideConflictsLookupCheck:      LookupFailClosed,
+	PickAllFleetSizeLookupCheck:       LookupFailOpen,
+	IncidentWindowLookupCheck:         LookupFailClosed,
+}
+
+// LookupChecks returns the names of the client-backed checks.
+func LookupChecks() []string {
+	return []string{
+		ClusterNamesLookupCheck,
+		PlacementNameCollisionLookupCheck,
+		UpdateRunsLookupCheck,
+		TeamQuotaLookupCheck,
+		OwnedPolicySnapshotsLookupCheck,
+		PolicySimulationLookupCheck,
+		PlacementNamespaceLookupCheck,
+		NumberOfClustersLookupCheck,
+		OverrideConflictsLookupCheck,
+		PickAllFleetSizeLookupCheck,
+		IncidentWindowLookupCheck,
+	}
+}
+
+// LookupUnavailableReason is the reason of the response which denies a request because a check failing closed
+// exhausted its lookup budget.
+const LookupUnavailableReason metav1.StatusReason = "ValidationUnavailable"
+
+// lookupBudgetCause is the cause of the cancellation of a context returned by WithLookupBudget.
+type lookupBudgetCause struct {
+	budget time.Duration
+}
+
+func (c *lookupBudgetCause) Error() string {
+	return fmt.Sprintf("the lookup budget of %s is exhausted", c.budget)
+}
+
+// WithLookupBudget returns a copy of ctx which is cancelled once the lookup budget d elapses, so that a slow or
+// partitioned hub cache cannot stall the admission of a request. LookupBudget is used if d is not positive. Budgets nest: the checks run with a context which already carries a budget never outlive it,
+// so a handler can bound all of its checks with a single budget.
+func (c Config) WithLookupBudget(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		d = c.lookupBudget()
+	}
+	return context.WithTimeoutCause(ctx, d, &lookupBudgetCause{budget: d})
+}
+
+// LookupBudgetExhaustedError is the error of a client-backed check whose lookup exhausted the lookup budget.
+type LookupBudgetExhaustedError struct {
+	// Check is the name of the check, e.g., ClusterNamesLookupCheck.
+	Check string
+	// Budget is the exhausted lookup budget.
+	Budget time.Duration
+	// Policy is the failure policy of the check.
+	Policy LookupFailurePolicy
+}
+
+func (e *LookupBudgetExhaustedError) Error() string {
+	return fmt.Sprintf("validation unavailable: the %s check could not read the hub cluster within %s, please retry the request", e.Check, e.Budget)
+}
+
+// LookupBudgetExhausted returns a *LookupBudgetExhaustedError if the lookup of the check failed with err because
+// the lookup budget of ctx is exhausted, and nil otherwise. The failure policy of the check is looked up in
+// LookupFailurePolicies. Every exhaustion is counted in the metrics.
+func (c Config) LookupBudgetExhausted(ctx context.Context, check string, err error) *LookupBudgetExhaustedError {
+	if err == nil || ctx.Err() == nil {
+		return nil
+	}
+	var cause *lookupBudgetCause
+	if !errors.As(context.Cause(ctx), &cause) {
+		// The context is cancelled for another reason, e.g., the admission request is abandoned.
+		return nil
+	}
+	hubmetrics.FleetWebhookLookupBudgetExhaustedTotal.WithLabelValues(check).Inc()
+	policy := c.lookupFailurePolicy(check)
+	klog.V(2).InfoS("Client-backed validation exhausted its lookup budget", "check", check, "budget", cause.budget, "policy", policy, "error", err)
+	return &LookupBudgetExhaustedError{Check: check, Budget: cause.budget, Policy: policy}
+}
+
+// LookupUnavailableResponse returns the response for a request whose check exhausted its lookup budget: resp with
+// a warning if the check fails open, or a denial with the LookupUnavailableReason if it fails closed.
+func LookupUnavailableResponse(err *LookupBudgetExhaustedError, resp admission.Response) admission.Response {
+	if err.Policy == LookupFailOpen {
+		return resp.WithWarnings(err.Error())
+	}
+	denied := admission.Denied(err.Error())
+	denied.Result.Reason = LookupUnavailableReason
+	return denied
+}
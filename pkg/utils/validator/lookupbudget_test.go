@@ -0,0 +1,306 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	clusterv1beta1 "github.com/kubefleet-dev/kubefleet/apis/cluster/v1beta1"
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	hubmetrics "github.com/kubefleet-dev/kubefleet/pkg/metrics/hub"
+)
+
+// newHangingClient returns a client whose reads never complete until their context is done, like the reads of a
+// partitioned hub cache.
+func newHangingClient(scheme *runtime.Scheme) client.Client {
+	return fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		Get: func(ctx context.Context, _ client.WithWatch, _ client.ObjectKey, _ client.Object, _ ...client.GetOption) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+		List: func(ctx context.Context, _ client.WithWatch, _ client.ObjectList, _ ...client.ListOption) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	}).Build()
+}
+
+func TestWithLookupBudget(t *testing.T) {
+	testCases := map[string]struct {
+		config     Config
+		parent     time.Duration
+		d          time.Duration
+		wantBudget time.Duration
+	}{
+		"default budget": {
+			wantBudget: DefaultLookupBudget,
+		},
+		"configured budget": {
+			config:     Config{LookupBudget: time.Minute},
+			wantBudget: time.Minute,
+		},
+		"argued budget": {
+			config:     Config{LookupBudget: time.Minute},
+			d:          time.Hour,
+			wantBudget: time.Hour,
+		},
+		"nested budget never outlives the parent": {
+			parent:     time.Minute,
+			d:          time.Hour,
+			wantBudget: time.Minute,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := tc.config
+			parent := context.Background()
+			if tc.parent > 0 {
+				var cancel context.CancelFunc
+				parent, cancel = config.WithLookupBudget(parent, tc.parent)
+				defer cancel()
+			}
+			start := time.Now()
+			ctx, cancel := config.WithLookupBudget(parent, tc.d)
+			defer cancel()
+			end := time.Now()
+			deadline, ok := ctx.Deadline()
+			if !ok {
+				t.Fatalf("WithLookupBudget() has no deadline")
+			}
+			if deadline.Before(start.Add(tc.wantBudget-time.Second)) || deadline.After(end.Add(tc.wantBudget)) {
+				t.Errorf("WithLookupBudget() budget = %s, want %s", deadline.Sub(start), tc.wantBudget)
+			}
+		})
+	}
+}
+
+func TestLookupBudgetExhausted(t *testing.T) {
+	exhaustedBudget := func() context.Context {
+		ctx, cancel := Config{}.WithLookupBudget(context.Background(), time.Millisecond)
+		t.Cleanup(cancel)
+		<-ctx.Done()
+		return ctx
+	}
+	testCases := map[string]struct {
+		config Config
+		ctx    func() context.Context
+		check  string
+		err    error
+		want   *LookupBudgetExhaustedError
+	}{
+		"no error": {
+			ctx:   exhaustedBudget,
+			check: TeamQuotaLookupCheck,
+		},
+		"lookup failed within the budget": {
+			ctx: func() context.Context {
+				ctx, cancel := Config{}.WithLookupBudget(context.Background(), time.Hour)
+				t.Cleanup(cancel)
+				return ctx
+			},
+			check: TeamQuotaLookupCheck,
+			err:   errors.New("forbidden"),
+		},
+		"request abandoned": {
+			ctx: func() context.Context {
+				parent, cancel := context.WithCancel(context.Background())
+				ctx, cancelBudget := Config{}.WithLookupBudget(parent, time.Hour)
+				t.Cleanup(cancelBudget)
+				cancel()
+				return ctx
+			},
+			check: TeamQuotaLookupCheck,
+			err:   context.Canceled,
+		},
+		"budget exhausted by a check failing closed by default": {
+			ctx:   exhaustedBudget,
+			check: TeamQuotaLookupCheck,
+			err:   context.DeadlineExceeded,
+			want:  &LookupBudgetExhaustedError{Check: TeamQuotaLookupCheck, Budget: time.Millisecond, Policy: LookupFailClosed},
+		},
+		"budget exhausted by a check failing open by default": {
+			ctx:   exhaustedBudget,
+			check: ClusterNamesLookupCheck,
+			err:   context.DeadlineExceeded,
+			want:  &LookupBudgetExhaustedError{Check: ClusterNamesLookupCheck, Budget: time.Millisecond, Policy: LookupFailOpen},
+		},
+		"budget exhausted by a check with a configured policy": {
+			config: Config{LookupFailurePolicies: map[string]LookupFailurePolicy{TeamQuotaLookupCheck: LookupFailOpen}},
+			ctx:    exhaustedBudget,
+			check:  TeamQuotaLookupCheck,
+			err:    context.DeadlineExceeded,
+			want:   &LookupBudgetExhaustedError{Check: TeamQuotaLookupCheck, Budget: time.Millisecond, Policy: LookupFailOpen},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := tc.config
+			exhaustions := hubmetrics.FleetWebhookLookupBudgetExhaustedTotal.WithLabelValues(tc.check)
+			before := testutil.ToFloat64(exhaustions)
+			got := config.LookupBudgetExhausted(tc.ctx(), tc.check, tc.err)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("LookupBudgetExhausted() mismatch (-want, +got):\n%s", diff)
+			}
+			wantCount := 0.0
+			if tc.want != nil {
+				wantCount = 1
+			}
+			if got := testutil.ToFloat64(exhaustions) - before; got != wantCount {
+				t.Errorf("FleetWebhookLookupBudgetExhaustedTotal increase = %v, want %v", got, wantCount)
+			}
+		})
+	}
+}
+
+func TestLookupUnavailableResponse(t *testing.T) {
+	resp := admission.Allowed("").WithWarnings("existing warning")
+	wantMessage := "validation unavailable: the TeamQuota check could not read the hub cluster within 2s, please retry the request"
+
+	got := LookupUnavailableResponse(&LookupBudgetExhaustedError{Check: TeamQuotaLookupCheck, Budget: 2 * time.Second, Policy: LookupFailOpen}, resp)
+	if !got.Allowed {
+		t.Errorf("LookupUnavailableResponse() of a check failing open allowed = false, want true")
+	}
+	if diff := cmp.Diff([]string{"existing warning", wantMessage}, got.Warnings); diff != "" {
+		t.Errorf("LookupUnavailableResponse() of a check failing open warnings mismatch (-want, +got):\n%s", diff)
+	}
+
+	got = LookupUnavailableResponse(&LookupBudgetExhaustedError{Check: TeamQuotaLookupCheck, Budget: 2 * time.Second, Policy: LookupFailClosed}, resp)
+	if got.Allowed {
+		t.Fatalf("LookupUnavailableResponse() of a check failing closed allowed = true, want false")
+	}
+	if got.Result.Reason != LookupUnavailableReason || got.Result.Code != http.StatusForbidden || got.Result.Message != wantMessage {
+		t.Errorf("LookupUnavailableResponse() of a check failing closed result = %+v, want reason %s, code %d and message %q", got.Result, LookupUnavailableReason, http.StatusForbidden, wantMessage)
+	}
+}
+
+func TestValidatePlacementClusterNamesLookupBudget(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clusterv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() = %v, want no error", err)
+	}
+	crp := &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-crp"},
+		Spec: placementv1beta1.PlacementSpec{
+			Policy: &placementv1beta1.PlacementPolicy{PlacementType: placementv1beta1.PickFixedPlacementType, ClusterNames: []string{"member-1"}},
+		},
+	}
+	wantMessage := "validation unavailable: the ClusterNames check could not read the hub cluster within 50ms, please retry the request"
+	testCases := map[string]struct {
+		policy       LookupFailurePolicy
+		wantAllowed  bool
+		wantWarnings []string
+	}{
+		"fail open": {
+			policy:       LookupFailOpen,
+			wantAllowed:  true,
+			wantWarnings: []string{wantMessage},
+		},
+		"fail closed": {
+			policy: LookupFailClosed,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := Config{
+				ClusterNamesValidation: ClusterNamesValidationEnforce,
+				LookupBudget:           50 * time.Millisecond,
+				LookupFailurePolicies:  map[string]LookupFailurePolicy{ClusterNamesLookupCheck: tc.policy},
+			}
+			start := time.Now()
+			resp := config.ValidatePlacementClusterNames(context.Background(), newHangingClient(scheme), crp, nil, admission.Allowed(""))
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("ValidatePlacementClusterNames() took %s, want it bounded by the lookup budget", elapsed)
+			}
+			if resp.Allowed != tc.wantAllowed {
+				t.Fatalf("ValidatePlacementClusterNames() allowed = %t, want %t", resp.Allowed, tc.wantAllowed)
+			}
+			if !tc.wantAllowed && (resp.Result.Reason != LookupUnavailableReason || resp.Result.Message != wantMessage) {
+				t.Errorf("ValidatePlacementClusterNames() result = %+v, want reason %s and message %q", resp.Result, LookupUnavailableReason, wantMessage)
+			}
+			if diff := cmp.Diff(tc.wantWarnings, resp.Warnings); diff != "" {
+				t.Errorf("ValidatePlacementClusterNames() warnings mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHandlePlacementValidationLookupBudget(t *testing.T) {
+	originalReader := UpdateRunReader
+	t.Cleanup(func() {
+		UpdateRunReader = originalReader
+	})
+
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() = %v, want no error", err)
+	}
+	UpdateRunReader = newHangingClient(scheme)
+	decoder := admission.NewDecoder(scheme)
+	wantMessage := "validation unavailable: the UpdateRuns check could not read the hub cluster within 50ms, please retry the request"
+	testCases := map[string]struct {
+		policy       LookupFailurePolicy
+		wantAllowed  bool
+		wantWarnings []string
+	}{
+		"fail open": {
+			policy:       LookupFailOpen,
+			wantAllowed:  true,
+			wantWarnings: []string{wantMessage},
+		},
+		"fail closed": {
+			policy: LookupFailClosed,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := Config{
+				DenySpecUpdatesDuringUpdateRuns: true,
+				LookupBudget:                    50 * time.Millisecond,
+				LookupFailurePolicies:           map[string]LookupFailurePolicy{UpdateRunsLookupCheck: tc.policy},
+			}
+			req := buildPlacementRequest(t, admissionv1.Update, untrustedServiceAccount, newCRPWithPolicyLists(1, 0, 0, 0), newCRPWithPolicyLists(0, 0, 0, 0))
+			start := time.Now()
+			resp := config.HandlePlacementValidation(context.Background(), req, decoder, "CRP", nil, decodeCRP, decodeOldCRP, func(placementv1beta1.PlacementObj) error { return nil })
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("HandlePlacementValidation() took %s, want it bounded by the lookup budget", elapsed)
+			}
+			if resp.Allowed != tc.wantAllowed {
+				t.Fatalf("HandlePlacementValidation() allowed = %t, want %t: %+v", resp.Allowed, tc.wantAllowed, resp.Result)
+			}
+			if !tc.wantAllowed && (resp.Result.Reason != LookupUnavailableReason || resp.Result.Message != wantMessage) {
+				t.Errorf("HandlePlacementValidation() result = %+v, want reason %s and message %q", resp.Result, LookupUnavailableReason, wantMessage)
+			}
+			if diff := cmp.Diff(tc.wantWarnings, resp.Warnings); diff != "" {
+				t.Errorf("HandlePlacementValidation() warnings mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
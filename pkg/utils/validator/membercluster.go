@@ -10,9 +10,10 @@ import (
 )
 
 var (
-	invalidTaintKeyErrFmt   = "invalid taint key %+v: %s"
-	invalidTaintValueErrFmt = "invalid taint value %+v: %s"
-	uniqueTaintErrFmt       = "taint %+v already exists, taints must be unique"
+	invalidTaintKeyErrFmt    = "invalid taint key %+v: %s"
+	invalidTaintValueErrFmt  = "invalid taint value %+v: %s"
+	uniqueTaintErrFmt        = "taint %+v already exists, taints must be unique"
+	invalidTaintEffectErrFmt = "invalid taint effect %+v: unsupported effect %q, supported effects are %s"
 )
 
 // ValidateMemberCluster validates member cluster fields and returns error.
@@ -32,6 +33,9 @@ func validateTaints(taints []clusterv1beta1.Taint) error {
 				allErr = append(allErr, fmt.Errorf(invalidTaintValueErrFmt, taint, msg))
 			}
 		}
+		if !isSupportedTaintEffect(taint.Effect) {
+			allErr = append(allErr, fmt.Errorf(invalidTaintEffectErrFmt, taint, taint.Effect, supportedTaintEffectsString()))
+		}
 		if taintMap[taint] {
 			allErr = append(allErr, fmt.Errorf(uniqueTaintErrFmt, taint))
 		}
@@ -66,6 +66,35 @@ func TestValidateTaints(t *testing.T) {
 			wantErr:    true,
 			wantErrMsg: "taints must be unique",
 		},
+		"invalid taint, PreferNoSchedule effect": {
+			taints: []clusterv1beta1.Taint{
+				{
+					Key:    "key1",
+					Effect: "PreferNoSchedule",
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: `unsupported effect "PreferNoSchedule", supported effects are NoSchedule`,
+		},
+		"invalid taint, NoExecute effect": {
+			taints: []clusterv1beta1.Taint{
+				{
+					Key:    "key1",
+					Effect: "NoExecute",
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: `unsupported effect "NoExecute", supported effects are NoSchedule`,
+		},
+		"invalid taint, empty effect": {
+			taints: []clusterv1beta1.Taint{
+				{
+					Key: "key1",
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: `unsupported effect "", supported effects are NoSchedule`,
+		},
 		"valid taints": {
 			taints: []clusterv1beta1.Taint{
 				{
@@ -0,0 +1,116 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+// The annotations which configure how a ResourcePlacement propagates its own namespace, until the settings land
+// in the placement API.
+const (
+	// SkipNamespacePropagationAnnotation opts a ResourcePlacement out of propagating its namespace object when set to
+	// "true", in which case the namespace must already exist on the selected member clusters.
+	SkipNamespacePropagationAnnotation = "kubefleet.io/skip-namespace-propagation"
+	// NamespaceLabelsFilterAnnotation is the comma-separated list of the label key patterns of the namespace which
+	// are propagated with it. A pattern is either a label key or a key prefix followed by "/*".
+	NamespaceLabelsFilterAnnotation = "kubefleet.io/propagated-namespace-labels"
+	// NamespaceAnnotationsFilterAnnotation is the comma-separated list of the annotation key patterns of the namespace
+	// which are propagated with it, in the same syntax as NamespaceLabelsFilterAnnotation.
+	NamespaceAnnotationsFilterAnnotation = "kubefleet.io/propagated-namespace-annotations"
+)
+
+// validateNamespacePropagation validates the namespace propagation settings of a ResourcePlacement. The opt-out must
+// be "true" or "false", and cannot be combined with the label and annotation filters, which apply to the propagated
+// namespace, or with a policy which does not pin the placement to specific clusters, e.g., a PickAll policy without
+// any required cluster affinity, as such a placement also lands on the clusters joining later where the namespace
+// is unlikely to exist. Each filter pattern must be a valid key pattern.
+func validateNamespacePropagation(annotations map[string]string, policy *placementv1beta1.PlacementPolicy) field.ErrorList {
+	allErrs := field.ErrorList{}
+	fldPath := field.NewPath("metadata", "annotations")
+	for _, key := range []string{NamespaceLabelsFilterAnnotation, NamespaceAnnotationsFilterAnnotation} {
+		if v, ok := annotations[key]; ok {
+			allErrs = append(allErrs, validateKeyPatterns(fldPath.Key(key), v)...)
+		}
+	}
+	skip, ok := annotations[SkipNamespacePropagationAnnotation]
+	if !ok {
+		return allErrs
+	}
+	skipPath := fldPath.Key(SkipNamespacePropagationAnnotation)
+	switch skip {
+	case "false":
+		return allErrs
+	case "true":
+	default:
+		return append(allErrs, field.NotSupported(skipPath, skip, []string{"true", "false"}))
+	}
+	for _, key := range []string{NamespaceLabelsFilterAnnotation, NamespaceAnnotationsFilterAnnotation} {
+		if _, ok := annotations[key]; ok {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Key(key), fmt.Sprintf("the filter has no effect as the namespace is not propagated when %s is \"true\"", SkipNamespacePropagationAnnotation)))
+		}
+	}
+	if !pinsClusters(policy) {
+		allErrs = append(allErrs, field.Forbidden(skipPath, "the namespace must already exist on the selected clusters when it is not propagated, "+
+			"which requires the placement to select its clusters with a PickFixed policy or a required cluster affinity"))
+	}
+	return allErrs
+}
+
+// pinsClusters returns true if the policy only selects the named clusters or the ones matching a required cluster
+// affinity.
+func pinsClusters(policy *placementv1beta1.PlacementPolicy) bool {
+	if policy == nil {
+		return false
+	}
+	if policy.PlacementType == placementv1beta1.PickFixedPlacementType {
+		return true
+	}
+	return policy.Affinity != nil && policy.Affinity.ClusterAffinity != nil &&
+		policy.Affinity.ClusterAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil &&
+		len(policy.Affinity.ClusterAffinity.RequiredDuringSchedulingIgnoredDuringExecution.ClusterSelectorTerms) > 0
+}
+
+// validateKeyPatterns validates the comma-separated key patterns, each of which is either a qualified name, e.g.,
+// example.com/team, or a DNS subdomain prefix followed by "/*", e.g., example.com/*.
+func validateKeyPatterns(fldPath *field.Path, value string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	patterns := strings.Split(value, ",")
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath, value, "the list cannot contain an empty key pattern"))
+			continue
+		}
+		var errs []string
+		if prefix, ok := strings.CutSuffix(pattern, "/*"); ok {
+			errs = validation.IsDNS1123Subdomain(prefix)
+		} else {
+			errs = validation.IsQualifiedName(pattern)
+		}
+		if len(errs) > 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath, pattern, fmt.Sprintf("invalid key pattern, it must be a key or a key prefix followed by \"/*\": %s", strings.Join(errs, "; "))))
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils"
+	testinformer "github.com/kubefleet-dev/kubefleet/test/utils/informer"
+)
+
+func TestValidateResourcePlacementNamespacePropagation(t *testing.T) {
+	pickFixed := &placementv1beta1.PlacementPolicy{
+		PlacementType: placementv1beta1.PickFixedPlacementType,
+		ClusterNames:  []string{"member-1"},
+	}
+	requiredAffinity := &placementv1beta1.PlacementPolicy{
+		PlacementType: placementv1beta1.PickAllPlacementType,
+		Affinity: &placementv1beta1.Affinity{
+			ClusterAffinity: &placementv1beta1.ClusterAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &placementv1beta1.ClusterSelector{
+					ClusterSelectorTerms: []placementv1beta1.ClusterSelectorTerm{
+						{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}},
+					},
+				},
+			},
+		},
+	}
+	tests := map[string]struct {
+		annotations map[string]string
+		policy      *placementv1beta1.PlacementPolicy
+		wantErrMsgs []string
+	}{
+		"no settings": {},
+		"valid filters": {
+			annotations: map[string]string{
+				NamespaceLabelsFilterAnnotation:      "team, example.com/*",
+				NamespaceAnnotationsFilterAnnotation: "example.com/owner",
+			},
+		},
+		"opt-out with a PickFixed policy": {
+			annotations: map[string]string{SkipNamespacePropagationAnnotation: "true"},
+			policy:      pickFixed,
+		},
+		"opt-out with a required cluster affinity": {
+			annotations: map[string]string{SkipNamespacePropagationAnnotation: "true"},
+			policy:      requiredAffinity,
+		},
+		"opt-out disabled with filters": {
+			annotations: map[string]string{
+				SkipNamespacePropagationAnnotation: "false",
+				NamespaceLabelsFilterAnnotation:    "team",
+			},
+		},
+		"invalid opt-out value": {
+			annotations: map[string]string{SkipNamespacePropagationAnnotation: "yes"},
+			policy:      pickFixed,
+			wantErrMsgs: []string{`metadata.annotations[kubefleet.io/skip-namespace-propagation]: Unsupported value: "yes"`},
+		},
+		"opt-out without a policy": {
+			annotations: map[string]string{SkipNamespacePropagationAnnotation: "true"},
+			wantErrMsgs: []string{"metadata.annotations[kubefleet.io/skip-namespace-propagation]: Forbidden: the namespace must already exist on the selected clusters"},
+		},
+		"opt-out with a PickN policy without a required cluster affinity": {
+			annotations: map[string]string{SkipNamespacePropagationAnnotation: "true"},
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:    placementv1beta1.PickNPlacementType,
+				NumberOfClusters: ptr.To[int32](2),
+			},
+			wantErrMsgs: []string{"metadata.annotations[kubefleet.io/skip-namespace-propagation]: Forbidden"},
+		},
+		"opt-out combined with filters": {
+			annotations: map[string]string{
+				SkipNamespacePropagationAnnotation:   "true",
+				NamespaceLabelsFilterAnnotation:      "team",
+				NamespaceAnnotationsFilterAnnotation: "example.com/*",
+			},
+			policy: pickFixed,
+			wantErrMsgs: []string{
+				"metadata.annotations[kubefleet.io/propagated-namespace-labels]: Forbidden: the filter has no effect",
+				"metadata.annotations[kubefleet.io/propagated-namespace-annotations]: Forbidden: the filter has no effect",
+			},
+		},
+		"invalid key patterns": {
+			annotations: map[string]string{
+				NamespaceLabelsFilterAnnotation:      "team,,Example.com/*",
+				NamespaceAnnotationsFilterAnnotation: "example.com/*/owner",
+			},
+			wantErrMsgs: []string{
+				"metadata.annotations[kubefleet.io/propagated-namespace-labels]: Invalid value: \"team,,Example.com/*\": the list cannot contain an empty key pattern",
+				"metadata.annotations[kubefleet.io/propagated-namespace-labels]: Invalid value: \"Example.com/*\": invalid key pattern",
+				"metadata.annotations[kubefleet.io/propagated-namespace-annotations]: Invalid value: \"example.com/*/owner\": invalid key pattern",
+			},
+		},
+	}
+	for testName, tc := range tests {
+		t.Run(testName, func(t *testing.T) {
+			RestMapper = utils.TestMapper{}
+			ResourceInformer = &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.DeploymentGVK: true},
+				IsClusterScopedResource: false,
+			}
+			rp := &placementv1beta1.ResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-rp", Namespace: "test-namespace", Annotations: tc.annotations},
+				Spec: placementv1beta1.PlacementSpec{
+					ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{
+						{
+							Group:   "apps",
+							Version: "v1",
+							Kind:    "Deployment",
+							Name:    "test-deployment",
+						},
+					},
+					Policy: tc.policy,
+				},
+			}
+			gotErr := Config{}.ValidateResourcePlacement(rp)
+			if len(tc.wantErrMsgs) == 0 {
+				if gotErr != nil {
+					t.Errorf("ValidateResourcePlacement() = %v, want no error", gotErr)
+				}
+				return
+			}
+			if gotErr == nil {
+				t.Fatalf("ValidateResourcePlacement() = nil, want error containing %v", tc.wantErrMsgs)
+			}
+			for _, msg := range tc.wantErrMsgs {
+				if !strings.Contains(gotErr.Error(), msg) {
+					t.Errorf("ValidateResourcePlacement() = %v, want error containing %s", gotErr, msg)
+				}
+			}
+		})
+	}
+}
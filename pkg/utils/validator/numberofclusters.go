@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	clusterv1beta1 "github.com/kubefleet-dev/kubefleet/apis/cluster/v1beta1"
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+// NumberOfClustersValidationMode is how a PickN placement requesting more clusters than the fleet has is handled, as
+// the placement can never be fully scheduled and its status stays degraded.
+type NumberOfClustersValidationMode string
+
+const (
+	// NumberOfClustersValidationDisabled does not validate the number of clusters of a placement.
+	NumberOfClustersValidationDisabled NumberOfClustersValidationMode = "disabled"
+	// NumberOfClustersValidationWarn allows the placement with a warning, as more clusters may join the fleet later.
+	NumberOfClustersValidationWarn NumberOfClustersValidationMode = "warn"
+	// NumberOfClustersValidationEnforce denies the placement.
+	NumberOfClustersValidationEnforce NumberOfClustersValidationMode = "enforce"
+)
+
+// NumberOfClustersValidationModes are the valid number of clusters validation modes.
+var NumberOfClustersValidationModes = []NumberOfClustersValidationMode{NumberOfClustersValidationDisabled, NumberOfClustersValidationWarn, NumberOfClustersValidationEnforce}
+
+// ValidatePlacementNumberOfClusters compares the number of clusters requested by a PickN placement allowed by resp
+// against the number of MemberClusters which have joined or are joining the fleet, and warns about, or denies in the
+// enforce mode, a request which can never be fully scheduled. The joining clusters count as available so that the
+// placements created during the onboarding of the clusters are not rejected. On update, the check only runs if the
+// number of clusters is raised; oldPlacement is nil on creation. The check is skipped unless
+// Config.NumberOfClustersValidation is warn or enforce. The lookup is bounded by the lookup budget.
+func (c Config) ValidatePlacementNumberOfClusters(ctx context.Context, reader client.Reader, placement, oldPlacement placementv1beta1.PlacementObj, resp admission.Response) admission.Response {
+	mode := c.NumberOfClustersValidation
+	if (mode != NumberOfClustersValidationWarn && mode != NumberOfClustersValidationEnforce) || reader == nil || !resp.Allowed {
+		return resp
+	}
+	requested, ok := raisedNumberOfClusters(placement, oldPlacement)
+	if !ok {
+		return resp
+	}
+	ctx, cancel := c.WithLookupBudget(ctx, 0)
+	defer cancel()
+	available, err := countAvailableClusters(ctx, reader)
+	if err != nil {
+		if exhausted := c.LookupBudgetExhausted(ctx, NumberOfClustersLookupCheck, err); exhausted != nil {
+			return LookupUnavailableResponse(exhausted, resp)
+		}
+		klog.ErrorS(err, "Failed to count the member clusters for the placement", "placement", klog.KObj(placement))
+		if mode == NumberOfClustersValidationEnforce {
+			return admission.Errored(http.StatusInternalServerError, fmt.Errorf("failed to count the member clusters for spec.policy.numberOfClusters, please retry the request: %w", err))
+		}
+		return resp.WithWarnings(fmt.Sprintf("failed to count the member clusters for spec.policy.numberOfClusters: %v", err))
+	}
+	if int(requested) <= available {
+		return resp
+	}
+	if mode == NumberOfClustersValidationEnforce {
+		klog.V(2).InfoS("Placement requests more clusters than the fleet has, request is denied", "placement", klog.KObj(placement), "requested", requested, "available", available)
+		return admission.Denied(c.DenialMessage(PlacementNumberOfClustersUnschedulableMessageID, map[string]any{"requested": requested, "available": available}))
+	}
+	klog.V(2).InfoS("Placement requests more clusters than the fleet has, allowing the request with a warning", "placement", klog.KObj(placement), "requested", requested, "available", available)
+	return resp.WithWarnings(fmt.Sprintf("spec.policy.numberOfClusters requests %d cluster(s) but only %d member cluster(s) have joined or are joining the fleet, "+
+		"the placement will not be fully scheduled until more clusters join", requested, available))
+}
+
+// raisedNumberOfClusters returns the number of clusters requested by the PickN placement, and whether it should be
+// checked, i.e., the placement is new, was not PickN, or requested fewer clusters before the update.
+func raisedNumberOfClusters(placement, oldPlacement placementv1beta1.PlacementObj) (int32, bool) {
+	policy := placement.GetPlacementSpec().Policy
+	if policy == nil || policy.PlacementType != placementv1beta1.PickNPlacementType || policy.NumberOfClusters == nil {
+		return 0, false
+	}
+	requested := *policy.NumberOfClusters
+	if oldPlacement != nil {
+		oldPolicy := oldPlacement.GetPlacementSpec().Policy
+		if oldPolicy != nil && oldPolicy.PlacementType == placementv1beta1.PickNPlacementType && oldPolicy.NumberOfClusters != nil && *oldPolicy.NumberOfClusters >= requested {
+			return 0, false
+		}
+	}
+	return requested, true
+}
+
+// countAvailableClusters returns the number of MemberClusters which have joined or are joining the fleet, i.e., are
+// not being deleted and have not left.
+func countAvailableClusters(ctx context.Context, c client.Reader) (int, error) {
+	mcList := &clusterv1beta1.MemberClusterList{}
+	if err := c.List(ctx, mcList); err != nil {
+		return 0, err
+	}
+	available := 0
+	for i := range mcList.Items {
+		mc := &mcList.Items[i]
+		joinedCond := meta.FindStatusCondition(mc.Status.Conditions, string(clusterv1beta1.ConditionTypeMemberClusterJoined))
+		if mc.DeletionTimestamp != nil || (joinedCond != nil && joinedCond.Status == metav1.ConditionFalse) {
+			continue
+		}
+		available++
+	}
+	return available, nil
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	clusterv1beta1 "github.com/kubefleet-dev/kubefleet/apis/cluster/v1beta1"
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+func TestValidatePlacementNumberOfClusters(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clusterv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() = %v, want no error", err)
+	}
+	newCRP := func(placementType placementv1beta1.PlacementType, numberOfClusters *int32) *placementv1beta1.ClusterResourcePlacement {
+		return &placementv1beta1.ClusterResourcePlacement{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-crp"},
+			Spec: placementv1beta1.PlacementSpec{
+				Policy: &placementv1beta1.PlacementPolicy{PlacementType: placementType, NumberOfClusters: numberOfClusters},
+			},
+		}
+	}
+	newCluster := func(name string, joined metav1.ConditionStatus) *clusterv1beta1.MemberCluster {
+		mc := &clusterv1beta1.MemberCluster{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if joined != "" {
+			mc.Status.Conditions = []metav1.Condition{{Type: string(clusterv1beta1.ConditionTypeMemberClusterJoined), Status: joined}}
+		}
+		return mc
+	}
+	newJoinedClusters := func(count int) []client.Object {
+		clusters := make([]client.Object, 0, count)
+		for i := 0; i < count; i++ {
+			clusters = append(clusters, newCluster(fmt.Sprintf("joined-%d", i), metav1.ConditionTrue))
+		}
+		return clusters
+	}
+	leavingCluster := newCluster("leaving", metav1.ConditionTrue)
+	leavingCluster.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	leavingCluster.Finalizers = []string{"test-finalizer"}
+	// Two joined clusters, two joining clusters, one leaving cluster and one left cluster.
+	mixed := append(newJoinedClusters(2),
+		newCluster("joining", metav1.ConditionUnknown),
+		newCluster("new", ""),
+		leavingCluster,
+		newCluster("left", metav1.ConditionFalse),
+	)
+
+	testCases := map[string]struct {
+		mode              NumberOfClustersValidationMode
+		clusters          []client.Object
+		lookupErr         error
+		placement         placementv1beta1.PlacementObj
+		oldPlacement      placementv1beta1.PlacementObj
+		wantDeniedMessage string
+		wantErrored       bool
+		wantWarnings      []string
+	}{
+		"enough joined clusters": {
+			mode:      NumberOfClustersValidationEnforce,
+			clusters:  newJoinedClusters(10),
+			placement: newCRP(placementv1beta1.PickNPlacementType, ptr.To(int32(10))),
+		},
+		"more clusters than joined are warned about": {
+			mode:         NumberOfClustersValidationWarn,
+			clusters:     newJoinedClusters(10),
+			placement:    newCRP(placementv1beta1.PickNPlacementType, ptr.To(int32(50))),
+			wantWarnings: []string{"spec.policy.numberOfClusters requests 50 cluster(s) but only 10 member cluster(s) have joined or are joining the fleet, the placement will not be fully scheduled until more clusters join"},
+		},
+		"more clusters than joined are denied in the enforce mode": {
+			mode:              NumberOfClustersValidationEnforce,
+			clusters:          newJoinedClusters(10),
+			placement:         newCRP(placementv1beta1.PickNPlacementType, ptr.To(int32(50))),
+			wantDeniedMessage: "spec.policy.numberOfClusters requests 50 cluster(s) but only 10 member cluster(s) have joined or are joining the fleet, the placement would never be fully scheduled",
+		},
+		"no cluster has joined": {
+			mode:              NumberOfClustersValidationEnforce,
+			placement:         newCRP(placementv1beta1.PickNPlacementType, ptr.To(int32(1))),
+			wantDeniedMessage: "spec.policy.numberOfClusters requests 1 cluster(s) but only 0 member cluster(s) have joined or are joining the fleet, the placement would never be fully scheduled",
+		},
+		"joining clusters count as available": {
+			mode:      NumberOfClustersValidationEnforce,
+			clusters:  mixed,
+			placement: newCRP(placementv1beta1.PickNPlacementType, ptr.To(int32(4))),
+		},
+		"leaving and left clusters do not count as available": {
+			mode:              NumberOfClustersValidationEnforce,
+			clusters:          mixed,
+			placement:         newCRP(placementv1beta1.PickNPlacementType, ptr.To(int32(5))),
+			wantDeniedMessage: "spec.policy.numberOfClusters requests 5 cluster(s) but only 4 member cluster(s) have joined or are joining the fleet, the placement would never be fully scheduled",
+		},
+		"raised number of clusters is checked on update": {
+			mode:              NumberOfClustersValidationEnforce,
+			clusters:          newJoinedClusters(3),
+			placement:         newCRP(placementv1beta1.PickNPlacementType, ptr.To(int32(5))),
+			oldPlacement:      newCRP(placementv1beta1.PickNPlacementType, ptr.To(int32(3))),
+			wantDeniedMessage: "spec.policy.numberOfClusters requests 5 cluster(s) but only 3 member cluster(s) have joined or are joining the fleet, the placement would never be fully scheduled",
+		},
+		"unchanged number of clusters is not checked on update": {
+			mode:         NumberOfClustersValidationEnforce,
+			clusters:     newJoinedClusters(3),
+			placement:    newCRP(placementv1beta1.PickNPlacementType, ptr.To(int32(5))),
+			oldPlacement: newCRP(placementv1beta1.PickNPlacementType, ptr.To(int32(5))),
+		},
+		"lowered number of clusters is not checked on update": {
+			mode:         NumberOfClustersValidationEnforce,
+			clusters:     newJoinedClusters(3),
+			placement:    newCRP(placementv1beta1.PickNPlacementType, ptr.To(int32(4))),
+			oldPlacement: newCRP(placementv1beta1.PickNPlacementType, ptr.To(int32(5))),
+		},
+		"PickAll placement": {
+			mode:      NumberOfClustersValidationEnforce,
+			placement: newCRP(placementv1beta1.PickAllPlacementType, nil),
+		},
+		"validation is disabled": {
+			mode:      NumberOfClustersValidationDisabled,
+			placement: newCRP(placementv1beta1.PickNPlacementType, ptr.To(int32(50))),
+		},
+		"lookup failure is warned about": {
+			mode:         NumberOfClustersValidationWarn,
+			lookupErr:    errors.New("lookup failed"),
+			placement:    newCRP(placementv1beta1.PickNPlacementType, ptr.To(int32(1))),
+			wantWarnings: []string{"failed to count the member clusters for spec.policy.numberOfClusters: lookup failed"},
+		},
+		"lookup failure is an error in the enforce mode": {
+			mode:        NumberOfClustersValidationEnforce,
+			lookupErr:   errors.New("lookup failed"),
+			placement:   newCRP(placementv1beta1.PickNPlacementType, ptr.To(int32(1))),
+			wantErrored: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := Config{NumberOfClustersValidation: tc.mode}
+			builder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.clusters...)
+			if tc.lookupErr != nil {
+				builder = builder.WithInterceptorFuncs(interceptor.Funcs{
+					List: func(context.Context, client.WithWatch, client.ObjectList, ...client.ListOption) error {
+						return tc.lookupErr
+					},
+				})
+			}
+
+			resp := config.ValidatePlacementNumberOfClusters(context.Background(), builder.Build(), tc.placement, tc.oldPlacement, admission.Allowed("allowed"))
+			switch {
+			case tc.wantDeniedMessage != "":
+				if resp.Allowed || resp.Result.Message != tc.wantDeniedMessage {
+					t.Errorf("ValidatePlacementNumberOfClusters() = %+v, want denied with message %q", resp.Result, tc.wantDeniedMessage)
+				}
+			case tc.wantErrored:
+				if resp.Allowed || resp.Result.Code != http.StatusInternalServerError {
+					t.Errorf("ValidatePlacementNumberOfClusters() = %+v, want an internal server error", resp.Result)
+				}
+			default:
+				if !resp.Allowed {
+					t.Errorf("ValidatePlacementNumberOfClusters() = %+v, want allowed", resp.Result)
+				}
+				if diff := cmp.Diff(tc.wantWarnings, []string(resp.Warnings)); diff != "" {
+					t.Errorf("ValidatePlacementNumberOfClusters() warnings mismatch (-want, +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
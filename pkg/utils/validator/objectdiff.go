@@ -0,0 +1,182 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	// redactedValue replaces the values of the sensitive fields in the object diffs.
+	redactedValue = "[REDACTED]"
+
+	// managedFieldsPath is the path of the managed fields, which are left out of the object diffs.
+	managedFieldsPath = "/metadata/managedFields"
+)
+
+// sensitiveFieldNameRegex matches the names of the fields, e.g., the annotation keys, whose values may hold
+// credentials.
+var sensitiveFieldNameRegex = regexp.MustCompile(`(?i)(token|passw(or)?d|secret|credential|api[-_]?key|private[-_]?key)`)
+
+// ObjectDiffEntry is a field changed between two versions of an object.
+type ObjectDiffEntry struct {
+	// Path is the JSON pointer of the changed field.
+	Path string `json:"path"`
+	// Old is the old value of the field; it is omitted if the field is added.
+	Old interface{} `json:"old,omitempty"`
+	// New is the new value of the field; it is omitted if the field is removed.
+	New interface{} `json:"new,omitempty"`
+}
+
+// ObjectDiff returns the canonical JSON list of the fields changed from oldObj to newObj, sorted by their paths.
+// The values of the fields whose names look like credentials, e.g., an annotation named like a token or a
+// password, are redacted. The JSON is truncated to maxBytes if maxBytes is positive.
+func ObjectDiff(oldObj, newObj interface{}, maxBytes int) (string, error) {
+	oldValue, err := toJSONValue(oldObj)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert the old object: %w", err)
+	}
+	newValue, err := toJSONValue(newObj)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert the new object: %w", err)
+	}
+	entries := []ObjectDiffEntry{}
+	diffJSONValues("", oldValue, newValue, false, &entries)
+	raw, err := json.Marshal(entries)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode the object diff: %w", err)
+	}
+	return truncateString(string(raw), maxBytes), nil
+}
+
+// LogDeniedUpdateDiff logs the diff between the old and new objects of a denied update request, so that the users
+// can be told what they changed. It only logs if the klog verbosity is at least 4 or LogDeniedUpdateDiffs is set.
+func (c Config) LogDeniedUpdateDiff(reason string, req admission.Request, oldObj, newObj interface{}) {
+	if !c.LogDeniedUpdateDiffs && !klog.V(4).Enabled() {
+		return
+	}
+	namespacedName := types.NamespacedName{Name: req.Name, Namespace: req.Namespace}
+	diff, err := ObjectDiff(oldObj, newObj, c.maxDiffLogBytes())
+	if err != nil {
+		klog.ErrorS(err, "Failed to compute the diff of the denied update", "reason", reason, "kind", req.Kind.Kind, "namespacedName", namespacedName)
+		return
+	}
+	klog.InfoS("Update is denied", "reason", reason, "kind", req.Kind.Kind, "namespacedName", namespacedName, "userName", req.UserInfo.Username, "diff", diff)
+}
+
+// toJSONValue returns the generic JSON value, i.e., maps, slices and scalars, of the object.
+func toJSONValue(obj interface{}) (interface{}, error) {
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// diffJSONValues appends the changes between the old and new JSON values at the path to the entries. The objects
+// are compared field by field, while the other values, including lists, are compared as a whole. The values are
+// redacted if redact is true or the name of any field on the path looks like a credential.
+func diffJSONValues(path string, oldValue, newValue interface{}, redact bool, entries *[]ObjectDiffEntry) {
+	if path == managedFieldsPath || reflect.DeepEqual(oldValue, newValue) {
+		return
+	}
+	oldMap, oldIsMap := oldValue.(map[string]interface{})
+	newMap, newIsMap := newValue.(map[string]interface{})
+	if !oldIsMap || !newIsMap {
+		if path == "" {
+			path = "/"
+		}
+		*entries = append(*entries, ObjectDiffEntry{
+			Path: path,
+			Old:  redactJSONValue(oldValue, redact),
+			New:  redactJSONValue(newValue, redact),
+		})
+		return
+	}
+	keys := make([]string, 0, len(oldMap)+len(newMap))
+	for k := range oldMap {
+		keys = append(keys, k)
+	}
+	for k := range newMap {
+		if _, ok := oldMap[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		diffJSONValues(path+"/"+escapeJSONPointerToken(k), oldMap[k], newMap[k], redact || sensitiveFieldNameRegex.MatchString(k), entries)
+	}
+}
+
+// redactJSONValue returns the JSON value with the values of the fields whose names look like credentials
+// redacted, or the redacted placeholder if redact is true.
+func redactJSONValue(value interface{}, redact bool) interface{} {
+	if value == nil {
+		return nil
+	}
+	if redact {
+		return redactedValue
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			redacted[k] = redactJSONValue(item, sensitiveFieldNameRegex.MatchString(k))
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, item := range v {
+			redacted[i] = redactJSONValue(item, false)
+		}
+		return redacted
+	default:
+		return value
+	}
+}
+
+// escapeJSONPointerToken escapes a field name as a JSON pointer reference token.
+func escapeJSONPointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+// truncateString truncates the string to at most maxBytes bytes, without splitting a character, and notes the
+// original size. The string is returned as it is if maxBytes is not positive.
+func truncateString(s string, maxBytes int) string {
+	if maxBytes <= 0 || len(s) <= maxBytes {
+		return s
+	}
+	end := maxBytes
+	for end > 0 && !utf8.RuneStart(s[end]) {
+		end--
+	}
+	return fmt.Sprintf("%s...(truncated from %d bytes)", s[:end], len(s))
+}
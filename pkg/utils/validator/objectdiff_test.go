@@ -0,0 +1,155 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+func TestObjectDiff(t *testing.T) {
+	newCRP := func(annotations map[string]string, placementType placementv1beta1.PlacementType) *placementv1beta1.ClusterResourcePlacement {
+		return &placementv1beta1.ClusterResourcePlacement{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:          "test-crp",
+				Annotations:   annotations,
+				ManagedFields: []metav1.ManagedFieldsEntry{{Manager: string(placementType)}},
+			},
+			Spec: placementv1beta1.PlacementSpec{
+				Policy: &placementv1beta1.PlacementPolicy{PlacementType: placementType},
+			},
+		}
+	}
+	testCases := map[string]struct {
+		oldObj interface{}
+		newObj interface{}
+		want   []ObjectDiffEntry
+	}{
+		"no change": {
+			oldObj: newCRP(map[string]string{"app": "test"}, placementv1beta1.PickAllPlacementType),
+			newObj: newCRP(map[string]string{"app": "test"}, placementv1beta1.PickAllPlacementType),
+			want:   []ObjectDiffEntry{},
+		},
+		"changed, added and removed fields without the managed fields": {
+			oldObj: newCRP(map[string]string{"app": "test", "team": "billing"}, placementv1beta1.PickAllPlacementType),
+			newObj: newCRP(map[string]string{"app": "demo", "example.com/owner": "alice"}, placementv1beta1.PickNPlacementType),
+			want: []ObjectDiffEntry{
+				{Path: "/metadata/annotations/app", Old: "test", New: "demo"},
+				{Path: "/metadata/annotations/example.com~1owner", New: "alice"},
+				{Path: "/metadata/annotations/team", Old: "billing"},
+				{Path: "/spec/policy/placementType", Old: "PickAll", New: "PickN"},
+			},
+		},
+		"annotations named like credentials are redacted": {
+			oldObj: newCRP(map[string]string{"example.com/api-token": "old-token", "db-password": "old-password"}, placementv1beta1.PickAllPlacementType),
+			newObj: newCRP(map[string]string{"example.com/api-token": "new-token", "DB_PASSWD": "new-password", "client-secret": "secret"}, placementv1beta1.PickAllPlacementType),
+			want: []ObjectDiffEntry{
+				{Path: "/metadata/annotations/DB_PASSWD", New: redactedValue},
+				{Path: "/metadata/annotations/client-secret", New: redactedValue},
+				{Path: "/metadata/annotations/db-password", Old: redactedValue},
+				{Path: "/metadata/annotations/example.com~1api-token", Old: redactedValue, New: redactedValue},
+			},
+		},
+		"added annotations holding credentials are redacted": {
+			oldObj: map[string]interface{}{"metadata": map[string]interface{}{"name": "test"}},
+			newObj: map[string]interface{}{"metadata": map[string]interface{}{"name": "test", "annotations": map[string]interface{}{"token": "abc", "app": "test"}}},
+			want: []ObjectDiffEntry{
+				{Path: "/metadata/annotations", New: map[string]interface{}{"token": redactedValue, "app": "test"}},
+			},
+		},
+		"credentials in changed lists are redacted": {
+			oldObj: map[string]interface{}{"items": []interface{}{map[string]interface{}{"name": "a", "password": "old-item-password"}}},
+			newObj: map[string]interface{}{"items": []interface{}{map[string]interface{}{"name": "b", "password": "new-item-password"}}},
+			want: []ObjectDiffEntry{
+				{
+					Path: "/items",
+					Old:  []interface{}{map[string]interface{}{"name": "a", "password": redactedValue}},
+					New:  []interface{}{map[string]interface{}{"name": "b", "password": redactedValue}},
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ObjectDiff(tc.oldObj, tc.newObj, 0)
+			if err != nil {
+				t.Fatalf("ObjectDiff() = %v, want no error", err)
+			}
+			for _, secret := range []string{"old-token", "new-token", "old-password", "new-password", `"secret"`, "abc", "old-item-password", "new-item-password"} {
+				if strings.Contains(got, secret) {
+					t.Errorf("ObjectDiff() = %s, which leaks %s", got, secret)
+				}
+			}
+			wantJSON, err := json.Marshal(tc.want)
+			if err != nil {
+				t.Fatalf("json.Marshal() = %v, want no error", err)
+			}
+			if diff := cmp.Diff(string(wantJSON), got); diff != "" {
+				t.Errorf("ObjectDiff() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObjectDiffTruncation(t *testing.T) {
+	oldObj := map[string]interface{}{"spec": map[string]interface{}{"value": "old"}}
+	newObj := map[string]interface{}{"spec": map[string]interface{}{"value": strings.Repeat("é", 100)}}
+	full, err := ObjectDiff(oldObj, newObj, 0)
+	if err != nil {
+		t.Fatalf("ObjectDiff() = %v, want no error", err)
+	}
+
+	testCases := map[string]struct {
+		maxBytes int
+		want     string
+	}{
+		"not truncated without a limit": {
+			want: full,
+		},
+		"not truncated within the limit": {
+			maxBytes: len(full),
+			want:     full,
+		},
+		"truncated above the limit": {
+			maxBytes: 20,
+			want:     full[:20] + fmt.Sprintf("...(truncated from %d bytes)", len(full)),
+		},
+		"truncated without splitting a character": {
+			// The first byte of the new value is at 42 and each of its characters takes two bytes.
+			maxBytes: 43,
+			want:     full[:42] + fmt.Sprintf("...(truncated from %d bytes)", len(full)),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ObjectDiff(oldObj, newObj, tc.maxBytes)
+			if err != nil {
+				t.Fatalf("ObjectDiff() = %v, want no error", err)
+			}
+			if got != tc.want {
+				t.Errorf("ObjectDiff() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
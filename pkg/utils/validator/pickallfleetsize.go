@@ -0,0 +1,104 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+// ConfirmPickAllAnnotation is the annotation which, set to "true", confirms that a PickAll placement without
+// required cluster affinity is meant to select every member cluster of a large fleet.
+const ConfirmPickAllAnnotation = "kubefleet.io/confirm-pick-all"
+
+// PickAllFleetSizeValidationMode is how a PickAll placement without required cluster affinity is handled in a fleet
+// above the size threshold, as it ships the selected resources to every member cluster, which is rarely intended.
+type PickAllFleetSizeValidationMode string
+
+const (
+	// PickAllFleetSizeValidationDisabled does not validate the PickAll placements.
+	PickAllFleetSizeValidationDisabled PickAllFleetSizeValidationMode = "disabled"
+	// PickAllFleetSizeValidationWarn allows the placement with a warning.
+	PickAllFleetSizeValidationWarn PickAllFleetSizeValidationMode = "warn"
+	// PickAllFleetSizeValidationEnforce denies the placement unless it carries the ConfirmPickAllAnnotation.
+	PickAllFleetSizeValidationEnforce PickAllFleetSizeValidationMode = "enforce"
+)
+
+// PickAllFleetSizeValidationModes are the valid PickAll fleet size validation modes.
+var PickAllFleetSizeValidationModes = []PickAllFleetSizeValidationMode{PickAllFleetSizeValidationDisabled, PickAllFleetSizeValidationWarn, PickAllFleetSizeValidationEnforce}
+
+// ValidatePlacementPickAllFleetSize counts the MemberClusters which have joined or are joining the fleet when the
+// placement allowed by resp is PickAll, explicitly or by default, without required cluster affinity, and warns
+// about, or denies in the enforce mode, the placement if the count is above Config.PickAllFleetSizeThreshold. The
+// placements carrying the ConfirmPickAllAnnotation set to "true" are not checked. On update, the check only runs
+// if the placement was scoped before; oldPlacement is nil on creation. The check is skipped unless
+// Config.PickAllFleetSizeValidation is warn or enforce. The lookup is bounded by the lookup budget.
+func (c Config) ValidatePlacementPickAllFleetSize(ctx context.Context, reader client.Reader, placement, oldPlacement placementv1beta1.PlacementObj, resp admission.Response) admission.Response {
+	mode := c.PickAllFleetSizeValidation
+	if (mode != PickAllFleetSizeValidationWarn && mode != PickAllFleetSizeValidationEnforce) || reader == nil || !resp.Allowed {
+		return resp
+	}
+	if !isUnscopedPickAll(placement) || (oldPlacement != nil && isUnscopedPickAll(oldPlacement)) || placement.GetAnnotations()[ConfirmPickAllAnnotation] == "true" {
+		return resp
+	}
+	ctx, cancel := c.WithLookupBudget(ctx, 0)
+	defer cancel()
+	clusters, err := countAvailableClusters(ctx, reader)
+	if err != nil {
+		if exhausted := c.LookupBudgetExhausted(ctx, PickAllFleetSizeLookupCheck, err); exhausted != nil {
+			return LookupUnavailableResponse(exhausted, resp)
+		}
+		klog.ErrorS(err, "Failed to count the member clusters for the PickAll placement", "placement", klog.KObj(placement))
+		if mode == PickAllFleetSizeValidationEnforce {
+			return admission.Errored(http.StatusInternalServerError, fmt.Errorf("failed to count the member clusters the PickAll placement selects, please retry the request: %w", err))
+		}
+		return resp.WithWarnings(fmt.Sprintf("failed to count the member clusters the PickAll placement selects: %v", err))
+	}
+	threshold := c.pickAllFleetSizeThreshold()
+	if clusters <= threshold {
+		return resp
+	}
+	if mode == PickAllFleetSizeValidationEnforce {
+		klog.V(2).InfoS("PickAll placement without required affinity selects a large fleet, request is denied", "placement", klog.KObj(placement), "clusters", clusters, "threshold", threshold)
+		return admission.Denied(c.DenialMessage(PlacementPickAllFleetSizeMessageID, map[string]any{"clusters": clusters, "threshold": threshold, "annotation": ConfirmPickAllAnnotation}))
+	}
+	klog.V(2).InfoS("PickAll placement without required affinity selects a large fleet, allowing the request with a warning", "placement", klog.KObj(placement), "clusters", clusters, "threshold", threshold)
+	return resp.WithWarnings(fmt.Sprintf("the PickAll placement without a required cluster affinity selects all the %d member clusters of the fleet, above the threshold of %d; "+
+		"consider a PickN policy or a required cluster affinity to scope it, or add the annotation %s: \"true\" to confirm", clusters, threshold, ConfirmPickAllAnnotation))
+}
+
+// isUnscopedPickAll returns true if the placement picks all the clusters, as its policy is nil or PickAll, without
+// any required cluster affinity term to scope them.
+func isUnscopedPickAll(placement placementv1beta1.PlacementObj) bool {
+	policy := placement.GetPlacementSpec().Policy
+	if policy == nil {
+		return true
+	}
+	if policy.PlacementType != "" && policy.PlacementType != placementv1beta1.PickAllPlacementType {
+		return false
+	}
+	affinity := policy.Affinity
+	return affinity == nil || affinity.ClusterAffinity == nil || affinity.ClusterAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil ||
+		len(affinity.ClusterAffinity.RequiredDuringSchedulingIgnoredDuringExecution.ClusterSelectorTerms) == 0
+}
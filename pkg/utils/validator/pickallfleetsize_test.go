@@ -0,0 +1,193 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	clusterv1beta1 "github.com/kubefleet-dev/kubefleet/apis/cluster/v1beta1"
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+func TestValidatePlacementPickAllFleetSize(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clusterv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() = %v, want no error", err)
+	}
+	newCRP := func(policy *placementv1beta1.PlacementPolicy, annotations map[string]string) *placementv1beta1.ClusterResourcePlacement {
+		return &placementv1beta1.ClusterResourcePlacement{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-crp", Annotations: annotations},
+			Spec:       placementv1beta1.PlacementSpec{Policy: policy},
+		}
+	}
+	newJoinedClusters := func(count int) []client.Object {
+		clusters := make([]client.Object, 0, count)
+		for i := 0; i < count; i++ {
+			clusters = append(clusters, &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("joined-%d", i)},
+				Status: clusterv1beta1.MemberClusterStatus{
+					Conditions: []metav1.Condition{{Type: string(clusterv1beta1.ConditionTypeMemberClusterJoined), Status: metav1.ConditionTrue}},
+				},
+			})
+		}
+		return clusters
+	}
+	pickAll := &placementv1beta1.PlacementPolicy{PlacementType: placementv1beta1.PickAllPlacementType}
+	scopedPickAll := &placementv1beta1.PlacementPolicy{
+		PlacementType: placementv1beta1.PickAllPlacementType,
+		Affinity: &placementv1beta1.Affinity{
+			ClusterAffinity: &placementv1beta1.ClusterAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &placementv1beta1.ClusterSelector{
+					ClusterSelectorTerms: []placementv1beta1.ClusterSelectorTerm{
+						{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}},
+					},
+				},
+			},
+		},
+	}
+	pickN := &placementv1beta1.PlacementPolicy{PlacementType: placementv1beta1.PickNPlacementType, NumberOfClusters: ptr.To(int32(3))}
+	confirmed := map[string]string{ConfirmPickAllAnnotation: "true"}
+
+	testCases := map[string]struct {
+		mode              PickAllFleetSizeValidationMode
+		clusters          []client.Object
+		lookupErr         error
+		placement         placementv1beta1.PlacementObj
+		oldPlacement      placementv1beta1.PlacementObj
+		wantDeniedMessage string
+		wantErrored       bool
+		wantWarnings      []string
+	}{
+		"fleet at the threshold": {
+			mode:      PickAllFleetSizeValidationEnforce,
+			clusters:  newJoinedClusters(5),
+			placement: newCRP(pickAll, nil),
+		},
+		"fleet above the threshold is warned about": {
+			mode:      PickAllFleetSizeValidationWarn,
+			clusters:  newJoinedClusters(6),
+			placement: newCRP(pickAll, nil),
+			wantWarnings: []string{"the PickAll placement without a required cluster affinity selects all the 6 member clusters of the fleet, above the threshold of 5; " +
+				"consider a PickN policy or a required cluster affinity to scope it, or add the annotation kubefleet.io/confirm-pick-all: \"true\" to confirm"},
+		},
+		"fleet above the threshold is denied in the enforce mode": {
+			mode:      PickAllFleetSizeValidationEnforce,
+			clusters:  newJoinedClusters(6),
+			placement: newCRP(pickAll, nil),
+			wantDeniedMessage: "the PickAll placement without a required cluster affinity would select all the 6 member clusters of the fleet, above the threshold of 5; " +
+				"use a PickN policy or a required cluster affinity to scope it, or add the annotation kubefleet.io/confirm-pick-all: \"true\" to confirm",
+		},
+		"nil policy defaults to PickAll": {
+			mode:      PickAllFleetSizeValidationEnforce,
+			clusters:  newJoinedClusters(6),
+			placement: newCRP(nil, nil),
+			wantDeniedMessage: "the PickAll placement without a required cluster affinity would select all the 6 member clusters of the fleet, above the threshold of 5; " +
+				"use a PickN policy or a required cluster affinity to scope it, or add the annotation kubefleet.io/confirm-pick-all: \"true\" to confirm",
+		},
+		"confirmed placement is allowed in the enforce mode": {
+			mode:      PickAllFleetSizeValidationEnforce,
+			clusters:  newJoinedClusters(6),
+			placement: newCRP(pickAll, confirmed),
+		},
+		"PickAll placement with required affinity": {
+			mode:      PickAllFleetSizeValidationEnforce,
+			clusters:  newJoinedClusters(6),
+			placement: newCRP(scopedPickAll, nil),
+		},
+		"PickN placement": {
+			mode:      PickAllFleetSizeValidationEnforce,
+			clusters:  newJoinedClusters(6),
+			placement: newCRP(pickN, nil),
+		},
+		"update dropping the required affinity is checked": {
+			mode:         PickAllFleetSizeValidationEnforce,
+			clusters:     newJoinedClusters(6),
+			placement:    newCRP(pickAll, nil),
+			oldPlacement: newCRP(scopedPickAll, nil),
+			wantDeniedMessage: "the PickAll placement without a required cluster affinity would select all the 6 member clusters of the fleet, above the threshold of 5; " +
+				"use a PickN policy or a required cluster affinity to scope it, or add the annotation kubefleet.io/confirm-pick-all: \"true\" to confirm",
+		},
+		"update of a placement which already picked all the clusters is not checked": {
+			mode:         PickAllFleetSizeValidationEnforce,
+			clusters:     newJoinedClusters(6),
+			placement:    newCRP(pickAll, nil),
+			oldPlacement: newCRP(nil, nil),
+		},
+		"validation is disabled": {
+			mode:      PickAllFleetSizeValidationDisabled,
+			clusters:  newJoinedClusters(6),
+			placement: newCRP(pickAll, nil),
+		},
+		"lookup failure is warned about": {
+			mode:         PickAllFleetSizeValidationWarn,
+			lookupErr:    errors.New("lookup failed"),
+			placement:    newCRP(pickAll, nil),
+			wantWarnings: []string{"failed to count the member clusters the PickAll placement selects: lookup failed"},
+		},
+		"lookup failure is an error in the enforce mode": {
+			mode:        PickAllFleetSizeValidationEnforce,
+			lookupErr:   errors.New("lookup failed"),
+			placement:   newCRP(pickAll, nil),
+			wantErrored: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := Config{PickAllFleetSizeValidation: tc.mode, PickAllFleetSizeThreshold: 5}
+			builder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.clusters...)
+			if tc.lookupErr != nil {
+				builder = builder.WithInterceptorFuncs(interceptor.Funcs{
+					List: func(context.Context, client.WithWatch, client.ObjectList, ...client.ListOption) error {
+						return tc.lookupErr
+					},
+				})
+			}
+
+			resp := config.ValidatePlacementPickAllFleetSize(context.Background(), builder.Build(), tc.placement, tc.oldPlacement, admission.Allowed("allowed"))
+			switch {
+			case tc.wantDeniedMessage != "":
+				if resp.Allowed || resp.Result.Message != tc.wantDeniedMessage {
+					t.Errorf("ValidatePlacementPickAllFleetSize() = %+v, want denied with message %q", resp.Result, tc.wantDeniedMessage)
+				}
+			case tc.wantErrored:
+				if resp.Allowed || resp.Result.Code != http.StatusInternalServerError {
+					t.Errorf("ValidatePlacementPickAllFleetSize() = %+v, want an internal server error", resp.Result)
+				}
+			default:
+				if !resp.Allowed {
+					t.Errorf("ValidatePlacementPickAllFleetSize() = %+v, want allowed", resp.Result)
+				}
+				if diff := cmp.Diff(tc.wantWarnings, []string(resp.Warnings)); diff != "" {
+					t.Errorf("ValidatePlacementPickAllFleetSize() warnings mismatch (-want, +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
@@ -19,14 +19,18 @@ package validator
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"regexp"
+	"slices"
 	"sort"
 	"strings"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -35,12 +39,15 @@ import (
 	apiErrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	hubmetrics "github.com/kubefleet-dev/kubefleet/pkg/metrics/hub"
 	"github.com/kubefleet-dev/kubefleet/pkg/propertyprovider"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils"
 	"github.com/kubefleet-dev/kubefleet/pkg/utils/controller"
 	"github.com/kubefleet-dev/kubefleet/pkg/utils/informer"
 )
@@ -49,30 +56,82 @@ var ResourceInformer informer.Manager
 var RestMapper meta.RESTMapper
 
 var (
-	invalidTolerationErrFmt      = "invalid toleration %+v: %s"
-	invalidTolerationKeyErrFmt   = "invalid toleration key %+v: %s"
-	invalidTolerationValueErrFmt = "invalid toleration value %+v: %s"
-	uniqueTolerationErrFmt       = "toleration %+v already exists, tolerations must be unique"
+	invalidTolerationErrFmt       = "invalid toleration %+v: %s"
+	invalidTolerationKeyErrFmt    = "invalid toleration key %+v: %s"
+	invalidTolerationValueErrFmt  = "invalid toleration value %+v: %s"
+	uniqueTolerationErrFmt        = "toleration %+v already exists, tolerations must be unique"
+	invalidTolerationEffectErrFmt = "invalid toleration effect %+v: unsupported effect %q, supported effects are %s, or empty to match all effects"
 
 	// Webhook validation message format strings
-	AllowUpdateOldInvalidFmt   = "allow update on old invalid v1beta1 %s with DeletionTimestamp set"
-	DenyUpdateOldInvalidFmt    = "deny update on old invalid v1beta1 %s with DeletionTimestamp not set %s"
-	DenyCreateUpdateInvalidFmt = "deny create/update v1beta1 %s has invalid fields %s"
-	AllowModifyFmt             = "any user is allowed to modify v1beta1 %s"
+	AllowUpdateOldInvalidFmt              = "allow update on old invalid v1beta1 %s with DeletionTimestamp set"
+	DenyUpdateOldInvalidFmt               = "deny update on old invalid v1beta1 %s with DeletionTimestamp not set %s"
+	DenyCreateUpdateInvalidFmt            = "deny create/update v1beta1 %s has invalid fields %s"
+	AllowValidatedFmt                     = "%s passed %d validation rules with %d warnings"
+	WarnShadowRuleFailedFmt               = "placement validation rule %s is in shadow mode and would have denied the request: %v"
+	AllowSpecUnchangedUpdateOldInvalidFmt = "allow update on old invalid v1beta1 %s which does not modify the spec"
+	WarnOldInvalidFmt                     = "the v1beta1 %s has invalid fields, only the updates which do not modify its spec are allowed until they are fixed: %s"
 
 	// Below is the map of supported capacity types.
 	supportedResourceCapacityTypesMap = map[string]bool{propertyprovider.AllocatableCapacityName: true, propertyprovider.AvailableCapacityName: true, propertyprovider.TotalCapacityName: true}
 	resourceCapacityTypes             = supportedResourceCapacityTypes()
+
+	// resourceSelectorVersionRegex matches the API versions, e.g., v1, v1beta1 or v2alpha1.
+	resourceSelectorVersionRegex = regexp.MustCompile("^v[0-9]+(alpha|beta)?[0-9]*$")
 )
 
 // validatePlacement validates a placement object (either ClusterResourcePlacement or ResourcePlacement).
-func validatePlacement(name string, resourceSelectors []placementv1beta1.ResourceSelectorTerm, policy *placementv1beta1.PlacementPolicy, strategy placementv1beta1.RolloutStrategy, isClusterScoped bool) error {
-	allErr := make([]error, 0)
+func (c Config) validatePlacement(name string, resourceSelectors []placementv1beta1.ResourceSelectorTerm, policy *placementv1beta1.PlacementPolicy, strategy placementv1beta1.RolloutStrategy, isClusterScoped bool) error {
+	return runValidations(c.placementValidations(name, resourceSelectors, policy, strategy, isClusterScoped)...)
+}
+
+// placementValidations returns the independent validations of a placement object.
+func (c Config) placementValidations(name string, resourceSelectors []placementv1beta1.ResourceSelectorTerm, policy *placementv1beta1.PlacementPolicy, strategy placementv1beta1.RolloutStrategy, isClusterScoped bool) []func() []error {
+	return []func() []error{
+		func() []error {
+			if len(name) > validation.DNS1035LabelMaxLength {
+				return []error{fmt.Errorf("the name field cannot have length exceeding %d", validation.DNS1035LabelMaxLength)}
+			}
+			return nil
+		},
+		func() []error {
+			return validatePlacementResourceSelectors(resourceSelectors, isClusterScoped)
+		},
+		func() []error {
+			if policy == nil {
+				return nil
+			}
+			validatePolicy := c.validatePlacementPolicy
+			if !isClusterScoped {
+				validatePolicy = c.ValidateResourcePlacementPolicy
+			}
+			if err := validatePolicy(policy); err != nil {
+				return []error{fmt.Errorf("the placement policy field is invalid: %w", err)}
+			}
+			return nil
+		},
+		func() []error {
+			if err := validateRolloutStrategy(strategy); err != nil {
+				return []error{fmt.Errorf("the rollout Strategy field  is invalid: %w", err)}
+			}
+			return nil
+		},
+	}
+}
 
-	if len(name) > validation.DNS1035LabelMaxLength {
-		allErr = append(allErr, fmt.Errorf("the name field cannot have length exceeding %d", validation.DNS1035LabelMaxLength))
+// runValidations runs the validations in order and aggregates their errors, so that every invalid field is reported
+// instead of only the first one.
+func runValidations(validations ...func() []error) error {
+	allErr := make([]error, 0)
+	for _, validate := range validations {
+		allErr = append(allErr, validate()...)
 	}
+	return apiErrors.NewAggregate(allErr)
+}
 
+// validatePlacementResourceSelectors validates the resource selectors of a placement and checks that the selected resources
+// match the scope of the placement.
+func validatePlacementResourceSelectors(resourceSelectors []placementv1beta1.ResourceSelectorTerm, isClusterScoped bool) []error {
+	allErr := make([]error, 0)
 	for _, selector := range resourceSelectors {
 		if selector.LabelSelector != nil {
 			if len(selector.Name) != 0 {
@@ -87,7 +146,7 @@ func validatePlacement(name string, resourceSelectors []placementv1beta1.Resourc
 		}
 		if _, err := RestMapper.RESTMapping(gk, selector.Version); err != nil {
 			allErr = append(allErr, fmt.Errorf("failed to get GVR of the selector: %w", err))
-			return apiErrors.NewAggregate(allErr) // skip next check if we cannot get GVR
+			return allErr // skip next check if we cannot get GVR
 		}
 
 		if ResourceInformer != nil {
@@ -111,40 +170,209 @@ func validatePlacement(name string, resourceSelectors []placementv1beta1.Resourc
 			allErr = append(allErr, fmt.Errorf("cannot perform resource scope check for now, please retry"))
 		}
 	}
+	return allErr
+}
 
-	if policy != nil {
-		if err := validatePlacementPolicy(policy); err != nil {
-			allErr = append(allErr, fmt.Errorf("the placement policy field is invalid: %w", err))
+// ValidateClusterResourcePlacement validates a ClusterResourcePlacement object.
+func (c Config) ValidateClusterResourcePlacement(clusterResourcePlacement *placementv1beta1.ClusterResourcePlacement) error {
+	validations := []func() []error{
+		func() []error {
+			return []error{c.validateFleetNamespaceNotSelected(clusterResourcePlacement.Spec.ResourceSelectors)}
+		},
+		func() []error {
+			return []error{validateResourceSelectorNames(clusterResourcePlacement.Spec.ResourceSelectors, clusterResourcePlacement.Annotations)}
+		},
+		func() []error {
+			return []error{validateResourceSelectorGroupKinds(clusterResourcePlacement.Spec.ResourceSelectors).ToAggregate()}
+		},
+		func() []error {
+			return []error{validateResourceSelectorVersions(clusterResourcePlacement.Spec.ResourceSelectors).ToAggregate()}
+		},
+	}
+	validations = append(validations, c.placementValidations(
+		clusterResourcePlacement.Name,
+		clusterResourcePlacement.Spec.ResourceSelectors,
+		clusterResourcePlacement.Spec.Policy,
+		clusterResourcePlacement.Spec.Strategy,
+		true, // isClusterScoped
+	)...)
+	return runValidations(validations...)
+}
+
+// validateResourceSelectorGroupKinds checks that every resource selector identifies the kind of the resources it
+// selects, i.e., has both the group and the kind set, or selects a single resource by name. An empty group is
+// accepted for the kinds of the core API group.
+func validateResourceSelectorGroupKinds(resourceSelectors []placementv1beta1.ResourceSelectorTerm) field.ErrorList {
+	allErrs := field.ErrorList{}
+	fldPath := field.NewPath("spec", "resourceSelectors")
+	for i, selector := range resourceSelectors {
+		if selector.Name != "" || (selector.Group != "" && selector.Kind != "") {
+			continue
+		}
+		if selector.Kind == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("kind"), "kind must be set unless the selector selects a resource by name"))
+			continue
+		}
+		if _, err := RestMapper.RESTMapping(schema.GroupKind{Kind: selector.Kind}, selector.Version); err != nil {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("group"),
+				fmt.Sprintf("group must be set for kind %s, which is not in the core API group, unless the selector selects a resource by name", selector.Kind)))
 		}
 	}
+	return allErrs
+}
 
-	if err := validateRolloutStrategy(strategy); err != nil {
-		allErr = append(allErr, fmt.Errorf("the rollout Strategy field  is invalid: %w", err))
+// validateResourceSelectorVersions checks that every resource selector sets a valid API version. An empty version
+// is denied as it is ambiguous which versions of the resources it selects.
+func validateResourceSelectorVersions(resourceSelectors []placementv1beta1.ResourceSelectorTerm) field.ErrorList {
+	allErrs := field.ErrorList{}
+	fldPath := field.NewPath("spec", "resourceSelectors")
+	for i, selector := range resourceSelectors {
+		switch {
+		case selector.Version == "":
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("version"), "version must be set"))
+		case !resourceSelectorVersionRegex.MatchString(selector.Version):
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("version"), selector.Version,
+				fmt.Sprintf("version must be a valid API version, e.g., v1 or v1beta1, matching %s", resourceSelectorVersionRegex)))
+		}
 	}
+	return allErrs
+}
 
+// validateFleetNamespaceNotSelected denies the resource selectors which select the fleet namespace by name,
+// as placing it would expose fleet's internal state to the member clusters.
+func (c Config) validateFleetNamespaceNotSelected(resourceSelectors []placementv1beta1.ResourceSelectorTerm) error {
+	fleetNamespace := c.fleetNamespace()
+	allErr := make([]error, 0)
+	for _, selector := range resourceSelectors {
+		if selector.Group == corev1.GroupName && selector.Kind == utils.NamespaceKind && selector.Name == fleetNamespace {
+			allErr = append(allErr, fmt.Errorf("the resource selector %+v selects the fleet namespace %s, which cannot be placed", selector, fleetNamespace))
+		}
+	}
 	return apiErrors.NewAggregate(allErr)
 }
 
-// ValidateClusterResourcePlacement validates a ClusterResourcePlacement object.
-func ValidateClusterResourcePlacement(clusterResourcePlacement *placementv1beta1.ClusterResourcePlacement) error {
-	return validatePlacement(
-		clusterResourcePlacement.Name,
-		clusterResourcePlacement.Spec.ResourceSelectors,
-		clusterResourcePlacement.Spec.Policy,
-		clusterResourcePlacement.Spec.Strategy,
-		true, // isClusterScoped
-	)
+// ValidateRequiredLabels checks that the labels of a ClusterResourcePlacement carry every configured required
+// label with a value matching its regular expression, if any.
+func (c Config) ValidateRequiredLabels(labels map[string]string) error {
+	requiredLabels := c.RequiredLabels
+	keys := make([]string, 0, len(requiredLabels))
+	for k := range requiredLabels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	allErr := make([]error, 0)
+	for _, k := range keys {
+		v, ok := labels[k]
+		if !ok {
+			allErr = append(allErr, fmt.Errorf("the required label %q is missing", k))
+			continue
+		}
+		if re := requiredLabels[k]; re != nil && !re.MatchString(v) {
+			allErr = append(allErr, fmt.Errorf("the value %q of the required label %q does not match %q", v, k, re.String()))
+		}
+	}
+	return apiErrors.NewAggregate(allErr)
+}
+
+// ParseRequiredLabels parses a JSON object mapping the label keys every ClusterResourcePlacement must carry to the
+// regular expressions their values must match, e.g., {"team": "", "cost-center": "^cc-[0-9]+$"}; an empty regular
+// expression only requires the key to be present. No label is required if the string is empty.
+func ParseRequiredLabels(str string) (map[string]*regexp.Regexp, error) {
+	if str == "" {
+		return nil, nil
+	}
+	var patterns map[string]string
+	if err := json.Unmarshal([]byte(str), &patterns); err != nil {
+		return nil, fmt.Errorf("invalid required labels, must be a JSON object mapping label keys to regular expressions: %w", err)
+	}
+	requiredLabels := make(map[string]*regexp.Regexp, len(patterns))
+	for k, pattern := range patterns {
+		if errs := validation.IsQualifiedName(k); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid required label key %q: %s", k, strings.Join(errs, "; "))
+		}
+		if pattern == "" {
+			requiredLabels[k] = nil
+			continue
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression of the required label %q: %w", k, err)
+		}
+		requiredLabels[k] = re
+	}
+	return requiredLabels, nil
+}
+
+// NormalizePolicy converts a zero-value placement policy (i.e., `policy: {}`) of the ClusterResourcePlacement
+// to nil, so that it is defaulted and validated the same way as an omitted policy instead of being persisted.
+func NormalizePolicy(crp *placementv1beta1.ClusterResourcePlacement) {
+	if crp.Spec.Policy != nil && equality.Semantic.DeepEqual(*crp.Spec.Policy, placementv1beta1.PlacementPolicy{}) {
+		crp.Spec.Policy = nil
+	}
 }
 
 // ValidateResourcePlacement validates a ResourcePlacement object.
-func ValidateResourcePlacement(resourcePlacement *placementv1beta1.ResourcePlacement) error {
-	return validatePlacement(
+func (c Config) ValidateResourcePlacement(resourcePlacement *placementv1beta1.ResourcePlacement) error {
+	validations := []func() []error{
+		func() []error {
+			return []error{validateNamespacePropagation(resourcePlacement.Annotations, resourcePlacement.Spec.Policy).ToAggregate()}
+		},
+	}
+	validations = append(validations, c.placementValidations(
 		resourcePlacement.Name,
 		resourcePlacement.Spec.ResourceSelectors,
 		resourcePlacement.Spec.Policy,
 		resourcePlacement.Spec.Strategy,
 		false, // isClusterScoped
-	)
+	)...)
+	return runValidations(validations...)
+}
+
+// ValidateResourcePlacementPolicy validates the placement policy of a ResourcePlacement. On top of the validations
+// shared with ClusterResourcePlacement, it rejects the policy fields which are valid for a cluster-scoped placement
+// but not for a namespace-scoped one, i.e., the tolerations of the taints reserved for the fleet administrators.
+func (c Config) ValidateResourcePlacementPolicy(policy *placementv1beta1.PlacementPolicy) error {
+	if policy == nil {
+		return nil
+	}
+	allErr := make([]error, 0)
+	if err := c.validatePlacementPolicy(policy); err != nil {
+		allErr = append(allErr, err)
+	}
+	if err := c.validateResourcePlacementTolerationKeys(policy).ToAggregate(); err != nil {
+		allErr = append(allErr, err)
+	}
+	return apiErrors.NewAggregate(allErr)
+}
+
+// validateResourcePlacementTolerationKeys denies the tolerations of a ResourcePlacement whose key starts with any of
+// ResourcePlacementDeniedTolerationKeyPrefixes, as the taints with these keys are managed by the fleet
+// administrators and a namespace-scoped placement must not get around them. A toleration with an empty key and the
+// Exists operator is denied as well since it tolerates every taint.
+func (c Config) validateResourcePlacementTolerationKeys(policy *placementv1beta1.PlacementPolicy) field.ErrorList {
+	allErrs := field.ErrorList{}
+	prefixes := c.ResourcePlacementDeniedTolerationKeyPrefixes
+	if policy == nil || len(prefixes) == 0 {
+		return allErrs
+	}
+	fldPath := field.NewPath("spec", "policy", "tolerations")
+	for i, toleration := range policy.Tolerations {
+		if toleration.Key == "" {
+			if toleration.Operator == corev1.TolerationOpExists {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Index(i).Child("key"),
+					fmt.Sprintf("a toleration with an empty key and the Exists operator tolerates every taint, including the ones with the key prefixes %s which ResourcePlacements cannot tolerate", strings.Join(prefixes, ", "))))
+			}
+			continue
+		}
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(toleration.Key, prefix) {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Index(i).Child("key"),
+					fmt.Sprintf("toleration key %q has the prefix %q, which is reserved for the taints managed by the fleet administrators and cannot be tolerated by ResourcePlacements", toleration.Key, prefix)))
+				break
+			}
+		}
+	}
+	return allErrs
 }
 
 func IsPlacementPolicyTypeUpdated(oldPolicy, currentPolicy *placementv1beta1.PlacementPolicy) bool {
@@ -163,11 +391,14 @@ func IsPlacementPolicyTypeUpdated(oldPolicy, currentPolicy *placementv1beta1.Pla
 	return false
 }
 
-func validatePlacementPolicy(policy *placementv1beta1.PlacementPolicy) error {
+func (c Config) validatePlacementPolicy(policy *placementv1beta1.PlacementPolicy) error {
 	allErr := make([]error, 0)
+	if err := validatePolicyFieldConflicts(policy).ToAggregate(); err != nil {
+		allErr = append(allErr, err)
+	}
 	switch policy.PlacementType {
 	case placementv1beta1.PickFixedPlacementType:
-		if err := validatePolicyForPickFixedPlacementType(policy); err != nil {
+		if err := c.validatePolicyForPickFixedPlacementType(policy); err != nil {
 			allErr = append(allErr, err)
 		}
 	case placementv1beta1.PickAllPlacementType:
@@ -183,12 +414,80 @@ func validatePlacementPolicy(policy *placementv1beta1.PlacementPolicy) error {
 	return apiErrors.NewAggregate(allErr)
 }
 
-func validatePolicyForPickFixedPlacementType(policy *placementv1beta1.PlacementPolicy) error {
+// validateClusterNames checks that the PickFixed cluster names are unique and do not exceed the configured
+// maximum list length, returning an error for each duplicate index.
+func (c Config) validateClusterNames(clusterNames []string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	fldPath := field.NewPath("spec", "policy", "clusterNames")
+	if maxClusterNames := c.maxClusterNames(); len(clusterNames) > maxClusterNames {
+		allErrs = append(allErrs, field.TooMany(fldPath, len(clusterNames), maxClusterNames))
+	}
+	seen := make(map[string]bool, len(clusterNames))
+	for i, name := range clusterNames {
+		if seen[name] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), name))
+			continue
+		}
+		seen[name] = true
+	}
+	return allErrs
+}
+
+// placementTypeSelection explains how each placement type selects the clusters, i.e., why the fields of the other
+// placement types would be ignored.
+var placementTypeSelection = map[placementv1beta1.PlacementType]string{
+	placementv1beta1.PickFixedPlacementType: "PickFixed places the resources on exactly the clusters in clusterNames",
+	placementv1beta1.PickAllPlacementType:   "PickAll places the resources on all the clusters which match the affinity and tolerations",
+	placementv1beta1.PickNPlacementType:     "PickN places the resources on numberOfClusters clusters picked by the affinity, tolerations and topology spread constraints",
+}
+
+// validatePolicyFieldConflicts denies the policy fields which the placement type ignores, so that users are not
+// misled into thinking they take effect, e.g., an affinity on a PickFixed policy. All the conflicting fields are
+// reported in a single error list.
+func validatePolicyFieldConflicts(policy *placementv1beta1.PlacementPolicy) field.ErrorList {
+	allErrs := field.ErrorList{}
+	fldPath := field.NewPath("spec", "policy")
+	placementType := policy.PlacementType
+	forbid := func(path *field.Path, msg string) {
+		allErrs = append(allErrs, field.Forbidden(path, fmt.Sprintf("%s; %s", msg, placementTypeSelection[placementType])))
+	}
+	switch placementType {
+	case placementv1beta1.PickFixedPlacementType:
+		if policy.NumberOfClusters != nil {
+			forbid(fldPath.Child("numberOfClusters"), fmt.Sprintf("number of clusters must be nil for policy type %s, only valid for PickN placement policy type", placementType))
+		}
+		if policy.Affinity != nil {
+			forbid(fldPath.Child("affinity"), fmt.Sprintf("affinity must be nil for policy type %s, only valid for PickAll/PickN placement policy types", placementType))
+		}
+		if len(policy.TopologySpreadConstraints) > 0 {
+			forbid(fldPath.Child("topologySpreadConstraints"), fmt.Sprintf("topology spread constraints needs to be empty for policy type %s, only valid for PickN policy type", placementType))
+		}
+		if policy.Tolerations != nil {
+			forbid(fldPath.Child("tolerations"), fmt.Sprintf("tolerations needs to be empty for policy type %s, only valid for PickAll/PickN", placementType))
+		}
+	case placementv1beta1.PickAllPlacementType:
+		if len(policy.ClusterNames) > 0 {
+			forbid(fldPath.Child("clusterNames"), fmt.Sprintf("cluster names needs to be empty for policy type %s, only valid for PickFixed policy type", placementType))
+		}
+		if policy.NumberOfClusters != nil {
+			forbid(fldPath.Child("numberOfClusters"), fmt.Sprintf("number of clusters must be nil for policy type %s, only valid for PickN placement policy type", placementType))
+		}
+		if len(policy.TopologySpreadConstraints) > 0 {
+			forbid(fldPath.Child("topologySpreadConstraints"), fmt.Sprintf("topology spread constraints needs to be empty for policy type %s, only valid for PickN policy type", placementType))
+		}
+	case placementv1beta1.PickNPlacementType:
+		if len(policy.ClusterNames) > 0 {
+			forbid(fldPath.Child("clusterNames"), fmt.Sprintf("cluster names needs to be empty for policy type %s, only valid for PickFixed policy type", placementType))
+		}
+	}
+	return allErrs
+}
+
+func (c Config) validatePolicyForPickFixedPlacementType(policy *placementv1beta1.PlacementPolicy) error {
 	allErr := make([]error, 0)
 	if len(policy.ClusterNames) == 0 {
 		allErr = append(allErr, fmt.Errorf("cluster names cannot be empty for policy type %s", placementv1beta1.PickFixedPlacementType))
 	}
-	uniqueClusterNames := make(map[string]bool)
 	for _, name := range policy.ClusterNames {
 		nameErr := validation.IsDNS1123Subdomain(name)
 		if nameErr != nil {
@@ -197,23 +496,9 @@ func validatePolicyForPickFixedPlacementType(policy *placementv1beta1.PlacementP
 		if len(name) > validation.DNS1035LabelMaxLength {
 			allErr = append(allErr, fmt.Errorf("PickFixed cluster name %s cannot have length exceeding %d", name, validation.DNS1035LabelMaxLength))
 		}
-		if _, ok := uniqueClusterNames[name]; ok {
-			allErr = append(allErr, fmt.Errorf("cluster names must be unique for policy type %s", placementv1beta1.PickFixedPlacementType))
-			break
-		}
-		uniqueClusterNames[name] = true
-	}
-	if policy.NumberOfClusters != nil {
-		allErr = append(allErr, fmt.Errorf("number of clusters must be nil for policy type %s, only valid for PickN placement policy type", placementv1beta1.PickFixedPlacementType))
-	}
-	if policy.Affinity != nil {
-		allErr = append(allErr, fmt.Errorf("affinity must be nil for policy type %s, only valid for PickAll/PickN placement policy types", placementv1beta1.PickFixedPlacementType))
 	}
-	if len(policy.TopologySpreadConstraints) > 0 {
-		allErr = append(allErr, fmt.Errorf("topology spread constraints needs to be empty for policy type %s, only valid for PickN policy type", placementv1beta1.PickFixedPlacementType))
-	}
-	if policy.Tolerations != nil {
-		allErr = append(allErr, fmt.Errorf("tolerations needs to be empty for policy type %s, only valid for PickAll/PickN", placementv1beta1.PickFixedPlacementType))
+	for _, err := range c.validateClusterNames(policy.ClusterNames) {
+		allErr = append(allErr, err)
 	}
 
 	return apiErrors.NewAggregate(allErr)
@@ -221,19 +506,10 @@ func validatePolicyForPickFixedPlacementType(policy *placementv1beta1.PlacementP
 
 func validatePolicyForPickAllPlacementType(policy *placementv1beta1.PlacementPolicy) error {
 	allErr := make([]error, 0)
-	if len(policy.ClusterNames) > 0 {
-		allErr = append(allErr, fmt.Errorf("cluster names needs to be empty for policy type %s, only valid for PickFixed policy type", placementv1beta1.PickAllPlacementType))
-	}
-	if policy.NumberOfClusters != nil {
-		allErr = append(allErr, fmt.Errorf("number of clusters must be nil for policy type %s, only valid for PickN placement policy type", placementv1beta1.PickAllPlacementType))
-	}
 	// Allowing user to supply empty cluster affinity, only validating cluster affinity if non-nil
 	if policy.Affinity != nil && policy.Affinity.ClusterAffinity != nil {
 		allErr = append(allErr, validateClusterAffinity(policy.Affinity.ClusterAffinity, policy.PlacementType))
 	}
-	if len(policy.TopologySpreadConstraints) > 0 {
-		allErr = append(allErr, fmt.Errorf("topology spread constraints needs to be empty for policy type %s, only valid for PickN policy type", placementv1beta1.PickAllPlacementType))
-	}
 	allErr = append(allErr, validateTolerations(policy.Tolerations))
 
 	return apiErrors.NewAggregate(allErr)
@@ -241,9 +517,6 @@ func validatePolicyForPickAllPlacementType(policy *placementv1beta1.PlacementPol
 
 func validatePolicyForPickNPolicyType(policy *placementv1beta1.PlacementPolicy) error {
 	allErr := make([]error, 0)
-	if len(policy.ClusterNames) > 0 {
-		allErr = append(allErr, fmt.Errorf("cluster names needs to be empty for policy type %s, only valid for PickFixed policy type", placementv1beta1.PickNPlacementType))
-	}
 	if policy.NumberOfClusters != nil {
 		if *policy.NumberOfClusters < 0 {
 			allErr = append(allErr, fmt.Errorf("number of clusters cannot be %d for policy type %s", *policy.NumberOfClusters, placementv1beta1.PickNPlacementType))
@@ -307,6 +580,10 @@ func validateTolerations(tolerations []placementv1beta1.Toleration) error {
 				allErr = append(allErr, fmt.Errorf(invalidTolerationValueErrFmt, toleration, msg))
 			}
 		}
+		// An empty effect matches all the taint effects.
+		if toleration.Effect != "" && !isSupportedTaintEffect(toleration.Effect) {
+			allErr = append(allErr, fmt.Errorf(invalidTolerationEffectErrFmt, toleration, toleration.Effect, supportedTaintEffectsString()))
+		}
 		if tolerationMap[toleration] {
 			allErr = append(allErr, fmt.Errorf(uniqueTolerationErrFmt, toleration))
 		}
@@ -315,19 +592,88 @@ func validateTolerations(tolerations []placementv1beta1.Toleration) error {
 	return apiErrors.NewAggregate(allErr)
 }
 
+// IsTolerationsUpdatedOrDeleted returns true if any of the old tolerations is not found in the new tolerations,
+// as the tolerations of a placement can only be added. The order of the tolerations does not matter.
 func IsTolerationsUpdatedOrDeleted(oldTolerations []placementv1beta1.Toleration, newTolerations []placementv1beta1.Toleration) bool {
 	newTolerationsMap := make(map[placementv1beta1.Toleration]bool)
 	for _, newToleration := range newTolerations {
-		newTolerationsMap[newToleration] = true
+		newTolerationsMap[normalizeToleration(newToleration)] = true
 	}
 	for _, oldToleration := range oldTolerations {
-		if !newTolerationsMap[oldToleration] {
+		if !newTolerationsMap[normalizeToleration(oldToleration)] {
 			return true
 		}
 	}
 	return false
 }
 
+// normalizeToleration returns the toleration with its omitted operator set to Equal, the default of the API, so that
+// a toleration stored before the defaulting is not reported as updated once it is defaulted.
+func normalizeToleration(toleration placementv1beta1.Toleration) placementv1beta1.Toleration {
+	if toleration.Operator == "" {
+		toleration.Operator = corev1.TolerationOpEqual
+	}
+	return toleration
+}
+
+// validateAddedTolerationsNotRedundant returns the errors of the tolerations added to newTolerations, i.e., the ones
+// beyond the occurrences found in oldTolerations, which duplicate or shadow another toleration of newTolerations, as
+// the redundant tolerations break the semantic comparisons of the tolerations in the scheduler. A toleration shadows
+// another one if it tolerates every taint the other one tolerates, e.g., Exists on a key another toleration
+// tolerates with Equal. The redundant tolerations which are not added are not reported so that the existing
+// placements can be updated.
+func validateAddedTolerationsNotRedundant(oldTolerations, newTolerations []placementv1beta1.Toleration) field.ErrorList {
+	allErrs := field.ErrorList{}
+	fldPath := field.NewPath("spec", "policy", "tolerations")
+	oldCounts := make(map[placementv1beta1.Toleration]int)
+	for _, oldToleration := range oldTolerations {
+		oldCounts[normalizeToleration(oldToleration)]++
+	}
+	isAdded := make([]bool, len(newTolerations))
+	for i, newToleration := range newTolerations {
+		normalized := normalizeToleration(newToleration)
+		if oldCounts[normalized] > 0 {
+			oldCounts[normalized]--
+			continue
+		}
+		isAdded[i] = true
+	}
+	for j, toleration := range newTolerations {
+		if !isAdded[j] {
+			continue
+		}
+		added := normalizeToleration(toleration)
+		for i, other := range newTolerations {
+			other = normalizeToleration(other)
+			// The pairs of the added tolerations are reported once, on the later one.
+			if i == j || (i > j && isAdded[i]) {
+				continue
+			}
+			switch {
+			case added == other:
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(j), toleration, fmt.Sprintf("duplicates %s", fldPath.Index(i))))
+			case toleratesAll(added, other):
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(j), toleration, fmt.Sprintf("shadows %s", fldPath.Index(i))))
+			case toleratesAll(other, added):
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(j), toleration, fmt.Sprintf("is shadowed by %s", fldPath.Index(i))))
+			}
+		}
+	}
+	return allErrs
+}
+
+// toleratesAll returns true if the normalized toleration tolerates every taint the normalized other toleration
+// tolerates.
+func toleratesAll(toleration, other placementv1beta1.Toleration) bool {
+	if toleration.Key != other.Key && (toleration.Key != "" || toleration.Operator != corev1.TolerationOpExists) {
+		return false
+	}
+	if toleration.Operator != corev1.TolerationOpExists && (other.Operator != corev1.TolerationOpEqual || toleration.Value != other.Value) {
+		return false
+	}
+	return toleration.Effect == "" || toleration.Effect == other.Effect
+}
+
 func validateTopologySpreadConstraints(topologyConstraints []placementv1beta1.TopologySpreadConstraint) error {
 	allErr := make([]error, 0)
 	for _, tc := range topologyConstraints {
@@ -382,12 +728,27 @@ func validateLabelSelector(labelSelector *metav1.LabelSelector, parent string) e
 	return nil
 }
 
+// supportedRolloutStrategyTypes are the accepted values of spec.strategy.type.
+var supportedRolloutStrategyTypes = []placementv1beta1.RolloutStrategyType{
+	placementv1beta1.RollingUpdateRolloutStrategyType,
+	placementv1beta1.ExternalRolloutStrategyType,
+}
+
+// IsValidStrategyType returns true if t is an accepted rollout strategy type. The empty type is valid as it
+// defaults to RollingUpdate.
+func IsValidStrategyType(t placementv1beta1.RolloutStrategyType) bool {
+	return t == "" || slices.Contains(supportedRolloutStrategyTypes, t)
+}
+
 func validateRolloutStrategy(rolloutStrategy placementv1beta1.RolloutStrategy) error {
 	allErr := make([]error, 0)
 
-	if rolloutStrategy.Type != "" && rolloutStrategy.Type != placementv1beta1.RollingUpdateRolloutStrategyType &&
-		rolloutStrategy.Type != placementv1beta1.ExternalRolloutStrategyType {
-		allErr = append(allErr, fmt.Errorf("unsupported rollout strategy type `%s`", rolloutStrategy.Type))
+	if !IsValidStrategyType(rolloutStrategy.Type) {
+		validValues := make([]string, 0, len(supportedRolloutStrategyTypes))
+		for _, t := range supportedRolloutStrategyTypes {
+			validValues = append(validValues, string(t))
+		}
+		allErr = append(allErr, field.NotSupported(field.NewPath("spec", "strategy", "type"), rolloutStrategy.Type, validValues))
 	}
 
 	if rolloutStrategy.RollingUpdate != nil {
@@ -550,19 +911,27 @@ func supportedResourceCapacityTypes() []string {
 	return capacityTypes
 }
 
-// HandlePlacementValidation provides consolidated webhook validation logic for placement objects.
-// This function accepts higher-order functions for type-specific operations.
-func HandlePlacementValidation(
+// HandlePlacementValidation provides consolidated webhook validation logic for placement objects under the validator
+// settings. This function accepts higher-order functions for type-specific operations. The requests whose kind is
+// not one of acceptedKinds are handled by HandleUnknownKind before they are decoded.
+func (c Config) HandlePlacementValidation(
 	ctx context.Context,
 	req admission.Request,
 	decoder webhook.AdmissionDecoder,
 	resourceType string,
+	acceptedKinds []metav1.GroupVersionKind,
 	decodeFunc func(admission.Request, webhook.AdmissionDecoder) (placementv1beta1.PlacementObj, error),
 	decodeOldFunc func(admission.Request, webhook.AdmissionDecoder) (placementv1beta1.PlacementObj, error),
 	validateFunc func(placementv1beta1.PlacementObj) error,
 ) admission.Response {
+	if resp, handled := c.HandleUnknownKind(req, resourceType, acceptedKinds); handled {
+		return resp
+	}
 	if req.Operation == admissionv1.Create || req.Operation == admissionv1.Update {
 		klog.V(2).InfoS("handling placement", "resourceType", resourceType, "operation", req.Operation, "namespacedName", types.NamespacedName{Name: req.Name, Namespace: req.Namespace})
+		// Every rule is timed so that the slow ones can be found as the validation grows.
+		timings := newRuleTimings(resourceType, c.slowValidationThreshold())
+		defer timings.logIfSlow(req)
 
 		placement, err := decodeFunc(req, decoder)
 		if err != nil {
@@ -570,37 +939,103 @@ func HandlePlacementValidation(
 			return admission.Errored(http.StatusBadRequest, err)
 		}
 
+		var oldPlacement placementv1beta1.PlacementObj
+		// oldInvalidErr is set when the update leaves the spec of a placement with invalid fields untouched.
+		var oldInvalidErr error
 		if req.Operation == admissionv1.Update {
-			oldPlacement, err := decodeOldFunc(req, decoder)
+			oldPlacement, err = decodeOldFunc(req, decoder)
 			if err != nil {
 				return admission.Errored(http.StatusBadRequest, err)
 			}
 
 			// Special case: allow updates to old placement objects with invalid fields so that we can
-			// update the placement to remove finalizer then delete it.
+			// update the placement to remove finalizer then delete it. The updates which leave the spec
+			// untouched are allowed as well so that the controllers can keep maintaining the metadata of the
+			// placements stored before the validation was tightened.
 			if err := validateFunc(oldPlacement); err != nil {
 				if placement.GetDeletionTimestamp() != nil {
 					return admission.Allowed(fmt.Sprintf(AllowUpdateOldInvalidFmt, resourceType))
 				}
-				return admission.Denied(fmt.Sprintf(DenyUpdateOldInvalidFmt, resourceType, err))
+				if unchanged, _ := PlacementSpecSemanticEqual(oldPlacement, placement); !unchanged {
+					return DeniedWithCauses(c.DenialMessage(PlacementOldInvalidFieldsMessageID, map[string]any{"resourceType": resourceType, "error": err}), FieldErrorCauses(err))
+				}
+				// The rules which do not look at the spec still run, e.g., the metadata cannot grow past its limits.
+				oldInvalidErr = err
 			}
+		}
 
-			// Handle update case where placement type should be immutable.
-			if IsPlacementPolicyTypeUpdated(oldPlacement.GetPlacementSpec().Policy, placement.GetPlacementSpec().Policy) {
-				return admission.Denied("placement type is immutable")
+		// Trusted identities (e.g., the fleet controllers) skip the advisory validations but never the correctness ones.
+		trusted := c.IsTrustedIdentity(req.UserInfo)
+		var warnings []string
+		passed := 0
+		for _, rule := range placementValidationRules {
+			if oldInvalidErr != nil && !rule.SpecIndependent {
+				continue
 			}
-
-			// Handle update case where existing tolerations were updated/deleted
-			if IsTolerationsUpdatedOrDeleted(oldPlacement.GetPlacementSpec().Tolerations(), placement.GetPlacementSpec().Tolerations()) {
-				return admission.Denied("tolerations have been updated/deleted, only additions to tolerations are allowed")
+			if trusted && rule.Class == AdvisoryValidation {
+				klog.V(3).InfoS("skipping advisory placement validation for trusted identity", "rule", rule.Name, "resourceType", resourceType, "userName", req.UserInfo.Username)
+				continue
 			}
+			var err error
+			var ruleWarnings []string
+			timings.run(rule.Name, func() {
+				err = rule.Validate(ctx, c, req, placement, oldPlacement)
+				if rule.Warn != nil {
+					ruleWarnings = rule.Warn(c, req, placement, oldPlacement)
+				}
+			})
+			if err != nil {
+				var exhausted *LookupBudgetExhaustedError
+				switch {
+				case rule.maturity(c) == ShadowRule:
+					// Only the enforced rules decide the verdict; the failures of the shadow rules are surfaced as warnings.
+					klog.V(2).InfoS("placement failed shadow validation, request is not denied", "rule", rule.Name, "class", rule.Class, "resourceType", resourceType, "operation", req.Operation, "namespacedName", types.NamespacedName{Name: placement.GetName(), Namespace: req.Namespace}, "error", err)
+					hubmetrics.FleetShadowPlacementValidationFailuresTotal.WithLabelValues(rule.Name, resourceType).Inc()
+					warnings = append(warnings, fmt.Sprintf(WarnShadowRuleFailedFmt, rule.Name, err))
+				case errors.As(err, &exhausted):
+					// The rule could not read the hub cluster in time, the failure policy of its check decides the verdict.
+					if exhausted.Policy != LookupFailOpen {
+						return LookupUnavailableResponse(exhausted, admission.Response{})
+					}
+					warnings = append(warnings, exhausted.Error())
+				default:
+					klog.V(2).InfoS("placement failed validation, request is denied", "rule", rule.Name, "class", rule.Class, "resourceType", resourceType, "operation", req.Operation, "namespacedName", types.NamespacedName{Name: placement.GetName(), Namespace: req.Namespace})
+					if oldPlacement != nil {
+						c.LogDeniedUpdateDiff(rule.Name, req, oldPlacement, placement)
+					}
+					return DeniedWithCauses(err.Error(), FieldErrorCauses(err))
+				}
+			} else {
+				passed++
+			}
+			warnings = append(warnings, ruleWarnings...)
+		}
+
+		if oldInvalidErr != nil {
+			klog.V(2).InfoS("allowing the update which does not modify the spec of an invalid v1beta1 placement", "resourceType", resourceType, "namespacedName", types.NamespacedName{Name: placement.GetName(), Namespace: req.Namespace}, "userName", req.UserInfo.Username, "error", oldInvalidErr)
+			return admission.Allowed(fmt.Sprintf(AllowSpecUnchangedUpdateOldInvalidFmt, resourceType)).
+				WithWarnings(append([]string{fmt.Sprintf(WarnOldInvalidFmt, resourceType, oldInvalidErr)}, warnings...)...)
 		}
 
-		if err := validateFunc(placement); err != nil {
+		timings.run(fieldValidationRuleName, func() { err = validateFunc(placement) })
+		if err != nil {
 			klog.V(2).InfoS("v1beta1 placement has invalid fields, request is denied", "resourceType", resourceType, "operation", req.Operation, "namespacedName", types.NamespacedName{Name: placement.GetName(), Namespace: req.Namespace})
-			return admission.Denied(fmt.Sprintf(DenyCreateUpdateInvalidFmt, resourceType, err))
+			if oldPlacement != nil {
+				c.LogDeniedUpdateDiff("InvalidFields", req, oldPlacement, placement)
+			}
+			return DeniedWithCauses(c.DenialMessage(PlacementInvalidFieldsMessageID, map[string]any{"resourceType": resourceType, "error": err}), FieldErrorCauses(err))
 		}
+		// The field validation of the placement spec counts as one more rule.
+		passed++
+		return admission.Allowed(AllowedMessage("v1beta1 "+resourceType, passed, len(warnings))).WithWarnings(warnings...)
 	}
 
-	return admission.Allowed(fmt.Sprintf(AllowModifyFmt, resourceType))
+	return admission.Allowed(AllowedMessage(fmt.Sprintf("%s of v1beta1 %s", req.Operation, resourceType), 0, 0))
+}
+
+// AllowedMessage returns the message of an allowed admission response, stating the number of validation rules the
+// subject of the request passed and the number of warnings attached to the response, so that the audit log tells
+// what was validated. A request which is not validated passes 0 rules.
+func AllowedMessage(subject string, passedRules, warnings int) string {
+	return fmt.Sprintf(AllowValidatedFmt, subject, passedRules, warnings)
 }
@@ -17,13 +17,18 @@ limitations under the License.
 package validator
 
 import (
+	"fmt"
+	"regexp"
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	apiErrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
 	"github.com/kubefleet-dev/kubefleet/pkg/utils"
@@ -84,6 +89,29 @@ func TestValidateClusterResourcePlacement(t *testing.T) {
 				IsClusterScopedResource: true},
 			wantErrMsg: "the name field cannot have length exceeding 63",
 		},
+		"invalid Resource Selector with group but no kind": {
+			crp: &placementv1beta1.ClusterResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-crp",
+				},
+				Spec: placementv1beta1.PlacementSpec{
+					ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{
+						{
+							Group:   "rbac.authorization.k8s.io",
+							Version: "v1",
+						},
+					},
+					Strategy: placementv1beta1.RolloutStrategy{
+						Type: placementv1beta1.RollingUpdateRolloutStrategyType,
+					},
+				},
+			},
+			resourceInformer: &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
+				IsClusterScopedResource: true},
+			wantErr:    true,
+			wantErrMsg: "spec.resourceSelectors[0].kind: Required value: kind must be set unless the selector selects a resource by name",
+		},
 		"invalid Resource Selector with name & label selector": {
 			crp: &placementv1beta1.ClusterResourcePlacement{
 				ObjectMeta: metav1.ObjectMeta{
@@ -167,6 +195,49 @@ func TestValidateClusterResourcePlacement(t *testing.T) {
 			wantErr:          true,
 			wantErrMsg:       "cannot perform resource scope check for now, please retry",
 		},
+		"CRP selecting the fleet namespace should fail": {
+			crp: &placementv1beta1.ClusterResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-crp",
+				},
+				Spec: placementv1beta1.PlacementSpec{
+					ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{
+						{
+							Group:   "",
+							Version: "v1",
+							Kind:    "Namespace",
+							Name:    utils.FleetSystemNamespace,
+						},
+					},
+				},
+			},
+			resourceInformer: &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.NamespaceGVK: true},
+				IsClusterScopedResource: true},
+			wantErr:    true,
+			wantErrMsg: "selects the fleet namespace fleet-system, which cannot be placed",
+		},
+		"CRP selecting another namespace": {
+			crp: &placementv1beta1.ClusterResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-crp",
+				},
+				Spec: placementv1beta1.PlacementSpec{
+					ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{
+						{
+							Group:   "",
+							Version: "v1",
+							Kind:    "Namespace",
+							Name:    "test-ns",
+						},
+					},
+				},
+			},
+			resourceInformer: &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.NamespaceGVK: true},
+				IsClusterScopedResource: true},
+			wantErr: false,
+		},
 		"CRP with namespaced resource should fail": {
 			crp: &placementv1beta1.ClusterResourcePlacement{
 				ObjectMeta: metav1.ObjectMeta{
@@ -195,7 +266,7 @@ func TestValidateClusterResourcePlacement(t *testing.T) {
 		t.Run(testName, func(t *testing.T) {
 			RestMapper = utils.TestMapper{}
 			ResourceInformer = testCase.resourceInformer
-			gotErr := ValidateClusterResourcePlacement(testCase.crp)
+			gotErr := Config{}.ValidateClusterResourcePlacement(testCase.crp)
 			if (gotErr != nil) != testCase.wantErr {
 				t.Errorf("ValidateClusterResourcePlacement() error = %v, wantErr %v", gotErr, testCase.wantErr)
 			}
@@ -206,6 +277,355 @@ func TestValidateClusterResourcePlacement(t *testing.T) {
 	}
 }
 
+func TestValidateClusterResourcePlacementReportsAllErrors(t *testing.T) {
+	RestMapper = utils.TestMapper{}
+	ResourceInformer = &testinformer.FakeManager{
+		APIResources:            map[schema.GroupVersionKind]bool{utils.NamespaceGVK: true},
+		IsClusterScopedResource: true,
+	}
+	crp := &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.Repeat("a", 64),
+		},
+		Spec: placementv1beta1.PlacementSpec{
+			ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{
+				{
+					Group:   "",
+					Version: "v1",
+					Kind:    "Namespace",
+					Name:    utils.FleetSystemNamespace,
+				},
+			},
+			Policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:    placementv1beta1.PickNPlacementType,
+				NumberOfClusters: &negativeNumberOfClusters,
+			},
+			Strategy: placementv1beta1.RolloutStrategy{
+				Type: "invalid",
+			},
+		},
+	}
+	gotErr := Config{}.ValidateClusterResourcePlacement(crp)
+	if gotErr == nil {
+		t.Fatalf("ValidateClusterResourcePlacement() = nil, want errors from all sub-validations")
+	}
+	wantErrMsgs := []string{
+		"selects the fleet namespace fleet-system, which cannot be placed",
+		"the name field cannot have length exceeding 63",
+		"the placement policy field is invalid",
+		"the rollout Strategy field  is invalid: spec.strategy.type: Unsupported value: \"invalid\": supported values: \"RollingUpdate\", \"External\"",
+	}
+	for _, wantErrMsg := range wantErrMsgs {
+		if !strings.Contains(gotErr.Error(), wantErrMsg) {
+			t.Errorf("ValidateClusterResourcePlacement() got %v, should contain want %s", gotErr, wantErrMsg)
+		}
+	}
+}
+
+func TestRunValidations(t *testing.T) {
+	testCases := map[string]struct {
+		validations []func() []error
+		wantErrs    []string
+	}{
+		"no validations": {},
+		"all validations pass": {
+			validations: []func() []error{
+				func() []error { return nil },
+				func() []error { return []error{} },
+			},
+		},
+		"errors are kept in the order of the validations": {
+			validations: []func() []error{
+				func() []error { return []error{fmt.Errorf("first"), fmt.Errorf("second")} },
+				func() []error { return nil },
+				func() []error { return []error{fmt.Errorf("third")} },
+			},
+			wantErrs: []string{"first", "second", "third"},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := runValidations(tc.validations...)
+			var gotErrs []string
+			if err != nil {
+				for _, e := range err.(apiErrors.Aggregate).Errors() {
+					gotErrs = append(gotErrs, e.Error())
+				}
+			}
+			if diff := cmp.Diff(tc.wantErrs, gotErrs); diff != "" {
+				t.Errorf("runValidations() errors mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateFleetNamespaceNotSelected(t *testing.T) {
+	namespaceSelector := func(name string) placementv1beta1.ResourceSelectorTerm {
+		return placementv1beta1.ResourceSelectorTerm{Group: "", Version: "v1", Kind: "Namespace", Name: name}
+	}
+	testCases := map[string]struct {
+		config     Config
+		selectors  []placementv1beta1.ResourceSelectorTerm
+		wantErrMsg string
+	}{
+		"default fleet namespace is selected": {
+			selectors:  []placementv1beta1.ResourceSelectorTerm{resourceSelector, namespaceSelector("fleet-system")},
+			wantErrMsg: "selects the fleet namespace fleet-system, which cannot be placed",
+		},
+		"configured fleet namespace is selected": {
+			config:     Config{FleetNamespace: "custom-fleet"},
+			selectors:  []placementv1beta1.ResourceSelectorTerm{namespaceSelector("custom-fleet")},
+			wantErrMsg: "selects the fleet namespace custom-fleet, which cannot be placed",
+		},
+		"default fleet namespace is not protected once another one is configured": {
+			config:    Config{FleetNamespace: "custom-fleet"},
+			selectors: []placementv1beta1.ResourceSelectorTerm{namespaceSelector("fleet-system")},
+		},
+		"other namespace is selected": {
+			selectors: []placementv1beta1.ResourceSelectorTerm{namespaceSelector("test-ns")},
+		},
+		"cluster-scoped resource with the fleet namespace name is selected": {
+			selectors: []placementv1beta1.ResourceSelectorTerm{
+				{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole", Name: "fleet-system"},
+			},
+		},
+		"namespaces are selected by labels": {
+			selectors: []placementv1beta1.ResourceSelectorTerm{
+				{Group: "", Version: "v1", Kind: "Namespace", LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}}},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := tc.config
+			err := config.validateFleetNamespaceNotSelected(tc.selectors)
+			if tc.wantErrMsg == "" {
+				if err != nil {
+					t.Errorf("validateFleetNamespaceNotSelected() = %v, want no error", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErrMsg) {
+				t.Errorf("validateFleetNamespaceNotSelected() = %v, want error containing %q", err, tc.wantErrMsg)
+			}
+		})
+	}
+}
+
+func TestValidateResourceSelectorGroupKinds(t *testing.T) {
+	RestMapper = utils.TestMapper{}
+	testCases := map[string]struct {
+		selectors []placementv1beta1.ResourceSelectorTerm
+		wantErrs  field.ErrorList
+	}{
+		"group and kind": {
+			selectors: []placementv1beta1.ResourceSelectorTerm{{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole"}},
+			wantErrs:  field.ErrorList{},
+		},
+		"group without kind": {
+			selectors: []placementv1beta1.ResourceSelectorTerm{{Group: "rbac.authorization.k8s.io", Version: "v1"}},
+			wantErrs: field.ErrorList{
+				field.Required(field.NewPath("spec", "resourceSelectors").Index(0).Child("kind"), "kind must be set unless the selector selects a resource by name"),
+			},
+		},
+		"kind of the core API group without group": {
+			selectors: []placementv1beta1.ResourceSelectorTerm{{Version: "v1", Kind: "Namespace"}},
+			wantErrs:  field.ErrorList{},
+		},
+		"kind of another API group without group": {
+			selectors: []placementv1beta1.ResourceSelectorTerm{resourceSelector, {Version: "v1", Kind: "Widget"}},
+			wantErrs: field.ErrorList{
+				field.Required(field.NewPath("spec", "resourceSelectors").Index(1).Child("group"), "group must be set for kind Widget, which is not in the core API group, unless the selector selects a resource by name"),
+			},
+		},
+		"neither group nor kind": {
+			selectors: []placementv1beta1.ResourceSelectorTerm{{Version: "v1"}},
+			wantErrs: field.ErrorList{
+				field.Required(field.NewPath("spec", "resourceSelectors").Index(0).Child("kind"), "kind must be set unless the selector selects a resource by name"),
+			},
+		},
+		"name only": {
+			selectors: []placementv1beta1.ResourceSelectorTerm{{Version: "v1", Name: "test-resource"}},
+			wantErrs:  field.ErrorList{},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			gotErrs := validateResourceSelectorGroupKinds(tc.selectors)
+			if diff := cmp.Diff(tc.wantErrs, gotErrs); diff != "" {
+				t.Errorf("validateResourceSelectorGroupKinds() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateResourceSelectorVersions(t *testing.T) {
+	fldPath := field.NewPath("spec", "resourceSelectors")
+	versionDetail := "version must be a valid API version, e.g., v1 or v1beta1, matching ^v[0-9]+(alpha|beta)?[0-9]*$"
+	testCases := map[string]struct {
+		selectors []placementv1beta1.ResourceSelectorTerm
+		wantErrs  field.ErrorList
+	}{
+		"valid versions": {
+			selectors: []placementv1beta1.ResourceSelectorTerm{
+				{Version: "v1", Kind: "Namespace"},
+				{Group: "example.com", Version: "v1beta1", Kind: "Widget"},
+				{Group: "example.com", Version: "v2alpha3", Kind: "Widget"},
+				{Group: "example.com", Version: "v10", Kind: "Widget"},
+			},
+			wantErrs: field.ErrorList{},
+		},
+		"invalid versions": {
+			selectors: []placementv1beta1.ResourceSelectorTerm{
+				{Version: "v1", Kind: "Namespace"},
+				{Group: "example.com", Version: "1", Kind: "Widget"},
+				{Group: "example.com", Version: "V1", Kind: "Widget"},
+				{Group: "example.com", Version: "v1gamma1", Kind: "Widget"},
+				{Group: "example.com", Version: "example.com/v1", Kind: "Widget"},
+			},
+			wantErrs: field.ErrorList{
+				field.Invalid(fldPath.Index(1).Child("version"), "1", versionDetail),
+				field.Invalid(fldPath.Index(2).Child("version"), "V1", versionDetail),
+				field.Invalid(fldPath.Index(3).Child("version"), "v1gamma1", versionDetail),
+				field.Invalid(fldPath.Index(4).Child("version"), "example.com/v1", versionDetail),
+			},
+		},
+		"empty version": {
+			selectors: []placementv1beta1.ResourceSelectorTerm{{Version: "", Kind: "Namespace", Name: "app"}},
+			wantErrs: field.ErrorList{
+				field.Required(fldPath.Index(0).Child("version"), "version must be set"),
+			},
+		},
+		// The version is not a pointer, so an omitted version decodes to the empty string and is not a wildcard.
+		"omitted version": {
+			selectors: []placementv1beta1.ResourceSelectorTerm{{Group: "example.com", Kind: "Widget"}},
+			wantErrs: field.ErrorList{
+				field.Required(fldPath.Index(0).Child("version"), "version must be set"),
+			},
+		},
+		"no selectors": {
+			wantErrs: field.ErrorList{},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			gotErrs := validateResourceSelectorVersions(tc.selectors)
+			if diff := cmp.Diff(tc.wantErrs, gotErrs); diff != "" {
+				t.Errorf("validateResourceSelectorVersions() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateRequiredLabels(t *testing.T) {
+	requiredLabels := map[string]*regexp.Regexp{
+		"fleet.azure.com/team":        nil,
+		"fleet.azure.com/cost-center": regexp.MustCompile(`^cc-[0-9]+$`),
+	}
+	testCases := map[string]struct {
+		requiredLabels map[string]*regexp.Regexp
+		labels         map[string]string
+		wantErrMsg     string
+	}{
+		"no required labels": {
+			labels: map[string]string{"app": "test"},
+		},
+		"empty required labels": {
+			requiredLabels: map[string]*regexp.Regexp{},
+		},
+		"required labels are present with matching values": {
+			requiredLabels: requiredLabels,
+			labels:         map[string]string{"fleet.azure.com/team": "any", "fleet.azure.com/cost-center": "cc-42"},
+		},
+		"required label is missing": {
+			requiredLabels: requiredLabels,
+			labels:         map[string]string{"fleet.azure.com/cost-center": "cc-42"},
+			wantErrMsg:     `the required label "fleet.azure.com/team" is missing`,
+		},
+		"required label value does not match": {
+			requiredLabels: requiredLabels,
+			labels:         map[string]string{"fleet.azure.com/team": "any", "fleet.azure.com/cost-center": "finance"},
+			wantErrMsg:     `the value "finance" of the required label "fleet.azure.com/cost-center" does not match "^cc-[0-9]+$"`,
+		},
+		"required label without a regular expression only needs the key": {
+			requiredLabels: map[string]*regexp.Regexp{"fleet.azure.com/team": nil},
+			labels:         map[string]string{"fleet.azure.com/team": ""},
+		},
+		"all required labels are missing": {
+			requiredLabels: requiredLabels,
+			wantErrMsg:     `[the required label "fleet.azure.com/cost-center" is missing, the required label "fleet.azure.com/team" is missing]`,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := Config{RequiredLabels: tc.requiredLabels}
+			err := config.ValidateRequiredLabels(tc.labels)
+			if tc.wantErrMsg == "" {
+				if err != nil {
+					t.Errorf("ValidateRequiredLabels() = %v, want no error", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.wantErrMsg {
+				t.Errorf("ValidateRequiredLabels() = %v, want %s", err, tc.wantErrMsg)
+			}
+		})
+	}
+}
+
+func TestParseRequiredLabels(t *testing.T) {
+	testCases := map[string]struct {
+		str        string
+		want       map[string]string
+		wantErrMsg string
+	}{
+		"empty": {},
+		"keys with and without regular expressions": {
+			str:  `{"fleet.azure.com/team": "", "fleet.azure.com/cost-center": "^cc-[0-9]+$"}`,
+			want: map[string]string{"fleet.azure.com/team": "", "fleet.azure.com/cost-center": "^cc-[0-9]+$"},
+		},
+		"not a JSON object": {
+			str:        "fleet.azure.com/team",
+			wantErrMsg: "invalid required labels, must be a JSON object mapping label keys to regular expressions",
+		},
+		"invalid label key": {
+			str:        `{"team!": ""}`,
+			wantErrMsg: `invalid required label key "team!"`,
+		},
+		"invalid regular expression": {
+			str:        `{"fleet.azure.com/cost-center": "^cc-[0-9+$"}`,
+			wantErrMsg: `invalid regular expression of the required label "fleet.azure.com/cost-center"`,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseRequiredLabels(tc.str)
+			if tc.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErrMsg) {
+					t.Fatalf("ParseRequiredLabels() = %v, want error containing %q", err, tc.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRequiredLabels() = %v, want no error", err)
+			}
+			var gotPatterns map[string]string
+			for k, re := range got {
+				if gotPatterns == nil {
+					gotPatterns = make(map[string]string, len(got))
+				}
+				gotPatterns[k] = ""
+				if re != nil {
+					gotPatterns[k] = re.String()
+				}
+			}
+			if diff := cmp.Diff(tc.want, gotPatterns); diff != "" {
+				t.Errorf("ParseRequiredLabels() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestValidateClusterResourcePlacement_RolloutStrategy(t *testing.T) {
 	var unavailablePeriodSeconds = -10
 
@@ -217,6 +637,12 @@ func TestValidateClusterResourcePlacement_RolloutStrategy(t *testing.T) {
 		"empty rollout strategy": {
 			wantErr: false,
 		},
+		"valid rollout strategy - RollingUpdate": {
+			strategy: placementv1beta1.RolloutStrategy{
+				Type: placementv1beta1.RollingUpdateRolloutStrategyType,
+			},
+			wantErr: false,
+		},
 		"valid rollout strategy - External": {
 			strategy: placementv1beta1.RolloutStrategy{
 				Type: placementv1beta1.ExternalRolloutStrategyType,
@@ -238,7 +664,14 @@ func TestValidateClusterResourcePlacement_RolloutStrategy(t *testing.T) {
 				Type: "random type",
 			},
 			wantErr:    true,
-			wantErrMsg: "unsupported rollout strategy type `random type`",
+			wantErrMsg: `spec.strategy.type: Unsupported value: "random type": supported values: "RollingUpdate", "External"`,
+		},
+		"invalid rollout strategy - BlueGreen": {
+			strategy: placementv1beta1.RolloutStrategy{
+				Type: "BlueGreen",
+			},
+			wantErr:    true,
+			wantErrMsg: `spec.strategy.type: Unsupported value: "BlueGreen": supported values: "RollingUpdate", "External"`,
 		},
 		"invalid rollout strategy - UnavailablePeriodSeconds": {
 			strategy: placementv1beta1.RolloutStrategy{
@@ -342,6 +775,61 @@ func TestValidateClusterResourcePlacement_RolloutStrategy(t *testing.T) {
 	}
 }
 
+func TestIsValidStrategyType(t *testing.T) {
+	tests := map[string]struct {
+		strategyType placementv1beta1.RolloutStrategyType
+		want         bool
+	}{
+		"empty type defaults to RollingUpdate": {
+			want: true,
+		},
+		"RollingUpdate": {
+			strategyType: placementv1beta1.RollingUpdateRolloutStrategyType,
+			want:         true,
+		},
+		"External": {
+			strategyType: placementv1beta1.ExternalRolloutStrategyType,
+			want:         true,
+		},
+		"BlueGreen": {
+			strategyType: "BlueGreen",
+			want:         false,
+		},
+		"lower case RollingUpdate": {
+			strategyType: "rollingupdate",
+			want:         false,
+		},
+	}
+	for testName, testCase := range tests {
+		t.Run(testName, func(t *testing.T) {
+			if got := IsValidStrategyType(testCase.strategyType); got != testCase.want {
+				t.Errorf("IsValidStrategyType(%q) = %t, want %t", testCase.strategyType, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestValidateClusterResourcePlacementStrategyTypeCause(t *testing.T) {
+	crp := &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-crp"},
+		Spec: placementv1beta1.PlacementSpec{
+			Strategy: placementv1beta1.RolloutStrategy{Type: "BlueGreen"},
+		},
+	}
+	gotErr := Config{}.ValidateClusterResourcePlacement(crp)
+	if gotErr == nil {
+		t.Fatalf("ValidateClusterResourcePlacement() = nil, want the strategy type error")
+	}
+	want := []metav1.StatusCause{{
+		Type:    metav1.CauseTypeFieldValueNotSupported,
+		Message: `Unsupported value: "BlueGreen": supported values: "RollingUpdate", "External"`,
+		Field:   "spec.strategy.type",
+	}}
+	if diff := cmp.Diff(want, FieldErrorCauses(gotErr)); diff != "" {
+		t.Errorf("FieldErrorCauses(ValidateClusterResourcePlacement()) mismatch (-want, +got):\n%s", diff)
+	}
+}
+
 func TestValidateClusterResourcePlacement_PickFixedPlacementPolicy(t *testing.T) {
 	tests := map[string]struct {
 		policy     *placementv1beta1.PlacementPolicy
@@ -368,7 +856,7 @@ func TestValidateClusterResourcePlacement_PickFixedPlacementPolicy(t *testing.T)
 				ClusterNames:  []string{"test-cluster1", "test-cluster1", "test-cluster2", "test-cluster2"},
 			},
 			wantErr:    true,
-			wantErrMsg: "cluster names must be unique for policy type PickFixed",
+			wantErrMsg: `spec.policy.clusterNames[1]: Duplicate value: "test-cluster1"`,
 		},
 		"invalid placement policy - PickFixed with invalid cluster names": {
 			policy: &placementv1beta1.PlacementPolicy{
@@ -463,7 +951,7 @@ func TestValidateClusterResourcePlacement_PickFixedPlacementPolicy(t *testing.T)
 
 	for testName, testCase := range tests {
 		t.Run(testName, func(t *testing.T) {
-			gotErr := validatePlacementPolicy(testCase.policy)
+			gotErr := Config{}.validatePlacementPolicy(testCase.policy)
 			if (gotErr != nil) != testCase.wantErr {
 				t.Errorf("validatePlacementPolicy() error = %v, wantErr %v", gotErr, testCase.wantErr)
 			}
@@ -474,6 +962,133 @@ func TestValidateClusterResourcePlacement_PickFixedPlacementPolicy(t *testing.T)
 	}
 }
 
+func TestNormalizePolicy(t *testing.T) {
+	tests := map[string]struct {
+		policy     *placementv1beta1.PlacementPolicy
+		wantPolicy *placementv1beta1.PlacementPolicy
+	}{
+		"nil policy": {
+			policy:     nil,
+			wantPolicy: nil,
+		},
+		"zero-value policy": {
+			policy:     &placementv1beta1.PlacementPolicy{},
+			wantPolicy: nil,
+		},
+		"zero-value policy with empty lists": {
+			policy: &placementv1beta1.PlacementPolicy{
+				ClusterNames: []string{},
+				Tolerations:  []placementv1beta1.Toleration{},
+			},
+			wantPolicy: nil,
+		},
+		"policy with placement type": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType: placementv1beta1.PickAllPlacementType,
+			},
+			wantPolicy: &placementv1beta1.PlacementPolicy{
+				PlacementType: placementv1beta1.PickAllPlacementType,
+			},
+		},
+		"policy with cluster names only": {
+			policy: &placementv1beta1.PlacementPolicy{
+				ClusterNames: []string{"cluster1"},
+			},
+			wantPolicy: &placementv1beta1.PlacementPolicy{
+				ClusterNames: []string{"cluster1"},
+			},
+		},
+	}
+	for testName, testCase := range tests {
+		t.Run(testName, func(t *testing.T) {
+			crp := &placementv1beta1.ClusterResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-crp"},
+				Spec: placementv1beta1.PlacementSpec{
+					ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{resourceSelector},
+					Policy:            testCase.policy,
+				},
+			}
+			NormalizePolicy(crp)
+			if diff := cmp.Diff(testCase.wantPolicy, crp.Spec.Policy); diff != "" {
+				t.Errorf("NormalizePolicy() policy mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateClusterNames(t *testing.T) {
+	fldPath := field.NewPath("spec", "policy", "clusterNames")
+	tests := map[string]struct {
+		clusterNames    []string
+		maxClusterNames int
+		wantErrs        field.ErrorList
+	}{
+		"nil list": {
+			clusterNames: nil,
+			wantErrs:     field.ErrorList{},
+		},
+		"no duplicates": {
+			clusterNames: []string{"cluster1", "cluster2", "cluster3"},
+			wantErrs:     field.ErrorList{},
+		},
+		"one duplicate": {
+			clusterNames: []string{"cluster1", "cluster2", "cluster1"},
+			wantErrs: field.ErrorList{
+				field.Duplicate(fldPath.Index(2), "cluster1"),
+			},
+		},
+		"multiple duplicates": {
+			clusterNames: []string{"cluster1", "cluster1", "cluster2", "cluster2", "cluster1"},
+			wantErrs: field.ErrorList{
+				field.Duplicate(fldPath.Index(1), "cluster1"),
+				field.Duplicate(fldPath.Index(3), "cluster2"),
+				field.Duplicate(fldPath.Index(4), "cluster1"),
+			},
+		},
+		"at max length with default max": {
+			clusterNames: generateClusterNames(DefaultMaxClusterNames),
+			wantErrs:     field.ErrorList{},
+		},
+		"over max length with default max": {
+			clusterNames: generateClusterNames(DefaultMaxClusterNames + 1),
+			wantErrs: field.ErrorList{
+				field.TooMany(fldPath, DefaultMaxClusterNames+1, DefaultMaxClusterNames),
+			},
+		},
+		"at max length with configured max": {
+			clusterNames:    generateClusterNames(3),
+			maxClusterNames: 3,
+			wantErrs:        field.ErrorList{},
+		},
+		"over max length with configured max": {
+			clusterNames:    []string{"cluster1", "cluster2", "cluster3", "cluster1"},
+			maxClusterNames: 3,
+			wantErrs: field.ErrorList{
+				field.TooMany(fldPath, 4, 3),
+				field.Duplicate(fldPath.Index(3), "cluster1"),
+			},
+		},
+	}
+
+	for testName, testCase := range tests {
+		t.Run(testName, func(t *testing.T) {
+			config := Config{MaxClusterNames: testCase.maxClusterNames}
+			gotErrs := config.validateClusterNames(testCase.clusterNames)
+			if diff := cmp.Diff(testCase.wantErrs, gotErrs); diff != "" {
+				t.Errorf("validateClusterNames() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func generateClusterNames(n int) []string {
+	clusterNames := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		clusterNames = append(clusterNames, fmt.Sprintf("cluster%d", i))
+	}
+	return clusterNames
+}
+
 func TestValidateClusterResourcePlacement_PickAllPlacementPolicy(t *testing.T) {
 	tests := map[string]struct {
 		policy     *placementv1beta1.PlacementPolicy
@@ -745,7 +1360,7 @@ func TestValidateClusterResourcePlacement_PickAllPlacementPolicy(t *testing.T) {
 	}
 	for testName, testCase := range tests {
 		t.Run(testName, func(t *testing.T) {
-			gotErr := validatePlacementPolicy(testCase.policy)
+			gotErr := Config{}.validatePlacementPolicy(testCase.policy)
 			if (gotErr != nil) != testCase.wantErr {
 				t.Errorf("validatePlacementPolicy() error = %v, wantErr %v", gotErr, testCase.wantErr)
 			}
@@ -1269,7 +1884,7 @@ func TestValidateClusterResourcePlacement_PickNPlacementPolicy(t *testing.T) {
 
 	for testName, testCase := range tests {
 		t.Run(testName, func(t *testing.T) {
-			gotErr := validatePlacementPolicy(testCase.policy)
+			gotErr := Config{}.validatePlacementPolicy(testCase.policy)
 			if (gotErr != nil) != testCase.wantErr {
 				t.Errorf("validatePlacementPolicy() error = %v, wantErr %v", gotErr, testCase.wantErr)
 			}
@@ -1544,15 +2159,38 @@ func TestValidateTolerations(t *testing.T) {
 			wantErr:    true,
 			wantErrMsg: "toleration value needs to be empty, when operator is Exists",
 		},
-		"invalid toleration, non-unique toleration": {
+		"invalid toleration, PreferNoSchedule effect": {
 			tolerations: []placementv1beta1.Toleration{
 				{
 					Key:      "key1",
-					Operator: corev1.TolerationOpEqual,
-					Value:    "value1",
-					Effect:   corev1.TaintEffectNoSchedule,
+					Operator: corev1.TolerationOpExists,
+					Effect:   corev1.TaintEffectPreferNoSchedule,
 				},
-				{
+			},
+			wantErr:    true,
+			wantErrMsg: `unsupported effect "PreferNoSchedule", supported effects are NoSchedule`,
+		},
+		"invalid toleration, NoExecute effect": {
+			tolerations: []placementv1beta1.Toleration{
+				{
+					Key:      "key1",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "value1",
+					Effect:   corev1.TaintEffectNoExecute,
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: `unsupported effect "NoExecute", supported effects are NoSchedule`,
+		},
+		"invalid toleration, non-unique toleration": {
+			tolerations: []placementv1beta1.Toleration{
+				{
+					Key:      "key1",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "value1",
+					Effect:   corev1.TaintEffectNoSchedule,
+				},
+				{
 					Key:      "key2",
 					Operator: corev1.TolerationOpExists,
 					Effect:   corev1.TaintEffectNoSchedule,
@@ -1733,6 +2371,299 @@ func TestIsTolerationsUpdatedOrDeleted(t *testing.T) {
 			},
 			want: false,
 		},
+		"same tolerations in a different order": {
+			oldTolerations: []placementv1beta1.Toleration{
+				{
+					Key:      "key1",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "value1",
+					Effect:   corev1.TaintEffectNoSchedule,
+				},
+				{
+					Key:      "key2",
+					Operator: corev1.TolerationOpExists,
+					Effect:   corev1.TaintEffectNoSchedule,
+				},
+			},
+			newTolerations: []placementv1beta1.Toleration{
+				{
+					Key:      "key2",
+					Operator: corev1.TolerationOpExists,
+					Effect:   corev1.TaintEffectNoSchedule,
+				},
+				{
+					Key:      "key1",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "value1",
+					Effect:   corev1.TaintEffectNoSchedule,
+				},
+			},
+			want: false,
+		},
+		"operator was changed from Equal to Exists": {
+			oldTolerations: []placementv1beta1.Toleration{
+				{
+					Key:      "key1",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "value1",
+					Effect:   corev1.TaintEffectNoSchedule,
+				},
+			},
+			newTolerations: []placementv1beta1.Toleration{
+				{
+					Key:      "key1",
+					Operator: corev1.TolerationOpExists,
+					Effect:   corev1.TaintEffectNoSchedule,
+				},
+			},
+			want: true,
+		},
+		"operator was changed from Exists to Equal": {
+			oldTolerations: []placementv1beta1.Toleration{
+				{
+					Key:      "key2",
+					Operator: corev1.TolerationOpExists,
+					Effect:   corev1.TaintEffectNoSchedule,
+				},
+			},
+			newTolerations: []placementv1beta1.Toleration{
+				{
+					Key:      "key2",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "value2",
+					Effect:   corev1.TaintEffectNoSchedule,
+				},
+			},
+			want: true,
+		},
+		"omitted operator is defaulted to Equal": {
+			oldTolerations: []placementv1beta1.Toleration{
+				{
+					Key:    "key1",
+					Value:  "value1",
+					Effect: corev1.TaintEffectNoSchedule,
+				},
+			},
+			newTolerations: []placementv1beta1.Toleration{
+				{
+					Key:      "key1",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "value1",
+					Effect:   corev1.TaintEffectNoSchedule,
+				},
+			},
+			want: false,
+		},
+		"Equal operator is omitted": {
+			oldTolerations: []placementv1beta1.Toleration{
+				{
+					Key:      "key1",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "value1",
+					Effect:   corev1.TaintEffectNoSchedule,
+				},
+			},
+			newTolerations: []placementv1beta1.Toleration{
+				{
+					Key:    "key1",
+					Value:  "value1",
+					Effect: corev1.TaintEffectNoSchedule,
+				},
+			},
+			want: false,
+		},
+		"effect was changed from NoSchedule to NoExecute": {
+			oldTolerations: []placementv1beta1.Toleration{
+				{
+					Key:      "key1",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "value1",
+					Effect:   corev1.TaintEffectNoSchedule,
+				},
+			},
+			newTolerations: []placementv1beta1.Toleration{
+				{
+					Key:      "key1",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "value1",
+					Effect:   corev1.TaintEffectNoExecute,
+				},
+			},
+			want: true,
+		},
+		"effect was changed from PreferNoSchedule to NoSchedule": {
+			oldTolerations: []placementv1beta1.Toleration{
+				{
+					Key:      "key1",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "value1",
+					Effect:   corev1.TaintEffectPreferNoSchedule,
+				},
+			},
+			newTolerations: []placementv1beta1.Toleration{
+				{
+					Key:      "key1",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "value1",
+					Effect:   corev1.TaintEffectNoSchedule,
+				},
+			},
+			want: true,
+		},
+		"effect was removed to match all effects": {
+			oldTolerations: []placementv1beta1.Toleration{
+				{
+					Key:      "key1",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "value1",
+					Effect:   corev1.TaintEffectNoSchedule,
+				},
+			},
+			newTolerations: []placementv1beta1.Toleration{
+				{
+					Key:      "key1",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "value1",
+				},
+			},
+			want: true,
+		},
+		"same PreferNoSchedule toleration": {
+			oldTolerations: []placementv1beta1.Toleration{
+				{
+					Key:      "key1",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "value1",
+					Effect:   corev1.TaintEffectPreferNoSchedule,
+				},
+			},
+			newTolerations: []placementv1beta1.Toleration{
+				{
+					Key:      "key1",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "value1",
+					Effect:   corev1.TaintEffectPreferNoSchedule,
+				},
+			},
+			want: false,
+		},
+		"same Exists toleration with empty key": {
+			oldTolerations: []placementv1beta1.Toleration{
+				{
+					Operator: corev1.TolerationOpExists,
+				},
+			},
+			newTolerations: []placementv1beta1.Toleration{
+				{
+					Operator: corev1.TolerationOpExists,
+				},
+			},
+			want: false,
+		},
+		"Exists toleration with empty key was given a key": {
+			oldTolerations: []placementv1beta1.Toleration{
+				{
+					Operator: corev1.TolerationOpExists,
+				},
+			},
+			newTolerations: []placementv1beta1.Toleration{
+				{
+					Key:      "key1",
+					Operator: corev1.TolerationOpExists,
+				},
+			},
+			want: true,
+		},
+		"Exists toleration with empty key was deleted": {
+			oldTolerations: []placementv1beta1.Toleration{
+				{
+					Operator: corev1.TolerationOpExists,
+				},
+				{
+					Key:      "key1",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "value1",
+					Effect:   corev1.TaintEffectNoSchedule,
+				},
+			},
+			newTolerations: []placementv1beta1.Toleration{
+				{
+					Key:      "key1",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "value1",
+					Effect:   corev1.TaintEffectNoSchedule,
+				},
+			},
+			want: true,
+		},
+		"Exists toleration with empty key was added": {
+			oldTolerations: []placementv1beta1.Toleration{
+				{
+					Key:      "key1",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "value1",
+					Effect:   corev1.TaintEffectNoSchedule,
+				},
+			},
+			newTolerations: []placementv1beta1.Toleration{
+				{
+					Key:      "key1",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "value1",
+					Effect:   corev1.TaintEffectNoSchedule,
+				},
+				{
+					Operator: corev1.TolerationOpExists,
+				},
+			},
+			want: false,
+		},
+		"value was changed for Equal operator": {
+			oldTolerations: []placementv1beta1.Toleration{
+				{
+					Key:      "key1",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "value1",
+					Effect:   corev1.TaintEffectNoSchedule,
+				},
+			},
+			newTolerations: []placementv1beta1.Toleration{
+				{
+					Key:      "key1",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "value2",
+					Effect:   corev1.TaintEffectNoSchedule,
+				},
+			},
+			want: true,
+		},
+		"key was changed": {
+			oldTolerations: []placementv1beta1.Toleration{
+				{
+					Key:      "key1",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "value1",
+					Effect:   corev1.TaintEffectNoSchedule,
+				},
+			},
+			newTolerations: []placementv1beta1.Toleration{
+				{
+					Key:      "key3",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "value1",
+					Effect:   corev1.TaintEffectNoSchedule,
+				},
+			},
+			want: true,
+		},
+		"old tolerations, new tolerations are empty": {
+			oldTolerations: []placementv1beta1.Toleration{},
+			newTolerations: []placementv1beta1.Toleration{},
+			want:           false,
+		},
+		"old tolerations, new tolerations are nil": {
+			want: false,
+		},
 	}
 	for testName, testCase := range tests {
 		t.Run(testName, func(t *testing.T) {
@@ -1743,6 +2674,92 @@ func TestIsTolerationsUpdatedOrDeleted(t *testing.T) {
 	}
 }
 
+func TestValidateAddedTolerationsNotRedundant(t *testing.T) {
+	fldPath := field.NewPath("spec", "policy", "tolerations")
+	equalKey1 := placementv1beta1.Toleration{Key: "key1", Operator: corev1.TolerationOpEqual, Value: "value1", Effect: corev1.TaintEffectNoSchedule}
+	equalKey1DefaultOperator := placementv1beta1.Toleration{Key: "key1", Value: "value1", Effect: corev1.TaintEffectNoSchedule}
+	existsKey1 := placementv1beta1.Toleration{Key: "key1", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}
+	equalKey1AnyEffect := placementv1beta1.Toleration{Key: "key1", Operator: corev1.TolerationOpEqual, Value: "value1"}
+	equalKey1OtherValue := placementv1beta1.Toleration{Key: "key1", Operator: corev1.TolerationOpEqual, Value: "value2", Effect: corev1.TaintEffectNoSchedule}
+	existsKey1OtherEffect := placementv1beta1.Toleration{Key: "key1", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute}
+	equalKey2 := placementv1beta1.Toleration{Key: "key2", Operator: corev1.TolerationOpEqual, Value: "value1", Effect: corev1.TaintEffectNoSchedule}
+	existsAllKeys := placementv1beta1.Toleration{Operator: corev1.TolerationOpExists}
+
+	tests := map[string]struct {
+		oldTolerations []placementv1beta1.Toleration
+		newTolerations []placementv1beta1.Toleration
+		wantErrs       field.ErrorList
+	}{
+		"distinct additions": {
+			oldTolerations: []placementv1beta1.Toleration{equalKey1},
+			newTolerations: []placementv1beta1.Toleration{equalKey1, equalKey1OtherValue, existsKey1OtherEffect, equalKey2},
+			wantErrs:       field.ErrorList{},
+		},
+		"exact duplicate of an existing toleration": {
+			oldTolerations: []placementv1beta1.Toleration{equalKey1},
+			newTolerations: []placementv1beta1.Toleration{equalKey1, equalKey2, equalKey1},
+			wantErrs: field.ErrorList{
+				field.Invalid(fldPath.Index(2), equalKey1, "duplicates spec.policy.tolerations[0]"),
+			},
+		},
+		"exact duplicate with the defaulted operator": {
+			oldTolerations: []placementv1beta1.Toleration{equalKey2},
+			newTolerations: []placementv1beta1.Toleration{equalKey2, equalKey1, equalKey1DefaultOperator},
+			wantErrs: field.ErrorList{
+				field.Invalid(fldPath.Index(2), equalKey1DefaultOperator, "duplicates spec.policy.tolerations[1]"),
+			},
+		},
+		"added exact duplicates": {
+			newTolerations: []placementv1beta1.Toleration{equalKey2, equalKey2},
+			wantErrs: field.ErrorList{
+				field.Invalid(fldPath.Index(1), equalKey2, "duplicates spec.policy.tolerations[0]"),
+			},
+		},
+		"added Exists shadowing an existing Equal": {
+			oldTolerations: []placementv1beta1.Toleration{equalKey1},
+			newTolerations: []placementv1beta1.Toleration{equalKey1, existsKey1},
+			wantErrs: field.ErrorList{
+				field.Invalid(fldPath.Index(1), existsKey1, "shadows spec.policy.tolerations[0]"),
+			},
+		},
+		"added Equal shadowed by an existing Exists": {
+			oldTolerations: []placementv1beta1.Toleration{existsKey1},
+			newTolerations: []placementv1beta1.Toleration{equalKey1, existsKey1},
+			wantErrs: field.ErrorList{
+				field.Invalid(fldPath.Index(0), equalKey1, "is shadowed by spec.policy.tolerations[1]"),
+			},
+		},
+		"added toleration of every effect shadowing an existing one": {
+			oldTolerations: []placementv1beta1.Toleration{equalKey1},
+			newTolerations: []placementv1beta1.Toleration{equalKey1, equalKey1AnyEffect},
+			wantErrs: field.ErrorList{
+				field.Invalid(fldPath.Index(1), equalKey1AnyEffect, "shadows spec.policy.tolerations[0]"),
+			},
+		},
+		"added toleration of every key shadowing the others": {
+			oldTolerations: []placementv1beta1.Toleration{equalKey1, equalKey2},
+			newTolerations: []placementv1beta1.Toleration{equalKey1, equalKey2, existsAllKeys},
+			wantErrs: field.ErrorList{
+				field.Invalid(fldPath.Index(2), existsAllKeys, "shadows spec.policy.tolerations[0]"),
+				field.Invalid(fldPath.Index(2), existsAllKeys, "shadows spec.policy.tolerations[1]"),
+			},
+		},
+		"redundant existing tolerations": {
+			oldTolerations: []placementv1beta1.Toleration{equalKey1, existsKey1},
+			newTolerations: []placementv1beta1.Toleration{equalKey1, existsKey1, equalKey2},
+			wantErrs:       field.ErrorList{},
+		},
+	}
+	for testName, testCase := range tests {
+		t.Run(testName, func(t *testing.T) {
+			gotErrs := validateAddedTolerationsNotRedundant(testCase.oldTolerations, testCase.newTolerations)
+			if diff := cmp.Diff(testCase.wantErrs, gotErrs); diff != "" {
+				t.Errorf("validateAddedTolerationsNotRedundant() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestValidateResourcePlacement(t *testing.T) {
 	tests := map[string]struct {
 		rp               *placementv1beta1.ResourcePlacement
@@ -1859,7 +2876,7 @@ func TestValidateResourcePlacement(t *testing.T) {
 		t.Run(testName, func(t *testing.T) {
 			RestMapper = utils.TestMapper{}
 			ResourceInformer = testCase.resourceInformer
-			gotErr := ValidateResourcePlacement(testCase.rp)
+			gotErr := Config{}.ValidateResourcePlacement(testCase.rp)
 			if (gotErr != nil) != testCase.wantErr {
 				t.Errorf("ValidateResourcePlacement() error = %v, wantErr %v", gotErr, testCase.wantErr)
 			}
@@ -1869,3 +2886,181 @@ func TestValidateResourcePlacement(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateResourcePlacementTolerationKeys(t *testing.T) {
+	tolerations := []placementv1beta1.Toleration{
+		{
+			Key:      "team",
+			Operator: corev1.TolerationOpEqual,
+			Value:    "a",
+			Effect:   corev1.TaintEffectNoSchedule,
+		},
+		{
+			Key:      "fleet.io/maintenance",
+			Operator: corev1.TolerationOpExists,
+			Effect:   corev1.TaintEffectNoSchedule,
+		},
+	}
+	tests := map[string]struct {
+		prefixes    []string
+		tolerations []placementv1beta1.Toleration
+		wantErrMsgs []string
+	}{
+		"key with a denied prefix": {
+			prefixes:    []string{"admin.example.com/", "fleet.io/"},
+			tolerations: tolerations,
+			wantErrMsgs: []string{`spec.policy.tolerations[1].key: Forbidden: toleration key "fleet.io/maintenance" has the prefix "fleet.io/"`},
+		},
+		"empty key with the Exists operator": {
+			prefixes: []string{"fleet.io/"},
+			tolerations: []placementv1beta1.Toleration{
+				{
+					Operator: corev1.TolerationOpExists,
+				},
+			},
+			wantErrMsgs: []string{"spec.policy.tolerations[0].key: Forbidden: a toleration with an empty key and the Exists operator tolerates every taint"},
+		},
+		"keys without a denied prefix": {
+			prefixes:    []string{"admin.example.com/", "fleet.io/admin-"},
+			tolerations: tolerations,
+		},
+		"no denied prefixes": {
+			tolerations: tolerations,
+		},
+	}
+	for testName, tc := range tests {
+		t.Run(testName, func(t *testing.T) {
+			RestMapper = utils.TestMapper{}
+			ResourceInformer = &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.DeploymentGVK: true},
+				IsClusterScopedResource: false,
+			}
+			config := Config{ResourcePlacementDeniedTolerationKeyPrefixes: tc.prefixes}
+			policy := &placementv1beta1.PlacementPolicy{
+				PlacementType: placementv1beta1.PickAllPlacementType,
+				Tolerations:   tc.tolerations,
+			}
+			rp := &placementv1beta1.ResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-rp", Namespace: "test-namespace"},
+				Spec: placementv1beta1.PlacementSpec{
+					ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{
+						{
+							Group:   "apps",
+							Version: "v1",
+							Kind:    "Deployment",
+							Name:    "test-deployment",
+						},
+					},
+					Policy: policy,
+				},
+			}
+			gotErr := config.ValidateResourcePlacement(rp)
+			if len(tc.wantErrMsgs) == 0 {
+				if gotErr != nil {
+					t.Errorf("ValidateResourcePlacement() = %v, want no error", gotErr)
+				}
+			} else {
+				if gotErr == nil {
+					t.Fatalf("ValidateResourcePlacement() = nil, want error containing %v", tc.wantErrMsgs)
+				}
+				for _, msg := range tc.wantErrMsgs {
+					if !strings.Contains(gotErr.Error(), msg) {
+						t.Errorf("ValidateResourcePlacement() = %v, want error containing %s", gotErr, msg)
+					}
+				}
+			}
+
+			// The tolerations of ClusterResourcePlacements are never restricted.
+			ResourceInformer = &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.NamespaceGVK: true},
+				IsClusterScopedResource: true,
+			}
+			crp := &placementv1beta1.ClusterResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-crp"},
+				Spec: placementv1beta1.PlacementSpec{
+					ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{
+						{
+							Group:   "",
+							Version: "v1",
+							Kind:    "Namespace",
+							Name:    "test-namespace",
+						},
+					},
+					Policy: policy,
+				},
+			}
+			if err := config.ValidateClusterResourcePlacement(crp); err != nil {
+				t.Errorf("ValidateClusterResourcePlacement() = %v, want no error", err)
+			}
+		})
+	}
+}
+
+func TestValidateResourcePlacementPolicy(t *testing.T) {
+	config := Config{ResourcePlacementDeniedTolerationKeyPrefixes: []string{"fleet.io/"}}
+
+	tests := map[string]struct {
+		policy         *placementv1beta1.PlacementPolicy
+		wantRPErrMsgs  []string
+		wantCRPErrMsgs []string
+	}{
+		"nil policy": {},
+		"toleration of a reserved taint is only valid for a cluster-scoped placement": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType: placementv1beta1.PickAllPlacementType,
+				Tolerations: []placementv1beta1.Toleration{
+					{Key: "fleet.io/maintenance", Operator: corev1.TolerationOpExists},
+				},
+			},
+			wantRPErrMsgs: []string{`spec.policy.tolerations[0].key: Forbidden: toleration key "fleet.io/maintenance" has the prefix "fleet.io/"`},
+		},
+		"toleration of every taint is only valid for a cluster-scoped placement": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:    placementv1beta1.PickNPlacementType,
+				NumberOfClusters: &positiveNumberOfClusters,
+				Tolerations: []placementv1beta1.Toleration{
+					{Operator: corev1.TolerationOpExists},
+				},
+			},
+			wantRPErrMsgs: []string{"spec.policy.tolerations[0].key: Forbidden: a toleration with an empty key and the Exists operator tolerates every taint"},
+		},
+		"cluster names are valid for both placements": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType: placementv1beta1.PickFixedPlacementType,
+				ClusterNames:  []string{"member-1"},
+			},
+		},
+		"shared validations apply to both placements": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType: placementv1beta1.PickAllPlacementType,
+				ClusterNames:  []string{"member-1"},
+			},
+			wantRPErrMsgs:  []string{"cluster names needs to be empty for policy type PickAll"},
+			wantCRPErrMsgs: []string{"cluster names needs to be empty for policy type PickAll"},
+		},
+	}
+	for testName, tc := range tests {
+		t.Run(testName, func(t *testing.T) {
+			assertErrMsgs := func(name string, gotErr error, wantErrMsgs []string) {
+				if len(wantErrMsgs) == 0 {
+					if gotErr != nil {
+						t.Errorf("%s() = %v, want no error", name, gotErr)
+					}
+					return
+				}
+				if gotErr == nil {
+					t.Fatalf("%s() = nil, want error containing %v", name, wantErrMsgs)
+				}
+				for _, msg := range wantErrMsgs {
+					if !strings.Contains(gotErr.Error(), msg) {
+						t.Errorf("%s() = %v, want error containing %s", name, gotErr, msg)
+					}
+				}
+			}
+			assertErrMsgs("ValidateResourcePlacementPolicy", config.ValidateResourcePlacementPolicy(tc.policy), tc.wantRPErrMsgs)
+			if tc.policy != nil {
+				assertErrMsgs("validatePlacementPolicy", config.validatePlacementPolicy(tc.policy), tc.wantCRPErrMsgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+// ValidatePlacementNameCollision denies the creation of a placement whose name is already used by a placement of
+// the other scope, i.e., a ClusterResourcePlacement and a ResourcePlacement in any namespace, as the namespaces and
+// works they generate on the member clusters could collide. The check is skipped unless
+// Config.DenyPlacementNameCollisions is set, and the request is allowed with a warning if the lookup fails. The
+// lookup is bounded by the lookup budget.
+//
+// Placements of both scopes created at the same time may both pass the check. The tiebreak in that case is
+// deterministic: the ClusterResourcePlacement takes precedence and the ResourcePlacement must be deleted, as
+// spelled out in the deny message.
+func (c Config) ValidatePlacementNameCollision(ctx context.Context, reader client.Reader, placement placementv1beta1.PlacementObj, resp admission.Response) admission.Response {
+	if !c.DenyPlacementNameCollisions || reader == nil {
+		return resp
+	}
+	ctx, cancel := c.WithLookupBudget(ctx, 0)
+	defer cancel()
+	conflicts, err := findPlacementNameCollisions(ctx, reader, placement)
+	if err != nil {
+		if exhausted := c.LookupBudgetExhausted(ctx, PlacementNameCollisionLookupCheck, err); exhausted != nil {
+			return LookupUnavailableResponse(exhausted, resp)
+		}
+		klog.ErrorS(err, "Failed to look up placements of the other scope, allowing the request", "placement", klog.KObj(placement))
+		resp.Warnings = append(resp.Warnings, fmt.Sprintf("failed to check whether the name %q is used by a placement of the other scope: %v", placement.GetName(), err))
+		return resp
+	}
+	if len(conflicts) == 0 {
+		return resp
+	}
+	klog.V(2).InfoS("Placement name collides with a placement of the other scope, request is denied", "placement", klog.KObj(placement), "conflicts", conflicts)
+	return admission.Denied(c.DenialMessage(PlacementNameCollisionMessageID, map[string]any{"name": placement.GetName(), "conflicts": strings.Join(conflicts, ", ")}))
+}
+
+// findPlacementNameCollisions returns the sorted placements of the other scope which have the same name.
+func findPlacementNameCollisions(ctx context.Context, c client.Reader, placement placementv1beta1.PlacementObj) ([]string, error) {
+	if placement.GetNamespace() != "" {
+		crp := &placementv1beta1.ClusterResourcePlacement{}
+		if err := c.Get(ctx, types.NamespacedName{Name: placement.GetName()}, crp); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return []string{fmt.Sprintf("clusterResourcePlacement %s", crp.Name)}, nil
+	}
+	rpList := &placementv1beta1.ResourcePlacementList{}
+	if err := ListByIndex(ctx, c, rpList, PlacementNameIndexKey, placement.GetName()); err != nil {
+		return nil, err
+	}
+	var conflicts []string
+	for i := range rpList.Items {
+		conflicts = append(conflicts, fmt.Sprintf("resourcePlacement %s/%s", rpList.Items[i].Namespace, rpList.Items[i].Name))
+	}
+	sort.Strings(conflicts)
+	return conflicts, nil
+}
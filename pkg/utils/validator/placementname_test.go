@@ -0,0 +1,123 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+func TestValidatePlacementNameCollision(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() = %v, want no error", err)
+	}
+	newCRP := func(name string) *placementv1beta1.ClusterResourcePlacement {
+		return &placementv1beta1.ClusterResourcePlacement{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+	newRP := func(namespace, name string) *placementv1beta1.ResourcePlacement {
+		return &placementv1beta1.ResourcePlacement{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	}
+	lookupErr := errors.New("lookup failed")
+
+	testCases := map[string]struct {
+		disabled          bool
+		existing          []client.Object
+		lookupErr         error
+		placement         placementv1beta1.PlacementObj
+		wantDeniedMessage string
+		wantWarnings      []string
+	}{
+		"CRP name used by RPs": {
+			existing:          []client.Object{newRP("ns-b", "web"), newRP("ns-a", "web"), newRP("ns-a", "api")},
+			placement:         newCRP("web"),
+			wantDeniedMessage: `the name "web" is already used by resourcePlacement ns-a/web, resourcePlacement ns-b/web;`,
+		},
+		"RP name used by a CRP": {
+			existing:          []client.Object{newCRP("web")},
+			placement:         newRP("apps", "web"),
+			wantDeniedMessage: `the name "web" is already used by clusterResourcePlacement web;`,
+		},
+		"CRP name not used by any RP": {
+			existing:  []client.Object{newRP("apps", "api"), newCRP("web-2")},
+			placement: newCRP("web"),
+		},
+		"RP name not used by any CRP": {
+			existing:  []client.Object{newCRP("api"), newRP("other", "web")},
+			placement: newRP("apps", "web"),
+		},
+		"check is disabled": {
+			disabled:  true,
+			existing:  []client.Object{newCRP("web")},
+			placement: newRP("apps", "web"),
+		},
+		"failed to list RPs": {
+			lookupErr:    lookupErr,
+			placement:    newCRP("web"),
+			wantWarnings: []string{`failed to check whether the name "web" is used by a placement of the other scope: lookup failed`},
+		},
+		"failed to get the CRP": {
+			lookupErr:    lookupErr,
+			placement:    newRP("apps", "web"),
+			wantWarnings: []string{`failed to check whether the name "web" is used by a placement of the other scope: lookup failed`},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := Config{DenyPlacementNameCollisions: !tc.disabled}
+			builder := newIndexedClientBuilder(scheme).WithObjects(tc.existing...)
+			if tc.lookupErr != nil {
+				builder = builder.WithInterceptorFuncs(interceptor.Funcs{
+					Get: func(context.Context, client.WithWatch, client.ObjectKey, client.Object, ...client.GetOption) error {
+						return tc.lookupErr
+					},
+					List: func(context.Context, client.WithWatch, client.ObjectList, ...client.ListOption) error {
+						return tc.lookupErr
+					},
+				})
+			}
+
+			resp := config.ValidatePlacementNameCollision(context.Background(), builder.Build(), tc.placement, admission.Allowed("allowed"))
+			if tc.wantDeniedMessage != "" {
+				if resp.Allowed || !strings.Contains(resp.Result.Message, tc.wantDeniedMessage) {
+					t.Errorf("ValidatePlacementNameCollision() = %+v, want denied with message containing %q", resp.Result, tc.wantDeniedMessage)
+				}
+				if !strings.Contains(resp.Result.Message, "the ClusterResourcePlacement takes precedence") {
+					t.Errorf("ValidatePlacementNameCollision() message = %q, want the tiebreak explained", resp.Result.Message)
+				}
+				return
+			}
+			if !resp.Allowed {
+				t.Errorf("ValidatePlacementNameCollision() = %+v, want allowed", resp.Result)
+			}
+			if diff := cmp.Diff(tc.wantWarnings, resp.Warnings); diff != "" {
+				t.Errorf("ValidatePlacementNameCollision() warnings mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,392 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/defaulter"
+)
+
+// ValidationClass classifies a placement validation rule by whether trusted identities may skip it.
+type ValidationClass string
+
+const (
+	// CorrectnessValidation rules protect the invariants fleet relies on (e.g., immutable or required fields);
+	// they are enforced for every identity.
+	CorrectnessValidation ValidationClass = "Correctness"
+
+	// AdvisoryValidation rules guard against likely mistakes (e.g., warnings, strict decoding, spec-size limits);
+	// they are skipped for trusted identities.
+	AdvisoryValidation ValidationClass = "Advisory"
+)
+
+// RuleMaturity decides whether a failing placement validation rule denies the request.
+type RuleMaturity string
+
+const (
+	// EnforcedRule rules deny the request when they fail.
+	EnforcedRule RuleMaturity = "Enforced"
+
+	// ShadowRule rules only record a metric and return a warning when they fail, so that a new rule can be
+	// observed against the existing workloads before it is enforced.
+	ShadowRule RuleMaturity = "Shadow"
+)
+
+// PlacementValidationRule is a validation rule run by HandlePlacementValidation on placement create and update.
+type PlacementValidationRule struct {
+	// Name identifies the rule in logs.
+	Name string
+	// Class decides whether the rule is skipped for trusted identities.
+	Class ValidationClass
+	// Maturity decides whether the rule denies the request when it fails; it defaults to EnforcedRule.
+	// Rules listed in Config.ShadowValidationRules run as ShadowRule regardless.
+	Maturity RuleMaturity
+	// Validate returns an error if the request should be denied under the validator settings; oldPlacement is nil
+	// on create.
+	Validate func(ctx context.Context, config Config, req admission.Request, placement, oldPlacement placementv1beta1.PlacementObj) error
+	// Warn returns the warnings to attach to an allowed request; it is optional.
+	Warn func(config Config, req admission.Request, placement, oldPlacement placementv1beta1.PlacementObj) []string
+	// SpecIndependent marks the rules which do not look at the placement spec; they still run on the updates which
+	// leave the spec of a placement with invalid fields untouched.
+	SpecIndependent bool
+}
+
+// maturity returns the effective maturity of the rule under the validator settings.
+func (r PlacementValidationRule) maturity(config Config) RuleMaturity {
+	if r.Maturity == ShadowRule || config.isShadowValidationRule(r.Name) {
+		return ShadowRule
+	}
+	return EnforcedRule
+}
+
+// PlacementValidationRuleNames returns the names of the rules HandlePlacementValidation runs.
+func PlacementValidationRuleNames() []string {
+	names := make([]string, 0, len(placementValidationRules))
+	for _, rule := range placementValidationRules {
+		names = append(names, rule.Name)
+	}
+	return names
+}
+
+// maxAnnotationValueBytes is the size above which an annotation value is assumed to carry an embedded
+// manifest, which fleet would copy onto the derived bindings and works.
+const maxAnnotationValueBytes = 64 * 1024
+
+// placementValidationRules are the rules HandlePlacementValidation runs before validating the placement spec.
+var placementValidationRules = []PlacementValidationRule{
+	{
+		Name:     "PlacementTypeImmutable",
+		Class:    CorrectnessValidation,
+		Validate: validatePlacementTypeImmutable,
+	},
+	{
+		Name:     "TolerationsAddOnly",
+		Class:    CorrectnessValidation,
+		Validate: validateTolerationsAddOnly,
+	},
+	{
+		Name:            "GenerationNotStale",
+		Class:           CorrectnessValidation,
+		Validate:        validateGenerationNotStale,
+		SpecIndependent: true,
+	},
+	{
+		Name:     "StrategyTypeTransition",
+		Class:    CorrectnessValidation,
+		Validate: validateStrategyTypeTransition,
+	},
+	{
+		Name:     "NoSpecUpdateDuringUpdateRun",
+		Class:    CorrectnessValidation,
+		Validate: validateNoSpecUpdateDuringUpdateRun,
+	},
+	{
+		Name:     "NoSpecUpdateWhileRolloutPaused",
+		Class:    CorrectnessValidation,
+		Validate: validateNoSpecUpdateWhilePaused,
+	},
+	{
+		Name:     "RevisionHistoryLimitReduction",
+		Class:    CorrectnessValidation,
+		Validate: validateRevisionHistoryLimitReduction,
+	},
+	{
+		Name:            "MetadataSize",
+		Class:           CorrectnessValidation,
+		Validate:        validateMetadataSize,
+		Warn:            warnMetadataSize,
+		SpecIndependent: true,
+	},
+	{
+		Name:     "PolicyListSizes",
+		Class:    AdvisoryValidation,
+		Validate: validatePolicyListSizes,
+		Warn:     warnPolicyListSizes,
+	},
+	{
+		Name:     "MaxUnavailableBelowClusterCount",
+		Class:    AdvisoryValidation,
+		Validate: validateMaxUnavailableBelowClusterCount,
+		Warn:     warnMaxUnavailableBelowClusterCount,
+	},
+	{
+		Name:     "SecretPropagationOptIn",
+		Class:    AdvisoryValidation,
+		Validate: validateSecretPropagationOptIn,
+		Warn:     warnSecretPropagation,
+	},
+	{
+		Name:     "CRDCoSelection",
+		Class:    AdvisoryValidation,
+		Validate: validateCRDCoSelection,
+		Warn:     warnCRDCoSelection,
+	},
+}
+
+// validatePlacementTypeImmutable denies the update if the placement type is changed.
+func validatePlacementTypeImmutable(_ context.Context, config Config, _ admission.Request, placement, oldPlacement placementv1beta1.PlacementObj) error {
+	if oldPlacement == nil {
+		return nil
+	}
+	if IsPlacementPolicyTypeUpdated(oldPlacement.GetPlacementSpec().Policy, placement.GetPlacementSpec().Policy) {
+		return errors.New(config.DenialMessage(PlacementTypeImmutableMessageID, nil))
+	}
+	return nil
+}
+
+// validateTolerationsAddOnly denies the update if any existing toleration is updated or deleted, or if any added
+// toleration duplicates or shadows another toleration.
+func validateTolerationsAddOnly(_ context.Context, _ Config, _ admission.Request, placement, oldPlacement placementv1beta1.PlacementObj) error {
+	if oldPlacement == nil {
+		return nil
+	}
+	if IsTolerationsUpdatedOrDeleted(oldPlacement.GetPlacementSpec().Tolerations(), placement.GetPlacementSpec().Tolerations()) {
+		return errors.New("tolerations have been updated/deleted, only additions to tolerations are allowed")
+	}
+	return validateAddedTolerationsNotRedundant(oldPlacement.GetPlacementSpec().Tolerations(), placement.GetPlacementSpec().Tolerations()).ToAggregate()
+}
+
+// validateGenerationNotStale denies the update if the placement carries a generation lower than the existing
+// object's. It is only a defense in depth: the API server sets the generation from the stored object before the
+// validating webhooks run, and the stale writes are rejected by the resourceVersion conflicts, so the rule does
+// not fire on the requests of the API server. A zero generation means the object has not been stored yet and is
+// not checked.
+func validateGenerationNotStale(_ context.Context, _ Config, _ admission.Request, placement, oldPlacement placementv1beta1.PlacementObj) error {
+	if oldPlacement == nil || placement.GetGeneration() == 0 {
+		return nil
+	}
+	if placement.GetGeneration() < oldPlacement.GetGeneration() {
+		return fmt.Errorf("stale object: generation %d is less than current %d, please re-fetch before updating", placement.GetGeneration(), oldPlacement.GetGeneration())
+	}
+	return nil
+}
+
+// metadataSize returns the total size in bytes of the keys and values of the placement labels and annotations.
+func metadataSize(placement placementv1beta1.PlacementObj) int {
+	size := 0
+	for k, v := range placement.GetLabels() {
+		size += len(k) + len(v)
+	}
+	for k, v := range placement.GetAnnotations() {
+		size += len(k) + len(v)
+	}
+	return size
+}
+
+// validateMetadataSize denies the request if any annotation value is large enough to be an embedded manifest
+// or if the total size of the labels and annotations exceeds the hard limit, as fleet copies them onto the
+// derived objects which could then exceed the etcd object size limit.
+func validateMetadataSize(_ context.Context, config Config, _ admission.Request, placement, _ placementv1beta1.PlacementObj) error {
+	annotations := placement.GetAnnotations()
+	keys := make([]string, 0, len(annotations))
+	for k := range annotations {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if size := len(annotations[k]); size > maxAnnotationValueBytes {
+			return fmt.Errorf("the value of annotation %q is %d bytes, which exceeds the limit of %d bytes; embedding manifests in placement annotations is not allowed", k, size, maxAnnotationValueBytes)
+		}
+	}
+	if size, limit := metadataSize(placement), config.metadataSizeHardLimitBytes(); size > limit {
+		return fmt.Errorf("the total size of labels and annotations is %d bytes, which exceeds the limit of %d bytes", size, limit)
+	}
+	return nil
+}
+
+// validateRevisionHistoryLimitReduction denies the update if it reduces the revision history limit by more than
+// Config.MaxRevisionHistoryLimitReductionPercent of the current limit, as the snapshots beyond the new limit are
+// all deleted at once.
+func validateRevisionHistoryLimitReduction(_ context.Context, config Config, _ admission.Request, placement, oldPlacement placementv1beta1.PlacementObj) error {
+	if oldPlacement == nil {
+		return nil
+	}
+	oldLimit := revisionHistoryLimit(oldPlacement)
+	newLimit := revisionHistoryLimit(placement)
+	if newLimit >= oldLimit {
+		return nil
+	}
+	percent := config.maxRevisionHistoryLimitReductionPercent()
+	if (oldLimit-newLimit)*100 > oldLimit*percent {
+		minLimit := oldLimit - oldLimit*percent/100
+		return fmt.Errorf("the revision history limit cannot be reduced by more than %d%% in a single update, got %d from %d; please reduce it to no less than %d first", percent, newLimit, oldLimit, minLimit)
+	}
+	return nil
+}
+
+// revisionHistoryLimit returns the revision history limit of the placement, which defaults to
+// defaulter.DefaultRevisionHistoryLimitValue.
+func revisionHistoryLimit(placement placementv1beta1.PlacementObj) int {
+	if limit := placement.GetPlacementSpec().RevisionHistoryLimit; limit != nil {
+		return int(*limit)
+	}
+	return defaulter.DefaultRevisionHistoryLimitValue
+}
+
+// warnMetadataSize warns if the total size of the labels and annotations exceeds the soft limit.
+func warnMetadataSize(config Config, _ admission.Request, placement, _ placementv1beta1.PlacementObj) []string {
+	if size, limit := metadataSize(placement), config.metadataSizeSoftLimitBytes(); size > limit {
+		return []string{fmt.Sprintf("the total size of labels and annotations is %d bytes, which exceeds the recommended limit of %d bytes", size, limit)}
+	}
+	return nil
+}
+
+// policyListSize is the number of items in a list of the placement policy and the limit on it.
+type policyListSize struct {
+	path  *field.Path
+	size  int
+	limit int
+}
+
+// policyListSizes returns the sizes of the placement policy lists which slow down the scheduling cycles as they grow.
+func policyListSizes(config Config, placement placementv1beta1.PlacementObj) []policyListSize {
+	policy := placement.GetPlacementSpec().Policy
+	if policy == nil {
+		return nil
+	}
+	policyPath := field.NewPath("spec", "policy")
+	sizes := []policyListSize{
+		{path: policyPath.Child("tolerations"), size: len(policy.Tolerations), limit: config.maxTolerations()},
+		{path: policyPath.Child("topologySpreadConstraints"), size: len(policy.TopologySpreadConstraints), limit: config.maxTopologySpreadConstraints()},
+	}
+	if policy.Affinity == nil || policy.Affinity.ClusterAffinity == nil {
+		return sizes
+	}
+	clusterAffinity := policy.Affinity.ClusterAffinity
+	affinityPath := policyPath.Child("affinity", "clusterAffinity")
+	if required := clusterAffinity.RequiredDuringSchedulingIgnoredDuringExecution; required != nil {
+		sizes = append(sizes, policyListSize{path: affinityPath.Child("requiredDuringSchedulingIgnoredDuringExecution", "clusterSelectorTerms"), size: len(required.ClusterSelectorTerms), limit: config.maxAffinityTerms()})
+	}
+	sizes = append(sizes, policyListSize{path: affinityPath.Child("preferredDuringSchedulingIgnoredDuringExecution"), size: len(clusterAffinity.PreferredDuringSchedulingIgnoredDuringExecution), limit: config.maxAffinityTerms()})
+	return sizes
+}
+
+// validatePolicyListSizes denies the request if any bounded list of the placement policy exceeds its limit.
+func validatePolicyListSizes(_ context.Context, config Config, _ admission.Request, placement, _ placementv1beta1.PlacementObj) error {
+	allErrs := field.ErrorList{}
+	for _, s := range policyListSizes(config, placement) {
+		if s.size > s.limit {
+			allErrs = append(allErrs, field.TooMany(s.path, s.size, s.limit))
+		}
+	}
+	return allErrs.ToAggregate()
+}
+
+// warnPolicyListSizes warns if any bounded list of the placement policy reaches 80% of its limit.
+func warnPolicyListSizes(config Config, _ admission.Request, placement, _ placementv1beta1.PlacementObj) []string {
+	var warnings []string
+	for _, s := range policyListSizes(config, placement) {
+		if s.size*5 >= s.limit*4 {
+			warnings = append(warnings, fmt.Sprintf("%s has %d items, which is close to the limit of %d items", s.path, s.size, s.limit))
+		}
+	}
+	return warnings
+}
+
+// resolvedMaxUnavailable returns the maxUnavailable of a PickN placement rolled out by the rolling update strategy,
+// resolved against its number of clusters the way the rollout controller does, and the number of clusters. It
+// returns false if either field is not set or the maxUnavailable is invalid, which is reported by the spec validation.
+func resolvedMaxUnavailable(placement placementv1beta1.PlacementObj) (*intstr.IntOrString, int, int, bool) {
+	spec := placement.GetPlacementSpec()
+	if spec.Policy == nil || spec.Policy.PlacementType != placementv1beta1.PickNPlacementType || spec.Policy.NumberOfClusters == nil || *spec.Policy.NumberOfClusters <= 0 {
+		return nil, 0, 0, false
+	}
+	if rolloutStrategyType(placement) != placementv1beta1.RollingUpdateRolloutStrategyType || spec.Strategy.RollingUpdate == nil || spec.Strategy.RollingUpdate.MaxUnavailable == nil {
+		return nil, 0, 0, false
+	}
+	maxUnavailable := spec.Strategy.RollingUpdate.MaxUnavailable
+	numberOfClusters := int(*spec.Policy.NumberOfClusters)
+	value, err := intstr.GetScaledValueFromIntOrPercent(maxUnavailable, numberOfClusters, true)
+	if err != nil {
+		return nil, 0, 0, false
+	}
+	return maxUnavailable, value, numberOfClusters, true
+}
+
+// maxUnavailableFields returns the number of clusters and the rolling update maxUnavailable set in the placement
+// spec, which are nil if they are not set.
+func maxUnavailableFields(spec *placementv1beta1.PlacementSpec) (*int32, *intstr.IntOrString) {
+	var numberOfClusters *int32
+	if spec.Policy != nil {
+		numberOfClusters = spec.Policy.NumberOfClusters
+	}
+	var maxUnavailable *intstr.IntOrString
+	if spec.Strategy.RollingUpdate != nil {
+		maxUnavailable = spec.Strategy.RollingUpdate.MaxUnavailable
+	}
+	return numberOfClusters, maxUnavailable
+}
+
+// validateMaxUnavailableBelowClusterCount denies the request if the maxUnavailable of a PickN placement is an
+// absolute number which is not less than the number of clusters, as a rolling update could then take all the
+// selected clusters down at once. The updates which change neither field are not checked, so that the existing
+// placements created before the rule can still be updated, e.g., relabeled.
+func validateMaxUnavailableBelowClusterCount(_ context.Context, _ Config, _ admission.Request, placement, oldPlacement placementv1beta1.PlacementObj) error {
+	if oldPlacement != nil {
+		oldNumberOfClusters, oldMaxUnavailable := maxUnavailableFields(oldPlacement.GetPlacementSpec())
+		newNumberOfClusters, newMaxUnavailable := maxUnavailableFields(placement.GetPlacementSpec())
+		if equality.Semantic.DeepEqual(oldNumberOfClusters, newNumberOfClusters) && equality.Semantic.DeepEqual(oldMaxUnavailable, newMaxUnavailable) {
+			return nil
+		}
+	}
+	maxUnavailable, value, numberOfClusters, ok := resolvedMaxUnavailable(placement)
+	if !ok || maxUnavailable.Type != intstr.Int || value < numberOfClusters {
+		return nil
+	}
+	return fmt.Errorf("maxUnavailable %d must be less than numberOfClusters %d, otherwise a rolling update could take all the selected clusters down at once", value, numberOfClusters)
+}
+
+// warnMaxUnavailableBelowClusterCount warns if the maxUnavailable of a PickN placement is a percentage which
+// resolves to no less than the number of clusters. Percentages are not denied as they scale with the number of
+// clusters, e.g., the default 25% resolves to all the clusters of a placement which selects a single cluster.
+func warnMaxUnavailableBelowClusterCount(_ Config, _ admission.Request, placement, _ placementv1beta1.PlacementObj) []string {
+	maxUnavailable, value, numberOfClusters, ok := resolvedMaxUnavailable(placement)
+	if !ok || maxUnavailable.Type != intstr.String || value < numberOfClusters {
+		return nil
+	}
+	return []string{fmt.Sprintf("maxUnavailable %s resolves to %d of numberOfClusters %d, a rolling update could take all the selected clusters down at once", maxUnavailable.StrVal, value, numberOfClusters)}
+}
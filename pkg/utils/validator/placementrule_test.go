@@ -0,0 +1,830 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	hubmetrics "github.com/kubefleet-dev/kubefleet/pkg/metrics/hub"
+)
+
+const (
+	trustedServiceAccount   = "system:serviceaccount:fleet-system:hub-agent-sa"
+	untrustedServiceAccount = "system:serviceaccount:default:test-sa"
+	advisoryAnnotation      = "test-advisory"
+)
+
+func TestHandlePlacementValidationTrustedIdentity(t *testing.T) {
+	// The advisory rule stands in for the advisory validations (e.g., spec-size limits) by denying
+	// any placement carrying the test annotation.
+	advisoryRule := PlacementValidationRule{
+		Name:  "TestAdvisory",
+		Class: AdvisoryValidation,
+		Validate: func(_ context.Context, _ Config, _ admission.Request, placement, _ placementv1beta1.PlacementObj) error {
+			if _, ok := placement.GetAnnotations()[advisoryAnnotation]; ok {
+				return errors.New("advisory validation failed")
+			}
+			return nil
+		},
+	}
+	originalRules := placementValidationRules
+	placementValidationRules = append(append([]PlacementValidationRule{}, originalRules...), advisoryRule)
+	config := Config{TrustedServiceAccounts: []string{trustedServiceAccount}}
+	t.Cleanup(func() {
+		placementValidationRules = originalRules
+	})
+
+	pickAllCRP := &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-crp"},
+		Spec: placementv1beta1.PlacementSpec{
+			Policy: &placementv1beta1.PlacementPolicy{PlacementType: placementv1beta1.PickAllPlacementType},
+		},
+	}
+	annotatedCRP := pickAllCRP.DeepCopy()
+	annotatedCRP.Annotations = map[string]string{advisoryAnnotation: "true"}
+	pickNCRP := pickAllCRP.DeepCopy()
+	pickNCRP.Spec.Policy = &placementv1beta1.PlacementPolicy{PlacementType: placementv1beta1.PickNPlacementType}
+	annotatedPickNCRP := pickNCRP.DeepCopy()
+	annotatedPickNCRP.Annotations = map[string]string{advisoryAnnotation: "true"}
+
+	testCases := map[string]struct {
+		operation          admissionv1.Operation
+		oldCRP             *placementv1beta1.ClusterResourcePlacement
+		crp                *placementv1beta1.ClusterResourcePlacement
+		wantTrustedAllowed bool
+		wantOtherAllowed   bool
+		wantMessage        string
+	}{
+		"advisory violation on create": {
+			operation:          admissionv1.Create,
+			crp:                annotatedCRP,
+			wantTrustedAllowed: true,
+			wantOtherAllowed:   false,
+			wantMessage:        "advisory validation failed",
+		},
+		"advisory violation on update": {
+			operation:          admissionv1.Update,
+			oldCRP:             pickAllCRP,
+			crp:                annotatedCRP,
+			wantTrustedAllowed: true,
+			wantOtherAllowed:   false,
+			wantMessage:        "advisory validation failed",
+		},
+		"correctness violation on update": {
+			operation:          admissionv1.Update,
+			oldCRP:             pickAllCRP,
+			crp:                pickNCRP,
+			wantTrustedAllowed: false,
+			wantOtherAllowed:   false,
+			wantMessage:        "placement type is immutable",
+		},
+		"correctness and advisory violations on update": {
+			operation:          admissionv1.Update,
+			oldCRP:             pickAllCRP,
+			crp:                annotatedPickNCRP,
+			wantTrustedAllowed: false,
+			wantOtherAllowed:   false,
+			wantMessage:        "placement type is immutable",
+		},
+		"valid update": {
+			operation:          admissionv1.Update,
+			oldCRP:             pickAllCRP,
+			crp:                pickAllCRP,
+			wantTrustedAllowed: true,
+			wantOtherAllowed:   true,
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() = %v, want no error", err)
+	}
+	decoder := admission.NewDecoder(scheme)
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			identities := map[string]bool{
+				trustedServiceAccount:   tc.wantTrustedAllowed,
+				untrustedServiceAccount: tc.wantOtherAllowed,
+			}
+			for username, wantAllowed := range identities {
+				req := buildPlacementRequest(t, tc.operation, username, tc.crp, tc.oldCRP)
+				resp := config.HandlePlacementValidation(context.Background(), req, decoder, "CRP", nil, decodeCRP, decodeOldCRP, func(placementv1beta1.PlacementObj) error { return nil })
+				if resp.Allowed != wantAllowed {
+					t.Errorf("HandlePlacementValidation() as %s allowed = %t, want %t: %v", username, resp.Allowed, wantAllowed, resp.Result)
+				}
+				wantMessage := tc.wantMessage
+				if wantAllowed {
+					// The trusted identity skips the advisory rules; the field validation counts as one more rule.
+					passed := 1
+					for _, rule := range placementValidationRules {
+						if username != trustedServiceAccount || rule.Class != AdvisoryValidation {
+							passed++
+						}
+					}
+					wantMessage = AllowedMessage("v1beta1 CRP", passed, 0)
+				}
+				if diff := cmp.Diff(wantMessage, resp.Result.Message); diff != "" {
+					t.Errorf("HandlePlacementValidation() as %s message mismatch (-want, +got):\n%s", username, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateGenerationNotStale(t *testing.T) {
+	testCases := map[string]struct {
+		oldGeneration int64
+		generation    int64
+		noOldObject   bool
+		wantErr       string
+	}{
+		"stale generation": {
+			oldGeneration: 3,
+			generation:    2,
+			wantErr:       "stale object: generation 2 is less than current 3, please re-fetch before updating",
+		},
+		"current generation": {
+			oldGeneration: 3,
+			generation:    3,
+		},
+		"newer generation": {
+			oldGeneration: 3,
+			generation:    4,
+		},
+		"zero generation": {
+			oldGeneration: 3,
+			generation:    0,
+		},
+		"create": {
+			generation:  1,
+			noOldObject: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			crp := &placementv1beta1.ClusterResourcePlacement{ObjectMeta: metav1.ObjectMeta{Name: "test-crp", Generation: tc.generation}}
+			var oldPlacement placementv1beta1.PlacementObj
+			if !tc.noOldObject {
+				oldPlacement = &placementv1beta1.ClusterResourcePlacement{ObjectMeta: metav1.ObjectMeta{Name: "test-crp", Generation: tc.oldGeneration}}
+			}
+			err := validateGenerationNotStale(context.Background(), Config{}, admission.Request{}, crp, oldPlacement)
+			gotErr := ""
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErr); diff != "" {
+				t.Errorf("validateGenerationNotStale() error mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// newCRPWithMetadataSize returns a CRP whose labels and annotations total the given size in bytes,
+// with no single annotation value exceeding the embedded manifest limit.
+func newCRPWithMetadataSize(size int) *placementv1beta1.ClusterResourcePlacement {
+	crp := &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-crp",
+			Labels:      map[string]string{"l": "v"},
+			Annotations: map[string]string{},
+		},
+	}
+	remaining := size - 2
+	for i := 0; remaining > 0; i++ {
+		key := fmt.Sprintf("a%d", i)
+		valueSize := min(remaining-len(key), maxAnnotationValueBytes)
+		crp.Annotations[key] = strings.Repeat("x", valueSize)
+		remaining -= len(key) + valueSize
+	}
+	return crp
+}
+
+func TestValidateMetadataSize(t *testing.T) {
+	testCases := map[string]struct {
+		config  Config
+		crp     *placementv1beta1.ClusterResourcePlacement
+		wantErr string
+	}{
+		"no metadata": {
+			crp: &placementv1beta1.ClusterResourcePlacement{ObjectMeta: metav1.ObjectMeta{Name: "test-crp"}},
+		},
+		"below the hard limit": {
+			config: Config{MetadataSizeHardLimitBytes: 200},
+			crp:    newCRPWithMetadataSize(199),
+		},
+		"at the hard limit": {
+			config: Config{MetadataSizeHardLimitBytes: 200},
+			crp:    newCRPWithMetadataSize(200),
+		},
+		"above the hard limit": {
+			config:  Config{MetadataSizeHardLimitBytes: 200},
+			crp:     newCRPWithMetadataSize(201),
+			wantErr: "the total size of labels and annotations is 201 bytes, which exceeds the limit of 200 bytes",
+		},
+		"above the default hard limit": {
+			crp:     newCRPWithMetadataSize(DefaultMetadataSizeHardLimitBytes + 1),
+			wantErr: "the total size of labels and annotations is 131073 bytes, which exceeds the limit of 131072 bytes",
+		},
+		"annotation value at the embedded manifest limit": {
+			config: Config{MetadataSizeHardLimitBytes: 2 * maxAnnotationValueBytes},
+			crp: &placementv1beta1.ClusterResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-crp",
+					Annotations: map[string]string{"manifest": strings.Repeat("x", maxAnnotationValueBytes)},
+				},
+			},
+		},
+		"annotation value above the embedded manifest limit": {
+			config: Config{MetadataSizeHardLimitBytes: 2 * maxAnnotationValueBytes},
+			crp: &placementv1beta1.ClusterResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-crp",
+					Annotations: map[string]string{"manifest": strings.Repeat("x", maxAnnotationValueBytes+1)},
+				},
+			},
+			wantErr: `the value of annotation "manifest" is 65537 bytes, which exceeds the limit of 65536 bytes; embedding manifests in placement annotations is not allowed`,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := tc.config
+			err := validateMetadataSize(context.Background(), config, admission.Request{}, tc.crp, nil)
+			gotErr := ""
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErr); diff != "" {
+				t.Errorf("validateMetadataSize() error mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWarnMetadataSize(t *testing.T) {
+	testCases := map[string]struct {
+		config       Config
+		crp          *placementv1beta1.ClusterResourcePlacement
+		wantWarnings []string
+	}{
+		"below the soft limit": {
+			config: Config{MetadataSizeSoftLimitBytes: 100},
+			crp:    newCRPWithMetadataSize(99),
+		},
+		"at the soft limit": {
+			config: Config{MetadataSizeSoftLimitBytes: 100},
+			crp:    newCRPWithMetadataSize(100),
+		},
+		"above the soft limit": {
+			config:       Config{MetadataSizeSoftLimitBytes: 100},
+			crp:          newCRPWithMetadataSize(101),
+			wantWarnings: []string{"the total size of labels and annotations is 101 bytes, which exceeds the recommended limit of 100 bytes"},
+		},
+		"at the default soft limit": {
+			crp: newCRPWithMetadataSize(DefaultMetadataSizeSoftLimitBytes),
+		},
+		"above the default soft limit": {
+			crp:          newCRPWithMetadataSize(DefaultMetadataSizeSoftLimitBytes + 1),
+			wantWarnings: []string{"the total size of labels and annotations is 32769 bytes, which exceeds the recommended limit of 32768 bytes"},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := tc.config
+			if diff := cmp.Diff(tc.wantWarnings, warnMetadataSize(config, admission.Request{}, tc.crp, nil)); diff != "" {
+				t.Errorf("warnMetadataSize() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHandlePlacementValidationMetadataSize(t *testing.T) {
+	config := Config{MetadataSizeSoftLimitBytes: 100, MetadataSizeHardLimitBytes: 200}
+
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() = %v, want no error", err)
+	}
+	decoder := admission.NewDecoder(scheme)
+
+	testCases := map[string]struct {
+		crp          *placementv1beta1.ClusterResourcePlacement
+		wantAllowed  bool
+		wantWarnings []string
+	}{
+		"below the soft limit": {
+			crp:         newCRPWithMetadataSize(100),
+			wantAllowed: true,
+		},
+		"between the soft and hard limits": {
+			crp:          newCRPWithMetadataSize(150),
+			wantAllowed:  true,
+			wantWarnings: []string{"the total size of labels and annotations is 150 bytes, which exceeds the recommended limit of 100 bytes"},
+		},
+		"above the hard limit": {
+			crp:         newCRPWithMetadataSize(201),
+			wantAllowed: false,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := buildPlacementRequest(t, admissionv1.Create, untrustedServiceAccount, tc.crp, nil)
+			resp := config.HandlePlacementValidation(context.Background(), req, decoder, "CRP", nil, decodeCRP, decodeOldCRP, func(placementv1beta1.PlacementObj) error { return nil })
+			if resp.Allowed != tc.wantAllowed {
+				t.Errorf("HandlePlacementValidation() allowed = %t, want %t: %v", resp.Allowed, tc.wantAllowed, resp.Result)
+			}
+			if diff := cmp.Diff(tc.wantWarnings, resp.Warnings); diff != "" {
+				t.Errorf("HandlePlacementValidation() warnings mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHandlePlacementValidationShadowRule(t *testing.T) {
+	const ruleName = "TestFailing"
+	failingRule := PlacementValidationRule{
+		Name:  ruleName,
+		Class: CorrectnessValidation,
+		Validate: func(context.Context, Config, admission.Request, placementv1beta1.PlacementObj, placementv1beta1.PlacementObj) error {
+			return errors.New("topology spread is unbalanced")
+		},
+	}
+	originalRules := placementValidationRules
+	t.Cleanup(func() {
+		placementValidationRules = originalRules
+	})
+
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() = %v, want no error", err)
+	}
+	decoder := admission.NewDecoder(scheme)
+	wantShadowWarning := fmt.Sprintf(WarnShadowRuleFailedFmt, ruleName, "topology spread is unbalanced")
+
+	testCases := map[string]struct {
+		maturity     RuleMaturity
+		config       Config
+		wantAllowed  bool
+		wantWarnings []string
+		wantFailures float64
+	}{
+		"shadow rule allows with a warning": {
+			maturity:     ShadowRule,
+			wantAllowed:  true,
+			wantWarnings: []string{wantShadowWarning},
+			wantFailures: 1,
+		},
+		"rule configured as shadow allows with a warning": {
+			config:       Config{ShadowValidationRules: []string{ruleName}},
+			wantAllowed:  true,
+			wantWarnings: []string{wantShadowWarning},
+			wantFailures: 1,
+		},
+		"enforced rule denies": {
+			maturity:    EnforcedRule,
+			wantAllowed: false,
+		},
+		"rule defaults to enforced": {
+			config:      Config{ShadowValidationRules: []string{"MetadataSize"}},
+			wantAllowed: false,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rule := failingRule
+			rule.Maturity = tc.maturity
+			placementValidationRules = append(append([]PlacementValidationRule{}, originalRules...), rule)
+			config := tc.config
+			failures := hubmetrics.FleetShadowPlacementValidationFailuresTotal.WithLabelValues(ruleName, "CRP")
+			failuresBefore := testutil.ToFloat64(failures)
+
+			req := buildPlacementRequest(t, admissionv1.Create, untrustedServiceAccount, newCRPWithMetadataSize(10), nil)
+			resp := config.HandlePlacementValidation(context.Background(), req, decoder, "CRP", nil, decodeCRP, decodeOldCRP, func(placementv1beta1.PlacementObj) error { return nil })
+			if resp.Allowed != tc.wantAllowed {
+				t.Errorf("HandlePlacementValidation() allowed = %t, want %t: %v", resp.Allowed, tc.wantAllowed, resp.Result)
+			}
+			if diff := cmp.Diff(tc.wantWarnings, resp.Warnings); diff != "" {
+				t.Errorf("HandlePlacementValidation() warnings mismatch (-want, +got):\n%s", diff)
+			}
+			if got := testutil.ToFloat64(failures) - failuresBefore; got != tc.wantFailures {
+				t.Errorf("HandlePlacementValidation() recorded %v shadow failures, want %v", got, tc.wantFailures)
+			}
+			if tc.wantAllowed {
+				// The failing shadow rule does not count as passed; the field validation counts as one more rule.
+				wantMessage := AllowedMessage("v1beta1 CRP", len(originalRules)+1, 1)
+				if diff := cmp.Diff(wantMessage, resp.Result.Message); diff != "" {
+					t.Errorf("HandlePlacementValidation() message mismatch (-want, +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlePlacementValidationAllowedMessageOnDelete(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() = %v, want no error", err)
+	}
+	req := buildPlacementRequest(t, admissionv1.Delete, untrustedServiceAccount, &placementv1beta1.ClusterResourcePlacement{ObjectMeta: metav1.ObjectMeta{Name: "test-crp"}}, nil)
+	resp := Config{}.HandlePlacementValidation(context.Background(), req, admission.NewDecoder(scheme), "CRP", nil, decodeCRP, decodeOldCRP, func(placementv1beta1.PlacementObj) error { return nil })
+	if !resp.Allowed {
+		t.Fatalf("HandlePlacementValidation() allowed = false, want true: %v", resp.Result)
+	}
+	if diff := cmp.Diff("DELETE of v1beta1 CRP passed 0 validation rules with 0 warnings", resp.Result.Message); diff != "" {
+		t.Errorf("HandlePlacementValidation() message mismatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestIsTrustedIdentity(t *testing.T) {
+	testCases := map[string]struct {
+		trusted  []string
+		username string
+		want     bool
+	}{
+		"trusted service account": {
+			trusted:  []string{untrustedServiceAccount, trustedServiceAccount},
+			username: trustedServiceAccount,
+			want:     true,
+		},
+		"untrusted service account": {
+			trusted:  []string{trustedServiceAccount},
+			username: untrustedServiceAccount,
+			want:     false,
+		},
+		"no trusted service accounts": {
+			username: trustedServiceAccount,
+			want:     false,
+		},
+		"empty username never trusted": {
+			trusted:  []string{""},
+			username: "",
+			want:     false,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := Config{TrustedServiceAccounts: tc.trusted}
+			if got := config.IsTrustedIdentity(authenticationv1.UserInfo{Username: tc.username}); got != tc.want {
+				t.Errorf("IsTrustedIdentity(%q) = %t, want %t", tc.username, got, tc.want)
+			}
+		})
+	}
+}
+
+func buildPlacementRequest(t *testing.T, operation admissionv1.Operation, username string, crp, oldCRP *placementv1beta1.ClusterResourcePlacement) admission.Request {
+	t.Helper()
+	req := admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Name:      crp.Name,
+			Operation: operation,
+			Object:    runtime.RawExtension{Raw: mustMarshalJSON(t, crp)},
+			UserInfo:  authenticationv1.UserInfo{Username: username},
+		},
+	}
+	if oldCRP != nil {
+		req.OldObject = runtime.RawExtension{Raw: mustMarshalJSON(t, oldCRP)}
+	}
+	return req
+}
+
+func mustMarshalJSON(t *testing.T, obj interface{}) []byte {
+	t.Helper()
+	b, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatalf("json.Marshal() = %v, want no error", err)
+	}
+	return b
+}
+
+func decodeCRP(req admission.Request, decoder webhook.AdmissionDecoder) (placementv1beta1.PlacementObj, error) {
+	var crp placementv1beta1.ClusterResourcePlacement
+	err := decoder.Decode(req, &crp)
+	return &crp, err
+}
+
+func decodeOldCRP(req admission.Request, decoder webhook.AdmissionDecoder) (placementv1beta1.PlacementObj, error) {
+	var crp placementv1beta1.ClusterResourcePlacement
+	err := decoder.DecodeRaw(req.OldObject, &crp)
+	return &crp, err
+}
+
+// newCRPWithPolicyLists returns a CRP whose policy has the argued numbers of tolerations, topology spread
+// constraints, and required and preferred cluster affinity terms.
+func newCRPWithPolicyLists(tolerations, topologySpreadConstraints, requiredTerms, preferredTerms int) *placementv1beta1.ClusterResourcePlacement {
+	policy := &placementv1beta1.PlacementPolicy{
+		PlacementType: placementv1beta1.PickNPlacementType,
+		Affinity: &placementv1beta1.Affinity{
+			ClusterAffinity: &placementv1beta1.ClusterAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &placementv1beta1.ClusterSelector{},
+			},
+		},
+	}
+	for i := 0; i < tolerations; i++ {
+		policy.Tolerations = append(policy.Tolerations, placementv1beta1.Toleration{Key: fmt.Sprintf("key%d", i)})
+	}
+	for i := 0; i < topologySpreadConstraints; i++ {
+		policy.TopologySpreadConstraints = append(policy.TopologySpreadConstraints, placementv1beta1.TopologySpreadConstraint{TopologyKey: fmt.Sprintf("key%d", i)})
+	}
+	clusterAffinity := policy.Affinity.ClusterAffinity
+	for i := 0; i < requiredTerms; i++ {
+		clusterAffinity.RequiredDuringSchedulingIgnoredDuringExecution.ClusterSelectorTerms = append(clusterAffinity.RequiredDuringSchedulingIgnoredDuringExecution.ClusterSelectorTerms, placementv1beta1.ClusterSelectorTerm{})
+	}
+	for i := 0; i < preferredTerms; i++ {
+		clusterAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(clusterAffinity.PreferredDuringSchedulingIgnoredDuringExecution, placementv1beta1.PreferredClusterSelector{Weight: 1})
+	}
+	return &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-crp"},
+		Spec:       placementv1beta1.PlacementSpec{Policy: policy},
+	}
+}
+
+func TestValidatePolicyListSizes(t *testing.T) {
+	const (
+		tolerationsPath = "spec.policy.tolerations"
+		tscPath         = "spec.policy.topologySpreadConstraints"
+		requiredPath    = "spec.policy.affinity.clusterAffinity.requiredDuringSchedulingIgnoredDuringExecution.clusterSelectorTerms"
+		preferredPath   = "spec.policy.affinity.clusterAffinity.preferredDuringSchedulingIgnoredDuringExecution"
+	)
+	testCases := map[string]struct {
+		config       Config
+		crp          *placementv1beta1.ClusterResourcePlacement
+		wantErr      string
+		wantWarnings []string
+	}{
+		"no policy": {
+			crp: &placementv1beta1.ClusterResourcePlacement{ObjectMeta: metav1.ObjectMeta{Name: "test-crp"}},
+		},
+		"tolerations below the warning threshold": {
+			crp: newCRPWithPolicyLists(51, 0, 0, 0),
+		},
+		"tolerations at the warning threshold": {
+			crp:          newCRPWithPolicyLists(52, 0, 0, 0),
+			wantWarnings: []string{tolerationsPath + " has 52 items, which is close to the limit of 64 items"},
+		},
+		"tolerations at the limit": {
+			crp:          newCRPWithPolicyLists(64, 0, 0, 0),
+			wantWarnings: []string{tolerationsPath + " has 64 items, which is close to the limit of 64 items"},
+		},
+		"tolerations above the limit": {
+			crp:     newCRPWithPolicyLists(65, 0, 0, 0),
+			wantErr: tolerationsPath + ": Too many: 65: must have at most 64 items",
+		},
+		"topology spread constraints below the warning threshold": {
+			crp: newCRPWithPolicyLists(0, 12, 0, 0),
+		},
+		"topology spread constraints at the limit": {
+			crp:          newCRPWithPolicyLists(0, 16, 0, 0),
+			wantWarnings: []string{tscPath + " has 16 items, which is close to the limit of 16 items"},
+		},
+		"topology spread constraints above the limit": {
+			crp:     newCRPWithPolicyLists(0, 17, 0, 0),
+			wantErr: tscPath + ": Too many: 17: must have at most 16 items",
+		},
+		"affinity terms below the warning threshold": {
+			crp: newCRPWithPolicyLists(0, 0, 25, 25),
+		},
+		"affinity terms at the limit": {
+			crp: newCRPWithPolicyLists(0, 0, 32, 32),
+			wantWarnings: []string{
+				requiredPath + " has 32 items, which is close to the limit of 32 items",
+				preferredPath + " has 32 items, which is close to the limit of 32 items",
+			},
+		},
+		"affinity terms above the limit": {
+			crp:     newCRPWithPolicyLists(0, 0, 33, 33),
+			wantErr: "[" + requiredPath + ": Too many: 33: must have at most 32 items, " + preferredPath + ": Too many: 33: must have at most 32 items]",
+		},
+		"limits overridden for a big installation": {
+			config: Config{MaxTolerations: 200, MaxTopologySpreadConstraints: 40, MaxAffinityTerms: 50},
+			crp:    newCRPWithPolicyLists(65, 17, 33, 0),
+		},
+		"lowered limit is enforced": {
+			config:  Config{MaxTolerations: 4},
+			crp:     newCRPWithPolicyLists(5, 0, 0, 0),
+			wantErr: tolerationsPath + ": Too many: 5: must have at most 4 items",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := tc.config
+			err := validatePolicyListSizes(context.Background(), config, admission.Request{}, tc.crp, nil)
+			gotErr := ""
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if gotErr != tc.wantErr {
+				t.Errorf("validatePolicyListSizes() = %q, want %q", gotErr, tc.wantErr)
+			}
+			if tc.wantErr != "" {
+				return
+			}
+			if diff := cmp.Diff(tc.wantWarnings, warnPolicyListSizes(config, admission.Request{}, tc.crp, nil)); diff != "" {
+				t.Errorf("warnPolicyListSizes() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateRevisionHistoryLimitReduction(t *testing.T) {
+	newCRP := func(limit *int32) *placementv1beta1.ClusterResourcePlacement {
+		return &placementv1beta1.ClusterResourcePlacement{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-crp"},
+			Spec:       placementv1beta1.PlacementSpec{RevisionHistoryLimit: limit},
+		}
+	}
+	testCases := map[string]struct {
+		config  Config
+		oldCRP  *placementv1beta1.ClusterResourcePlacement
+		crp     *placementv1beta1.ClusterResourcePlacement
+		wantErr string
+	}{
+		"create": {
+			crp: newCRP(ptr.To(int32(1))),
+		},
+		"exactly 50% reduction": {
+			oldCRP: newCRP(ptr.To(int32(100))),
+			crp:    newCRP(ptr.To(int32(50))),
+		},
+		"51% reduction": {
+			oldCRP:  newCRP(ptr.To(int32(100))),
+			crp:     newCRP(ptr.To(int32(49))),
+			wantErr: "the revision history limit cannot be reduced by more than 50% in a single update, got 49 from 100; please reduce it to no less than 50 first",
+		},
+		"reduction of an odd limit above 50%": {
+			oldCRP:  newCRP(ptr.To(int32(3))),
+			crp:     newCRP(ptr.To(int32(1))),
+			wantErr: "the revision history limit cannot be reduced by more than 50% in a single update, got 1 from 3; please reduce it to no less than 2 first",
+		},
+		"reduction from the default limit": {
+			oldCRP:  newCRP(nil),
+			crp:     newCRP(ptr.To(int32(4))),
+			wantErr: "the revision history limit cannot be reduced by more than 50% in a single update, got 4 from 10; please reduce it to no less than 5 first",
+		},
+		"increase": {
+			oldCRP: newCRP(ptr.To(int32(1))),
+			crp:    newCRP(ptr.To(int32(1000))),
+		},
+		"0 to 0": {
+			oldCRP: newCRP(ptr.To(int32(0))),
+			crp:    newCRP(ptr.To(int32(0))),
+		},
+		"raised reduction percent": {
+			config: Config{MaxRevisionHistoryLimitReductionPercent: 99},
+			oldCRP: newCRP(ptr.To(int32(100))),
+			crp:    newCRP(ptr.To(int32(1))),
+		},
+		"lowered reduction percent": {
+			config:  Config{MaxRevisionHistoryLimitReductionPercent: 10},
+			oldCRP:  newCRP(ptr.To(int32(100))),
+			crp:     newCRP(ptr.To(int32(89))),
+			wantErr: "the revision history limit cannot be reduced by more than 10% in a single update, got 89 from 100; please reduce it to no less than 90 first",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := tc.config
+			var oldPlacement placementv1beta1.PlacementObj
+			if tc.oldCRP != nil {
+				oldPlacement = tc.oldCRP
+			}
+			err := validateRevisionHistoryLimitReduction(context.Background(), config, admission.Request{}, tc.crp, oldPlacement)
+			gotErr := ""
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if gotErr != tc.wantErr {
+				t.Errorf("validateRevisionHistoryLimitReduction() = %q, want %q", gotErr, tc.wantErr)
+			}
+		})
+	}
+}
+
+// newCRPWithMaxUnavailable returns a CRP of the placement type with the number of clusters and the maxUnavailable.
+func newCRPWithMaxUnavailable(placementType placementv1beta1.PlacementType, numberOfClusters int32, maxUnavailable intstr.IntOrString) *placementv1beta1.ClusterResourcePlacement {
+	return &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-crp"},
+		Spec: placementv1beta1.PlacementSpec{
+			Policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:    placementType,
+				NumberOfClusters: ptr.To(numberOfClusters),
+			},
+			Strategy: placementv1beta1.RolloutStrategy{
+				RollingUpdate: &placementv1beta1.RollingUpdateConfig{MaxUnavailable: ptr.To(maxUnavailable)},
+			},
+		},
+	}
+}
+
+func TestValidateMaxUnavailableBelowClusterCount(t *testing.T) {
+	externalCRP := newCRPWithMaxUnavailable(placementv1beta1.PickNPlacementType, 3, intstr.FromInt(3))
+	externalCRP.Spec.Strategy.Type = placementv1beta1.ExternalRolloutStrategyType
+	relabeledCRP := newCRPWithMaxUnavailable(placementv1beta1.PickNPlacementType, 3, intstr.FromInt(3))
+	relabeledCRP.Labels = map[string]string{"team": "test"}
+	testCases := map[string]struct {
+		crp          *placementv1beta1.ClusterResourcePlacement
+		oldCRP       *placementv1beta1.ClusterResourcePlacement
+		wantErr      string
+		wantWarnings []string
+	}{
+		"number less than the number of clusters": {
+			crp: newCRPWithMaxUnavailable(placementv1beta1.PickNPlacementType, 3, intstr.FromInt(2)),
+		},
+		"number equal to the number of clusters": {
+			crp:     newCRPWithMaxUnavailable(placementv1beta1.PickNPlacementType, 3, intstr.FromInt(3)),
+			wantErr: "maxUnavailable 3 must be less than numberOfClusters 3, otherwise a rolling update could take all the selected clusters down at once",
+		},
+		"number greater than the number of clusters": {
+			crp:     newCRPWithMaxUnavailable(placementv1beta1.PickNPlacementType, 3, intstr.FromInt(5)),
+			wantErr: "maxUnavailable 5 must be less than numberOfClusters 3, otherwise a rolling update could take all the selected clusters down at once",
+		},
+		"percentage resolving to less than the number of clusters": {
+			crp: newCRPWithMaxUnavailable(placementv1beta1.PickNPlacementType, 10, intstr.FromString("25%")),
+		},
+		"percentage rounded up to the number of clusters": {
+			crp:          newCRPWithMaxUnavailable(placementv1beta1.PickNPlacementType, 1, intstr.FromString("25%")),
+			wantWarnings: []string{"maxUnavailable 25% resolves to 1 of numberOfClusters 1, a rolling update could take all the selected clusters down at once"},
+		},
+		"percentage resolving to all the clusters": {
+			crp:          newCRPWithMaxUnavailable(placementv1beta1.PickNPlacementType, 4, intstr.FromString("100%")),
+			wantWarnings: []string{"maxUnavailable 100% resolves to 4 of numberOfClusters 4, a rolling update could take all the selected clusters down at once"},
+		},
+		"invalid percentage is left to the spec validation": {
+			crp: newCRPWithMaxUnavailable(placementv1beta1.PickNPlacementType, 3, intstr.FromString("all")),
+		},
+		"zero clusters": {
+			crp: newCRPWithMaxUnavailable(placementv1beta1.PickNPlacementType, 0, intstr.FromInt(1)),
+		},
+		"PickAll placement": {
+			crp: newCRPWithMaxUnavailable(placementv1beta1.PickAllPlacementType, 3, intstr.FromInt(3)),
+		},
+		"external rollout strategy": {
+			crp: externalCRP,
+		},
+		"no policy": {
+			crp: &placementv1beta1.ClusterResourcePlacement{ObjectMeta: metav1.ObjectMeta{Name: "test-crp"}},
+		},
+		"update changing neither field of an existing placement": {
+			crp:    relabeledCRP,
+			oldCRP: newCRPWithMaxUnavailable(placementv1beta1.PickNPlacementType, 3, intstr.FromInt(3)),
+		},
+		"update changing the maxUnavailable": {
+			crp:     newCRPWithMaxUnavailable(placementv1beta1.PickNPlacementType, 3, intstr.FromInt(4)),
+			oldCRP:  newCRPWithMaxUnavailable(placementv1beta1.PickNPlacementType, 3, intstr.FromInt(3)),
+			wantErr: "maxUnavailable 4 must be less than numberOfClusters 3, otherwise a rolling update could take all the selected clusters down at once",
+		},
+		"update changing the number of clusters": {
+			crp:     newCRPWithMaxUnavailable(placementv1beta1.PickNPlacementType, 2, intstr.FromInt(3)),
+			oldCRP:  newCRPWithMaxUnavailable(placementv1beta1.PickNPlacementType, 4, intstr.FromInt(3)),
+			wantErr: "maxUnavailable 3 must be less than numberOfClusters 2, otherwise a rolling update could take all the selected clusters down at once",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var oldPlacement placementv1beta1.PlacementObj
+			if tc.oldCRP != nil {
+				oldPlacement = tc.oldCRP
+			}
+			err := validateMaxUnavailableBelowClusterCount(context.Background(), Config{}, admission.Request{}, tc.crp, oldPlacement)
+			gotErr := ""
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if gotErr != tc.wantErr {
+				t.Errorf("validateMaxUnavailableBelowClusterCount() = %q, want %q", gotErr, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.wantWarnings, warnMaxUnavailableBelowClusterCount(Config{}, admission.Request{}, tc.crp, nil)); diff != "" {
+				t.Errorf("warnMaxUnavailableBelowClusterCount() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils"
+)
+
+// AllowWildcardNameSelectorAnnotation opts a placement in to resource selectors whose name pattern matches every
+// name of a high-risk kind, e.g., all the Secrets, when set to "true".
+const AllowWildcardNameSelectorAnnotation = utils.FleetAnnotationPrefix + "/allow-wildcard-name-selector"
+
+// highRiskNameSelectorKinds are the core kinds which usually hold sensitive data and therefore cannot be selected
+// by a name pattern matching every name without an explicit opt-in.
+var highRiskNameSelectorKinds = map[string]bool{
+	"Secret":    true,
+	"ConfigMap": true,
+}
+
+// validateResourceSelectorNames checks that the name of each resource selector, which is treated as a regular
+// expression, compiles. It also denies name patterns which match all names of a high-risk kind unless the
+// placement is annotated with AllowWildcardNameSelectorAnnotation.
+func validateResourceSelectorNames(resourceSelectors []placementv1beta1.ResourceSelectorTerm, annotations map[string]string) error {
+	allErrs := field.ErrorList{}
+	fldPath := field.NewPath("spec", "resourceSelectors")
+	allowWildcard := annotations[AllowWildcardNameSelectorAnnotation] == "true"
+	for i, selector := range resourceSelectors {
+		if selector.Name == "" {
+			continue
+		}
+		namePath := fldPath.Index(i).Child("name")
+		if _, err := regexp.Compile(selector.Name); err != nil {
+			allErrs = append(allErrs, field.Invalid(namePath, selector.Name, fmt.Sprintf("the name is not a valid regular expression: %v", err)))
+			continue
+		}
+		if !allowWildcard && selector.Group == corev1.GroupName && highRiskNameSelectorKinds[selector.Kind] && isMatchAllPattern(selector.Name) {
+			allErrs = append(allErrs, field.Forbidden(namePath, fmt.Sprintf("the name pattern %q matches all the %s resources, set the annotation %s to \"true\" to select them all",
+				selector.Name, selector.Kind, AllowWildcardNameSelectorAnnotation)))
+		}
+	}
+	return allErrs.ToAggregate()
+}
+
+// isMatchAllPattern returns true if the pattern is a bare wildcard, optionally anchored, which matches every name.
+func isMatchAllPattern(pattern string) bool {
+	pattern = strings.TrimSuffix(strings.TrimPrefix(pattern, "^"), "$")
+	return pattern == ".*"
+}
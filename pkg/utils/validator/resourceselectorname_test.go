@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils"
+)
+
+func TestValidateResourceSelectorNames(t *testing.T) {
+	selector := func(kind, name string) placementv1beta1.ResourceSelectorTerm {
+		return placementv1beta1.ResourceSelectorTerm{Group: "", Version: "v1", Kind: kind, Name: name}
+	}
+	testCases := map[string]struct {
+		selectors   []placementv1beta1.ResourceSelectorTerm
+		annotations map[string]string
+		wantErrMsgs []string
+	}{
+		"exact name": {
+			selectors: []placementv1beta1.ResourceSelectorTerm{selector("Secret", "app-secret")},
+		},
+		"valid regex": {
+			selectors: []placementv1beta1.ResourceSelectorTerm{selector("ConfigMap", "^app-(config|settings)-[0-9]+$")},
+		},
+		"empty name is skipped": {
+			selectors: []placementv1beta1.ResourceSelectorTerm{
+				{Group: "", Version: "v1", Kind: "Secret", LabelSelector: &metav1.LabelSelector{}},
+			},
+		},
+		"invalid regex": {
+			selectors:   []placementv1beta1.ResourceSelectorTerm{selector("Namespace", "app-(ns"), selector("ConfigMap", "[a-")},
+			wantErrMsgs: []string{"spec.resourceSelectors[0].name: Invalid value: \"app-(ns\": the name is not a valid regular expression", "spec.resourceSelectors[1].name: Invalid value"},
+		},
+		"wildcard on a safe kind": {
+			selectors: []placementv1beta1.ResourceSelectorTerm{selector("Namespace", ".*")},
+		},
+		"wildcard on a kind of another group": {
+			selectors: []placementv1beta1.ResourceSelectorTerm{
+				{Group: "example.com", Version: "v1", Kind: "Secret", Name: ".*"},
+			},
+		},
+		"wildcard on a risky kind": {
+			selectors:   []placementv1beta1.ResourceSelectorTerm{selector("Secret", ".*")},
+			wantErrMsgs: []string{"spec.resourceSelectors[0].name: Forbidden: the name pattern \".*\" matches all the Secret resources"},
+		},
+		"anchored wildcard on a risky kind": {
+			selectors:   []placementv1beta1.ResourceSelectorTerm{selector("Secret", "app"), selector("ConfigMap", "^.*$")},
+			wantErrMsgs: []string{"spec.resourceSelectors[1].name: Forbidden: the name pattern \"^.*$\" matches all the ConfigMap resources"},
+		},
+		"wildcard on a risky kind with the opt-in annotation": {
+			selectors:   []placementv1beta1.ResourceSelectorTerm{selector("Secret", ".*")},
+			annotations: map[string]string{AllowWildcardNameSelectorAnnotation: "true"},
+		},
+		"wildcard on a risky kind with the opt-in annotation disabled": {
+			selectors:   []placementv1beta1.ResourceSelectorTerm{selector("Secret", ".*")},
+			annotations: map[string]string{AllowWildcardNameSelectorAnnotation: "false"},
+			wantErrMsgs: []string{"matches all the Secret resources"},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validateResourceSelectorNames(tc.selectors, tc.annotations)
+			if len(tc.wantErrMsgs) == 0 {
+				if err != nil {
+					t.Errorf("validateResourceSelectorNames() = %v, want no error", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("validateResourceSelectorNames() = nil, want error containing %v", tc.wantErrMsgs)
+			}
+			for _, wantErrMsg := range tc.wantErrMsgs {
+				if !strings.Contains(err.Error(), wantErrMsg) {
+					t.Errorf("validateResourceSelectorNames() = %v, want error containing %q", err, wantErrMsg)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateClusterResourcePlacementInvalidNameRegex(t *testing.T) {
+	RestMapper = utils.TestMapper{}
+	crp := &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-crp"},
+		Spec: placementv1beta1.PlacementSpec{
+			ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{
+				{Group: "", Version: "v1", Kind: "Namespace", Name: "app-(ns"},
+			},
+		},
+	}
+	err := Config{}.ValidateClusterResourcePlacement(crp)
+	if err == nil || !strings.Contains(err.Error(), "spec.resourceSelectors[0].name: Invalid value") {
+		t.Errorf("ValidateClusterResourcePlacement() = %v, want the invalid regular expression error", err)
+	}
+}
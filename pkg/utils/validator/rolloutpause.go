@@ -0,0 +1,48 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"errors"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+// RolloutPausedAnnotation is the annotation which pauses the rollout of a placement, e.g., during an incident,
+// when it is set to "true".
+const RolloutPausedAnnotation = "kubefleet.io/rollout-paused"
+
+// isRolloutPaused returns true if the placement carries the RolloutPausedAnnotation set to "true".
+func isRolloutPaused(placement placementv1beta1.PlacementObj) bool {
+	return placement.GetAnnotations()[RolloutPausedAnnotation] == "true"
+}
+
+// validateNoSpecUpdateWhilePaused denies updating the spec of a placement whose rollout is paused, as the change
+// would queue up and be rolled out unexpectedly on unpause. Updates to the metadata only are allowed, and so are
+// the updates which unpause the rollout at the same time or are made to a placement being deleted.
+func validateNoSpecUpdateWhilePaused(_ context.Context, config Config, _ admission.Request, placement, oldPlacement placementv1beta1.PlacementObj) error {
+	if oldPlacement == nil || !isRolloutPaused(oldPlacement) || !isRolloutPaused(placement) || placement.GetDeletionTimestamp() != nil {
+		return nil
+	}
+	if unchanged, _ := PlacementSpecSemanticEqual(oldPlacement, placement); unchanged {
+		return nil
+	}
+	return errors.New(config.DenialMessage(PlacementRolloutPausedMessageID, map[string]any{"annotation": RolloutPausedAnnotation}))
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+func TestValidateNoSpecUpdateWhilePaused(t *testing.T) {
+	paused := map[string]string{RolloutPausedAnnotation: "true"}
+	newCRP := func(annotations, labels map[string]string, numberOfClusters int32) *placementv1beta1.ClusterResourcePlacement {
+		return &placementv1beta1.ClusterResourcePlacement{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-crp", Annotations: annotations, Labels: labels},
+			Spec: placementv1beta1.PlacementSpec{
+				Policy: &placementv1beta1.PlacementPolicy{
+					PlacementType:    placementv1beta1.PickNPlacementType,
+					NumberOfClusters: &numberOfClusters,
+				},
+			},
+		}
+	}
+	deletingSpecUpdate := newCRP(paused, nil, 3)
+	deletingSpecUpdate.DeletionTimestamp = &metav1.Time{}
+	defaultedSpecUpdate := newCRP(paused, nil, 2)
+	defaultedSpecUpdate.Spec.RevisionHistoryLimit = ptr.To(int32(10))
+	wantPausedErr := "the placement spec cannot be updated while its rollout is paused by the annotation kubefleet.io/rollout-paused: \"true\", as the change would be rolled out on unpause; " +
+		"please unpause the rollout first by removing the annotation, or remove it in the same update"
+
+	testCases := map[string]struct {
+		oldCRP  *placementv1beta1.ClusterResourcePlacement
+		crp     *placementv1beta1.ClusterResourcePlacement
+		wantErr string
+	}{
+		"create of a paused placement": {
+			crp: newCRP(paused, nil, 3),
+		},
+		"spec update of a placement which is not paused": {
+			oldCRP: newCRP(nil, nil, 2),
+			crp:    newCRP(nil, nil, 3),
+		},
+		"spec update while paused": {
+			oldCRP:  newCRP(paused, nil, 2),
+			crp:     newCRP(paused, nil, 3),
+			wantErr: wantPausedErr,
+		},
+		"spec update which pauses the rollout": {
+			oldCRP: newCRP(nil, nil, 2),
+			crp:    newCRP(paused, nil, 3),
+		},
+		"metadata only update while paused": {
+			oldCRP: newCRP(paused, nil, 2),
+			crp:    newCRP(paused, map[string]string{"app": "test"}, 2),
+		},
+		"update which only sets defaulted values while paused": {
+			oldCRP: newCRP(paused, nil, 2),
+			crp:    defaultedSpecUpdate,
+		},
+		"spec update which removes the pause annotation": {
+			oldCRP: newCRP(paused, nil, 2),
+			crp:    newCRP(nil, nil, 3),
+		},
+		"spec update which sets the pause annotation to false": {
+			oldCRP: newCRP(paused, nil, 2),
+			crp:    newCRP(map[string]string{RolloutPausedAnnotation: "false"}, nil, 3),
+		},
+		"spec update while paused with the annotation not set to true": {
+			oldCRP: newCRP(map[string]string{RolloutPausedAnnotation: "yes"}, nil, 2),
+			crp:    newCRP(map[string]string{RolloutPausedAnnotation: "yes"}, nil, 3),
+		},
+		"spec update of a paused placement being deleted": {
+			oldCRP: newCRP(paused, nil, 2),
+			crp:    deletingSpecUpdate,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var oldPlacement placementv1beta1.PlacementObj
+			if tc.oldCRP != nil {
+				oldPlacement = tc.oldCRP
+			}
+			err := validateNoSpecUpdateWhilePaused(context.Background(), Config{}, admission.Request{}, tc.crp, oldPlacement)
+			gotErr := ""
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErr); diff != "" {
+				t.Errorf("validateNoSpecUpdateWhilePaused() error mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHandlePlacementValidationRolloutPaused(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() = %v, want no error", err)
+	}
+	decoder := admission.NewDecoder(scheme)
+	newCRP := func(annotations map[string]string, numberOfClusters int32) *placementv1beta1.ClusterResourcePlacement {
+		return &placementv1beta1.ClusterResourcePlacement{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-crp", Annotations: annotations},
+			Spec: placementv1beta1.PlacementSpec{
+				Policy: &placementv1beta1.PlacementPolicy{
+					PlacementType:    placementv1beta1.PickNPlacementType,
+					NumberOfClusters: &numberOfClusters,
+				},
+			},
+		}
+	}
+	paused := map[string]string{RolloutPausedAnnotation: "true"}
+
+	testCases := map[string]struct {
+		oldCRP      *placementv1beta1.ClusterResourcePlacement
+		crp         *placementv1beta1.ClusterResourcePlacement
+		wantAllowed bool
+	}{
+		"paused and spec change": {
+			oldCRP: newCRP(paused, 2),
+			crp:    newCRP(paused, 3),
+		},
+		"paused and metadata change": {
+			oldCRP:      newCRP(paused, 2),
+			crp:         newCRP(map[string]string{RolloutPausedAnnotation: "true", "owner": "test"}, 2),
+			wantAllowed: true,
+		},
+		"unpause and spec change": {
+			oldCRP:      newCRP(paused, 2),
+			crp:         newCRP(nil, 3),
+			wantAllowed: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := buildPlacementRequest(t, admissionv1.Update, untrustedServiceAccount, tc.crp, tc.oldCRP)
+			resp := Config{}.HandlePlacementValidation(context.Background(), req, decoder, "CRP", nil, decodeCRP, decodeOldCRP, func(placementv1beta1.PlacementObj) error { return nil })
+			if resp.Allowed != tc.wantAllowed {
+				t.Errorf("HandlePlacementValidation() allowed = %t, want %t: %v", resp.Allowed, tc.wantAllowed, resp.Result)
+			}
+		})
+	}
+}
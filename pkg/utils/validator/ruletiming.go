@@ -0,0 +1,74 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	hubmetrics "github.com/kubefleet-dev/kubefleet/pkg/metrics/hub"
+)
+
+// fieldValidationRuleName is the name the field validation of the placement spec is timed as.
+const fieldValidationRuleName = "FieldValidation"
+
+// validationClock is the clock the placement validation rules are timed with; it is replaced in the tests.
+var validationClock clock.PassiveClock = clock.RealClock{}
+
+// ruleTimings times the placement validation rules run for a request and tracks the slowest one.
+type ruleTimings struct {
+	resourceType string
+	// threshold is the time the validation of the request can take before its slowest rule is logged.
+	threshold   time.Duration
+	start       time.Time
+	slowestRule string
+	slowest     time.Duration
+}
+
+// newRuleTimings starts timing the validation of a request for a placement of the argued kind.
+func newRuleTimings(resourceType string, threshold time.Duration) *ruleTimings {
+	return &ruleTimings{resourceType: resourceType, threshold: threshold, start: validationClock.Now()}
+}
+
+// run runs the named rule, recording its duration in the rule duration histogram.
+func (t *ruleTimings) run(rule string, f func()) {
+	start := validationClock.Now()
+	f()
+	elapsed := validationClock.Since(start)
+	hubmetrics.FleetPlacementValidationRuleDurationSeconds.WithLabelValues(rule, t.resourceType).Observe(elapsed.Seconds())
+	if t.slowestRule == "" || elapsed > t.slowest {
+		t.slowestRule = rule
+		t.slowest = elapsed
+	}
+}
+
+// logIfSlow logs the slowest rule if the request has been validated for longer than the threshold. It returns true
+// if the request is logged.
+func (t *ruleTimings) logIfSlow(req admission.Request) bool {
+	total := validationClock.Since(t.start)
+	if total <= t.threshold {
+		return false
+	}
+	klog.V(3).InfoS("placement validation is slow", "resourceType", t.resourceType, "operation", req.Operation,
+		"namespacedName", types.NamespacedName{Name: req.Name, Namespace: req.Namespace}, "total", total, "threshold", t.threshold,
+		"slowestRule", t.slowestRule, "slowestRuleDuration", t.slowest)
+	return true
+}
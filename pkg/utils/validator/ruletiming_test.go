@@ -0,0 +1,148 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	prometheusclientmodel "github.com/prometheus/client_model/go"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	hubmetrics "github.com/kubefleet-dev/kubefleet/pkg/metrics/hub"
+)
+
+// ruleDurationSamples returns the number and the sum of the durations observed for the rule on CRPs.
+func ruleDurationSamples(t *testing.T, rule string) (uint64, float64) {
+	t.Helper()
+	metric := &prometheusclientmodel.Metric{}
+	if err := hubmetrics.FleetPlacementValidationRuleDurationSeconds.WithLabelValues(rule, "CRP").(prometheus.Metric).Write(metric); err != nil {
+		t.Fatalf("Write() = %v, want no error", err)
+	}
+	return metric.GetHistogram().GetSampleCount(), metric.GetHistogram().GetSampleSum()
+}
+
+// newSteppingRule returns a passing placement validation rule which takes the argued duration on the fake clock.
+func newSteppingRule(name string, fakeClock *clocktesting.FakePassiveClock, duration time.Duration) PlacementValidationRule {
+	return PlacementValidationRule{
+		Name:  name,
+		Class: CorrectnessValidation,
+		Validate: func(context.Context, Config, admission.Request, placementv1beta1.PlacementObj, placementv1beta1.PlacementObj) error {
+			fakeClock.SetTime(fakeClock.Now().Add(duration))
+			return nil
+		},
+	}
+}
+
+func TestHandlePlacementValidationRuleTimings(t *testing.T) {
+	originalRules := placementValidationRules
+	originalClock := validationClock
+	t.Cleanup(func() {
+		placementValidationRules = originalRules
+		validationClock = originalClock
+	})
+	fakeClock := clocktesting.NewFakePassiveClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	validationClock = fakeClock
+	placementValidationRules = []PlacementValidationRule{
+		newSteppingRule("TestFastRule", fakeClock, 10*time.Millisecond),
+		newSteppingRule("TestSlowRule", fakeClock, 2*time.Second),
+	}
+	wantDurations := map[string]float64{"TestFastRule": 0.01, "TestSlowRule": 2, fieldValidationRuleName: 0}
+
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() = %v, want no error", err)
+	}
+	countsBefore := make(map[string]uint64, len(wantDurations))
+	sumsBefore := make(map[string]float64, len(wantDurations))
+	for rule := range wantDurations {
+		countsBefore[rule], sumsBefore[rule] = ruleDurationSamples(t, rule)
+	}
+
+	req := buildPlacementRequest(t, admissionv1.Create, untrustedServiceAccount, newCRPWithMetadataSize(10), nil)
+	resp := Config{}.HandlePlacementValidation(context.Background(), req, admission.NewDecoder(scheme), "CRP", nil, decodeCRP, decodeOldCRP, func(placementv1beta1.PlacementObj) error { return nil })
+	if !resp.Allowed {
+		t.Fatalf("HandlePlacementValidation() allowed = false, want true: %v", resp.Result)
+	}
+	for rule, wantDuration := range wantDurations {
+		count, sum := ruleDurationSamples(t, rule)
+		if got := count - countsBefore[rule]; got != 1 {
+			t.Errorf("HandlePlacementValidation() observed %d durations of rule %s, want 1", got, rule)
+		}
+		if got := sum - sumsBefore[rule]; got != wantDuration {
+			t.Errorf("HandlePlacementValidation() observed %v seconds for rule %s, want %v", got, rule, wantDuration)
+		}
+	}
+}
+
+func TestRuleTimingsLogIfSlow(t *testing.T) {
+	originalClock := validationClock
+	t.Cleanup(func() {
+		validationClock = originalClock
+	})
+
+	testCases := map[string]struct {
+		config          Config
+		durations       map[string]time.Duration
+		wantLogged      bool
+		wantSlowestRule string
+	}{
+		"fast request is not logged": {
+			durations:       map[string]time.Duration{"RuleA": 100 * time.Millisecond, "RuleB": 200 * time.Millisecond},
+			wantLogged:      false,
+			wantSlowestRule: "RuleB",
+		},
+		"request at the threshold is not logged": {
+			durations:       map[string]time.Duration{"RuleA": DefaultSlowValidationThreshold},
+			wantLogged:      false,
+			wantSlowestRule: "RuleA",
+		},
+		"slow request is logged with its slowest rule": {
+			durations:       map[string]time.Duration{"RuleA": 100 * time.Millisecond, "RuleB": 700 * time.Millisecond},
+			wantLogged:      true,
+			wantSlowestRule: "RuleB",
+		},
+		"many fast rules add up to a slow request": {
+			config:          Config{SlowValidationThreshold: 250 * time.Millisecond},
+			durations:       map[string]time.Duration{"RuleA": 100 * time.Millisecond, "RuleB": 120 * time.Millisecond, "RuleC": 80 * time.Millisecond},
+			wantLogged:      true,
+			wantSlowestRule: "RuleB",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			fakeClock := clocktesting.NewFakePassiveClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+			validationClock = fakeClock
+			timings := newRuleTimings("CRP", tc.config.slowValidationThreshold())
+			for rule, duration := range tc.durations {
+				timings.run(rule, func() { fakeClock.SetTime(fakeClock.Now().Add(duration)) })
+			}
+			if got := timings.logIfSlow(admission.Request{}); got != tc.wantLogged {
+				t.Errorf("logIfSlow() = %t, want %t", got, tc.wantLogged)
+			}
+			if timings.slowestRule != tc.wantSlowestRule {
+				t.Errorf("slowest rule = %s, want %s", timings.slowestRule, tc.wantSlowestRule)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+// AllowSecretPropagationAnnotation is the annotation a placement carries with the value "true" to acknowledge
+// that it propagates Secrets to the member clusters.
+const AllowSecretPropagationAnnotation = "kubefleet.io/allow-secret-propagation"
+
+// secretSelections describes the resource selectors of the placement which select Secrets, in the order of the
+// selectors. It returns nil if the placement selects no Secret.
+func secretSelections(placement placementv1beta1.PlacementObj) []string {
+	var selections []string
+	for i, selector := range placement.GetPlacementSpec().ResourceSelectors {
+		if selector.Group != "" {
+			continue
+		}
+		var selection string
+		switch {
+		case strings.EqualFold(selector.Kind, "Secret"):
+			selection = describeSecretSelector(placement, selector)
+		case strings.EqualFold(selector.Kind, "Namespace") && selector.SelectionScope != placementv1beta1.NamespaceOnly:
+			selection = describeNamespaceSelector(selector)
+		default:
+			continue
+		}
+		selections = append(selections, fmt.Sprintf("%s (resourceSelectors[%d])", selection, i))
+	}
+	return selections
+}
+
+// describeSecretSelector describes a resource selector of the Secret kind, telling a selector naming a specific
+// Secret apart from one selecting all the Secrets or the ones matching a label selector.
+func describeSecretSelector(placement placementv1beta1.PlacementObj, selector placementv1beta1.ResourceSelectorTerm) string {
+	scope := ""
+	if placement.GetNamespace() != "" {
+		scope = " in namespace " + placement.GetNamespace()
+	}
+	switch {
+	case selector.Name != "":
+		return fmt.Sprintf("Secret %s%s by name", selector.Name, scope)
+	case selector.LabelSelector != nil:
+		return fmt.Sprintf("all the Secrets%s matching label selector %q", scope, metav1.FormatLabelSelector(selector.LabelSelector))
+	default:
+		return "all the Secrets" + scope
+	}
+}
+
+// describeNamespaceSelector describes a resource selector of the Namespace kind which selects all the resources in
+// the namespaces, including their Secrets.
+func describeNamespaceSelector(selector placementv1beta1.ResourceSelectorTerm) string {
+	switch {
+	case selector.Name != "":
+		return fmt.Sprintf("namespace %s with all its resources, including its Secrets", selector.Name)
+	case selector.LabelSelector != nil:
+		return fmt.Sprintf("the namespaces matching label selector %q with all their resources, including their Secrets", metav1.FormatLabelSelector(selector.LabelSelector))
+	default:
+		return "all the namespaces with all their resources, including their Secrets"
+	}
+}
+
+// allowsSecretPropagation returns true if the placement carries the annotation acknowledging the propagation of
+// Secrets.
+func allowsSecretPropagation(placement placementv1beta1.PlacementObj) bool {
+	return placement.GetAnnotations()[AllowSecretPropagationAnnotation] == "true"
+}
+
+// validateSecretPropagationOptIn denies a placement which selects Secrets without the annotation acknowledging it
+// if Config.RequireSecretPropagationOptIn is set.
+func validateSecretPropagationOptIn(_ context.Context, config Config, _ admission.Request, placement, _ placementv1beta1.PlacementObj) error {
+	if !config.RequireSecretPropagationOptIn || allowsSecretPropagation(placement) {
+		return nil
+	}
+	selections := secretSelections(placement)
+	if len(selections) == 0 {
+		return nil
+	}
+	return errors.New(config.DenialMessage(SecretPropagationOptInMessageID, map[string]any{
+		"selections": strings.Join(selections, "; "),
+		"annotation": AllowSecretPropagationAnnotation,
+	}))
+}
+
+// warnSecretPropagation warns about a placement which selects Secrets without the annotation acknowledging it. The
+// placement is denied by validateSecretPropagationOptIn instead if Config.RequireSecretPropagationOptIn is set.
+func warnSecretPropagation(config Config, _ admission.Request, placement, _ placementv1beta1.PlacementObj) []string {
+	if config.RequireSecretPropagationOptIn || allowsSecretPropagation(placement) {
+		return nil
+	}
+	var warnings []string
+	for _, selection := range secretSelections(placement) {
+		warnings = append(warnings, fmt.Sprintf("the placement selects %s, which propagates Secrets to the member clusters; "+
+			"add the annotation %s: \"true\" to acknowledge the propagation of Secrets", selection, AllowSecretPropagationAnnotation))
+	}
+	return warnings
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+func TestSecretPropagationOptIn(t *testing.T) {
+	newCRP := func(annotations map[string]string, selectors ...placementv1beta1.ResourceSelectorTerm) *placementv1beta1.ClusterResourcePlacement {
+		return &placementv1beta1.ClusterResourcePlacement{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-crp", Annotations: annotations},
+			Spec:       placementv1beta1.PlacementSpec{ResourceSelectors: selectors},
+		}
+	}
+	namedSecret := placementv1beta1.ResourceSelectorTerm{Version: "v1", Kind: "Secret", Name: "db-password"}
+	labeledSecrets := placementv1beta1.ResourceSelectorTerm{Version: "v1", Kind: "Secret", LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}}}
+	namespace := placementv1beta1.ResourceSelectorTerm{Version: "v1", Kind: "Namespace", Name: "app"}
+	labeledNamespaces := placementv1beta1.ResourceSelectorTerm{Version: "v1", Kind: "Namespace", LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}}
+	namespaceOnly := placementv1beta1.ResourceSelectorTerm{Version: "v1", Kind: "Namespace", Name: "app", SelectionScope: placementv1beta1.NamespaceOnly}
+	configMap := placementv1beta1.ResourceSelectorTerm{Version: "v1", Kind: "ConfigMap", Name: "settings"}
+	optIn := map[string]string{AllowSecretPropagationAnnotation: "true"}
+	rp := &placementv1beta1.ResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-rp", Namespace: "test-ns"},
+		Spec:       placementv1beta1.PlacementSpec{ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{namedSecret, {Version: "v1", Kind: "Secret"}}},
+	}
+
+	testCases := map[string]struct {
+		placement    placementv1beta1.PlacementObj
+		strict       bool
+		wantErr      string
+		wantWarnings []string
+	}{
+		"named Secret in warn mode": {
+			placement:    newCRP(nil, configMap, namedSecret),
+			wantWarnings: []string{`the placement selects Secret db-password by name (resourceSelectors[1]), which propagates Secrets to the member clusters; add the annotation kubefleet.io/allow-secret-propagation: "true" to acknowledge the propagation of Secrets`},
+		},
+		"label selected Secrets in warn mode": {
+			placement:    newCRP(nil, labeledSecrets),
+			wantWarnings: []string{`the placement selects all the Secrets matching label selector "app=db" (resourceSelectors[0]), which propagates Secrets to the member clusters; add the annotation kubefleet.io/allow-secret-propagation: "true" to acknowledge the propagation of Secrets`},
+		},
+		"namespaces with all their resources in warn mode": {
+			placement: newCRP(nil, namespace, labeledNamespaces),
+			wantWarnings: []string{
+				`the placement selects namespace app with all its resources, including its Secrets (resourceSelectors[0]), which propagates Secrets to the member clusters; add the annotation kubefleet.io/allow-secret-propagation: "true" to acknowledge the propagation of Secrets`,
+				`the placement selects the namespaces matching label selector "team=a" with all their resources, including their Secrets (resourceSelectors[1]), which propagates Secrets to the member clusters; add the annotation kubefleet.io/allow-secret-propagation: "true" to acknowledge the propagation of Secrets`,
+			},
+		},
+		"Secrets of a resource placement in warn mode": {
+			placement: rp,
+			wantWarnings: []string{
+				`the placement selects Secret db-password in namespace test-ns by name (resourceSelectors[0]), which propagates Secrets to the member clusters; add the annotation kubefleet.io/allow-secret-propagation: "true" to acknowledge the propagation of Secrets`,
+				`the placement selects all the Secrets in namespace test-ns (resourceSelectors[1]), which propagates Secrets to the member clusters; add the annotation kubefleet.io/allow-secret-propagation: "true" to acknowledge the propagation of Secrets`,
+			},
+		},
+		"no Secret selected": {
+			placement: newCRP(nil, configMap, namespaceOnly, placementv1beta1.ResourceSelectorTerm{Group: "example.com", Version: "v1", Kind: "Secret"}),
+		},
+		"no Secret selected in strict mode": {
+			placement: newCRP(nil, configMap, namespaceOnly),
+			strict:    true,
+		},
+		"named and label selected Secrets in strict mode": {
+			placement: newCRP(nil, namedSecret, labeledSecrets),
+			strict:    true,
+			wantErr: `the placement selects Secret db-password by name (resourceSelectors[0]); all the Secrets matching label selector "app=db" (resourceSelectors[1]), ` +
+				`which propagates Secrets to the member clusters; add the annotation kubefleet.io/allow-secret-propagation: "true" to allow the propagation of Secrets`,
+		},
+		"namespace with all its resources in strict mode": {
+			placement: newCRP(nil, namespace),
+			strict:    true,
+			wantErr: `the placement selects namespace app with all its resources, including its Secrets (resourceSelectors[0]), ` +
+				`which propagates Secrets to the member clusters; add the annotation kubefleet.io/allow-secret-propagation: "true" to allow the propagation of Secrets`,
+		},
+		"opted in placement in warn mode": {
+			placement: newCRP(optIn, namedSecret, namespace),
+		},
+		"opted in placement in strict mode": {
+			placement: newCRP(optIn, namedSecret, namespace),
+			strict:    true,
+		},
+		"opt-in annotation with a value other than true in strict mode": {
+			placement: newCRP(map[string]string{AllowSecretPropagationAnnotation: "yes"}, namedSecret),
+			strict:    true,
+			wantErr: `the placement selects Secret db-password by name (resourceSelectors[0]), ` +
+				`which propagates Secrets to the member clusters; add the annotation kubefleet.io/allow-secret-propagation: "true" to allow the propagation of Secrets`,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := Config{RequireSecretPropagationOptIn: tc.strict}
+			err := validateSecretPropagationOptIn(context.Background(), config, admission.Request{}, tc.placement, nil)
+			gotErr := ""
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if gotErr != tc.wantErr {
+				t.Errorf("validateSecretPropagationOptIn() = %q, want %q", gotErr, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.wantWarnings, warnSecretPropagation(config, admission.Request{}, tc.placement, nil)); diff != "" {
+				t.Errorf("warnSecretPropagation() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/defaulter"
+)
+
+// PlacementSpecSemanticEqual returns true if the specs of the old and new placements are equal once they are
+// normalized, together with the paths of the spec fields which differ, sorted. A spec is normalized by setting
+// its defaulted values, e.g., the PickAll placement type of a nil policy, sorting its tolerations, whose order is
+// irrelevant, and dropping its nil and empty lists alike.
+func PlacementSpecSemanticEqual(oldPlacement, newPlacement placementv1beta1.PlacementObj) (bool, []string) {
+	if oldPlacement == nil || newPlacement == nil {
+		if oldPlacement == nil && newPlacement == nil {
+			return true, nil
+		}
+		return false, []string{field.NewPath("spec").String()}
+	}
+	oldValue, oldErr := normalizedPlacementSpec(oldPlacement.GetPlacementSpec())
+	newValue, newErr := normalizedPlacementSpec(newPlacement.GetPlacementSpec())
+	if oldErr != nil || newErr != nil {
+		// The specs are plain structs which always marshal; they are told apart as a whole should they not.
+		return false, []string{field.NewPath("spec").String()}
+	}
+	var entries []ObjectDiffEntry
+	diffJSONValues("", oldValue, newValue, false, &entries)
+	paths := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		paths = append(paths, specFieldPath(entry.Path))
+	}
+	return len(paths) == 0, paths
+}
+
+// normalizedPlacementSpec returns the generic JSON value of a normalized copy of the spec.
+func normalizedPlacementSpec(spec *placementv1beta1.PlacementSpec) (interface{}, error) {
+	normalized := &placementv1beta1.ClusterResourcePlacement{Spec: *spec.DeepCopy()}
+	defaulter.SetPlacementDefaults(normalized)
+	if normalized.Spec.Policy.PlacementType == "" {
+		normalized.Spec.Policy.PlacementType = placementv1beta1.PickAllPlacementType
+	}
+	if normalized.Spec.StatusReportingScope == "" {
+		normalized.Spec.StatusReportingScope = placementv1beta1.ClusterScopeOnly
+	}
+	slices.SortStableFunc(normalized.Spec.Policy.Tolerations, func(a, b placementv1beta1.Toleration) int {
+		return cmp.Or(
+			cmp.Compare(a.Key, b.Key),
+			cmp.Compare(a.Operator, b.Operator),
+			cmp.Compare(a.Value, b.Value),
+			cmp.Compare(a.Effect, b.Effect),
+		)
+	})
+	value, err := toJSONValue(normalized.Spec)
+	if err != nil {
+		return nil, err
+	}
+	return pruneEmptyJSONLists(value), nil
+}
+
+// pruneEmptyJSONLists returns the JSON value with the fields holding null or an empty list removed from all its
+// objects, so that the nil and empty lists of the Go structs compare equal.
+func pruneEmptyJSONLists(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, item := range v {
+			item = pruneEmptyJSONLists(item)
+			if list, isList := item.([]interface{}); item == nil || (isList && len(list) == 0) {
+				delete(v, k)
+				continue
+			}
+			v[k] = item
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = pruneEmptyJSONLists(v[i])
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+// specFieldPath returns the field path, e.g., spec.policy.tolerations, of the JSON pointer relative to the spec.
+func specFieldPath(pointer string) string {
+	path := field.NewPath("spec")
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		if token == "" {
+			continue
+		}
+		path = path.Child(strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~"))
+	}
+	return path.String()
+}
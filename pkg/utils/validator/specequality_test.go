@@ -0,0 +1,253 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+func TestPlacementSpecSemanticEqual(t *testing.T) {
+	selectors := func() []placementv1beta1.ResourceSelectorTerm {
+		return []placementv1beta1.ResourceSelectorTerm{{Group: "", Version: "v1", Kind: "Namespace", Name: "app"}}
+	}
+	newCRP := func(mutate func(spec *placementv1beta1.PlacementSpec)) *placementv1beta1.ClusterResourcePlacement {
+		crp := &placementv1beta1.ClusterResourcePlacement{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-crp"},
+			Spec:       placementv1beta1.PlacementSpec{ResourceSelectors: selectors()},
+		}
+		if mutate != nil {
+			mutate(&crp.Spec)
+		}
+		return crp
+	}
+	tolerationA := placementv1beta1.Toleration{Key: "a", Operator: corev1.TolerationOpEqual, Value: "1", Effect: corev1.TaintEffectNoSchedule}
+	tolerationB := placementv1beta1.Toleration{Key: "b", Operator: corev1.TolerationOpExists}
+	pickN := func(n int32) func(spec *placementv1beta1.PlacementSpec) {
+		return func(spec *placementv1beta1.PlacementSpec) {
+			spec.Policy = &placementv1beta1.PlacementPolicy{PlacementType: placementv1beta1.PickNPlacementType, NumberOfClusters: ptr.To(n)}
+		}
+	}
+
+	testCases := map[string]struct {
+		oldPlacement placementv1beta1.PlacementObj
+		newPlacement placementv1beta1.PlacementObj
+		wantEqual    bool
+		wantPaths    []string
+	}{
+		"identical specs": {
+			oldPlacement: newCRP(pickN(2)),
+			newPlacement: newCRP(pickN(2)),
+			wantEqual:    true,
+		},
+		"metadata only change": {
+			oldPlacement: newCRP(nil),
+			newPlacement: &placementv1beta1.ClusterResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-crp", Labels: map[string]string{"app": "test"}},
+				Spec:       placementv1beta1.PlacementSpec{ResourceSelectors: selectors()},
+			},
+			wantEqual: true,
+		},
+		"nil policy and the defaulted PickAll policy": {
+			oldPlacement: newCRP(nil),
+			newPlacement: newCRP(func(spec *placementv1beta1.PlacementSpec) {
+				spec.Policy = &placementv1beta1.PlacementPolicy{PlacementType: placementv1beta1.PickAllPlacementType}
+			}),
+			wantEqual: true,
+		},
+		"empty placement type and PickAll": {
+			oldPlacement: newCRP(func(spec *placementv1beta1.PlacementSpec) {
+				spec.Policy = &placementv1beta1.PlacementPolicy{Tolerations: []placementv1beta1.Toleration{tolerationA}}
+			}),
+			newPlacement: newCRP(func(spec *placementv1beta1.PlacementSpec) {
+				spec.Policy = &placementv1beta1.PlacementPolicy{PlacementType: placementv1beta1.PickAllPlacementType, Tolerations: []placementv1beta1.Toleration{tolerationA}}
+			}),
+			wantEqual: true,
+		},
+		"reordered tolerations": {
+			oldPlacement: newCRP(func(spec *placementv1beta1.PlacementSpec) {
+				spec.Policy = &placementv1beta1.PlacementPolicy{Tolerations: []placementv1beta1.Toleration{tolerationA, tolerationB}}
+			}),
+			newPlacement: newCRP(func(spec *placementv1beta1.PlacementSpec) {
+				spec.Policy = &placementv1beta1.PlacementPolicy{Tolerations: []placementv1beta1.Toleration{tolerationB, tolerationA}}
+			}),
+			wantEqual: true,
+		},
+		"toleration with the defaulted Equal operator": {
+			oldPlacement: newCRP(func(spec *placementv1beta1.PlacementSpec) {
+				spec.Policy = &placementv1beta1.PlacementPolicy{Tolerations: []placementv1beta1.Toleration{{Key: "a", Value: "1", Effect: corev1.TaintEffectNoSchedule}}}
+			}),
+			newPlacement: newCRP(func(spec *placementv1beta1.PlacementSpec) {
+				spec.Policy = &placementv1beta1.PlacementPolicy{Tolerations: []placementv1beta1.Toleration{tolerationA}}
+			}),
+			wantEqual: true,
+		},
+		"nil and empty lists": {
+			oldPlacement: newCRP(func(spec *placementv1beta1.PlacementSpec) {
+				spec.ResourceSelectors = nil
+				spec.Policy = &placementv1beta1.PlacementPolicy{PlacementType: placementv1beta1.PickFixedPlacementType}
+			}),
+			newPlacement: newCRP(func(spec *placementv1beta1.PlacementSpec) {
+				spec.ResourceSelectors = []placementv1beta1.ResourceSelectorTerm{}
+				spec.Policy = &placementv1beta1.PlacementPolicy{
+					PlacementType: placementv1beta1.PickFixedPlacementType,
+					ClusterNames:  []string{},
+					Tolerations:   []placementv1beta1.Toleration{},
+				}
+			}),
+			wantEqual: true,
+		},
+		"defaulted strategy, revision history limit and status reporting scope": {
+			oldPlacement: newCRP(nil),
+			newPlacement: newCRP(func(spec *placementv1beta1.PlacementSpec) {
+				spec.Strategy = placementv1beta1.RolloutStrategy{
+					Type: placementv1beta1.RollingUpdateRolloutStrategyType,
+					RollingUpdate: &placementv1beta1.RollingUpdateConfig{
+						MaxUnavailable:           ptr.To(intstr.FromString("25%")),
+						MaxSurge:                 ptr.To(intstr.FromString("25%")),
+						UnavailablePeriodSeconds: ptr.To(60),
+					},
+					ApplyStrategy: &placementv1beta1.ApplyStrategy{Type: placementv1beta1.ApplyStrategyTypeClientSideApply},
+				}
+				spec.RevisionHistoryLimit = ptr.To(int32(10))
+				spec.StatusReportingScope = placementv1beta1.ClusterScopeOnly
+			}),
+			wantEqual: true,
+		},
+		"resource placements": {
+			oldPlacement: &placementv1beta1.ResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-rp", Namespace: "app"},
+				Spec:       placementv1beta1.PlacementSpec{ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{{Group: "apps", Version: "v1", Kind: "Deployment", Name: "web"}}},
+			},
+			newPlacement: &placementv1beta1.ResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-rp", Namespace: "app"},
+				Spec: placementv1beta1.PlacementSpec{
+					ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{{Group: "apps", Version: "v1", Kind: "Deployment", Name: "web"}},
+					Policy:            &placementv1beta1.PlacementPolicy{PlacementType: placementv1beta1.PickAllPlacementType},
+				},
+			},
+			wantEqual: true,
+		},
+		"number of clusters changed": {
+			oldPlacement: newCRP(pickN(2)),
+			newPlacement: newCRP(pickN(3)),
+			wantPaths:    []string{"spec.policy.numberOfClusters"},
+		},
+		"placement type changed": {
+			oldPlacement: newCRP(nil),
+			newPlacement: newCRP(pickN(2)),
+			wantPaths:    []string{"spec.policy.numberOfClusters", "spec.policy.placementType"},
+		},
+		"toleration added": {
+			oldPlacement: newCRP(func(spec *placementv1beta1.PlacementSpec) {
+				spec.Policy = &placementv1beta1.PlacementPolicy{Tolerations: []placementv1beta1.Toleration{tolerationA}}
+			}),
+			newPlacement: newCRP(func(spec *placementv1beta1.PlacementSpec) {
+				spec.Policy = &placementv1beta1.PlacementPolicy{Tolerations: []placementv1beta1.Toleration{tolerationB, tolerationA}}
+			}),
+			wantPaths: []string{"spec.policy.tolerations"},
+		},
+		"toleration value changed": {
+			oldPlacement: newCRP(func(spec *placementv1beta1.PlacementSpec) {
+				spec.Policy = &placementv1beta1.PlacementPolicy{Tolerations: []placementv1beta1.Toleration{tolerationA}}
+			}),
+			newPlacement: newCRP(func(spec *placementv1beta1.PlacementSpec) {
+				changed := tolerationA
+				changed.Value = "2"
+				spec.Policy = &placementv1beta1.PlacementPolicy{Tolerations: []placementv1beta1.Toleration{changed}}
+			}),
+			wantPaths: []string{"spec.policy.tolerations"},
+		},
+		"resource selector changed": {
+			oldPlacement: newCRP(nil),
+			newPlacement: newCRP(func(spec *placementv1beta1.PlacementSpec) {
+				spec.ResourceSelectors[0].Name = "other"
+			}),
+			wantPaths: []string{"spec.resourceSelectors"},
+		},
+		"revision history limit changed from the default": {
+			oldPlacement: newCRP(nil),
+			newPlacement: newCRP(func(spec *placementv1beta1.PlacementSpec) {
+				spec.RevisionHistoryLimit = ptr.To(int32(5))
+			}),
+			wantPaths: []string{"spec.revisionHistoryLimit"},
+		},
+		"strategy and apply strategy changed": {
+			oldPlacement: newCRP(nil),
+			newPlacement: newCRP(func(spec *placementv1beta1.PlacementSpec) {
+				spec.Strategy.RollingUpdate = &placementv1beta1.RollingUpdateConfig{MaxUnavailable: ptr.To(intstr.FromInt32(1))}
+				spec.Strategy.ApplyStrategy = &placementv1beta1.ApplyStrategy{Type: placementv1beta1.ApplyStrategyTypeServerSideApply}
+			}),
+			wantPaths: []string{
+				"spec.strategy.applyStrategy.serverSideApplyConfig",
+				"spec.strategy.applyStrategy.type",
+				"spec.strategy.rollingUpdate.maxUnavailable",
+			},
+		},
+		"status reporting scope changed": {
+			oldPlacement: newCRP(nil),
+			newPlacement: newCRP(func(spec *placementv1beta1.PlacementSpec) {
+				spec.StatusReportingScope = placementv1beta1.NamespaceAccessible
+			}),
+			wantPaths: []string{"spec.statusReportingScope"},
+		},
+		"no old placement": {
+			newPlacement: newCRP(nil),
+			wantPaths:    []string{"spec"},
+		},
+		"no placements": {
+			wantEqual: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var oldSpec, newSpec *placementv1beta1.PlacementSpec
+			if tc.oldPlacement != nil {
+				oldSpec = tc.oldPlacement.GetPlacementSpec().DeepCopy()
+			}
+			if tc.newPlacement != nil {
+				newSpec = tc.newPlacement.GetPlacementSpec().DeepCopy()
+			}
+			gotEqual, gotPaths := PlacementSpecSemanticEqual(tc.oldPlacement, tc.newPlacement)
+			if gotEqual != tc.wantEqual {
+				t.Errorf("PlacementSpecSemanticEqual() = %t, want %t", gotEqual, tc.wantEqual)
+			}
+			if diff := cmp.Diff(tc.wantPaths, gotPaths, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("PlacementSpecSemanticEqual() paths mismatch (-want, +got):\n%s", diff)
+			}
+			// The normalization works on copies of the specs.
+			if tc.oldPlacement != nil {
+				if diff := cmp.Diff(oldSpec, tc.oldPlacement.GetPlacementSpec()); diff != "" {
+					t.Errorf("PlacementSpecSemanticEqual() mutated the old spec (-want, +got):\n%s", diff)
+				}
+			}
+			if tc.newPlacement != nil {
+				if diff := cmp.Diff(newSpec, tc.newPlacement.GetPlacementSpec()); diff != "" {
+					t.Errorf("PlacementSpecSemanticEqual() mutated the new spec (-want, +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
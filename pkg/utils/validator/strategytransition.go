@@ -0,0 +1,143 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/condition"
+)
+
+// UpdateRunReader is used to look up the staged update runs of a placement when its rollout strategy type
+// changes from External to RollingUpdate. The lookup is skipped if it is nil, e.g., when the staged update
+// run APIs are not enabled.
+var UpdateRunReader client.Reader
+
+// rolloutConditionTypes are the placement conditions which track the progress of a rollout.
+var rolloutConditionTypes = []condition.ResourceCondition{
+	condition.RolloutStartedCondition,
+	condition.OverriddenCondition,
+	condition.WorkSynchronizedCondition,
+	condition.AppliedCondition,
+	condition.AvailableCondition,
+}
+
+// validateStrategyTypeTransition denies changing the rollout strategy type while the existing placement is in
+// the middle of a rollout, so that the bindings are not left half-managed by both rollout mechanisms. It also
+// denies changing the type from External to RollingUpdate while any staged update run references the placement.
+func validateStrategyTypeTransition(ctx context.Context, config Config, _ admission.Request, placement, oldPlacement placementv1beta1.PlacementObj) error {
+	if oldPlacement == nil {
+		return nil
+	}
+	oldType := rolloutStrategyType(oldPlacement)
+	newType := rolloutStrategyType(placement)
+	if oldType == newType {
+		return nil
+	}
+	if isRolloutInProgress(oldPlacement) {
+		return fmt.Errorf("the rollout strategy type cannot be changed from %s to %s while a rollout is in progress, please retry after the rollout completes", oldType, newType)
+	}
+	if oldType == placementv1beta1.ExternalRolloutStrategyType && UpdateRunReader != nil {
+		updateRuns, err := listUpdateRunNames(ctx, config, oldPlacement, nil)
+		if err != nil {
+			return fmt.Errorf("failed to list the staged update runs of the placement, please retry the request: %w", err)
+		}
+		if len(updateRuns) > 0 {
+			return fmt.Errorf("the rollout strategy type cannot be changed from %s to %s while staged update run(s) %s reference the placement, please delete them first", oldType, newType, strings.Join(updateRuns, ", "))
+		}
+	}
+	return nil
+}
+
+// rolloutStrategyType returns the rollout strategy type of the placement, which defaults to RollingUpdate.
+func rolloutStrategyType(placement placementv1beta1.PlacementObj) placementv1beta1.RolloutStrategyType {
+	if t := placement.GetPlacementSpec().Strategy.Type; t != "" {
+		return t
+	}
+	return placementv1beta1.RollingUpdateRolloutStrategyType
+}
+
+// isRolloutInProgress returns true if the placement status reports a rollout which has not settled yet, i.e.,
+// the rollout is blocked from starting on some clusters or any rollout condition is still unknown. A placement
+// whose rollout is controlled by an external controller is not considered in progress as its update runs are
+// checked instead.
+func isRolloutInProgress(placement placementv1beta1.PlacementObj) bool {
+	conditions := placement.GetPlacementStatus().Conditions
+	for _, c := range rolloutConditionTypes {
+		conditionType := string(c.ClusterResourcePlacementConditionType())
+		if placement.GetNamespace() != "" {
+			conditionType = string(c.ResourcePlacementConditionType())
+		}
+		cond := meta.FindStatusCondition(conditions, conditionType)
+		if cond == nil {
+			continue
+		}
+		if c == condition.RolloutStartedCondition {
+			if cond.Reason == condition.RolloutControlledByExternalControllerReason {
+				return false
+			}
+			if cond.Status == metav1.ConditionFalse {
+				return true
+			}
+		}
+		if cond.Status == metav1.ConditionUnknown {
+			return true
+		}
+	}
+	return false
+}
+
+// listUpdateRunNames returns the sorted names of the staged update runs which reference the placement. Only the
+// update runs the filter returns true for are included if the filter is not nil. A *LookupBudgetExhaustedError is
+// returned if the lookup budget is exhausted.
+func listUpdateRunNames(ctx context.Context, config Config, placement placementv1beta1.PlacementObj, filter func(placementv1beta1.UpdateRunObj) bool) ([]string, error) {
+	ctx, cancel := config.WithLookupBudget(ctx, 0)
+	defer cancel()
+	var list placementv1beta1.UpdateRunObjList = &placementv1beta1.ClusterStagedUpdateRunList{}
+	var opts []client.ListOption
+	if placement.GetNamespace() != "" {
+		list = &placementv1beta1.StagedUpdateRunList{}
+		opts = append(opts, client.InNamespace(placement.GetNamespace()))
+	}
+	if err := ListByIndex(ctx, UpdateRunReader, list, UpdateRunPlacementNameIndexKey, placement.GetName(), opts...); err != nil {
+		if exhausted := config.LookupBudgetExhausted(ctx, UpdateRunsLookupCheck, err); exhausted != nil {
+			return nil, exhausted
+		}
+		// The update run API of the placement scope may not be installed, in which case there is no update run.
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var names []string
+	for _, updateRun := range list.GetUpdateRunObjs() {
+		if filter == nil || filter(updateRun) {
+			names = append(names, updateRun.GetName())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
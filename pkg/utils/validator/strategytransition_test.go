@@ -0,0 +1,244 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/condition"
+)
+
+// rolloutConditions returns the CRP rollout conditions with the argued statuses, in the order of
+// RolloutStarted, Overridden, WorkSynchronized, Applied and Available.
+func rolloutConditions(reason string, statuses ...metav1.ConditionStatus) []metav1.Condition {
+	conditions := make([]metav1.Condition, 0, len(statuses))
+	for i, status := range statuses {
+		conditions = append(conditions, metav1.Condition{
+			Type:   string(rolloutConditionTypes[i].ClusterResourcePlacementConditionType()),
+			Status: status,
+			Reason: reason,
+		})
+	}
+	return conditions
+}
+
+func newCRPWithStrategy(strategyType placementv1beta1.RolloutStrategyType, conditions []metav1.Condition) *placementv1beta1.ClusterResourcePlacement {
+	return &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-crp"},
+		Spec: placementv1beta1.PlacementSpec{
+			Strategy: placementv1beta1.RolloutStrategy{Type: strategyType},
+		},
+		Status: placementv1beta1.PlacementStatus{Conditions: conditions},
+	}
+}
+
+func newClusterStagedUpdateRun(name, placementName string) *placementv1beta1.ClusterStagedUpdateRun {
+	return &placementv1beta1.ClusterStagedUpdateRun{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       placementv1beta1.UpdateRunSpec{PlacementName: placementName},
+	}
+}
+
+func TestValidateStrategyTypeTransition(t *testing.T) {
+	rollingUpdate := placementv1beta1.RollingUpdateRolloutStrategyType
+	external := placementv1beta1.ExternalRolloutStrategyType
+	ok, f, u := metav1.ConditionTrue, metav1.ConditionFalse, metav1.ConditionUnknown
+	completed := rolloutConditions(condition.RolloutStartedReason, ok, ok, ok, ok, ok)
+	blocked := rolloutConditions(condition.RolloutNotStartedYetReason, f)
+	applying := rolloutConditions(condition.RolloutStartedReason, ok, ok, ok, u)
+	failed := rolloutConditions(condition.RolloutStartedReason, ok, ok, ok, f)
+	externallyControlled := rolloutConditions(condition.RolloutControlledByExternalControllerReason, u, u)
+
+	testCases := map[string]struct {
+		oldCRP      *placementv1beta1.ClusterResourcePlacement
+		crp         *placementv1beta1.ClusterResourcePlacement
+		noOldObject bool
+		updateRuns  []client.Object
+		listErr     error
+		noReader    bool
+		wantErr     string
+	}{
+		"create": {
+			crp:         newCRPWithStrategy(external, nil),
+			noOldObject: true,
+		},
+		"unchanged type during an active rollout": {
+			oldCRP: newCRPWithStrategy(rollingUpdate, applying),
+			crp:    newCRPWithStrategy("", applying),
+		},
+		"RollingUpdate to External on a completed rollout": {
+			oldCRP: newCRPWithStrategy(rollingUpdate, completed),
+			crp:    newCRPWithStrategy(external, completed),
+		},
+		"RollingUpdate to External on a failed rollout": {
+			oldCRP: newCRPWithStrategy(rollingUpdate, failed),
+			crp:    newCRPWithStrategy(external, failed),
+		},
+		"RollingUpdate to External without status": {
+			oldCRP: newCRPWithStrategy(rollingUpdate, nil),
+			crp:    newCRPWithStrategy(external, nil),
+		},
+		"RollingUpdate to External while the rollout is blocked": {
+			oldCRP:  newCRPWithStrategy(rollingUpdate, blocked),
+			crp:     newCRPWithStrategy(external, blocked),
+			wantErr: "the rollout strategy type cannot be changed from RollingUpdate to External while a rollout is in progress, please retry after the rollout completes",
+		},
+		"RollingUpdate to External while the resources are being applied": {
+			oldCRP:  newCRPWithStrategy("", applying),
+			crp:     newCRPWithStrategy(external, applying),
+			wantErr: "the rollout strategy type cannot be changed from RollingUpdate to External while a rollout is in progress, please retry after the rollout completes",
+		},
+		"External to RollingUpdate without update runs": {
+			oldCRP:     newCRPWithStrategy(external, externallyControlled),
+			crp:        newCRPWithStrategy(rollingUpdate, externallyControlled),
+			updateRuns: []client.Object{newClusterStagedUpdateRun("other-run", "other-crp")},
+		},
+		"External to RollingUpdate while update runs reference the placement": {
+			oldCRP: newCRPWithStrategy(external, completed),
+			crp:    newCRPWithStrategy(rollingUpdate, completed),
+			updateRuns: []client.Object{
+				newClusterStagedUpdateRun("run-2", "test-crp"),
+				newClusterStagedUpdateRun("run-1", "test-crp"),
+				newClusterStagedUpdateRun("other-run", "other-crp"),
+			},
+			wantErr: "the rollout strategy type cannot be changed from External to RollingUpdate while staged update run(s) run-1, run-2 reference the placement, please delete them first",
+		},
+		"External to RollingUpdate with update runs but without the lookup": {
+			oldCRP:     newCRPWithStrategy(external, completed),
+			crp:        newCRPWithStrategy(rollingUpdate, completed),
+			updateRuns: []client.Object{newClusterStagedUpdateRun("run-1", "test-crp")},
+			noReader:   true,
+		},
+		"External to RollingUpdate when the update runs cannot be listed": {
+			oldCRP:  newCRPWithStrategy(external, completed),
+			crp:     newCRPWithStrategy(rollingUpdate, completed),
+			listErr: errors.New("list failed"),
+			wantErr: "failed to list the staged update runs of the placement, please retry the request: list failed",
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() = %v, want no error", err)
+	}
+	originalReader := UpdateRunReader
+	t.Cleanup(func() { UpdateRunReader = originalReader })
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			builder := newIndexedClientBuilder(scheme).WithObjects(tc.updateRuns...)
+			if tc.listErr != nil {
+				builder = builder.WithInterceptorFuncs(interceptor.Funcs{
+					List: func(context.Context, client.WithWatch, client.ObjectList, ...client.ListOption) error {
+						return tc.listErr
+					},
+				})
+			}
+			UpdateRunReader = builder.Build()
+			if tc.noReader {
+				UpdateRunReader = nil
+			}
+			var oldPlacement placementv1beta1.PlacementObj
+			if !tc.noOldObject {
+				oldPlacement = tc.oldCRP
+			}
+			err := validateStrategyTypeTransition(context.Background(), Config{}, admission.Request{}, tc.crp, oldPlacement)
+			gotErr := ""
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErr); diff != "" {
+				t.Errorf("validateStrategyTypeTransition() error mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateStrategyTypeTransitionResourcePlacement(t *testing.T) {
+	newRP := func(strategyType placementv1beta1.RolloutStrategyType, conditions ...metav1.Condition) *placementv1beta1.ResourcePlacement {
+		return &placementv1beta1.ResourcePlacement{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-rp", Namespace: "test-ns"},
+			Spec: placementv1beta1.PlacementSpec{
+				Strategy: placementv1beta1.RolloutStrategy{Type: strategyType},
+			},
+			Status: placementv1beta1.PlacementStatus{Conditions: conditions},
+		}
+	}
+	newStagedUpdateRun := func(name, namespace string) *placementv1beta1.StagedUpdateRun {
+		return &placementv1beta1.StagedUpdateRun{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       placementv1beta1.UpdateRunSpec{PlacementName: "test-rp"},
+		}
+	}
+	applying := metav1.Condition{
+		Type:   string(placementv1beta1.ResourcePlacementAppliedConditionType),
+		Status: metav1.ConditionUnknown,
+	}
+
+	testCases := map[string]struct {
+		oldRP      *placementv1beta1.ResourcePlacement
+		rp         *placementv1beta1.ResourcePlacement
+		updateRuns []client.Object
+		wantErr    string
+	}{
+		"RollingUpdate to External while the resources are being applied": {
+			oldRP:   newRP(placementv1beta1.RollingUpdateRolloutStrategyType, applying),
+			rp:      newRP(placementv1beta1.ExternalRolloutStrategyType, applying),
+			wantErr: "the rollout strategy type cannot be changed from RollingUpdate to External while a rollout is in progress, please retry after the rollout completes",
+		},
+		"External to RollingUpdate with an update run in the same namespace": {
+			oldRP:      newRP(placementv1beta1.ExternalRolloutStrategyType),
+			rp:         newRP(placementv1beta1.RollingUpdateRolloutStrategyType),
+			updateRuns: []client.Object{newStagedUpdateRun("run-1", "test-ns")},
+			wantErr:    "the rollout strategy type cannot be changed from External to RollingUpdate while staged update run(s) run-1 reference the placement, please delete them first",
+		},
+		"External to RollingUpdate with an update run in another namespace": {
+			oldRP:      newRP(placementv1beta1.ExternalRolloutStrategyType),
+			rp:         newRP(placementv1beta1.RollingUpdateRolloutStrategyType),
+			updateRuns: []client.Object{newStagedUpdateRun("run-1", "other-ns")},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() = %v, want no error", err)
+	}
+	originalReader := UpdateRunReader
+	t.Cleanup(func() { UpdateRunReader = originalReader })
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			UpdateRunReader = newIndexedClientBuilder(scheme).WithObjects(tc.updateRuns...).Build()
+			err := validateStrategyTypeTransition(context.Background(), Config{}, admission.Request{}, tc.rp, tc.oldRP)
+			gotErr := ""
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErr); diff != "" {
+				t.Errorf("validateStrategyTypeTransition() error mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// SupportedTaintEffects are the taint effects the fleet scheduler supports. Both the MemberCluster taints and the
+// placement tolerations are validated against this list so that the two cannot diverge.
+var SupportedTaintEffects = []corev1.TaintEffect{corev1.TaintEffectNoSchedule}
+
+// isSupportedTaintEffect returns true if the effect is one of SupportedTaintEffects.
+func isSupportedTaintEffect(effect corev1.TaintEffect) bool {
+	return slices.Contains(SupportedTaintEffects, effect)
+}
+
+// supportedTaintEffectsString returns the supported taint effects as a comma-separated list for error messages.
+func supportedTaintEffectsString() string {
+	effects := make([]string, len(SupportedTaintEffects))
+	for i, effect := range SupportedTaintEffects {
+		effects[i] = string(effect)
+	}
+	return strings.Join(effects, ", ")
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"fmt"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	hubmetrics "github.com/kubefleet-dev/kubefleet/pkg/metrics/hub"
+)
+
+// UnknownKindWarningFmt is the warning of the allowed request whose kind is not accepted by the webhook.
+const UnknownKindWarningFmt = "the %s webhook does not accept the kind %s, the request is allowed without validation"
+
+// IsAcceptedKind returns true if the kind of the request is one of the accepted kinds, or accepted is empty.
+func IsAcceptedKind(req admission.Request, accepted []metav1.GroupVersionKind) bool {
+	return len(accepted) == 0 || slices.Contains(accepted, req.Kind)
+}
+
+// HandleUnknownKind handles the request routed to the webhook whose kind is not one of the accepted kinds, e.g., a
+// version served after a CRD upgrade which the scheme of the webhook does not know. Such requests are counted, and
+// allowed with a warning if AllowUnknownKinds is set, in which case true is returned and the response must be
+// returned as is. Otherwise, the request is validated strictly as usual, i.e., it fails if it cannot be decoded.
+// Every kind is accepted if accepted is empty.
+func (c Config) HandleUnknownKind(req admission.Request, webhookName string, accepted []metav1.GroupVersionKind) (admission.Response, bool) {
+	if IsAcceptedKind(req, accepted) {
+		return admission.Response{}, false
+	}
+	hubmetrics.FleetWebhookUnknownKindTotal.WithLabelValues(webhookName, req.Kind.String()).Inc()
+	namespacedName := types.NamespacedName{Name: req.Name, Namespace: req.Namespace}
+	if !c.AllowUnknownKinds {
+		klog.V(2).InfoS("webhook received a request of an unknown kind, validating it strictly", "webhook", webhookName, "GVK", req.Kind, "operation", req.Operation, "namespacedName", namespacedName)
+		return admission.Response{}, false
+	}
+	klog.V(2).InfoS("webhook received a request of an unknown kind, allowing it without validation", "webhook", webhookName, "GVK", req.Kind, "operation", req.Operation, "namespacedName", namespacedName)
+	return admission.Allowed(fmt.Sprintf("%s of unknown kind %s", req.Operation, req.Kind.String())).
+		WithWarnings(fmt.Sprintf(UnknownKindWarningFmt, webhookName, req.Kind.String())), true
+}
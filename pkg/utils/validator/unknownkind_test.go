@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	hubmetrics "github.com/kubefleet-dev/kubefleet/pkg/metrics/hub"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils"
+)
+
+func TestHandleUnknownKind(t *testing.T) {
+	unknownKind := metav1.GroupVersionKind{Group: utils.ClusterResourcePlacementMetaGVK.Group, Version: "v2", Kind: utils.ClusterResourcePlacementMetaGVK.Kind}
+	accepted := []metav1.GroupVersionKind{utils.ClusterResourcePlacementMetaGVK}
+
+	testCases := map[string]struct {
+		kind         metav1.GroupVersionKind
+		accepted     []metav1.GroupVersionKind
+		allowUnknown bool
+		wantHandled  bool
+		wantWarnings []string
+		wantCount    float64
+	}{
+		"accepted kind is not handled": {
+			kind:         utils.ClusterResourcePlacementMetaGVK,
+			accepted:     accepted,
+			allowUnknown: true,
+		},
+		"every kind is accepted without accepted kinds": {
+			kind:         unknownKind,
+			allowUnknown: true,
+		},
+		"unknown kind is validated strictly by default": {
+			kind:      unknownKind,
+			accepted:  accepted,
+			wantCount: 1,
+		},
+		"unknown kind is allowed with a warning if allowed": {
+			kind:         unknownKind,
+			accepted:     accepted,
+			allowUnknown: true,
+			wantHandled:  true,
+			wantWarnings: []string{fmt.Sprintf(UnknownKindWarningFmt, "CRP", unknownKind.String())},
+			wantCount:    1,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := Config{AllowUnknownKinds: tc.allowUnknown}
+			req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Name: "test-crp", Operation: admissionv1.Create, Kind: tc.kind}}
+			unknownKinds := hubmetrics.FleetWebhookUnknownKindTotal.WithLabelValues("CRP", tc.kind.String())
+			before := testutil.ToFloat64(unknownKinds)
+
+			resp, handled := config.HandleUnknownKind(req, "CRP", tc.accepted)
+			if handled != tc.wantHandled {
+				t.Fatalf("HandleUnknownKind() handled = %t, want %t", handled, tc.wantHandled)
+			}
+			if handled && !resp.Allowed {
+				t.Errorf("HandleUnknownKind() allowed = false, want true: %v", resp.Result)
+			}
+			if diff := cmp.Diff(tc.wantWarnings, resp.Warnings); diff != "" {
+				t.Errorf("HandleUnknownKind() warnings mismatch (-want, +got):\n%s", diff)
+			}
+			if got := testutil.ToFloat64(unknownKinds) - before; got != tc.wantCount {
+				t.Errorf("HandleUnknownKind() counted %v requests of unknown kinds, want %v", got, tc.wantCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+// validateNoSpecUpdateDuringUpdateRun denies updating the spec of a placement while any staged update run which
+// references it has not finished, as the run would keep applying the snapshot taken before the update. Updates
+// to the metadata only are allowed. The check is skipped unless Config.DenySpecUpdatesDuringUpdateRuns is set
+// and the staged update run APIs are enabled.
+func validateNoSpecUpdateDuringUpdateRun(ctx context.Context, config Config, _ admission.Request, placement, oldPlacement placementv1beta1.PlacementObj) error {
+	if oldPlacement == nil || !config.DenySpecUpdatesDuringUpdateRuns || UpdateRunReader == nil {
+		return nil
+	}
+	unchanged, changedPaths := PlacementSpecSemanticEqual(oldPlacement, placement)
+	if unchanged {
+		return nil
+	}
+	updateRuns, err := listUpdateRunNames(ctx, config, oldPlacement, func(updateRun placementv1beta1.UpdateRunObj) bool {
+		return !isUpdateRunFinished(updateRun)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list the staged update runs of the placement, please retry the request: %w", err)
+	}
+	if len(updateRuns) > 0 {
+		klog.V(2).InfoS("Denying the placement spec update during staged update runs", "placement", klog.KObj(oldPlacement), "changedPaths", changedPaths, "updateRuns", updateRuns)
+		return fmt.Errorf("the placement spec cannot be updated while staged update run(s) %s referencing the placement are in progress, as they would roll out a stale snapshot; please retry after they finish or delete them", strings.Join(updateRuns, ", "))
+	}
+	return nil
+}
+
+// isUpdateRunFinished returns true if the staged update run has succeeded or failed, including failing to
+// initialize.
+func isUpdateRunFinished(updateRun placementv1beta1.UpdateRunObj) bool {
+	conditions := updateRun.GetUpdateRunStatus().Conditions
+	initCond := meta.FindStatusCondition(conditions, string(placementv1beta1.StagedUpdateRunConditionInitialized))
+	if initCond != nil && initCond.Status == metav1.ConditionFalse {
+		return true
+	}
+	succeededCond := meta.FindStatusCondition(conditions, string(placementv1beta1.StagedUpdateRunConditionSucceeded))
+	return succeededCond != nil && succeededCond.Status != metav1.ConditionUnknown
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+// updateRunConditions returns the staged update run conditions with the argued statuses, in the order of
+// Initialized, Progressing and Succeeded.
+func updateRunConditions(statuses ...metav1.ConditionStatus) []metav1.Condition {
+	types := []placementv1beta1.StagedUpdateRunConditionType{
+		placementv1beta1.StagedUpdateRunConditionInitialized,
+		placementv1beta1.StagedUpdateRunConditionProgressing,
+		placementv1beta1.StagedUpdateRunConditionSucceeded,
+	}
+	conditions := make([]metav1.Condition, 0, len(statuses))
+	for i, status := range statuses {
+		conditions = append(conditions, metav1.Condition{Type: string(types[i]), Status: status, Reason: "Test"})
+	}
+	return conditions
+}
+
+func TestValidateNoSpecUpdateDuringUpdateRun(t *testing.T) {
+	ok, f, u := metav1.ConditionTrue, metav1.ConditionFalse, metav1.ConditionUnknown
+	newUpdateRun := func(name string, state placementv1beta1.State, conditions []metav1.Condition) client.Object {
+		updateRun := newClusterStagedUpdateRun(name, "test-crp")
+		updateRun.Spec.State = state
+		updateRun.Status.Conditions = conditions
+		return updateRun
+	}
+	newCRP := func(labels map[string]string, numberOfClusters int32) *placementv1beta1.ClusterResourcePlacement {
+		return &placementv1beta1.ClusterResourcePlacement{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-crp", Labels: labels},
+			Spec: placementv1beta1.PlacementSpec{
+				Policy: &placementv1beta1.PlacementPolicy{
+					PlacementType:    placementv1beta1.PickNPlacementType,
+					NumberOfClusters: &numberOfClusters,
+				},
+				Strategy: placementv1beta1.RolloutStrategy{Type: placementv1beta1.ExternalRolloutStrategyType},
+			},
+		}
+	}
+	oldCRP := newCRP(nil, 2)
+	specUpdate := newCRP(nil, 3)
+	metadataUpdate := newCRP(map[string]string{"app": "test"}, 2)
+
+	testCases := map[string]struct {
+		disabled    bool
+		crp         *placementv1beta1.ClusterResourcePlacement
+		noOldObject bool
+		updateRuns  []client.Object
+		wantErr     string
+	}{
+		"create": {
+			crp:         specUpdate,
+			noOldObject: true,
+			updateRuns:  []client.Object{newUpdateRun("run-1", placementv1beta1.StateRun, updateRunConditions(ok, ok))},
+		},
+		"spec update while an update run is executing but the check is disabled": {
+			disabled:   true,
+			crp:        specUpdate,
+			updateRuns: []client.Object{newUpdateRun("run-1", placementv1beta1.StateRun, updateRunConditions(ok, ok))},
+		},
+		"metadata only update while an update run is executing": {
+			crp:        metadataUpdate,
+			updateRuns: []client.Object{newUpdateRun("run-1", placementv1beta1.StateRun, updateRunConditions(ok, ok))},
+		},
+		"spec update while an update run is executing": {
+			crp:        specUpdate,
+			updateRuns: []client.Object{newUpdateRun("run-1", placementv1beta1.StateRun, updateRunConditions(ok, ok))},
+			wantErr:    "the placement spec cannot be updated while staged update run(s) run-1 referencing the placement are in progress, as they would roll out a stale snapshot; please retry after they finish or delete them",
+		},
+		"spec update while update runs are initialized, stopped or transitioning": {
+			crp: specUpdate,
+			updateRuns: []client.Object{
+				newUpdateRun("run-initializing", placementv1beta1.StateInitialize, nil),
+				newUpdateRun("run-initialized", placementv1beta1.StateInitialize, updateRunConditions(ok)),
+				newUpdateRun("run-stopped", placementv1beta1.StateStop, updateRunConditions(ok, f)),
+				newUpdateRun("run-transitioning", placementv1beta1.StateRun, updateRunConditions(ok, u, u)),
+			},
+			wantErr: "the placement spec cannot be updated while staged update run(s) run-initialized, run-initializing, run-stopped, run-transitioning referencing the placement are in progress, as they would roll out a stale snapshot; please retry after they finish or delete them",
+		},
+		"spec update with succeeded and failed update runs": {
+			crp: specUpdate,
+			updateRuns: []client.Object{
+				newUpdateRun("run-succeeded", placementv1beta1.StateRun, updateRunConditions(ok, f, ok)),
+				newUpdateRun("run-failed", placementv1beta1.StateRun, updateRunConditions(ok, f, f)),
+				newUpdateRun("run-initialization-failed", placementv1beta1.StateRun, updateRunConditions(f)),
+			},
+		},
+		"spec update with an executing update run of another placement": {
+			crp: specUpdate,
+			updateRuns: []client.Object{func() client.Object {
+				updateRun := newClusterStagedUpdateRun("other-run", "other-crp")
+				updateRun.Status.Conditions = updateRunConditions(ok, ok)
+				return updateRun
+			}()},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() = %v, want no error", err)
+	}
+	originalReader := UpdateRunReader
+	t.Cleanup(func() {
+		UpdateRunReader = originalReader
+	})
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := Config{DenySpecUpdatesDuringUpdateRuns: !tc.disabled}
+			UpdateRunReader = newIndexedClientBuilder(scheme).WithObjects(tc.updateRuns...).Build()
+			var oldPlacement placementv1beta1.PlacementObj
+			if !tc.noOldObject {
+				oldPlacement = oldCRP
+			}
+			err := validateNoSpecUpdateDuringUpdateRun(context.Background(), config, admission.Request{}, tc.crp, oldPlacement)
+			gotErr := ""
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErr); diff != "" {
+				t.Errorf("validateNoSpecUpdateDuringUpdateRun() error mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
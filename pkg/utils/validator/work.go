@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/util/errors"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+// ValidateWork validates the manifests embedded in the Work created or updated by the user against the configured
+// limits, as Works with too many or too large manifests destabilize the member agent and etcd. objectSize is the
+// size of the serialized Work in the request. Each manifest must also be a JSON or YAML object with its apiVersion
+// and kind set.
+func (c Config) ValidateWork(work *placementv1beta1.Work, objectSize int, userInfo authenticationv1.UserInfo) error {
+	limits := c.workLimits(userInfo)
+	allErr := make([]error, 0)
+	if objectSize > limits.MaxSizeBytes {
+		allErr = append(allErr, fmt.Errorf("the size of the work is %d bytes, which exceeds the limit of %d bytes", objectSize, limits.MaxSizeBytes))
+	}
+	manifests := work.Spec.Workload.Manifests
+	if len(manifests) > limits.MaxManifests {
+		allErr = append(allErr, fmt.Errorf("the work has %d manifests, which exceeds the limit of %d manifests", len(manifests), limits.MaxManifests))
+	}
+	for i := range manifests {
+		raw := manifests[i].Raw
+		if len(raw) > limits.MaxManifestSizeBytes {
+			allErr = append(allErr, fmt.Errorf("the size of manifest %d is %d bytes, which exceeds the limit of %d bytes", i, len(raw), limits.MaxManifestSizeBytes))
+			continue
+		}
+		if err := validateManifest(raw); err != nil {
+			allErr = append(allErr, fmt.Errorf("manifest %d is invalid: %w", i, err))
+		}
+	}
+	return errors.NewAggregate(allErr)
+}
+
+// validateManifest returns an error if the manifest is not a JSON or YAML object with its apiVersion and kind set.
+func validateManifest(raw []byte) error {
+	if len(raw) == 0 {
+		return fmt.Errorf("the manifest is empty")
+	}
+	data, err := utilyaml.ToJSON(raw)
+	if err != nil {
+		return fmt.Errorf("the manifest cannot be parsed as JSON or YAML: %w", err)
+	}
+	var obj map[string]any
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return fmt.Errorf("the manifest is not an object: %w", err)
+	}
+	for _, field := range []string{"apiVersion", "kind"} {
+		if v, _ := obj[field].(string); v == "" {
+			return fmt.Errorf("the %s of the manifest is not set", field)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+// newWork returns a Work embedding the raw manifests.
+func newWork(manifests ...string) *placementv1beta1.Work {
+	work := &placementv1beta1.Work{}
+	for _, manifest := range manifests {
+		work.Spec.Workload.Manifests = append(work.Spec.Workload.Manifests, placementv1beta1.Manifest{RawExtension: runtime.RawExtension{Raw: []byte(manifest)}})
+	}
+	return work
+}
+
+func TestValidateWork(t *testing.T) {
+	configMap := `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"app","namespace":"app"}}`
+	hubAgent := authenticationv1.UserInfo{Username: "system:serviceaccount:fleet-system:hub-agent"}
+	config := Config{
+		WorkLimits:         WorkLimits{MaxManifests: 2, MaxManifestSizeBytes: 128, MaxSizeBytes: 1024},
+		HubAgentWorkLimits: WorkLimits{MaxManifests: 3, MaxSizeBytes: 2048},
+		HubAgentIdentities: []string{hubAgent.Username},
+	}
+
+	testCases := map[string]struct {
+		config     Config
+		work       *placementv1beta1.Work
+		objectSize int
+		userInfo   authenticationv1.UserInfo
+		wantErr    string
+	}{
+		"valid JSON manifests": {
+			config:     config,
+			work:       newWork(configMap, configMap),
+			objectSize: 512,
+		},
+		"valid YAML manifest": {
+			config:     config,
+			work:       newWork("apiVersion: v1\nkind: Namespace\nmetadata:\n  name: app\n"),
+			objectSize: 512,
+		},
+		"no manifest": {
+			config: config,
+			work:   newWork(),
+		},
+		"too many manifests": {
+			config:     config,
+			work:       newWork(configMap, configMap, configMap),
+			objectSize: 512,
+			wantErr:    "the work has 3 manifests, which exceeds the limit of 2 manifests",
+		},
+		"too many manifests are allowed for the hub agent": {
+			config:     config,
+			work:       newWork(configMap, configMap, configMap),
+			objectSize: 512,
+			userInfo:   hubAgent,
+		},
+		"hub agent limits apply to the hub agent": {
+			config:     config,
+			work:       newWork(configMap, configMap, configMap, configMap),
+			objectSize: 4096,
+			userInfo:   hubAgent,
+			wantErr:    "[the size of the work is 4096 bytes, which exceeds the limit of 2048 bytes, the work has 4 manifests, which exceeds the limit of 3 manifests]",
+		},
+		"hub agent falls back to the work limits": {
+			config:     config,
+			work:       newWork(`{"apiVersion":"v1","kind":"ConfigMap","data":{"key":"` + strings.Repeat("v", 128) + `"}}`),
+			objectSize: 512,
+			userInfo:   hubAgent,
+			wantErr:    "the size of manifest 0 is 184 bytes, which exceeds the limit of 128 bytes",
+		},
+		"oversized manifest": {
+			config:     config,
+			work:       newWork(configMap, `{"apiVersion":"v1","kind":"ConfigMap","data":{"key":"`+strings.Repeat("v", 128)+`"}}`),
+			objectSize: 512,
+			wantErr:    "the size of manifest 1 is 184 bytes, which exceeds the limit of 128 bytes",
+		},
+		"oversized work": {
+			config:     config,
+			work:       newWork(configMap),
+			objectSize: 1025,
+			wantErr:    "the size of the work is 1025 bytes, which exceeds the limit of 1024 bytes",
+		},
+		"default limits": {
+			work:       newWork(configMap),
+			objectSize: DefaultMaxWorkSizeBytes + 1,
+			wantErr:    "the size of the work is 1572865 bytes, which exceeds the limit of 1572864 bytes",
+		},
+		"empty manifest": {
+			config:  config,
+			work:    newWork(""),
+			wantErr: "manifest 0 is invalid: the manifest is empty",
+		},
+		"unparsable manifest": {
+			config:  config,
+			work:    newWork("kind: [ConfigMap"),
+			wantErr: "manifest 0 is invalid: the manifest cannot be parsed as JSON or YAML",
+		},
+		"manifest is not an object": {
+			config:  config,
+			work:    newWork(`["apiVersion", "kind"]`),
+			wantErr: "manifest 0 is invalid: the manifest is not an object",
+		},
+		"manifest without an apiVersion": {
+			config:  config,
+			work:    newWork(`{"kind":"ConfigMap"}`),
+			wantErr: "manifest 0 is invalid: the apiVersion of the manifest is not set",
+		},
+		"manifest without a kind": {
+			config:  config,
+			work:    newWork(configMap, "apiVersion: v1\nmetadata:\n  name: app\n"),
+			wantErr: "manifest 1 is invalid: the kind of the manifest is not set",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := tc.config
+			err := config.ValidateWork(tc.work, tc.objectSize, tc.userInfo)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateWork() = %v, want no error", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("ValidateWork() = %v, want error containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestWorkLimits(t *testing.T) {
+	hubAgent := authenticationv1.UserInfo{Username: "system:serviceaccount:fleet-system:hub-agent"}
+	defaults := WorkLimits{MaxManifests: DefaultMaxWorkManifests, MaxManifestSizeBytes: DefaultMaxWorkManifestSizeBytes, MaxSizeBytes: DefaultMaxWorkSizeBytes}
+	testCases := map[string]struct {
+		config   Config
+		userInfo authenticationv1.UserInfo
+		want     WorkLimits
+	}{
+		"defaults": {
+			want: defaults,
+		},
+		"configured limits": {
+			config: Config{WorkLimits: WorkLimits{MaxManifests: 10, MaxManifestSizeBytes: -1}},
+			want:   WorkLimits{MaxManifests: 10, MaxManifestSizeBytes: DefaultMaxWorkManifestSizeBytes, MaxSizeBytes: DefaultMaxWorkSizeBytes},
+		},
+		"hub agent limits": {
+			config: Config{
+				WorkLimits:         WorkLimits{MaxManifests: 10},
+				HubAgentWorkLimits: WorkLimits{MaxSizeBytes: 4 * 1024 * 1024},
+				HubAgentIdentities: []string{hubAgent.Username},
+			},
+			userInfo: hubAgent,
+			want:     WorkLimits{MaxManifests: 10, MaxManifestSizeBytes: DefaultMaxWorkManifestSizeBytes, MaxSizeBytes: 4 * 1024 * 1024},
+		},
+		"hub agent limits do not apply to other users": {
+			config: Config{
+				HubAgentWorkLimits: WorkLimits{MaxManifests: 1000},
+				HubAgentIdentities: []string{hubAgent.Username},
+			},
+			userInfo: authenticationv1.UserInfo{Username: "alice"},
+			want:     defaults,
+		},
+		"empty username is not the hub agent": {
+			config: Config{
+				HubAgentWorkLimits: WorkLimits{MaxManifests: 1000},
+				HubAgentIdentities: []string{""},
+			},
+			want: defaults,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.config.workLimits(tc.userInfo)); diff != "" {
+				t.Errorf("workLimits() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
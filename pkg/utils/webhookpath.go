@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// The kinds of the webhooks a path can be registered for.
+const (
+	// ValidatingWebhookPathKind is the kind of the paths served by validating webhooks.
+	ValidatingWebhookPathKind = "validate"
+	// MutatingWebhookPathKind is the kind of the paths served by mutating webhooks.
+	MutatingWebhookPathKind = "mutate"
+)
+
+// webhookPathRegistry records the paths the webhook handlers are served at, so that two handlers never share a path.
+type webhookPathRegistry struct {
+	mu    sync.Mutex
+	paths map[string]bool
+}
+
+// webhookPaths is the registry of the paths of all the fleet webhooks.
+var webhookPaths = &webhookPathRegistry{paths: make(map[string]bool)}
+
+// register returns the canonical path of the webhook for the resource, or an error if the kind is unknown or the
+// path is already registered.
+func (r *webhookPathRegistry) register(group, version, resource, kind string) (string, error) {
+	path, err := buildWebhookPath(group, version, resource, kind)
+	if err != nil {
+		return "", err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.paths[path] {
+		return "", fmt.Errorf("webhook path %s is already registered", path)
+	}
+	r.paths[path] = true
+	return path, nil
+}
+
+// list returns the sorted registered paths.
+func (r *webhookPathRegistry) list() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	paths := make([]string, 0, len(r.paths))
+	for path := range r.paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// buildWebhookPath returns the canonical path of the webhook for the resource. The group and version are left out
+// if both are empty, e.g., for the webhooks which handle resources of any group.
+func buildWebhookPath(group, version, resource, kind string) (string, error) {
+	if resource == "" {
+		return "", errors.New("the resource of the webhook path must not be empty")
+	}
+	var format string
+	switch kind {
+	case ValidatingWebhookPathKind:
+		format = ValidationPathFmt
+	case MutatingWebhookPathKind:
+		format = MutatingPathFmt
+	default:
+		return "", fmt.Errorf("unknown webhook path kind %q, must be %q or %q", kind, ValidatingWebhookPathKind, MutatingWebhookPathKind)
+	}
+	if group == "" && version == "" {
+		return fmt.Sprintf("/%s-%s", kind, resource), nil
+	}
+	return fmt.Sprintf(format, group, version, resource), nil
+}
+
+// RegisterWebhookPath registers and returns the canonical path of the webhook of the kind, i.e., ValidatingWebhookPathKind
+// or MutatingWebhookPathKind, for the resource. The webhook packages register their paths in their package variables,
+// so the function panics on an unknown kind or a path which is already registered, which surfaces a collision at init
+// time rather than as a webhook served by the wrong handler.
+func RegisterWebhookPath(group, version, resource, kind string) string {
+	path, err := webhookPaths.register(group, version, resource, kind)
+	if err != nil {
+		panic(fmt.Sprintf("failed to register the webhook path: %v", err))
+	}
+	return path
+}
+
+// AllWebhookPaths returns the sorted paths of all the registered webhooks.
+func AllWebhookPaths() []string {
+	return webhookPaths.list()
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWebhookPathRegistry(t *testing.T) {
+	testCases := map[string]struct {
+		// registered are the group, version, resource and kind of the paths registered before.
+		registered      [][4]string
+		group           string
+		version         string
+		resource        string
+		kind            string
+		wantPath        string
+		wantErrContains string
+	}{
+		"validating webhook path": {
+			group:    "placement.kubernetes-fleet.io",
+			version:  "v1beta1",
+			resource: "clusterresourceplacement",
+			kind:     ValidatingWebhookPathKind,
+			wantPath: "/validate-placement.kubernetes-fleet.io-v1beta1-clusterresourceplacement",
+		},
+		"mutating webhook path": {
+			group:    "placement.kubernetes-fleet.io",
+			version:  "v1beta1",
+			resource: "clusterresourceplacement",
+			kind:     MutatingWebhookPathKind,
+			wantPath: "/mutate-placement.kubernetes-fleet.io-v1beta1-clusterresourceplacement",
+		},
+		"core group": {
+			version:  "v1",
+			resource: "pod",
+			kind:     ValidatingWebhookPathKind,
+			wantPath: "/validate--v1-pod",
+		},
+		"no group and version": {
+			resource: "fleetresourcehandler",
+			kind:     ValidatingWebhookPathKind,
+			wantPath: "/validate-fleetresourcehandler",
+		},
+		"validating and mutating webhooks of the same resource": {
+			registered: [][4]string{{"placement.kubernetes-fleet.io", "v1beta1", "clusterresourceplacement", MutatingWebhookPathKind}},
+			group:      "placement.kubernetes-fleet.io",
+			version:    "v1beta1",
+			resource:   "clusterresourceplacement",
+			kind:       ValidatingWebhookPathKind,
+			wantPath:   "/validate-placement.kubernetes-fleet.io-v1beta1-clusterresourceplacement",
+		},
+		"duplicate registration": {
+			registered:      [][4]string{{"placement.kubernetes-fleet.io", "v1beta1", "resourceplacement", ValidatingWebhookPathKind}},
+			group:           "placement.kubernetes-fleet.io",
+			version:         "v1beta1",
+			resource:        "resourceplacement",
+			kind:            ValidatingWebhookPathKind,
+			wantErrContains: "webhook path /validate-placement.kubernetes-fleet.io-v1beta1-resourceplacement is already registered",
+		},
+		"unknown kind": {
+			group:           "placement.kubernetes-fleet.io",
+			version:         "v1beta1",
+			resource:        "resourceplacement",
+			kind:            "convert",
+			wantErrContains: `unknown webhook path kind "convert"`,
+		},
+		"empty resource": {
+			group:           "placement.kubernetes-fleet.io",
+			version:         "v1beta1",
+			kind:            ValidatingWebhookPathKind,
+			wantErrContains: "the resource of the webhook path must not be empty",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			r := &webhookPathRegistry{paths: make(map[string]bool)}
+			for _, args := range tc.registered {
+				if _, err := r.register(args[0], args[1], args[2], args[3]); err != nil {
+					t.Fatalf("register(%v) = %v, want no error", args, err)
+				}
+			}
+			want := r.list()
+			got, err := r.register(tc.group, tc.version, tc.resource, tc.kind)
+			if tc.wantErrContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErrContains) {
+					t.Fatalf("register() = %v, want error containing %q", err, tc.wantErrContains)
+				}
+				if diff := cmp.Diff(want, r.list()); diff != "" {
+					t.Errorf("list() after a failed registration mismatch (-want, +got):\n%s", diff)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("register() = %v, want no error", err)
+			}
+			if got != tc.wantPath {
+				t.Errorf("register() = %s, want %s", got, tc.wantPath)
+			}
+			if !strings.HasPrefix(got, "/") {
+				t.Errorf("register() = %s, want an absolute path", got)
+			}
+		})
+	}
+}
+
+func TestRegisterWebhookPath(t *testing.T) {
+	path := RegisterWebhookPath("test.kubernetes-fleet.io", "v1", "registerwebhookpathtest", ValidatingWebhookPathKind)
+	if got := AllWebhookPaths(); !slices.Contains(got, path) {
+		t.Errorf("AllWebhookPaths() = %v, want it to contain %s", got, path)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("RegisterWebhookPath() with a duplicate path did not panic")
+		}
+	}()
+	RegisterWebhookPath("test.kubernetes-fleet.io", "v1", "registerwebhookpathtest", ValidatingWebhookPathKind)
+}
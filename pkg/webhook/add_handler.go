@@ -11,6 +11,7 @@ import (
 	"github.com/kubefleet-dev/kubefleet/pkg/webhook/replicaset"
 	"github.com/kubefleet-dev/kubefleet/pkg/webhook/resourceoverride"
 	"github.com/kubefleet-dev/kubefleet/pkg/webhook/resourceplacement"
+	"github.com/kubefleet-dev/kubefleet/pkg/webhook/work"
 )
 
 func init() {
@@ -18,13 +19,20 @@ func init() {
 	AddToManagerFleetResourceValidator = fleetresourcehandler.Add
 	AddToManagerMemberclusterValidator = membercluster.Add
 	// AddToManagerFuncs is a list of functions to register webhook validators and mutators to the webhook server
+	AddToManagerFuncs = append(AddToManagerFuncs, clusterresourceplacement.AddRollbackMutating)
 	AddToManagerFuncs = append(AddToManagerFuncs, clusterresourceplacement.AddMutating)
+	AddToManagerFuncs = append(AddToManagerFuncs, clusterresourceplacement.AddPhaseTimestampsMutating)
+	AddToManagerFuncs = append(AddToManagerFuncs, clusterresourceplacement.AddAnnotationNormalizingMutating)
+	AddToManagerFuncs = append(AddToManagerFuncs, clusterresourceplacement.AddSpecHistoryMutating)
 	AddToManagerFuncs = append(AddToManagerFuncs, clusterresourceplacement.Add)
+	AddToManagerFuncs = append(AddToManagerFuncs, clusterresourceplacement.AddArchiving)
 	AddToManagerFuncs = append(AddToManagerFuncs, resourceplacement.Add)
-	AddToManagerFuncs = append(AddToManagerFuncs, pod.Add)
-	AddToManagerFuncs = append(AddToManagerFuncs, replicaset.Add)
 	AddToManagerFuncs = append(AddToManagerFuncs, clusterresourceoverride.Add)
 	AddToManagerFuncs = append(AddToManagerFuncs, resourceoverride.Add)
 	AddToManagerFuncs = append(AddToManagerFuncs, clusterresourceplacementeviction.Add)
 	AddToManagerFuncs = append(AddToManagerFuncs, clusterresourceplacementdisruptionbudget.Add)
+	AddToManagerFuncs = append(AddToManagerFuncs, work.Add)
+	// AddToManagerWorkloadFuncs is a list of functions to register the workload webhook validators to the webhook server
+	AddToManagerWorkloadFuncs = append(AddToManagerWorkloadFuncs, pod.Add)
+	AddToManagerWorkloadFuncs = append(AddToManagerWorkloadFuncs, replicaset.Add)
 }
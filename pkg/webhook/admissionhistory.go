@@ -0,0 +1,217 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kubefleet-dev/kubefleet/pkg/webhook/clusterresourceplacement"
+)
+
+const (
+	// AdmissionHistoryPath is the path on the webhook server at which the denied admission requests are served.
+	AdmissionHistoryPath = "/debug/admission-history"
+
+	// DefaultAdmissionHistorySize is the default number of denied admission requests kept by the store.
+	DefaultAdmissionHistorySize = 100
+)
+
+// AdmissionHistoryEntry is a denied admission request kept for replaying it when debugging.
+type AdmissionHistoryEntry struct {
+	// Resource is the lowercase kind of the object, e.g., clusterresourceplacement.
+	Resource string `json:"resource"`
+	// Timestamp is the time the request was denied.
+	Timestamp time.Time `json:"timestamp"`
+	// Message is the reason the request is denied.
+	Message string `json:"message,omitempty"`
+	// Request is the admission request as JSON, which can be sent to the webhook again to replay it.
+	Request json.RawMessage `json:"request"`
+}
+
+// AdmissionRequestStore keeps the last denied admission requests in a ring buffer.
+type AdmissionRequestStore struct {
+	mu      sync.Mutex
+	entries []AdmissionHistoryEntry
+	// next is the index the next entry is written at.
+	next int
+	// full indicates the ring buffer has wrapped, i.e., every slot holds an entry.
+	full bool
+	now  func() time.Time
+}
+
+// NewAdmissionRequestStore returns a store which keeps the last size denied admission requests.
+// DefaultAdmissionHistorySize is used if the size is not positive.
+func NewAdmissionRequestStore(size int) *AdmissionRequestStore {
+	if size <= 0 {
+		size = DefaultAdmissionHistorySize
+	}
+	return &AdmissionRequestStore{entries: make([]AdmissionHistoryEntry, size), now: time.Now}
+}
+
+// Record stores the denied admission request, overwriting the oldest one if the store is full.
+func (s *AdmissionRequestStore) Record(req admission.Request, message string) {
+	raw, err := json.Marshal(req.AdmissionRequest)
+	if err != nil {
+		klog.ErrorS(err, "Failed to marshal the denied admission request", "operation", req.Operation, "kind", req.Kind.Kind, "name", req.Name)
+		return
+	}
+	entry := AdmissionHistoryEntry{
+		Resource:  strings.ToLower(req.Kind.Kind),
+		Timestamp: s.now().UTC(),
+		Message:   message,
+		Request:   raw,
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[s.next] = entry
+	s.next = (s.next + 1) % len(s.entries)
+	if s.next == 0 {
+		s.full = true
+	}
+}
+
+// List returns up to limit of the stored requests of the resource, newest first. All the resources match an empty
+// resource, and all the matching requests are returned if the limit is not positive.
+func (s *AdmissionRequestStore) List(resource string, limit int) []AdmissionHistoryEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	count := s.next
+	if s.full {
+		count = len(s.entries)
+	}
+	entries := []AdmissionHistoryEntry{}
+	for i := 1; i <= count; i++ {
+		entry := s.entries[(s.next-i+len(s.entries))%len(s.entries)]
+		if resource != "" && !strings.EqualFold(entry.Resource, resource) {
+			continue
+		}
+		entries = append(entries, entry)
+		if limit > 0 && len(entries) == limit {
+			break
+		}
+	}
+	return entries
+}
+
+// Handler returns a read-only handler which serves the stored requests as JSON to the clients presenting the
+// bearer token. The resource and limit query parameters filter the requests, see List.
+func (s *AdmissionRequestStore) Handler(token string) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			rw.Header().Set("Allow", http.MethodGet)
+			http.Error(rw, "only GET is allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		got, found := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+		if token == "" || !found || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			rw.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(rw, "a valid bearer token is required", http.StatusUnauthorized)
+			return
+		}
+		limit := 0
+		if value := req.URL.Query().Get("limit"); value != "" {
+			var err error
+			if limit, err = strconv.Atoi(value); err != nil || limit <= 0 {
+				http.Error(rw, "limit must be a positive integer", http.StatusBadRequest)
+				return
+			}
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(rw)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(s.List(req.URL.Query().Get("resource"), limit)); err != nil {
+			klog.ErrorS(err, "Failed to write the admission history")
+		}
+	})
+}
+
+// admissionHistoryHandler is the admission middleware which records the requests denied by the wrapped handler.
+type admissionHistoryHandler struct {
+	handler admission.Handler
+	store   *AdmissionRequestStore
+}
+
+// WithAdmissionHistory wraps the admission handler so that every request it denies is recorded in the store.
+func WithAdmissionHistory(handler admission.Handler, store *AdmissionRequestStore) admission.Handler {
+	return &admissionHistoryHandler{handler: handler, store: store}
+}
+
+// Handle handles the request with the wrapped handler and records the request if it is denied.
+func (h *admissionHistoryHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	resp := h.handler.Handle(ctx, req)
+	if !resp.Allowed {
+		message := ""
+		if resp.Result != nil {
+			message = resp.Result.Message
+		}
+		h.store.Record(req, message)
+	}
+	return resp
+}
+
+// recordedPaths are the webhook paths whose denied requests are recorded in the admission history.
+var recordedPaths = map[string]bool{
+	clusterresourceplacement.ValidationPath:          true,
+	clusterresourceplacement.ArchivingValidationPath: true,
+}
+
+// historyWebhookServer wraps the admission handlers registered at the recorded paths with the admission history
+// middleware.
+type historyWebhookServer struct {
+	webhook.Server
+	store *AdmissionRequestStore
+}
+
+// Register registers the handler, wrapping it with the admission history middleware if the path is recorded.
+func (s *historyWebhookServer) Register(path string, hook http.Handler) {
+	if wh, ok := hook.(*webhook.Admission); ok && recordedPaths[path] {
+		wh.Handler = WithAdmissionHistory(wh.Handler, s.store)
+	}
+	s.Server.Register(path, hook)
+}
+
+// historyManager is a manager whose webhook server records the denied requests to the recorded paths.
+type historyManager struct {
+	manager.Manager
+	server *historyWebhookServer
+}
+
+// GetWebhookServer returns the recording webhook server.
+func (m *historyManager) GetWebhookServer() webhook.Server {
+	return m.server
+}
+
+// withAdmissionHistory returns the manager which registers the webhooks with the admission history middleware, or
+// the manager itself if the store is nil.
+func withAdmissionHistory(m manager.Manager, store *AdmissionRequestStore) manager.Manager {
+	if store == nil {
+		return m
+	}
+	return &historyManager{Manager: m, server: &historyWebhookServer{Server: m.GetWebhookServer(), store: store}}
+}
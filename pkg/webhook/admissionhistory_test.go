@@ -0,0 +1,284 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kubefleet-dev/kubefleet/cmd/hubagent/options"
+	"github.com/kubefleet-dev/kubefleet/pkg/webhook/clusterresourceplacement"
+)
+
+const testAdmissionHistoryToken = "test-token"
+
+// newHistoryRequest returns an admission request for the object of the argued kind and name.
+func newHistoryRequest(kind, name string) admission.Request {
+	return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		UID:       types.UID("uid-" + name),
+		Kind:      metav1.GroupVersionKind{Group: "placement.kubernetes-fleet.io", Version: "v1beta1", Kind: kind},
+		Name:      name,
+		Operation: admissionv1.Create,
+	}}
+}
+
+// historyRequestNames returns the names of the requests of the entries.
+func historyRequestNames(t *testing.T, entries []AdmissionHistoryEntry) []string {
+	names := []string{}
+	for _, entry := range entries {
+		var req admissionv1.AdmissionRequest
+		if err := json.Unmarshal(entry.Request, &req); err != nil {
+			t.Fatalf("json.Unmarshal(request) = %v, want no error", err)
+		}
+		names = append(names, req.Name)
+	}
+	return names
+}
+
+func TestAdmissionRequestStore(t *testing.T) {
+	testCases := map[string]struct {
+		size      int
+		requests  []admission.Request
+		resource  string
+		limit     int
+		wantNames []string
+	}{
+		"empty store": {
+			size:      3,
+			wantNames: []string{},
+		},
+		"store which has not wrapped": {
+			size:      3,
+			requests:  []admission.Request{newHistoryRequest("ClusterResourcePlacement", "crp-1"), newHistoryRequest("ClusterResourcePlacement", "crp-2")},
+			wantNames: []string{"crp-2", "crp-1"},
+		},
+		"store which is exactly full": {
+			size: 3,
+			requests: []admission.Request{
+				newHistoryRequest("ClusterResourcePlacement", "crp-1"),
+				newHistoryRequest("ClusterResourcePlacement", "crp-2"),
+				newHistoryRequest("ClusterResourcePlacement", "crp-3"),
+			},
+			wantNames: []string{"crp-3", "crp-2", "crp-1"},
+		},
+		"store which has wrapped keeps the newest requests": {
+			size: 3,
+			requests: []admission.Request{
+				newHistoryRequest("ClusterResourcePlacement", "crp-1"),
+				newHistoryRequest("ClusterResourcePlacement", "crp-2"),
+				newHistoryRequest("ClusterResourcePlacement", "crp-3"),
+				newHistoryRequest("ClusterResourcePlacement", "crp-4"),
+				newHistoryRequest("ClusterResourcePlacement", "crp-5"),
+			},
+			wantNames: []string{"crp-5", "crp-4", "crp-3"},
+		},
+		"limit": {
+			size: 3,
+			requests: []admission.Request{
+				newHistoryRequest("ClusterResourcePlacement", "crp-1"),
+				newHistoryRequest("ClusterResourcePlacement", "crp-2"),
+				newHistoryRequest("ClusterResourcePlacement", "crp-3"),
+				newHistoryRequest("ClusterResourcePlacement", "crp-4"),
+			},
+			limit:     2,
+			wantNames: []string{"crp-4", "crp-3"},
+		},
+		"resource filter": {
+			size: 4,
+			requests: []admission.Request{
+				newHistoryRequest("ClusterResourcePlacement", "crp-1"),
+				newHistoryRequest("ResourcePlacement", "rp-1"),
+				newHistoryRequest("ClusterResourcePlacement", "crp-2"),
+				newHistoryRequest("ResourcePlacement", "rp-2"),
+			},
+			resource:  "clusterresourceplacement",
+			wantNames: []string{"crp-2", "crp-1"},
+		},
+		"default size": {
+			size: 0,
+			requests: func() []admission.Request {
+				var requests []admission.Request
+				for i := 0; i < DefaultAdmissionHistorySize+1; i++ {
+					requests = append(requests, newHistoryRequest("ClusterResourcePlacement", fmt.Sprintf("crp-%d", i)))
+				}
+				return requests
+			}(),
+			limit:     1,
+			wantNames: []string{fmt.Sprintf("crp-%d", DefaultAdmissionHistorySize)},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			store := NewAdmissionRequestStore(tc.size)
+			for _, req := range tc.requests {
+				store.Record(req, "denied")
+			}
+			if diff := cmp.Diff(tc.wantNames, historyRequestNames(t, store.List(tc.resource, tc.limit))); diff != "" {
+				t.Errorf("List() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAdmissionRequestStoreHandler(t *testing.T) {
+	store := NewAdmissionRequestStore(3)
+	store.now = func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+	store.Record(newHistoryRequest("ClusterResourcePlacement", "crp-1"), "placement type is immutable")
+	store.Record(newHistoryRequest("ResourcePlacement", "rp-1"), "denied")
+	store.Record(newHistoryRequest("ClusterResourcePlacement", "crp-2"), "denied")
+
+	testCases := map[string]struct {
+		method     string
+		target     string
+		token      string
+		wantStatus int
+		wantNames  []string
+	}{
+		"all the requests": {
+			target:     AdmissionHistoryPath,
+			token:      testAdmissionHistoryToken,
+			wantStatus: http.StatusOK,
+			wantNames:  []string{"crp-2", "rp-1", "crp-1"},
+		},
+		"requests of the resource with a limit": {
+			target:     AdmissionHistoryPath + "?resource=clusterresourceplacement&limit=1",
+			token:      testAdmissionHistoryToken,
+			wantStatus: http.StatusOK,
+			wantNames:  []string{"crp-2"},
+		},
+		"no token": {
+			target:     AdmissionHistoryPath,
+			wantStatus: http.StatusUnauthorized,
+		},
+		"wrong token": {
+			target:     AdmissionHistoryPath,
+			token:      "wrong-token",
+			wantStatus: http.StatusUnauthorized,
+		},
+		"invalid limit": {
+			target:     AdmissionHistoryPath + "?limit=-1",
+			token:      testAdmissionHistoryToken,
+			wantStatus: http.StatusBadRequest,
+		},
+		"non GET method": {
+			method:     http.MethodPost,
+			target:     AdmissionHistoryPath,
+			token:      testAdmissionHistoryToken,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			method := tc.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req := httptest.NewRequest(method, tc.target, nil)
+			if tc.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+			rec := httptest.NewRecorder()
+			store.Handler(testAdmissionHistoryToken).ServeHTTP(rec, req)
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("Handler() status = %d, want %d: %s", rec.Code, tc.wantStatus, rec.Body.String())
+			}
+			if tc.wantStatus != http.StatusOK {
+				return
+			}
+			var entries []AdmissionHistoryEntry
+			if err := json.Unmarshal(rec.Body.Bytes(), &entries); err != nil {
+				t.Fatalf("Handler() returned invalid JSON: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantNames, historyRequestNames(t, entries)); diff != "" {
+				t.Errorf("Handler() requests mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAdmissionRequestStoreHandlerWithoutToken(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, AdmissionHistoryPath, nil)
+	req.Header.Set("Authorization", "Bearer ")
+	rec := httptest.NewRecorder()
+	NewAdmissionRequestStore(1).Handler("").ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Handler() status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestWithAdmissionHistory(t *testing.T) {
+	testCases := map[string]struct {
+		resp      admission.Response
+		wantNames []string
+	}{
+		"denied request is recorded": {
+			resp:      admission.Denied("placement type is immutable"),
+			wantNames: []string{"crp-1"},
+		},
+		"allowed request is not recorded": {
+			resp:      admission.Allowed("ok"),
+			wantNames: []string{},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			store := NewAdmissionRequestStore(3)
+			handler := WithAdmissionHistory(admission.HandlerFunc(func(context.Context, admission.Request) admission.Response { return tc.resp }), store)
+			handler.Handle(context.Background(), newHistoryRequest("ClusterResourcePlacement", "crp-1"))
+			entries := store.List("", 0)
+			if diff := cmp.Diff(tc.wantNames, historyRequestNames(t, entries)); diff != "" {
+				t.Errorf("Handle() recorded requests mismatch (-want, +got):\n%s", diff)
+			}
+			if len(entries) > 0 && entries[0].Message != "placement type is immutable" {
+				t.Errorf("Handle() recorded message = %q, want %q", entries[0].Message, "placement type is immutable")
+			}
+		})
+	}
+}
+
+func TestAddToManagerRecordsCRPDenials(t *testing.T) {
+	mgr := &fakeWebhookManager{server: &pathRecordingServer{}}
+	w := &Config{role: options.WebhookRolePlacement}
+	if err := w.AddToManager(mgr, nil, NewAdmissionRequestStore(1)); err != nil {
+		t.Fatalf("AddToManager() = %v, want no error", err)
+	}
+	for path, hook := range mgr.server.handlers {
+		wh, ok := hook.(*webhook.Admission)
+		if !ok {
+			continue
+		}
+		_, recorded := wh.Handler.(*admissionHistoryHandler)
+		if want := recordedPaths[path]; recorded != want {
+			t.Errorf("AddToManager() handler at %s recorded = %t, want %t", path, recorded, want)
+		}
+	}
+	if _, ok := mgr.server.handlers[clusterresourceplacement.ValidationPath]; !ok {
+		t.Errorf("AddToManager() registered no handler at %s", clusterresourceplacement.ValidationPath)
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kubefleet-dev/kubefleet/pkg/webhook/clusterresourceplacement"
+)
+
+// AuditEntry is the structured record of an admission request written to the audit log.
+type AuditEntry struct {
+	// User is the name of the user who sent the request.
+	User string `json:"user"`
+	// Groups are the groups of the user who sent the request.
+	Groups []string `json:"groups,omitempty"`
+	// Operation is the operation of the request, e.g., CREATE or UPDATE.
+	Operation string `json:"operation"`
+	// Resource is the group, version and resource of the object, e.g., placement.kubernetes-fleet.io/v1beta1/clusterresourceplacements.
+	Resource string `json:"resource"`
+	// Namespace is the namespace of the object, which is empty for cluster scoped objects.
+	Namespace string `json:"namespace,omitempty"`
+	// Name is the name of the object.
+	Name string `json:"name"`
+	// RequestUID is the UID of the admission request.
+	RequestUID string `json:"requestUID"`
+	// DryRun indicates the request is not persisted.
+	DryRun bool `json:"dryRun,omitempty"`
+	// Timestamp is the time the request was handled.
+	Timestamp time.Time `json:"timestamp"`
+	// Allowed is the result of the request.
+	Allowed bool `json:"allowed"`
+	// Patched indicates the webhook mutated the object.
+	Patched bool `json:"patched,omitempty"`
+	// Message is the reason the request is denied or errored.
+	Message string `json:"message,omitempty"`
+}
+
+// CloudAuditLogger writes audit entries to a cloud audit log service.
+type CloudAuditLogger interface {
+	Log(ctx context.Context, entry AuditEntry) error
+}
+
+// JSONAuditLogger writes each audit entry as a single line of JSON, which the logging agents of the cloud providers
+// ingest as a structured log entry when written to the standard output.
+type JSONAuditLogger struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// NewJSONAuditLogger returns an audit logger which writes to out.
+func NewJSONAuditLogger(out io.Writer) *JSONAuditLogger {
+	return &JSONAuditLogger{out: out}
+}
+
+// Log writes the audit entry as a line of JSON.
+func (l *JSONAuditLogger) Log(_ context.Context, entry AuditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the audit entry: %w", err)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.out.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write the audit entry: %w", err)
+	}
+	return nil
+}
+
+// auditHandler is the admission middleware which records every request handled by the wrapped handler.
+type auditHandler struct {
+	handler admission.Handler
+	logger  CloudAuditLogger
+	now     func() time.Time
+}
+
+// WithAuditLogging wraps the admission handler so that every request it handles is written to the audit logger.
+// A failure to write the audit entry is logged but does not change the admission response.
+func WithAuditLogging(handler admission.Handler, logger CloudAuditLogger) admission.Handler {
+	return &auditHandler{handler: handler, logger: logger, now: time.Now}
+}
+
+// Handle handles the request with the wrapped handler and writes the audit entry of the response.
+func (h *auditHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	resp := h.handler.Handle(ctx, req)
+	entry := AuditEntry{
+		User:       req.UserInfo.Username,
+		Groups:     req.UserInfo.Groups,
+		Operation:  string(req.Operation),
+		Resource:   req.Resource.String(),
+		Namespace:  req.Namespace,
+		Name:       req.Name,
+		RequestUID: string(req.UID),
+		DryRun:     req.DryRun != nil && *req.DryRun,
+		Timestamp:  h.now().UTC(),
+		Allowed:    resp.Allowed,
+		Patched:    len(resp.Patches) > 0,
+	}
+	if resp.Result != nil {
+		entry.Message = resp.Result.Message
+	}
+	if err := h.logger.Log(ctx, entry); err != nil {
+		klog.ErrorS(err, "Failed to write the audit entry", "operation", req.Operation, "resource", entry.Resource, "name", req.Name, "user", entry.User)
+	}
+	return resp
+}
+
+// auditedPaths are the webhook paths whose requests are written to the audit log.
+var auditedPaths = map[string]bool{
+	clusterresourceplacement.MutatingPath: true,
+}
+
+// auditingWebhookServer wraps the admission handlers registered at the audited paths with the audit middleware.
+type auditingWebhookServer struct {
+	webhook.Server
+	logger CloudAuditLogger
+}
+
+// Register registers the handler, wrapping it with the audit middleware if the path is audited.
+func (s *auditingWebhookServer) Register(path string, hook http.Handler) {
+	if wh, ok := hook.(*webhook.Admission); ok && auditedPaths[path] {
+		wh.Handler = WithAuditLogging(wh.Handler, s.logger)
+	}
+	s.Server.Register(path, hook)
+}
+
+// auditingManager is a manager whose webhook server audits the requests to the audited paths.
+type auditingManager struct {
+	manager.Manager
+	server *auditingWebhookServer
+}
+
+// GetWebhookServer returns the auditing webhook server.
+func (m *auditingManager) GetWebhookServer() webhook.Server {
+	return m.server
+}
+
+// withAuditLogging returns the manager which registers the webhooks with the audit middleware, or the manager
+// itself if the logger is nil.
+func withAuditLogging(m manager.Manager, logger CloudAuditLogger) manager.Manager {
+	if logger == nil {
+		return m
+	}
+	return &auditingManager{Manager: m, server: &auditingWebhookServer{Server: m.GetWebhookServer(), logger: logger}}
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kubefleet-dev/kubefleet/cmd/hubagent/options"
+	"github.com/kubefleet-dev/kubefleet/pkg/webhook/clusterresourceplacement"
+)
+
+// mockAuditLogger captures the audit entries and returns the configured error.
+type mockAuditLogger struct {
+	entries []AuditEntry
+	err     error
+}
+
+func (l *mockAuditLogger) Log(_ context.Context, entry AuditEntry) error {
+	l.entries = append(l.entries, entry)
+	return l.err
+}
+
+func TestWithAuditLogging(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	req := admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			UID:       types.UID("test-uid"),
+			Name:      "test-crp",
+			Operation: admissionv1.Update,
+			Resource:  metav1.GroupVersionResource{Group: "placement.kubernetes-fleet.io", Version: "v1beta1", Resource: "clusterresourceplacements"},
+			UserInfo:  authenticationv1.UserInfo{Username: "test-user", Groups: []string{"system:authenticated"}},
+			DryRun:    ptr.To(false),
+		},
+	}
+	testCases := map[string]struct {
+		resp      admission.Response
+		loggerErr error
+		wantEntry AuditEntry
+	}{
+		"patched request": {
+			resp: admission.Patched("defaulted"),
+			wantEntry: AuditEntry{
+				User:       "test-user",
+				Groups:     []string{"system:authenticated"},
+				Operation:  "UPDATE",
+				Resource:   "placement.kubernetes-fleet.io/v1beta1, Resource=clusterresourceplacements",
+				Name:       "test-crp",
+				RequestUID: "test-uid",
+				Timestamp:  now,
+				Allowed:    true,
+				Message:    "defaulted",
+			},
+		},
+		"denied request": {
+			resp: admission.Denied("not allowed"),
+			wantEntry: AuditEntry{
+				User:       "test-user",
+				Groups:     []string{"system:authenticated"},
+				Operation:  "UPDATE",
+				Resource:   "placement.kubernetes-fleet.io/v1beta1, Resource=clusterresourceplacements",
+				Name:       "test-crp",
+				RequestUID: "test-uid",
+				Timestamp:  now,
+				Message:    "not allowed",
+			},
+		},
+		"failure to log does not change the response": {
+			resp:      admission.Allowed(""),
+			loggerErr: errors.New("audit log service is unavailable"),
+			wantEntry: AuditEntry{
+				User:       "test-user",
+				Groups:     []string{"system:authenticated"},
+				Operation:  "UPDATE",
+				Resource:   "placement.kubernetes-fleet.io/v1beta1, Resource=clusterresourceplacements",
+				Name:       "test-crp",
+				RequestUID: "test-uid",
+				Timestamp:  now,
+				Allowed:    true,
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			logger := &mockAuditLogger{err: tc.loggerErr}
+			inner := admission.HandlerFunc(func(context.Context, admission.Request) admission.Response { return tc.resp })
+			h := WithAuditLogging(inner, logger).(*auditHandler)
+			h.now = func() time.Time { return now }
+
+			gotResp := h.Handle(context.Background(), req)
+			if diff := cmp.Diff(tc.resp, gotResp); diff != "" {
+				t.Errorf("Handle() response mismatch (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff([]AuditEntry{tc.wantEntry}, logger.entries); diff != "" {
+				t.Errorf("Handle() audit entries mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestJSONAuditLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewJSONAuditLogger(&buf)
+	entry := AuditEntry{
+		User:       "test-user",
+		Operation:  "CREATE",
+		Resource:   "placement.kubernetes-fleet.io/v1beta1, Resource=clusterresourceplacements",
+		Name:       "test-crp",
+		RequestUID: "test-uid",
+		Timestamp:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Allowed:    true,
+		Patched:    true,
+	}
+	if err := logger.Log(context.Background(), entry); err != nil {
+		t.Fatalf("Log() = %v, want no error", err)
+	}
+	line, err := buf.ReadBytes('\n')
+	if err != nil {
+		t.Fatalf("Log() wrote %q, want a line", buf.String())
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(line, &got); err != nil {
+		t.Fatalf("Log() wrote invalid JSON %q: %v", line, err)
+	}
+	want := map[string]interface{}{
+		"user":       "test-user",
+		"operation":  "CREATE",
+		"resource":   "placement.kubernetes-fleet.io/v1beta1, Resource=clusterresourceplacements",
+		"name":       "test-crp",
+		"requestUID": "test-uid",
+		"timestamp":  "2025-01-01T00:00:00Z",
+		"allowed":    true,
+		"patched":    true,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Log() JSON mismatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestAddToManagerAuditsCRPMutations(t *testing.T) {
+	mgr := &fakeWebhookManager{server: &pathRecordingServer{}}
+	w := &Config{role: options.WebhookRolePlacement}
+	if err := w.AddToManager(mgr, &mockAuditLogger{}, nil); err != nil {
+		t.Fatalf("AddToManager() = %v, want no error", err)
+	}
+	for path, hook := range mgr.server.handlers {
+		wh, ok := hook.(*webhook.Admission)
+		if !ok {
+			continue
+		}
+		_, audited := wh.Handler.(*auditHandler)
+		if want := path == clusterresourceplacement.MutatingPath; audited != want {
+			t.Errorf("AddToManager() handler at %s audited = %t, want %t", path, audited, want)
+		}
+	}
+	if _, ok := mgr.server.handlers[clusterresourceplacement.MutatingPath]; !ok {
+		t.Errorf("AddToManager() registered no handler at %s", clusterresourceplacement.MutatingPath)
+	}
+}
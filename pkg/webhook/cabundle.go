@@ -0,0 +1,119 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// buildCABundle returns the caBundle published in the webhooks, which holds the CA generated for the webhook's server
+// certificate and the additional PEM encoded CA certificates, e.g., the root and intermediate CAs of an issuer chain,
+// so that the API server keeps trusting the webhooks when one of the CAs rotates. The additional bundle may hold
+// multiple certificates, each of which must be a CA certificate; the duplicates are dropped and the CAs are ordered
+// deterministically, see normalizeCABundle. An empty additional bundle only publishes the generated CA.
+func buildCABundle(selfSignedCAPEM, additional []byte) ([]byte, error) {
+	if len(bytes.TrimSpace(additional)) == 0 {
+		return selfSignedCAPEM, nil
+	}
+	if _, err := parseCABundle(additional); err != nil {
+		return nil, fmt.Errorf("invalid additional CA bundle: %w", err)
+	}
+	caPEM, err := normalizeCABundle(slices.Concat(selfSignedCAPEM, []byte("\n"), additional))
+	if err != nil {
+		return nil, fmt.Errorf("invalid CA bundle: %w", err)
+	}
+	return caPEM, nil
+}
+
+// normalizeCABundle parses the PEM encoded CA certificates of the bundle, drops the duplicates and re-encodes them,
+// the self-signed root CAs first and then the intermediate CAs, each ordered by subject and then by their DER bytes,
+// so that the same set of CAs is always published as the same caBundle whatever the order they are given in.
+func normalizeCABundle(bundle []byte) ([]byte, error) {
+	certs, err := parseCABundle(bundle)
+	if err != nil {
+		return nil, err
+	}
+	certs = slices.CompactFunc(slices.SortedFunc(slices.Values(certs), compareCACertificates), func(a, b *x509.Certificate) bool {
+		return a.Equal(b)
+	})
+	var buf bytes.Buffer
+	for _, cert := range certs {
+		if err := pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// parseCABundle returns the CA certificates of the PEM encoded bundle in the order they appear. It returns an error
+// naming the index of the offending PEM block if any block is not a CA certificate, or if the bundle holds no
+// certificate or anything besides the PEM blocks.
+func parseCABundle(bundle []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := bundle
+	for i := 0; ; i++ {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			if len(bytes.TrimSpace(rest)) > 0 {
+				return nil, fmt.Errorf("block %d is not PEM encoded", i)
+			}
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("block %d is a %s, want a CERTIFICATE", i, block.Type)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the certificate of block %d: %w", i, err)
+		}
+		if !cert.IsCA {
+			return nil, fmt.Errorf("the certificate of block %d, %s, is not a CA", i, cert.Subject)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("the bundle holds no certificate")
+	}
+	return certs, nil
+}
+
+// compareCACertificates orders the self-signed root CAs before the intermediate CAs, and then the CAs by subject
+// and by their DER bytes.
+func compareCACertificates(a, b *x509.Certificate) int {
+	if aRoot, bRoot := isRootCA(a), isRootCA(b); aRoot != bRoot {
+		if aRoot {
+			return -1
+		}
+		return 1
+	}
+	if c := strings.Compare(a.Subject.String(), b.Subject.String()); c != 0 {
+		return c
+	}
+	return bytes.Compare(a.Raw, b.Raw)
+}
+
+// isRootCA returns true if the CA certificate is self-signed.
+func isRootCA(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawSubject, cert.RawIssuer) && cert.CheckSignatureFrom(cert) == nil
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// testCA is a CA certificate along with its private key to sign other certificates.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+// newTestCA returns a CA certificate of the common name signed by parent, or a self-signed root CA if parent is nil.
+func newTestCA(t *testing.T, commonName string, parent *testCA) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() = %v, want no error", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	signer, signerKey := template, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("CreateCertificate() = %v, want no error", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("ParseCertificate() = %v, want no error", err)
+	}
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// concatPEMs joins the PEM blocks into a bundle.
+func concatPEMs(blocks ...[]byte) []byte {
+	return bytes.Join(blocks, nil)
+}
+
+func TestNormalizeCABundle(t *testing.T) {
+	root := newTestCA(t, "root", nil)
+	intermediate := newTestCA(t, "intermediate", root)
+	issuing := newTestCA(t, "issuing", intermediate)
+	corrupt := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("not a certificate")})
+	key := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: []byte("key")})
+
+	testCases := map[string]struct {
+		bundle        []byte
+		want          []byte
+		wantErrSubstr string
+	}{
+		"single CA": {
+			bundle: root.pem,
+			want:   root.pem,
+		},
+		"two-cert bundle is ordered root first": {
+			bundle: concatPEMs(intermediate.pem, root.pem),
+			want:   concatPEMs(root.pem, intermediate.pem),
+		},
+		"three-cert bundle is ordered root first and then by subject": {
+			bundle: concatPEMs(issuing.pem, root.pem, intermediate.pem),
+			want:   concatPEMs(root.pem, intermediate.pem, issuing.pem),
+		},
+		"bundle of any order is normalized to the same bundle": {
+			bundle: concatPEMs(intermediate.pem, issuing.pem, root.pem),
+			want:   concatPEMs(root.pem, intermediate.pem, issuing.pem),
+		},
+		"duplicate certificates are dropped": {
+			bundle: concatPEMs(intermediate.pem, root.pem, intermediate.pem, root.pem),
+			want:   concatPEMs(root.pem, intermediate.pem),
+		},
+		"bundle with surrounding whitespace": {
+			bundle: concatPEMs([]byte("\n"), root.pem, []byte("\n\n"), intermediate.pem, []byte("\n")),
+			want:   concatPEMs(root.pem, intermediate.pem),
+		},
+		"corrupt block": {
+			bundle:        concatPEMs(root.pem, corrupt, intermediate.pem),
+			wantErrSubstr: "failed to parse the certificate of block 1",
+		},
+		"block of other type": {
+			bundle:        concatPEMs(root.pem, intermediate.pem, key),
+			wantErrSubstr: "block 2 is a EC PRIVATE KEY, want a CERTIFICATE",
+		},
+		"trailing data which is not PEM encoded": {
+			bundle:        concatPEMs(root.pem, []byte("garbage")),
+			wantErrSubstr: "block 1 is not PEM encoded",
+		},
+		"empty bundle": {
+			bundle:        []byte("\n"),
+			wantErrSubstr: "the bundle holds no certificate",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := normalizeCABundle(tc.bundle)
+			if tc.wantErrSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErrSubstr) {
+					t.Fatalf("normalizeCABundle() = %v, want an error containing %q", err, tc.wantErrSubstr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("normalizeCABundle() = %v, want no error", err)
+			}
+			if diff := cmp.Diff(string(tc.want), string(got)); diff != "" {
+				t.Errorf("normalizeCABundle() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNormalizeCABundleDeniesLeafCertificates(t *testing.T) {
+	root := newTestCA(t, "root", nil)
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() = %v, want no error", err)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "leaf"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}, root.cert, &leafKey.PublicKey, root.key)
+	if err != nil {
+		t.Fatalf("CreateCertificate() = %v, want no error", err)
+	}
+	bundle := concatPEMs(root.pem, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	if _, err := normalizeCABundle(bundle); err == nil || !strings.Contains(err.Error(), "the certificate of block 1, CN=leaf, is not a CA") {
+		t.Errorf("normalizeCABundle() = %v, want the leaf certificate of block 1 denied", err)
+	}
+}
+
+func TestBuildCABundle(t *testing.T) {
+	generated := newTestCA(t, "generated", nil)
+	root := newTestCA(t, "root", nil)
+	intermediate := newTestCA(t, "intermediate", root)
+	corrupt := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("not a certificate")})
+
+	caPEM, err := buildCABundle(generated.pem, concatPEMs(intermediate.pem, root.pem))
+	if err != nil {
+		t.Fatalf("buildCABundle() = %v, want no error", err)
+	}
+	// The generated CA sorts before the other root by subject.
+	want := concatPEMs(generated.pem, root.pem, intermediate.pem)
+	if diff := cmp.Diff(string(want), string(caPEM)); diff != "" {
+		t.Errorf("buildCABundle() mismatch (-want, +got):\n%s", diff)
+	}
+	w := newHashTestConfig()
+	w.caPEM, w.selfSignedCAPEM = caPEM, generated.pem
+	for _, wh := range w.buildFleetValidatingWebhooks() {
+		if !bytes.Equal(wh.ClientConfig.CABundle, want) {
+			t.Errorf("webhook %s caBundle = %q, want the full bundle", wh.Name, wh.ClientConfig.CABundle)
+		}
+	}
+
+	// The block index of the error is the one in the argued bundle, not counting the generated CA.
+	if _, err := buildCABundle(generated.pem, concatPEMs(root.pem, corrupt)); err == nil || !strings.Contains(err.Error(), "block 1") {
+		t.Errorf("buildCABundle() = %v, want an error naming block 1", err)
+	}
+
+	caPEM, err = buildCABundle(generated.pem, nil)
+	if err != nil {
+		t.Fatalf("buildCABundle(nil) = %v, want no error", err)
+	}
+	if !bytes.Equal(caPEM, generated.pem) {
+		t.Errorf("buildCABundle(nil) = %q, want only the generated CA", caPEM)
+	}
+}
@@ -32,11 +32,12 @@ import (
 	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
 	"github.com/kubefleet-dev/kubefleet/pkg/utils"
 	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
+	"github.com/kubefleet-dev/kubefleet/pkg/webhook/validation"
 )
 
 var (
 	// ValidationPath is the webhook service path which admission requests are routed to for validating ClusterResourceOverride resources.
-	ValidationPath = fmt.Sprintf(utils.ValidationPathFmt, placementv1beta1.GroupVersion.Group, placementv1beta1.GroupVersion.Version, "clusterresourceoverride")
+	ValidationPath = utils.RegisterWebhookPath(placementv1beta1.GroupVersion.Group, placementv1beta1.GroupVersion.Version, "clusterresourceoverride", utils.ValidatingWebhookPathKind)
 )
 
 type clusterResourceOverrideValidator struct {
@@ -44,12 +45,18 @@ type clusterResourceOverrideValidator struct {
 	// since we need to guarantee that a resource cannot be selected by multiple overrides.
 	client  client.Reader
 	decoder webhook.AdmissionDecoder
+	// config holds the validator settings of the requests.
+	config *validator.ConfigStore
 }
 
 // Add registers the webhook for K8s bulit-in object types.
-func Add(mgr manager.Manager) error {
+func Add(mgr manager.Manager, config *validator.ConfigStore) error {
+	decoder, err := validation.SharedDecoder(mgr.GetScheme(), &placementv1beta1.ClusterResourceOverride{})
+	if err != nil {
+		return err
+	}
 	hookServer := mgr.GetWebhookServer()
-	hookServer.Register(ValidationPath, &webhook.Admission{Handler: &clusterResourceOverrideValidator{mgr.GetAPIReader(), admission.NewDecoder(mgr.GetScheme())}})
+	hookServer.Register(ValidationPath, &webhook.Admission{Handler: &clusterResourceOverrideValidator{client: mgr.GetAPIReader(), decoder: decoder, config: config}})
 	return nil
 }
 
@@ -71,7 +78,7 @@ func (v *clusterResourceOverrideValidator) Handle(ctx context.Context, req admis
 	// Check if the override count limit has been reached, if there are at most 100 cluster resource overrides
 	if req.Operation == admissionv1.Create && len(croList.Items) >= 100 {
 		klog.Errorf("ClusterResourceOverride limit has been reached: at most 100 cluster resources can be created.")
-		return admission.Denied("clusterResourceOverride limit has been reached: at most 100 cluster resources can be created.")
+		return admission.Denied(v.config.Load().DenialMessage(validator.ClusterResourceOverrideLimitMessageID, map[string]any{"limit": 100}))
 	}
 
 	if err := validator.ValidateClusterResourceOverride(cro, croList); err != nil {
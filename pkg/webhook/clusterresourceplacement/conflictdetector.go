@@ -0,0 +1,204 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceplacement
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1beta1 "github.com/kubefleet-dev/kubefleet/apis/cluster/v1beta1"
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+// OverrideConflict is a resource which two CRPs place on the same cluster with different ClusterResourceOverrides.
+type OverrideConflict struct {
+	// Placement is the name of the existing CRP.
+	Placement string
+	// Cluster is the name of the member cluster both CRPs target.
+	Cluster string
+	// Resource identifies the resource both CRPs select, e.g., Namespace/app.
+	Resource string
+	// Overrides are the sorted names of the overrides of the new CRP which select the resource.
+	Overrides []string
+	// ConflictingOverrides are the sorted names of the overrides of the existing CRP which select the resource.
+	ConflictingOverrides []string
+}
+
+// ConflictDetector finds the existing CRPs whose resources would conflict with the ones of a CRP at apply time.
+type ConflictDetector interface {
+	// DetectConflicts returns the resources which the CRP and any existing CRP place on the same cluster with
+	// different ClusterResourceOverrides.
+	DetectConflicts(ctx context.Context, crp *placementv1beta1.ClusterResourcePlacement) ([]OverrideConflict, error)
+}
+
+// OverrideConflictDetector compares the CRP against the existing CRPs and the ClusterResourceOverrides in the hub
+// cluster. The clusters of the CRP are estimated by matching its policy against the member clusters, while the
+// clusters of the existing CRPs are the ones reported in their status. Only the resources selected by name are
+// compared, as the resources selected by labels are unknown until the placement runs, and the cluster selectors
+// of the override policies are not evaluated.
+type OverrideConflictDetector struct {
+	client client.Reader
+}
+
+// NewOverrideConflictDetector returns a conflict detector which reads the hub cluster with the given client.
+func NewOverrideConflictDetector(c client.Reader) *OverrideConflictDetector {
+	return &OverrideConflictDetector{client: c}
+}
+
+// resourceKey identifies a resource selected by name regardless of its version.
+type resourceKey struct {
+	group string
+	kind  string
+	name  string
+}
+
+// String returns the resource in the kind.group/name form, or kind/name for the core group.
+func (k resourceKey) String() string {
+	if k.group == "" {
+		return k.kind + "/" + k.name
+	}
+	return k.kind + "." + k.group + "/" + k.name
+}
+
+// DetectConflicts returns the conflicts sorted by the placement, cluster and resource.
+func (d *OverrideConflictDetector) DetectConflicts(ctx context.Context, crp *placementv1beta1.ClusterResourcePlacement) ([]OverrideConflict, error) {
+	resources := namedResources(crp.Spec.ResourceSelectors)
+	if len(resources) == 0 {
+		return nil, nil
+	}
+	clusters, err := d.matchingClusters(ctx, crp)
+	if err != nil {
+		return nil, err
+	}
+	if len(clusters) == 0 {
+		return nil, nil
+	}
+	crpList := &placementv1beta1.ClusterResourcePlacementList{}
+	if err := d.client.List(ctx, crpList); err != nil {
+		return nil, fmt.Errorf("failed to list the cluster resource placements: %w", err)
+	}
+	croList := &placementv1beta1.ClusterResourceOverrideList{}
+	if err := d.client.List(ctx, croList); err != nil {
+		return nil, fmt.Errorf("failed to list the cluster resource overrides: %w", err)
+	}
+
+	var conflicts []OverrideConflict
+	for i := range crpList.Items {
+		existing := &crpList.Items[i]
+		if existing.Name == crp.Name || existing.DeletionTimestamp != nil {
+			continue
+		}
+		var sharedResources []resourceKey
+		for key := range namedResources(existing.Spec.ResourceSelectors) {
+			if resources.Has(key) {
+				sharedResources = append(sharedResources, key)
+			}
+		}
+		if len(sharedResources) == 0 {
+			continue
+		}
+		var sharedClusters []string
+		for _, status := range existing.Status.PerClusterPlacementStatuses {
+			if status.ClusterName != "" && clusters.Has(status.ClusterName) {
+				sharedClusters = append(sharedClusters, status.ClusterName)
+			}
+		}
+		for _, key := range sharedResources {
+			overrides := overridesSelecting(croList.Items, crp.Name, key)
+			conflictingOverrides := overridesSelecting(croList.Items, existing.Name, key)
+			if slices.Equal(overrides, conflictingOverrides) {
+				continue
+			}
+			for _, cluster := range sharedClusters {
+				conflicts = append(conflicts, OverrideConflict{
+					Placement:            existing.Name,
+					Cluster:              cluster,
+					Resource:             key.String(),
+					Overrides:            overrides,
+					ConflictingOverrides: conflictingOverrides,
+				})
+			}
+		}
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		if conflicts[i].Placement != conflicts[j].Placement {
+			return conflicts[i].Placement < conflicts[j].Placement
+		}
+		if conflicts[i].Cluster != conflicts[j].Cluster {
+			return conflicts[i].Cluster < conflicts[j].Cluster
+		}
+		return conflicts[i].Resource < conflicts[j].Resource
+	})
+	return conflicts, nil
+}
+
+// matchingClusters returns the names of the member clusters which are not being deleted and match the policy of
+// the CRP.
+func (d *OverrideConflictDetector) matchingClusters(ctx context.Context, crp *placementv1beta1.ClusterResourcePlacement) (sets.Set[string], error) {
+	clusterList := &clusterv1beta1.MemberClusterList{}
+	if err := d.client.List(ctx, clusterList); err != nil {
+		return nil, fmt.Errorf("failed to list the member clusters: %w", err)
+	}
+	clusters := sets.New[string]()
+	for i := range clusterList.Items {
+		cluster := &clusterList.Items[i]
+		if cluster.DeletionTimestamp != nil {
+			continue
+		}
+		matched, err := policyMatchesCluster(crp.Spec.Policy, cluster)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			clusters.Insert(cluster.Name)
+		}
+	}
+	return clusters, nil
+}
+
+// namedResources returns the resources the selectors select by name.
+func namedResources(selectors []placementv1beta1.ResourceSelectorTerm) sets.Set[resourceKey] {
+	keys := sets.New[resourceKey]()
+	for _, selector := range selectors {
+		if selector.Name != "" {
+			keys.Insert(resourceKey{group: selector.Group, kind: selector.Kind, name: selector.Name})
+		}
+	}
+	return keys
+}
+
+// overridesSelecting returns the sorted names of the overrides which reference the CRP and select the resource by
+// name. The overrides without a placement reference apply to every CRP alike, so they never conflict.
+func overridesSelecting(overrides []placementv1beta1.ClusterResourceOverride, crpName string, key resourceKey) []string {
+	var names []string
+	for i := range overrides {
+		cro := &overrides[i]
+		if cro.Spec.Placement == nil || cro.Spec.Placement.Name != crpName || cro.Spec.Placement.Scope == placementv1beta1.NamespaceScoped {
+			continue
+		}
+		if namedResources(cro.Spec.ClusterResourceSelectors).Has(key) {
+			names = append(names, cro.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceplacement
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	clusterv1beta1 "github.com/kubefleet-dev/kubefleet/apis/cluster/v1beta1"
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	webhooktesting "github.com/kubefleet-dev/kubefleet/pkg/webhook/testing"
+)
+
+func TestOverrideConflictDetectorDetectConflicts(t *testing.T) {
+	namespaceSelector := func(name string) placementv1beta1.ResourceSelectorTerm {
+		return placementv1beta1.ResourceSelectorTerm{Version: "v1", Kind: "Namespace", Name: name}
+	}
+	clusterRoleSelector := placementv1beta1.ResourceSelectorTerm{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole", Name: "reader"}
+	newCRP := func(name string, selectors []placementv1beta1.ResourceSelectorTerm, clusters ...string) *placementv1beta1.ClusterResourcePlacement {
+		crp := &placementv1beta1.ClusterResourcePlacement{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: placementv1beta1.PlacementSpec{
+				ResourceSelectors: selectors,
+				Policy:            &placementv1beta1.PlacementPolicy{PlacementType: placementv1beta1.PickFixedPlacementType, ClusterNames: clusters},
+			},
+		}
+		for _, cluster := range clusters {
+			crp.Status.PerClusterPlacementStatuses = append(crp.Status.PerClusterPlacementStatuses, placementv1beta1.PerClusterPlacementStatus{ClusterName: cluster})
+		}
+		return crp
+	}
+	newCRO := func(name, placement string, selectors ...placementv1beta1.ResourceSelectorTerm) *placementv1beta1.ClusterResourceOverride {
+		cro := &placementv1beta1.ClusterResourceOverride{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: placementv1beta1.ClusterResourceOverrideSpec{
+				ClusterResourceSelectors: selectors,
+				Policy:                   &placementv1beta1.OverridePolicy{},
+			},
+		}
+		if placement != "" {
+			cro.Spec.Placement = &placementv1beta1.PlacementRef{Name: placement}
+		}
+		return cro
+	}
+	clusters := []client.Object{
+		&clusterv1beta1.MemberCluster{ObjectMeta: metav1.ObjectMeta{Name: "member-1"}},
+		&clusterv1beta1.MemberCluster{ObjectMeta: metav1.ObjectMeta{Name: "member-2"}},
+		&clusterv1beta1.MemberCluster{ObjectMeta: metav1.ObjectMeta{Name: "member-3"}},
+	}
+	appSelectors := []placementv1beta1.ResourceSelectorTerm{namespaceSelector("app"), clusterRoleSelector}
+
+	testCases := map[string]struct {
+		crp           *placementv1beta1.ClusterResourcePlacement
+		existing      []client.Object
+		listErr       error
+		wantConflicts []OverrideConflict
+		wantErr       bool
+	}{
+		"no other CRP": {
+			crp:      newCRP("new-crp", appSelectors, "member-1"),
+			existing: []client.Object{newCRO("cro-a", "new-crp", namespaceSelector("app"))},
+		},
+		"overlapping CRP with different overrides": {
+			crp: newCRP("new-crp", appSelectors, "member-1", "member-2"),
+			existing: []client.Object{
+				newCRP("crp-a", appSelectors, "member-2", "member-3"),
+				newCRO("cro-a", "crp-a", namespaceSelector("app"), clusterRoleSelector),
+				newCRO("cro-new", "new-crp", clusterRoleSelector),
+			},
+			wantConflicts: []OverrideConflict{
+				{Placement: "crp-a", Cluster: "member-2", Resource: "ClusterRole.rbac.authorization.k8s.io/reader", Overrides: []string{"cro-new"}, ConflictingOverrides: []string{"cro-a"}},
+				{Placement: "crp-a", Cluster: "member-2", Resource: "Namespace/app", ConflictingOverrides: []string{"cro-a"}},
+			},
+		},
+		"overlapping CRP with the same overrides": {
+			crp: newCRP("new-crp", appSelectors, "member-1"),
+			existing: []client.Object{
+				newCRP("crp-a", appSelectors, "member-1"),
+				newCRO("cro-unscoped", "", namespaceSelector("app")),
+			},
+		},
+		"overlapping resources on other clusters": {
+			crp: newCRP("new-crp", appSelectors, "member-1"),
+			existing: []client.Object{
+				newCRP("crp-a", appSelectors, "member-2"),
+				newCRO("cro-a", "crp-a", namespaceSelector("app")),
+			},
+		},
+		"overridden resource not selected by the new CRP": {
+			crp: newCRP("new-crp", []placementv1beta1.ResourceSelectorTerm{namespaceSelector("other")}, "member-1"),
+			existing: []client.Object{
+				newCRP("crp-a", appSelectors, "member-1"),
+				newCRO("cro-a", "crp-a", namespaceSelector("app")),
+			},
+		},
+		"CRP being updated is not compared with itself": {
+			crp: newCRP("crp-a", appSelectors, "member-1"),
+			existing: []client.Object{
+				newCRP("crp-a", appSelectors, "member-1"),
+				newCRO("cro-a", "crp-a", namespaceSelector("app")),
+			},
+		},
+		"list fails": {
+			crp:     newCRP("new-crp", appSelectors, "member-1"),
+			listErr: errors.New("cache is not synced"),
+			wantErr: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(webhooktesting.Scheme).
+				WithObjects(append(tc.existing, clusters...)...).
+				WithInterceptorFuncs(interceptor.Funcs{
+					List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+						if tc.listErr != nil {
+							return tc.listErr
+						}
+						return c.List(ctx, list, opts...)
+					},
+				}).
+				Build()
+			got, err := NewOverrideConflictDetector(fakeClient).DetectConflicts(context.Background(), tc.crp)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("DetectConflicts() error = %v, want error %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.wantConflicts, got); diff != "" {
+				t.Errorf("DetectConflicts() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+//go:build integration
+
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceplacement
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	admv1 "k8s.io/api/admissionregistration/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	clusterv1beta1 "github.com/kubefleet-dev/kubefleet/apis/cluster/v1beta1"
+	placementv1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1"
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
+	testinformer "github.com/kubefleet-dev/kubefleet/test/utils/informer"
+)
+
+// startWebhookTestEnv starts an API server with the fleet CRDs installed and the CRP validating webhook served by
+// a manager, and returns a client of the API server. Unlike the production webhook, the webhook also intercepts the
+// deletions so that their pass-through is verified.
+func startWebhookTestEnv(t *testing.T) client.Client {
+	t.Helper()
+	testEnv := &envtest.Environment{
+		CRDDirectoryPaths:     []string{filepath.Join("..", "..", "..", "config", "crd", "bases")},
+		ErrorIfCRDPathMissing: true,
+		WebhookInstallOptions: envtest.WebhookInstallOptions{
+			ValidatingWebhooks: []*admv1.ValidatingWebhookConfiguration{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "fleet-validating-webhook-configuration"},
+					Webhooks: []admv1.ValidatingWebhook{
+						{
+							Name: "fleet.clusterresourceplacementv1beta1.validating",
+							ClientConfig: admv1.WebhookClientConfig{
+								Service: &admv1.ServiceReference{Namespace: "fleet-system", Name: "fleetwebhook", Path: ptr.To(ValidationPath)},
+							},
+							FailurePolicy:           ptr.To(admv1.Fail),
+							SideEffects:             ptr.To(admv1.SideEffectClassNone),
+							AdmissionReviewVersions: []string{"v1"},
+							Rules: []admv1.RuleWithOperations{
+								{
+									Operations: []admv1.OperationType{admv1.Create, admv1.Update, admv1.Delete},
+									Rule: admv1.Rule{
+										APIGroups:   []string{placementv1beta1.GroupVersion.Group},
+										APIVersions: []string{placementv1beta1.GroupVersion.Version},
+										Resources:   []string{placementv1beta1.ClusterResourcePlacementResource},
+										Scope:       ptr.To(admv1.ClusterScope),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	cfg, err := testEnv.Start()
+	if err != nil {
+		t.Fatalf("failed to start the test environment: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := testEnv.Stop(); err != nil {
+			t.Errorf("failed to stop the test environment: %v", err)
+		}
+	})
+
+	scheme := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{clientgoscheme.AddToScheme, clusterv1beta1.AddToScheme, placementv1beta1.AddToScheme, placementv1.AddToScheme} {
+		if err := add(scheme); err != nil {
+			t.Fatalf("failed to build the scheme: %v", err)
+		}
+	}
+	opts := testEnv.WebhookInstallOptions
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:  scheme,
+		Metrics: metricsserver.Options{BindAddress: "0"},
+		WebhookServer: webhook.NewServer(webhook.Options{
+			Host:    opts.LocalServingHost,
+			Port:    opts.LocalServingPort,
+			CertDir: opts.LocalServingCertDir,
+		}),
+	})
+	if err != nil {
+		t.Fatalf("failed to create the manager: %v", err)
+	}
+
+	// The validator resolves the resource selectors with the mapper and the informer manager set by the hub agent.
+	originalRestMapper, originalResourceInformer := validator.RestMapper, validator.ResourceInformer
+	t.Cleanup(func() {
+		validator.RestMapper, validator.ResourceInformer = originalRestMapper, originalResourceInformer
+	})
+	validator.RestMapper = mgr.GetRESTMapper()
+	validator.ResourceInformer = &testinformer.FakeManager{
+		APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
+		IsClusterScopedResource: true,
+	}
+	if err := Add(mgr); err != nil {
+		t.Fatalf("Add() = %v, want no error", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- mgr.Start(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("the manager stopped with an error: %v", err)
+		}
+	})
+
+	// Wait for the webhook server to serve before sending any request through the API server.
+	addr := net.JoinHostPort(opts.LocalServingHost, fmt.Sprint(opts.LocalServingPort))
+	dialer := &net.Dialer{Timeout: time.Second}
+	if err := wait.PollUntilContextTimeout(ctx, 100*time.Millisecond, 30*time.Second, true, func(context.Context) (bool, error) {
+		conn, err := tls.DialWithDialer(dialer, "tcp", addr, &tls.Config{InsecureSkipVerify: true}) //nolint:gosec // the test only probes the local webhook server.
+		if err != nil {
+			return false, nil
+		}
+		return true, conn.Close()
+	}); err != nil {
+		t.Fatalf("the webhook server is not serving at %s: %v", addr, err)
+	}
+
+	k8sClient, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		t.Fatalf("failed to create the client: %v", err)
+	}
+	return k8sClient
+}
+
+func newIntegrationCRP(name string) *placementv1beta1.ClusterResourcePlacement {
+	return &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: placementv1beta1.PlacementSpec{
+			ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{
+				{
+					Group:   "rbac.authorization.k8s.io",
+					Version: "v1",
+					Kind:    "ClusterRole",
+					Name:    "test-cluster-role",
+				},
+			},
+			Policy: &placementv1beta1.PlacementPolicy{
+				PlacementType: placementv1beta1.PickAllPlacementType,
+			},
+		},
+	}
+}
+
+// wantDenied fails the test unless the error is the denial of the webhook with a message containing wantMessage.
+func wantDenied(t *testing.T, operation string, err error, wantMessage string) {
+	t.Helper()
+	if err == nil {
+		t.Fatalf("%s = nil, want the webhook to deny the request", operation)
+	}
+	if !apierrors.IsForbidden(err) || !strings.Contains(err.Error(), "denied the request") || !strings.Contains(err.Error(), wantMessage) {
+		t.Fatalf("%s = %v, want a webhook denial containing %q", operation, err, wantMessage)
+	}
+}
+
+func TestClusterResourcePlacementWebhookIntegration(t *testing.T) {
+	k8sClient := startWebhookTestEnv(t)
+	ctx := context.Background()
+
+	t.Run("allow create", func(t *testing.T) {
+		crp := newIntegrationCRP("test-crp-create")
+		if err := k8sClient.Create(ctx, crp); err != nil {
+			t.Fatalf("Create() = %v, want no error", err)
+		}
+	})
+
+	t.Run("deny create with an invalid selector", func(t *testing.T) {
+		crp := newIntegrationCRP("test-crp-invalid-selector")
+		crp.Spec.ResourceSelectors[0].LabelSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}}
+		wantDenied(t, "Create()", k8sClient.Create(ctx, crp), "the labelSelector and name fields are mutually exclusive")
+		if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(crp), &placementv1beta1.ClusterResourcePlacement{}); !apierrors.IsNotFound(err) {
+			t.Errorf("Get() = %v, want the denied CRP not to exist", err)
+		}
+	})
+
+	t.Run("deny update changing the placement type", func(t *testing.T) {
+		crp := newIntegrationCRP("test-crp-type-change")
+		if err := k8sClient.Create(ctx, crp); err != nil {
+			t.Fatalf("Create() = %v, want no error", err)
+		}
+		crp.Spec.Policy = &placementv1beta1.PlacementPolicy{
+			PlacementType:    placementv1beta1.PickNPlacementType,
+			NumberOfClusters: ptr.To[int32](1),
+		}
+		wantDenied(t, "Update()", k8sClient.Update(ctx, crp), "placement type is immutable")
+	})
+
+	t.Run("allow delete", func(t *testing.T) {
+		crp := newIntegrationCRP("test-crp-delete")
+		if err := k8sClient.Create(ctx, crp); err != nil {
+			t.Fatalf("Create() = %v, want no error", err)
+		}
+		if err := k8sClient.Delete(ctx, crp); err != nil {
+			t.Fatalf("Delete() = %v, want no error", err)
+		}
+		if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(crp), &placementv1beta1.ClusterResourcePlacement{}); !apierrors.IsNotFound(err) {
+			t.Errorf("Get() = %v, want the deleted CRP not to exist", err)
+		}
+	})
+}
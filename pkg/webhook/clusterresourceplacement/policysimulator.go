@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceplacement
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1beta1 "github.com/kubefleet-dev/kubefleet/apis/cluster/v1beta1"
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+// noMatchingClustersWarning is the warning returned when the scheduling policy of a new CRP matches no cluster.
+const noMatchingClustersWarning = "policy matches 0 current clusters"
+
+// PolicySimulator estimates which clusters the scheduling policy of a CRP selects.
+type PolicySimulator interface {
+	// MatchingClusterCount returns the number of current member clusters the scheduling policy of the CRP matches.
+	MatchingClusterCount(ctx context.Context, crp *placementv1beta1.ClusterResourcePlacement) (int, error)
+}
+
+// MemberClusterPolicySimulator matches the scheduling policy against the member clusters in the hub cluster. It
+// only evaluates the cluster labels and the NoSchedule taints; the property selectors are assumed to match as
+// the simulation is used to warn, and must never report a cluster the scheduler would pick as unmatched.
+type MemberClusterPolicySimulator struct {
+	client client.Reader
+}
+
+// NewMemberClusterPolicySimulator returns a policy simulator which lists the member clusters with the given client.
+func NewMemberClusterPolicySimulator(c client.Reader) *MemberClusterPolicySimulator {
+	return &MemberClusterPolicySimulator{client: c}
+}
+
+// MatchingClusterCount returns the number of member clusters which are not being deleted, tolerate the policy and
+// satisfy its required cluster affinity.
+func (s *MemberClusterPolicySimulator) MatchingClusterCount(ctx context.Context, crp *placementv1beta1.ClusterResourcePlacement) (int, error) {
+	clusterList := &clusterv1beta1.MemberClusterList{}
+	if err := s.client.List(ctx, clusterList); err != nil {
+		return 0, fmt.Errorf("failed to list the member clusters: %w", err)
+	}
+	policy := crp.Spec.Policy
+	count := 0
+	for i := range clusterList.Items {
+		cluster := &clusterList.Items[i]
+		if cluster.DeletionTimestamp != nil {
+			continue
+		}
+		matched, err := policyMatchesCluster(policy, cluster)
+		if err != nil {
+			return 0, err
+		}
+		if matched {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// policyMatchesCluster returns true if the cluster tolerates the policy and satisfies its required cluster affinity.
+func policyMatchesCluster(policy *placementv1beta1.PlacementPolicy, cluster *clusterv1beta1.MemberCluster) (bool, error) {
+	if policy == nil {
+		// A CRP without a policy selects all the clusters.
+		return true, nil
+	}
+	if policy.PlacementType == placementv1beta1.PickFixedPlacementType {
+		for _, name := range policy.ClusterNames {
+			if name == cluster.Name {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	for _, taint := range cluster.Spec.Taints {
+		if taint.Effect == corev1.TaintEffectNoSchedule && !tolerationsTolerateTaint(policy.Tolerations, taint) {
+			return false, nil
+		}
+	}
+	if policy.Affinity == nil || policy.Affinity.ClusterAffinity == nil || policy.Affinity.ClusterAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return true, nil
+	}
+	terms := policy.Affinity.ClusterAffinity.RequiredDuringSchedulingIgnoredDuringExecution.ClusterSelectorTerms
+	if len(terms) == 0 {
+		return true, nil
+	}
+	// The terms are ORed.
+	for _, term := range terms {
+		if term.LabelSelector == nil {
+			return true, nil
+		}
+		selector, err := metav1.LabelSelectorAsSelector(term.LabelSelector)
+		if err != nil {
+			return false, fmt.Errorf("failed to parse the label selector of the required cluster affinity: %w", err)
+		}
+		if selector.Matches(labels.Set(cluster.Labels)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// tolerationsTolerateTaint returns true if any of the tolerations tolerates the taint.
+func tolerationsTolerateTaint(tolerations []placementv1beta1.Toleration, taint clusterv1beta1.Taint) bool {
+	for _, toleration := range tolerations {
+		if toleration.Effect != "" && toleration.Effect != taint.Effect {
+			continue
+		}
+		switch toleration.Operator {
+		case corev1.TolerationOpExists:
+			if toleration.Key == "" || toleration.Key == taint.Key {
+				return true
+			}
+		case corev1.TolerationOpEqual, "":
+			if toleration.Key == taint.Key && toleration.Value == taint.Value {
+				return true
+			}
+		}
+	}
+	return false
+}
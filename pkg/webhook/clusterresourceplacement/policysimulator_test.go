@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceplacement
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	clusterv1beta1 "github.com/kubefleet-dev/kubefleet/apis/cluster/v1beta1"
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	webhooktesting "github.com/kubefleet-dev/kubefleet/pkg/webhook/testing"
+)
+
+func TestMemberClusterPolicySimulatorMatchingClusterCount(t *testing.T) {
+	newCluster := func(name string, labels map[string]string, taints []clusterv1beta1.Taint, deleting bool) *clusterv1beta1.MemberCluster {
+		mc := &clusterv1beta1.MemberCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Spec:       clusterv1beta1.MemberClusterSpec{Taints: taints},
+		}
+		if deleting {
+			mc.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			mc.Finalizers = []string{"kubernetes-fleet.io/membercluster-finalizer"}
+		}
+		return mc
+	}
+	gpuTaint := clusterv1beta1.Taint{Key: "gpu", Value: "true", Effect: corev1.TaintEffectNoSchedule}
+	existing := []client.Object{
+		newCluster("east", map[string]string{"region": "east"}, nil, false),
+		newCluster("west", map[string]string{"region": "west"}, nil, false),
+		newCluster("gpu", map[string]string{"region": "east"}, []clusterv1beta1.Taint{gpuTaint}, false),
+		newCluster("leaving", map[string]string{"region": "north"}, nil, true),
+	}
+	requiredAffinity := func(terms ...placementv1beta1.ClusterSelectorTerm) *placementv1beta1.Affinity {
+		return &placementv1beta1.Affinity{
+			ClusterAffinity: &placementv1beta1.ClusterAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &placementv1beta1.ClusterSelector{ClusterSelectorTerms: terms},
+			},
+		}
+	}
+	regionTerm := func(region string) placementv1beta1.ClusterSelectorTerm {
+		return placementv1beta1.ClusterSelectorTerm{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"region": region}}}
+	}
+	testCases := map[string]struct {
+		policy    *placementv1beta1.PlacementPolicy
+		listErr   error
+		wantCount int
+		wantErr   bool
+	}{
+		"no policy, the tainted cluster is not skipped": {
+			wantCount: 3,
+		},
+		"pick all skips the untolerated taints and the clusters being deleted": {
+			policy:    &placementv1beta1.PlacementPolicy{PlacementType: placementv1beta1.PickAllPlacementType},
+			wantCount: 2,
+		},
+		"pick all tolerating the taint": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType: placementv1beta1.PickAllPlacementType,
+				Tolerations:   []placementv1beta1.Toleration{{Key: "gpu", Operator: corev1.TolerationOpExists}},
+			},
+			wantCount: 3,
+		},
+		"required affinity terms are ORed": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType: placementv1beta1.PickAllPlacementType,
+				Affinity:      requiredAffinity(regionTerm("west"), regionTerm("east")),
+			},
+			wantCount: 2,
+		},
+		"required affinity matching no cluster": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType: placementv1beta1.PickNPlacementType,
+				Affinity:      requiredAffinity(regionTerm("south")),
+			},
+			wantCount: 0,
+		},
+		"required affinity matching only a cluster being deleted": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType: placementv1beta1.PickAllPlacementType,
+				Affinity:      requiredAffinity(regionTerm("north")),
+			},
+			wantCount: 0,
+		},
+		"pick fixed counts the named clusters": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType: placementv1beta1.PickFixedPlacementType,
+				ClusterNames:  []string{"gpu", "unknown"},
+			},
+			wantCount: 1,
+		},
+		"list fails": {
+			listErr: errors.New("cache is not synced"),
+			wantErr: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(webhooktesting.Scheme).
+				WithObjects(existing...).
+				WithInterceptorFuncs(interceptor.Funcs{
+					List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+						if tc.listErr != nil {
+							return tc.listErr
+						}
+						return c.List(ctx, list, opts...)
+					},
+				}).
+				Build()
+			crp := &placementv1beta1.ClusterResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-crp"},
+				Spec:       placementv1beta1.PlacementSpec{Policy: tc.policy},
+			}
+			got, err := NewMemberClusterPolicySimulator(fakeClient).MatchingClusterCount(context.Background(), crp)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("MatchingClusterCount() error = %v, want error %t", err, tc.wantErr)
+			}
+			if !tc.wantErr && got != tc.wantCount {
+				t.Errorf("MatchingClusterCount() = %d, want %d", got, tc.wantCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceplacement
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
+)
+
+// TeamLabel is the label which identifies the team owning a CRP, the number of active CRPs of each team is
+// capped by the fleet quota.
+const TeamLabel = utils.FleetAnnotationPrefix + "/team"
+
+// QuotaEnforcer checks the fleet quota of the CRPs of a team.
+type QuotaEnforcer interface {
+	// CheckQuota returns the number of CRPs the team can still create.
+	CheckQuota(ctx context.Context, teamLabel string) (remaining int, err error)
+}
+
+// KubernetesCountQuotaEnforcer enforces the quota by counting the active CRPs carrying the team label. The quota
+// is validator.Config.MaxPlacementsPerTeam, which is read on every check so that it can be changed at runtime.
+type KubernetesCountQuotaEnforcer struct {
+	client client.Reader
+	config *validator.ConfigStore
+}
+
+// NewKubernetesCountQuotaEnforcer returns a quota enforcer which lists the CRPs with the given client and reads the
+// quota from the validator settings of config.
+func NewKubernetesCountQuotaEnforcer(c client.Reader, config *validator.ConfigStore) *KubernetesCountQuotaEnforcer {
+	return &KubernetesCountQuotaEnforcer{client: c, config: config}
+}
+
+// CheckQuota returns the number of CRPs the team can still create, or math.MaxInt if the quota is not enforced.
+// The CRPs being deleted are not counted.
+func (e *KubernetesCountQuotaEnforcer) CheckQuota(ctx context.Context, teamLabel string) (int, error) {
+	limit := e.config.Load().MaxPlacementsPerTeam
+	if limit <= 0 {
+		return math.MaxInt, nil
+	}
+	crpList := &placementv1beta1.ClusterResourcePlacementList{}
+	if err := e.client.List(ctx, crpList, client.MatchingLabels{TeamLabel: teamLabel}); err != nil {
+		return 0, fmt.Errorf("failed to list the CRPs of team %q: %w", teamLabel, err)
+	}
+	active := 0
+	for i := range crpList.Items {
+		if crpList.Items[i].DeletionTimestamp == nil {
+			active++
+		}
+	}
+	return max(limit-active, 0), nil
+}
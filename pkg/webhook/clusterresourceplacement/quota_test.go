@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceplacement
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
+	webhooktesting "github.com/kubefleet-dev/kubefleet/pkg/webhook/testing"
+)
+
+func TestKubernetesCountQuotaEnforcerCheckQuota(t *testing.T) {
+	newCRP := func(name, team string, deleting bool) *placementv1beta1.ClusterResourcePlacement {
+		crp := &placementv1beta1.ClusterResourcePlacement{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{TeamLabel: team}},
+		}
+		if deleting {
+			crp.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			crp.Finalizers = []string{"kubernetes-fleet.io/crp-cleanup"}
+		}
+		return crp
+	}
+	existing := []client.Object{
+		newCRP("billing-1", "billing", false),
+		newCRP("billing-2", "billing", false),
+		newCRP("billing-3", "billing", true),
+		newCRP("payments-1", "payments", false),
+	}
+	testCases := map[string]struct {
+		maxPlacementsPerTeam int
+		team                 string
+		listErr              error
+		wantRemaining        int
+		wantErr              bool
+	}{
+		"quota not enforced": {
+			team:          "billing",
+			wantRemaining: math.MaxInt,
+		},
+		"quota left, the CRPs being deleted are not counted": {
+			maxPlacementsPerTeam: 3,
+			team:                 "billing",
+			wantRemaining:        1,
+		},
+		"quota exhausted": {
+			maxPlacementsPerTeam: 2,
+			team:                 "billing",
+			wantRemaining:        0,
+		},
+		"quota lowered below the active CRPs": {
+			maxPlacementsPerTeam: 1,
+			team:                 "billing",
+			wantRemaining:        0,
+		},
+		"team without CRPs": {
+			maxPlacementsPerTeam: 2,
+			team:                 "search",
+			wantRemaining:        2,
+		},
+		"failed to list the CRPs": {
+			maxPlacementsPerTeam: 2,
+			team:                 "billing",
+			listErr:              errors.New("cache is not synced"),
+			wantErr:              true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(webhooktesting.Scheme).
+				WithObjects(existing...).
+				WithInterceptorFuncs(interceptor.Funcs{
+					List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+						if tc.listErr != nil {
+							return tc.listErr
+						}
+						return c.List(ctx, list, opts...)
+					},
+				}).
+				Build()
+			got, err := NewKubernetesCountQuotaEnforcer(fakeClient, validator.NewConfigStore(validator.Config{MaxPlacementsPerTeam: tc.maxPlacementsPerTeam})).CheckQuota(context.Background(), tc.team)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("CheckQuota() error = %v, want error %t", err, tc.wantErr)
+			}
+			if !tc.wantErr && got != tc.wantRemaining {
+				t.Errorf("CheckQuota() = %d, want %d", got, tc.wantRemaining)
+			}
+		})
+	}
+}
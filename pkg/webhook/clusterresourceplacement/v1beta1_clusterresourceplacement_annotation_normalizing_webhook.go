@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceplacement
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
+	"github.com/kubefleet-dev/kubefleet/pkg/webhook/validation"
+)
+
+var (
+	// AnnotationNormalizingMutatingPath is the webhook service path for stripping the unwanted annotations of v1beta1 CRP resources.
+	AnnotationNormalizingMutatingPath = utils.RegisterWebhookPath(v1beta1.GroupVersion.Group, v1beta1.GroupVersion.Version, "clusterresourceplacementannotationnormalizing", utils.MutatingWebhookPathKind)
+
+	// protectedAnnotationPrefixes are the prefixes of the annotations owned by fleet, which are never stripped
+	// whatever prefixes are configured.
+	protectedAnnotationPrefixes = []string{utils.FleetAnnotationPrefix + "/", v1beta1.FleetPrefix}
+)
+
+type clusterResourcePlacementAnnotationNormalizer struct {
+	decoder webhook.AdmissionDecoder
+	// config holds the validator settings of the requests.
+	config *validator.ConfigStore
+}
+
+// AddAnnotationNormalizingMutating registers the mutating webhook which strips the unwanted annotations of v1beta1 CRP.
+func AddAnnotationNormalizingMutating(mgr manager.Manager, config *validator.ConfigStore) error {
+	decoder, err := validation.SharedDecoder(mgr.GetScheme(), &v1beta1.ClusterResourcePlacement{})
+	if err != nil {
+		return err
+	}
+	hookServer := mgr.GetWebhookServer()
+	hookServer.Register(AnnotationNormalizingMutatingPath, &webhook.Admission{Handler: &clusterResourcePlacementAnnotationNormalizer{decoder: decoder, config: config}})
+	return nil
+}
+
+// Handle removes the annotations of the CRP whose keys start with any of the configured
+// validator.Config.StripAnnotationPrefixes on create and update, so that annotations with unknown keys do not
+// accumulate on the CRP. The annotations owned by fleet are always kept.
+func (n *clusterResourcePlacementAnnotationNormalizer) Handle(_ context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.Allowed("annotations are only normalized on create and update")
+	}
+	prefixes := n.config.Load().StripAnnotationPrefixes
+	if len(prefixes) == 0 {
+		return admission.Allowed("no annotation prefix to strip")
+	}
+	var crp v1beta1.ClusterResourcePlacement
+	if err := n.decoder.Decode(req, &crp); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	annotations := crp.GetAnnotations()
+	stripped := stripAnnotations(annotations, prefixes)
+	if len(stripped) == 0 {
+		return admission.Allowed("no annotation to strip")
+	}
+	crp.SetAnnotations(annotations)
+
+	marshaled, err := json.Marshal(crp)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	klog.V(3).InfoS("stripping CRP annotations", "crp", req.Name, "operation", req.Operation, "annotations", stripped)
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
+
+// stripAnnotations deletes the annotations whose keys start with any of the prefixes, except the protected ones,
+// and returns the sorted keys of the deleted annotations.
+func stripAnnotations(annotations map[string]string, prefixes []string) []string {
+	var stripped []string
+	for key := range annotations {
+		if hasAnyPrefix(key, protectedAnnotationPrefixes) || !hasAnyPrefix(key, prefixes) {
+			continue
+		}
+		delete(annotations, key)
+		stripped = append(stripped, key)
+	}
+	sort.Strings(stripped)
+	return stripped
+}
+
+// hasAnyPrefix returns true if the key starts with any of the non-empty prefixes.
+func hasAnyPrefix(key string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if prefix != "" && strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceplacement
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	jsonpatchv5 "github.com/evanphx/json-patch/v5"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
+	webhooktesting "github.com/kubefleet-dev/kubefleet/pkg/webhook/testing"
+)
+
+func TestAnnotationNormalizingMutatingHandle(t *testing.T) {
+	newCRP := func(annotations map[string]string) *placementv1beta1.ClusterResourcePlacement {
+		return &placementv1beta1.ClusterResourcePlacement{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-crp", Annotations: annotations},
+		}
+	}
+	annotations := map[string]string{
+		"example.com/owner":                  "team-a",
+		"example.com/ticket":                 "1234",
+		"kubectl.kubernetes.io/last-applied": "{}",
+		"team.example.org/contact":           "oncall",
+		"fleet.azure.com/phase-timestamps":   "{}",
+		"kubernetes-fleet.io/resource-scope": "cluster",
+	}
+	testCases := map[string]struct {
+		prefixes        []string
+		oldCRP          *placementv1beta1.ClusterResourcePlacement
+		crp             *placementv1beta1.ClusterResourcePlacement
+		wantPatched     bool
+		wantAnnotations map[string]string
+	}{
+		"listed prefixes are stripped on create": {
+			prefixes:    []string{"example.com/", "kubectl.kubernetes.io/"},
+			crp:         newCRP(annotations),
+			wantPatched: true,
+			wantAnnotations: map[string]string{
+				"team.example.org/contact":           "oncall",
+				"fleet.azure.com/phase-timestamps":   "{}",
+				"kubernetes-fleet.io/resource-scope": "cluster",
+			},
+		},
+		"listed prefixes are stripped on update": {
+			prefixes:    []string{"example.com/"},
+			oldCRP:      newCRP(nil),
+			crp:         newCRP(annotations),
+			wantPatched: true,
+			wantAnnotations: map[string]string{
+				"kubectl.kubernetes.io/last-applied": "{}",
+				"team.example.org/contact":           "oncall",
+				"fleet.azure.com/phase-timestamps":   "{}",
+				"kubernetes-fleet.io/resource-scope": "cluster",
+			},
+		},
+		"unlisted prefixes are preserved": {
+			prefixes:        []string{"other.example.com/"},
+			crp:             newCRP(annotations),
+			wantAnnotations: annotations,
+		},
+		"fleet annotations are never stripped": {
+			prefixes:    []string{"fleet.azure.com/", "kubernetes-fleet.io/", "team."},
+			crp:         newCRP(annotations),
+			wantPatched: true,
+			wantAnnotations: map[string]string{
+				"example.com/owner":                  "team-a",
+				"example.com/ticket":                 "1234",
+				"kubectl.kubernetes.io/last-applied": "{}",
+				"fleet.azure.com/phase-timestamps":   "{}",
+				"kubernetes-fleet.io/resource-scope": "cluster",
+			},
+		},
+		"no prefix is configured": {
+			crp:             newCRP(annotations),
+			wantAnnotations: annotations,
+		},
+		"empty prefix strips nothing": {
+			prefixes:        []string{""},
+			crp:             newCRP(annotations),
+			wantAnnotations: annotations,
+		},
+		"no annotation": {
+			prefixes: []string{"example.com/"},
+			crp:      newCRP(nil),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			normalizer := &clusterResourcePlacementAnnotationNormalizer{
+				decoder: admission.NewDecoder(webhooktesting.Scheme),
+				config:  validator.NewConfigStore(validator.Config{StripAnnotationPrefixes: tc.prefixes}),
+			}
+			req := webhooktesting.NewCreateRequest(tc.crp.DeepCopy())
+			if tc.oldCRP != nil {
+				req = webhooktesting.NewUpdateRequest(tc.oldCRP, tc.crp.DeepCopy())
+			}
+			raw := req.Object.Raw
+			resp := normalizer.Handle(context.Background(), req)
+			webhooktesting.AssertAllowed(t, resp)
+			if gotPatched := len(resp.Patches) > 0; gotPatched != tc.wantPatched {
+				t.Errorf("Handle() patched = %t, want %t: %v", gotPatched, tc.wantPatched, resp.Patches)
+			}
+
+			patches, err := json.Marshal(resp.Patches)
+			if err != nil {
+				t.Fatalf("json.Marshal(patches) = %v, want no error", err)
+			}
+			patch, err := jsonpatchv5.DecodePatch(patches)
+			if err != nil {
+				t.Fatalf("DecodePatch() = %v, want no error", err)
+			}
+			patched, err := patch.Apply(raw)
+			if err != nil {
+				t.Fatalf("Apply() = %v, want no error", err)
+			}
+			var gotCRP placementv1beta1.ClusterResourcePlacement
+			if err := json.Unmarshal(patched, &gotCRP); err != nil {
+				t.Fatalf("json.Unmarshal() = %v, want no error", err)
+			}
+			if diff := cmp.Diff(tc.wantAnnotations, gotCRP.Annotations); diff != "" {
+				t.Errorf("Handle() annotations mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceplacement
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
+	"github.com/kubefleet-dev/kubefleet/pkg/webhook/validation"
+)
+
+const (
+	// ArchiveLabel marks the ConfigMaps which archive the spec of a deleted CRP.
+	ArchiveLabel = utils.FleetAnnotationPrefix + "/archive"
+	// ArchivedCRPLabel is the label on an archive ConfigMap whose value is the name of the archived CRP.
+	ArchivedCRPLabel = utils.FleetAnnotationPrefix + "/archived-crp"
+	// ArchiveSpecKey is the key of the archive ConfigMap data which holds the JSON encoded spec of the CRP.
+	ArchiveSpecKey = "spec"
+
+	// archiveTimestampFormat is the format of the timestamp suffix in the name of an archive ConfigMap.
+	archiveTimestampFormat = "20060102150405"
+)
+
+var (
+	// ArchivingValidationPath is the webhook service path for archiving the spec of v1beta1 CRP resources on deletion.
+	ArchivingValidationPath = utils.RegisterWebhookPath(v1beta1.GroupVersion.Group, v1beta1.GroupVersion.Version, "clusterresourceplacementarchiving", utils.ValidatingWebhookPathKind)
+)
+
+type clusterResourcePlacementArchiver struct {
+	client  client.Client
+	decoder webhook.AdmissionDecoder
+	// now returns the time the archive is taken at.
+	now func() time.Time
+	// config holds the validator settings of the requests.
+	config *validator.ConfigStore
+}
+
+// AddArchiving registers the webhook which archives the spec of v1beta1 CRP before it is deleted.
+func AddArchiving(mgr manager.Manager, config *validator.ConfigStore) error {
+	decoder, err := validation.SharedDecoder(mgr.GetScheme(), &v1beta1.ClusterResourcePlacement{})
+	if err != nil {
+		return err
+	}
+	hookServer := mgr.GetWebhookServer()
+	hookServer.Register(ArchivingValidationPath, &webhook.Admission{Handler: &clusterResourcePlacementArchiver{
+		client:  mgr.GetClient(),
+		decoder: decoder,
+		now:     time.Now,
+		config:  config,
+	}})
+	return nil
+}
+
+// Handle writes the spec of the CRP to a ConfigMap named <crp-name>-archive-<timestamp> in the fleet namespace on
+// delete, so that the spec can be recovered once the CRP is gone. The deletion is always allowed; a warning is
+// returned if the archive cannot be written. Nothing is written for dry run requests.
+func (a *clusterResourcePlacementArchiver) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Delete {
+		return admission.Allowed("CRP spec is only archived on delete")
+	}
+	if ptr.Deref(req.DryRun, false) {
+		return admission.Allowed("CRP spec is not archived for dry run requests")
+	}
+	var crp v1beta1.ClusterResourcePlacement
+	if err := a.decoder.DecodeRaw(req.OldObject, &crp); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	cm, err := a.archive(ctx, &crp)
+	if err != nil {
+		klog.ErrorS(err, "Failed to archive the CRP spec, allowing the deletion", "clusterResourcePlacement", crp.Name)
+		return admission.Allowed("CRP deletion is allowed").
+			WithWarnings(fmt.Sprintf("failed to archive the spec of CRP %s, it cannot be recovered after the deletion: %v", crp.Name, err))
+	}
+	klog.V(2).InfoS("Archived the CRP spec", "clusterResourcePlacement", crp.Name, "configMap", klog.KObj(cm))
+	return admission.Allowed(fmt.Sprintf("CRP spec is archived in ConfigMap %s/%s", cm.Namespace, cm.Name))
+}
+
+// archive writes the spec of the CRP to a new archive ConfigMap and returns it. An archive of the same name which
+// already exists, e.g., when the request is retried, is kept as is.
+func (a *clusterResourcePlacementArchiver) archive(ctx context.Context, crp *v1beta1.ClusterResourcePlacement) (*corev1.ConfigMap, error) {
+	spec, err := json.Marshal(crp.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the spec: %w", err)
+	}
+	namespace := a.config.Load().FleetNamespace
+	if namespace == "" {
+		namespace = utils.FleetSystemNamespace
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-archive-%s", crp.Name, a.now().UTC().Format(archiveTimestampFormat)),
+			Namespace: namespace,
+			Labels: map[string]string{
+				ArchiveLabel:     "true",
+				ArchivedCRPLabel: crp.Name,
+			},
+		},
+		Data: map[string]string{ArchiveSpecKey: string(spec)},
+	}
+	if err := a.client.Create(ctx, cm); err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, err
+	}
+	return cm, nil
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceplacement
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
+	webhooktesting "github.com/kubefleet-dev/kubefleet/pkg/webhook/testing"
+)
+
+func TestArchivingHandle(t *testing.T) {
+	crp := &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-crp"},
+		Spec: placementv1beta1.PlacementSpec{
+			ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{{Version: "v1", Kind: "Namespace", Name: "test-ns"}},
+			Policy:            &placementv1beta1.PlacementPolicy{PlacementType: placementv1beta1.PickAllPlacementType},
+		},
+	}
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	const archiveName = "test-crp-archive-20250102030405"
+	wantArchive := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      archiveName,
+			Namespace: "fleet-system",
+			Labels: map[string]string{
+				"fleet.azure.com/archive":      "true",
+				"fleet.azure.com/archived-crp": "test-crp",
+			},
+		},
+		Data: map[string]string{ArchiveSpecKey: `{"resourceSelectors":[{"group":"","version":"v1","kind":"Namespace","name":"test-ns"}],"policy":{"placementType":"PickAll"},"strategy":{}}`},
+	}
+	testCases := map[string]struct {
+		req          admission.Request
+		createErr    error
+		wantArchive  *corev1.ConfigMap
+		wantWarnings []string
+	}{
+		"spec is archived on delete": {
+			req:         webhooktesting.NewDeleteRequest(crp),
+			wantArchive: wantArchive,
+		},
+		"deletion is allowed when the archive cannot be written": {
+			req:          webhooktesting.NewDeleteRequest(crp),
+			createErr:    errors.New("etcd is unavailable"),
+			wantWarnings: []string{"failed to archive the spec of CRP test-crp, it cannot be recovered after the deletion: etcd is unavailable"},
+		},
+		"nothing is archived for dry run requests": {
+			req: webhooktesting.NewDeleteRequest(crp, webhooktesting.WithDryRun()),
+		},
+		"nothing is archived on update": {
+			req: webhooktesting.NewUpdateRequest(crp, crp),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithScheme(webhooktesting.Scheme).WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					if tc.createErr != nil {
+						return tc.createErr
+					}
+					return c.Create(ctx, obj, opts...)
+				},
+			}).Build()
+			archiver := &clusterResourcePlacementArchiver{
+				client:  fakeClient,
+				decoder: admission.NewDecoder(webhooktesting.Scheme),
+				now:     func() time.Time { return now },
+				config:  validator.NewConfigStore(validator.Config{FleetNamespace: "fleet-system"}),
+			}
+
+			resp := archiver.Handle(context.Background(), tc.req)
+			webhooktesting.AssertAllowed(t, resp)
+			if diff := cmp.Diff(tc.wantWarnings, resp.Warnings); diff != "" {
+				t.Errorf("Handle() warnings mismatch (-want, +got):\n%s", diff)
+			}
+
+			var archives corev1.ConfigMapList
+			if err := fakeClient.List(context.Background(), &archives, client.MatchingLabels{ArchiveLabel: "true"}); err != nil {
+				t.Fatalf("List() = %v, want no error", err)
+			}
+			if tc.wantArchive == nil {
+				if len(archives.Items) != 0 {
+					t.Errorf("Handle() wrote %d archives, want none", len(archives.Items))
+				}
+				return
+			}
+			if len(archives.Items) != 1 {
+				t.Fatalf("Handle() wrote %d archives, want 1", len(archives.Items))
+			}
+			got := &archives.Items[0]
+			if diff := cmp.Diff(tc.wantArchive, got, cmp.FilterPath(func(p cmp.Path) bool {
+				return strings.HasPrefix(p.String(), "ObjectMeta.ResourceVersion") || p.String() == "TypeMeta"
+			}, cmp.Ignore())); diff != "" {
+				t.Errorf("Handle() archive mismatch (-want, +got):\n%s", diff)
+			}
+			if !strings.Contains(resp.Result.Message, archiveName) {
+				t.Errorf("Handle() message = %q, want the archive name %s", resp.Result.Message, archiveName)
+			}
+
+			// A retried request keeps the archive already written.
+			resp = archiver.Handle(context.Background(), tc.req)
+			webhooktesting.AssertAllowed(t, resp)
+			if len(resp.Warnings) != 0 {
+				t.Errorf("Handle() of a retried request warnings = %v, want none", resp.Warnings)
+			}
+		})
+	}
+}
@@ -23,7 +23,12 @@ import (
 	"fmt"
 	"net/http"
 
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -31,21 +36,59 @@ import (
 	"github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
 	"github.com/kubefleet-dev/kubefleet/pkg/utils"
 	"github.com/kubefleet-dev/kubefleet/pkg/utils/defaulter"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
+	"github.com/kubefleet-dev/kubefleet/pkg/webhook/validation"
+)
+
+const (
+	// LastSpecDiffAnnotation is the annotation that records the JSON merge patch from the previous CRP spec
+	// to the current one, set on every update which changes the spec.
+	LastSpecDiffAnnotation = utils.FleetAnnotationPrefix + "/last-spec-diff"
+
+	// LastSpecDiffTruncatedAnnotation is set to "true" when the diff exceeds maxSpecDiffBytes, in which case
+	// LastSpecDiffAnnotation records truncatedSpecDiff instead of the diff.
+	LastSpecDiffTruncatedAnnotation = utils.FleetAnnotationPrefix + "/last-spec-diff-truncated"
+
+	// maxSpecDiffBytes is the maximum size of the spec diff recorded in the annotation.
+	maxSpecDiffBytes = 4 * 1024
+
+	// truncatedSpecDiff is recorded in place of a spec diff larger than maxSpecDiffBytes so that the
+	// recorded value is always valid JSON.
+	truncatedSpecDiff = `{"truncated":true}`
+
+	// TriggerDryRunReconcileAnnotation requests a read-only reconciliation of the CRP when set to "true" on
+	// an update. The annotation is never persisted; the webhook strips it and emits a DryRunReconcileEventReason
+	// event instead, which the hub controller uses to simulate the placement without changing member clusters.
+	TriggerDryRunReconcileAnnotation = utils.FleetAnnotationPrefix + "/trigger-dry-run-reconcile"
+
+	// DryRunReconcileEventReason is the reason of the event emitted when a dry run reconciliation is requested.
+	DryRunReconcileEventReason = "DryRunReconcile"
+
+	// mutatingWebhookEventSource is the source of the events emitted by the CRP mutating webhook.
+	mutatingWebhookEventSource = "clusterresourceplacement-mutating-webhook"
 )
 
 var (
 	// MutatingPath is the webhook service path for mutating v1beta1 CRP resources.
-	MutatingPath = fmt.Sprintf(utils.MutatingPathFmt, v1beta1.GroupVersion.Group, v1beta1.GroupVersion.Version, "clusterresourceplacement")
+	MutatingPath = utils.RegisterWebhookPath(v1beta1.GroupVersion.Group, v1beta1.GroupVersion.Version, "clusterresourceplacement", utils.MutatingWebhookPathKind)
 )
 
 type clusterResourcePlacementMutator struct {
-	decoder webhook.AdmissionDecoder
+	decoder  webhook.AdmissionDecoder
+	recorder record.EventRecorder
 }
 
 // AddMutating registers the mutating webhook for v1beta1 CRP.
-func AddMutating(mgr manager.Manager) error {
+func AddMutating(mgr manager.Manager, _ *validator.ConfigStore) error {
+	decoder, err := validation.SharedDecoder(mgr.GetScheme(), &v1beta1.ClusterResourcePlacement{})
+	if err != nil {
+		return err
+	}
 	hookServer := mgr.GetWebhookServer()
-	hookServer.Register(MutatingPath, &webhook.Admission{Handler: &clusterResourcePlacementMutator{admission.NewDecoder(mgr.GetScheme())}})
+	hookServer.Register(MutatingPath, &webhook.Admission{Handler: &clusterResourcePlacementMutator{
+		decoder:  decoder,
+		recorder: mgr.GetEventRecorderFor(mutatingWebhookEventSource),
+	}})
 	return nil
 }
 
@@ -58,8 +101,24 @@ func (m *clusterResourcePlacementMutator) Handle(_ context.Context, req admissio
 		return admission.Errored(http.StatusBadRequest, err)
 	}
 
+	// Normalize the zero-value policy to nil so that it is defaulted like an omitted one.
+	validator.NormalizePolicy(&crp)
 	// Apply default values to the CRP object.
 	defaulter.SetPlacementDefaults(&crp)
+
+	if req.Operation == admissionv1.Update {
+		var oldCRP v1beta1.ClusterResourcePlacement
+		if err := m.decoder.DecodeRaw(req.OldObject, &oldCRP); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		diff, truncated, err := buildSpecDiff(&oldCRP.Spec, &crp.Spec)
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		setSpecDiffAnnotations(&crp, &oldCRP, diff, truncated)
+	}
+	m.triggerDryRunReconcile(&crp, req)
+
 	marshaled, err := json.Marshal(crp)
 	if err != nil {
 		return admission.Errored(http.StatusInternalServerError, err)
@@ -67,3 +126,73 @@ func (m *clusterResourcePlacementMutator) Handle(_ context.Context, req admissio
 	klog.V(2).InfoS("mutating CRP", "operation", req.Operation, "crp", req.Name)
 	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
 }
+
+// triggerDryRunReconcile strips the dry run trigger annotation from the CRP and, if it is set to "true" on
+// an update, emits the event which triggers a dry run reconciliation. The annotation is stripped on create
+// too so that it cannot be persisted and retrigger on every later update. No event is emitted for dry run
+// requests as they are never persisted.
+func (m *clusterResourcePlacementMutator) triggerDryRunReconcile(crp *v1beta1.ClusterResourcePlacement, req admission.Request) {
+	annotations := crp.GetAnnotations()
+	value, ok := annotations[TriggerDryRunReconcileAnnotation]
+	if !ok {
+		return
+	}
+	delete(annotations, TriggerDryRunReconcileAnnotation)
+	crp.SetAnnotations(annotations)
+	if value != "true" || req.Operation != admissionv1.Update || ptr.Deref(req.DryRun, false) || m.recorder == nil {
+		return
+	}
+	klog.V(2).InfoS("Triggering a dry run reconciliation of the CRP", "crp", req.Name, "user", req.UserInfo.Username)
+	m.recorder.Eventf(crp, corev1.EventTypeNormal, DryRunReconcileEventReason, "Dry run reconciliation is requested by %s", req.UserInfo.Username)
+}
+
+// buildSpecDiff returns the JSON merge patch from the old spec to the new spec, or truncatedSpecDiff if the
+// patch exceeds maxSpecDiffBytes. It returns an empty diff if the specs are the same.
+func buildSpecDiff(oldSpec, newSpec *v1beta1.PlacementSpec) (string, bool, error) {
+	oldSpecBytes, err := json.Marshal(oldSpec)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to marshal the old spec: %w", err)
+	}
+	newSpecBytes, err := json.Marshal(newSpec)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to marshal the new spec: %w", err)
+	}
+	patch, err := jsonpatch.CreateMergePatch(oldSpecBytes, newSpecBytes)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to create the merge patch between the old and new spec: %w", err)
+	}
+	if string(patch) == "{}" {
+		return "", false, nil
+	}
+	if len(patch) <= maxSpecDiffBytes {
+		return string(patch), false, nil
+	}
+	return truncatedSpecDiff, true, nil
+}
+
+// setSpecDiffAnnotations records the spec diff on the CRP. If the spec is not changed, it keeps the diff
+// recorded by the last update which changed the spec.
+func setSpecDiffAnnotations(crp, oldCRP *v1beta1.ClusterResourcePlacement, diff string, truncated bool) {
+	annotations := crp.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string, 2)
+	}
+	if diff == "" {
+		oldAnnotations := oldCRP.GetAnnotations()
+		for _, key := range []string{LastSpecDiffAnnotation, LastSpecDiffTruncatedAnnotation} {
+			if value, ok := oldAnnotations[key]; ok {
+				annotations[key] = value
+			} else {
+				delete(annotations, key)
+			}
+		}
+	} else {
+		annotations[LastSpecDiffAnnotation] = diff
+		if truncated {
+			annotations[LastSpecDiffTruncatedAnnotation] = "true"
+		} else {
+			delete(annotations, LastSpecDiffTruncatedAnnotation)
+		}
+	}
+	crp.SetAnnotations(annotations)
+}
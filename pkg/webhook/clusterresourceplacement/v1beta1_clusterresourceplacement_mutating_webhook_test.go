@@ -19,6 +19,8 @@ package clusterresourceplacement
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
 
 	"gomodules.xyz/jsonpatch/v2"
@@ -32,6 +34,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -93,6 +96,10 @@ func TestMutatingHandle(t *testing.T) {
 		},
 	}
 
+	crpWithZeroValuePolicy := crpWithNoPolicy.DeepCopy()
+	crpWithZeroValuePolicy.Name = "test-crp-zero-value-policy"
+	crpWithZeroValuePolicy.Spec.Policy = &placementv1beta1.PlacementPolicy{}
+
 	crpWithNoStrategy := &placementv1beta1.ClusterResourcePlacement{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "test-crp-no-strategy",
@@ -282,6 +289,7 @@ func TestMutatingHandle(t *testing.T) {
 
 	crpWithNoRevisionHistoryLimitBytes, _ := json.Marshal(crpWithNoRevisionHistoryLimit)
 	crpWithNoPolicyBytes, _ := json.Marshal(crpWithNoPolicy)
+	crpWithZeroValuePolicyBytes, _ := json.Marshal(crpWithZeroValuePolicy)
 	crpWithNoStrategyBytes, _ := json.Marshal(crpWithNoStrategy)
 	crpWithNoApplyStrategyBytes, _ := json.Marshal(crpWithNoApplyStrategy)
 	crpWithNoRollingUpdateConfigBytes, _ := json.Marshal(crpWithNoRollingUpdateConfig)
@@ -340,6 +348,22 @@ func TestMutatingHandle(t *testing.T) {
 	crpUpdateAllFieldsOld := crpWithAllFields.DeepCopy()
 	crpUpdateAllFieldsNew := crpWithAllFields.DeepCopy()
 
+	crpUpdateStaleDiffOld := crpWithAllFields.DeepCopy()
+	crpUpdateStaleDiffNew := crpWithAllFields.DeepCopy()
+	crpUpdateStaleDiffNew.Annotations = map[string]string{
+		LastSpecDiffAnnotation:          `{"revisionHistoryLimit":10}`,
+		LastSpecDiffTruncatedAnnotation: "true",
+	}
+	crpUpdateStaleDiffNew.Labels = map[string]string{"foo": "bar"}
+
+	// The old CRP carries the diff recorded by the previous update which changed the spec.
+	crpUpdateMetadataOnlyOld := crpWithAllFields.DeepCopy()
+	crpUpdateMetadataOnlyOld.Annotations = map[string]string{
+		LastSpecDiffAnnotation: `{"revisionHistoryLimit":10}`,
+	}
+	crpUpdateMetadataOnlyNew := crpUpdateMetadataOnlyOld.DeepCopy()
+	crpUpdateMetadataOnlyNew.Labels = map[string]string{"foo": "bar"}
+
 	crpUpdateChangeFieldOldBytes, _ := json.Marshal(crpUpdateChangeFieldOld)
 	crpUpdateChangeFieldNewBytes, _ := json.Marshal(crpUpdateChangeFieldNew)
 	crpUpdateMissingFieldsOldBytes, _ := json.Marshal(crpUpdateMissingFieldsOld)
@@ -347,6 +371,10 @@ func TestMutatingHandle(t *testing.T) {
 
 	crpUpdateAllFieldsOldBytes, _ := json.Marshal(crpUpdateAllFieldsOld)
 	crpUpdateAllFieldsNewBytes, _ := json.Marshal(crpUpdateAllFieldsNew)
+	crpUpdateStaleDiffOldBytes, _ := json.Marshal(crpUpdateStaleDiffOld)
+	crpUpdateStaleDiffNewBytes, _ := json.Marshal(crpUpdateStaleDiffNew)
+	crpUpdateMetadataOnlyOldBytes, _ := json.Marshal(crpUpdateMetadataOnlyOld)
+	crpUpdateMetadataOnlyNewBytes, _ := json.Marshal(crpUpdateMetadataOnlyNew)
 
 	scheme := runtime.NewScheme()
 	assert.Nil(t, placementv1beta1.AddToScheme(scheme))
@@ -417,6 +445,36 @@ func TestMutatingHandle(t *testing.T) {
 				},
 			},
 		},
+		"should normalize and default zero-value policy (CREATE)": {
+			req: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Name: "test-crp-zero-value-policy",
+					Object: runtime.RawExtension{
+						Raw:    crpWithZeroValuePolicyBytes,
+						Object: crpWithZeroValuePolicy,
+					},
+					UserInfo: authenticationv1.UserInfo{
+						Username: "test-user",
+						Groups:   []string{"system:masters"},
+					},
+					RequestKind: &utils.ClusterResourcePlacementMetaGVK,
+					Operation:   admissionv1.Create,
+				},
+			},
+			wantResponse: admission.Response{
+				Patches: []jsonpatch.JsonPatchOperation{
+					{
+						Operation: "add",
+						Path:      "/spec/policy/placementType",
+						Value:     string(placementv1beta1.PickAllPlacementType),
+					},
+				},
+				AdmissionResponse: admissionv1.AdmissionResponse{
+					Allowed:   true,
+					PatchType: ptr.To(admissionv1.PatchTypeJSONPatch),
+				},
+			},
+		},
 		"should default missing strategy (CREATE)": {
 			req: admission.Request{
 				AdmissionRequest: admissionv1.AdmissionRequest{
@@ -706,6 +764,13 @@ func TestMutatingHandle(t *testing.T) {
 						Path:      "/spec/revisionHistoryLimit",
 						Value:     float64(defaulter.DefaultRevisionHistoryLimitValue),
 					},
+					{
+						Operation: "add",
+						Path:      "/metadata/annotations",
+						Value: map[string]any{
+							LastSpecDiffAnnotation: `{"policy":{"tolerations":null,"topologySpreadConstraints":null},"strategy":{"rollingUpdate":{"maxSurge":"25%","maxUnavailable":"25%"}}}`,
+						},
+					},
 				},
 			},
 		},
@@ -736,6 +801,66 @@ func TestMutatingHandle(t *testing.T) {
 				Patches: []jsonpatch.JsonPatchOperation{},
 			},
 		},
+		"should restore the recorded spec diff if the spec is not changed (UPDATE)": {
+			req: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Name: "test-crp-all-fields",
+					OldObject: runtime.RawExtension{
+						Raw:    crpUpdateStaleDiffOldBytes,
+						Object: crpUpdateStaleDiffOld,
+					},
+					Object: runtime.RawExtension{
+						Raw:    crpUpdateStaleDiffNewBytes,
+						Object: crpUpdateStaleDiffNew,
+					},
+					UserInfo: authenticationv1.UserInfo{
+						Username: "test-user",
+						Groups:   []string{"system:masters"},
+					},
+					RequestKind: &utils.ClusterResourcePlacementMetaGVK,
+					Operation:   admissionv1.Update,
+				},
+			},
+			wantResponse: admission.Response{
+				AdmissionResponse: admissionv1.AdmissionResponse{
+					Allowed:   true,
+					PatchType: ptr.To(admissionv1.PatchTypeJSONPatch),
+				},
+				Patches: []jsonpatch.JsonPatchOperation{
+					{
+						Operation: "remove",
+						Path:      "/metadata/annotations",
+					},
+				},
+			},
+		},
+		"should keep the recorded spec diff on a metadata-only update (UPDATE)": {
+			req: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Name: "test-crp-all-fields",
+					OldObject: runtime.RawExtension{
+						Raw:    crpUpdateMetadataOnlyOldBytes,
+						Object: crpUpdateMetadataOnlyOld,
+					},
+					Object: runtime.RawExtension{
+						Raw:    crpUpdateMetadataOnlyNewBytes,
+						Object: crpUpdateMetadataOnlyNew,
+					},
+					UserInfo: authenticationv1.UserInfo{
+						Username: "test-user",
+						Groups:   []string{"system:masters"},
+					},
+					RequestKind: &utils.ClusterResourcePlacementMetaGVK,
+					Operation:   admissionv1.Update,
+				},
+			},
+			wantResponse: admission.Response{
+				AdmissionResponse: admissionv1.AdmissionResponse{
+					Allowed: true,
+				},
+				Patches: []jsonpatch.JsonPatchOperation{},
+			},
+		},
 		"should patch default if a field is changed (UPDATE)": {
 			req: admission.Request{
 				AdmissionRequest: admissionv1.AdmissionRequest{
@@ -772,6 +897,13 @@ func TestMutatingHandle(t *testing.T) {
 						Path:      "/spec/strategy/applyStrategy/serverSideApplyConfig",
 						Value:     map[string]any{"force": bool(false)},
 					},
+					{
+						Operation: "add",
+						Path:      "/metadata/annotations",
+						Value: map[string]any{
+							LastSpecDiffAnnotation: `{"policy":{"numberOfClusters":5,"tolerations":[{"key":"foo","operator":"Equal","value":"bar"}],"topologySpreadConstraints":null},"strategy":{"applyStrategy":{"serverSideApplyConfig":{"force":false},"type":"ServerSideApply"}}}`,
+						},
+					},
 				},
 			},
 		},
@@ -793,3 +925,180 @@ func TestMutatingHandle(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildSpecDiff(t *testing.T) {
+	oldSpec := &placementv1beta1.PlacementSpec{
+		ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{resourceSelector},
+		Policy: &placementv1beta1.PlacementPolicy{
+			PlacementType:    placementv1beta1.PickNPlacementType,
+			NumberOfClusters: ptr.To(int32(3)),
+		},
+		RevisionHistoryLimit: ptr.To(int32(10)),
+	}
+	largeSelectors := make([]placementv1beta1.ResourceSelectorTerm, 0, 100)
+	for i := 0; i < 100; i++ {
+		largeSelectors = append(largeSelectors, placementv1beta1.ResourceSelectorTerm{
+			Group:   "",
+			Version: "v1",
+			Kind:    "Namespace",
+			Name:    fmt.Sprintf("test-namespace-%d", i),
+		})
+	}
+	multiByteSelectors := []placementv1beta1.ResourceSelectorTerm{
+		{
+			Version: "v1",
+			Kind:    "Namespace",
+			Name:    strings.Repeat("é", maxSpecDiffBytes),
+		},
+	}
+
+	testCases := map[string]struct {
+		newSpec       *placementv1beta1.PlacementSpec
+		wantDiff      string
+		wantTruncated bool
+	}{
+		"no diff": {
+			newSpec:  oldSpec.DeepCopy(),
+			wantDiff: "",
+		},
+		"changed and removed fields": {
+			newSpec: &placementv1beta1.PlacementSpec{
+				ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{resourceSelector},
+				Policy: &placementv1beta1.PlacementPolicy{
+					PlacementType:    placementv1beta1.PickNPlacementType,
+					NumberOfClusters: ptr.To(int32(5)),
+				},
+			},
+			wantDiff: `{"policy":{"numberOfClusters":5},"revisionHistoryLimit":null}`,
+		},
+		"large diff is truncated": {
+			newSpec: &placementv1beta1.PlacementSpec{
+				ResourceSelectors:    largeSelectors,
+				Policy:               oldSpec.Policy.DeepCopy(),
+				RevisionHistoryLimit: ptr.To(int32(10)),
+			},
+			wantDiff:      truncatedSpecDiff,
+			wantTruncated: true,
+		},
+		"large multi-byte diff is truncated": {
+			newSpec: &placementv1beta1.PlacementSpec{
+				ResourceSelectors:    multiByteSelectors,
+				Policy:               oldSpec.Policy.DeepCopy(),
+				RevisionHistoryLimit: ptr.To(int32(10)),
+			},
+			wantDiff:      truncatedSpecDiff,
+			wantTruncated: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			gotDiff, gotTruncated, err := buildSpecDiff(oldSpec, tc.newSpec)
+			if err != nil {
+				t.Fatalf("buildSpecDiff() = %v, want no error", err)
+			}
+			if gotTruncated != tc.wantTruncated {
+				t.Errorf("buildSpecDiff() truncated = %t, want %t", gotTruncated, tc.wantTruncated)
+			}
+			if diff := cmp.Diff(tc.wantDiff, gotDiff); diff != "" {
+				t.Errorf("buildSpecDiff() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMutatingHandleDryRunReconcile(t *testing.T) {
+	oldCRP := &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-crp",
+			Annotations: map[string]string{"foo": "bar"},
+		},
+		Spec: placementv1beta1.PlacementSpec{
+			ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{resourceSelector},
+		},
+	}
+	defaulter.SetPlacementDefaults(oldCRP)
+	oldCRPBytes, _ := json.Marshal(oldCRP)
+
+	scheme := runtime.NewScheme()
+	assert.Nil(t, placementv1beta1.AddToScheme(scheme))
+	decoder := admission.NewDecoder(scheme)
+
+	testCases := map[string]struct {
+		operation    admissionv1.Operation
+		triggerValue *string
+		dryRun       bool
+		wantPatches  []jsonpatch.JsonPatchOperation
+		wantEvents   []string
+	}{
+		"annotation triggers a dry run reconcile (UPDATE)": {
+			operation:    admissionv1.Update,
+			triggerValue: ptr.To("true"),
+			wantPatches: []jsonpatch.JsonPatchOperation{
+				{Operation: "remove", Path: "/metadata/annotations/fleet.azure.com~1trigger-dry-run-reconcile"},
+			},
+			wantEvents: []string{"Normal DryRunReconcile Dry run reconciliation is requested by test-user"},
+		},
+		"annotation which is not true is stripped without an event (UPDATE)": {
+			operation:    admissionv1.Update,
+			triggerValue: ptr.To("false"),
+			wantPatches: []jsonpatch.JsonPatchOperation{
+				{Operation: "remove", Path: "/metadata/annotations/fleet.azure.com~1trigger-dry-run-reconcile"},
+			},
+		},
+		"annotation is stripped without an event on a dry run request (UPDATE)": {
+			operation:    admissionv1.Update,
+			triggerValue: ptr.To("true"),
+			dryRun:       true,
+			wantPatches: []jsonpatch.JsonPatchOperation{
+				{Operation: "remove", Path: "/metadata/annotations/fleet.azure.com~1trigger-dry-run-reconcile"},
+			},
+		},
+		"no annotation (UPDATE)": {
+			operation: admissionv1.Update,
+		},
+		"annotation is stripped without an event on create (CREATE)": {
+			operation:    admissionv1.Create,
+			triggerValue: ptr.To("true"),
+			wantPatches: []jsonpatch.JsonPatchOperation{
+				{Operation: "remove", Path: "/metadata/annotations/fleet.azure.com~1trigger-dry-run-reconcile"},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			crp := oldCRP.DeepCopy()
+			if tc.triggerValue != nil {
+				crp.Annotations[TriggerDryRunReconcileAnnotation] = *tc.triggerValue
+			}
+			crpBytes, _ := json.Marshal(crp)
+			recorder := record.NewFakeRecorder(10)
+			mutator := &clusterResourcePlacementMutator{decoder: decoder, recorder: recorder}
+			req := admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Name:        crp.Name,
+					Object:      runtime.RawExtension{Raw: crpBytes},
+					OldObject:   runtime.RawExtension{Raw: oldCRPBytes},
+					UserInfo:    authenticationv1.UserInfo{Username: "test-user"},
+					RequestKind: &utils.ClusterResourcePlacementMetaGVK,
+					Operation:   tc.operation,
+					DryRun:      ptr.To(tc.dryRun),
+				},
+			}
+			gotResponse := mutator.Handle(context.Background(), req)
+			if !gotResponse.Allowed {
+				t.Fatalf("Handle() = %+v, want allowed", gotResponse.Result)
+			}
+			if diff := cmp.Diff(tc.wantPatches, gotResponse.Patches, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Handle() patches mismatch (-want, +got):\n%s", diff)
+			}
+			close(recorder.Events)
+			var gotEvents []string
+			for event := range recorder.Events {
+				gotEvents = append(gotEvents, event)
+			}
+			if diff := cmp.Diff(tc.wantEvents, gotEvents); diff != "" {
+				t.Errorf("Handle() events mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
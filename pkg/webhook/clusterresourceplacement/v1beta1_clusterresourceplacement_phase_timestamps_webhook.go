@@ -0,0 +1,154 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceplacement
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
+	"github.com/kubefleet-dev/kubefleet/pkg/webhook/validation"
+)
+
+// PhaseTimestampsAnnotation is the annotation that records, as a JSON map, when the CRP first entered each
+// phase, e.g., {"Scheduled":"2025-01-01T00:00:00Z","Applied":"2025-01-01T00:01:00Z"}.
+const PhaseTimestampsAnnotation = utils.FleetAnnotationPrefix + "/phase-timestamps"
+
+var (
+	// PhaseTimestampsMutatingPath is the webhook service path for recording the phase timestamps of v1beta1 CRP resources.
+	PhaseTimestampsMutatingPath = utils.RegisterWebhookPath(v1beta1.GroupVersion.Group, v1beta1.GroupVersion.Version, "clusterresourceplacementphasetimestamps", utils.MutatingWebhookPathKind)
+
+	// crpPhaseConditionTypes are the CRP condition types which mark a phase once they become true.
+	crpPhaseConditionTypes = []v1beta1.ClusterResourcePlacementConditionType{
+		v1beta1.ClusterResourcePlacementScheduledConditionType,
+		v1beta1.ClusterResourcePlacementRolloutStartedConditionType,
+		v1beta1.ClusterResourcePlacementOverriddenConditionType,
+		v1beta1.ClusterResourcePlacementWorkSynchronizedConditionType,
+		v1beta1.ClusterResourcePlacementAppliedConditionType,
+		v1beta1.ClusterResourcePlacementAvailableConditionType,
+		v1beta1.ClusterResourcePlacementDiffReportedConditionType,
+	}
+)
+
+type clusterResourcePlacementPhaseTimestampsMutator struct {
+	decoder webhook.AdmissionDecoder
+}
+
+// AddPhaseTimestampsMutating registers the mutating webhook which records the phase timestamps of v1beta1 CRP.
+func AddPhaseTimestampsMutating(mgr manager.Manager, _ *validator.ConfigStore) error {
+	decoder, err := validation.SharedDecoder(mgr.GetScheme(), &v1beta1.ClusterResourcePlacement{})
+	if err != nil {
+		return err
+	}
+	hookServer := mgr.GetWebhookServer()
+	hookServer.Register(PhaseTimestampsMutatingPath, &webhook.Admission{Handler: &clusterResourcePlacementPhaseTimestampsMutator{decoder}})
+	return nil
+}
+
+// Handle records the time the CRP entered each phase on update. The timestamps recorded on the old object
+// are preserved so that clients cannot rewrite or drop them, and a timestamp is added for every phase condition
+// which is true but has not been recorded yet.
+//
+// Status changes go through the status subresource which ignores metadata changes, so the timestamp of a phase
+// is recorded on the first update of the CRP after the phase has been reached, using the last transition time of
+// its condition.
+func (m *clusterResourcePlacementPhaseTimestampsMutator) Handle(_ context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Update {
+		return admission.Allowed("phase timestamps are only recorded on update")
+	}
+	var crp, oldCRP v1beta1.ClusterResourcePlacement
+	if err := m.decoder.Decode(req, &crp); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if err := m.decoder.DecodeRaw(req.OldObject, &oldCRP); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	timestamps := parsePhaseTimestamps(oldCRP.GetAnnotations()[PhaseTimestampsAnnotation])
+	if !recordPhaseTimestamps(timestamps, crp.Status.Conditions) && crp.GetAnnotations()[PhaseTimestampsAnnotation] == oldCRP.GetAnnotations()[PhaseTimestampsAnnotation] {
+		return admission.Allowed("no new phase to record")
+	}
+	if len(timestamps) == 0 {
+		annotations := crp.GetAnnotations()
+		delete(annotations, PhaseTimestampsAnnotation)
+		crp.SetAnnotations(annotations)
+	} else {
+		value, err := json.Marshal(timestamps)
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		annotations := crp.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string, 1)
+		}
+		annotations[PhaseTimestampsAnnotation] = string(value)
+		crp.SetAnnotations(annotations)
+	}
+
+	marshaled, err := json.Marshal(crp)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	klog.V(2).InfoS("recording CRP phase timestamps", "crp", req.Name, "phaseTimestamps", timestamps)
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
+
+// parsePhaseTimestamps returns the phase timestamps recorded in the annotation value. A malformed value is
+// logged and treated as empty so that it does not block updates to the CRP.
+func parsePhaseTimestamps(value string) map[string]string {
+	timestamps := make(map[string]string)
+	if value == "" {
+		return timestamps
+	}
+	if err := json.Unmarshal([]byte(value), &timestamps); err != nil {
+		klog.ErrorS(err, "failed to parse the CRP phase timestamps annotation, discarding it", "value", value)
+		return make(map[string]string)
+	}
+	return timestamps
+}
+
+// recordPhaseTimestamps adds the timestamps of the true phase conditions which have not been recorded yet.
+// It returns true if any timestamp has been added.
+func recordPhaseTimestamps(timestamps map[string]string, conditions []metav1.Condition) bool {
+	added := false
+	for _, conditionType := range crpPhaseConditionTypes {
+		phase := strings.TrimPrefix(string(conditionType), "ClusterResourcePlacement")
+		if _, ok := timestamps[phase]; ok {
+			continue
+		}
+		condition := meta.FindStatusCondition(conditions, string(conditionType))
+		if condition == nil || condition.Status != metav1.ConditionTrue {
+			continue
+		}
+		timestamps[phase] = condition.LastTransitionTime.UTC().Format(time.RFC3339)
+		added = true
+	}
+	return added
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceplacement
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	jsonpatchv5 "github.com/evanphx/json-patch/v5"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	webhooktesting "github.com/kubefleet-dev/kubefleet/pkg/webhook/testing"
+)
+
+var (
+	scheduledTime = metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	appliedTime   = metav1.NewTime(time.Date(2025, 1, 1, 0, 5, 0, 0, time.UTC))
+)
+
+// newCRPWithPhases returns a CRP with the argued phase timestamps annotation and status conditions.
+func newCRPWithPhases(phaseTimestamps string, conditions ...metav1.Condition) *placementv1beta1.ClusterResourcePlacement {
+	crp := &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-crp",
+			Annotations: map[string]string{"test-annotation": "test-value"},
+		},
+		Status: placementv1beta1.PlacementStatus{
+			Conditions: conditions,
+		},
+	}
+	if phaseTimestamps != "" {
+		crp.Annotations[PhaseTimestampsAnnotation] = phaseTimestamps
+	}
+	return crp
+}
+
+func newCRPCondition(conditionType placementv1beta1.ClusterResourcePlacementConditionType, status metav1.ConditionStatus, lastTransitionTime metav1.Time) metav1.Condition {
+	return metav1.Condition{
+		Type:               string(conditionType),
+		Status:             status,
+		Reason:             "TestReason",
+		LastTransitionTime: lastTransitionTime,
+	}
+}
+
+func TestPhaseTimestampsMutatingHandle(t *testing.T) {
+	scheduled := newCRPCondition(placementv1beta1.ClusterResourcePlacementScheduledConditionType, metav1.ConditionTrue, scheduledTime)
+	applied := newCRPCondition(placementv1beta1.ClusterResourcePlacementAppliedConditionType, metav1.ConditionTrue, appliedTime)
+	appliedUnknown := newCRPCondition(placementv1beta1.ClusterResourcePlacementAppliedConditionType, metav1.ConditionUnknown, appliedTime)
+	scheduledOnly := `{"Scheduled":"2025-01-01T00:00:00Z"}`
+	scheduledAndApplied := `{"Applied":"2025-01-01T00:05:00Z","Scheduled":"2025-01-01T00:00:00Z"}`
+
+	testCases := map[string]struct {
+		oldCRP              *placementv1beta1.ClusterResourcePlacement
+		crp                 *placementv1beta1.ClusterResourcePlacement
+		wantPatched         bool
+		wantPhaseTimestamps string
+	}{
+		"create is not mutated": {
+			crp:         newCRPWithPhases("", scheduled),
+			wantPatched: false,
+		},
+		"first phase transition is recorded": {
+			oldCRP:              newCRPWithPhases(""),
+			crp:                 newCRPWithPhases("", scheduled),
+			wantPatched:         true,
+			wantPhaseTimestamps: scheduledOnly,
+		},
+		"new phase is appended and existing timestamps are preserved": {
+			oldCRP:              newCRPWithPhases(scheduledOnly, scheduled),
+			crp:                 newCRPWithPhases(scheduledOnly, scheduled, applied),
+			wantPatched:         true,
+			wantPhaseTimestamps: scheduledAndApplied,
+		},
+		"recorded timestamp is not overwritten by a later transition": {
+			oldCRP:              newCRPWithPhases(scheduledOnly, scheduled),
+			crp:                 newCRPWithPhases(scheduledOnly, newCRPCondition(placementv1beta1.ClusterResourcePlacementScheduledConditionType, metav1.ConditionTrue, appliedTime)),
+			wantPatched:         false,
+			wantPhaseTimestamps: scheduledOnly,
+		},
+		"phase which is not true is not recorded": {
+			oldCRP:              newCRPWithPhases(scheduledOnly, scheduled),
+			crp:                 newCRPWithPhases(scheduledOnly, scheduled, appliedUnknown),
+			wantPatched:         false,
+			wantPhaseTimestamps: scheduledOnly,
+		},
+		"no phase reached": {
+			oldCRP:      newCRPWithPhases(""),
+			crp:         newCRPWithPhases("", appliedUnknown),
+			wantPatched: false,
+		},
+		"dropped annotation is restored": {
+			oldCRP:              newCRPWithPhases(scheduledOnly, scheduled),
+			crp:                 newCRPWithPhases("", scheduled),
+			wantPatched:         true,
+			wantPhaseTimestamps: scheduledOnly,
+		},
+		"rewritten annotation is reverted": {
+			oldCRP:              newCRPWithPhases(scheduledOnly, scheduled),
+			crp:                 newCRPWithPhases(`{"Scheduled":"2030-01-01T00:00:00Z"}`, scheduled),
+			wantPatched:         true,
+			wantPhaseTimestamps: scheduledOnly,
+		},
+		"malformed annotation is replaced": {
+			oldCRP:              newCRPWithPhases("not-json", scheduled),
+			crp:                 newCRPWithPhases("not-json", scheduled, applied),
+			wantPatched:         true,
+			wantPhaseTimestamps: scheduledAndApplied,
+		},
+	}
+
+	mutator := &clusterResourcePlacementPhaseTimestampsMutator{decoder: admission.NewDecoder(webhooktesting.Scheme)}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := webhooktesting.NewCreateRequest(tc.crp)
+			if tc.oldCRP != nil {
+				req = webhooktesting.NewUpdateRequest(tc.oldCRP, tc.crp)
+			}
+			raw := req.Object.Raw
+			resp := mutator.Handle(context.Background(), req)
+			webhooktesting.AssertAllowed(t, resp)
+			if gotPatched := len(resp.Patches) > 0; gotPatched != tc.wantPatched {
+				t.Errorf("Handle() patched = %t, want %t: %v", gotPatched, tc.wantPatched, resp.Patches)
+			}
+
+			patches, err := json.Marshal(resp.Patches)
+			if err != nil {
+				t.Fatalf("json.Marshal(patches) = %v, want no error", err)
+			}
+			patch, err := jsonpatchv5.DecodePatch(patches)
+			if err != nil {
+				t.Fatalf("DecodePatch() = %v, want no error", err)
+			}
+			patched, err := patch.Apply(raw)
+			if err != nil {
+				t.Fatalf("Apply() = %v, want no error", err)
+			}
+			var gotCRP placementv1beta1.ClusterResourcePlacement
+			if err := json.Unmarshal(patched, &gotCRP); err != nil {
+				t.Fatalf("json.Unmarshal() = %v, want no error", err)
+			}
+			if diff := cmp.Diff(tc.wantPhaseTimestamps, gotCRP.Annotations[PhaseTimestampsAnnotation]); diff != "" {
+				t.Errorf("Handle() phase timestamps mismatch (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff("test-value", gotCRP.Annotations["test-annotation"]); diff != "" {
+				t.Errorf("Handle() other annotation mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
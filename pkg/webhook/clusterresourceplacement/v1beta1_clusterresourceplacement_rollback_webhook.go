@@ -0,0 +1,154 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceplacement
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
+	"github.com/kubefleet-dev/kubefleet/pkg/webhook/validation"
+)
+
+// RollbackToAnnotation is the annotation which requests the policy of the CRP to be rolled back to the policy
+// snapshot with the given index, e.g., `kubectl annotate crp my-crp fleet.azure.com/rollback-to=3`.
+const RollbackToAnnotation = utils.FleetAnnotationPrefix + "/rollback-to"
+
+var (
+	// RollbackMutatingPath is the webhook service path for rolling back the policy of v1beta1 CRP resources.
+	RollbackMutatingPath = utils.RegisterWebhookPath(v1beta1.GroupVersion.Group, v1beta1.GroupVersion.Version, "clusterresourceplacementrollback", utils.MutatingWebhookPathKind)
+)
+
+// PolicySnapshotLister lists the cluster scheduling policy snapshots of a CRP.
+type PolicySnapshotLister interface {
+	// ListPolicySnapshots returns the cluster scheduling policy snapshots of the named CRP.
+	ListPolicySnapshots(ctx context.Context, crpName string) ([]v1beta1.ClusterSchedulingPolicySnapshot, error)
+}
+
+// clientPolicySnapshotLister lists the policy snapshots through a client.
+type clientPolicySnapshotLister struct {
+	client client.Reader
+}
+
+// ListPolicySnapshots returns the cluster scheduling policy snapshots tracking the named CRP.
+func (l *clientPolicySnapshotLister) ListPolicySnapshots(ctx context.Context, crpName string) ([]v1beta1.ClusterSchedulingPolicySnapshot, error) {
+	snapshotList := &v1beta1.ClusterSchedulingPolicySnapshotList{}
+	if err := validator.ListByIndex(ctx, l.client, snapshotList, validator.PolicySnapshotPlacementIndexKey, crpName); err != nil {
+		return nil, err
+	}
+	return snapshotList.Items, nil
+}
+
+type clusterResourcePlacementRollbackMutator struct {
+	lister  PolicySnapshotLister
+	decoder webhook.AdmissionDecoder
+	// config holds the validator settings of the requests.
+	config *validator.ConfigStore
+}
+
+// AddRollbackMutating registers the mutating webhook which rolls back the policy of v1beta1 CRP on request. The policy
+// snapshots are looked up by the index which Add registers.
+func AddRollbackMutating(mgr manager.Manager, config *validator.ConfigStore) error {
+	decoder, err := validation.SharedDecoder(mgr.GetScheme(), &v1beta1.ClusterResourcePlacement{})
+	if err != nil {
+		return err
+	}
+	hookServer := mgr.GetWebhookServer()
+	hookServer.Register(RollbackMutatingPath, &webhook.Admission{Handler: &clusterResourcePlacementRollbackMutator{
+		lister:  &clientPolicySnapshotLister{client: mgr.GetClient()},
+		decoder: decoder,
+		config:  config,
+	}})
+	return nil
+}
+
+// Handle rolls back the policy of the CRP to the policy snapshot named by the RollbackToAnnotation on update,
+// and removes the annotation so that the rollback is only applied once. A policy snapshot only records the
+// placement policy, so the rest of the spec is kept. The request is denied if the CRP has no snapshot with the
+// requested index.
+func (m *clusterResourcePlacementRollbackMutator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Update {
+		return admission.Allowed("rollback is only applied on update")
+	}
+	var crp v1beta1.ClusterResourcePlacement
+	if err := m.decoder.Decode(req, &crp); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	annotations := crp.GetAnnotations()
+	value, ok := annotations[RollbackToAnnotation]
+	if !ok {
+		return admission.Allowed("no rollback is requested")
+	}
+	revision, err := strconv.Atoi(value)
+	if err != nil || revision < 0 {
+		return admission.Denied(fmt.Sprintf("the value %q of annotation %s is not a valid revision, it must be the non-negative index of a clusterSchedulingPolicySnapshot of the CRP", value, RollbackToAnnotation))
+	}
+
+	snapshots, err := m.lister.ListPolicySnapshots(ctx, crp.Name)
+	if err != nil {
+		klog.ErrorS(err, "Failed to list clusterSchedulingPolicySnapshots when rolling back", "clusterResourcePlacement", crp.Name, "revision", revision)
+		return admission.Errored(http.StatusInternalServerError, fmt.Errorf("failed to list clusterSchedulingPolicySnapshots, please retry the request: %w", err))
+	}
+	var target *v1beta1.ClusterSchedulingPolicySnapshot
+	revisions := make([]int, 0, len(snapshots))
+	for i := range snapshots {
+		index, err := strconv.Atoi(snapshots[i].GetLabels()[v1beta1.PolicyIndexLabel])
+		if err != nil {
+			klog.V(2).InfoS("Skipping the clusterSchedulingPolicySnapshot with an invalid policy index", "clusterResourcePlacement", crp.Name, "clusterSchedulingPolicySnapshot", snapshots[i].Name)
+			continue
+		}
+		revisions = append(revisions, index)
+		if index == revision {
+			target = &snapshots[i]
+		}
+	}
+	if target == nil {
+		sort.Ints(revisions)
+		available := make([]string, 0, len(revisions))
+		for _, r := range revisions {
+			available = append(available, strconv.Itoa(r))
+		}
+		klog.V(2).InfoS("The revision to roll back to is not found, request is denied", "clusterResourcePlacement", crp.Name, "revision", revision, "revisions", revisions)
+		return admission.Denied(m.config.Load().DenialMessage(validator.PlacementRollbackRevisionNotFoundMessageID, map[string]any{
+			"revision": revision, "name": crp.Name, "revisions": strings.Join(available, ", "),
+		}))
+	}
+
+	crp.Spec.Policy = target.Spec.Policy.DeepCopy()
+	delete(annotations, RollbackToAnnotation)
+	crp.SetAnnotations(annotations)
+	marshaled, err := json.Marshal(crp)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	klog.V(2).InfoS("Rolling back the CRP policy", "clusterResourcePlacement", crp.Name, "revision", revision, "clusterSchedulingPolicySnapshot", target.Name, "userName", req.UserInfo.Username)
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
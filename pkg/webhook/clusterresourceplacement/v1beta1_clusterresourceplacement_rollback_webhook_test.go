@@ -0,0 +1,238 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceplacement
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+
+	jsonpatchv5 "github.com/evanphx/json-patch/v5"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	webhooktesting "github.com/kubefleet-dev/kubefleet/pkg/webhook/testing"
+)
+
+// fakePolicySnapshotLister returns the snapshots, or the error if it is set.
+type fakePolicySnapshotLister struct {
+	snapshots []placementv1beta1.ClusterSchedulingPolicySnapshot
+	err       error
+	// listed records the names of the CRPs whose snapshots are listed.
+	listed []string
+}
+
+func (l *fakePolicySnapshotLister) ListPolicySnapshots(_ context.Context, crpName string) ([]placementv1beta1.ClusterSchedulingPolicySnapshot, error) {
+	l.listed = append(l.listed, crpName)
+	return l.snapshots, l.err
+}
+
+// newPolicySnapshot returns a policy snapshot of test-crp with the argued index and number of clusters.
+func newPolicySnapshot(index int, numberOfClusters int32) placementv1beta1.ClusterSchedulingPolicySnapshot {
+	return placementv1beta1.ClusterSchedulingPolicySnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-crp-" + strconv.Itoa(index),
+			Labels: map[string]string{
+				placementv1beta1.PlacementTrackingLabel: "test-crp",
+				placementv1beta1.PolicyIndexLabel:       strconv.Itoa(index),
+			},
+		},
+		Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+			Policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:    placementv1beta1.PickNPlacementType,
+				NumberOfClusters: ptr.To(numberOfClusters),
+			},
+		},
+	}
+}
+
+// newRollbackCRP returns test-crp picking the argued number of clusters with the argued annotations.
+func newRollbackCRP(annotations map[string]string, numberOfClusters int32) *placementv1beta1.ClusterResourcePlacement {
+	return &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-crp", Annotations: annotations},
+		Spec: placementv1beta1.PlacementSpec{
+			Policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:    placementv1beta1.PickNPlacementType,
+				NumberOfClusters: ptr.To(numberOfClusters),
+			},
+			RevisionHistoryLimit: ptr.To(int32(5)),
+		},
+	}
+}
+
+func TestRollbackMutatingHandle(t *testing.T) {
+	snapshots := []placementv1beta1.ClusterSchedulingPolicySnapshot{newPolicySnapshot(0, 1), newPolicySnapshot(1, 2), newPolicySnapshot(2, 3)}
+	rollbackTo := func(revision string) map[string]string {
+		return map[string]string{RollbackToAnnotation: revision, "test-annotation": "test-value"}
+	}
+
+	testCases := map[string]struct {
+		create          bool
+		crp             *placementv1beta1.ClusterResourcePlacement
+		lister          *fakePolicySnapshotLister
+		wantAllowed     bool
+		wantCode        int32
+		wantMessage     string
+		wantPatched     bool
+		wantCRP         *placementv1beta1.ClusterResourcePlacement
+		wantListedNames []string
+	}{
+		"create is not mutated": {
+			create:      true,
+			crp:         newRollbackCRP(rollbackTo("1"), 3),
+			lister:      &fakePolicySnapshotLister{snapshots: snapshots},
+			wantAllowed: true,
+			wantCode:    http.StatusOK,
+			wantMessage: "rollback is only applied on update",
+		},
+		"update without the annotation is not mutated": {
+			crp:         newRollbackCRP(map[string]string{"test-annotation": "test-value"}, 3),
+			lister:      &fakePolicySnapshotLister{snapshots: snapshots},
+			wantAllowed: true,
+			wantCode:    http.StatusOK,
+			wantMessage: "no rollback is requested",
+		},
+		"policy is rolled back and the annotation is removed": {
+			crp:             newRollbackCRP(rollbackTo("1"), 3),
+			lister:          &fakePolicySnapshotLister{snapshots: snapshots},
+			wantAllowed:     true,
+			wantPatched:     true,
+			wantCRP:         newRollbackCRP(map[string]string{"test-annotation": "test-value"}, 2),
+			wantListedNames: []string{"test-crp"},
+		},
+		"policy is rolled back to the first revision": {
+			crp:             newRollbackCRP(rollbackTo("0"), 3),
+			lister:          &fakePolicySnapshotLister{snapshots: snapshots},
+			wantAllowed:     true,
+			wantPatched:     true,
+			wantCRP:         newRollbackCRP(map[string]string{"test-annotation": "test-value"}, 1),
+			wantListedNames: []string{"test-crp"},
+		},
+		"revision not found": {
+			crp:             newRollbackCRP(rollbackTo("7"), 3),
+			lister:          &fakePolicySnapshotLister{snapshots: snapshots},
+			wantCode:        http.StatusForbidden,
+			wantMessage:     "revision 7 of clusterResourcePlacement test-crp is not found, the available revisions are [0, 1, 2]; please annotate the CRP with one of them to roll back",
+			wantListedNames: []string{"test-crp"},
+		},
+		"invalid revision": {
+			crp:         newRollbackCRP(rollbackTo("latest"), 3),
+			lister:      &fakePolicySnapshotLister{snapshots: snapshots},
+			wantCode:    http.StatusForbidden,
+			wantMessage: `the value "latest" of annotation fleet.azure.com/rollback-to is not a valid revision, it must be the non-negative index of a clusterSchedulingPolicySnapshot of the CRP`,
+		},
+		"negative revision": {
+			crp:         newRollbackCRP(rollbackTo("-1"), 3),
+			lister:      &fakePolicySnapshotLister{snapshots: snapshots},
+			wantCode:    http.StatusForbidden,
+			wantMessage: `the value "-1" of annotation fleet.azure.com/rollback-to is not a valid revision, it must be the non-negative index of a clusterSchedulingPolicySnapshot of the CRP`,
+		},
+		"listing the snapshots fails": {
+			crp:             newRollbackCRP(rollbackTo("1"), 3),
+			lister:          &fakePolicySnapshotLister{err: errors.New("cache is not synced")},
+			wantCode:        http.StatusInternalServerError,
+			wantMessage:     "failed to list clusterSchedulingPolicySnapshots, please retry the request: cache is not synced",
+			wantListedNames: []string{"test-crp"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mutator := &clusterResourcePlacementRollbackMutator{lister: tc.lister, decoder: admission.NewDecoder(webhooktesting.Scheme)}
+			req := webhooktesting.NewUpdateRequest(newRollbackCRP(nil, 3), tc.crp)
+			if tc.create {
+				req = webhooktesting.NewCreateRequest(tc.crp)
+			}
+			resp := mutator.Handle(context.Background(), req)
+			if resp.Allowed != tc.wantAllowed {
+				t.Fatalf("Handle() allowed = %t, want %t: %v", resp.Allowed, tc.wantAllowed, resp.Result)
+			}
+			// A patch response carries no result.
+			var gotCode int32
+			var gotMessage string
+			if resp.Result != nil {
+				gotCode, gotMessage = resp.Result.Code, resp.Result.Message
+			}
+			if gotCode != tc.wantCode {
+				t.Errorf("Handle() code = %d, want %d", gotCode, tc.wantCode)
+			}
+			if diff := cmp.Diff(tc.wantMessage, gotMessage); diff != "" {
+				t.Errorf("Handle() message mismatch (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantListedNames, tc.lister.listed); diff != "" {
+				t.Errorf("ListPolicySnapshots() calls mismatch (-want, +got):\n%s", diff)
+			}
+			if gotPatched := len(resp.Patches) > 0; gotPatched != tc.wantPatched {
+				t.Fatalf("Handle() patched = %t, want %t: %v", gotPatched, tc.wantPatched, resp.Patches)
+			}
+			if !tc.wantPatched {
+				return
+			}
+
+			patches, err := json.Marshal(resp.Patches)
+			if err != nil {
+				t.Fatalf("json.Marshal(patches) = %v, want no error", err)
+			}
+			patch, err := jsonpatchv5.DecodePatch(patches)
+			if err != nil {
+				t.Fatalf("DecodePatch() = %v, want no error", err)
+			}
+			patched, err := patch.Apply(req.Object.Raw)
+			if err != nil {
+				t.Fatalf("Apply() = %v, want no error", err)
+			}
+			var gotCRP placementv1beta1.ClusterResourcePlacement
+			if err := json.Unmarshal(patched, &gotCRP); err != nil {
+				t.Fatalf("json.Unmarshal() = %v, want no error", err)
+			}
+			if diff := cmp.Diff(tc.wantCRP.ObjectMeta, gotCRP.ObjectMeta); diff != "" {
+				t.Errorf("Handle() metadata mismatch (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantCRP.Spec, gotCRP.Spec); diff != "" {
+				t.Errorf("Handle() spec mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClientPolicySnapshotLister(t *testing.T) {
+	otherSnapshot := newPolicySnapshot(0, 1)
+	otherSnapshot.Name = "other-crp-0"
+	otherSnapshot.Labels[placementv1beta1.PlacementTrackingLabel] = "other-crp"
+	snapshot0, snapshot1 := newPolicySnapshot(0, 1), newPolicySnapshot(1, 2)
+	fakeClient := webhooktesting.NewFakeClientBuilder().WithObjects(&snapshot0, &snapshot1, &otherSnapshot).Build()
+
+	lister := &clientPolicySnapshotLister{client: fakeClient}
+	got, err := lister.ListPolicySnapshots(context.Background(), "test-crp")
+	if err != nil {
+		t.Fatalf("ListPolicySnapshots() = %v, want no error", err)
+	}
+	var gotNames []string
+	for _, snapshot := range got {
+		gotNames = append(gotNames, client.ObjectKeyFromObject(&snapshot).Name)
+	}
+	if diff := cmp.Diff([]string{"test-crp-0", "test-crp-1"}, gotNames); diff != "" {
+		t.Errorf("ListPolicySnapshots() mismatch (-want, +got):\n%s", diff)
+	}
+}
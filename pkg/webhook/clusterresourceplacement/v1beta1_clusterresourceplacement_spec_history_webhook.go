@@ -0,0 +1,183 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceplacement
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
+	"github.com/kubefleet-dev/kubefleet/pkg/webhook/validation"
+)
+
+// SpecHistoryAnnotation is the annotation that records, as a JSON list, the most recent spec changes of the CRP,
+// e.g., [{"gen":5,"ts":"2025-01-01T00:00:00Z","user":"alice","diff":"{\"revisionHistoryLimit\":5}"}].
+const SpecHistoryAnnotation = utils.FleetAnnotationPrefix + "/spec-history"
+
+const (
+	// maxSpecHistoryEntries is the number of the most recent spec changes kept in the spec history.
+	maxSpecHistoryEntries = 10
+
+	// maxSpecHistoryBytes is the maximum size of the spec history annotation value, well below the annotation value
+	// size the MetadataSize validation rule denies, so that the history never blocks the later spec updates.
+	maxSpecHistoryBytes = 16 * 1024
+)
+
+var (
+	// SpecHistoryMutatingPath is the webhook service path for recording the spec history of v1beta1 CRP resources.
+	SpecHistoryMutatingPath = utils.RegisterWebhookPath(v1beta1.GroupVersion.Group, v1beta1.GroupVersion.Version, "clusterresourceplacementspechistory", utils.MutatingWebhookPathKind)
+)
+
+// specHistoryEntry is a spec change recorded in the spec history.
+type specHistoryEntry struct {
+	// Generation is the generation of the CRP with the changed spec.
+	Generation int64 `json:"gen"`
+	// Timestamp is when the spec was changed, in RFC 3339.
+	Timestamp string `json:"ts"`
+	// User is the name of the user who changed the spec.
+	User string `json:"user"`
+	// Diff is the JSON merge patch from the old spec to the new one, or truncatedSpecDiff if it is too large.
+	Diff string `json:"diff"`
+	// Truncated is true if the diff exceeds maxSpecDiffBytes and has been replaced by truncatedSpecDiff.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+type clusterResourcePlacementSpecHistoryMutator struct {
+	decoder webhook.AdmissionDecoder
+	// now returns the current time, it is replaced in the tests.
+	now func() time.Time
+}
+
+// AddSpecHistoryMutating registers the mutating webhook which records the spec history of v1beta1 CRP.
+func AddSpecHistoryMutating(mgr manager.Manager, _ *validator.ConfigStore) error {
+	decoder, err := validation.SharedDecoder(mgr.GetScheme(), &v1beta1.ClusterResourcePlacement{})
+	if err != nil {
+		return err
+	}
+	hookServer := mgr.GetWebhookServer()
+	hookServer.Register(SpecHistoryMutatingPath, &webhook.Admission{Handler: &clusterResourcePlacementSpecHistoryMutator{decoder: decoder, now: time.Now}})
+	return nil
+}
+
+// Handle appends the spec change of the CRP to its spec history on update, keeping the last maxSpecHistoryEntries
+// entries which fit in maxSpecHistoryBytes. The history recorded on the old object is preserved so that clients cannot rewrite or drop it, and no
+// entry is added if the spec is not changed.
+func (m *clusterResourcePlacementSpecHistoryMutator) Handle(_ context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Update {
+		return admission.Allowed("spec history is only recorded on update")
+	}
+	var crp, oldCRP v1beta1.ClusterResourcePlacement
+	if err := m.decoder.Decode(req, &crp); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if err := m.decoder.DecodeRaw(req.OldObject, &oldCRP); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	oldValue := oldCRP.GetAnnotations()[SpecHistoryAnnotation]
+	specChanged := !equality.Semantic.DeepEqual(crp.Spec, oldCRP.Spec)
+	// The history recorded before it was limited to maxSpecHistoryBytes is trimmed on the next update.
+	if !specChanged && crp.GetAnnotations()[SpecHistoryAnnotation] == oldValue && len(oldValue) <= maxSpecHistoryBytes {
+		return admission.Allowed("spec is not changed")
+	}
+	history := parseSpecHistory(oldValue)
+	if specChanged {
+		diff, truncated, err := buildSpecDiff(&oldCRP.Spec, &crp.Spec)
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		history = appendSpecHistory(history, specHistoryEntry{
+			Generation: oldCRP.Generation + 1,
+			Timestamp:  m.now().UTC().Format(time.RFC3339),
+			User:       req.UserInfo.Username,
+			Diff:       diff,
+			Truncated:  truncated,
+		})
+	}
+
+	history, value, err := encodeSpecHistory(history)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	annotations := crp.GetAnnotations()
+	if len(history) == 0 {
+		delete(annotations, SpecHistoryAnnotation)
+	} else {
+		if annotations == nil {
+			annotations = make(map[string]string, 1)
+		}
+		annotations[SpecHistoryAnnotation] = value
+	}
+	crp.SetAnnotations(annotations)
+
+	marshaled, err := json.Marshal(crp)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	klog.V(2).InfoS("recording CRP spec history", "crp", req.Name, "specChanged", specChanged, "entries", len(history))
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
+
+// parseSpecHistory returns the spec history recorded in the annotation value. A malformed value is logged and
+// treated as empty so that it does not block updates to the CRP.
+func parseSpecHistory(value string) []specHistoryEntry {
+	if value == "" {
+		return nil
+	}
+	var history []specHistoryEntry
+	if err := json.Unmarshal([]byte(value), &history); err != nil {
+		klog.ErrorS(err, "failed to parse the CRP spec history annotation, discarding it", "value", value)
+		return nil
+	}
+	return history
+}
+
+// appendSpecHistory appends the entry to the history, dropping the oldest entries beyond maxSpecHistoryEntries.
+func appendSpecHistory(history []specHistoryEntry, entry specHistoryEntry) []specHistoryEntry {
+	history = append(history, entry)
+	if len(history) > maxSpecHistoryEntries {
+		history = history[len(history)-maxSpecHistoryEntries:]
+	}
+	return history
+}
+
+// encodeSpecHistory returns the history with the oldest entries dropped until its JSON encoding fits in
+// maxSpecHistoryBytes, along with the encoding.
+func encodeSpecHistory(history []specHistoryEntry) ([]specHistoryEntry, string, error) {
+	for len(history) > 0 {
+		value, err := json.Marshal(history)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(value) <= maxSpecHistoryBytes {
+			return history, string(value), nil
+		}
+		history = history[1:]
+	}
+	return nil, "", nil
+}
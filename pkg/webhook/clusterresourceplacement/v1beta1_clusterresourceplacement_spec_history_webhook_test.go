@@ -0,0 +1,264 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceplacement
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	jsonpatchv5 "github.com/evanphx/json-patch/v5"
+	"github.com/google/go-cmp/cmp"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
+	webhooktesting "github.com/kubefleet-dev/kubefleet/pkg/webhook/testing"
+	testinformer "github.com/kubefleet-dev/kubefleet/test/utils/informer"
+)
+
+var specChangeTime = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// newCRPWithSpecHistory returns a CRP at the argued generation with the argued revision history limit and spec
+// history entries.
+func newCRPWithSpecHistory(t *testing.T, generation int64, revisionHistoryLimit int32, history []specHistoryEntry) *placementv1beta1.ClusterResourcePlacement {
+	crp := &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-crp",
+			Generation:  generation,
+			Annotations: map[string]string{"test-annotation": "test-value"},
+		},
+		Spec: placementv1beta1.PlacementSpec{
+			RevisionHistoryLimit: ptr.To(revisionHistoryLimit),
+		},
+	}
+	if history != nil {
+		crp.Annotations[SpecHistoryAnnotation] = marshalSpecHistory(t, history)
+	}
+	return crp
+}
+
+func marshalSpecHistory(t *testing.T, history []specHistoryEntry) string {
+	value, err := json.Marshal(history)
+	if err != nil {
+		t.Fatalf("json.Marshal(history) = %v, want no error", err)
+	}
+	return string(value)
+}
+
+// newSpecHistory returns the history entries of the generations from first to last inclusive.
+func newSpecHistory(first, last int64) []specHistoryEntry {
+	var history []specHistoryEntry
+	for gen := first; gen <= last; gen++ {
+		history = append(history, specHistoryEntry{
+			Generation: gen,
+			Timestamp:  "2024-12-31T00:00:00Z",
+			User:       "old-user",
+			Diff:       fmt.Sprintf(`{"revisionHistoryLimit":%d}`, gen),
+		})
+	}
+	return history
+}
+
+func TestSpecHistoryMutatingHandle(t *testing.T) {
+	newEntry := specHistoryEntry{
+		Generation: 4,
+		Timestamp:  "2025-01-01T00:00:00Z",
+		User:       "test-user",
+		Diff:       `{"revisionHistoryLimit":20}`,
+	}
+	labelsChanged := newCRPWithSpecHistory(t, 3, 10, newSpecHistory(1, 3))
+	labelsChanged.Labels = map[string]string{"test-label": "test-value"}
+
+	testCases := map[string]struct {
+		oldCRP      *placementv1beta1.ClusterResourcePlacement
+		crp         *placementv1beta1.ClusterResourcePlacement
+		wantPatched bool
+		wantHistory []specHistoryEntry
+	}{
+		"create is not mutated": {
+			crp:         newCRPWithSpecHistory(t, 1, 10, nil),
+			wantPatched: false,
+		},
+		"first spec change is recorded": {
+			oldCRP:      newCRPWithSpecHistory(t, 3, 10, nil),
+			crp:         newCRPWithSpecHistory(t, 3, 20, nil),
+			wantPatched: true,
+			wantHistory: []specHistoryEntry{newEntry},
+		},
+		"spec change is appended to the existing history": {
+			oldCRP:      newCRPWithSpecHistory(t, 3, 10, newSpecHistory(1, 3)),
+			crp:         newCRPWithSpecHistory(t, 3, 20, newSpecHistory(1, 3)),
+			wantPatched: true,
+			wantHistory: append(newSpecHistory(1, 3), newEntry),
+		},
+		"oldest entry is rotated out at the limit": {
+			oldCRP:      newCRPWithSpecHistory(t, 3, 10, newSpecHistory(-6, 3)),
+			crp:         newCRPWithSpecHistory(t, 3, 20, newSpecHistory(-6, 3)),
+			wantPatched: true,
+			wantHistory: append(newSpecHistory(-5, 3), newEntry),
+		},
+		"unchanged spec adds no entry": {
+			oldCRP:      newCRPWithSpecHistory(t, 3, 10, newSpecHistory(1, 3)),
+			crp:         newCRPWithSpecHistory(t, 3, 10, newSpecHistory(1, 3)),
+			wantPatched: false,
+			wantHistory: newSpecHistory(1, 3),
+		},
+		"metadata only change adds no entry": {
+			oldCRP:      newCRPWithSpecHistory(t, 3, 10, newSpecHistory(1, 3)),
+			crp:         labelsChanged,
+			wantPatched: false,
+			wantHistory: newSpecHistory(1, 3),
+		},
+		"rewritten history is reverted": {
+			oldCRP:      newCRPWithSpecHistory(t, 3, 10, newSpecHistory(1, 3)),
+			crp:         newCRPWithSpecHistory(t, 3, 10, newSpecHistory(2, 3)),
+			wantPatched: true,
+			wantHistory: newSpecHistory(1, 3),
+		},
+		"malformed history is replaced": {
+			oldCRP: func() *placementv1beta1.ClusterResourcePlacement {
+				crp := newCRPWithSpecHistory(t, 3, 10, nil)
+				crp.Annotations[SpecHistoryAnnotation] = "not-json"
+				return crp
+			}(),
+			crp:         newCRPWithSpecHistory(t, 3, 20, nil),
+			wantPatched: true,
+			wantHistory: []specHistoryEntry{newEntry},
+		},
+	}
+
+	mutator := &clusterResourcePlacementSpecHistoryMutator{
+		decoder: admission.NewDecoder(webhooktesting.Scheme),
+		now:     func() time.Time { return specChangeTime },
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			userInfo := webhooktesting.WithUserInfo(authenticationv1.UserInfo{Username: "test-user"})
+			req := webhooktesting.NewCreateRequest(tc.crp, userInfo)
+			if tc.oldCRP != nil {
+				req = webhooktesting.NewUpdateRequest(tc.oldCRP, tc.crp, userInfo)
+			}
+			raw := req.Object.Raw
+			resp := mutator.Handle(context.Background(), req)
+			webhooktesting.AssertAllowed(t, resp)
+			if gotPatched := len(resp.Patches) > 0; gotPatched != tc.wantPatched {
+				t.Errorf("Handle() patched = %t, want %t: %v", gotPatched, tc.wantPatched, resp.Patches)
+			}
+
+			patches, err := json.Marshal(resp.Patches)
+			if err != nil {
+				t.Fatalf("json.Marshal(patches) = %v, want no error", err)
+			}
+			patch, err := jsonpatchv5.DecodePatch(patches)
+			if err != nil {
+				t.Fatalf("DecodePatch() = %v, want no error", err)
+			}
+			patched, err := patch.Apply(raw)
+			if err != nil {
+				t.Fatalf("Apply() = %v, want no error", err)
+			}
+			var gotCRP placementv1beta1.ClusterResourcePlacement
+			if err := json.Unmarshal(patched, &gotCRP); err != nil {
+				t.Fatalf("json.Unmarshal() = %v, want no error", err)
+			}
+			if diff := cmp.Diff(tc.wantHistory, parseSpecHistory(gotCRP.Annotations[SpecHistoryAnnotation])); diff != "" {
+				t.Errorf("Handle() spec history mismatch (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff("test-value", gotCRP.Annotations["test-annotation"]); diff != "" {
+				t.Errorf("Handle() other annotation mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSpecHistoryKeepsLargeSpecUpdatesAdmitted(t *testing.T) {
+	validator.RestMapper = utils.TestMapper{}
+	validator.ResourceInformer = &testinformer.FakeManager{
+		APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
+		IsClusterScopedResource: true,
+	}
+	// The history is full of the large entries recorded before the diffs were truncated.
+	var history []specHistoryEntry
+	for gen := int64(1); gen <= maxSpecHistoryEntries; gen++ {
+		history = append(history, specHistoryEntry{
+			Generation: gen,
+			Timestamp:  "2024-12-31T00:00:00Z",
+			User:       "old-user",
+			Diff:       strings.Repeat("x", 2*maxSpecDiffBytes),
+		})
+	}
+	oldCRP := newCRPWithSpecHistory(t, maxSpecHistoryEntries, 10, history)
+	oldCRP.Spec.ResourceSelectors = []placementv1beta1.ResourceSelectorTerm{resourceSelector}
+	crp := oldCRP.DeepCopy()
+	for i := 0; i < 40; i++ {
+		selector := resourceSelector
+		selector.Name = fmt.Sprintf("%s-%d", strings.Repeat("cluster-role", 20), i)
+		crp.Spec.ResourceSelectors = append(crp.Spec.ResourceSelectors, selector)
+	}
+
+	mutator := &clusterResourcePlacementSpecHistoryMutator{
+		decoder: admission.NewDecoder(webhooktesting.Scheme),
+		now:     func() time.Time { return specChangeTime },
+	}
+	req := webhooktesting.NewUpdateRequest(oldCRP, crp, webhooktesting.WithUserInfo(testUserInfo))
+	resp := mutator.Handle(context.Background(), req)
+	webhooktesting.AssertAllowed(t, resp)
+	patches, err := json.Marshal(resp.Patches)
+	if err != nil {
+		t.Fatalf("json.Marshal(patches) = %v, want no error", err)
+	}
+	patch, err := jsonpatchv5.DecodePatch(patches)
+	if err != nil {
+		t.Fatalf("DecodePatch() = %v, want no error", err)
+	}
+	patched, err := patch.Apply(req.Object.Raw)
+	if err != nil {
+		t.Fatalf("Apply() = %v, want no error", err)
+	}
+	var gotCRP placementv1beta1.ClusterResourcePlacement
+	if err := json.Unmarshal(patched, &gotCRP); err != nil {
+		t.Fatalf("json.Unmarshal(patched) = %v, want no error", err)
+	}
+
+	value := gotCRP.Annotations[SpecHistoryAnnotation]
+	if len(value) > maxSpecHistoryBytes {
+		t.Errorf("Handle() spec history has %d bytes, want at most %d", len(value), maxSpecHistoryBytes)
+	}
+	gotHistory := parseSpecHistory(value)
+	if len(gotHistory) == 0 {
+		t.Fatalf("Handle() spec history is empty, want the new entry")
+	}
+	if last := gotHistory[len(gotHistory)-1]; last.Generation != maxSpecHistoryEntries+1 || !last.Truncated || len(last.Diff) > maxSpecDiffBytes {
+		t.Errorf("Handle() last spec history entry = gen %d, truncated %t, %d diff bytes, want gen %d with a truncated diff of at most %d bytes",
+			last.Generation, last.Truncated, len(last.Diff), maxSpecHistoryEntries+1, maxSpecDiffBytes)
+	}
+
+	v := clusterResourcePlacementValidator{
+		client:  webhooktesting.NewFakeClientBuilder().Build(),
+		decoder: NewVersionedDecoder(admission.NewDecoder(webhooktesting.Scheme)),
+	}
+	webhooktesting.AssertAllowed(t, v.Handle(context.Background(), webhooktesting.NewUpdateRequest(oldCRP, &gotCRP, webhooktesting.WithUserInfo(testUserInfo))))
+}
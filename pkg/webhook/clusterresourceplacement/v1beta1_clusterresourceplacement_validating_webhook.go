@@ -20,50 +20,282 @@ package clusterresourceplacement
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"sort"
+	"strings"
 
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	placementv1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1"
 	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
 	"github.com/kubefleet-dev/kubefleet/pkg/utils"
 	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
+	"github.com/kubefleet-dev/kubefleet/pkg/webhook/validation"
 )
 
+// maxConflictsInMessage is the maximum number of override conflicts listed in the deny message.
+const maxConflictsInMessage = 10
+
 var (
 	// ValidationPath is the webhook service path which admission requests are routed to for validating v1beta1 CRP resources.
-	ValidationPath = fmt.Sprintf(utils.ValidationPathFmt, placementv1beta1.GroupVersion.Group, placementv1beta1.GroupVersion.Version, "clusterresourceplacement")
+	ValidationPath = utils.RegisterWebhookPath(placementv1beta1.GroupVersion.Group, placementv1beta1.GroupVersion.Version, "clusterresourceplacement", utils.ValidatingWebhookPathKind)
 )
 
 type clusterResourcePlacementValidator struct {
-	decoder webhook.AdmissionDecoder
+	client client.Reader
+	// decoder decodes the CRPs of every served version into the v1beta1 type.
+	decoder *VersionedDecoder
+	// quotaEnforcer denies the creation of a CRP once its team has exhausted the quota. The quota is not checked
+	// if it is nil.
+	quotaEnforcer QuotaEnforcer
+	// policySimulator estimates the number of clusters the scheduling policy of a new CRP matches, so that a
+	// warning is returned if it matches none. The simulation is skipped if it is nil.
+	policySimulator PolicySimulator
+	// conflictDetector denies a CRP which places the resources of another CRP on the same clusters with different
+	// overrides. The conflicts are not checked if it is nil.
+	conflictDetector ConflictDetector
+	// config holds the validator settings of the requests.
+	config *validator.ConfigStore
 }
 
 // Add registers the webhook for K8s bulit-in object types.
-func Add(mgr manager.Manager) error {
+func Add(mgr manager.Manager, config *validator.ConfigStore) error {
+	decoder, err := validation.SharedDecoder(mgr.GetScheme(), &placementv1beta1.ClusterResourcePlacement{}, &placementv1.ClusterResourcePlacement{})
+	if err != nil {
+		return err
+	}
+	// The policy snapshot index is shared with the rollback mutating webhook.
+	indexer := mgr.GetFieldIndexer()
+	if err := indexer.IndexField(context.Background(), &placementv1beta1.ClusterSchedulingPolicySnapshot{}, validator.PolicySnapshotPlacementIndexKey, validator.PolicySnapshotPlacementIndexer); err != nil {
+		klog.ErrorS(err, "Failed to set up the placement index for cluster scheduling policy snapshots")
+		return err
+	}
+	if err := indexer.IndexField(context.Background(), &placementv1beta1.ResourcePlacement{}, validator.PlacementNameIndexKey, validator.PlacementNameIndexer); err != nil {
+		klog.ErrorS(err, "Failed to set up the name index for resource placements")
+		return err
+	}
+	if err := validator.IndexUpdateRunsByPlacementName(context.Background(), indexer, &placementv1beta1.ClusterStagedUpdateRun{}); err != nil {
+		klog.ErrorS(err, "Failed to set up the placement name index for cluster staged update runs")
+		return err
+	}
+	// The override conflicts are opt-in, as detecting them lists the member clusters, CRPs and overrides of the hub.
+	var conflictDetector ConflictDetector
+	if config.Load().DenyPlacementOverrideConflicts {
+		conflictDetector = NewOverrideConflictDetector(mgr.GetClient())
+	}
 	hookServer := mgr.GetWebhookServer()
-	hookServer.Register(ValidationPath, &webhook.Admission{Handler: &clusterResourcePlacementValidator{admission.NewDecoder(mgr.GetScheme())}})
+	hookServer.Register(ValidationPath, &webhook.Admission{Handler: &clusterResourcePlacementValidator{
+		client:           mgr.GetClient(),
+		decoder:          NewVersionedDecoder(decoder),
+		quotaEnforcer:    NewKubernetesCountQuotaEnforcer(mgr.GetClient(), config),
+		policySimulator:  NewMemberClusterPolicySimulator(mgr.GetClient()),
+		conflictDetector: conflictDetector,
+		config:           config,
+	}})
 	return nil
 }
 
-// Handle clusterResourcePlacementValidator handles create, update CRP requests.
+// Handle clusterResourcePlacementValidator handles create, update CRP requests. The connect requests, e.g., the
+// exec or port-forward through a future CRP proxy object, are always allowed so that the webhook never blocks them.
 func (v *clusterResourcePlacementValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
-	return validator.HandlePlacementValidation(ctx, req, v.decoder,
+	switch req.Operation {
+	case admissionv1.Create, admissionv1.Update, admissionv1.Delete:
+		return v.validate(ctx, req)
+	case admissionv1.Connect:
+		klog.FromContext(ctx).V(4).Info("allowing the connect request of clusterResourcePlacement", "name", req.Name, "subResource", req.SubResource, "user", req.UserInfo.Username)
+		return admission.Allowed(validator.AllowedMessage(fmt.Sprintf("%s of v1beta1 CRP", req.Operation), 0, 0))
+	default:
+		return admission.Allowed("unknown operation")
+	}
+}
+
+// validate validates the create, update and delete CRP requests.
+func (v *clusterResourcePlacementValidator) validate(ctx context.Context, req admission.Request) admission.Response {
+	// All the client-backed checks of the request share one lookup budget, so that a slow or partitioned hub cache
+	// cannot stall the admission.
+	config := v.config.Load()
+	ctx, cancel := config.WithLookupBudget(ctx, 0)
+	defer cancel()
+	// The CRPs of every version are decoded by the versioned decoder, so the admission decoder is not needed.
+	resp := config.HandlePlacementValidation(ctx, req, nil,
 		"CRP",
+		acceptedKinds,
 		// decodeFunc
-		func(req admission.Request, decoder webhook.AdmissionDecoder) (placementv1beta1.PlacementObj, error) {
-			var crp placementv1beta1.ClusterResourcePlacement
-			err := decoder.Decode(req, &crp)
-			return &crp, err
+		func(req admission.Request, _ webhook.AdmissionDecoder) (placementv1beta1.PlacementObj, error) {
+			return v.decoder.Decode(req)
 		},
 		// decodeOldFunc
-		func(req admission.Request, decoder webhook.AdmissionDecoder) (placementv1beta1.PlacementObj, error) {
-			var oldCRP placementv1beta1.ClusterResourcePlacement
-			err := decoder.DecodeRaw(req.OldObject, &oldCRP)
-			return &oldCRP, err
+		func(req admission.Request, _ webhook.AdmissionDecoder) (placementv1beta1.PlacementObj, error) {
+			return v.decoder.DecodeOld(req)
 		},
 		// validateFunc
 		func(obj placementv1beta1.PlacementObj) error {
-			return validator.ValidateClusterResourcePlacement(obj.(*placementv1beta1.ClusterResourcePlacement))
+			return config.ValidateClusterResourcePlacement(obj.(*placementv1beta1.ClusterResourcePlacement))
 		})
+	// The requests of the unknown kinds allowed without validation cannot be decoded.
+	if !resp.Allowed || (req.Operation != admissionv1.Create && req.Operation != admissionv1.Update) || !validator.IsAcceptedKind(req, acceptedKinds) {
+		return resp
+	}
+	crp, err := v.decoder.Decode(req)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if req.Operation == admissionv1.Update {
+		oldCRP, err := v.decoder.DecodeOld(req)
+		if err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if resp = config.ValidatePlacementIncidentWindow(ctx, crp, oldCRP, resp); !resp.Allowed {
+			return resp
+		}
+		resp = config.ValidatePlacementClusterNames(ctx, v.client, crp, oldCRP, resp)
+		resp = config.ValidatePlacementNumberOfClusters(ctx, v.client, crp, oldCRP, resp)
+		resp = config.ValidatePlacementPickAllFleetSize(ctx, v.client, crp, oldCRP, resp)
+		return v.validateNoOverrideConflicts(ctx, config, crp, oldCRP, resp)
+	}
+	if err := config.ValidateRequiredLabels(crp.Labels); err != nil {
+		klog.FromContext(ctx).V(2).Info("CRP is missing required labels, request is denied", "clusterResourcePlacement", crp.Name, "error", err)
+		return admission.Denied(err.Error())
+	}
+	if resp = v.validateTeamQuota(ctx, config, crp, resp); !resp.Allowed {
+		return resp
+	}
+	if resp = v.validateNoOwnedPolicySnapshots(ctx, config, req.Name, resp); !resp.Allowed {
+		return resp
+	}
+	if resp = config.ValidatePlacementNameCollision(ctx, v.client, crp, resp); !resp.Allowed {
+		return resp
+	}
+	resp = config.ValidatePlacementClusterNames(ctx, v.client, crp, nil, resp)
+	resp = config.ValidatePlacementNumberOfClusters(ctx, v.client, crp, nil, resp)
+	resp = config.ValidatePlacementPickAllFleetSize(ctx, v.client, crp, nil, resp)
+	if resp = v.validateNoOverrideConflicts(ctx, config, crp, nil, resp); !resp.Allowed {
+		return resp
+	}
+	return v.warnNoMatchingClusters(ctx, config, crp, resp)
+}
+
+// warnNoMatchingClusters adds a warning to the response if the scheduling policy of the new CRP matches no current
+// cluster. The CRP is still allowed so that it can be created ahead of the clusters it targets, and a failed
+// simulation only skips the warning.
+func (v *clusterResourcePlacementValidator) warnNoMatchingClusters(ctx context.Context, config validator.Config, crp *placementv1beta1.ClusterResourcePlacement, resp admission.Response) admission.Response {
+	if v.policySimulator == nil || !resp.Allowed {
+		return resp
+	}
+	count, err := v.policySimulator.MatchingClusterCount(ctx, crp)
+	if err != nil {
+		if exhausted := config.LookupBudgetExhausted(ctx, validator.PolicySimulationLookupCheck, err); exhausted != nil {
+			return validator.LookupUnavailableResponse(exhausted, resp)
+		}
+		klog.FromContext(ctx).Error(err, "Failed to simulate the scheduling policy of the CRP, skipping the check", "clusterResourcePlacement", crp.Name)
+		return resp
+	}
+	if count > 0 {
+		return resp
+	}
+	klog.FromContext(ctx).V(2).Info("The scheduling policy of the CRP matches no current cluster", "clusterResourcePlacement", crp.Name)
+	return resp.WithWarnings(noMatchingClustersWarning)
+}
+
+// validateNoOverrideConflicts denies the CRP allowed by resp if it places any resource of an existing CRP on the
+// same cluster with different overrides. On update, the conflicts are only checked if the resource selectors or the
+// policy change, so that the CRPs which already conflict can still be updated otherwise; oldCRP is nil on creation.
+func (v *clusterResourcePlacementValidator) validateNoOverrideConflicts(ctx context.Context, config validator.Config, crp, oldCRP *placementv1beta1.ClusterResourcePlacement, resp admission.Response) admission.Response {
+	if v.conflictDetector == nil || !resp.Allowed {
+		return resp
+	}
+	if oldCRP != nil && equality.Semantic.DeepEqual(crp.Spec.ResourceSelectors, oldCRP.Spec.ResourceSelectors) && equality.Semantic.DeepEqual(crp.Spec.Policy, oldCRP.Spec.Policy) {
+		return resp
+	}
+	conflicts, err := v.conflictDetector.DetectConflicts(ctx, crp)
+	if err != nil {
+		if exhausted := config.LookupBudgetExhausted(ctx, validator.OverrideConflictsLookupCheck, err); exhausted != nil {
+			return validator.LookupUnavailableResponse(exhausted, resp)
+		}
+		klog.FromContext(ctx).Error(err, "Failed to detect the override conflicts of the CRP", "clusterResourcePlacement", crp.Name)
+		return admission.Errored(http.StatusInternalServerError, fmt.Errorf("failed to detect the override conflicts with the other clusterResourcePlacements, please retry the request: %w", err))
+	}
+	if len(conflicts) == 0 {
+		return resp
+	}
+	listed := conflicts
+	if len(listed) > maxConflictsInMessage {
+		listed = listed[:maxConflictsInMessage]
+	}
+	details := make([]string, 0, len(listed))
+	for _, c := range listed {
+		details = append(details, fmt.Sprintf("clusterResourcePlacement %s on cluster %s for resource %s (overrides %s vs %s)",
+			c.Placement, c.Cluster, c.Resource, overrideNames(c.Overrides), overrideNames(c.ConflictingOverrides)))
+	}
+	msg := strings.Join(details, "; ")
+	if more := len(conflicts) - len(listed); more > 0 {
+		msg += fmt.Sprintf(" and %d more", more)
+	}
+	klog.FromContext(ctx).V(2).Info("The CRP conflicts with the overrides of other CRPs, request is denied", "clusterResourcePlacement", crp.Name, "conflictCount", len(conflicts))
+	return admission.Denied(config.DenialMessage(validator.PlacementOverrideConflictMessageID, map[string]any{"conflicts": msg}))
+}
+
+// overrideNames returns the override names for the denial message.
+func overrideNames(names []string) string {
+	if len(names) == 0 {
+		return "none"
+	}
+	return "[" + strings.Join(names, ", ") + "]"
+}
+
+// validateTeamQuota denies the creation of the CRP allowed by resp if the team in its team label has no quota left.
+func (v *clusterResourcePlacementValidator) validateTeamQuota(ctx context.Context, config validator.Config, crp *placementv1beta1.ClusterResourcePlacement, resp admission.Response) admission.Response {
+	team := crp.Labels[TeamLabel]
+	if v.quotaEnforcer == nil || team == "" {
+		return resp
+	}
+	remaining, err := v.quotaEnforcer.CheckQuota(ctx, team)
+	if err != nil {
+		if exhausted := config.LookupBudgetExhausted(ctx, validator.TeamQuotaLookupCheck, err); exhausted != nil {
+			return validator.LookupUnavailableResponse(exhausted, resp)
+		}
+		klog.FromContext(ctx).Error(err, "Failed to check the CRP quota of the team", "clusterResourcePlacement", crp.Name, "team", team)
+		return admission.Errored(http.StatusInternalServerError, fmt.Errorf("failed to check the CRP quota of team %q, please retry the request: %w", team, err))
+	}
+	if remaining > 0 {
+		return resp
+	}
+	klog.FromContext(ctx).V(2).Info("The CRP quota of the team is exhausted, request is denied", "clusterResourcePlacement", crp.Name, "team", team)
+	return admission.Denied(config.DenialMessage(validator.TeamQuotaExhaustedMessageID, map[string]any{"team": team, "label": TeamLabel, "remaining": remaining}))
+}
+
+// validateNoOwnedPolicySnapshots denies the creation of the CRP allowed by resp if any existing cluster scheduling
+// policy snapshot is owned by a CRP with the same name, which means a previously deleted CRP of the same name has
+// left its snapshots behind and the new CRP would adopt them.
+func (v *clusterResourcePlacementValidator) validateNoOwnedPolicySnapshots(ctx context.Context, config validator.Config, crpName string, resp admission.Response) admission.Response {
+	snapshotList := &placementv1beta1.ClusterSchedulingPolicySnapshotList{}
+	if err := validator.ListByIndex(ctx, v.client, snapshotList, validator.PolicySnapshotPlacementIndexKey, crpName); err != nil {
+		if exhausted := config.LookupBudgetExhausted(ctx, validator.OwnedPolicySnapshotsLookupCheck, err); exhausted != nil {
+			return validator.LookupUnavailableResponse(exhausted, resp)
+		}
+		klog.FromContext(ctx).Error(err, "Failed to list clusterSchedulingPolicySnapshots when validating", "clusterResourcePlacement", crpName)
+		return admission.Errored(http.StatusInternalServerError, fmt.Errorf("failed to list clusterSchedulingPolicySnapshots, please retry the request: %w", err))
+	}
+
+	var conflicts []string
+	for i := range snapshotList.Items {
+		for _, owner := range snapshotList.Items[i].GetOwnerReferences() {
+			if owner.Kind == placementv1beta1.ClusterResourcePlacementKind && owner.Name == crpName {
+				conflicts = append(conflicts, snapshotList.Items[i].Name)
+				break
+			}
+		}
+	}
+	if len(conflicts) == 0 {
+		return resp
+	}
+	sort.Strings(conflicts)
+	klog.FromContext(ctx).V(2).Info("Cluster scheduling policy snapshots owned by a previous CRP of the same name still exist, request is denied", "clusterResourcePlacement", crpName, "snapshots", conflicts)
+	return admission.Denied(config.DenialMessage(validator.OwnedPolicySnapshotsMessageID, map[string]any{"snapshots": strings.Join(conflicts, ", "), "name": crpName}))
 }
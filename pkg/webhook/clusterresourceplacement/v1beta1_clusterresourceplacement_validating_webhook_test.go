@@ -2,25 +2,32 @@ package clusterresourceplacement
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
 	admissionv1 "k8s.io/api/admission/v1"
 	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
 	"github.com/kubefleet-dev/kubefleet/pkg/utils"
 	"github.com/kubefleet-dev/kubefleet/pkg/utils/informer"
 	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
+	webhooktesting "github.com/kubefleet-dev/kubefleet/pkg/webhook/testing"
 	testinformer "github.com/kubefleet-dev/kubefleet/test/utils/informer"
 )
 
@@ -31,10 +38,16 @@ var (
 		Kind:    "ClusterRole",
 		Name:    "test-cluster-role",
 	}
+	testUserInfo = authenticationv1.UserInfo{
+		Username: "test-user",
+		Groups:   []string{"system:masters"},
+	}
 	errString = "the rollout Strategy field  is invalid: maxUnavailable must be greater than or equal to 0, got `-1`"
 )
 
 func TestHandle(t *testing.T) {
+	// Every placement validation rule and the field validation pass on the allowed requests.
+	allRulesPassed := len(validator.PlacementValidationRuleNames()) + 1
 	invalidCRPObject := &placementv1beta1.ClusterResourcePlacement{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:       "test-crp",
@@ -190,6 +203,13 @@ func TestHandle(t *testing.T) {
 		},
 	}
 
+	metadataUpdatedInvalidCRPObject := invalidCRPObject.DeepCopy()
+	metadataUpdatedInvalidCRPObject.Labels = map[string]string{"key1": "value1"}
+	metadataUpdatedInvalidCRPObject.Annotations = map[string]string{"key2": "value2"}
+
+	oversizedAnnotationInvalidCRPObject := invalidCRPObject.DeepCopy()
+	oversizedAnnotationInvalidCRPObject.Annotations = map[string]string{"manifest": strings.Repeat("a", 64*1024+1)}
+
 	updatedLabelInvalidCRPObject := &placementv1beta1.ClusterResourcePlacement{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:       "test-crp",
@@ -223,32 +243,12 @@ func TestHandle(t *testing.T) {
 		},
 	}
 
-	validCRPObjectBytes, err := json.Marshal(validCRPObject)
-	assert.Nil(t, err)
-	invalidCRPObjectBytes, err := json.Marshal(invalidCRPObject)
-	assert.Nil(t, err)
-	invalidCRPObjectDeletingFinalizersRemovedBytes, err := json.Marshal(invalidCRPObjectDeletingFinalizersRemoved)
-	assert.Nil(t, err)
-	invalidCRPObjectFinalizersRemovedBytes, err := json.Marshal(invalidCRPObjectFinalizersRemoved)
-	assert.Nil(t, err)
-	invalidCRPObjectDeletingBytes, err := json.Marshal(invalidCRPObjectDeleting)
-	assert.Nil(t, err)
-	updatedValidSpecCRPObjectBytes, err := json.Marshal(updatedValidSpecCRPObject)
-	assert.Nil(t, err)
-	updatedValidSpecCRPObjectDeletingFinalizerRemovedBytes, err := json.Marshal(updatedValidSpecCRPObjectDeletingFinalizerRemoved)
-	assert.Nil(t, err)
-	updatedLabelInvalidCRPObjectBytes, err := json.Marshal(updatedLabelInvalidCRPObject)
-	assert.Nil(t, err)
-	updatedPlacementTypeCRPObjectBytes, err := json.Marshal(updatedPlacementTypeCRPObject)
-	assert.Nil(t, err)
-	validCRPObjectWithTolerationsBytes, err := json.Marshal(validCRPObjectWithTolerations)
-	assert.Nil(t, err)
-
-	scheme := runtime.NewScheme()
-	err = placementv1beta1.AddToScheme(scheme)
-	assert.Nil(t, err)
-	decoder := admission.NewDecoder(scheme)
-	assert.Nil(t, err)
+	currentGenerationCRPObject := validCRPObject.DeepCopy()
+	currentGenerationCRPObject.Generation = 3
+	staleGenerationCRPObject := validCRPObject.DeepCopy()
+	staleGenerationCRPObject.Generation = 2
+
+	decoder := admission.NewDecoder(webhooktesting.Scheme)
 
 	testCases := map[string]struct {
 		req               admission.Request
@@ -257,345 +257,198 @@ func TestHandle(t *testing.T) {
 		wantResponse      admission.Response
 	}{
 		"allow CRP create": {
-			req: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Name: "test-crp",
-					Object: runtime.RawExtension{
-						Raw:    validCRPObjectBytes,
-						Object: validCRPObject,
-					},
-					UserInfo: authenticationv1.UserInfo{
-						Username: "test-user",
-						Groups:   []string{"system:masters"},
-					},
-					RequestKind: &utils.ClusterResourcePlacementMetaGVK,
-					Operation:   admissionv1.Create,
-				},
-			},
+			req: webhooktesting.NewCreateRequest(validCRPObject, webhooktesting.WithUserInfo(testUserInfo)),
 			resourceInformer: &testinformer.FakeManager{
 				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
 				IsClusterScopedResource: true,
 			},
 			resourceValidator: clusterResourcePlacementValidator{
-				decoder: decoder,
+				client:  webhooktesting.NewFakeClientBuilder().Build(),
+				decoder: NewVersionedDecoder(decoder),
 			},
-			wantResponse: admission.Allowed(fmt.Sprintf(validator.AllowModifyFmt, "CRP")),
+			wantResponse: admission.Allowed(validator.AllowedMessage("v1beta1 CRP", allRulesPassed, 0)),
 		},
 		"deny CRP create - invalid CRP object": {
-			req: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Name: "test-crp",
-					Object: runtime.RawExtension{
-						Raw:    invalidCRPObjectBytes,
-						Object: invalidCRPObject,
-					},
-					UserInfo: authenticationv1.UserInfo{
-						Username: "test-user",
-						Groups:   []string{"system:masters"},
-					},
-					RequestKind: &utils.ClusterResourcePlacementMetaGVK,
-					Operation:   admissionv1.Create,
-				},
-			},
+			req: webhooktesting.NewCreateRequest(invalidCRPObject, webhooktesting.WithUserInfo(testUserInfo)),
 			resourceInformer: &testinformer.FakeManager{
 				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
 				IsClusterScopedResource: true,
 			},
 			resourceValidator: clusterResourcePlacementValidator{
-				decoder: decoder,
+				decoder: NewVersionedDecoder(decoder),
 			},
 			wantResponse: admission.Denied(fmt.Sprintf(validator.DenyCreateUpdateInvalidFmt, "CRP", errString)),
 		},
 		"allow CRP update - valid update": {
-			req: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Name: "test-crp",
-					OldObject: runtime.RawExtension{
-						Raw:    validCRPObjectBytes,
-						Object: validCRPObject,
-					},
-					Object: runtime.RawExtension{
-						Raw:    updatedValidSpecCRPObjectBytes,
-						Object: updatedValidSpecCRPObject,
-					},
-					UserInfo: authenticationv1.UserInfo{
-						Username: "test-user",
-						Groups:   []string{"system:masters"},
-					},
-					RequestKind: &utils.ClusterResourcePlacementMetaGVK,
-					Operation:   admissionv1.Update,
-				},
-			},
+			req: webhooktesting.NewUpdateRequest(validCRPObject, updatedValidSpecCRPObject, webhooktesting.WithUserInfo(testUserInfo)),
 			resourceInformer: &testinformer.FakeManager{
 				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
 				IsClusterScopedResource: true,
 			},
 			resourceValidator: clusterResourcePlacementValidator{
-				decoder: decoder,
+				decoder: NewVersionedDecoder(decoder),
 			},
-			wantResponse: admission.Allowed(fmt.Sprintf(validator.AllowModifyFmt, "CRP")),
+			wantResponse: admission.Allowed(validator.AllowedMessage("v1beta1 CRP", allRulesPassed, 0)),
 		},
 		"allow CRP update - invalid old CRP object, invalid new CRP is deleting, finalizer removed": {
-			req: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Name: "test-crp",
-					OldObject: runtime.RawExtension{
-						Raw:    invalidCRPObjectBytes,
-						Object: invalidCRPObject,
-					},
-					Object: runtime.RawExtension{
-						Raw:    invalidCRPObjectDeletingFinalizersRemovedBytes,
-						Object: invalidCRPObjectDeletingFinalizersRemoved,
-					},
-					UserInfo: authenticationv1.UserInfo{
-						Username: "test-user",
-						Groups:   []string{"system:masters"},
-					},
-					RequestKind: &utils.ClusterResourcePlacementMetaGVK,
-					Operation:   admissionv1.Update,
-				},
-			},
+			req: webhooktesting.NewUpdateRequest(invalidCRPObject, invalidCRPObjectDeletingFinalizersRemoved, webhooktesting.WithUserInfo(testUserInfo)),
 			resourceInformer: &testinformer.FakeManager{
 				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
 				IsClusterScopedResource: true,
 			},
 			resourceValidator: clusterResourcePlacementValidator{
-				decoder: decoder,
+				decoder: NewVersionedDecoder(decoder),
 			},
 			wantResponse: admission.Allowed(fmt.Sprintf(validator.AllowUpdateOldInvalidFmt, "CRP")),
 		},
 		"allow CRP update - invalid old CRP object, valid new CRP is deleting, finalizer removed, spec updated": {
-			req: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Name: "test-crp",
-					OldObject: runtime.RawExtension{
-						Raw:    invalidCRPObjectBytes,
-						Object: invalidCRPObject,
-					},
-					Object: runtime.RawExtension{
-						Raw:    updatedValidSpecCRPObjectDeletingFinalizerRemovedBytes,
-						Object: updatedValidSpecCRPObjectDeletingFinalizerRemoved,
-					},
-					UserInfo: authenticationv1.UserInfo{
-						Username: "test-user",
-						Groups:   []string{"system:masters"},
-					},
-					RequestKind: &utils.ClusterResourcePlacementMetaGVK,
-					Operation:   admissionv1.Update,
-				},
-			},
+			req: webhooktesting.NewUpdateRequest(invalidCRPObject, updatedValidSpecCRPObjectDeletingFinalizerRemoved, webhooktesting.WithUserInfo(testUserInfo)),
 			resourceInformer: &testinformer.FakeManager{
 				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
 				IsClusterScopedResource: true,
 			},
 			resourceValidator: clusterResourcePlacementValidator{
-				decoder: decoder,
+				decoder: NewVersionedDecoder(decoder),
 			},
 			wantResponse: admission.Allowed(fmt.Sprintf(validator.AllowUpdateOldInvalidFmt, "CRP")),
 		},
-		"deny CRP update - invalid old CRP, invalid new CRP is not deleting, finalizer removed": {
-			req: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Name: "test-crp",
-					OldObject: runtime.RawExtension{
-						Raw:    invalidCRPObjectBytes,
-						Object: invalidCRPObject,
-					},
-					Object: runtime.RawExtension{
-						Raw:    invalidCRPObjectFinalizersRemovedBytes,
-						Object: invalidCRPObjectFinalizersRemoved,
-					},
-					UserInfo: authenticationv1.UserInfo{
-						Username: "test-user",
-						Groups:   []string{"system:masters"},
-					},
-					RequestKind: &utils.ClusterResourcePlacementMetaGVK,
-					Operation:   admissionv1.Update,
-				},
+		"allow CRP update - invalid old CRP, invalid new CRP is not deleting, finalizer removed, spec not updated": {
+			req: webhooktesting.NewUpdateRequest(invalidCRPObject, invalidCRPObjectFinalizersRemoved, webhooktesting.WithUserInfo(testUserInfo)),
+			resourceInformer: &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
+				IsClusterScopedResource: true,
 			},
+			resourceValidator: clusterResourcePlacementValidator{
+				decoder: NewVersionedDecoder(decoder),
+			},
+			wantResponse: admission.Allowed(fmt.Sprintf(validator.AllowSpecUnchangedUpdateOldInvalidFmt, "CRP")).WithWarnings(fmt.Sprintf(validator.WarnOldInvalidFmt, "CRP", errString)),
+		},
+		"allow CRP update - invalid old CRP, invalid new CRP is not deleting, only metadata updated": {
+			req: webhooktesting.NewUpdateRequest(invalidCRPObject, metadataUpdatedInvalidCRPObject, webhooktesting.WithUserInfo(testUserInfo)),
 			resourceInformer: &testinformer.FakeManager{
 				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
 				IsClusterScopedResource: true,
 			},
 			resourceValidator: clusterResourcePlacementValidator{
-				decoder: decoder,
+				decoder: NewVersionedDecoder(decoder),
 			},
-			wantResponse: admission.Denied(fmt.Sprintf(validator.DenyUpdateOldInvalidFmt, "CRP", errString)),
+			wantResponse: admission.Allowed(fmt.Sprintf(validator.AllowSpecUnchangedUpdateOldInvalidFmt, "CRP")).WithWarnings(fmt.Sprintf(validator.WarnOldInvalidFmt, "CRP", errString)),
 		},
-		"allow CRP update - invalid old CRP, invalid new CRP is deleting, finalizer not removed": {
-			req: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Name: "test-crp",
-					OldObject: runtime.RawExtension{
-						Raw:    invalidCRPObjectBytes,
-						Object: invalidCRPObject,
-					},
-					Object: runtime.RawExtension{
-						Raw:    invalidCRPObjectDeletingBytes,
-						Object: invalidCRPObjectDeleting,
-					},
-					UserInfo: authenticationv1.UserInfo{
-						Username: "test-user",
-						Groups:   []string{"system:masters"},
-					},
-					RequestKind: &utils.ClusterResourcePlacementMetaGVK,
-					Operation:   admissionv1.Update,
-				},
+		"deny CRP update - invalid old CRP, invalid new CRP is not deleting, oversized annotation added": {
+			req: webhooktesting.NewUpdateRequest(invalidCRPObject, oversizedAnnotationInvalidCRPObject, webhooktesting.WithUserInfo(testUserInfo)),
+			resourceInformer: &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
+				IsClusterScopedResource: true,
 			},
+			resourceValidator: clusterResourcePlacementValidator{
+				decoder: NewVersionedDecoder(decoder),
+			},
+			wantResponse: admission.Denied(fmt.Sprintf("the value of annotation %q is %d bytes, which exceeds the limit of %d bytes; embedding manifests in placement annotations is not allowed", "manifest", 64*1024+1, 64*1024)),
+		},
+		"allow CRP update - invalid old CRP, invalid new CRP is deleting, finalizer not removed": {
+			req: webhooktesting.NewUpdateRequest(invalidCRPObject, invalidCRPObjectDeleting, webhooktesting.WithUserInfo(testUserInfo)),
 			resourceInformer: &testinformer.FakeManager{
 				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
 				IsClusterScopedResource: true,
 			},
 			resourceValidator: clusterResourcePlacementValidator{
-				decoder: decoder,
+				decoder: NewVersionedDecoder(decoder),
 			},
 			wantResponse: admission.Allowed(fmt.Sprintf(validator.AllowUpdateOldInvalidFmt, "CRP")),
 		},
 		"deny CRP update - invalid old CRP, invalid new CRP, label is updated": {
-			req: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Name: "test-crp",
-					OldObject: runtime.RawExtension{
-						Raw:    invalidCRPObjectBytes,
-						Object: invalidCRPObject,
-					},
-					Object: runtime.RawExtension{
-						Raw:    updatedLabelInvalidCRPObjectBytes,
-						Object: updatedLabelInvalidCRPObject,
-					},
-					UserInfo: authenticationv1.UserInfo{
-						Username: "test-user",
-						Groups:   []string{"system:masters"},
-					},
-					RequestKind: &utils.ClusterResourcePlacementMetaGVK,
-					Operation:   admissionv1.Update,
-				},
-			},
+			req: webhooktesting.NewUpdateRequest(invalidCRPObject, updatedLabelInvalidCRPObject, webhooktesting.WithUserInfo(testUserInfo)),
 			resourceInformer: &testinformer.FakeManager{
 				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
 				IsClusterScopedResource: true,
 			},
 			resourceValidator: clusterResourcePlacementValidator{
-				decoder: decoder,
+				decoder: NewVersionedDecoder(decoder),
 			},
 			wantResponse: admission.Denied(fmt.Sprintf(validator.DenyUpdateOldInvalidFmt, "CRP", errString)),
 		},
 		"deny CRP update - invalid old CRP, valid new CRP, spec updated": {
-			req: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Name: "test-crp",
-					OldObject: runtime.RawExtension{
-						Raw:    invalidCRPObjectBytes,
-						Object: invalidCRPObject,
-					},
-					Object: runtime.RawExtension{
-						Raw:    updatedValidSpecCRPObjectBytes,
-						Object: updatedValidSpecCRPObject,
-					},
-					UserInfo: authenticationv1.UserInfo{
-						Username: "test-user",
-						Groups:   []string{"system:masters"},
-					},
-					RequestKind: &utils.ClusterResourcePlacementMetaGVK,
-					Operation:   admissionv1.Update,
-				},
-			},
+			req: webhooktesting.NewUpdateRequest(invalidCRPObject, updatedValidSpecCRPObject, webhooktesting.WithUserInfo(testUserInfo)),
 			resourceInformer: &testinformer.FakeManager{
 				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
 				IsClusterScopedResource: true,
 			},
 			resourceValidator: clusterResourcePlacementValidator{
-				decoder: decoder,
+				decoder: NewVersionedDecoder(decoder),
 			},
 			wantResponse: admission.Denied(fmt.Sprintf(validator.DenyUpdateOldInvalidFmt, "CRP", errString)),
 		},
 		"deny CRP update - valid old CRP, invalid new CRP, spec updated": {
-			req: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Name: "test-crp",
-					OldObject: runtime.RawExtension{
-						Raw:    validCRPObjectBytes,
-						Object: validCRPObject,
-					},
-					Object: runtime.RawExtension{
-						Raw:    invalidCRPObjectBytes,
-						Object: invalidCRPObject,
-					},
-					UserInfo: authenticationv1.UserInfo{
-						Username: "test-user",
-						Groups:   []string{"system:masters"},
-					},
-					RequestKind: &utils.ClusterResourcePlacementMetaGVK,
-					Operation:   admissionv1.Update,
-				},
-			},
+			req: webhooktesting.NewUpdateRequest(validCRPObject, invalidCRPObject, webhooktesting.WithUserInfo(testUserInfo)),
 			resourceInformer: &testinformer.FakeManager{
 				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
 				IsClusterScopedResource: true,
 			},
 			resourceValidator: clusterResourcePlacementValidator{
-				decoder: decoder,
+				decoder: NewVersionedDecoder(decoder),
 			},
 			wantResponse: admission.Denied(fmt.Sprintf(validator.DenyCreateUpdateInvalidFmt, "CRP", errString)),
 		},
 		"deny CRP update - new CRP immutable placement type": {
-			req: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Name: "test-crp",
-					OldObject: runtime.RawExtension{
-						Raw:    validCRPObjectBytes,
-						Object: validCRPObject,
-					},
-					Object: runtime.RawExtension{
-						Raw:    updatedPlacementTypeCRPObjectBytes,
-						Object: updatedPlacementTypeCRPObject,
-					},
-					UserInfo: authenticationv1.UserInfo{
-						Username: "test-user",
-						Groups:   []string{"system:masters"},
-					},
-					RequestKind: &utils.ClusterResourcePlacementMetaGVK,
-					Operation:   admissionv1.Update,
-				},
-			},
+			req: webhooktesting.NewUpdateRequest(validCRPObject, updatedPlacementTypeCRPObject, webhooktesting.WithUserInfo(testUserInfo)),
 			resourceInformer: &testinformer.FakeManager{
 				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
 				IsClusterScopedResource: true,
 			},
 			resourceValidator: clusterResourcePlacementValidator{
-				decoder: decoder,
+				decoder: NewVersionedDecoder(decoder),
 			},
 			wantResponse: admission.Denied("placement type is immutable"),
 		},
 		"deny CRP update - new CRP tolerations updated": {
-			req: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Name: "test-crp",
-					OldObject: runtime.RawExtension{
-						Raw:    validCRPObjectWithTolerationsBytes,
-						Object: validCRPObjectWithTolerations,
-					},
-					Object: runtime.RawExtension{
-						Raw:    validCRPObjectBytes,
-						Object: validCRPObject,
-					},
-					UserInfo: authenticationv1.UserInfo{
-						Username: "test-user",
-						Groups:   []string{"system:masters"},
-					},
-					RequestKind: &utils.ClusterResourcePlacementMetaGVK,
-					Operation:   admissionv1.Update,
-				},
-			},
+			req: webhooktesting.NewUpdateRequest(validCRPObjectWithTolerations, validCRPObject, webhooktesting.WithUserInfo(testUserInfo)),
 			resourceInformer: &testinformer.FakeManager{
 				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
 				IsClusterScopedResource: true,
 			},
 			resourceValidator: clusterResourcePlacementValidator{
-				decoder: decoder,
+				decoder: NewVersionedDecoder(decoder),
 			},
 			wantResponse: admission.Denied("tolerations have been updated/deleted, only additions to tolerations are allowed"),
 		},
+		"deny CRP update - new CRP has stale generation": {
+			req: webhooktesting.NewUpdateRequest(currentGenerationCRPObject, staleGenerationCRPObject, webhooktesting.WithUserInfo(testUserInfo)),
+			resourceInformer: &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
+				IsClusterScopedResource: true,
+			},
+			resourceValidator: clusterResourcePlacementValidator{
+				decoder: NewVersionedDecoder(decoder),
+			},
+			wantResponse: admission.Denied("stale object: generation 2 is less than current 3, please re-fetch before updating"),
+		},
+		"allow CRP connect": {
+			req: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Name:        "test-crp",
+					Operation:   admissionv1.Connect,
+					SubResource: "proxy",
+					UserInfo:    testUserInfo,
+				},
+			},
+			resourceValidator: clusterResourcePlacementValidator{
+				decoder: NewVersionedDecoder(decoder),
+			},
+			wantResponse: admission.Allowed(validator.AllowedMessage("CONNECT of v1beta1 CRP", 0, 0)),
+		},
+		"allow CRP unknown operation": {
+			req: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Name:      "test-crp",
+					Operation: admissionv1.Operation("PATCH"),
+					UserInfo:  testUserInfo,
+				},
+			},
+			resourceValidator: clusterResourcePlacementValidator{
+				decoder: NewVersionedDecoder(decoder),
+			},
+			wantResponse: admission.Allowed("unknown operation"),
+		},
 	}
 
 	for testName, testCase := range testCases {
@@ -607,3 +460,838 @@ func TestHandle(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleCreateWithOwnedPolicySnapshots(t *testing.T) {
+	crp := &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-crp"},
+		Spec: placementv1beta1.PlacementSpec{
+			ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{resourceSelector},
+			Policy: &placementv1beta1.PlacementPolicy{
+				PlacementType: placementv1beta1.PickAllPlacementType,
+			},
+		},
+	}
+	newSnapshot := func(name, trackedCRP string, ownerKind, ownerName string) *placementv1beta1.ClusterSchedulingPolicySnapshot {
+		snapshot := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{placementv1beta1.PlacementTrackingLabel: trackedCRP},
+			},
+		}
+		if ownerName != "" {
+			snapshot.OwnerReferences = []metav1.OwnerReference{
+				{
+					APIVersion: placementv1beta1.GroupVersion.String(),
+					Kind:       ownerKind,
+					Name:       ownerName,
+					UID:        "old-crp-uid",
+				},
+			}
+		}
+		return snapshot
+	}
+
+	testCases := map[string]struct {
+		snapshots         []client.Object
+		listErr           error
+		wantAllowed       bool
+		wantDeniedMessage string
+		wantErrored       bool
+	}{
+		"no snapshots": {
+			wantAllowed: true,
+		},
+		"snapshots of another CRP": {
+			snapshots: []client.Object{
+				newSnapshot("other-crp-0", "other-crp", placementv1beta1.ClusterResourcePlacementKind, "other-crp"),
+			},
+			wantAllowed: true,
+		},
+		"tracked snapshot without an owner reference": {
+			snapshots: []client.Object{
+				newSnapshot("test-crp-0", "test-crp", "", ""),
+			},
+			wantAllowed: true,
+		},
+		"tracked snapshot owned by another kind": {
+			snapshots: []client.Object{
+				newSnapshot("test-crp-0", "test-crp", "ResourcePlacement", "test-crp"),
+			},
+			wantAllowed: true,
+		},
+		"snapshots owned by a previous CRP of the same name": {
+			snapshots: []client.Object{
+				newSnapshot("test-crp-1", "test-crp", placementv1beta1.ClusterResourcePlacementKind, "test-crp"),
+				newSnapshot("test-crp-0", "test-crp", placementv1beta1.ClusterResourcePlacementKind, "test-crp"),
+				newSnapshot("other-crp-0", "other-crp", placementv1beta1.ClusterResourcePlacementKind, "other-crp"),
+			},
+			wantDeniedMessage: "clusterSchedulingPolicySnapshot(s) test-crp-0, test-crp-1 are still owned by a previously deleted clusterResourcePlacement named test-crp",
+		},
+		"failed to list snapshots": {
+			listErr:     errors.New("list failed"),
+			wantErrored: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			validator.RestMapper = utils.TestMapper{}
+			validator.ResourceInformer = &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
+				IsClusterScopedResource: true,
+			}
+			builder := webhooktesting.NewFakeClientBuilder().WithObjects(tc.snapshots...)
+			if tc.listErr != nil {
+				builder = builder.WithInterceptorFuncs(interceptor.Funcs{
+					List: func(context.Context, client.WithWatch, client.ObjectList, ...client.ListOption) error {
+						return tc.listErr
+					},
+				})
+			}
+			v := clusterResourcePlacementValidator{
+				client:  builder.Build(),
+				decoder: NewVersionedDecoder(admission.NewDecoder(webhooktesting.Scheme)),
+			}
+
+			resp := v.Handle(context.Background(), webhooktesting.NewCreateRequest(crp, webhooktesting.WithUserInfo(testUserInfo)))
+			switch {
+			case tc.wantAllowed:
+				webhooktesting.AssertAllowed(t, resp)
+			case tc.wantErrored:
+				if resp.Allowed || resp.Result.Code != http.StatusInternalServerError {
+					t.Errorf("Handle() = %+v, want an internal server error", resp.Result)
+				}
+			default:
+				webhooktesting.AssertDenied(t, resp, tc.wantDeniedMessage)
+			}
+		})
+	}
+}
+
+func TestHandleCreateWithRequiredLabels(t *testing.T) {
+	config := validator.NewConfigStore(validator.Config{RequiredLabels: map[string]*regexp.Regexp{
+		"fleet.azure.com/team": regexp.MustCompile(`^[a-z]+$`),
+	}})
+
+	newCRP := func(labels map[string]string) *placementv1beta1.ClusterResourcePlacement {
+		return &placementv1beta1.ClusterResourcePlacement{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-crp", Labels: labels},
+			Spec: placementv1beta1.PlacementSpec{
+				ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{resourceSelector},
+			},
+		}
+	}
+	testCases := map[string]struct {
+		req               admission.Request
+		wantDeniedMessage string
+	}{
+		"create with the required label": {
+			req: webhooktesting.NewCreateRequest(newCRP(map[string]string{"fleet.azure.com/team": "billing"}), webhooktesting.WithUserInfo(testUserInfo)),
+		},
+		"create without the required label": {
+			req:               webhooktesting.NewCreateRequest(newCRP(nil), webhooktesting.WithUserInfo(testUserInfo)),
+			wantDeniedMessage: `the required label "fleet.azure.com/team" is missing`,
+		},
+		"create with a non-matching required label": {
+			req:               webhooktesting.NewCreateRequest(newCRP(map[string]string{"fleet.azure.com/team": "Billing"}), webhooktesting.WithUserInfo(testUserInfo)),
+			wantDeniedMessage: `the value "Billing" of the required label "fleet.azure.com/team" does not match`,
+		},
+		"update of an existing CRP without the required label": {
+			req: webhooktesting.NewUpdateRequest(newCRP(nil), newCRP(map[string]string{"app": "test"}), webhooktesting.WithUserInfo(testUserInfo)),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			validator.RestMapper = utils.TestMapper{}
+			validator.ResourceInformer = &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
+				IsClusterScopedResource: true,
+			}
+			v := clusterResourcePlacementValidator{
+				client:  webhooktesting.NewFakeClientBuilder().Build(),
+				decoder: NewVersionedDecoder(admission.NewDecoder(webhooktesting.Scheme)),
+				config:  config,
+			}
+			resp := v.Handle(context.Background(), tc.req)
+			if tc.wantDeniedMessage == "" {
+				webhooktesting.AssertAllowed(t, resp)
+				return
+			}
+			webhooktesting.AssertDenied(t, resp, tc.wantDeniedMessage)
+		})
+	}
+}
+
+func TestHandlePolicyFieldConflicts(t *testing.T) {
+	newCRP := func(policy *placementv1beta1.PlacementPolicy) *placementv1beta1.ClusterResourcePlacement {
+		return &placementv1beta1.ClusterResourcePlacement{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-crp"},
+			Spec: placementv1beta1.PlacementSpec{
+				ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{resourceSelector},
+				Policy:            policy,
+			},
+		}
+	}
+	testCases := map[string]struct {
+		policy             *placementv1beta1.PlacementPolicy
+		wantDeniedMessages []string
+	}{
+		"PickFixed with cluster names only": {
+			policy: &placementv1beta1.PlacementPolicy{PlacementType: placementv1beta1.PickFixedPlacementType, ClusterNames: []string{"member-1"}},
+		},
+		"PickFixed with affinity": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType: placementv1beta1.PickFixedPlacementType,
+				ClusterNames:  []string{"member-1"},
+				Affinity:      &placementv1beta1.Affinity{},
+			},
+			wantDeniedMessages: []string{"spec.policy.affinity: Forbidden: affinity must be nil for policy type PickFixed, only valid for PickAll/PickN placement policy types; PickFixed places the resources on exactly the clusters in clusterNames"},
+		},
+		"PickFixed with topology spread constraints": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:             placementv1beta1.PickFixedPlacementType,
+				ClusterNames:              []string{"member-1"},
+				TopologySpreadConstraints: []placementv1beta1.TopologySpreadConstraint{{TopologyKey: "region"}},
+			},
+			wantDeniedMessages: []string{"spec.policy.topologySpreadConstraints: Forbidden: topology spread constraints needs to be empty for policy type PickFixed"},
+		},
+		"PickFixed with tolerations": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType: placementv1beta1.PickFixedPlacementType,
+				ClusterNames:  []string{"member-1"},
+				Tolerations:   []placementv1beta1.Toleration{{Key: "key1", Operator: corev1.TolerationOpExists}},
+			},
+			wantDeniedMessages: []string{"spec.policy.tolerations: Forbidden: tolerations needs to be empty for policy type PickFixed"},
+		},
+		"PickFixed with every conflicting field": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:             placementv1beta1.PickFixedPlacementType,
+				ClusterNames:              []string{"member-1"},
+				NumberOfClusters:          ptr.To(int32(1)),
+				Affinity:                  &placementv1beta1.Affinity{},
+				TopologySpreadConstraints: []placementv1beta1.TopologySpreadConstraint{{TopologyKey: "region"}},
+				Tolerations:               []placementv1beta1.Toleration{{Key: "key1", Operator: corev1.TolerationOpExists}},
+			},
+			wantDeniedMessages: []string{
+				"spec.policy.numberOfClusters: Forbidden",
+				"spec.policy.affinity: Forbidden",
+				"spec.policy.topologySpreadConstraints: Forbidden",
+				"spec.policy.tolerations: Forbidden",
+			},
+		},
+		"PickAll with cluster names": {
+			policy: &placementv1beta1.PlacementPolicy{PlacementType: placementv1beta1.PickAllPlacementType, ClusterNames: []string{"member-1"}},
+			wantDeniedMessages: []string{"spec.policy.clusterNames: Forbidden: cluster names needs to be empty for policy type PickAll, only valid for PickFixed policy type; " +
+				"PickAll places the resources on all the clusters which match the affinity and tolerations"},
+		},
+		"PickAll with topology spread constraints": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:             placementv1beta1.PickAllPlacementType,
+				TopologySpreadConstraints: []placementv1beta1.TopologySpreadConstraint{{TopologyKey: "region"}},
+			},
+			wantDeniedMessages: []string{"spec.policy.topologySpreadConstraints: Forbidden: topology spread constraints needs to be empty for policy type PickAll"},
+		},
+		"PickN with cluster names": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:    placementv1beta1.PickNPlacementType,
+				NumberOfClusters: ptr.To(int32(1)),
+				ClusterNames:     []string{"member-1"},
+			},
+			wantDeniedMessages: []string{"spec.policy.clusterNames: Forbidden: cluster names needs to be empty for policy type PickN, only valid for PickFixed policy type; PickN places the resources"},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			validator.RestMapper = utils.TestMapper{}
+			validator.ResourceInformer = &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
+				IsClusterScopedResource: true,
+			}
+			v := clusterResourcePlacementValidator{
+				client:  webhooktesting.NewFakeClientBuilder().Build(),
+				decoder: NewVersionedDecoder(admission.NewDecoder(webhooktesting.Scheme)),
+			}
+			resp := v.Handle(context.Background(), webhooktesting.NewCreateRequest(newCRP(tc.policy), webhooktesting.WithUserInfo(testUserInfo)))
+			if len(tc.wantDeniedMessages) == 0 {
+				webhooktesting.AssertAllowed(t, resp)
+				return
+			}
+			for _, want := range tc.wantDeniedMessages {
+				webhooktesting.AssertDenied(t, resp, want)
+			}
+		})
+	}
+}
+
+// fakeQuotaEnforcer returns the configured quota and records the teams it is checked for.
+type fakeQuotaEnforcer struct {
+	remaining  int
+	err        error
+	checkedFor []string
+}
+
+func (f *fakeQuotaEnforcer) CheckQuota(_ context.Context, teamLabel string) (int, error) {
+	f.checkedFor = append(f.checkedFor, teamLabel)
+	return f.remaining, f.err
+}
+
+func TestHandleCreateWithTeamQuota(t *testing.T) {
+	newCRP := func(labels map[string]string) *placementv1beta1.ClusterResourcePlacement {
+		return &placementv1beta1.ClusterResourcePlacement{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-crp", Labels: labels},
+			Spec: placementv1beta1.PlacementSpec{
+				ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{resourceSelector},
+			},
+		}
+	}
+	teamLabels := map[string]string{TeamLabel: "billing"}
+	testCases := map[string]struct {
+		req               admission.Request
+		enforcer          *fakeQuotaEnforcer
+		wantDeniedMessage string
+		wantErrored       bool
+		wantCheckedFor    []string
+	}{
+		"create with quota left": {
+			req:            webhooktesting.NewCreateRequest(newCRP(teamLabels), webhooktesting.WithUserInfo(testUserInfo)),
+			enforcer:       &fakeQuotaEnforcer{remaining: 1},
+			wantCheckedFor: []string{"billing"},
+		},
+		"create with the quota exhausted": {
+			req:               webhooktesting.NewCreateRequest(newCRP(teamLabels), webhooktesting.WithUserInfo(testUserInfo)),
+			enforcer:          &fakeQuotaEnforcer{remaining: 0},
+			wantDeniedMessage: `the CRP quota of team "billing" (label fleet.azure.com/team) is exhausted with 0 CRP(s) remaining`,
+			wantCheckedFor:    []string{"billing"},
+		},
+		"create when the quota check fails": {
+			req:            webhooktesting.NewCreateRequest(newCRP(teamLabels), webhooktesting.WithUserInfo(testUserInfo)),
+			enforcer:       &fakeQuotaEnforcer{err: errors.New("cache is not synced")},
+			wantErrored:    true,
+			wantCheckedFor: []string{"billing"},
+		},
+		"create without the team label": {
+			req:      webhooktesting.NewCreateRequest(newCRP(nil), webhooktesting.WithUserInfo(testUserInfo)),
+			enforcer: &fakeQuotaEnforcer{remaining: 0},
+		},
+		"update with the quota exhausted": {
+			req:      webhooktesting.NewUpdateRequest(newCRP(teamLabels), newCRP(teamLabels), webhooktesting.WithUserInfo(testUserInfo)),
+			enforcer: &fakeQuotaEnforcer{remaining: 0},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			validator.RestMapper = utils.TestMapper{}
+			validator.ResourceInformer = &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
+				IsClusterScopedResource: true,
+			}
+			v := clusterResourcePlacementValidator{
+				client:        webhooktesting.NewFakeClientBuilder().Build(),
+				decoder:       NewVersionedDecoder(admission.NewDecoder(webhooktesting.Scheme)),
+				quotaEnforcer: tc.enforcer,
+			}
+			resp := v.Handle(context.Background(), tc.req)
+			switch {
+			case tc.wantErrored:
+				if resp.Allowed || resp.Result == nil || resp.Result.Code != http.StatusInternalServerError {
+					t.Errorf("Handle() = %+v, want an internal server error", resp.Result)
+				}
+			case tc.wantDeniedMessage != "":
+				webhooktesting.AssertDenied(t, resp, tc.wantDeniedMessage)
+			default:
+				webhooktesting.AssertAllowed(t, resp)
+			}
+			if diff := cmp.Diff(tc.wantCheckedFor, tc.enforcer.checkedFor); diff != "" {
+				t.Errorf("CheckQuota() teams mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// fakePolicySimulator returns the configured cluster count and records the CRPs it simulates.
+type fakePolicySimulator struct {
+	count     int
+	err       error
+	simulated []string
+}
+
+func (f *fakePolicySimulator) MatchingClusterCount(_ context.Context, crp *placementv1beta1.ClusterResourcePlacement) (int, error) {
+	f.simulated = append(f.simulated, crp.Name)
+	return f.count, f.err
+}
+
+func TestHandleCreateWithNoMatchingClusters(t *testing.T) {
+	crp := &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-crp"},
+		Spec: placementv1beta1.PlacementSpec{
+			ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{resourceSelector},
+		},
+	}
+	invalidCRP := crp.DeepCopy()
+	invalidCRP.Spec.Strategy.RollingUpdate = &placementv1beta1.RollingUpdateConfig{
+		MaxUnavailable: &intstr.IntOrString{Type: intstr.Int, IntVal: -1},
+	}
+	testCases := map[string]struct {
+		req               admission.Request
+		simulator         *fakePolicySimulator
+		wantDeniedMessage string
+		wantWarnings      []string
+		wantSimulated     []string
+	}{
+		"create matching clusters": {
+			req:           webhooktesting.NewCreateRequest(crp, webhooktesting.WithUserInfo(testUserInfo)),
+			simulator:     &fakePolicySimulator{count: 2},
+			wantSimulated: []string{"test-crp"},
+		},
+		"create matching no cluster is allowed with a warning": {
+			req:           webhooktesting.NewCreateRequest(crp, webhooktesting.WithUserInfo(testUserInfo)),
+			simulator:     &fakePolicySimulator{count: 0},
+			wantWarnings:  []string{"policy matches 0 current clusters"},
+			wantSimulated: []string{"test-crp"},
+		},
+		"create when the simulation fails": {
+			req:           webhooktesting.NewCreateRequest(crp, webhooktesting.WithUserInfo(testUserInfo)),
+			simulator:     &fakePolicySimulator{err: errors.New("cache is not synced")},
+			wantSimulated: []string{"test-crp"},
+		},
+		"denied create is not simulated": {
+			req:               webhooktesting.NewCreateRequest(invalidCRP, webhooktesting.WithUserInfo(testUserInfo)),
+			simulator:         &fakePolicySimulator{count: 0},
+			wantDeniedMessage: errString,
+		},
+		"update is not simulated": {
+			req:       webhooktesting.NewUpdateRequest(crp, crp, webhooktesting.WithUserInfo(testUserInfo)),
+			simulator: &fakePolicySimulator{count: 0},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			validator.RestMapper = utils.TestMapper{}
+			validator.ResourceInformer = &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
+				IsClusterScopedResource: true,
+			}
+			v := clusterResourcePlacementValidator{
+				client:          webhooktesting.NewFakeClientBuilder().Build(),
+				decoder:         NewVersionedDecoder(admission.NewDecoder(webhooktesting.Scheme)),
+				policySimulator: tc.simulator,
+			}
+			resp := v.Handle(context.Background(), tc.req)
+			if tc.wantDeniedMessage != "" {
+				webhooktesting.AssertDenied(t, resp, tc.wantDeniedMessage)
+			} else {
+				webhooktesting.AssertAllowed(t, resp)
+			}
+			if diff := cmp.Diff(tc.wantWarnings, resp.Warnings); diff != "" {
+				t.Errorf("Handle() warnings mismatch (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantSimulated, tc.simulator.simulated); diff != "" {
+				t.Errorf("MatchingClusterCount() CRPs mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// fakeConflictDetector returns the configured conflicts and records the CRPs it checks.
+type fakeConflictDetector struct {
+	conflicts []OverrideConflict
+	err       error
+	checked   []string
+}
+
+func (f *fakeConflictDetector) DetectConflicts(_ context.Context, crp *placementv1beta1.ClusterResourcePlacement) ([]OverrideConflict, error) {
+	f.checked = append(f.checked, crp.Name)
+	return f.conflicts, f.err
+}
+
+func TestHandleWithOverrideConflicts(t *testing.T) {
+	crp := &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-crp"},
+		Spec: placementv1beta1.PlacementSpec{
+			ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{resourceSelector},
+		},
+	}
+	relabeledCRP := crp.DeepCopy()
+	relabeledCRP.Labels = map[string]string{"env": "prod"}
+	reselectedCRP := crp.DeepCopy()
+	reselectedCRP.Spec.ResourceSelectors = append(reselectedCRP.Spec.ResourceSelectors, placementv1beta1.ResourceSelectorTerm{Version: "v1", Kind: "Namespace", Name: "app"})
+	conflict := OverrideConflict{
+		Placement:            "crp-a",
+		Cluster:              "member-1",
+		Resource:             "ClusterRole.rbac.authorization.k8s.io/test-cluster-role",
+		ConflictingOverrides: []string{"cro-a", "cro-b"},
+	}
+	manyConflicts := make([]OverrideConflict, 12)
+	for i := range manyConflicts {
+		manyConflicts[i] = conflict
+		manyConflicts[i].Cluster = fmt.Sprintf("member-%02d", i)
+	}
+	wantMessage := "the placement selects the same resources on the same clusters as other clusterResourcePlacement(s) with different clusterResourceOverrides, " +
+		"which conflict when the resources are applied: clusterResourcePlacement crp-a on cluster member-1 for resource ClusterRole.rbac.authorization.k8s.io/test-cluster-role (overrides none vs [cro-a, cro-b])"
+	testCases := map[string]struct {
+		req               admission.Request
+		detector          *fakeConflictDetector
+		wantDeniedMessage string
+		wantErrored       bool
+		wantChecked       []string
+	}{
+		"create without conflicts": {
+			req:         webhooktesting.NewCreateRequest(crp, webhooktesting.WithUserInfo(testUserInfo)),
+			detector:    &fakeConflictDetector{},
+			wantChecked: []string{"test-crp"},
+		},
+		"create with a conflict is denied": {
+			req:               webhooktesting.NewCreateRequest(crp, webhooktesting.WithUserInfo(testUserInfo)),
+			detector:          &fakeConflictDetector{conflicts: []OverrideConflict{conflict}},
+			wantDeniedMessage: wantMessage,
+			wantChecked:       []string{"test-crp"},
+		},
+		"create with many conflicts lists the first ones": {
+			req:               webhooktesting.NewCreateRequest(crp, webhooktesting.WithUserInfo(testUserInfo)),
+			detector:          &fakeConflictDetector{conflicts: manyConflicts},
+			wantDeniedMessage: "cluster member-09 for resource ClusterRole.rbac.authorization.k8s.io/test-cluster-role (overrides none vs [cro-a, cro-b]) and 2 more",
+			wantChecked:       []string{"test-crp"},
+		},
+		"create when the detection fails": {
+			req:         webhooktesting.NewCreateRequest(crp, webhooktesting.WithUserInfo(testUserInfo)),
+			detector:    &fakeConflictDetector{err: errors.New("cache is not synced")},
+			wantErrored: true,
+			wantChecked: []string{"test-crp"},
+		},
+		"update changing the resource selectors is checked": {
+			req:               webhooktesting.NewUpdateRequest(reselectedCRP, crp, webhooktesting.WithUserInfo(testUserInfo)),
+			detector:          &fakeConflictDetector{conflicts: []OverrideConflict{conflict}},
+			wantDeniedMessage: wantMessage,
+			wantChecked:       []string{"test-crp"},
+		},
+		"update keeping the resource selectors and policy is not checked": {
+			req:      webhooktesting.NewUpdateRequest(relabeledCRP, crp, webhooktesting.WithUserInfo(testUserInfo)),
+			detector: &fakeConflictDetector{conflicts: []OverrideConflict{conflict}},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			validator.RestMapper = utils.TestMapper{}
+			validator.ResourceInformer = &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true, utils.NamespaceGVK: true},
+				IsClusterScopedResource: true,
+			}
+			v := clusterResourcePlacementValidator{
+				client:           webhooktesting.NewFakeClientBuilder().Build(),
+				decoder:          NewVersionedDecoder(admission.NewDecoder(webhooktesting.Scheme)),
+				conflictDetector: tc.detector,
+			}
+			resp := v.Handle(context.Background(), tc.req)
+			switch {
+			case tc.wantDeniedMessage != "":
+				if resp.Allowed || resp.Result == nil || !strings.Contains(resp.Result.Message, tc.wantDeniedMessage) {
+					t.Errorf("Handle() = %+v, want denied with a message containing %q", resp.Result, tc.wantDeniedMessage)
+				}
+			case tc.wantErrored:
+				if resp.Allowed || resp.Result == nil || resp.Result.Code != http.StatusInternalServerError {
+					t.Errorf("Handle() = %+v, want an internal server error", resp.Result)
+				}
+			default:
+				webhooktesting.AssertAllowed(t, resp)
+			}
+			if diff := cmp.Diff(tc.wantChecked, tc.detector.checked); diff != "" {
+				t.Errorf("DetectConflicts() CRPs mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHandleCreateWithExhaustedLookupBudget(t *testing.T) {
+	// admissionDeadline is the shortest timeout of the fleet webhooks.
+	admissionDeadline := time.Second
+	crp := &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-crp", Labels: map[string]string{TeamLabel: "billing"}},
+		Spec: placementv1beta1.PlacementSpec{
+			ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{resourceSelector},
+		},
+	}
+	unavailable := func(check string) string {
+		return fmt.Sprintf("validation unavailable: the %s check could not read the hub cluster within 50ms, please retry the request", check)
+	}
+	testCases := map[string]struct {
+		policy            validator.LookupFailurePolicy
+		wantDeniedMessage string
+		wantWarnings      []string
+	}{
+		"fail open": {
+			policy: validator.LookupFailOpen,
+			wantWarnings: []string{
+				unavailable(validator.TeamQuotaLookupCheck),
+				unavailable(validator.OwnedPolicySnapshotsLookupCheck),
+				unavailable(validator.PolicySimulationLookupCheck),
+			},
+		},
+		"fail closed": {
+			policy:            validator.LookupFailClosed,
+			wantDeniedMessage: unavailable(validator.TeamQuotaLookupCheck),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := validator.NewConfigStore(validator.Config{
+				MaxPlacementsPerTeam: 10,
+				LookupBudget:         50 * time.Millisecond,
+				LookupFailurePolicies: map[string]validator.LookupFailurePolicy{
+					validator.TeamQuotaLookupCheck:            tc.policy,
+					validator.OwnedPolicySnapshotsLookupCheck: tc.policy,
+					validator.PolicySimulationLookupCheck:     tc.policy,
+				},
+			})
+			validator.RestMapper = utils.TestMapper{}
+			validator.ResourceInformer = &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
+				IsClusterScopedResource: true,
+			}
+			// The hanging client never completes a read until its context is done, like a partitioned hub cache.
+			hangingClient := webhooktesting.NewFakeClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+				List: func(ctx context.Context, _ client.WithWatch, _ client.ObjectList, _ ...client.ListOption) error {
+					<-ctx.Done()
+					return ctx.Err()
+				},
+			}).Build()
+			v := clusterResourcePlacementValidator{
+				client:          hangingClient,
+				decoder:         NewVersionedDecoder(admission.NewDecoder(webhooktesting.Scheme)),
+				quotaEnforcer:   NewKubernetesCountQuotaEnforcer(hangingClient, config),
+				policySimulator: NewMemberClusterPolicySimulator(hangingClient),
+				config:          config,
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), admissionDeadline)
+			defer cancel()
+			resp := v.Handle(ctx, webhooktesting.NewCreateRequest(crp, webhooktesting.WithUserInfo(testUserInfo)))
+			if ctx.Err() != nil {
+				t.Fatalf("Handle() did not respond within the admission deadline of %s", admissionDeadline)
+			}
+			if tc.wantDeniedMessage != "" {
+				webhooktesting.AssertDenied(t, resp, tc.wantDeniedMessage)
+				if resp.Result.Reason != validator.LookupUnavailableReason {
+					t.Errorf("Handle() reason = %s, want %s", resp.Result.Reason, validator.LookupUnavailableReason)
+				}
+			} else {
+				webhooktesting.AssertAllowed(t, resp)
+			}
+			if diff := cmp.Diff(tc.wantWarnings, resp.Warnings); diff != "" {
+				t.Errorf("Handle() warnings mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHandleUnknownKind(t *testing.T) {
+	crp := &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-crp"},
+		Spec: placementv1beta1.PlacementSpec{
+			ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{resourceSelector},
+		},
+	}
+	// The version served after a CRD upgrade which the scheme of the webhook does not know.
+	unknownKind := metav1.GroupVersionKind{Group: placementv1beta1.GroupVersion.Group, Version: "v2", Kind: placementv1beta1.ClusterResourcePlacementKind}
+	testCases := map[string]struct {
+		allowUnknownKinds bool
+		wantAllowed       bool
+		wantWarnings      []string
+	}{
+		"strict": {},
+		"fail open": {
+			allowUnknownKinds: true,
+			wantAllowed:       true,
+			wantWarnings:      []string{fmt.Sprintf(validator.UnknownKindWarningFmt, "CRP", unknownKind.String())},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			v := clusterResourcePlacementValidator{
+				client:  webhooktesting.NewFakeClientBuilder().Build(),
+				decoder: NewVersionedDecoder(admission.NewDecoder(webhooktesting.Scheme)),
+				config:  validator.NewConfigStore(validator.Config{AllowUnknownKinds: tc.allowUnknownKinds}),
+			}
+			req := webhooktesting.NewCreateRequest(crp, webhooktesting.WithUserInfo(testUserInfo))
+			req.Kind = unknownKind
+			req.RequestKind = &unknownKind
+			resp := v.Handle(context.Background(), req)
+			if resp.Allowed != tc.wantAllowed {
+				t.Fatalf("Handle() allowed = %t, want %t: %v", resp.Allowed, tc.wantAllowed, resp.Result)
+			}
+			if !tc.wantAllowed && resp.Result.Code != http.StatusBadRequest {
+				t.Errorf("Handle() code = %d, want %d", resp.Result.Code, http.StatusBadRequest)
+			}
+			if diff := cmp.Diff(tc.wantWarnings, resp.Warnings); diff != "" {
+				t.Errorf("Handle() warnings mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHandleUpdateDuringUpdateRun(t *testing.T) {
+	originalReader := validator.UpdateRunReader
+	t.Cleanup(func() {
+		validator.UpdateRunReader = originalReader
+	})
+	executingRun := &placementv1beta1.ClusterStagedUpdateRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-run"},
+		Spec:       placementv1beta1.UpdateRunSpec{PlacementName: "test-crp", State: placementv1beta1.StateRun},
+		Status: placementv1beta1.UpdateRunStatus{Conditions: []metav1.Condition{
+			{Type: string(placementv1beta1.StagedUpdateRunConditionInitialized), Status: metav1.ConditionTrue, Reason: "Initialized"},
+			{Type: string(placementv1beta1.StagedUpdateRunConditionProgressing), Status: metav1.ConditionTrue, Reason: "Progressing"},
+		}},
+	}
+	validator.UpdateRunReader = webhooktesting.NewFakeClientBuilder().WithObjects(executingRun).Build()
+
+	newCRP := func(labels map[string]string, selectors ...placementv1beta1.ResourceSelectorTerm) *placementv1beta1.ClusterResourcePlacement {
+		return &placementv1beta1.ClusterResourcePlacement{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-crp", Labels: labels},
+			Spec: placementv1beta1.PlacementSpec{
+				ResourceSelectors: selectors,
+				Strategy:          placementv1beta1.RolloutStrategy{Type: placementv1beta1.ExternalRolloutStrategyType},
+			},
+		}
+	}
+	otherSelector := resourceSelector
+	otherSelector.Name = "other-cluster-role"
+	oldCRP := newCRP(nil, resourceSelector)
+	testCases := map[string]struct {
+		crp               *placementv1beta1.ClusterResourcePlacement
+		wantDeniedMessage string
+	}{
+		"spec update": {
+			crp:               newCRP(nil, resourceSelector, otherSelector),
+			wantDeniedMessage: "the placement spec cannot be updated while staged update run(s) test-run referencing the placement are in progress",
+		},
+		"metadata only update": {
+			crp: newCRP(map[string]string{"app": "test"}, resourceSelector),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			validator.RestMapper = utils.TestMapper{}
+			validator.ResourceInformer = &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
+				IsClusterScopedResource: true,
+			}
+			v := clusterResourcePlacementValidator{
+				client:  webhooktesting.NewFakeClientBuilder().Build(),
+				decoder: NewVersionedDecoder(admission.NewDecoder(webhooktesting.Scheme)),
+				config:  validator.NewConfigStore(validator.Config{DenySpecUpdatesDuringUpdateRuns: true}),
+			}
+			resp := v.Handle(context.Background(), webhooktesting.NewUpdateRequest(tc.crp, oldCRP, webhooktesting.WithUserInfo(testUserInfo)))
+			if tc.wantDeniedMessage == "" {
+				webhooktesting.AssertAllowed(t, resp)
+				return
+			}
+			webhooktesting.AssertDenied(t, resp, tc.wantDeniedMessage)
+		})
+	}
+}
+
+// fakeIncidentWindowChecker reports the configured incident window.
+type fakeIncidentWindowChecker struct {
+	inWindow bool
+	reason   string
+	err      error
+}
+
+func (c *fakeIncidentWindowChecker) IsInIncidentWindow(_ context.Context) (bool, string, error) {
+	return c.inWindow, c.reason, c.err
+}
+
+func TestHandleUpdateDuringIncidentWindow(t *testing.T) {
+	newCRP := func(annotations, labels map[string]string, selectors ...placementv1beta1.ResourceSelectorTerm) *placementv1beta1.ClusterResourcePlacement {
+		return &placementv1beta1.ClusterResourcePlacement{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-crp", Annotations: annotations, Labels: labels},
+			Spec:       placementv1beta1.PlacementSpec{ResourceSelectors: selectors},
+		}
+	}
+	otherSelector := resourceSelector
+	otherSelector.Name = "other-cluster-role"
+	oldCRP := newCRP(nil, nil, resourceSelector)
+	bypass := map[string]string{validator.IncidentBypassAnnotation: "true"}
+	incident := &fakeIncidentWindowChecker{inWindow: true, reason: "hub upgrade"}
+	testCases := map[string]struct {
+		checker           validator.IncidentWindowChecker
+		oldCRP            *placementv1beta1.ClusterResourcePlacement
+		crp               *placementv1beta1.ClusterResourcePlacement
+		wantDeniedMessage string
+		wantErrored       bool
+		wantWarning       bool
+	}{
+		"spec update during an incident window": {
+			checker:           incident,
+			crp:               newCRP(nil, nil, resourceSelector, otherSelector),
+			wantDeniedMessage: "the placement spec cannot be updated during the incident window: hub upgrade",
+		},
+		"spec update with the bypass annotation during an incident window": {
+			checker:     incident,
+			crp:         newCRP(bypass, nil, resourceSelector, otherSelector),
+			wantWarning: true,
+		},
+		"spec update with the bypass annotation left by an earlier update during an incident window": {
+			checker:           incident,
+			oldCRP:            newCRP(bypass, nil, resourceSelector),
+			crp:               newCRP(bypass, nil, resourceSelector, otherSelector),
+			wantDeniedMessage: "the placement spec cannot be updated during the incident window: hub upgrade",
+		},
+		"spec update setting the bypass annotation from another value during an incident window": {
+			checker:     incident,
+			oldCRP:      newCRP(map[string]string{validator.IncidentBypassAnnotation: "false"}, nil, resourceSelector),
+			crp:         newCRP(bypass, nil, resourceSelector, otherSelector),
+			wantWarning: true,
+		},
+		"spec update with the bypass annotation not set to true during an incident window": {
+			checker:           incident,
+			crp:               newCRP(map[string]string{validator.IncidentBypassAnnotation: "yes"}, nil, resourceSelector, otherSelector),
+			wantDeniedMessage: "the placement spec cannot be updated during the incident window",
+		},
+		"metadata only update during an incident window": {
+			checker: incident,
+			crp:     newCRP(nil, map[string]string{"app": "test"}, resourceSelector),
+		},
+		"spec update outside of incident windows": {
+			checker: &fakeIncidentWindowChecker{},
+			crp:     newCRP(nil, nil, resourceSelector, otherSelector),
+		},
+		"spec update without a checker": {
+			crp: newCRP(nil, nil, resourceSelector, otherSelector),
+		},
+		"spec update when the incident window cannot be checked": {
+			checker:     &fakeIncidentWindowChecker{err: errors.New("boom")},
+			crp:         newCRP(nil, nil, resourceSelector, otherSelector),
+			wantErrored: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			validator.RestMapper = utils.TestMapper{}
+			validator.ResourceInformer = &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
+				IsClusterScopedResource: true,
+			}
+			v := clusterResourcePlacementValidator{
+				client:  webhooktesting.NewFakeClientBuilder().Build(),
+				decoder: NewVersionedDecoder(admission.NewDecoder(webhooktesting.Scheme)),
+				config:  validator.NewConfigStore(validator.Config{IncidentWindowChecker: tc.checker}),
+			}
+			old := oldCRP
+			if tc.oldCRP != nil {
+				old = tc.oldCRP
+			}
+			resp := v.Handle(context.Background(), webhooktesting.NewUpdateRequest(old, tc.crp, webhooktesting.WithUserInfo(testUserInfo)))
+			switch {
+			case tc.wantErrored:
+				if resp.Allowed || resp.Result == nil || resp.Result.Code != http.StatusInternalServerError {
+					t.Errorf("Handle() = %+v, want an internal server error", resp.Result)
+				}
+			case tc.wantDeniedMessage != "":
+				webhooktesting.AssertDenied(t, resp, tc.wantDeniedMessage)
+			default:
+				webhooktesting.AssertAllowed(t, resp)
+				if gotWarning := len(resp.Warnings) > 0; gotWarning != tc.wantWarning {
+					t.Errorf("Handle() warnings = %v, want warnings %t", resp.Warnings, tc.wantWarning)
+				}
+			}
+		})
+	}
+}
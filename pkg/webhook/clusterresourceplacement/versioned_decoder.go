@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceplacement
+
+import (
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1"
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils"
+)
+
+// acceptedKinds are the kinds of the ClusterResourcePlacements the VersionedDecoder decodes.
+var acceptedKinds = []metav1.GroupVersionKind{
+	utils.ClusterResourcePlacementMetaGVK,
+	{Group: placementv1.GroupVersion.Group, Version: placementv1.GroupVersion.Version, Kind: placementv1beta1.ClusterResourcePlacementKind},
+}
+
+// VersionedDecoder decodes the ClusterResourcePlacement of an admission request into the v1beta1 type the
+// validation works on, whichever API version the object is sent in.
+type VersionedDecoder struct {
+	decoder webhook.AdmissionDecoder
+}
+
+// NewVersionedDecoder returns a VersionedDecoder which decodes the objects with the admission decoder. The scheme
+// of the decoder must know the ClusterResourcePlacement of every version to decode.
+func NewVersionedDecoder(decoder webhook.AdmissionDecoder) *VersionedDecoder {
+	return &VersionedDecoder{decoder: decoder}
+}
+
+// Decode decodes the object of the request.
+func (d *VersionedDecoder) Decode(req admission.Request) (*placementv1beta1.ClusterResourcePlacement, error) {
+	return d.decodeRaw(req.Kind.Version, req.Object)
+}
+
+// DecodeOld decodes the old object of the request.
+func (d *VersionedDecoder) DecodeOld(req admission.Request) (*placementv1beta1.ClusterResourcePlacement, error) {
+	return d.decodeRaw(req.Kind.Version, req.OldObject)
+}
+
+// decodeRaw decodes the raw object of the given version. The version is the one of req.Kind, which the API server
+// encodes the objects in; req.RequestKind may name another version if the API server has converted the objects to
+// a version the webhook is registered for.
+func (d *VersionedDecoder) decodeRaw(version string, raw runtime.RawExtension) (*placementv1beta1.ClusterResourcePlacement, error) {
+	switch version {
+	case placementv1beta1.GroupVersion.Version:
+		var crp placementv1beta1.ClusterResourcePlacement
+		if err := d.decoder.DecodeRaw(raw, &crp); err != nil {
+			return nil, err
+		}
+		return &crp, nil
+	case placementv1.GroupVersion.Version:
+		var crp placementv1.ClusterResourcePlacement
+		if err := d.decoder.DecodeRaw(raw, &crp); err != nil {
+			return nil, err
+		}
+		return convertV1ClusterResourcePlacement(&crp)
+	default:
+		return nil, fmt.Errorf("unsupported ClusterResourcePlacement version %q", version)
+	}
+}
+
+// convertV1ClusterResourcePlacement converts a v1 ClusterResourcePlacement to v1beta1. The v1beta1 API is a
+// superset of v1 with the same field names, so the object is converted through its JSON representation.
+func convertV1ClusterResourcePlacement(crp *placementv1.ClusterResourcePlacement) (*placementv1beta1.ClusterResourcePlacement, error) {
+	raw, err := json.Marshal(crp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the v1 ClusterResourcePlacement %s: %w", crp.Name, err)
+	}
+	var converted placementv1beta1.ClusterResourcePlacement
+	if err := json.Unmarshal(raw, &converted); err != nil {
+		return nil, fmt.Errorf("failed to convert the v1 ClusterResourcePlacement %s to v1beta1: %w", crp.Name, err)
+	}
+	converted.SetGroupVersionKind(placementv1beta1.GroupVersion.WithKind(placementv1beta1.ClusterResourcePlacementKind))
+	return &converted, nil
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceplacement
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1"
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
+	webhooktesting "github.com/kubefleet-dev/kubefleet/pkg/webhook/testing"
+	testinformer "github.com/kubefleet-dev/kubefleet/test/utils/informer"
+)
+
+// newV1CRP returns a v1 CRP which selects the test cluster role with the given maxUnavailable.
+func newV1CRP(maxUnavailable int) *placementv1.ClusterResourcePlacement {
+	return &placementv1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-crp", Labels: map[string]string{"app": "test"}},
+		Spec: placementv1.ClusterResourcePlacementSpec{
+			ResourceSelectors: []placementv1.ClusterResourceSelector{
+				{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole", Name: "test-cluster-role"},
+			},
+			Policy: &placementv1.PlacementPolicy{PlacementType: placementv1.PickNPlacementType, NumberOfClusters: ptr.To[int32](3)},
+			Strategy: placementv1.RolloutStrategy{
+				Type:          placementv1.RollingUpdateRolloutStrategyType,
+				RollingUpdate: &placementv1.RollingUpdateConfig{MaxUnavailable: ptr.To(intstr.FromInt(maxUnavailable))},
+			},
+			RevisionHistoryLimit: ptr.To[int32](5),
+		},
+	}
+}
+
+// newV1Beta1CRP returns the v1beta1 equivalent of newV1CRP.
+func newV1Beta1CRP(maxUnavailable int) *placementv1beta1.ClusterResourcePlacement {
+	return &placementv1beta1.ClusterResourcePlacement{
+		TypeMeta:   metav1.TypeMeta{APIVersion: placementv1beta1.GroupVersion.String(), Kind: placementv1beta1.ClusterResourcePlacementKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "test-crp", Labels: map[string]string{"app": "test"}},
+		Spec: placementv1beta1.PlacementSpec{
+			ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{resourceSelector},
+			Policy:            &placementv1beta1.PlacementPolicy{PlacementType: placementv1beta1.PickNPlacementType, NumberOfClusters: ptr.To[int32](3)},
+			Strategy: placementv1beta1.RolloutStrategy{
+				Type:          placementv1beta1.RollingUpdateRolloutStrategyType,
+				RollingUpdate: &placementv1beta1.RollingUpdateConfig{MaxUnavailable: ptr.To(intstr.FromInt(maxUnavailable))},
+			},
+			RevisionHistoryLimit: ptr.To[int32](5),
+		},
+	}
+}
+
+func TestVersionedDecoder(t *testing.T) {
+	v1alpha1Kind := metav1.GroupVersionKind{Group: placementv1beta1.GroupVersion.Group, Version: "v1alpha1", Kind: placementv1beta1.ClusterResourcePlacementKind}
+	unsupported := webhooktesting.NewCreateRequest(newV1Beta1CRP(1))
+	unsupported.Kind = v1alpha1Kind
+	// The API server encodes the objects in the version of req.Kind, which is not necessarily the requested version.
+	converted := webhooktesting.NewCreateRequest(newV1Beta1CRP(1))
+	converted.RequestKind = &metav1.GroupVersionKind{Group: placementv1.GroupVersion.Group, Version: placementv1.GroupVersion.Version, Kind: placementv1beta1.ClusterResourcePlacementKind}
+
+	testCases := map[string]struct {
+		req        admission.Request
+		wantCRP    *placementv1beta1.ClusterResourcePlacement
+		wantOldCRP *placementv1beta1.ClusterResourcePlacement
+		wantErr    bool
+	}{
+		"v1beta1 create": {
+			req:     webhooktesting.NewCreateRequest(newV1Beta1CRP(1)),
+			wantCRP: newV1Beta1CRP(1),
+		},
+		"v1beta1 update": {
+			req:        webhooktesting.NewUpdateRequest(newV1Beta1CRP(1), newV1Beta1CRP(2)),
+			wantCRP:    newV1Beta1CRP(2),
+			wantOldCRP: newV1Beta1CRP(1),
+		},
+		"v1 create": {
+			req:     webhooktesting.NewCreateRequest(newV1CRP(1)),
+			wantCRP: newV1Beta1CRP(1),
+		},
+		"v1 update": {
+			req:        webhooktesting.NewUpdateRequest(newV1CRP(1), newV1CRP(2)),
+			wantCRP:    newV1Beta1CRP(2),
+			wantOldCRP: newV1Beta1CRP(1),
+		},
+		"v1 request sent as v1beta1": {
+			req:     converted,
+			wantCRP: newV1Beta1CRP(1),
+		},
+		"unsupported version": {
+			req:     unsupported,
+			wantErr: true,
+		},
+	}
+	d := NewVersionedDecoder(admission.NewDecoder(webhooktesting.Scheme))
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := d.Decode(tc.req)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Decode() = %v, want error %t", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tc.wantCRP, got); diff != "" {
+				t.Errorf("Decode() mismatch (-want, +got):\n%s", diff)
+			}
+			if tc.wantOldCRP == nil {
+				return
+			}
+			gotOld, err := d.DecodeOld(tc.req)
+			if err != nil {
+				t.Fatalf("DecodeOld() = %v, want no error", err)
+			}
+			if diff := cmp.Diff(tc.wantOldCRP, gotOld); diff != "" {
+				t.Errorf("DecodeOld() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHandleVersions(t *testing.T) {
+	// Every placement validation rule and the field validation pass on the allowed requests.
+	allRulesPassed := len(validator.PlacementValidationRuleNames()) + 1
+	invalidErr := "the rollout Strategy field  is invalid: maxUnavailable must be greater than or equal to 0, got `-1`"
+	testCases := map[string]struct {
+		req          admission.Request
+		wantResponse admission.Response
+	}{
+		"allow valid v1beta1 CRP": {
+			req:          webhooktesting.NewCreateRequest(newV1Beta1CRP(1), webhooktesting.WithUserInfo(testUserInfo)),
+			wantResponse: admission.Allowed(validator.AllowedMessage("v1beta1 CRP", allRulesPassed, 0)),
+		},
+		"allow valid v1 CRP": {
+			req:          webhooktesting.NewCreateRequest(newV1CRP(1), webhooktesting.WithUserInfo(testUserInfo)),
+			wantResponse: admission.Allowed(validator.AllowedMessage("v1beta1 CRP", allRulesPassed, 0)),
+		},
+		"deny invalid v1beta1 CRP": {
+			req:          webhooktesting.NewCreateRequest(newV1Beta1CRP(-1), webhooktesting.WithUserInfo(testUserInfo)),
+			wantResponse: admission.Denied(fmt.Sprintf(validator.DenyCreateUpdateInvalidFmt, "CRP", invalidErr)),
+		},
+		"deny invalid v1 CRP": {
+			req:          webhooktesting.NewCreateRequest(newV1CRP(-1), webhooktesting.WithUserInfo(testUserInfo)),
+			wantResponse: admission.Denied(fmt.Sprintf(validator.DenyCreateUpdateInvalidFmt, "CRP", invalidErr)),
+		},
+		"deny v1 update of the placement type": {
+			req: webhooktesting.NewUpdateRequest(newV1CRP(1), func() *placementv1.ClusterResourcePlacement {
+				crp := newV1CRP(1)
+				crp.Spec.Policy = &placementv1.PlacementPolicy{PlacementType: placementv1.PickAllPlacementType}
+				return crp
+			}(), webhooktesting.WithUserInfo(testUserInfo)),
+			wantResponse: admission.Denied("placement type is immutable"),
+		},
+	}
+	v := clusterResourcePlacementValidator{
+		client:  webhooktesting.NewFakeClientBuilder().Build(),
+		decoder: NewVersionedDecoder(admission.NewDecoder(webhooktesting.Scheme)),
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			validator.RestMapper = utils.TestMapper{}
+			validator.ResourceInformer = &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
+				IsClusterScopedResource: true,
+			}
+			got := v.Handle(context.Background(), tc.req)
+			if diff := cmp.Diff(tc.wantResponse, got); diff != "" {
+				t.Errorf("Handle() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -30,11 +30,12 @@ import (
 	fleetv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
 	"github.com/kubefleet-dev/kubefleet/pkg/utils"
 	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
+	"github.com/kubefleet-dev/kubefleet/pkg/webhook/validation"
 )
 
 var (
 	// ValidationPath is the webhook service path which admission requests are routed to for validating clusterresourceplacementdisruptionbudget resources.
-	ValidationPath = fmt.Sprintf(utils.ValidationPathFmt, fleetv1beta1.GroupVersion.Group, fleetv1beta1.GroupVersion.Version, "clusterresourceplacementdisruptionbudget")
+	ValidationPath = utils.RegisterWebhookPath(fleetv1beta1.GroupVersion.Group, fleetv1beta1.GroupVersion.Version, "clusterresourceplacementdisruptionbudget", utils.ValidatingWebhookPathKind)
 )
 
 type clusterResourcePlacementDisruptionBudgetValidator struct {
@@ -43,9 +44,13 @@ type clusterResourcePlacementDisruptionBudgetValidator struct {
 }
 
 // Add registers the webhook for K8s bulit-in object types.
-func Add(mgr manager.Manager) error {
+func Add(mgr manager.Manager, _ *validator.ConfigStore) error {
+	decoder, err := validation.SharedDecoder(mgr.GetScheme(), &fleetv1beta1.ClusterResourcePlacementDisruptionBudget{})
+	if err != nil {
+		return err
+	}
 	hookServer := mgr.GetWebhookServer()
-	hookServer.Register(ValidationPath, &webhook.Admission{Handler: &clusterResourcePlacementDisruptionBudgetValidator{mgr.GetClient(), admission.NewDecoder(mgr.GetScheme())}})
+	hookServer.Register(ValidationPath, &webhook.Admission{Handler: &clusterResourcePlacementDisruptionBudgetValidator{mgr.GetClient(), decoder}})
 	return nil
 }
 
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"net/http"
 
+	admissionv1 "k8s.io/api/admission/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
@@ -34,22 +35,33 @@ import (
 	"github.com/kubefleet-dev/kubefleet/pkg/utils"
 	"github.com/kubefleet-dev/kubefleet/pkg/utils/condition"
 	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
+	"github.com/kubefleet-dev/kubefleet/pkg/webhook/validation"
 )
 
 var (
 	// ValidationPath is the webhook service path which admission requests are routed to for validating clusterresourceplacementeviction resources.
-	ValidationPath = fmt.Sprintf(utils.ValidationPathFmt, fleetv1beta1.GroupVersion.Group, fleetv1beta1.GroupVersion.Version, "clusterresourceplacementeviction")
+	ValidationPath = utils.RegisterWebhookPath(fleetv1beta1.GroupVersion.Group, fleetv1beta1.GroupVersion.Version, "clusterresourceplacementeviction", utils.ValidatingWebhookPathKind)
 )
 
 type clusterResourcePlacementEvictionValidator struct {
 	client  client.Client
 	decoder webhook.AdmissionDecoder
+	// config holds the validator settings of the requests.
+	config *validator.ConfigStore
 }
 
 // Add registers the webhook for K8s bulit-in object types.
-func Add(mgr manager.Manager) error {
+func Add(mgr manager.Manager, config *validator.ConfigStore) error {
+	decoder, err := validation.SharedDecoder(mgr.GetScheme(), &fleetv1beta1.ClusterResourcePlacementEviction{})
+	if err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &fleetv1beta1.ClusterResourcePlacementEviction{}, validator.EvictionPlacementClusterIndexKey, validator.EvictionPlacementClusterIndexer); err != nil {
+		klog.ErrorS(err, "Failed to set up the placement and cluster index for cluster resource placement evictions")
+		return err
+	}
 	hookServer := mgr.GetWebhookServer()
-	hookServer.Register(ValidationPath, &webhook.Admission{Handler: &clusterResourcePlacementEvictionValidator{mgr.GetClient(), admission.NewDecoder(mgr.GetScheme())}})
+	hookServer.Register(ValidationPath, &webhook.Admission{Handler: &clusterResourcePlacementEvictionValidator{client: mgr.GetClient(), decoder: decoder, config: config}})
 	return nil
 }
 
@@ -57,11 +69,25 @@ func Add(mgr manager.Manager) error {
 func (v *clusterResourcePlacementEvictionValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
 	var crpe fleetv1beta1.ClusterResourcePlacementEviction
 	klog.V(2).InfoS("Validating webhook handling cluster resource placement eviction", "operation", req.Operation, "clusterResourcePlacementEviction", req.Name)
+	config := v.config.Load()
 	if err := v.decoder.Decode(req, &crpe); err != nil {
 		klog.ErrorS(err, "Failed to decode cluster resource placement eviction object for validating fields", "userName", req.UserInfo.Username, "groups", req.UserInfo.Groups, "clusterResourcePlacementEviction", req.Name)
 		return admission.Errored(http.StatusBadRequest, err)
 	}
 
+	if req.Operation == admissionv1.Update {
+		var oldCRPE fleetv1beta1.ClusterResourcePlacementEviction
+		if err := v.decoder.DecodeRaw(req.OldObject, &oldCRPE); err != nil {
+			klog.ErrorS(err, "Failed to decode old cluster resource placement eviction object for validating fields", "userName", req.UserInfo.Username, "groups", req.UserInfo.Groups, "clusterResourcePlacementEviction", req.Name)
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if crpe.Spec != oldCRPE.Spec {
+			klog.V(2).InfoS("ClusterResourcePlacementEviction spec is updated, request is denied", "operation", req.Operation, "clusterResourcePlacementEviction", crpe.Name)
+			return admission.Denied(config.DenialMessage(validator.EvictionSpecImmutableMessageID, nil))
+		}
+		return admission.Allowed("clusterResourcePlacementEviction spec is not updated")
+	}
+
 	// Get the ClusterResourcePlacement object
 	var crp fleetv1beta1.ClusterResourcePlacement
 	if err := v.client.Get(ctx, types.NamespacedName{Name: crpe.Spec.PlacementName}, &crp); err != nil {
@@ -77,6 +103,26 @@ func (v *clusterResourcePlacementEvictionValidator) Handle(ctx context.Context,
 		return admission.Denied(err.Error())
 	}
 
+	mode := config.EvictionTargetValidation
+	if mode == validator.EvictionTargetValidationWarn || mode == validator.EvictionTargetValidationEnforce {
+		selected, err := validator.IsClusterSelectedByPlacement(ctx, v.client, types.NamespacedName{Name: crp.Name}, crpe.Spec.ClusterName)
+		switch {
+		case err != nil && mode == validator.EvictionTargetValidationEnforce:
+			return admission.Errored(http.StatusInternalServerError, fmt.Errorf("failed to get the latest scheduling decision of clusterResourcePlacement %s for clusterResourcePlacementEviction %s, please retry the request: %w", crp.Name, crpe.Name, err))
+		case err != nil:
+			klog.ErrorS(err, "Failed to get the latest scheduling decision of the clusterResourcePlacement, allowing the eviction", "clusterResourcePlacementEviction", crpe.Name, "clusterResourcePlacement", crp.Name)
+			return admission.Allowed("clusterResourcePlacementEviction has valid fields").WithWarnings(
+				fmt.Sprintf("failed to check whether cluster %s is selected by clusterResourcePlacement %s, the eviction may have no effect", crpe.Spec.ClusterName, crp.Name))
+		case !selected:
+			msg := fmt.Sprintf("cluster %s is not selected by the latest scheduling decision of clusterResourcePlacement %s, the eviction would have no effect", crpe.Spec.ClusterName, crp.Name)
+			klog.V(2).InfoS("ClusterResourcePlacementEviction targets a cluster not selected by the placement", "clusterResourcePlacementEviction", crpe.Name, "cluster", crpe.Spec.ClusterName, "mode", mode)
+			if mode == validator.EvictionTargetValidationEnforce {
+				return admission.Denied(config.DenialMessage(validator.EvictionClusterNotSelectedMessageID, map[string]any{"cluster": crpe.Spec.ClusterName, "placement": crp.Name}))
+			}
+			return admission.Allowed("clusterResourcePlacementEviction has valid fields").WithWarnings(msg)
+		}
+	}
+
 	klog.V(2).InfoS("ClusterResourcePlacementEviction has valid fields", "clusterResourcePlacementEviction", crpe.Name)
 	return admission.Allowed("clusterResourcePlacementEviction has valid fields")
 }
@@ -19,6 +19,9 @@ package clusterresourceplacementeviction
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
 	"testing"
 	"time"
 
@@ -30,10 +33,13 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
 	"github.com/kubefleet-dev/kubefleet/pkg/utils"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
+	webhooktesting "github.com/kubefleet-dev/kubefleet/pkg/webhook/testing"
 )
 
 func TestHandle(t *testing.T) {
@@ -232,3 +238,168 @@ func TestHandle(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleEvictionTarget(t *testing.T) {
+	crp := &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-crp"},
+		Spec: placementv1beta1.PlacementSpec{
+			Policy: &placementv1beta1.PlacementPolicy{PlacementType: placementv1beta1.PickAllPlacementType},
+		},
+	}
+	unscheduledCRP := &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{Name: "unscheduled-crp"},
+		Spec: placementv1beta1.PlacementSpec{
+			Policy: &placementv1beta1.PlacementPolicy{PlacementType: placementv1beta1.PickAllPlacementType},
+		},
+	}
+	newPolicySnapshot := func(name string, isLatest bool, decisions ...placementv1beta1.ClusterDecision) *placementv1beta1.ClusterSchedulingPolicySnapshot {
+		return &placementv1beta1.ClusterSchedulingPolicySnapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					placementv1beta1.PlacementTrackingLabel: "test-crp",
+					placementv1beta1.IsLatestSnapshotLabel:  strconv.FormatBool(isLatest),
+				},
+			},
+			Status: placementv1beta1.SchedulingPolicySnapshotStatus{ClusterDecisions: decisions},
+		}
+	}
+	objects := []client.Object{
+		crp,
+		unscheduledCRP,
+		newPolicySnapshot("test-crp-0", false, placementv1beta1.ClusterDecision{ClusterName: "cluster-old", Selected: true}),
+		newPolicySnapshot("test-crp-1", true,
+			placementv1beta1.ClusterDecision{ClusterName: "cluster-selected", Selected: true},
+			placementv1beta1.ClusterDecision{ClusterName: "cluster-unselected", Selected: false},
+		),
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(webhooktesting.Scheme).WithObjects(objects...).Build()
+	erroringClient := fake.NewClientBuilder().WithScheme(webhooktesting.Scheme).WithObjects(crp).WithInterceptorFuncs(interceptor.Funcs{
+		List: func(_ context.Context, _ client.WithWatch, _ client.ObjectList, _ ...client.ListOption) error {
+			return errors.New("list error")
+		},
+	}).Build()
+	decoder := admission.NewDecoder(webhooktesting.Scheme)
+
+	testCases := map[string]struct {
+		mode          validator.EvictionTargetValidationMode
+		client        client.Client
+		placementName string
+		clusterName   string
+		wantAllowed   bool
+		wantCode      int32
+		wantMessage   string
+		wantWarnings  []string
+	}{
+		"allow eviction of selected cluster": {
+			mode:          validator.EvictionTargetValidationEnforce,
+			client:        fakeClient,
+			placementName: "test-crp",
+			clusterName:   "cluster-selected",
+			wantAllowed:   true,
+		},
+		"deny eviction of unselected cluster": {
+			mode:          validator.EvictionTargetValidationEnforce,
+			client:        fakeClient,
+			placementName: "test-crp",
+			clusterName:   "cluster-unselected",
+			wantMessage:   "cluster cluster-unselected is not selected by the latest scheduling decision of clusterResourcePlacement test-crp, the eviction would have no effect",
+		},
+		"deny eviction of cluster selected by an old scheduling decision": {
+			mode:          validator.EvictionTargetValidationEnforce,
+			client:        fakeClient,
+			placementName: "test-crp",
+			clusterName:   "cluster-old",
+			wantMessage:   "cluster cluster-old is not selected by the latest scheduling decision of clusterResourcePlacement test-crp, the eviction would have no effect",
+		},
+		"deny eviction of unscheduled placement": {
+			mode:          validator.EvictionTargetValidationEnforce,
+			client:        fakeClient,
+			placementName: "unscheduled-crp",
+			clusterName:   "cluster-selected",
+			wantMessage:   "cluster cluster-selected is not selected by the latest scheduling decision of clusterResourcePlacement unscheduled-crp, the eviction would have no effect",
+		},
+		"warn on eviction of unselected cluster": {
+			mode:          validator.EvictionTargetValidationWarn,
+			client:        fakeClient,
+			placementName: "test-crp",
+			clusterName:   "cluster-unselected",
+			wantAllowed:   true,
+			wantWarnings:  []string{"cluster cluster-unselected is not selected by the latest scheduling decision of clusterResourcePlacement test-crp, the eviction would have no effect"},
+		},
+		"allow eviction of unselected cluster when the validation is disabled": {
+			mode:          validator.EvictionTargetValidationDisabled,
+			client:        fakeClient,
+			placementName: "test-crp",
+			clusterName:   "cluster-unselected",
+			wantAllowed:   true,
+		},
+		"allow eviction of missing placement": {
+			mode:          validator.EvictionTargetValidationEnforce,
+			client:        fakeClient,
+			placementName: "does-not-exist",
+			clusterName:   "cluster-selected",
+			wantAllowed:   true,
+		},
+		"error when the scheduling decision cannot be fetched": {
+			mode:          validator.EvictionTargetValidationEnforce,
+			client:        erroringClient,
+			placementName: "test-crp",
+			clusterName:   "cluster-selected",
+			wantCode:      http.StatusInternalServerError,
+		},
+		"warn when the scheduling decision cannot be fetched": {
+			mode:          validator.EvictionTargetValidationWarn,
+			client:        erroringClient,
+			placementName: "test-crp",
+			clusterName:   "cluster-selected",
+			wantAllowed:   true,
+			wantWarnings:  []string{"failed to check whether cluster cluster-selected is selected by clusterResourcePlacement test-crp, the eviction may have no effect"},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			crpe := &placementv1beta1.ClusterResourcePlacementEviction{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-crpe"},
+				Spec:       placementv1beta1.PlacementEvictionSpec{PlacementName: tc.placementName, ClusterName: tc.clusterName},
+			}
+			v := clusterResourcePlacementEvictionValidator{
+				client:  tc.client,
+				decoder: decoder,
+				config:  validator.NewConfigStore(validator.Config{EvictionTargetValidation: tc.mode}),
+			}
+			resp := v.Handle(context.Background(), webhooktesting.NewCreateRequest(crpe))
+			if resp.Allowed != tc.wantAllowed {
+				t.Fatalf("Handle() allowed = %t, want %t: %v", resp.Allowed, tc.wantAllowed, resp.Result)
+			}
+			if tc.wantCode != 0 && resp.Result.Code != tc.wantCode {
+				t.Errorf("Handle() code = %d, want %d", resp.Result.Code, tc.wantCode)
+			}
+			if tc.wantMessage != "" && resp.Result.Message != tc.wantMessage {
+				t.Errorf("Handle() message = %q, want %q", resp.Result.Message, tc.wantMessage)
+			}
+			if diff := cmp.Diff(tc.wantWarnings, resp.Warnings); diff != "" {
+				t.Errorf("Handle() warnings mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHandleUpdate(t *testing.T) {
+	oldCRPE := &placementv1beta1.ClusterResourcePlacementEviction{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-crpe"},
+		Spec:       placementv1beta1.PlacementEvictionSpec{PlacementName: "test-crp", ClusterName: "cluster-1"},
+	}
+	labeledCRPE := oldCRPE.DeepCopy()
+	labeledCRPE.Labels = map[string]string{"key": "value"}
+	retargetedCRPE := oldCRPE.DeepCopy()
+	retargetedCRPE.Spec.ClusterName = "cluster-2"
+	// The placement is not fetched on update, hence the validator has no client.
+	v := clusterResourcePlacementEvictionValidator{decoder: admission.NewDecoder(webhooktesting.Scheme)}
+
+	resp := v.Handle(context.Background(), webhooktesting.NewUpdateRequest(oldCRPE, labeledCRPE))
+	webhooktesting.AssertAllowed(t, resp)
+
+	resp = v.Handle(context.Background(), webhooktesting.NewUpdateRequest(oldCRPE, retargetedCRPE))
+	webhooktesting.AssertDenied(t, resp, validator.Config{}.DenialMessage(validator.EvictionSpecImmutableMessageID, nil))
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"runtime/debug"
+
+	admv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+// WebhookConfigurationHashAnnotation is the annotation on the applied webhook configurations which records the hash of
+// the desired configuration they were last applied from, so that operators can tell whether the configurations on a
+// hub match the ones the hub agent expects without diffing them.
+const WebhookConfigurationHashAnnotation = placementv1beta1.FleetPrefix + "webhook-configuration-hash"
+
+// hubAgentVersion is the version of the hub agent binary, which labels the webhook configuration hash metric.
+var hubAgentVersion = buildVersion()
+
+// buildVersion returns the VCS revision the binary is built from, falling back to its module version.
+func buildVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	for _, s := range info.Settings {
+		if s.Key == "vcs.revision" && s.Value != "" {
+			return s.Value
+		}
+	}
+	if info.Main.Version != "" {
+		return info.Main.Version
+	}
+	return "unknown"
+}
+
+// webhookConfigurationHash returns the hash of the desired webhook configuration of the kind and name holding the
+// webhooks. The caBundle is left out as it changes whenever the certificate is regenerated; the JSON encoding is
+// deterministic so the hash is stable across builds from the same Config.
+func webhookConfigurationHash(kind, name string, webhooks interface{}) (string, error) {
+	switch whs := webhooks.(type) {
+	case []admv1.MutatingWebhook:
+		stripped := make([]admv1.MutatingWebhook, len(whs))
+		copy(stripped, whs)
+		for i := range stripped {
+			stripped[i].ClientConfig.CABundle = nil
+		}
+		webhooks = stripped
+	case []admv1.ValidatingWebhook:
+		stripped := make([]admv1.ValidatingWebhook, len(whs))
+		copy(stripped, whs)
+		for i := range stripped {
+			stripped[i].ClientConfig.CABundle = nil
+		}
+		webhooks = stripped
+	}
+	b, err := json.Marshal(struct {
+		Kind     string      `json:"kind"`
+		Name     string      `json:"name"`
+		Webhooks interface{} `json:"webhooks"`
+	}{Kind: kind, Name: name, Webhooks: webhooks})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// isWebhookConfigurationUpToDate returns true if the existing webhook configuration was last applied from the
+// desired hash and all its webhooks trust the current caBundle, in which case it does not need to be overwritten.
+func isWebhookConfigurationUpToDate(existing metav1.Object, hash string, caBundles [][]byte, caPEM []byte) bool {
+	if existing.GetAnnotations()[WebhookConfigurationHashAnnotation] != hash {
+		return false
+	}
+	for _, caBundle := range caBundles {
+		if !bytes.Equal(caBundle, caPEM) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	admv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubefleet-dev/kubefleet/cmd/hubagent/options"
+	hubmetrics "github.com/kubefleet-dev/kubefleet/pkg/metrics/hub"
+)
+
+// newHashTestConfig returns a Config serving all the webhooks through the service URL.
+func newHashTestConfig() *Config {
+	url := options.URL
+	return &Config{
+		serviceNamespace:     "fleet-system",
+		serviceName:          "fleetwebhook",
+		servicePort:          443,
+		serviceURL:           "https://fleetwebhook.fleet-system.svc.cluster.local:443",
+		clientConnectionType: &url,
+		enableGuardRail:      true,
+		caPEM:                []byte("ca"),
+		webhookCache:         &webhookCache{},
+		applyStatus:          &webhookConfigurationApplyStatus{},
+	}
+}
+
+// desiredHashes returns the hashes of the desired webhook configurations keyed by their names.
+func desiredHashes(t *testing.T, w *Config) map[string]string {
+	t.Helper()
+	applies, err := w.desiredWebhookConfigurations()
+	if err != nil {
+		t.Fatalf("desiredWebhookConfigurations() = %v, want no error", err)
+	}
+	hashes := make(map[string]string, len(applies))
+	for _, a := range applies {
+		hashes[a.name] = a.hash
+	}
+	return hashes
+}
+
+func TestDesiredWebhookConfigurationHashes(t *testing.T) {
+	want := desiredHashes(t, newHashTestConfig())
+	if len(want) != 3 {
+		t.Fatalf("desiredWebhookConfigurations() returned %d configurations, want 3", len(want))
+	}
+
+	t.Run("stable across builds with identical Config", func(t *testing.T) {
+		w := newHashTestConfig()
+		for i := 0; i < 10; i++ {
+			if diff := cmp.Diff(want, desiredHashes(t, w)); diff != "" {
+				t.Fatalf("desiredWebhookConfigurations() hashes mismatch (-want, +got):\n%s", diff)
+			}
+		}
+		if diff := cmp.Diff(want, desiredHashes(t, newHashTestConfig())); diff != "" {
+			t.Errorf("desiredWebhookConfigurations() hashes of a fresh Config mismatch (-want, +got):\n%s", diff)
+		}
+	})
+
+	t.Run("caBundle is left out", func(t *testing.T) {
+		w := newHashTestConfig()
+		w.caPEM = []byte("rotated")
+		if diff := cmp.Diff(want, desiredHashes(t, w)); diff != "" {
+			t.Errorf("desiredWebhookConfigurations() hashes after a caBundle change mismatch (-want, +got):\n%s", diff)
+		}
+	})
+
+	service := options.Service
+	mutations := map[string]func(w *Config){
+		"service namespace": func(w *Config) { w.clientConnectionType = &service; w.serviceNamespace = "other-system" },
+		"service name":      func(w *Config) { w.clientConnectionType = &service; w.serviceName = "other" },
+		"service port":      func(w *Config) { w.clientConnectionType = &service; w.servicePort = 8443 },
+		"service URL":       func(w *Config) { w.serviceURL = "https://other.fleet-system.svc.cluster.local:443" },
+		"connection type":   func(w *Config) { w.clientConnectionType = &service },
+		"enable workload":   func(w *Config) { w.enableWorkload = true },
+		"service names": func(w *Config) {
+			w.serviceNames = map[options.WebhookRole]string{options.WebhookRolePlacement: "placementwebhook"}
+		},
+		"mutating webhook overrides": func(w *Config) {
+			w.mutatingWebhookOverrides = map[string]MutatingWebhookOverride{crpPhaseTimestampsMutatingWebhookName: {ReinvocationPolicy: ptr.To(admv1.IfNeededReinvocationPolicy)}}
+		},
+	}
+	for name, mutate := range mutations {
+		t.Run("changes with the "+name, func(t *testing.T) {
+			w := newHashTestConfig()
+			mutate(w)
+			if diff := cmp.Diff(want, desiredHashes(t, w)); diff == "" {
+				t.Errorf("desiredWebhookConfigurations() hashes did not change with the %s", name)
+			}
+		})
+	}
+}
+
+func TestCreateFleetWebhookConfigurationDrift(t *testing.T) {
+	desired := desiredHashes(t, newHashTestConfig())[fleetMutatingWebhookCfgName]
+	testCases := map[string]struct {
+		annotations   map[string]string
+		caBundle      []byte
+		wantOverwrite bool
+	}{
+		"up to date": {
+			annotations: map[string]string{WebhookConfigurationHashAnnotation: desired},
+			caBundle:    []byte("ca"),
+		},
+		"hash mismatch": {
+			annotations:   map[string]string{WebhookConfigurationHashAnnotation: "stale"},
+			caBundle:      []byte("ca"),
+			wantOverwrite: true,
+		},
+		"no hash": {
+			caBundle:      []byte("ca"),
+			wantOverwrite: true,
+		},
+		"stale caBundle": {
+			annotations:   map[string]string{WebhookConfigurationHashAnnotation: desired},
+			caBundle:      []byte("old-ca"),
+			wantOverwrite: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			existing := &admv1.MutatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: fleetMutatingWebhookCfgName, Annotations: tc.annotations},
+				Webhooks: []admv1.MutatingWebhook{{
+					Name:         "existing.mutating",
+					ClientConfig: admv1.WebhookClientConfig{CABundle: tc.caBundle},
+				}},
+			}
+			scheme := runtime.NewScheme()
+			if err := clientgoscheme.AddToScheme(scheme); err != nil {
+				t.Fatalf("AddToScheme() = %v, want no error", err)
+			}
+			fleetNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "fleet-system"}}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(fleetNamespace, existing).Build()
+			w := newHashTestConfig()
+			w.mgr = &fakeWebhookManager{client: fakeClient}
+
+			if err := w.createFleetWebhookConfiguration(context.Background()); err != nil {
+				t.Fatalf("createFleetWebhookConfiguration() = %v, want no error", err)
+			}
+
+			var got admv1.MutatingWebhookConfiguration
+			if err := fakeClient.Get(context.Background(), client.ObjectKey{Name: fleetMutatingWebhookCfgName}, &got); err != nil {
+				t.Fatalf("Get() = %v, want no error", err)
+			}
+			overwritten := got.Webhooks[0].Name != "existing.mutating"
+			if overwritten != tc.wantOverwrite {
+				t.Errorf("createFleetWebhookConfiguration() overwrote the existing configuration: %t, want %t", overwritten, tc.wantOverwrite)
+			}
+			if tc.wantOverwrite && got.Annotations[WebhookConfigurationHashAnnotation] != desired {
+				t.Errorf("createFleetWebhookConfiguration() hash annotation = %q, want %q", got.Annotations[WebhookConfigurationHashAnnotation], desired)
+			}
+			if got := testutil.ToFloat64(hubmetrics.FleetWebhookConfigurationHash.WithLabelValues(mutatingWebhookConfigurationKind, fleetMutatingWebhookCfgName, desired, hubAgentVersion)); got != 1 {
+				t.Errorf("FleetWebhookConfigurationHash() = %v, want 1", got)
+			}
+		})
+	}
+}
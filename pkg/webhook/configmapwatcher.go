@@ -0,0 +1,237 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
+)
+
+// The keys of the ConfigMap data which override the validator settings at runtime.
+const (
+	// trustedServiceAccountsConfigKey is the comma-separated list of trusted service accounts.
+	trustedServiceAccountsConfigKey = "trustedServiceAccounts"
+	// shadowValidationRulesConfigKey is the comma-separated list of placement validation rules run in shadow mode.
+	shadowValidationRulesConfigKey = "shadowValidationRules"
+	// stripAnnotationPrefixesConfigKey is the comma-separated list of the prefixes of the CRP annotation keys to strip.
+	stripAnnotationPrefixesConfigKey = "stripAnnotationPrefixes"
+	// rpDeniedTolerationKeyPrefixesConfigKey is the comma-separated list of the taint key prefixes RP tolerations cannot use.
+	rpDeniedTolerationKeyPrefixesConfigKey = "resourcePlacementDeniedTolerationKeyPrefixes"
+	// maxClusterNamesConfigKey is the maximum number of cluster names in a PickFixed placement policy.
+	maxClusterNamesConfigKey = "maxClusterNames"
+	// metadataSizeSoftLimitBytesConfigKey is the total placement metadata size above which a warning is returned.
+	metadataSizeSoftLimitBytesConfigKey = "metadataSizeSoftLimitBytes"
+	// metadataSizeHardLimitBytesConfigKey is the total placement metadata size above which the request is denied.
+	metadataSizeHardLimitBytesConfigKey = "metadataSizeHardLimitBytes"
+	// maxTolerationsConfigKey is the maximum number of tolerations in a placement policy.
+	maxTolerationsConfigKey = "maxTolerations"
+	// maxTopologySpreadConstraintsConfigKey is the maximum number of topology spread constraints in a placement policy.
+	maxTopologySpreadConstraintsConfigKey = "maxTopologySpreadConstraints"
+	// maxAffinityTermsConfigKey is the maximum number of required or preferred cluster affinity terms in a placement policy.
+	maxAffinityTermsConfigKey = "maxAffinityTerms"
+	// maxRevisionHistoryLimitReductionPercentConfigKey is the maximum percentage by which a single update can reduce
+	// the revision history limit of a placement.
+	maxRevisionHistoryLimitReductionPercentConfigKey = "maxRevisionHistoryLimitReductionPercent"
+	// maxDiffLogBytesConfigKey is the size above which the logged diff of a denied update is truncated.
+	maxDiffLogBytesConfigKey = "maxDiffLogBytes"
+	// pickAllFleetSizeThresholdConfigKey is the number of member clusters above which the PickAll placements without
+	// required cluster affinity are validated.
+	pickAllFleetSizeThresholdConfigKey = "pickAllFleetSizeThreshold"
+	// maxPlacementsPerTeamConfigKey is the maximum number of active CRPs carrying the same team label.
+	maxPlacementsPerTeamConfigKey = "maxPlacementsPerTeam"
+	// maxWorkManifestsConfigKey is the maximum number of manifests in a Work.
+	maxWorkManifestsConfigKey = "maxWorkManifests"
+	// maxWorkManifestSizeBytesConfigKey is the maximum size of a single manifest in a Work.
+	maxWorkManifestSizeBytesConfigKey = "maxWorkManifestSizeBytes"
+	// maxWorkSizeBytesConfigKey is the maximum size of a Work object.
+	maxWorkSizeBytesConfigKey = "maxWorkSizeBytes"
+	// hubAgentIdentitiesConfigKey is the comma-separated list of the usernames of the hub agent.
+	hubAgentIdentitiesConfigKey = "hubAgentIdentities"
+	// hubAgentMaxWorkManifestsConfigKey is the maximum number of manifests in a Work of the hub agent.
+	hubAgentMaxWorkManifestsConfigKey = "hubAgentMaxWorkManifests"
+	// hubAgentMaxWorkManifestSizeBytesConfigKey is the maximum size of a single manifest in a Work of the hub agent.
+	hubAgentMaxWorkManifestSizeBytesConfigKey = "hubAgentMaxWorkManifestSizeBytes"
+	// hubAgentMaxWorkSizeBytesConfigKey is the maximum size of a Work object of the hub agent.
+	hubAgentMaxWorkSizeBytesConfigKey = "hubAgentMaxWorkSizeBytes"
+	// requiredLabelsConfigKey is the JSON object mapping the label keys every CRP must carry to the regular
+	// expressions their values must match.
+	requiredLabelsConfigKey = "requiredLabels"
+	// memberClusterLabelSchemasConfigKey is the JSON object mapping the label keys of MemberClusters to the format
+	// their values must have.
+	memberClusterLabelSchemasConfigKey = "memberClusterLabelSchemas"
+	// denialMessageTemplateConfigKeyPrefix is the prefix of the keys whose values replace the template of the denial
+	// message with the ID following the prefix, e.g., denialMessageTemplate.placement-type-immutable.
+	denialMessageTemplateConfigKeyPrefix = "denialMessageTemplate."
+)
+
+// configMapInformer is the part of the controller-runtime informer used to watch the ConfigMap.
+type configMapInformer interface {
+	AddEventHandler(handler toolscache.ResourceEventHandler) (toolscache.ResourceEventHandlerRegistration, error)
+}
+
+// StartConfigMapWatcher watches the named ConfigMap and applies its data on top of the validator settings the
+// webhook is started with whenever the ConfigMap changes. The settings revert to the startup ones if the
+// ConfigMap is deleted. Invalid values are logged and ignored.
+func (w *Config) StartConfigMapWatcher(ctx context.Context, c client.Client, configMapName, namespace string) error {
+	informer, err := w.mgr.GetCache().GetInformer(ctx, &corev1.ConfigMap{})
+	if err != nil {
+		return fmt.Errorf("failed to get the ConfigMap informer: %w", err)
+	}
+	return w.watchConfigMap(ctx, c, informer, types.NamespacedName{Namespace: namespace, Name: configMapName})
+}
+
+// watchConfigMap registers the event handler which reloads the validator settings from the ConfigMap.
+func (w *Config) watchConfigMap(ctx context.Context, c client.Reader, informer configMapInformer, key types.NamespacedName) error {
+	base := w.validatorConfig()
+	reload := func(obj interface{}) {
+		if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		cm, ok := obj.(*corev1.ConfigMap)
+		if !ok || cm.Namespace != key.Namespace || cm.Name != key.Name {
+			return
+		}
+		// Read the latest ConfigMap so that out of order events never apply a stale version.
+		latest := &corev1.ConfigMap{}
+		if err := c.Get(ctx, key, latest); err != nil {
+			if !apierrors.IsNotFound(err) {
+				klog.ErrorS(err, "Failed to get the webhook config ConfigMap, keeping the current settings", "configMap", key)
+				return
+			}
+			klog.V(2).InfoS("Webhook config ConfigMap is deleted, reverting to the startup settings", "configMap", key)
+			w.validatorConfigs.Store(base)
+			return
+		}
+		w.validatorConfigs.Store(applyConfigMapData(base, latest.Data))
+		klog.V(2).InfoS("Reloaded the validator settings from the webhook config ConfigMap", "configMap", key, "resourceVersion", latest.ResourceVersion)
+	}
+	_, err := informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    reload,
+		UpdateFunc: func(_, newObj interface{}) { reload(newObj) },
+		DeleteFunc: reload,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add the ConfigMap event handler: %w", err)
+	}
+	return nil
+}
+
+// applyConfigMapData returns a copy of the validator settings with the values set in the ConfigMap data.
+func applyConfigMapData(base validator.Config, data map[string]string) validator.Config {
+	c := base
+	if v, ok := data[trustedServiceAccountsConfigKey]; ok {
+		c.TrustedServiceAccounts = splitConfigList(v)
+	}
+	if v, ok := data[shadowValidationRulesConfigKey]; ok {
+		c.ShadowValidationRules = splitConfigList(v)
+	}
+	if v, ok := data[stripAnnotationPrefixesConfigKey]; ok {
+		c.StripAnnotationPrefixes = splitConfigList(v)
+	}
+	if v, ok := data[rpDeniedTolerationKeyPrefixesConfigKey]; ok {
+		c.ResourcePlacementDeniedTolerationKeyPrefixes = splitConfigList(v)
+	}
+	if v, ok := data[hubAgentIdentitiesConfigKey]; ok {
+		c.HubAgentIdentities = splitConfigList(v)
+	}
+	if v, ok := data[requiredLabelsConfigKey]; ok {
+		requiredLabels, err := validator.ParseRequiredLabels(strings.TrimSpace(v))
+		if err != nil {
+			klog.ErrorS(err, "Ignoring the invalid webhook config value", "key", requiredLabelsConfigKey)
+		} else {
+			c.RequiredLabels = requiredLabels
+		}
+	}
+	if v, ok := data[memberClusterLabelSchemasConfigKey]; ok {
+		schemas, err := validator.ParseLabelSchemas(strings.TrimSpace(v))
+		if err != nil {
+			klog.ErrorS(err, "Ignoring the invalid webhook config value", "key", memberClusterLabelSchemasConfigKey)
+		} else {
+			c.LabelSchemas = schemas
+		}
+	}
+	for key, field := range map[string]*int{
+		maxClusterNamesConfigKey:                         &c.MaxClusterNames,
+		metadataSizeSoftLimitBytesConfigKey:              &c.MetadataSizeSoftLimitBytes,
+		metadataSizeHardLimitBytesConfigKey:              &c.MetadataSizeHardLimitBytes,
+		maxTolerationsConfigKey:                          &c.MaxTolerations,
+		maxTopologySpreadConstraintsConfigKey:            &c.MaxTopologySpreadConstraints,
+		maxAffinityTermsConfigKey:                        &c.MaxAffinityTerms,
+		maxPlacementsPerTeamConfigKey:                    &c.MaxPlacementsPerTeam,
+		pickAllFleetSizeThresholdConfigKey:               &c.PickAllFleetSizeThreshold,
+		maxRevisionHistoryLimitReductionPercentConfigKey: &c.MaxRevisionHistoryLimitReductionPercent,
+		maxDiffLogBytesConfigKey:                         &c.MaxDiffLogBytes,
+		maxWorkManifestsConfigKey:                        &c.WorkLimits.MaxManifests,
+		maxWorkManifestSizeBytesConfigKey:                &c.WorkLimits.MaxManifestSizeBytes,
+		maxWorkSizeBytesConfigKey:                        &c.WorkLimits.MaxSizeBytes,
+		hubAgentMaxWorkManifestsConfigKey:                &c.HubAgentWorkLimits.MaxManifests,
+		hubAgentMaxWorkManifestSizeBytesConfigKey:        &c.HubAgentWorkLimits.MaxManifestSizeBytes,
+		hubAgentMaxWorkSizeBytesConfigKey:                &c.HubAgentWorkLimits.MaxSizeBytes,
+	} {
+		v, ok := data[key]
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil || n <= 0 {
+			klog.ErrorS(err, "Ignoring the invalid webhook config value, it must be a positive integer", "key", key, "value", v)
+			continue
+		}
+		*field = n
+	}
+	// Copy the startup templates so that the reloads never modify them.
+	templates := maps.Clone(base.DenialMessageTemplates)
+	for key, v := range data {
+		id, ok := strings.CutPrefix(key, denialMessageTemplateConfigKeyPrefix)
+		if !ok {
+			continue
+		}
+		if err := validator.ValidateDenialMessageTemplate(id, v); err != nil {
+			klog.ErrorS(err, "Ignoring the invalid denial message template", "key", key)
+			continue
+		}
+		if templates == nil {
+			templates = make(map[string]string)
+		}
+		templates[id] = v
+	}
+	c.DenialMessageTemplates = templates
+	return c
+}
+
+// splitConfigList splits a comma-separated ConfigMap value into its non-empty items.
+func splitConfigList(v string) []string {
+	var items []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
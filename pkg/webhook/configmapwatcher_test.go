@@ -0,0 +1,313 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
+)
+
+// fakeConfigMapInformer records the registered event handler so that tests can fire events.
+type fakeConfigMapInformer struct {
+	handler toolscache.ResourceEventHandler
+}
+
+func (f *fakeConfigMapInformer) AddEventHandler(handler toolscache.ResourceEventHandler) (toolscache.ResourceEventHandlerRegistration, error) {
+	f.handler = handler
+	return nil, nil
+}
+
+// regexpComparer compares the regular expressions by their source text.
+var regexpComparer = cmp.Comparer(func(a, b *regexp.Regexp) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.String() == b.String()
+})
+
+func TestApplyConfigMapData(t *testing.T) {
+	base := validator.Config{
+		TrustedServiceAccounts: []string{"system:serviceaccount:fleet-system:startup"},
+		MaxClusterNames:        10,
+	}
+	testCases := map[string]struct {
+		data map[string]string
+		want validator.Config
+	}{
+		"no data": {
+			want: base,
+		},
+		"all keys": {
+			data: map[string]string{
+				trustedServiceAccountsConfigKey:                  " system:serviceaccount:ns:a, ,system:serviceaccount:ns:b",
+				shadowValidationRulesConfigKey:                   "MetadataSize",
+				stripAnnotationPrefixesConfigKey:                 "example.com/, ,kubectl.kubernetes.io/",
+				rpDeniedTolerationKeyPrefixesConfigKey:           "fleet.io/, admin.example.com/",
+				maxClusterNamesConfigKey:                         "20",
+				metadataSizeSoftLimitBytesConfigKey:              "1024",
+				metadataSizeHardLimitBytesConfigKey:              " 2048 ",
+				maxTolerationsConfigKey:                          "128",
+				maxTopologySpreadConstraintsConfigKey:            "32",
+				maxAffinityTermsConfigKey:                        "64",
+				maxPlacementsPerTeamConfigKey:                    "5",
+				maxRevisionHistoryLimitReductionPercentConfigKey: "25",
+				maxDiffLogBytesConfigKey:                         "1024",
+				maxWorkManifestsConfigKey:                        "50",
+				maxWorkManifestSizeBytesConfigKey:                "4096",
+				maxWorkSizeBytesConfigKey:                        "8192",
+				hubAgentIdentitiesConfigKey:                      "system:serviceaccount:fleet-system:hub-agent",
+				hubAgentMaxWorkManifestsConfigKey:                "500",
+				hubAgentMaxWorkManifestSizeBytesConfigKey:        "40960",
+				hubAgentMaxWorkSizeBytesConfigKey:                "81920",
+			},
+			want: validator.Config{
+				TrustedServiceAccounts:                       []string{"system:serviceaccount:ns:a", "system:serviceaccount:ns:b"},
+				ShadowValidationRules:                        []string{"MetadataSize"},
+				StripAnnotationPrefixes:                      []string{"example.com/", "kubectl.kubernetes.io/"},
+				ResourcePlacementDeniedTolerationKeyPrefixes: []string{"fleet.io/", "admin.example.com/"},
+				MaxClusterNames:                              20,
+				MetadataSizeSoftLimitBytes:                   1024,
+				MetadataSizeHardLimitBytes:                   2048,
+				MaxTolerations:                               128,
+				MaxTopologySpreadConstraints:                 32,
+				MaxAffinityTerms:                             64,
+				MaxPlacementsPerTeam:                         5,
+				MaxRevisionHistoryLimitReductionPercent:      25,
+				MaxDiffLogBytes:                              1024,
+				WorkLimits:                                   validator.WorkLimits{MaxManifests: 50, MaxManifestSizeBytes: 4096, MaxSizeBytes: 8192},
+				HubAgentIdentities:                           []string{"system:serviceaccount:fleet-system:hub-agent"},
+				HubAgentWorkLimits:                           validator.WorkLimits{MaxManifests: 500, MaxManifestSizeBytes: 40960, MaxSizeBytes: 81920},
+			},
+		},
+		"empty trusted service accounts clear the startup ones": {
+			data: map[string]string{trustedServiceAccountsConfigKey: ""},
+			want: validator.Config{MaxClusterNames: 10},
+		},
+		"denial message templates": {
+			data: map[string]string{
+				denialMessageTemplateConfigKeyPrefix + validator.PodCreationMessageID:            "pod {{.name}} is denied",
+				denialMessageTemplateConfigKeyPrefix + "unknown":                                 "ignored",
+				denialMessageTemplateConfigKeyPrefix + validator.PlacementTypeImmutableMessageID: "{{.name",
+			},
+			want: validator.Config{
+				TrustedServiceAccounts: base.TrustedServiceAccounts,
+				MaxClusterNames:        10,
+				DenialMessageTemplates: map[string]string{validator.PodCreationMessageID: "pod {{.name}} is denied"},
+			},
+		},
+		"required labels": {
+			data: map[string]string{requiredLabelsConfigKey: `{"fleet.azure.com/team": "", "fleet.azure.com/cost-center": "^cc-[0-9]+$"}`},
+			want: validator.Config{
+				TrustedServiceAccounts: base.TrustedServiceAccounts,
+				MaxClusterNames:        10,
+				RequiredLabels: map[string]*regexp.Regexp{
+					"fleet.azure.com/team":        nil,
+					"fleet.azure.com/cost-center": regexp.MustCompile(`^cc-[0-9]+$`),
+				},
+			},
+		},
+		"invalid required labels are ignored": {
+			data: map[string]string{requiredLabelsConfigKey: `{"fleet.azure.com/cost-center": "^cc-[0-9+$"}`},
+			want: base,
+		},
+		"member cluster label schemas": {
+			data: map[string]string{memberClusterLabelSchemasConfigKey: `{"region": {"allowedValues": ["eastus", "westus"]}, "tier": {"regex": "^tier-[0-9]+$"}}`},
+			want: validator.Config{
+				TrustedServiceAccounts: base.TrustedServiceAccounts,
+				MaxClusterNames:        10,
+				LabelSchemas: map[string]*validator.LabelSchema{
+					"region": {AllowedValues: []string{"eastus", "westus"}},
+					"tier":   {Regex: regexp.MustCompile(`^tier-[0-9]+$`)},
+				},
+			},
+		},
+		"invalid member cluster label schemas are ignored": {
+			data: map[string]string{memberClusterLabelSchemasConfigKey: `{"owner": {"maxLength": -1}}`},
+			want: base,
+		},
+		"invalid numbers are ignored": {
+			data: map[string]string{
+				maxClusterNamesConfigKey:            "many",
+				metadataSizeSoftLimitBytesConfigKey: "0",
+				metadataSizeHardLimitBytesConfigKey: "-1",
+			},
+			want: base,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := applyConfigMapData(base, tc.data)
+			if diff := cmp.Diff(tc.want, got, regexpComparer); diff != "" {
+				t.Errorf("applyConfigMapData() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWatchConfigMap(t *testing.T) {
+	ctx := context.Background()
+	key := types.NamespacedName{Namespace: "fleet-system", Name: "fleet-webhook-config"}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+		Data:       map[string]string{maxClusterNamesConfigKey: "20"},
+	}
+	fakeClient := fake.NewClientBuilder().WithObjects(cm).Build()
+	informer := &fakeConfigMapInformer{}
+	w := &Config{trustedServiceAccounts: []string{"system:serviceaccount:fleet-system:startup"}}
+	startup := w.validatorConfig()
+	w.validatorConfigs = validator.NewConfigStore(startup)
+
+	if err := w.watchConfigMap(ctx, fakeClient, informer, key); err != nil {
+		t.Fatalf("watchConfigMap() = %v, want no error", err)
+	}
+	if informer.handler == nil {
+		t.Fatalf("watchConfigMap() registered no event handler")
+	}
+
+	informer.handler.OnAdd(cm, true)
+	want := startup
+	want.MaxClusterNames = 20
+	if diff := cmp.Diff(want, w.validatorConfigs.Load()); diff != "" {
+		t.Errorf("Load() after add mismatch (-want, +got):\n%s", diff)
+	}
+
+	updated := cm.DeepCopy()
+	updated.Data = map[string]string{
+		trustedServiceAccountsConfigKey:     "system:serviceaccount:fleet-system:reloaded",
+		metadataSizeHardLimitBytesConfigKey: "4096",
+	}
+	if err := fakeClient.Update(ctx, updated); err != nil {
+		t.Fatalf("Update() = %v, want no error", err)
+	}
+	informer.handler.OnUpdate(cm, updated)
+	want = validator.Config{
+		TrustedServiceAccounts:     []string{"system:serviceaccount:fleet-system:reloaded"},
+		MetadataSizeHardLimitBytes: 4096,
+	}
+	if diff := cmp.Diff(want, w.validatorConfigs.Load()); diff != "" {
+		t.Errorf("Load() after update mismatch (-want, +got):\n%s", diff)
+	}
+
+	other := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: "other"},
+		Data:       map[string]string{maxClusterNamesConfigKey: "1"},
+	}
+	informer.handler.OnAdd(other, false)
+	if diff := cmp.Diff(want, w.validatorConfigs.Load()); diff != "" {
+		t.Errorf("Load() after an unrelated ConfigMap event mismatch (-want, +got):\n%s", diff)
+	}
+
+	if err := fakeClient.Delete(ctx, updated); err != nil {
+		t.Fatalf("Delete() = %v, want no error", err)
+	}
+	informer.handler.OnDelete(toolscache.DeletedFinalStateUnknown{Key: key.String(), Obj: updated})
+	if diff := cmp.Diff(startup, w.validatorConfigs.Load()); diff != "" {
+		t.Errorf("Load() after delete mismatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestWatchConfigMapKeepsRequiredLabels(t *testing.T) {
+	ctx := context.Background()
+	key := types.NamespacedName{Namespace: "fleet-system", Name: "fleet-webhook-config"}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+		Data:       map[string]string{maxClusterNamesConfigKey: "20"},
+	}
+	fakeClient := fake.NewClientBuilder().WithObjects(cm).Build()
+	informer := &fakeConfigMapInformer{}
+	requiredLabels := map[string]*regexp.Regexp{"fleet.azure.com/team": regexp.MustCompile(`^[a-z]+$`)}
+	w := &Config{requiredLabels: requiredLabels}
+	w.validatorConfigs = validator.NewConfigStore(w.validatorConfig())
+
+	if err := w.watchConfigMap(ctx, fakeClient, informer, key); err != nil {
+		t.Fatalf("watchConfigMap() = %v, want no error", err)
+	}
+	informer.handler.OnAdd(cm, true)
+	if diff := cmp.Diff(requiredLabels, w.validatorConfigs.Load().RequiredLabels, regexpComparer); diff != "" {
+		t.Errorf("Load().RequiredLabels after reload mismatch (-want, +got):\n%s", diff)
+	}
+
+	updated := cm.DeepCopy()
+	updated.Data = map[string]string{requiredLabelsConfigKey: `{"fleet.azure.com/cost-center": ""}`}
+	if err := fakeClient.Update(ctx, updated); err != nil {
+		t.Fatalf("Update() = %v, want no error", err)
+	}
+	informer.handler.OnUpdate(cm, updated)
+	want := map[string]*regexp.Regexp{"fleet.azure.com/cost-center": nil}
+	if diff := cmp.Diff(want, w.validatorConfigs.Load().RequiredLabels, regexpComparer); diff != "" {
+		t.Errorf("Load().RequiredLabels after override mismatch (-want, +got):\n%s", diff)
+	}
+
+	if err := fakeClient.Delete(ctx, updated); err != nil {
+		t.Fatalf("Delete() = %v, want no error", err)
+	}
+	informer.handler.OnDelete(updated)
+	if diff := cmp.Diff(requiredLabels, w.validatorConfigs.Load().RequiredLabels, regexpComparer); diff != "" {
+		t.Errorf("Load().RequiredLabels after delete mismatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestWatchConfigMapKeepsMemberClusterLabelSchemas(t *testing.T) {
+	ctx := context.Background()
+	key := types.NamespacedName{Namespace: "fleet-system", Name: "fleet-webhook-config"}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+		Data:       map[string]string{maxClusterNamesConfigKey: "20"},
+	}
+	fakeClient := fake.NewClientBuilder().WithObjects(cm).Build()
+	informer := &fakeConfigMapInformer{}
+	schemas := map[string]*validator.LabelSchema{"region": {AllowedValues: []string{"eastus", "westus"}}}
+	w := &Config{memberClusterLabelSchemas: schemas}
+	w.validatorConfigs = validator.NewConfigStore(w.validatorConfig())
+
+	if err := w.watchConfigMap(ctx, fakeClient, informer, key); err != nil {
+		t.Fatalf("watchConfigMap() = %v, want no error", err)
+	}
+	informer.handler.OnAdd(cm, true)
+	if diff := cmp.Diff(schemas, w.validatorConfigs.Load().LabelSchemas, regexpComparer); diff != "" {
+		t.Errorf("Load().LabelSchemas after reload mismatch (-want, +got):\n%s", diff)
+	}
+
+	updated := cm.DeepCopy()
+	updated.Data = map[string]string{memberClusterLabelSchemasConfigKey: `{"tier": {"maxLength": 10}}`}
+	if err := fakeClient.Update(ctx, updated); err != nil {
+		t.Fatalf("Update() = %v, want no error", err)
+	}
+	informer.handler.OnUpdate(cm, updated)
+	want := map[string]*validator.LabelSchema{"tier": {MaxLength: 10}}
+	if diff := cmp.Diff(want, w.validatorConfigs.Load().LabelSchemas, regexpComparer); diff != "" {
+		t.Errorf("Load().LabelSchemas after override mismatch (-want, +got):\n%s", diff)
+	}
+
+	if err := fakeClient.Delete(ctx, updated); err != nil {
+		t.Fatalf("Delete() = %v, want no error", err)
+	}
+	informer.handler.OnDelete(updated)
+	if diff := cmp.Diff(schemas, w.validatorConfigs.Load().LabelSchemas, regexpComparer); diff != "" {
+		t.Errorf("Load().LabelSchemas after delete mismatch (-want, +got):\n%s", diff)
+	}
+}
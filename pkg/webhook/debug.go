@@ -0,0 +1,235 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	admv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+
+	"github.com/kubefleet-dev/kubefleet/cmd/hubagent/options"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils"
+)
+
+// DebugPath is the path on the manager's metrics server at which the webhook config state is served.
+const DebugPath = "/debug/fleet-webhook"
+
+// debugState is the JSON view of the webhook config served at DebugPath. The caBundle is only exposed
+// through its fingerprint.
+type debugState struct {
+	Role                          options.WebhookRole            `json:"role"`
+	WebhookNameSuffix             string                         `json:"webhookNameSuffix,omitempty"`
+	ServiceNamespace              string                         `json:"serviceNamespace"`
+	ServiceName                   string                         `json:"serviceName"`
+	ServicePort                   int32                          `json:"servicePort"`
+	TargetPort                    int32                          `json:"targetPort"`
+	ServiceURL                    string                         `json:"serviceURL"`
+	ServiceNames                  map[options.WebhookRole]string `json:"serviceNames,omitempty"`
+	ClientConnectionType          string                         `json:"clientConnectionType"`
+	EnableGuardRail               bool                           `json:"enableGuardRail"`
+	EnableWorkload                bool                           `json:"enableWorkload"`
+	DenyModifyMemberClusterLabels bool                           `json:"denyModifyMemberClusterLabels"`
+	CABundleSHA256                string                         `json:"caBundleSHA256,omitempty"`
+	CANotAfter                    *time.Time                     `json:"caNotAfter,omitempty"`
+	WebhookPaths                  []string                       `json:"webhookPaths"`
+	Validator                     debugValidatorState            `json:"validator"`
+	Configurations                []debugWebhookConfiguration    `json:"configurations"`
+}
+
+// debugValidatorState is the JSON view of the settings currently used by the fleet validators.
+type debugValidatorState struct {
+	TrustedServiceAccounts          []string          `json:"trustedServiceAccounts,omitempty"`
+	ShadowValidationRules           []string          `json:"shadowValidationRules,omitempty"`
+	MaxClusterNames                 int               `json:"maxClusterNames,omitempty"`
+	MetadataSizeSoftLimitBytes      int               `json:"metadataSizeSoftLimitBytes,omitempty"`
+	MetadataSizeHardLimitBytes      int               `json:"metadataSizeHardLimitBytes,omitempty"`
+	FleetNamespace                  string            `json:"fleetNamespace,omitempty"`
+	RequiredLabels                  map[string]string `json:"requiredLabels,omitempty"`
+	DenyPlacementNameCollisions     bool              `json:"denyPlacementNameCollisions"`
+	MaxPlacementsPerTeam            int               `json:"maxPlacementsPerTeam,omitempty"`
+	DenySpecUpdatesDuringUpdateRuns bool              `json:"denySpecUpdatesDuringUpdateRuns"`
+	RequireSecretPropagationOptIn   bool              `json:"requireSecretPropagationOptIn"`
+	DenyCRDCoSelection              bool              `json:"denyCRDCoSelection"`
+	DenyPlacementOverrideConflicts  bool              `json:"denyPlacementOverrideConflicts"`
+	AllowUnknownKinds               bool              `json:"allowUnknownKinds"`
+	IncidentWindowCheck             bool              `json:"incidentWindowCheck"`
+	WebhookCertSecretName           string            `json:"webhookCertSecretName,omitempty"`
+	EvictionTargetValidation        string            `json:"evictionTargetValidation,omitempty"`
+	ClusterNamesValidation          string            `json:"clusterNamesValidation,omitempty"`
+	NumberOfClustersValidation      string            `json:"numberOfClustersValidation,omitempty"`
+	PickAllFleetSizeValidation      string            `json:"pickAllFleetSizeValidation,omitempty"`
+	PickAllFleetSizeThreshold       int               `json:"pickAllFleetSizeThreshold,omitempty"`
+	StripAnnotationPrefixes         []string          `json:"stripAnnotationPrefixes,omitempty"`
+	RPDeniedTolerationKeyPrefixes   []string          `json:"resourcePlacementDeniedTolerationKeyPrefixes,omitempty"`
+	DenialMessageTemplates          map[string]string `json:"denialMessageTemplates,omitempty"`
+	LookupBudget                    string            `json:"lookupBudget,omitempty"`
+	LookupFailurePolicies           map[string]string `json:"lookupFailurePolicies,omitempty"`
+}
+
+// debugWebhookConfiguration is the JSON view of a webhook configuration the hub agent registers.
+type debugWebhookConfiguration struct {
+	Name     string         `json:"name"`
+	Kind     string         `json:"kind"`
+	Webhooks []debugWebhook `json:"webhooks"`
+}
+
+// debugWebhook is the JSON view of a webhook without its caBundle.
+type debugWebhook struct {
+	Name           string `json:"name"`
+	Endpoint       string `json:"endpoint"`
+	FailurePolicy  string `json:"failurePolicy"`
+	TimeoutSeconds int32  `json:"timeoutSeconds"`
+}
+
+// DebugHandler returns a read-only handler which renders the webhook config and the webhook configurations
+// it registers as JSON.
+func (w *Config) DebugHandler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			rw.Header().Set("Allow", http.MethodGet)
+			http.Error(rw, "only GET is allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(rw)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(w.debugState()); err != nil {
+			klog.ErrorS(err, "Failed to write the webhook debug state")
+		}
+	})
+}
+
+// debugState returns a snapshot of the webhook config state.
+func (w *Config) debugState() debugState {
+	vc := w.validatorConfigs.Load()
+	state := debugState{
+		Role:                          w.role,
+		WebhookNameSuffix:             w.webhookNameSuffix,
+		ServiceNamespace:              w.serviceNamespace,
+		ServiceName:                   w.serviceName,
+		ServicePort:                   w.servicePort,
+		TargetPort:                    w.targetPort,
+		ServiceURL:                    w.serviceURL,
+		ServiceNames:                  w.serviceNames,
+		EnableGuardRail:               w.enableGuardRail,
+		EnableWorkload:                w.enableWorkload,
+		DenyModifyMemberClusterLabels: w.denyModifyMemberClusterLabels,
+		WebhookPaths:                  utils.AllWebhookPaths(),
+		Validator: debugValidatorState{
+			TrustedServiceAccounts:          vc.TrustedServiceAccounts,
+			ShadowValidationRules:           vc.ShadowValidationRules,
+			MaxClusterNames:                 vc.MaxClusterNames,
+			MetadataSizeSoftLimitBytes:      vc.MetadataSizeSoftLimitBytes,
+			MetadataSizeHardLimitBytes:      vc.MetadataSizeHardLimitBytes,
+			FleetNamespace:                  vc.FleetNamespace,
+			DenyPlacementNameCollisions:     vc.DenyPlacementNameCollisions,
+			MaxPlacementsPerTeam:            vc.MaxPlacementsPerTeam,
+			DenySpecUpdatesDuringUpdateRuns: vc.DenySpecUpdatesDuringUpdateRuns,
+			RequireSecretPropagationOptIn:   vc.RequireSecretPropagationOptIn,
+			DenyCRDCoSelection:              vc.DenyCRDCoSelection,
+			DenyPlacementOverrideConflicts:  vc.DenyPlacementOverrideConflicts,
+			AllowUnknownKinds:               vc.AllowUnknownKinds,
+			IncidentWindowCheck:             vc.IncidentWindowChecker != nil,
+			WebhookCertSecretName:           vc.WebhookCertSecretName,
+			EvictionTargetValidation:        string(vc.EvictionTargetValidation),
+			ClusterNamesValidation:          string(vc.ClusterNamesValidation),
+			NumberOfClustersValidation:      string(vc.NumberOfClustersValidation),
+			PickAllFleetSizeValidation:      string(vc.PickAllFleetSizeValidation),
+			PickAllFleetSizeThreshold:       vc.PickAllFleetSizeThreshold,
+			StripAnnotationPrefixes:         vc.StripAnnotationPrefixes,
+			RPDeniedTolerationKeyPrefixes:   vc.ResourcePlacementDeniedTolerationKeyPrefixes,
+			DenialMessageTemplates:          vc.DenialMessageTemplates,
+		},
+		Configurations: []debugWebhookConfiguration{},
+	}
+	if vc.LookupBudget > 0 {
+		state.Validator.LookupBudget = vc.LookupBudget.String()
+	}
+	if len(vc.LookupFailurePolicies) > 0 {
+		state.Validator.LookupFailurePolicies = make(map[string]string, len(vc.LookupFailurePolicies))
+		for check, policy := range vc.LookupFailurePolicies {
+			state.Validator.LookupFailurePolicies[check] = string(policy)
+		}
+	}
+	if len(vc.RequiredLabels) > 0 {
+		state.Validator.RequiredLabels = make(map[string]string, len(vc.RequiredLabels))
+		for k, re := range vc.RequiredLabels {
+			state.Validator.RequiredLabels[k] = ""
+			if re != nil {
+				state.Validator.RequiredLabels[k] = re.String()
+			}
+		}
+	}
+	// The webhooks are reached through the service URL if the connection type is not set.
+	state.ClientConnectionType = string(ptr.Deref(w.clientConnectionType, options.URL))
+	if len(w.caPEM) > 0 {
+		sum := sha256.Sum256(w.caPEM)
+		state.CABundleSHA256 = hex.EncodeToString(sum[:])
+		// The caBundle may hold several CAs, the first of them to expire is reported.
+		if certs, err := parseCABundle(w.caPEM); err == nil {
+			for _, cert := range certs {
+				if state.CANotAfter == nil || cert.NotAfter.Before(*state.CANotAfter) {
+					state.CANotAfter = ptr.To(cert.NotAfter.UTC())
+				}
+			}
+		}
+	}
+	if webhooks := w.buildFleetMutatingWebhooks(); len(webhooks) > 0 {
+		config := debugWebhookConfiguration{Name: w.webhookConfigurationName(fleetMutatingWebhookCfgName), Kind: mutatingWebhookConfigurationKind}
+		for _, wh := range webhooks {
+			config.Webhooks = append(config.Webhooks, newDebugWebhook(wh.Name, wh.ClientConfig, wh.FailurePolicy, wh.TimeoutSeconds))
+		}
+		state.Configurations = append(state.Configurations, config)
+	}
+	appendValidating := func(name string, webhooks []admv1.ValidatingWebhook) {
+		if len(webhooks) == 0 {
+			return
+		}
+		config := debugWebhookConfiguration{Name: w.webhookConfigurationName(name), Kind: validatingWebhookConfigurationKind}
+		for _, wh := range webhooks {
+			config.Webhooks = append(config.Webhooks, newDebugWebhook(wh.Name, wh.ClientConfig, wh.FailurePolicy, wh.TimeoutSeconds))
+		}
+		state.Configurations = append(state.Configurations, config)
+	}
+	appendValidating(fleetValidatingWebhookCfgName, w.buildFleetValidatingWebhooks())
+	if w.enableGuardRail {
+		appendValidating(fleetGuardRailWebhookCfgName, w.buildFleetGuardRailValidatingWebhooks())
+	}
+	return state
+}
+
+// newDebugWebhook returns the JSON view of a webhook, leaving out its caBundle.
+func newDebugWebhook(name string, clientConfig admv1.WebhookClientConfig, failurePolicy *admv1.FailurePolicyType, timeoutSeconds *int32) debugWebhook {
+	wh := debugWebhook{
+		Name:           name,
+		FailurePolicy:  string(ptr.Deref(failurePolicy, admv1.Fail)),
+		TimeoutSeconds: ptr.Deref(timeoutSeconds, 10),
+	}
+	switch {
+	case clientConfig.URL != nil:
+		wh.Endpoint = *clientConfig.URL
+	case clientConfig.Service != nil:
+		wh.Endpoint = clientConfig.Service.Namespace + "/" + clientConfig.Service.Name + ptr.Deref(clientConfig.Service.Path, "")
+	}
+	return wh
+}
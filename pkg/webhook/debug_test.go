@@ -0,0 +1,159 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/kubefleet-dev/kubefleet/cmd/hubagent/options"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils"
+)
+
+func TestDebugHandler(t *testing.T) {
+	url := options.URL
+	service := options.Service
+	testCases := map[string]struct {
+		connectionType     *options.WebhookClientConnectionType
+		enableGuardRail    bool
+		enableWorkload     bool
+		role               options.WebhookRole
+		wantConfigurations []string
+		wantEndpointPrefix string
+	}{
+		"all webhooks through the service URL": {
+			connectionType:  &url,
+			enableGuardRail: true,
+			enableWorkload:  true,
+			role:            options.WebhookRoleAll,
+			wantConfigurations: []string{
+				"MutatingWebhookConfiguration/fleet-mutating-webhook-configuration",
+				"ValidatingWebhookConfiguration/fleet-validating-webhook-configuration",
+				"ValidatingWebhookConfiguration/fleet-guard-rail-webhook-configuration",
+			},
+			wantEndpointPrefix: "https://fleetwebhook.fleet-system.svc.cluster.local:8080/",
+		},
+		"guard rail role with guard rail disabled through the service reference": {
+			connectionType:     &service,
+			role:               options.WebhookRoleGuardRail,
+			wantConfigurations: []string{},
+		},
+		"placement role through the service reference": {
+			connectionType: &service,
+			role:           options.WebhookRolePlacement,
+			wantConfigurations: []string{
+				"MutatingWebhookConfiguration/fleet-mutating-webhook-configuration-placement",
+				"ValidatingWebhookConfiguration/fleet-validating-webhook-configuration-placement",
+			},
+			wantEndpointPrefix: "fleet-system/fleetwebhook/",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("POD_NAMESPACE", "fleet-system")
+			w, err := NewWebhookConfig(nil, Options{
+				ServiceName:          "fleetwebhook",
+				ServicePort:          8080,
+				TargetPort:           8080,
+				ClientConnectionType: tc.connectionType,
+				CertDir:              t.TempDir(),
+				Role:                 tc.role,
+				EnableGuardRail:      tc.enableGuardRail,
+				EnableWorkload:       tc.enableWorkload,
+			})
+			if err != nil {
+				t.Fatalf("NewWebhookConfig() = %v, want no error", err)
+			}
+
+			rec := httptest.NewRecorder()
+			w.DebugHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, DebugPath, nil))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("DebugHandler() status = %d, want %d", rec.Code, http.StatusOK)
+			}
+			if got := rec.Header().Get("Content-Type"); got != "application/json" {
+				t.Errorf("DebugHandler() content type = %s, want application/json", got)
+			}
+			body := rec.Body.String()
+			if strings.Contains(body, "BEGIN CERTIFICATE") || strings.Contains(body, "caBundle\"") {
+				t.Errorf("DebugHandler() exposes the caBundle: %s", body)
+			}
+
+			// Decode the known fields strictly so that the schema stays stable.
+			var got debugState
+			decoder := json.NewDecoder(strings.NewReader(body))
+			decoder.DisallowUnknownFields()
+			if err := decoder.Decode(&got); err != nil {
+				t.Fatalf("DebugHandler() returned invalid JSON: %v", err)
+			}
+			if got.Role != tc.role || got.EnableGuardRail != tc.enableGuardRail || got.EnableWorkload != tc.enableWorkload {
+				t.Errorf("DebugHandler() role/enableGuardRail/enableWorkload = %s/%t/%t, want %s/%t/%t", got.Role, got.EnableGuardRail, got.EnableWorkload, tc.role, tc.enableGuardRail, tc.enableWorkload)
+			}
+			if got.ClientConnectionType != string(*tc.connectionType) {
+				t.Errorf("DebugHandler() clientConnectionType = %s, want %s", got.ClientConnectionType, *tc.connectionType)
+			}
+			sum := sha256.Sum256(w.caPEM)
+			if want := hex.EncodeToString(sum[:]); got.CABundleSHA256 != want {
+				t.Errorf("DebugHandler() caBundleSHA256 = %s, want %s", got.CABundleSHA256, want)
+			}
+			if got.CANotAfter == nil || got.CANotAfter.Before(time.Now()) {
+				t.Errorf("DebugHandler() caNotAfter = %v, want a time in the future", got.CANotAfter)
+			}
+			if diff := cmp.Diff(utils.AllWebhookPaths(), got.WebhookPaths); diff != "" {
+				t.Errorf("DebugHandler() webhookPaths mismatch (-want, +got):\n%s", diff)
+			}
+			if got.Validator.FleetNamespace != "fleet-system" {
+				t.Errorf("DebugHandler() validator fleetNamespace = %s, want fleet-system", got.Validator.FleetNamespace)
+			}
+
+			gotConfigurations := []string{}
+			for _, config := range got.Configurations {
+				gotConfigurations = append(gotConfigurations, config.Kind+"/"+config.Name)
+				if len(config.Webhooks) == 0 {
+					t.Errorf("DebugHandler() configuration %s has no webhooks", config.Name)
+				}
+				for _, wh := range config.Webhooks {
+					if !strings.HasPrefix(wh.Endpoint, tc.wantEndpointPrefix) {
+						t.Errorf("DebugHandler() webhook %s endpoint = %s, want prefix %s", wh.Name, wh.Endpoint, tc.wantEndpointPrefix)
+					}
+					if wh.FailurePolicy == "" || wh.TimeoutSeconds == 0 {
+						t.Errorf("DebugHandler() webhook %s failurePolicy/timeoutSeconds = %q/%d, want both set", wh.Name, wh.FailurePolicy, wh.TimeoutSeconds)
+					}
+				}
+			}
+			if diff := cmp.Diff(tc.wantConfigurations, gotConfigurations); diff != "" {
+				t.Errorf("DebugHandler() configurations mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDebugHandlerReadOnly(t *testing.T) {
+	w := &Config{}
+	rec := httptest.NewRecorder()
+	w.DebugHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, DebugPath, nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("DebugHandler() status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fleetresourcehandler
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	hubmetrics "github.com/kubefleet-dev/kubefleet/pkg/metrics/hub"
+)
+
+// DelegationFailurePolicy is how a guard rail request is handled when the external authorizer times out or fails.
+type DelegationFailurePolicy string
+
+const (
+	// DelegationFallbackLocal uses the decision of the local guard rail logic.
+	DelegationFallbackLocal DelegationFailurePolicy = "local"
+	// DelegationFailClosed denies the request.
+	DelegationFailClosed DelegationFailurePolicy = "deny"
+)
+
+// DelegationFailurePolicies are the valid delegation failure policies.
+var DelegationFailurePolicies = []DelegationFailurePolicy{DelegationFallbackLocal, DelegationFailClosed}
+
+// The decisions of the external authorizer.
+const (
+	// DelegationDecisionAllow leaves the decision to the local guard rail logic.
+	DelegationDecisionAllow = "allow"
+	// DelegationDecisionDeny denies the request regardless of the local guard rail logic.
+	DelegationDecisionDeny = "deny"
+)
+
+// The outcomes of a delegation, which label the delegation metrics.
+const (
+	delegationOutcomeAllow   = "allow"
+	delegationOutcomeDeny    = "deny"
+	delegationOutcomeTimeout = "timeout"
+	delegationOutcomeError   = "error"
+)
+
+// maxDelegationResponseBytes is the maximum size of the external authorizer response which is read.
+const maxDelegationResponseBytes = 64 * 1024
+
+// DelegationConfig configures the delegation of the guard rail decisions to an external authorizer, e.g., an OPA
+// or an internal policy service.
+type DelegationConfig struct {
+	// URL is the HTTPS endpoint of the external authorizer, which receives a DelegationRequest as a POST body.
+	URL string
+	// CABundle is the PEM encoded CA bundle used to verify the serving certificate of the external authorizer.
+	// The system roots are used if it is empty.
+	CABundle []byte
+	// Timeout is how long a request waits for the external authorizer.
+	Timeout time.Duration
+	// Kinds are the kinds of the resources whose guard rail decisions are delegated.
+	Kinds []schema.GroupKind
+	// FailurePolicy is how a request is handled when the external authorizer times out or fails.
+	FailurePolicy DelegationFailurePolicy
+}
+
+// DelegationRequest is the compact JSON sent to the external authorizer.
+type DelegationRequest struct {
+	User      string   `json:"user"`
+	Groups    []string `json:"groups,omitempty"`
+	Group     string   `json:"group"`
+	Version   string   `json:"version"`
+	Kind      string   `json:"kind"`
+	Namespace string   `json:"namespace,omitempty"`
+	Name      string   `json:"name"`
+	Operation string   `json:"operation"`
+}
+
+// DelegationResponse is the JSON returned by the external authorizer.
+type DelegationResponse struct {
+	// Decision is either DelegationDecisionAllow or DelegationDecisionDeny.
+	Decision string `json:"decision"`
+	// Reason is included in the denial message.
+	Reason string `json:"reason,omitempty"`
+}
+
+// DelegationClient calls the external authorizer for the guard rail requests of the delegated kinds. A deny from the
+// external authorizer is authoritative, while an allow still leaves the request to the local guard rail logic. It is
+// safe for concurrent use as it is never modified after it is created.
+type DelegationClient struct {
+	url           string
+	httpClient    *http.Client
+	timeout       time.Duration
+	kinds         []schema.GroupKind
+	failurePolicy DelegationFailurePolicy
+}
+
+// NewDelegationClient validates the config and returns a delegation client.
+func NewDelegationClient(config DelegationConfig) (*DelegationClient, error) {
+	u, err := url.Parse(config.URL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return nil, fmt.Errorf("invalid guard rail delegation URL %q, must be an absolute https URL", config.URL)
+	}
+	if config.Timeout <= 0 {
+		return nil, fmt.Errorf("invalid guard rail delegation timeout %s, must be greater than 0", config.Timeout)
+	}
+	if len(config.Kinds) == 0 {
+		return nil, errors.New("no kind is delegated, at least one kind must be set")
+	}
+	if !slices.Contains(DelegationFailurePolicies, config.FailurePolicy) {
+		return nil, fmt.Errorf("invalid guard rail delegation failure policy %q, must be one of %v", config.FailurePolicy, DelegationFailurePolicies)
+	}
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if len(config.CABundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(config.CABundle) {
+			return nil, errors.New("the guard rail delegation CA bundle contains no valid PEM encoded certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return &DelegationClient{
+		url:           config.URL,
+		httpClient:    &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}},
+		timeout:       config.Timeout,
+		kinds:         slices.Clone(config.Kinds),
+		failurePolicy: config.FailurePolicy,
+	}, nil
+}
+
+// Delegates returns true if the guard rail decisions of the kind are delegated.
+func (c *DelegationClient) Delegates(kind schema.GroupKind) bool {
+	return slices.Contains(c.kinds, kind)
+}
+
+// Review returns the response to the request allowed by the local guard rail logic: the denial of the external
+// authorizer, the local response if the external authorizer allows the request, or the response chosen by the
+// failure policy if the external authorizer times out or fails.
+func (c *DelegationClient) Review(ctx context.Context, req admission.Request, local admission.Response) admission.Response {
+	start := time.Now()
+	resp, err := c.call(ctx, req)
+	hubmetrics.FleetGuardRailDelegationDurationSeconds.Observe(time.Since(start).Seconds())
+	if err != nil {
+		outcome := delegationOutcomeError
+		if errors.Is(err, context.DeadlineExceeded) {
+			outcome = delegationOutcomeTimeout
+		}
+		hubmetrics.FleetGuardRailDelegationRequestsTotal.WithLabelValues(outcome).Inc()
+		klog.ErrorS(err, "Failed to delegate the guard rail decision", "outcome", outcome, "failurePolicy", c.failurePolicy, "kind", req.Kind, "name", req.Name, "namespace", req.Namespace)
+		if c.failurePolicy == DelegationFailClosed {
+			return admission.Denied(fmt.Sprintf("the external authorizer could not decide on the request (%s), please retry the request", outcome))
+		}
+		return local
+	}
+	if resp.Decision == DelegationDecisionDeny {
+		hubmetrics.FleetGuardRailDelegationRequestsTotal.WithLabelValues(delegationOutcomeDeny).Inc()
+		klog.V(2).InfoS("The external authorizer denied the guard rail request", "user", req.UserInfo.Username, "kind", req.Kind, "name", req.Name, "namespace", req.Namespace, "reason", resp.Reason)
+		msg := "denied by the external authorizer"
+		if resp.Reason != "" {
+			msg += ": " + resp.Reason
+		}
+		return admission.Denied(msg)
+	}
+	hubmetrics.FleetGuardRailDelegationRequestsTotal.WithLabelValues(delegationOutcomeAllow).Inc()
+	return local
+}
+
+// call sends the request to the external authorizer and returns its response.
+func (c *DelegationClient) call(ctx context.Context, req admission.Request) (*DelegationResponse, error) {
+	body, err := json.Marshal(DelegationRequest{
+		User:      req.UserInfo.Username,
+		Groups:    req.UserInfo.Groups,
+		Group:     req.Kind.Group,
+		Version:   req.Kind.Version,
+		Kind:      req.Kind.Kind,
+		Namespace: req.Namespace,
+		Name:      req.Name,
+		Operation: string(req.Operation),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the delegation request: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build the delegation request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call the external authorizer: %w", err)
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("the external authorizer returned status %d", httpResp.StatusCode)
+	}
+	resp := &DelegationResponse{}
+	if err := json.NewDecoder(io.LimitReader(httpResp.Body, maxDelegationResponseBytes)).Decode(resp); err != nil {
+		return nil, fmt.Errorf("failed to decode the external authorizer response: %w", err)
+	}
+	if resp.Decision != DelegationDecisionAllow && resp.Decision != DelegationDecisionDeny {
+		return nil, fmt.Errorf("the external authorizer returned the unknown decision %q", resp.Decision)
+	}
+	return resp, nil
+}
@@ -0,0 +1,301 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fleetresourcehandler
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	hubmetrics "github.com/kubefleet-dev/kubefleet/pkg/metrics/hub"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils"
+)
+
+var namespaceGroupKind = schema.GroupKind{Kind: "Namespace"}
+
+// newAuthorizer starts a TLS server which answers every delegation request with the handler, and returns the
+// delegation config pointing at it.
+func newAuthorizer(t *testing.T, handler http.HandlerFunc, failurePolicy DelegationFailurePolicy) DelegationConfig {
+	t.Helper()
+	srv := httptest.NewTLSServer(handler)
+	t.Cleanup(srv.Close)
+	return DelegationConfig{
+		URL:           srv.URL,
+		CABundle:      pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}),
+		Timeout:       200 * time.Millisecond,
+		Kinds:         []schema.GroupKind{namespaceGroupKind},
+		FailurePolicy: failurePolicy,
+	}
+}
+
+// decide returns a handler which answers with the decision and records the last delegation request.
+func decide(decision string, got *DelegationRequest, mu *sync.Mutex) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		req := DelegationRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		*got = req
+		mu.Unlock()
+		_ = json.NewEncoder(rw).Encode(DelegationResponse{Decision: decision, Reason: "policy " + decision})
+	}
+}
+
+// hang does not answer until the request is cancelled or long after the delegation timeout. The body is read so
+// that the server notices the cancelled request.
+func hang(_ http.ResponseWriter, r *http.Request) {
+	_, _ = io.Copy(io.Discard, r.Body)
+	select {
+	case <-r.Context().Done():
+	case <-time.After(5 * time.Second):
+	}
+}
+
+func namespaceRequest(name string) admission.Request {
+	return admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Name:        name,
+			Namespace:   name,
+			Operation:   admissionv1.Create,
+			Kind:        utils.NamespaceMetaGVK,
+			RequestKind: &utils.NamespaceMetaGVK,
+			UserInfo: authenticationv1.UserInfo{
+				Username: "test-user",
+				Groups:   []string{"test-group"},
+			},
+		},
+	}
+}
+
+func TestHandleWithDelegation(t *testing.T) {
+	testCases := map[string]struct {
+		handler       func(got *DelegationRequest, mu *sync.Mutex) http.HandlerFunc
+		failurePolicy DelegationFailurePolicy
+		kinds         []schema.GroupKind
+		namespace     string
+		wantAllowed   bool
+		wantMessage   string
+		wantOutcome   string
+		wantRequest   *DelegationRequest
+	}{
+		"external allow keeps the local allow": {
+			handler: func(got *DelegationRequest, mu *sync.Mutex) http.HandlerFunc {
+				return decide(DelegationDecisionAllow, got, mu)
+			},
+			failurePolicy: DelegationFallbackLocal,
+			namespace:     "test-ns",
+			wantAllowed:   true,
+			wantMessage:   allowedMessageNonReservedNamespace,
+			wantOutcome:   delegationOutcomeAllow,
+			wantRequest: &DelegationRequest{
+				User:      "test-user",
+				Groups:    []string{"test-group"},
+				Version:   "v1",
+				Kind:      "Namespace",
+				Name:      "test-ns",
+				Operation: "CREATE",
+			},
+		},
+		"external deny is authoritative": {
+			handler: func(got *DelegationRequest, mu *sync.Mutex) http.HandlerFunc {
+				return decide(DelegationDecisionDeny, got, mu)
+			},
+			failurePolicy: DelegationFallbackLocal,
+			namespace:     "test-ns",
+			wantMessage:   "denied by the external authorizer: policy deny",
+			wantOutcome:   delegationOutcomeDeny,
+		},
+		"timeout falls back to the local decision": {
+			handler:       func(*DelegationRequest, *sync.Mutex) http.HandlerFunc { return hang },
+			failurePolicy: DelegationFallbackLocal,
+			namespace:     "test-ns",
+			wantAllowed:   true,
+			wantMessage:   allowedMessageNonReservedNamespace,
+			wantOutcome:   delegationOutcomeTimeout,
+		},
+		"timeout denies with the deny failure policy": {
+			handler:       func(*DelegationRequest, *sync.Mutex) http.HandlerFunc { return hang },
+			failurePolicy: DelegationFailClosed,
+			namespace:     "test-ns",
+			wantMessage:   "the external authorizer could not decide on the request (timeout), please retry the request",
+			wantOutcome:   delegationOutcomeTimeout,
+		},
+		"unknown decision is an error": {
+			handler: func(got *DelegationRequest, mu *sync.Mutex) http.HandlerFunc {
+				return decide("maybe", got, mu)
+			},
+			failurePolicy: DelegationFailClosed,
+			namespace:     "test-ns",
+			wantMessage:   "the external authorizer could not decide on the request (error), please retry the request",
+			wantOutcome:   delegationOutcomeError,
+		},
+		"kinds which are not delegated are not sent": {
+			handler: func(got *DelegationRequest, mu *sync.Mutex) http.HandlerFunc {
+				return decide(DelegationDecisionDeny, got, mu)
+			},
+			failurePolicy: DelegationFallbackLocal,
+			kinds:         []schema.GroupKind{{Group: "apps", Kind: "Deployment"}},
+			namespace:     "test-ns",
+			wantAllowed:   true,
+			wantMessage:   allowedMessageNonReservedNamespace,
+		},
+		"local denials are not sent": {
+			handler: func(got *DelegationRequest, mu *sync.Mutex) http.HandlerFunc {
+				return decide(DelegationDecisionAllow, got, mu)
+			},
+			failurePolicy: DelegationFallbackLocal,
+			namespace:     "fleet-system",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var mu sync.Mutex
+			var gotRequest DelegationRequest
+			config := newAuthorizer(t, tc.handler(&gotRequest, &mu), tc.failurePolicy)
+			if tc.kinds != nil {
+				config.Kinds = tc.kinds
+			}
+			delegation, err := NewDelegationClient(config)
+			if err != nil {
+				t.Fatalf("NewDelegationClient() = %v, want no error", err)
+			}
+			outcomes := []string{delegationOutcomeAllow, delegationOutcomeDeny, delegationOutcomeTimeout, delegationOutcomeError}
+			before := make(map[string]float64, len(outcomes))
+			for _, outcome := range outcomes {
+				before[outcome] = testutil.ToFloat64(hubmetrics.FleetGuardRailDelegationRequestsTotal.WithLabelValues(outcome))
+			}
+
+			v := fleetResourceValidator{delegation: delegation}
+			got := v.Handle(context.Background(), namespaceRequest(tc.namespace))
+			if got.Allowed != tc.wantAllowed {
+				t.Errorf("Handle() allowed = %t, want %t, message: %s", got.Allowed, tc.wantAllowed, got.Result.Message)
+			}
+			if tc.wantMessage != "" && got.Result.Message != tc.wantMessage {
+				t.Errorf("Handle() message = %q, want %q", got.Result.Message, tc.wantMessage)
+			}
+			for _, outcome := range outcomes {
+				want := 0.0
+				if outcome == tc.wantOutcome {
+					want = 1
+				}
+				if diff := testutil.ToFloat64(hubmetrics.FleetGuardRailDelegationRequestsTotal.WithLabelValues(outcome)) - before[outcome]; diff != want {
+					t.Errorf("fleet_guard_rail_delegation_requests_total{outcome=%q} increased by %v, want %v", outcome, diff, want)
+				}
+			}
+			if tc.wantRequest != nil {
+				mu.Lock()
+				defer mu.Unlock()
+				if diff := cmp.Diff(*tc.wantRequest, gotRequest); diff != "" {
+					t.Errorf("delegation request mismatch (-want, +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestDelegationConcurrentReviews(t *testing.T) {
+	var mu sync.Mutex
+	var gotRequest DelegationRequest
+	config := newAuthorizer(t, decide(DelegationDecisionDeny, &gotRequest, &mu), DelegationFallbackLocal)
+	// The concurrent TLS handshakes can take longer than the default test timeout under the race detector, which
+	// would fall back to the local allow, so the result must not depend on how fast they are.
+	config.Timeout = time.Minute
+	delegation, err := NewDelegationClient(config)
+	if err != nil {
+		t.Fatalf("NewDelegationClient() = %v, want no error", err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got := delegation.Review(context.Background(), namespaceRequest("test-ns"), admission.Allowed("")); got.Allowed {
+				t.Errorf("Review() allowed the request, want denied")
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestNewDelegationClient(t *testing.T) {
+	valid := DelegationConfig{
+		URL:           "https://authorizer.example.com/decide",
+		Timeout:       time.Second,
+		Kinds:         []schema.GroupKind{namespaceGroupKind},
+		FailurePolicy: DelegationFallbackLocal,
+	}
+	testCases := map[string]struct {
+		mutate  func(c *DelegationConfig)
+		wantErr string
+	}{
+		"valid": {
+			mutate: func(*DelegationConfig) {},
+		},
+		"plain http URL": {
+			mutate:  func(c *DelegationConfig) { c.URL = "http://authorizer.example.com" },
+			wantErr: "must be an absolute https URL",
+		},
+		"no timeout": {
+			mutate:  func(c *DelegationConfig) { c.Timeout = 0 },
+			wantErr: "must be greater than 0",
+		},
+		"no kinds": {
+			mutate:  func(c *DelegationConfig) { c.Kinds = nil },
+			wantErr: "at least one kind must be set",
+		},
+		"unknown failure policy": {
+			mutate:  func(c *DelegationConfig) { c.FailurePolicy = "allow" },
+			wantErr: "invalid guard rail delegation failure policy",
+		},
+		"invalid CA bundle": {
+			mutate:  func(c *DelegationConfig) { c.CABundle = []byte("not a certificate") },
+			wantErr: "no valid PEM encoded certificate",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := valid
+			tc.mutate(&config)
+			_, err := NewDelegationClient(config)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("NewDelegationClient() = %v, want no error", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("NewDelegationClient() = %v, want error containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
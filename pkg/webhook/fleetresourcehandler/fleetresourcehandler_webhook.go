@@ -4,10 +4,16 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"reflect"
 	"regexp"
+	"slices"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -16,16 +22,26 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	clusterv1beta1 "github.com/kubefleet-dev/kubefleet/apis/cluster/v1beta1"
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
 	"github.com/kubefleet-dev/kubefleet/pkg/utils"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
 	"github.com/kubefleet-dev/kubefleet/pkg/webhook/validation"
 )
 
+// ValidationPath is the webhook service path which admission requests are routed to for validating custom resource definition resources.
+var ValidationPath = utils.RegisterWebhookPath("", "", "fleetresourcehandler", utils.ValidatingWebhookPathKind)
+
 const (
-	// ValidationPath is the webhook service path which admission requests are routed to for validating custom resource definition resources.
-	ValidationPath = "/validate-fleetresourcehandler"
-	groupMatch     = `^[^.]*\.(.*)`
+	groupMatch = `^[^.]*\.(.*)`
+
+	// webhookName is the name of the guard rail webhook in the metrics and warnings.
+	webhookName = "guardrail"
 )
 
+// decodedKinds are the kinds whose objects the guard rail decodes, the requests of their other versions are of
+// unknown kinds.
+var decodedKinds = []metav1.GroupVersionKind{utils.MCMetaGVK, utils.ClusterResourcePlacementMetaGVK, utils.ResourcePlacementMetaGVK}
+
 const (
 	// allowed messages.
 	allowedMessageMemberCluster                   = "upstream member cluster resource is allowed to be created/deleted by any user"
@@ -33,14 +49,29 @@ const (
 	allowedMessageFleetReservedNamespacedResource = "namespace name of resource object doesn't begin with fleet-/kube- prefix so we allow all operations on request objects in these namespace"
 )
 
-// Add registers the webhook for K8s built-in object types.
-func Add(mgr manager.Manager, whiteListedUsers []string, denyModifyMemberClusterLabels bool) error {
+// Add registers the webhook for K8s built-in object types. The users matching any of the fleetRBACWriterPatterns
+// are allowed to modify the fleet-managed Roles and RoleBindings in fleet member namespaces, and the users matching
+// any of the fleetSnapshotWriterPatterns are allowed to modify the controller-owned snapshots and to remove the fleet
+// finalizers of the ClusterResourcePlacements. Only the white listed users and the admin group users are allowed to
+// create or update the ResourcePlacements whose selectors reach beyond the request namespace. The requests of the
+// delegated kinds allowed by the local guard rail logic are also reviewed by the external authorizer if the delegation
+// client is not nil.
+func Add(mgr manager.Manager, config *validator.ConfigStore, whiteListedUsers, fleetRBACWriterPatterns, fleetSnapshotWriterPatterns []string, denyModifyMemberClusterLabels bool, delegation *DelegationClient) error {
+	decoder, err := validation.SharedDecoder(mgr.GetScheme(), &clusterv1beta1.MemberCluster{})
+	if err != nil {
+		return err
+	}
 	hookServer := mgr.GetWebhookServer()
 	handler := &fleetResourceValidator{
 		client:                        mgr.GetClient(),
+		restMapper:                    mgr.GetRESTMapper(),
 		whiteListedUsers:              whiteListedUsers,
-		decoder:                       admission.NewDecoder(mgr.GetScheme()),
+		fleetRBACWriterPatterns:       fleetRBACWriterPatterns,
+		fleetSnapshotWriterPatterns:   fleetSnapshotWriterPatterns,
+		decoder:                       decoder,
 		denyModifyMemberClusterLabels: denyModifyMemberClusterLabels,
+		delegation:                    delegation,
+		config:                        config,
 	}
 	hookServer.Register(ValidationPath, &webhook.Admission{Handler: handler})
 	return nil
@@ -48,9 +79,16 @@ func Add(mgr manager.Manager, whiteListedUsers []string, denyModifyMemberCluster
 
 type fleetResourceValidator struct {
 	client                        client.Client
+	restMapper                    meta.RESTMapper
 	whiteListedUsers              []string
+	fleetRBACWriterPatterns       []string
+	fleetSnapshotWriterPatterns   []string
 	decoder                       webhook.AdmissionDecoder
 	denyModifyMemberClusterLabels bool
+	// delegation reviews the allowed requests of the delegated kinds, it is nil if the delegation is disabled.
+	delegation *DelegationClient
+	// config holds the validator settings of the requests.
+	config *validator.ConfigStore
 }
 
 // Handle receives the request then allows/denies the request to modify fleet resources.
@@ -60,35 +98,66 @@ func (v *fleetResourceValidator) Handle(ctx context.Context, req admission.Reque
 		req.Namespace = ""
 	}
 	namespacedName := types.NamespacedName{Name: req.Name, Namespace: req.Namespace}
+	logger := klog.FromContext(ctx)
+	if isDecodedGroupKind(req.Kind) {
+		if resp, handled := v.config.Load().HandleUnknownKind(req, webhookName, decodedKinds); handled {
+			return resp
+		}
+	}
 	var response admission.Response
 	if req.Operation == admissionv1.Create || req.Operation == admissionv1.Update || req.Operation == admissionv1.Delete {
 		switch {
 		case req.Kind == utils.CRDMetaGVK:
-			klog.V(2).InfoS("handling CRD resource", "name", req.Name, "operation", req.Operation, "subResource", req.SubResource)
+			logger.V(2).Info("handling CRD resource", "name", req.Name, "operation", req.Operation, "subResource", req.SubResource)
 			response = v.handleCRD(req)
 		case req.Kind == utils.MCMetaGVK:
-			klog.V(2).InfoS("handling member cluster resource", "name", req.Name, "operation", req.Operation, "subResource", req.SubResource)
-			response = v.handleMemberCluster(req)
+			logger.V(2).Info("handling member cluster resource", "name", req.Name, "operation", req.Operation, "subResource", req.SubResource)
+			response = v.handleMemberCluster(ctx, req)
 		case req.Kind == utils.NamespaceMetaGVK:
-			klog.V(2).InfoS("handling namespace resource", "name", req.Name, "operation", req.Operation, "subResource", req.SubResource)
-			response = v.handleNamespace(req)
+			logger.V(2).Info("handling namespace resource", "name", req.Name, "operation", req.Operation, "subResource", req.SubResource)
+			response = v.handleNamespace(ctx, req)
 		case req.Kind == utils.IMCMetaGVK || req.Kind == utils.WorkMetaGVK || req.Kind == utils.EndpointSliceExportMetaGVK || req.Kind == utils.EndpointSliceImportMetaGVK || req.Kind == utils.InternalServiceExportMetaGVK || req.Kind == utils.InternalServiceImportMetaGVK:
-			klog.V(2).InfoS("handling fleet owned namespaced resource in fleet reserved namespaces", "GVK", req.RequestKind, "namespacedName", namespacedName, "operation", req.Operation, "subResource", req.SubResource)
+			logger.V(2).Info("handling fleet owned namespaced resource in fleet reserved namespaces", "GVK", req.RequestKind, "namespacedName", namespacedName, "operation", req.Operation, "subResource", req.SubResource)
 			response = v.handleFleetReservedNamespacedResource(ctx, req)
+		case req.Kind == utils.ClusterResourceSnapshotMetaGVK || req.Kind == utils.ClusterSchedulingPolicySnapshotMetaGVK:
+			logger.V(2).Info("handling controller-owned snapshot", "GVK", req.RequestKind, "name", req.Name, "operation", req.Operation, "subResource", req.SubResource)
+			response = validation.ValidateUserForFleetSnapshot(v.config.Load(), req, v.whiteListedUsers, v.fleetSnapshotWriterPatterns)
+		case req.Kind == utils.ClusterResourcePlacementMetaGVK:
+			logger.V(2).Info("handling cluster resource placement finalizers", "name", req.Name, "operation", req.Operation, "subResource", req.SubResource)
+			response = v.handleClusterResourcePlacement(ctx, req)
+		case req.Kind == utils.ResourcePlacementMetaGVK:
+			logger.V(2).Info("handling resource placement reach", "namespacedName", namespacedName, "operation", req.Operation, "subResource", req.SubResource)
+			response = v.handleResourcePlacement(ctx, req)
 		case req.Kind == utils.EventMetaGVK:
-			klog.V(3).InfoS("handling event resource", "namespacedName", namespacedName, "operation", req.Operation, "subResource", req.SubResource)
+			logger.V(3).Info("handling event resource", "namespacedName", namespacedName, "operation", req.Operation, "subResource", req.SubResource)
 			response = v.handleEvent(ctx, req)
+		case req.Kind == utils.RoleMetaGVK || req.Kind == utils.RoleBindingMetaGVK:
+			logger.V(2).Info("handling RBAC resource", "GVK", req.RequestKind, "namespacedName", namespacedName, "operation", req.Operation, "subResource", req.SubResource)
+			response = v.handleRBAC(ctx, req)
+		case req.Kind == utils.SecretMetaGVK:
+			logger.V(2).Info("handling secret resource", "namespacedName", namespacedName, "operation", req.Operation, "subResource", req.SubResource)
+			response = v.handleSecret(ctx, req)
 		case req.Namespace != "":
-			klog.V(2).InfoS("handling namespaced resource in fleet reserved namespaces", "GVK", req.RequestKind, "namespacedName", namespacedName, "operation", req.Operation, "subResource", req.SubResource)
-			response = validation.ValidateUserForResource(req, v.whiteListedUsers)
+			logger.V(2).Info("handling namespaced resource in fleet reserved namespaces", "GVK", req.RequestKind, "namespacedName", namespacedName, "operation", req.Operation, "subResource", req.SubResource)
+			response = validation.ValidateUserForResource(v.config.Load(), req, v.whiteListedUsers)
 		default:
-			klog.V(3).InfoS("resource is not monitored by fleet resource validator webhook", "GVK", req.RequestKind, "namespacedName", namespacedName, "operation", req.Operation, "subResource", req.SubResource)
-			response = admission.Allowed(fmt.Sprintf("user: %s in groups: %v is allowed to modify resource with GVK: %s", req.UserInfo.Username, req.UserInfo.Groups, req.Kind.String()))
+			logger.V(3).Info("resource is not monitored by fleet resource validator webhook", "GVK", req.RequestKind, "namespacedName", namespacedName, "operation", req.Operation, "subResource", req.SubResource)
+			response = admission.Allowed(validator.AllowedMessage(fmt.Sprintf("%s by user: %s in groups: %v of unguarded resource with GVK: %s", req.Operation, req.UserInfo.Username, req.UserInfo.Groups, req.Kind.String()), 0, 0))
 		}
 	}
+	if response.Allowed && v.delegation != nil && v.delegation.Delegates(schema.GroupKind{Group: req.Kind.Group, Kind: req.Kind.Kind}) {
+		response = v.delegation.Review(ctx, req, response)
+	}
 	return response
 }
 
+// isDecodedGroupKind returns true if the group and kind of kind are the ones of any decoded kind, whatever the version.
+func isDecodedGroupKind(kind metav1.GroupVersionKind) bool {
+	return slices.ContainsFunc(decodedKinds, func(decoded metav1.GroupVersionKind) bool {
+		return decoded.Group == kind.Group && decoded.Kind == kind.Kind
+	})
+}
+
 // handleCRD allows/denies the request to modify CRD object after validation.
 func (v *fleetResourceValidator) handleCRD(req admission.Request) admission.Response {
 	var group string
@@ -97,13 +166,13 @@ func (v *fleetResourceValidator) handleCRD(req admission.Request) admission.Resp
 	if len(match) > 1 {
 		group = match[1]
 	}
-	return validation.ValidateUserForFleetCRD(req, v.whiteListedUsers, group)
+	return validation.ValidateUserForFleetCRD(v.config.Load(), req, v.whiteListedUsers, group)
 }
 
 // handleMemberCluster allows/denies the request to modify member cluster object after validation.
-func (v *fleetResourceValidator) handleMemberCluster(req admission.Request) admission.Response {
+func (v *fleetResourceValidator) handleMemberCluster(ctx context.Context, req admission.Request) admission.Response {
 	var currentMC clusterv1beta1.MemberCluster
-	if err := v.decodeRequestObject(req, &currentMC); err != nil {
+	if err := v.decodeRequestObject(ctx, req, &currentMC); err != nil {
 		return admission.Errored(http.StatusBadRequest, err)
 	}
 	if req.Operation == admissionv1.Update {
@@ -113,17 +182,106 @@ func (v *fleetResourceValidator) handleMemberCluster(req admission.Request) admi
 		}
 		isFleetMC := utils.IsFleetAnnotationPresent(oldMC.Annotations)
 		if isFleetMC {
-			return validation.ValidateFleetMemberClusterUpdate(currentMC, oldMC, req, v.whiteListedUsers, v.denyModifyMemberClusterLabels)
+			return v.validateMemberClusterLabelSchemas(ctx, req, currentMC.Labels, oldMC.Labels, validation.ValidateFleetMemberClusterUpdate(v.config.Load(), currentMC, oldMC, req, v.whiteListedUsers, v.denyModifyMemberClusterLabels))
 		}
-		return validation.ValidatedUpstreamMemberClusterUpdate(currentMC, oldMC, req, v.whiteListedUsers)
+		return v.validateMemberClusterLabelSchemas(ctx, req, currentMC.Labels, oldMC.Labels, validation.ValidatedUpstreamMemberClusterUpdate(v.config.Load(), currentMC, oldMC, req, v.whiteListedUsers))
 	}
 	isFleetMC := utils.IsFleetAnnotationPresent(currentMC.Annotations)
 	if isFleetMC {
-		return validation.ValidateUserForResource(req, v.whiteListedUsers)
+		return v.validateMemberClusterLabelSchemas(ctx, req, currentMC.Labels, nil, validation.ValidateUserForResource(v.config.Load(), req, v.whiteListedUsers))
 	}
-	klog.V(3).InfoS(allowedMessageMemberCluster,
+	klog.FromContext(ctx).V(3).Info(allowedMessageMemberCluster,
 		"user", req.UserInfo.Username, "groups", req.UserInfo.Groups, "operation", req.Operation, "kind", req.RequestKind.Kind, "subResource", req.SubResource, "namespacedName", types.NamespacedName{Name: req.Name, Namespace: req.Namespace})
-	return admission.Allowed(allowedMessageMemberCluster)
+	return v.validateMemberClusterLabelSchemas(ctx, req, currentMC.Labels, nil, admission.Allowed(allowedMessageMemberCluster))
+}
+
+// handleClusterResourcePlacement allows/denies the request to remove the fleet finalizers of a cluster resource
+// placement, the other changes are validated by the placement webhooks.
+func (v *fleetResourceValidator) handleClusterResourcePlacement(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Update {
+		return admission.Allowed(validator.AllowedMessage(fmt.Sprintf("%s of clusterResourcePlacement %s", req.Operation, req.Name), 0, 0))
+	}
+	var currentCRP, oldCRP placementv1beta1.ClusterResourcePlacement
+	if err := v.decodeRequestObject(ctx, req, &currentCRP); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if err := v.decoder.DecodeRaw(req.OldObject, &oldCRP); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	return validation.ValidateFleetFinalizerRemoval(v.config.Load(), req, currentCRP.Finalizers, oldCRP.Finalizers, v.whiteListedUsers, v.fleetSnapshotWriterPatterns)
+}
+
+// handleResourcePlacement allows/denies the request to create a resource placement or to update its selectors, whose
+// selectors reach beyond the request namespace. The other changes are validated by the placement webhooks. The resource
+// placements in the fleet/kube reserved namespaces are guarded like the other resources in these namespaces.
+func (v *fleetResourceValidator) handleResourcePlacement(ctx context.Context, req admission.Request) admission.Response {
+	if utils.IsReservedNamespace(req.Namespace) {
+		return validation.ValidateUserForResource(v.config.Load(), req, v.whiteListedUsers)
+	}
+	if req.Operation == admissionv1.Delete {
+		return admission.Allowed(validator.AllowedMessage(fmt.Sprintf("%s of resourcePlacement %s", req.Operation, types.NamespacedName{Name: req.Name, Namespace: req.Namespace}), 0, 0))
+	}
+	var currentRP placementv1beta1.ResourcePlacement
+	if err := v.decodeRequestObject(ctx, req, &currentRP); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if req.Operation == admissionv1.Update {
+		var oldRP placementv1beta1.ResourcePlacement
+		if err := v.decoder.DecodeRaw(req.OldObject, &oldRP); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		// the controllers keep updating the existing resource placements, only the selector changes are reviewed.
+		if reflect.DeepEqual(currentRP.Spec.ResourceSelectors, oldRP.Spec.ResourceSelectors) {
+			return admission.Allowed(validator.AllowedMessage(fmt.Sprintf("%s of resourcePlacement %s without selector changes", req.Operation, types.NamespacedName{Name: req.Name, Namespace: req.Namespace}), 0, 0))
+		}
+	}
+	return validation.ValidateResourcePlacementReach(v.config.Load(), req, v.resourcePlacementReaches(ctx, req.Namespace, &currentRP), v.whiteListedUsers)
+}
+
+// resourcePlacementReaches returns the descriptions of the selectors of the resource placement which reach beyond
+// namespace, i.e., the selectors of the cluster-scoped resources, including the namespaces, and the placement itself
+// if it does not live in namespace. The selectors of the unknown kinds are skipped as they are denied by the spec
+// validation.
+func (v *fleetResourceValidator) resourcePlacementReaches(ctx context.Context, namespace string, rp *placementv1beta1.ResourcePlacement) []string {
+	var reaches []string
+	if rp.Namespace != "" && rp.Namespace != namespace {
+		reaches = append(reaches, fmt.Sprintf("namespace %s", rp.Namespace))
+	}
+	for i, selector := range rp.Spec.ResourceSelectors {
+		gk := schema.GroupKind{Group: selector.Group, Kind: selector.Kind}
+		if gk == utils.NamespaceGVK.GroupKind() {
+			if selector.Name != namespace {
+				reaches = append(reaches, fmt.Sprintf("resourceSelectors[%d] selects namespace %q", i, selector.Name))
+			}
+			continue
+		}
+		if v.restMapper == nil {
+			continue
+		}
+		mapping, err := v.restMapper.RESTMapping(gk, selector.Version)
+		if err != nil {
+			klog.FromContext(ctx).V(3).Info("skipping the resource selector of unknown kind", "groupKind", gk, "version", selector.Version, "error", err)
+			continue
+		}
+		if mapping.Scope.Name() == meta.RESTScopeNameRoot {
+			reaches = append(reaches, fmt.Sprintf("resourceSelectors[%d] selects cluster-scoped %s", i, gk.String()))
+		}
+	}
+	return reaches
+}
+
+// validateMemberClusterLabelSchemas denies the create or update request allowed by resp if any label added or
+// updated on the member cluster does not match its schema.
+func (v *fleetResourceValidator) validateMemberClusterLabelSchemas(ctx context.Context, req admission.Request, labels, oldLabels map[string]string, resp admission.Response) admission.Response {
+	if !resp.Allowed || req.Operation == admissionv1.Delete {
+		return resp
+	}
+	if err := v.config.Load().ValidateLabelSchemas(labels, oldLabels); err != nil {
+		klog.FromContext(ctx).V(2).Info("member cluster labels do not match their schemas, request is denied",
+			"user", req.UserInfo.Username, "groups", req.UserInfo.Groups, "operation", req.Operation, "namespacedName", types.NamespacedName{Name: req.Name}, "error", err)
+		return admission.Denied(err.Error())
+	}
+	return resp
 }
 
 // handleFleetReservedNamespacedResource allows/denies the request to modify object after validation.
@@ -131,49 +289,101 @@ func (v *fleetResourceValidator) handleFleetReservedNamespacedResource(ctx conte
 	var response admission.Response
 	if utils.IsFleetMemberNamespace(req.Namespace) {
 		// check to see if valid users other than member agent is making the request.
-		response = validation.ValidateUserForResource(req, v.whiteListedUsers)
+		response = validation.ValidateUserForResource(v.config.Load(), req, v.whiteListedUsers)
 		// check to see if member agent is making the request only on Update.
 		if !response.Allowed {
 			// if namespace name is just "fleet-member", mcName variable becomes empty and the request is allowed since that namespaces is not watched by member agents.
 			mcName := parseMemberClusterNameFromNamespace(req.Namespace)
-			return validation.ValidateMCIdentity(ctx, v.client, req, mcName)
+			return validation.ValidateMCIdentity(ctx, v.client, v.config.Load(), req, mcName)
 		}
 		return response
 	} else if utils.IsReservedNamespace(req.Namespace) {
-		return validation.ValidateUserForResource(req, v.whiteListedUsers)
+		return validation.ValidateUserForResource(v.config.Load(), req, v.whiteListedUsers)
 	}
-	klog.V(3).InfoS(allowedMessageFleetReservedNamespacedResource,
+	klog.FromContext(ctx).V(3).Info(allowedMessageFleetReservedNamespacedResource,
 		"user", req.UserInfo.Username, "groups", req.UserInfo.Groups, "operation", req.Operation, "kind", req.RequestKind.Kind, "subResource", req.SubResource, "namespacedName", types.NamespacedName{Name: req.Name, Namespace: req.Namespace})
 	return admission.Allowed(allowedMessageFleetReservedNamespacedResource)
 }
 
+// handleRBAC allows/denies the request to modify Role/RoleBinding after validation. The fleet-managed Roles and
+// RoleBindings in fleet member namespaces grant the member agents access to the hub cluster, hence they can only be
+// modified by the fleet RBAC writers.
+func (v *fleetResourceValidator) handleRBAC(ctx context.Context, req admission.Request) admission.Response {
+	if utils.IsFleetMemberNamespace(req.Namespace) {
+		isFleetManaged, err := v.isFleetManagedObject(ctx, req)
+		if err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if isFleetManaged {
+			return validation.ValidateUserForFleetRBAC(v.config.Load(), req, v.whiteListedUsers, v.fleetRBACWriterPatterns)
+		}
+	}
+	return validation.ValidateUserForResource(v.config.Load(), req, v.whiteListedUsers)
+}
+
+// handleSecret allows/denies the request to modify a Secret after validation. The Secret holding the webhook serving
+// certificate can only be updated or deleted by the hub controllers, i.e., the users matching the fleet snapshot writer
+// patterns, and the white listed users, as corrupting it takes down the admission of the fleet. The other Secrets in
+// the fleet/kube reserved namespaces are guarded like the other resources in these namespaces, and the ones in the
+// other namespaces, which only reach the guard rail through the webhook cert Secret webhook, are left untouched.
+func (v *fleetResourceValidator) handleSecret(ctx context.Context, req admission.Request) admission.Response {
+	if (req.Operation == admissionv1.Update || req.Operation == admissionv1.Delete) && v.config.Load().IsWebhookCertSecret(req.Namespace, req.Name) {
+		return validation.ValidateUserForWebhookCertSecret(v.config.Load(), req, v.whiteListedUsers, v.fleetSnapshotWriterPatterns)
+	}
+	if utils.IsReservedNamespace(req.Namespace) {
+		return validation.ValidateUserForResource(v.config.Load(), req, v.whiteListedUsers)
+	}
+	klog.FromContext(ctx).V(3).Info(allowedMessageFleetReservedNamespacedResource,
+		"user", req.UserInfo.Username, "groups", req.UserInfo.Groups, "operation", req.Operation, "kind", req.RequestKind.Kind, "subResource", req.SubResource, "namespacedName", types.NamespacedName{Name: req.Name, Namespace: req.Namespace})
+	return admission.Allowed(allowedMessageFleetReservedNamespacedResource)
+}
+
+// isFleetManagedObject returns true if the object of the request carries the fleet-managed label. The old object is
+// also checked so that the label cannot be removed to bypass the validation.
+func (v *fleetResourceValidator) isFleetManagedObject(ctx context.Context, req admission.Request) (bool, error) {
+	for _, raw := range []runtime.RawExtension{req.Object, req.OldObject} {
+		if len(raw.Raw) == 0 {
+			continue
+		}
+		var obj unstructured.Unstructured
+		if err := v.decoder.DecodeRaw(raw, &obj); err != nil {
+			klog.FromContext(ctx).Error(err, "failed to decode request object", "userName", req.UserInfo.Username, "groups", req.UserInfo.Groups)
+			return false, err
+		}
+		if obj.GetLabels()[utils.LabelFleetObj] == utils.LabelFleetObjValue {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // handleEvent allows/denies request to modify event after validation.
-func (v *fleetResourceValidator) handleEvent(_ context.Context, _ admission.Request) admission.Response {
+func (v *fleetResourceValidator) handleEvent(_ context.Context, req admission.Request) admission.Response {
 	// currently allowing all events will handle events after v1alpha1 resources are removed.
-	return admission.Allowed("all events are allowed")
+	return admission.Allowed(validator.AllowedMessage(fmt.Sprintf("%s of event %s", req.Operation, types.NamespacedName{Name: req.Name, Namespace: req.Namespace}), 0, 0))
 }
 
 // handlerNamespace allows/denies request to modify namespace after validation.
-func (v *fleetResourceValidator) handleNamespace(req admission.Request) admission.Response {
+func (v *fleetResourceValidator) handleNamespace(ctx context.Context, req admission.Request) admission.Response {
 	if utils.IsReservedNamespace(req.Name) {
-		return validation.ValidateUserForResource(req, v.whiteListedUsers)
+		return validation.ValidateUserForResource(v.config.Load(), req, v.whiteListedUsers)
 	}
-	klog.V(3).InfoS(allowedMessageNonReservedNamespace,
+	klog.FromContext(ctx).V(3).Info(allowedMessageNonReservedNamespace,
 		"user", req.UserInfo.Username, "groups", req.UserInfo.Groups, "operation", req.Operation, "kind", req.RequestKind.Kind, "subResource", req.SubResource, "namespacedName", types.NamespacedName{Name: req.Name, Namespace: req.Namespace})
 	return admission.Allowed(allowedMessageNonReservedNamespace)
 }
 
 // decodeRequestObject decodes the request object into the passed runtime object.
-func (v *fleetResourceValidator) decodeRequestObject(req admission.Request, obj runtime.Object) error {
+func (v *fleetResourceValidator) decodeRequestObject(ctx context.Context, req admission.Request, obj runtime.Object) error {
 	if req.Operation == admissionv1.Delete {
 		// req.Object is not populated for delete: https://github.com/kubernetes-sigs/controller-runtime/issues/1762.
 		if err := v.decoder.DecodeRaw(req.OldObject, obj); err != nil {
-			klog.ErrorS(err, "failed to decode old request object for delete operation", "userName", req.UserInfo.Username, "groups", req.UserInfo.Groups)
+			klog.FromContext(ctx).Error(err, "failed to decode old request object for delete operation", "userName", req.UserInfo.Username, "groups", req.UserInfo.Groups)
 			return err
 		}
 	} else {
 		if err := v.decoder.Decode(req, obj); err != nil {
-			klog.ErrorS(err, "failed to decode request object for create/update operation", "userName", req.UserInfo.Username, "groups", req.UserInfo.Groups)
+			klog.FromContext(ctx).Error(err, "failed to decode request object for create/update operation", "userName", req.UserInfo.Username, "groups", req.UserInfo.Groups)
 			return err
 		}
 	}
@@ -12,6 +12,7 @@ import (
 	admissionv1 "k8s.io/api/admission/v1"
 	authenticationv1 "k8s.io/api/authentication/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -19,7 +20,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	clusterv1beta1 "github.com/kubefleet-dev/kubefleet/apis/cluster/v1beta1"
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
 	"github.com/kubefleet-dev/kubefleet/pkg/utils"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
 	"github.com/kubefleet-dev/kubefleet/pkg/webhook/validation"
 )
 
@@ -531,12 +534,570 @@ func TestHandleMemberCluster(t *testing.T) {
 
 	for testName, testCase := range testCases {
 		t.Run(testName, func(t *testing.T) {
-			gotResult := testCase.resourceValidator.handleMemberCluster(testCase.req)
+			gotResult := testCase.resourceValidator.handleMemberCluster(context.Background(), testCase.req)
 			assert.Equal(t, testCase.wantResponse, gotResult, utils.TestCaseMsg, testName)
 		})
 	}
 }
 
+func TestHandleMemberClusterLabelSchemas(t *testing.T) {
+	config := validator.NewConfigStore(validator.Config{
+		LabelSchemas: map[string]*validator.LabelSchema{
+			"region": {AllowedValues: []string{"eastus", "westus"}},
+		},
+	})
+	mcBytes := func(labels map[string]string) []byte {
+		raw, err := json.Marshal(&clusterv1beta1.MemberCluster{
+			TypeMeta:   metav1.TypeMeta{Kind: "MemberCluster"},
+			ObjectMeta: metav1.ObjectMeta{Name: "test-mc", Labels: labels},
+		})
+		assert.Nil(t, err)
+		return raw
+	}
+	scheme := runtime.NewScheme()
+	assert.Nil(t, clusterv1beta1.AddToScheme(scheme))
+	v := fleetResourceValidator{decoder: admission.NewDecoder(scheme), config: config}
+	userInfo := authenticationv1.UserInfo{Username: "test-user", Groups: []string{"test-group"}}
+	wantUpdateAllowed := admission.Allowed(fmt.Sprintf(validation.ResourceAllowedFormat, "test-user", utils.GenerateGroupString([]string{"test-group"}), admissionv1.Update, &utils.MCMetaGVK, "", types.NamespacedName{Name: "test-mc"}))
+	wantDenied := admission.Denied(`the value "centralus" of label "region" is not allowed, it must be one of [eastus, westus]`)
+
+	testCases := map[string]struct {
+		operation    admissionv1.Operation
+		labels       map[string]string
+		oldLabels    map[string]string
+		wantResponse admission.Response
+	}{
+		"allow create with a label matching its schema": {
+			operation:    admissionv1.Create,
+			labels:       map[string]string{"region": "eastus"},
+			wantResponse: admission.Allowed(allowedMessageMemberCluster),
+		},
+		"deny create with a label not matching its schema": {
+			operation:    admissionv1.Create,
+			labels:       map[string]string{"region": "centralus"},
+			wantResponse: wantDenied,
+		},
+		"allow update to a label value matching its schema": {
+			operation:    admissionv1.Update,
+			labels:       map[string]string{"region": "westus"},
+			oldLabels:    map[string]string{"region": "eastus"},
+			wantResponse: wantUpdateAllowed,
+		},
+		"deny update to a label value not matching its schema": {
+			operation:    admissionv1.Update,
+			labels:       map[string]string{"region": "centralus"},
+			oldLabels:    map[string]string{"region": "eastus"},
+			wantResponse: wantDenied,
+		},
+		"allow update keeping an existing label value not matching its schema": {
+			operation:    admissionv1.Update,
+			labels:       map[string]string{"region": "centralus", "env": "prod"},
+			oldLabels:    map[string]string{"region": "centralus"},
+			wantResponse: wantUpdateAllowed,
+		},
+		"allow delete with a label not matching its schema": {
+			operation:    admissionv1.Delete,
+			oldLabels:    map[string]string{"region": "centralus"},
+			wantResponse: admission.Allowed(allowedMessageMemberCluster),
+		},
+	}
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			req := admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Name:        "test-mc",
+					UserInfo:    userInfo,
+					RequestKind: &utils.MCMetaGVK,
+					Operation:   tc.operation,
+				},
+			}
+			if tc.operation != admissionv1.Delete {
+				req.Object = runtime.RawExtension{Raw: mcBytes(tc.labels)}
+			}
+			if tc.operation != admissionv1.Create {
+				req.OldObject = runtime.RawExtension{Raw: mcBytes(tc.oldLabels)}
+			}
+			gotResult := v.handleMemberCluster(context.Background(), req)
+			assert.Equal(t, tc.wantResponse, gotResult, utils.TestCaseMsg, testName)
+		})
+	}
+}
+
+func TestHandleClusterResourcePlacementFinalizers(t *testing.T) {
+	crpBytes := func(finalizers []string) []byte {
+		raw, err := json.Marshal(&placementv1beta1.ClusterResourcePlacement{
+			TypeMeta:   metav1.TypeMeta{APIVersion: placementv1beta1.GroupVersion.String(), Kind: placementv1beta1.ClusterResourcePlacementKind},
+			ObjectMeta: metav1.ObjectMeta{Name: "test-crp", Finalizers: finalizers},
+		})
+		assert.Nil(t, err)
+		return raw
+	}
+	scheme := runtime.NewScheme()
+	assert.Nil(t, placementv1beta1.AddToScheme(scheme))
+	v := fleetResourceValidator{
+		decoder:                     admission.NewDecoder(scheme),
+		whiteListedUsers:            []string{"white-listed-user"},
+		fleetSnapshotWriterPatterns: []string{"system:serviceaccount:fleet-system:*"},
+	}
+	testUser := authenticationv1.UserInfo{Username: "test-user", Groups: []string{"test-group"}}
+	fleetController := authenticationv1.UserInfo{Username: "system:serviceaccount:fleet-system:hub-agent-sa", Groups: []string{"system:serviceaccounts"}}
+
+	testCases := map[string]struct {
+		userInfo      authenticationv1.UserInfo
+		finalizers    []string
+		oldFinalizers []string
+		wantAllowed   bool
+	}{
+		"deny user removing the fleet finalizer": {
+			userInfo:      testUser,
+			oldFinalizers: []string{placementv1beta1.PlacementCleanupFinalizer},
+		},
+		"allow fleet controller removing the fleet finalizer": {
+			userInfo:      fleetController,
+			oldFinalizers: []string{placementv1beta1.PlacementCleanupFinalizer},
+			wantAllowed:   true,
+		},
+		"allow user adding a finalizer": {
+			userInfo:      testUser,
+			finalizers:    []string{placementv1beta1.PlacementCleanupFinalizer, "example.com/other"},
+			oldFinalizers: []string{placementv1beta1.PlacementCleanupFinalizer},
+			wantAllowed:   true,
+		},
+	}
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			req := admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Name:        "test-crp",
+					UserInfo:    tc.userInfo,
+					Kind:        utils.ClusterResourcePlacementMetaGVK,
+					RequestKind: &utils.ClusterResourcePlacementMetaGVK,
+					Operation:   admissionv1.Update,
+					Object:      runtime.RawExtension{Raw: crpBytes(tc.finalizers)},
+					OldObject:   runtime.RawExtension{Raw: crpBytes(tc.oldFinalizers)},
+				},
+			}
+			gotResult := v.Handle(context.Background(), req)
+			assert.Equal(t, tc.wantAllowed, gotResult.Allowed, utils.TestCaseMsg, testName)
+		})
+	}
+}
+
+func TestHandleResourcePlacement(t *testing.T) {
+	rpBytes := func(selectors []placementv1beta1.ResourceSelectorTerm) []byte {
+		raw, err := json.Marshal(&placementv1beta1.ResourcePlacement{
+			TypeMeta:   metav1.TypeMeta{APIVersion: placementv1beta1.GroupVersion.String(), Kind: placementv1beta1.ResourcePlacementKind},
+			ObjectMeta: metav1.ObjectMeta{Name: "test-rp", Namespace: "test-ns"},
+			Spec:       placementv1beta1.PlacementSpec{ResourceSelectors: selectors},
+		})
+		assert.Nil(t, err)
+		return raw
+	}
+	scheme := runtime.NewScheme()
+	assert.Nil(t, placementv1beta1.AddToScheme(scheme))
+	restMapper := meta.NewDefaultRESTMapper(nil)
+	restMapper.Add(utils.DeploymentGVK, meta.RESTScopeNamespace)
+	restMapper.Add(utils.ClusterRoleGVK, meta.RESTScopeRoot)
+	v := fleetResourceValidator{
+		decoder:          admission.NewDecoder(scheme),
+		restMapper:       restMapper,
+		whiteListedUsers: []string{"white-listed-user"},
+	}
+	namespaceAdmin := authenticationv1.UserInfo{Username: "test-user", Groups: []string{"test-ns-admins"}}
+	fleetAdmin := authenticationv1.UserInfo{Username: "mastersUser", Groups: []string{"system:masters"}}
+	deploymentSelector := placementv1beta1.ResourceSelectorTerm{Group: utils.DeploymentGVK.Group, Version: utils.DeploymentGVK.Version, Kind: utils.DeploymentGVK.Kind, Name: "test-deployment"}
+	clusterRoleSelector := placementv1beta1.ResourceSelectorTerm{Group: utils.ClusterRoleGVK.Group, Version: utils.ClusterRoleGVK.Version, Kind: utils.ClusterRoleGVK.Kind, Name: "test-cluster-role"}
+	otherNamespaceSelector := placementv1beta1.ResourceSelectorTerm{Group: "", Version: "v1", Kind: "Namespace", Name: "other-ns"}
+
+	testCases := map[string]struct {
+		userInfo     authenticationv1.UserInfo
+		operation    admissionv1.Operation
+		namespace    string
+		selectors    []placementv1beta1.ResourceSelectorTerm
+		oldSelectors []placementv1beta1.ResourceSelectorTerm
+		wantAllowed  bool
+	}{
+		"allow namespace admin placing the resources of its own namespace": {
+			userInfo:    namespaceAdmin,
+			operation:   admissionv1.Create,
+			namespace:   "test-ns",
+			selectors:   []placementv1beta1.ResourceSelectorTerm{deploymentSelector},
+			wantAllowed: true,
+		},
+		"deny namespace admin selecting a cluster-scoped resource": {
+			userInfo:  namespaceAdmin,
+			operation: admissionv1.Create,
+			namespace: "test-ns",
+			selectors: []placementv1beta1.ResourceSelectorTerm{deploymentSelector, clusterRoleSelector},
+		},
+		"deny namespace admin selecting another namespace": {
+			userInfo:  namespaceAdmin,
+			operation: admissionv1.Create,
+			namespace: "test-ns",
+			selectors: []placementv1beta1.ResourceSelectorTerm{otherNamespaceSelector},
+		},
+		"deny namespace admin adding a cluster-scoped selector": {
+			userInfo:     namespaceAdmin,
+			operation:    admissionv1.Update,
+			namespace:    "test-ns",
+			selectors:    []placementv1beta1.ResourceSelectorTerm{deploymentSelector, clusterRoleSelector},
+			oldSelectors: []placementv1beta1.ResourceSelectorTerm{deploymentSelector},
+		},
+		"allow namespace admin updating a placement without selector changes": {
+			userInfo:     namespaceAdmin,
+			operation:    admissionv1.Update,
+			namespace:    "test-ns",
+			selectors:    []placementv1beta1.ResourceSelectorTerm{clusterRoleSelector},
+			oldSelectors: []placementv1beta1.ResourceSelectorTerm{clusterRoleSelector},
+			wantAllowed:  true,
+		},
+		"allow fleet admin selecting a cluster-scoped resource and another namespace": {
+			userInfo:    fleetAdmin,
+			operation:   admissionv1.Create,
+			namespace:   "test-ns",
+			selectors:   []placementv1beta1.ResourceSelectorTerm{clusterRoleSelector, otherNamespaceSelector},
+			wantAllowed: true,
+		},
+		"allow white listed user selecting a cluster-scoped resource": {
+			userInfo:    authenticationv1.UserInfo{Username: "white-listed-user"},
+			operation:   admissionv1.Create,
+			namespace:   "test-ns",
+			selectors:   []placementv1beta1.ResourceSelectorTerm{clusterRoleSelector},
+			wantAllowed: true,
+		},
+		"deny namespace admin creating a placement in a fleet reserved namespace": {
+			userInfo:  namespaceAdmin,
+			operation: admissionv1.Create,
+			namespace: "fleet-system",
+			selectors: []placementv1beta1.ResourceSelectorTerm{deploymentSelector},
+		},
+	}
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			req := admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Name:        "test-rp",
+					Namespace:   tc.namespace,
+					UserInfo:    tc.userInfo,
+					Kind:        utils.ResourcePlacementMetaGVK,
+					RequestKind: &utils.ResourcePlacementMetaGVK,
+					Operation:   tc.operation,
+					Object:      runtime.RawExtension{Raw: rpBytes(tc.selectors)},
+				},
+			}
+			if tc.operation == admissionv1.Update {
+				req.OldObject = runtime.RawExtension{Raw: rpBytes(tc.oldSelectors)}
+			}
+			gotResult := v.Handle(context.Background(), req)
+			assert.Equal(t, tc.wantAllowed, gotResult.Allowed, utils.TestCaseMsg, testName)
+		})
+	}
+}
+
+func TestHandleRBAC(t *testing.T) {
+	roleBytes := func(namespace string, labels map[string]string) []byte {
+		raw, err := json.Marshal(&rbacv1.Role{
+			TypeMeta:   metav1.TypeMeta{Kind: "Role", APIVersion: rbacv1.SchemeGroupVersion.String()},
+			ObjectMeta: metav1.ObjectMeta{Name: "fleet-role-mc1", Namespace: namespace, Labels: labels},
+		})
+		assert.Nil(t, err)
+		return raw
+	}
+	fleetManagedLabels := map[string]string{utils.LabelFleetObj: utils.LabelFleetObjValue}
+	hubAgent := authenticationv1.UserInfo{Username: "system:serviceaccount:fleet-system:hub-agent-sa", Groups: []string{"system:serviceaccounts"}}
+	memberAgent := authenticationv1.UserInfo{Username: "system:serviceaccount:fleet-member-mc1:member-agent-sa", Groups: []string{"system:serviceaccounts"}}
+	otherServiceAccount := authenticationv1.UserInfo{Username: "system:serviceaccount:fleet-member-mc1:other-sa", Groups: []string{"system:serviceaccounts"}}
+	masters := authenticationv1.UserInfo{Username: "mastersUser", Groups: []string{"system:masters"}}
+	user := authenticationv1.UserInfo{Username: "test-user", Groups: []string{"system:authenticated"}}
+	v := fleetResourceValidator{
+		decoder:                 admission.NewDecoder(runtime.NewScheme()),
+		whiteListedUsers:        []string{"white-listed-user"},
+		fleetRBACWriterPatterns: []string{"system:serviceaccount:fleet-system:hub-agent-sa", "system:serviceaccount:fleet-member-*:member-agent-sa"},
+	}
+
+	testCases := map[string]struct {
+		namespace   string
+		userInfo    authenticationv1.UserInfo
+		operation   admissionv1.Operation
+		labels      map[string]string
+		oldLabels   map[string]string
+		wantAllowed bool
+	}{
+		"allow hub agent to update fleet-managed role": {
+			namespace:   "fleet-member-mc1",
+			userInfo:    hubAgent,
+			operation:   admissionv1.Update,
+			labels:      fleetManagedLabels,
+			oldLabels:   fleetManagedLabels,
+			wantAllowed: true,
+		},
+		"allow member agent to create fleet-managed role": {
+			namespace:   "fleet-member-mc1",
+			userInfo:    memberAgent,
+			operation:   admissionv1.Create,
+			labels:      fleetManagedLabels,
+			wantAllowed: true,
+		},
+		"allow user in system:masters group to delete fleet-managed role": {
+			namespace:   "fleet-member-mc1",
+			userInfo:    masters,
+			operation:   admissionv1.Delete,
+			oldLabels:   fleetManagedLabels,
+			wantAllowed: true,
+		},
+		"allow white listed user to update fleet-managed role": {
+			namespace:   "fleet-member-mc1",
+			userInfo:    authenticationv1.UserInfo{Username: "white-listed-user", Groups: []string{"system:authenticated"}},
+			operation:   admissionv1.Update,
+			labels:      fleetManagedLabels,
+			oldLabels:   fleetManagedLabels,
+			wantAllowed: true,
+		},
+		"deny other service account to update fleet-managed role": {
+			namespace: "fleet-member-mc1",
+			userInfo:  otherServiceAccount,
+			operation: admissionv1.Update,
+			labels:    fleetManagedLabels,
+			oldLabels: fleetManagedLabels,
+		},
+		"deny other service account to remove the fleet-managed label": {
+			namespace: "fleet-member-mc1",
+			userInfo:  otherServiceAccount,
+			operation: admissionv1.Update,
+			oldLabels: fleetManagedLabels,
+		},
+		"deny user to delete fleet-managed role": {
+			namespace: "fleet-member-mc1",
+			userInfo:  user,
+			operation: admissionv1.Delete,
+			oldLabels: fleetManagedLabels,
+		},
+		"allow other service account to update unlabeled role": {
+			namespace:   "fleet-member-mc1",
+			userInfo:    otherServiceAccount,
+			operation:   admissionv1.Update,
+			wantAllowed: true,
+		},
+		"deny user to update unlabeled role": {
+			namespace: "fleet-member-mc1",
+			userInfo:  user,
+			operation: admissionv1.Update,
+		},
+		"allow other service account to update fleet-managed role outside fleet member namespaces": {
+			namespace:   "fleet-system",
+			userInfo:    otherServiceAccount,
+			operation:   admissionv1.Update,
+			labels:      fleetManagedLabels,
+			oldLabels:   fleetManagedLabels,
+			wantAllowed: true,
+		},
+	}
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			namespacedName := types.NamespacedName{Name: "fleet-role-mc1", Namespace: tc.namespace}
+			req := admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Name:        namespacedName.Name,
+					Namespace:   namespacedName.Namespace,
+					UserInfo:    tc.userInfo,
+					RequestKind: &utils.RoleMetaGVK,
+					Operation:   tc.operation,
+				},
+			}
+			if tc.operation != admissionv1.Delete {
+				req.Object = runtime.RawExtension{Raw: roleBytes(tc.namespace, tc.labels)}
+			}
+			if tc.operation != admissionv1.Create {
+				req.OldObject = runtime.RawExtension{Raw: roleBytes(tc.namespace, tc.oldLabels)}
+			}
+			want := admission.Denied(fmt.Sprintf(validation.ResourceDeniedFormat, tc.userInfo.Username, utils.GenerateGroupString(tc.userInfo.Groups), tc.operation, &utils.RoleMetaGVK, "", namespacedName))
+			if tc.wantAllowed {
+				want = admission.Allowed(fmt.Sprintf(validation.ResourceAllowedFormat, tc.userInfo.Username, utils.GenerateGroupString(tc.userInfo.Groups), tc.operation, &utils.RoleMetaGVK, "", namespacedName))
+			}
+			gotResult := v.handleRBAC(context.Background(), req)
+			assert.Equal(t, want, gotResult, utils.TestCaseMsg, testName)
+		})
+	}
+}
+
+func TestHandleSnapshot(t *testing.T) {
+	hubAgent := authenticationv1.UserInfo{Username: "system:serviceaccount:fleet-system:hub-agent-sa", Groups: []string{"system:serviceaccounts"}}
+	masters := authenticationv1.UserInfo{Username: "mastersUser", Groups: []string{"system:masters"}}
+	whiteListedUser := authenticationv1.UserInfo{Username: "white-listed-user", Groups: []string{"system:masters"}}
+	garbageCollector := authenticationv1.UserInfo{Username: "system:serviceaccount:kube-system:generic-garbage-collector", Groups: []string{"system:serviceaccounts"}}
+	v := fleetResourceValidator{
+		decoder:                     admission.NewDecoder(runtime.NewScheme()),
+		whiteListedUsers:            []string{"white-listed-user"},
+		fleetSnapshotWriterPatterns: []string{"system:serviceaccount:fleet-system:*"},
+	}
+
+	testCases := map[string]struct {
+		kind        metav1.GroupVersionKind
+		userInfo    authenticationv1.UserInfo
+		operation   admissionv1.Operation
+		wantAllowed bool
+	}{
+		"allow hub controller to create a resource snapshot": {
+			kind:        utils.ClusterResourceSnapshotMetaGVK,
+			userInfo:    hubAgent,
+			operation:   admissionv1.Create,
+			wantAllowed: true,
+		},
+		"allow hub controller to update a policy snapshot": {
+			kind:        utils.ClusterSchedulingPolicySnapshotMetaGVK,
+			userInfo:    hubAgent,
+			operation:   admissionv1.Update,
+			wantAllowed: true,
+		},
+		"deny user in system:masters group to update a resource snapshot": {
+			kind:      utils.ClusterResourceSnapshotMetaGVK,
+			userInfo:  masters,
+			operation: admissionv1.Update,
+		},
+		"deny user in system:masters group to delete a policy snapshot": {
+			kind:      utils.ClusterSchedulingPolicySnapshotMetaGVK,
+			userInfo:  masters,
+			operation: admissionv1.Delete,
+		},
+		"allow white listed user to update a resource snapshot": {
+			kind:        utils.ClusterResourceSnapshotMetaGVK,
+			userInfo:    whiteListedUser,
+			operation:   admissionv1.Update,
+			wantAllowed: true,
+		},
+		"allow garbage collector to delete a resource snapshot": {
+			kind:        utils.ClusterResourceSnapshotMetaGVK,
+			userInfo:    garbageCollector,
+			operation:   admissionv1.Delete,
+			wantAllowed: true,
+		},
+		"deny garbage collector to update a resource snapshot": {
+			kind:      utils.ClusterResourceSnapshotMetaGVK,
+			userInfo:  garbageCollector,
+			operation: admissionv1.Update,
+		},
+	}
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			namespacedName := types.NamespacedName{Name: "test-crp-0-snapshot"}
+			req := admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Name:        namespacedName.Name,
+					UserInfo:    tc.userInfo,
+					Kind:        tc.kind,
+					RequestKind: &tc.kind,
+					Operation:   tc.operation,
+				},
+			}
+			want := admission.Denied(fmt.Sprintf(validation.ResourceDeniedFormat, tc.userInfo.Username, utils.GenerateGroupString(tc.userInfo.Groups), tc.operation, &tc.kind, "", namespacedName))
+			if tc.wantAllowed {
+				want = admission.Allowed(fmt.Sprintf(validation.ResourceAllowedFormat, tc.userInfo.Username, utils.GenerateGroupString(tc.userInfo.Groups), tc.operation, &tc.kind, "", namespacedName))
+			}
+			gotResult := v.Handle(context.Background(), req)
+			assert.Equal(t, want, gotResult, utils.TestCaseMsg, testName)
+		})
+	}
+}
+
+func TestHandleSecret(t *testing.T) {
+	hubAgent := authenticationv1.UserInfo{Username: "system:serviceaccount:fleet-system:hub-agent-sa", Groups: []string{"system:serviceaccounts"}}
+	otherServiceAccount := authenticationv1.UserInfo{Username: "system:serviceaccount:app:app-sa", Groups: []string{"system:serviceaccounts"}}
+	masters := authenticationv1.UserInfo{Username: "mastersUser", Groups: []string{"system:masters"}}
+	whiteListedUser := authenticationv1.UserInfo{Username: "white-listed-user", Groups: []string{"system:authenticated"}}
+	user := authenticationv1.UserInfo{Username: "test-user", Groups: []string{"system:authenticated"}}
+	v := fleetResourceValidator{
+		decoder:                     admission.NewDecoder(runtime.NewScheme()),
+		whiteListedUsers:            []string{"white-listed-user"},
+		fleetSnapshotWriterPatterns: []string{"system:serviceaccount:fleet-system:hub-agent-sa"},
+		config:                      validator.NewConfigStore(validator.Config{FleetNamespace: "fleet-system", WebhookCertSecretName: "fleet-webhook-server-cert"}),
+	}
+
+	testCases := map[string]struct {
+		namespacedName types.NamespacedName
+		userInfo       authenticationv1.UserInfo
+		operation      admissionv1.Operation
+		wantAllowed    bool
+		// wantUntouched is set if the secret is not guarded at all.
+		wantUntouched bool
+	}{
+		"allow hub agent to update the webhook cert secret": {
+			namespacedName: types.NamespacedName{Name: "fleet-webhook-server-cert", Namespace: "fleet-system"},
+			userInfo:       hubAgent,
+			operation:      admissionv1.Update,
+			wantAllowed:    true,
+		},
+		"allow white listed user to delete the webhook cert secret": {
+			namespacedName: types.NamespacedName{Name: "fleet-webhook-server-cert", Namespace: "fleet-system"},
+			userInfo:       whiteListedUser,
+			operation:      admissionv1.Delete,
+			wantAllowed:    true,
+		},
+		"deny user in system:masters group to update the webhook cert secret": {
+			namespacedName: types.NamespacedName{Name: "fleet-webhook-server-cert", Namespace: "fleet-system"},
+			userInfo:       masters,
+			operation:      admissionv1.Update,
+		},
+		"deny other service account to delete the webhook cert secret": {
+			namespacedName: types.NamespacedName{Name: "fleet-webhook-server-cert", Namespace: "fleet-system"},
+			userInfo:       otherServiceAccount,
+			operation:      admissionv1.Delete,
+		},
+		"deny user to update the webhook cert secret": {
+			namespacedName: types.NamespacedName{Name: "fleet-webhook-server-cert", Namespace: "fleet-system"},
+			userInfo:       user,
+			operation:      admissionv1.Update,
+		},
+		"allow other service account to update other secret in the fleet namespace": {
+			namespacedName: types.NamespacedName{Name: "other-secret", Namespace: "fleet-system"},
+			userInfo:       otherServiceAccount,
+			operation:      admissionv1.Update,
+			wantAllowed:    true,
+		},
+		"deny user to update other secret in the fleet namespace": {
+			namespacedName: types.NamespacedName{Name: "other-secret", Namespace: "fleet-system"},
+			userInfo:       user,
+			operation:      admissionv1.Update,
+		},
+		"allow user to update the secret of the same name in other namespace": {
+			namespacedName: types.NamespacedName{Name: "fleet-webhook-server-cert", Namespace: "app"},
+			userInfo:       user,
+			operation:      admissionv1.Update,
+			wantUntouched:  true,
+		},
+		"allow user to delete other secret in other namespace": {
+			namespacedName: types.NamespacedName{Name: "other-secret", Namespace: "app"},
+			userInfo:       user,
+			operation:      admissionv1.Delete,
+			wantUntouched:  true,
+		},
+	}
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			req := admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Name:        tc.namespacedName.Name,
+					Namespace:   tc.namespacedName.Namespace,
+					UserInfo:    tc.userInfo,
+					Kind:        utils.SecretMetaGVK,
+					RequestKind: &utils.SecretMetaGVK,
+					Operation:   tc.operation,
+				},
+			}
+			var want admission.Response
+			switch {
+			case tc.wantUntouched:
+				want = admission.Allowed(allowedMessageFleetReservedNamespacedResource)
+			case tc.wantAllowed:
+				want = admission.Allowed(fmt.Sprintf(validation.ResourceAllowedFormat, tc.userInfo.Username, utils.GenerateGroupString(tc.userInfo.Groups), tc.operation, &utils.SecretMetaGVK, "", tc.namespacedName))
+			default:
+				want = admission.Denied(fmt.Sprintf(validation.ResourceDeniedFormat, tc.userInfo.Username, utils.GenerateGroupString(tc.userInfo.Groups), tc.operation, &utils.SecretMetaGVK, "", tc.namespacedName))
+			}
+			gotResult := v.Handle(context.Background(), req)
+			assert.Equal(t, want, gotResult, utils.TestCaseMsg, testName)
+		})
+	}
+}
+
 func TestHandleFleetReservedNamespacedResource(t *testing.T) {
 	mockClient := &test.MockClient{
 		MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
@@ -761,8 +1322,59 @@ func TestHandleNamespace(t *testing.T) {
 
 	for testName, testCase := range testCases {
 		t.Run(testName, func(t *testing.T) {
-			gotResult := testCase.resourceValidator.handleNamespace(testCase.req)
+			gotResult := testCase.resourceValidator.handleNamespace(context.Background(), testCase.req)
 			assert.Equal(t, testCase.wantResponse, gotResult, utils.TestCaseMsg, testName)
 		})
 	}
 }
+
+func TestHandleUnguardedRequests(t *testing.T) {
+	testUser := authenticationv1.UserInfo{Username: "test-user", Groups: []string{"test-group"}}
+	testCases := map[string]struct {
+		req         admission.Request
+		wantMessage string
+	}{
+		"allow create of an unguarded cluster scoped resource": {
+			req: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Name:      "test-cluster-role",
+					UserInfo:  testUser,
+					Kind:      metav1.GroupVersionKind{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole"},
+					Operation: admissionv1.Create,
+				},
+			},
+			wantMessage: "CREATE by user: test-user in groups: [test-group] of unguarded resource with GVK: rbac.authorization.k8s.io/v1, Kind=ClusterRole passed 0 validation rules with 0 warnings",
+		},
+		"allow create of a cluster resource placement": {
+			req: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Name:      "test-crp",
+					UserInfo:  testUser,
+					Kind:      utils.ClusterResourcePlacementMetaGVK,
+					Operation: admissionv1.Create,
+				},
+			},
+			wantMessage: "CREATE of clusterResourcePlacement test-crp passed 0 validation rules with 0 warnings",
+		},
+		"allow update of an event": {
+			req: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Name:      "test-event",
+					Namespace: "fleet-system",
+					UserInfo:  testUser,
+					Kind:      utils.EventMetaGVK,
+					Operation: admissionv1.Update,
+				},
+			},
+			wantMessage: "UPDATE of event fleet-system/test-event passed 0 validation rules with 0 warnings",
+		},
+	}
+	v := fleetResourceValidator{}
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			gotResult := v.Handle(context.Background(), tc.req)
+			assert.True(t, gotResult.Allowed, utils.TestCaseMsg, testName)
+			assert.Equal(t, tc.wantMessage, gotResult.Result.Message, utils.TestCaseMsg, testName)
+		})
+	}
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	admv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// IntegrityStatusPath is the path on the manager's metrics server at which the result of the last webhook
+	// configuration integrity check is served.
+	IntegrityStatusPath = "/integrity-status"
+
+	// WebhookConfigIntegrityEventSource is the source of the events emitted by the WebhookConfigIntegrity.
+	WebhookConfigIntegrityEventSource = "webhook-config-integrity"
+
+	// webhookConfigurationTamperedReason is the reason of the Warning event emitted on a webhook configuration
+	// which no longer matches the configuration applied by the hub agent.
+	webhookConfigurationTamperedReason = "WebhookConfigurationTampered"
+)
+
+// webhookConfigurationKey identifies a webhook configuration by its kind and name.
+type webhookConfigurationKey struct {
+	kind string
+	name string
+}
+
+// WebhookConfigIntegrity periodically re-reads the webhook configurations applied by the hub agent and emits a
+// Warning event on the ones which have been modified since, as a weakened webhook configuration silently stops
+// protecting the fleet. The expected hash of a configuration is the hash of the live configuration read right
+// after the hub agent applies it, so that the fields defaulted by the API server never count as modifications.
+type WebhookConfigIntegrity struct {
+	client   client.Reader
+	recorder record.EventRecorder
+	interval time.Duration
+
+	mu sync.RWMutex
+	// expected maps the webhook configurations applied by the hub agent to their expected hashes.
+	expected map[webhookConfigurationKey]string
+	// reported maps the modified webhook configurations to the live hash an event was last emitted for, so
+	// that a modification is reported once rather than on every check.
+	reported map[webhookConfigurationKey]string
+	// status is the result of the last check.
+	status integrityStatus
+}
+
+// integrityStatus is the JSON view of the result of a webhook configuration integrity check.
+type integrityStatus struct {
+	// CheckedAt is the time of the last check; it is not set until the first check.
+	CheckedAt *time.Time `json:"checkedAt,omitempty"`
+	// Intact is false if any webhook configuration differs from the applied one or cannot be read.
+	Intact         bool                            `json:"intact"`
+	Configurations []webhookConfigurationIntegrity `json:"configurations"`
+}
+
+// webhookConfigurationIntegrity is the JSON view of the integrity of a webhook configuration.
+type webhookConfigurationIntegrity struct {
+	Kind         string `json:"kind"`
+	Name         string `json:"name"`
+	ExpectedHash string `json:"expectedHash"`
+	LiveHash     string `json:"liveHash,omitempty"`
+	Match        bool   `json:"match"`
+	Error        string `json:"error,omitempty"`
+}
+
+// NewWebhookConfigIntegrity returns a WebhookConfigIntegrity which checks the webhook configurations with the
+// client every interval and emits the events with the recorder.
+func NewWebhookConfigIntegrity(c client.Reader, recorder record.EventRecorder, interval time.Duration) *WebhookConfigIntegrity {
+	return &WebhookConfigIntegrity{
+		client:   c,
+		recorder: recorder,
+		interval: interval,
+		expected: make(map[webhookConfigurationKey]string),
+		reported: make(map[webhookConfigurationKey]string),
+		status:   integrityStatus{Intact: true, Configurations: []webhookConfigurationIntegrity{}},
+	}
+}
+
+// Start checks the webhook configurations every interval until the context is done. It runs on the leader only so
+// that the modifications are reported once.
+func (i *WebhookConfigIntegrity) Start(ctx context.Context) error {
+	ticker := time.NewTicker(i.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			i.check(ctx)
+		}
+	}
+}
+
+// record reads the webhook configuration the hub agent has just applied and records the hash of the live
+// configuration as the expected one.
+func (i *WebhookConfigIntegrity) record(ctx context.Context, kind, name string) error {
+	key := webhookConfigurationKey{kind: kind, name: name}
+	obj, err := newWebhookConfigurationObject(kind)
+	if err != nil {
+		return err
+	}
+	if err := i.client.Get(ctx, client.ObjectKey{Name: name}, obj); err != nil {
+		return fmt.Errorf("failed to read the applied %s %s: %w", kind, name, err)
+	}
+	hash, err := liveWebhookConfigurationHash(obj)
+	if err != nil {
+		return err
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.expected[key] = hash
+	delete(i.reported, key)
+	klog.V(2).InfoS("Recorded the expected hash of the webhook configuration", "kind", kind, "name", name, "hash", hash)
+	return nil
+}
+
+// check compares the live webhook configurations against their expected hashes and emits a Warning event on
+// every configuration modified since the last check.
+func (i *WebhookConfigIntegrity) check(ctx context.Context) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	keys := make([]webhookConfigurationKey, 0, len(i.expected))
+	for key := range i.expected {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b webhookConfigurationKey) int {
+		return cmp.Or(cmp.Compare(a.kind, b.kind), cmp.Compare(a.name, b.name))
+	})
+
+	now := time.Now()
+	status := integrityStatus{CheckedAt: &now, Intact: true, Configurations: make([]webhookConfigurationIntegrity, 0, len(keys))}
+	for _, key := range keys {
+		result := webhookConfigurationIntegrity{Kind: key.kind, Name: key.name, ExpectedHash: i.expected[key]}
+		obj, err := i.liveWebhookConfiguration(ctx, key)
+		if err == nil {
+			result.LiveHash, err = liveWebhookConfigurationHash(obj)
+		}
+		switch {
+		case err != nil:
+			klog.ErrorS(err, "Failed to check the integrity of the webhook configuration", "kind", key.kind, "name", key.name)
+			result.Error = err.Error()
+		case result.LiveHash == result.ExpectedHash:
+			result.Match = true
+			delete(i.reported, key)
+		case i.reported[key] != result.LiveHash:
+			klog.InfoS("Webhook configuration differs from the one applied by the hub agent", "kind", key.kind, "name", key.name, "expectedHash", result.ExpectedHash, "liveHash", result.LiveHash)
+			i.recorder.Eventf(obj, corev1.EventTypeWarning, webhookConfigurationTamperedReason,
+				"The %s %s has been modified since it was applied by the hub agent: expected hash %s, live hash %s", key.kind, key.name, result.ExpectedHash, result.LiveHash)
+			i.reported[key] = result.LiveHash
+		}
+		status.Intact = status.Intact && result.Match
+		status.Configurations = append(status.Configurations, result)
+	}
+	i.status = status
+}
+
+// liveWebhookConfiguration reads the live webhook configuration identified by the key.
+func (i *WebhookConfigIntegrity) liveWebhookConfiguration(ctx context.Context, key webhookConfigurationKey) (client.Object, error) {
+	obj, err := newWebhookConfigurationObject(key.kind)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.client.Get(ctx, client.ObjectKey{Name: key.name}, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("the %s %s applied by the hub agent has been deleted", key.kind, key.name)
+		}
+		return nil, fmt.Errorf("failed to read the %s %s: %w", key.kind, key.name, err)
+	}
+	return obj, nil
+}
+
+// StatusHandler returns a read-only handler which renders the result of the last integrity check as JSON.
+func (i *WebhookConfigIntegrity) StatusHandler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			rw.Header().Set("Allow", http.MethodGet)
+			http.Error(rw, "only GET is allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		i.mu.RLock()
+		status := i.status
+		i.mu.RUnlock()
+		rw.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(rw)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(status); err != nil {
+			klog.ErrorS(err, "Failed to write the webhook configuration integrity status")
+		}
+	})
+}
+
+// newWebhookConfigurationObject returns an empty webhook configuration object of the kind.
+func newWebhookConfigurationObject(kind string) (client.Object, error) {
+	switch kind {
+	case mutatingWebhookConfigurationKind:
+		return &admv1.MutatingWebhookConfiguration{}, nil
+	case validatingWebhookConfigurationKind:
+		return &admv1.ValidatingWebhookConfiguration{}, nil
+	default:
+		return nil, fmt.Errorf("unknown webhook configuration kind %s", kind)
+	}
+}
+
+// liveWebhookConfigurationHash returns the hash of the webhooks of a live webhook configuration. Unlike
+// webhookConfigurationHash, the caBundle is included as replacing it redirects the trust of the API server.
+func liveWebhookConfigurationHash(obj client.Object) (string, error) {
+	var kind string
+	var webhooks interface{}
+	switch config := obj.(type) {
+	case *admv1.MutatingWebhookConfiguration:
+		kind, webhooks = mutatingWebhookConfigurationKind, config.Webhooks
+	case *admv1.ValidatingWebhookConfiguration:
+		kind, webhooks = validatingWebhookConfigurationKind, config.Webhooks
+	default:
+		return "", fmt.Errorf("unknown webhook configuration type %T", obj)
+	}
+	b, err := json.Marshal(struct {
+		Kind     string      `json:"kind"`
+		Name     string      `json:"name"`
+		Webhooks interface{} `json:"webhooks"`
+	}{Kind: kind, Name: obj.GetName(), Webhooks: webhooks})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
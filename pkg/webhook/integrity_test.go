@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	admv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubefleet-dev/kubefleet/cmd/hubagent/options"
+)
+
+func TestWebhookConfigIntegrity(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() = %v, want no error", err)
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "fleet-system"}}).Build()
+	recorder := record.NewFakeRecorder(10)
+	integrity := NewWebhookConfigIntegrity(fakeClient, recorder, time.Minute)
+	url := options.URL
+	w := &Config{
+		mgr:                  &fakeWebhookManager{client: fakeClient},
+		serviceNamespace:     "fleet-system",
+		serviceName:          "fleetwebhook",
+		servicePort:          443,
+		serviceURL:           "https://fleetwebhook.fleet-system.svc.cluster.local:443",
+		clientConnectionType: &url,
+		caPEM:                []byte("ca"),
+		webhookCache:         &webhookCache{},
+		applyStatus:          &webhookConfigurationApplyStatus{},
+		integrity:            integrity,
+	}
+	if err := w.createFleetWebhookConfiguration(ctx); err != nil {
+		t.Fatalf("createFleetWebhookConfiguration() = %v, want no error", err)
+	}
+
+	ignoreHashes := cmpopts.IgnoreFields(webhookConfigurationIntegrity{}, "ExpectedHash", "LiveHash")
+	wantIntact := []webhookConfigurationIntegrity{
+		{Kind: mutatingWebhookConfigurationKind, Name: fleetMutatingWebhookCfgName, Match: true},
+		{Kind: validatingWebhookConfigurationKind, Name: fleetValidatingWebhookCfgName, Match: true},
+	}
+	integrity.check(ctx)
+	if !integrity.status.Intact {
+		t.Errorf("check() intact = false, want true before any modification")
+	}
+	if diff := cmp.Diff(wantIntact, integrity.status.Configurations, ignoreHashes); diff != "" {
+		t.Errorf("check() configurations mismatch (-want, +got):\n%s", diff)
+	}
+	assertNoEvent(t, recorder)
+
+	// Weaken the validating webhooks.
+	var validating admv1.ValidatingWebhookConfiguration
+	if err := fakeClient.Get(ctx, client.ObjectKey{Name: fleetValidatingWebhookCfgName}, &validating); err != nil {
+		t.Fatalf("Get() = %v, want no error", err)
+	}
+	original := validating.DeepCopy()
+	validating.Webhooks[0].FailurePolicy = &ignoreFailurePolicy
+	if err := fakeClient.Update(ctx, &validating); err != nil {
+		t.Fatalf("Update() = %v, want no error", err)
+	}
+	integrity.check(ctx)
+	if integrity.status.Intact {
+		t.Errorf("check() intact = true, want false after the validating webhooks are modified")
+	}
+	if got := integrity.status.Configurations[1]; got.Match || got.LiveHash == got.ExpectedHash {
+		t.Errorf("check() validating configuration = %+v, want a hash mismatch", got)
+	}
+	select {
+	case event := <-recorder.Events:
+		want := "Warning " + webhookConfigurationTamperedReason + " The ValidatingWebhookConfiguration " + fleetValidatingWebhookCfgName + " has been modified since it was applied by the hub agent"
+		if !strings.HasPrefix(event, want) {
+			t.Errorf("check() event = %q, want prefix %q", event, want)
+		}
+	default:
+		t.Errorf("check() emitted no event, want a Warning event on the modified configuration")
+	}
+	// The same modification is reported once.
+	integrity.check(ctx)
+	assertNoEvent(t, recorder)
+
+	// Restore the validating webhooks and delete the mutating ones.
+	validating.Webhooks = original.Webhooks
+	if err := fakeClient.Update(ctx, &validating); err != nil {
+		t.Fatalf("Update() = %v, want no error", err)
+	}
+	if err := fakeClient.Delete(ctx, &admv1.MutatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: fleetMutatingWebhookCfgName}}); err != nil {
+		t.Fatalf("Delete() = %v, want no error", err)
+	}
+	integrity.check(ctx)
+	want := []webhookConfigurationIntegrity{
+		{Kind: mutatingWebhookConfigurationKind, Name: fleetMutatingWebhookCfgName, Error: "the MutatingWebhookConfiguration " + fleetMutatingWebhookCfgName + " applied by the hub agent has been deleted"},
+		{Kind: validatingWebhookConfigurationKind, Name: fleetValidatingWebhookCfgName, Match: true},
+	}
+	if diff := cmp.Diff(want, integrity.status.Configurations, ignoreHashes); diff != "" {
+		t.Errorf("check() configurations mismatch (-want, +got):\n%s", diff)
+	}
+	if integrity.status.Intact {
+		t.Errorf("check() intact = true, want false after the mutating webhooks are deleted")
+	}
+	assertNoEvent(t, recorder)
+}
+
+func TestWebhookConfigIntegrityStatusHandler(t *testing.T) {
+	integrity := NewWebhookConfigIntegrity(nil, record.NewFakeRecorder(1), time.Minute)
+	rec := httptest.NewRecorder()
+	integrity.StatusHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, IntegrityStatusPath, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("StatusHandler() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var got integrityStatus
+	decoder := json.NewDecoder(rec.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&got); err != nil {
+		t.Fatalf("StatusHandler() returned invalid JSON: %v", err)
+	}
+	if diff := cmp.Diff(integrityStatus{Intact: true, Configurations: []webhookConfigurationIntegrity{}}, got); diff != "" {
+		t.Errorf("StatusHandler() mismatch before the first check (-want, +got):\n%s", diff)
+	}
+
+	rec = httptest.NewRecorder()
+	integrity.StatusHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, IntegrityStatusPath, nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("StatusHandler() status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+// assertNoEvent fails the test if the recorder has recorded any event.
+func assertNoEvent(t *testing.T, recorder *record.FakeRecorder) {
+	t.Helper()
+	select {
+	case event := <-recorder.Events:
+		t.Errorf("check() emitted event %q, want none", event)
+	default:
+	}
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
+)
+
+// validateLookupFailurePolicies returns an error if any of the client-backed checks or the policies determining how
+// the requests are handled once the checks exhaust the lookup budget is unknown.
+func validateLookupFailurePolicies(policies map[string]validator.LookupFailurePolicy) error {
+	checks := validator.LookupChecks()
+	for check, policy := range policies {
+		if !slices.Contains(checks, check) {
+			return fmt.Errorf("unknown client-backed check %q, must be one of %v", check, checks)
+		}
+		if !slices.Contains(validator.LookupFailurePolicies, policy) {
+			return fmt.Errorf("invalid lookup failure policy %q of check %q, must be one of %v", policy, check, validator.LookupFailurePolicies)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/kubefleet-dev/kubefleet/cmd/hubagent/options"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
+)
+
+func TestNewWebhookConfigLookupBudget(t *testing.T) {
+	testCases := map[string]struct {
+		policies map[string]validator.LookupFailurePolicy
+		wantErr  bool
+	}{
+		"default policies": {},
+		"valid policies": {
+			policies: map[string]validator.LookupFailurePolicy{
+				validator.ClusterNamesLookupCheck: validator.LookupFailClosed,
+				validator.TeamQuotaLookupCheck:    validator.LookupFailOpen,
+			},
+		},
+		"unknown check": {
+			policies: map[string]validator.LookupFailurePolicy{"Unknown": validator.LookupFailOpen},
+			wantErr:  true,
+		},
+		"invalid policy": {
+			policies: map[string]validator.LookupFailurePolicy{validator.TeamQuotaLookupCheck: "ignore"},
+			wantErr:  true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("POD_NAMESPACE", "fleet-system")
+			w, err := NewWebhookConfig(nil, Options{
+				ServiceName:            "fleetwebhook",
+				ServicePort:            443,
+				TargetPort:             9443,
+				CertDir:                t.TempDir(),
+				Role:                   options.WebhookRoleAll,
+				TrustedServiceAccounts: []string{"system:serviceaccount:fleet-system:startup"},
+				LookupBudget:           time.Second,
+				LookupFailurePolicies:  tc.policies,
+			})
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("NewWebhookConfig() = %v, want error %t", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			want := validator.Config{
+				TrustedServiceAccounts: []string{"system:serviceaccount:fleet-system:startup"},
+				FleetNamespace:         "fleet-system",
+				LookupBudget:           time.Second,
+				LookupFailurePolicies:  tc.policies,
+			}
+			if diff := cmp.Diff(want, w.validatorConfigs.Load()); diff != "" {
+				t.Errorf("NewWebhookConfig() validator settings mismatch (-want, +got):\n%s", diff)
+			}
+			// The ConfigMap reloads start from the startup settings, which must keep the lookup budget.
+			if diff := cmp.Diff(want.LookupFailurePolicies, w.validatorConfig().LookupFailurePolicies); diff != "" {
+				t.Errorf("validatorConfig() lookup failure policies mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	admv1 "k8s.io/api/admissionregistration/v1"
+)
+
+// maxMatchConditions is the maximum number of match conditions the API server accepts on a webhook.
+const maxMatchConditions = 64
+
+// validateMatchConditions returns an error if any of the CEL match conditions which decide whether the API server
+// calls the fleet webhooks has no name or expression, the names are not unique, or there are more conditions than
+// the API server accepts. The conditions are applied to every generated webhook only if the feature gate is set, as
+// the API servers older than Kubernetes 1.28 do not support them.
+func validateMatchConditions(conditions []admv1.MatchCondition) error {
+	if len(conditions) > maxMatchConditions {
+		return fmt.Errorf("invalid match conditions: at most %d conditions are allowed, got %d", maxMatchConditions, len(conditions))
+	}
+	names := make(map[string]bool, len(conditions))
+	for _, c := range conditions {
+		if c.Name == "" {
+			return errors.New("invalid match conditions: the name of a condition is empty")
+		}
+		if c.Expression == "" {
+			return fmt.Errorf("invalid match condition %s: the expression is empty", c.Name)
+		}
+		if names[c.Name] {
+			return fmt.Errorf("invalid match conditions: duplicate condition name %s", c.Name)
+		}
+		names[c.Name] = true
+	}
+	return nil
+}
+
+// effectiveMatchConditions returns the match conditions applied to the generated webhooks, which is nil unless the
+// feature gate is set.
+func (w *Config) effectiveMatchConditions() []admv1.MatchCondition {
+	if !w.featureGateMatchConditions {
+		return nil
+	}
+	return w.matchConditions
+}
+
+// applyMutatingMatchConditions sets the match conditions on the mutating webhooks and returns them.
+func (w *Config) applyMutatingMatchConditions(webhooks []admv1.MutatingWebhook) []admv1.MutatingWebhook {
+	if conditions := w.effectiveMatchConditions(); conditions != nil {
+		for i := range webhooks {
+			webhooks[i].MatchConditions = slices.Clone(conditions)
+		}
+	}
+	return webhooks
+}
+
+// applyValidatingMatchConditions sets the match conditions on the validating webhooks and returns them.
+func (w *Config) applyValidatingMatchConditions(webhooks []admv1.ValidatingWebhook) []admv1.ValidatingWebhook {
+	if conditions := w.effectiveMatchConditions(); conditions != nil {
+		for i := range webhooks {
+			webhooks[i].MatchConditions = slices.Clone(conditions)
+		}
+	}
+	return webhooks
+}
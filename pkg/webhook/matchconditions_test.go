@@ -0,0 +1,141 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	admv1 "k8s.io/api/admissionregistration/v1"
+
+	"github.com/kubefleet-dev/kubefleet/cmd/hubagent/options"
+)
+
+func TestMatchConditions(t *testing.T) {
+	url := options.WebhookClientConnectionType("url")
+	conditions := []admv1.MatchCondition{
+		{Name: "exclude-leases", Expression: `request.resource.resource != "leases"`},
+		{Name: "exclude-system-nodes", Expression: `!request.userInfo.username.startsWith("system:node:")`},
+	}
+	testCases := map[string]struct {
+		featureGate bool
+		conditions  []admv1.MatchCondition
+		want        []admv1.MatchCondition
+	}{
+		"feature gate enabled": {
+			featureGate: true,
+			conditions:  conditions,
+			want:        conditions,
+		},
+		"feature gate disabled": {
+			conditions: conditions,
+		},
+		"feature gate enabled without conditions": {
+			featureGate: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			w := &Config{
+				serviceNamespace:           "test-namespace",
+				servicePort:                8080,
+				serviceURL:                 "test-url",
+				clientConnectionType:       &url,
+				enableGuardRail:            true,
+				role:                       options.WebhookRoleAll,
+				featureGateMatchConditions: tc.featureGate,
+				matchConditions:            tc.conditions,
+			}
+			got := map[string][]admv1.MatchCondition{}
+			for _, wh := range w.buildFleetMutatingWebhooks() {
+				got[wh.Name] = wh.MatchConditions
+			}
+			validating := append(w.buildFleetValidatingWebhooks(), w.buildFleetGuardRailValidatingWebhooks()...)
+			for _, wh := range validating {
+				got[wh.Name] = wh.MatchConditions
+			}
+			if len(got) == 0 {
+				t.Fatalf("no webhook is generated")
+			}
+			for name, gotConditions := range got {
+				if diff := cmp.Diff(tc.want, gotConditions); diff != "" {
+					t.Errorf("webhook %s match conditions mismatch (-want, +got):\n%s", name, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestMatchConditionsChangeTheWebhooks(t *testing.T) {
+	url := options.WebhookClientConnectionType("url")
+	w := &Config{
+		serviceNamespace:     "test-namespace",
+		servicePort:          8080,
+		serviceURL:           "test-url",
+		clientConnectionType: &url,
+		role:                 options.WebhookRolePlacement,
+	}
+	conditions := []admv1.MatchCondition{{Name: "exclude-leases", Expression: `request.resource.resource != "leases"`}}
+	w.matchConditions = conditions
+	if got := w.buildFleetValidatingWebhooks()[0].MatchConditions; got != nil {
+		t.Fatalf("buildFleetValidatingWebhooks() match conditions = %v with the feature gate disabled, want nil", got)
+	}
+	w.featureGateMatchConditions = true
+	// The cached webhooks must be rebuilt with the conditions once the feature gate is enabled.
+	if diff := cmp.Diff(conditions, w.buildFleetValidatingWebhooks()[0].MatchConditions); diff != "" {
+		t.Errorf("buildFleetValidatingWebhooks() match conditions mismatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestValidateMatchConditions(t *testing.T) {
+	tooMany := make([]admv1.MatchCondition, maxMatchConditions+1)
+	for i := range tooMany {
+		tooMany[i] = admv1.MatchCondition{Name: strings.Repeat("a", i+1), Expression: "true"}
+	}
+	testCases := map[string]struct {
+		conditions []admv1.MatchCondition
+		wantErr    bool
+	}{
+		"valid conditions": {
+			conditions: []admv1.MatchCondition{{Name: "a", Expression: "true"}, {Name: "b", Expression: "false"}},
+		},
+		"empty name": {
+			conditions: []admv1.MatchCondition{{Expression: "true"}},
+			wantErr:    true,
+		},
+		"empty expression": {
+			conditions: []admv1.MatchCondition{{Name: "a"}},
+			wantErr:    true,
+		},
+		"duplicate names": {
+			conditions: []admv1.MatchCondition{{Name: "a", Expression: "true"}, {Name: "a", Expression: "false"}},
+			wantErr:    true,
+		},
+		"too many conditions": {
+			conditions: tooMany,
+			wantErr:    true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if err := validateMatchConditions(tc.conditions); (err != nil) != tc.wantErr {
+				t.Errorf("validateMatchConditions() = %v, want error %t", err, tc.wantErr)
+			}
+		})
+	}
+}
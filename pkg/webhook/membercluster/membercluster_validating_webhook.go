@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
+	"strings"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -14,37 +17,86 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	clusterv1beta1 "github.com/kubefleet-dev/kubefleet/apis/cluster/v1beta1"
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
 	"github.com/kubefleet-dev/kubefleet/pkg/utils"
 	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
+	"github.com/kubefleet-dev/kubefleet/pkg/webhook/validation"
 
 	fleetnetworkingv1alpha1 "go.goms.io/fleet-networking/api/v1alpha1"
 )
 
+const (
+	// ForceDeleteAnnotation, when set to "true" on a member cluster, allows the member cluster to be deleted
+	// even if it is still selected by cluster resource placements.
+	ForceDeleteAnnotation = "kubefleet.io/force-delete"
+
+	// PlacementClusterNameIndexKey is the field index key for the names of the clusters selected by a
+	// cluster resource placement, as reported in its status.
+	PlacementClusterNameIndexKey = "status.placementStatuses.clusterName"
+
+	// maxBlockingPlacementsInMessage is the maximum number of blocking placements listed in the deny message.
+	maxBlockingPlacementsInMessage = 10
+)
+
 var (
 	// ValidationPath is the webhook service path which admission requests are routed to for validating ReplicaSet resources.
-	ValidationPath = fmt.Sprintf(utils.ValidationPathFmt, clusterv1beta1.GroupVersion.Group, clusterv1beta1.GroupVersion.Version, "membercluster")
+	ValidationPath = utils.RegisterWebhookPath(clusterv1beta1.GroupVersion.Group, clusterv1beta1.GroupVersion.Version, "membercluster", utils.ValidatingWebhookPathKind)
+
+	// acceptedKinds are the kinds of the member clusters the validator decodes.
+	acceptedKinds = []metav1.GroupVersionKind{utils.MCMetaGVK}
 )
 
 type memberClusterValidator struct {
 	client                  client.Client
 	decoder                 webhook.AdmissionDecoder
 	networkingAgentsEnabled bool
+	// config holds the validator settings of the requests.
+	config *validator.ConfigStore
 }
 
 // Add registers the webhook for K8s bulit-in object types.
-func Add(mgr manager.Manager, networkingAgentsEnabled bool) {
+func Add(mgr manager.Manager, config *validator.ConfigStore, networkingAgentsEnabled bool) error {
+	decoder, err := validation.SharedDecoder(mgr.GetScheme(), &clusterv1beta1.MemberCluster{})
+	if err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &placementv1beta1.ClusterResourcePlacement{}, PlacementClusterNameIndexKey, PlacementClusterNameIndexer); err != nil {
+		klog.ErrorS(err, "Failed to set up the cluster name index for cluster resource placements")
+		return err
+	}
 	hookServer := mgr.GetWebhookServer()
 	hookServer.Register(ValidationPath, &webhook.Admission{Handler: &memberClusterValidator{
 		client:                  mgr.GetClient(),
-		decoder:                 admission.NewDecoder(mgr.GetScheme()),
+		decoder:                 decoder,
 		networkingAgentsEnabled: networkingAgentsEnabled,
+		config:                  config,
 	}})
+	return nil
+}
+
+// PlacementClusterNameIndexer returns the names of the clusters a cluster resource placement has selected.
+func PlacementClusterNameIndexer(obj client.Object) []string {
+	crp, ok := obj.(*placementv1beta1.ClusterResourcePlacement)
+	if !ok {
+		return nil
+	}
+	clusterNames := make([]string, 0, len(crp.Status.PerClusterPlacementStatuses))
+	for _, status := range crp.Status.PerClusterPlacementStatuses {
+		if status.ClusterName != "" {
+			clusterNames = append(clusterNames, status.ClusterName)
+		}
+	}
+	return clusterNames
 }
 
 // Handle memberClusterValidator checks to see if member cluster has valid fields.
 func (v *memberClusterValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
 	mcObjectName := types.NamespacedName{Name: req.Name, Namespace: req.Namespace}
 	klog.V(2).InfoS("Validating webhook handling member cluster", "operation", req.Operation, "memberCluster", mcObjectName)
+	config := v.config.Load()
+	if resp, handled := config.HandleUnknownKind(req, "membercluster", acceptedKinds); handled {
+		return resp
+	}
 
 	var mc clusterv1beta1.MemberCluster
 	if req.Operation == admissionv1.Delete { // Will reject the requests whenever the serviceExport is not deleted
@@ -57,6 +109,14 @@ func (v *memberClusterValidator) Handle(ctx context.Context, req admission.Reque
 			klog.V(2).InfoS("Skipping validation for member cluster DELETE when the validation mode is set to skip", "memberCluster", mcObjectName)
 			return admission.Allowed("Skipping validation for member cluster DELETE when the validation mode is set to skip")
 		}
+		if mc.Annotations[ForceDeleteAnnotation] != "true" {
+			resp, blocked := v.validateNoPlacementSelectsCluster(ctx, config, mcObjectName.Name)
+			if blocked {
+				return resp
+			}
+		} else {
+			klog.V(2).InfoS("Force deleting member cluster regardless of the placements selecting it", "memberCluster", mcObjectName)
+		}
 		if !v.networkingAgentsEnabled {
 			klog.V(2).InfoS("Networking agents disabled; skipping ServiceExport validation", "memberCluster", mcObjectName)
 			return admission.Allowed("Networking agents disabled; skipping ServiceExport validation")
@@ -72,7 +132,7 @@ func (v *memberClusterValidator) Handle(ctx context.Context, req admission.Reque
 		for _, internalServiceExport := range internalServiceExportList.Items {
 			if internalServiceExport.DeletionTimestamp.IsZero() {
 				klog.Warning("ServiceExport exists in the member cluster, request is denied", "operation", req.Operation, "memberCluster", mcObjectName)
-				return admission.Denied(fmt.Sprintf("Please delete serviceExport %s in the member cluster before leaving, request is denied", internalServiceExport.Spec.ServiceReference.NamespacedName))
+				return admission.Denied(config.DenialMessage(validator.MemberClusterServiceExportMessageID, map[string]any{"serviceExport": internalServiceExport.Spec.ServiceReference.NamespacedName}))
 			}
 		}
 		return admission.Allowed("Member cluster is ready to leave")
@@ -89,3 +149,35 @@ func (v *memberClusterValidator) Handle(ctx context.Context, req admission.Reque
 	}
 	return admission.Allowed("Member cluster has valid fields")
 }
+
+// validateNoPlacementSelectsCluster denies the deletion of the member cluster if any cluster resource placement
+// still selects it. The boolean return value is true if the request should be rejected with the returned response.
+func (v *memberClusterValidator) validateNoPlacementSelectsCluster(ctx context.Context, config validator.Config, mcName string) (admission.Response, bool) {
+	crpList := &placementv1beta1.ClusterResourcePlacementList{}
+	if err := validator.ListByIndex(ctx, v.client, crpList, PlacementClusterNameIndexKey, mcName); err != nil {
+		klog.ErrorS(err, "Failed to list clusterResourcePlacements when validating", "memberCluster", mcName)
+		return admission.Errored(http.StatusInternalServerError, fmt.Errorf("failed to list clusterResourcePlacements, please retry the request: %w", err)), true
+	}
+	if len(crpList.Items) == 0 {
+		return admission.Response{}, false
+	}
+
+	crpNames := make([]string, 0, len(crpList.Items))
+	for i := range crpList.Items {
+		crpNames = append(crpNames, crpList.Items[i].Name)
+	}
+	sort.Strings(crpNames)
+	listed := crpNames
+	if len(listed) > maxBlockingPlacementsInMessage {
+		listed = listed[:maxBlockingPlacementsInMessage]
+	}
+	msg := config.DenialMessage(validator.MemberClusterSelectedMessageID, map[string]any{
+		"memberCluster":         mcName,
+		"count":                 len(crpNames),
+		"placements":            strings.Join(listed, ", "),
+		"more":                  len(crpNames) - len(listed),
+		"forceDeleteAnnotation": ForceDeleteAnnotation,
+	})
+	klog.V(2).InfoS("Member cluster is still selected by placements, request is denied", "memberCluster", mcName, "placementCount", len(crpNames))
+	return admission.Denied(msg), true
+}
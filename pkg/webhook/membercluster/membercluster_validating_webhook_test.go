@@ -8,11 +8,13 @@ import (
 	"testing"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 
 	clusterv1beta1 "github.com/kubefleet-dev/kubefleet/apis/cluster/v1beta1"
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
 	"github.com/kubefleet-dev/kubefleet/pkg/utils"
 
 	fleetnetworkingv1alpha1 "go.goms.io/fleet-networking/api/v1alpha1"
@@ -76,6 +78,136 @@ func TestHandleDelete(t *testing.T) {
 	}
 }
 
+func TestHandleDeleteWithSelectingPlacements(t *testing.T) {
+	t.Parallel()
+
+	manyCRPs := make([]client.Object, 0, 12)
+	for i := 0; i < 12; i++ {
+		manyCRPs = append(manyCRPs, newClusterResourcePlacement(fmt.Sprintf("crp-%02d", i), "member-1"))
+	}
+
+	testCases := map[string]struct {
+		annotations       map[string]string
+		objs              []client.Object
+		wantAllowed       bool
+		wantMessageSubstr []string
+		wantNotInMessage  []string
+	}{
+		"no-placements-allows-delete": {
+			objs:        []client.Object{newClusterResourcePlacement("crp-other", "member-2")},
+			wantAllowed: true,
+		},
+		"selecting-placements-deny-delete": {
+			objs: []client.Object{
+				newClusterResourcePlacement("crp-b", "member-1", "member-2"),
+				newClusterResourcePlacement("crp-a", "member-1"),
+				newClusterResourcePlacement("crp-other", "member-2"),
+			},
+			wantAllowed:       false,
+			wantMessageSubstr: []string{"selected by 2 clusterResourcePlacement(s): crp-a, crp-b;", ForceDeleteAnnotation},
+			wantNotInMessage:  []string{"crp-other"},
+		},
+		"blocking-placements-are-capped-in-message": {
+			objs:              manyCRPs,
+			wantAllowed:       false,
+			wantMessageSubstr: []string{"selected by 12 clusterResourcePlacement(s)", "crp-09 and 2 more;"},
+			wantNotInMessage:  []string{"crp-10", "crp-11"},
+		},
+		"force-delete-annotation-allows-delete": {
+			annotations: map[string]string{ForceDeleteAnnotation: "true"},
+			objs:        []client.Object{newClusterResourcePlacement("crp-a", "member-1")},
+			wantAllowed: true,
+		},
+		"force-delete-annotation-not-true-denies-delete": {
+			annotations:       map[string]string{ForceDeleteAnnotation: "false"},
+			objs:              []client.Object{newClusterResourcePlacement("crp-a", "member-1")},
+			wantAllowed:       false,
+			wantMessageSubstr: []string{"crp-a"},
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			validator := newMemberClusterValidatorForTest(t, false, tc.objs...)
+			mc := &clusterv1beta1.MemberCluster{ObjectMeta: metav1.ObjectMeta{Name: "member-1", Annotations: tc.annotations}}
+			req := buildDeleteRequestFromObject(t, mc)
+
+			resp := validator.Handle(context.Background(), req)
+			if resp.Allowed != tc.wantAllowed {
+				t.Fatalf("Handle() got response: %+v, want allowed %t", resp, tc.wantAllowed)
+			}
+			for _, substr := range tc.wantMessageSubstr {
+				if resp.Result == nil || !strings.Contains(resp.Result.Message, substr) {
+					t.Errorf("Handle() got response result: %v, want contain: %q", resp.Result, substr)
+				}
+			}
+			for _, substr := range tc.wantNotInMessage {
+				if resp.Result != nil && strings.Contains(resp.Result.Message, substr) {
+					t.Errorf("Handle() got response result: %v, want not contain: %q", resp.Result, substr)
+				}
+			}
+		})
+	}
+}
+
+func TestHandleCreateTaintEffects(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		effect            corev1.TaintEffect
+		wantAllowed       bool
+		wantMessageSubstr string
+	}{
+		"no-schedule-allows-create": {
+			effect:      corev1.TaintEffectNoSchedule,
+			wantAllowed: true,
+		},
+		"prefer-no-schedule-denies-create": {
+			effect:            corev1.TaintEffectPreferNoSchedule,
+			wantMessageSubstr: `unsupported effect "PreferNoSchedule", supported effects are NoSchedule`,
+		},
+		"no-execute-denies-create": {
+			effect:            corev1.TaintEffectNoExecute,
+			wantMessageSubstr: `unsupported effect "NoExecute", supported effects are NoSchedule`,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			validator := newMemberClusterValidatorForTest(t, false)
+			mc := &clusterv1beta1.MemberCluster{ObjectMeta: metav1.ObjectMeta{Name: "member-1"}}
+			mc.Spec.Taints = []clusterv1beta1.Taint{{Key: "key1", Value: "value1", Effect: tc.effect}}
+			raw, err := json.Marshal(mc)
+			if err != nil {
+				t.Fatalf("failed to marshal member cluster: %v", err)
+			}
+			req := admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Name:      mc.Name,
+					Object:    runtime.RawExtension{Raw: raw},
+				},
+			}
+
+			resp := validator.Handle(context.Background(), req)
+			if resp.Allowed != tc.wantAllowed {
+				t.Fatalf("Handle() got response: %+v, want allowed %t", resp, tc.wantAllowed)
+			}
+			if tc.wantMessageSubstr != "" {
+				if resp.Result == nil || !strings.Contains(resp.Result.Message, tc.wantMessageSubstr) {
+					t.Fatalf("Handle() got response result: %v, want contain: %q", resp.Result, tc.wantMessageSubstr)
+				}
+			}
+		})
+	}
+}
+
 func newMemberClusterValidatorForTest(t *testing.T, networkingEnabled bool, objs ...client.Object) *memberClusterValidator {
 	t.Helper()
 
@@ -83,6 +215,9 @@ func newMemberClusterValidatorForTest(t *testing.T, networkingEnabled bool, objs
 	if err := clusterv1beta1.AddToScheme(scheme); err != nil {
 		t.Fatalf("failed to add member cluster scheme: %v", err)
 	}
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add placement scheme: %v", err)
+	}
 	if err := fleetnetworkingv1alpha1.AddToScheme(scheme); err != nil {
 		t.Fatalf("failed to add fleet networking scheme: %v", err)
 	}
@@ -92,7 +227,11 @@ func newMemberClusterValidatorForTest(t *testing.T, networkingEnabled bool, objs
 	)
 	metav1.AddToGroupVersion(scheme, fleetnetworkingv1alpha1.GroupVersion)
 
-	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithIndex(&placementv1beta1.ClusterResourcePlacement{}, PlacementClusterNameIndexKey, PlacementClusterNameIndexer).
+		Build()
 	decoder := admission.NewDecoder(scheme)
 
 	return &memberClusterValidator{
@@ -139,3 +278,17 @@ func newInternalServiceExport(clusterID, namespace string) *fleetnetworkingv1alp
 		},
 	}
 }
+
+func newClusterResourcePlacement(name string, clusterNames ...string) *placementv1beta1.ClusterResourcePlacement {
+	crp := &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
+	for _, clusterName := range clusterNames {
+		crp.Status.PerClusterPlacementStatuses = append(crp.Status.PerClusterPlacementStatuses, placementv1beta1.PerClusterPlacementStatus{
+			ClusterName: clusterName,
+		})
+	}
+	return crp
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"fmt"
+	"sort"
+
+	admv1 "k8s.io/api/admissionregistration/v1"
+)
+
+// The names of the fleet mutating webhooks.
+const (
+	crpRollbackMutatingWebhookName              = "fleet.clusterresourceplacementv1beta1rollback.mutating"
+	crpMutatingWebhookName                      = "fleet.clusterresourceplacementv1beta1.mutating"
+	crpAnnotationNormalizingMutatingWebhookName = "fleet.clusterresourceplacementv1beta1annotationnormalizing.mutating"
+	crpSpecHistoryMutatingWebhookName           = "fleet.clusterresourceplacementv1beta1spechistory.mutating"
+	crpPhaseTimestampsMutatingWebhookName       = "fleet.clusterresourceplacementv1beta1phasetimestamps.mutating"
+)
+
+var (
+	reinvokeIfNeeded = admv1.IfNeededReinvocationPolicy
+	reinvokeNever    = admv1.NeverReinvocationPolicy
+
+	// fleetMutatingWebhookNames lists the fleet mutating webhooks in the order the API server calls them by default.
+	// The rollback webhook runs first so that the rolled back policy is defaulted and normalized. The defaulting
+	// webhook must run before the annotation normalizing one so that the normalization always sees, and dedupes, the
+	// defaulted object. The spec history webhook runs after them so that it records the final spec change, and the
+	// phase timestamps webhook runs last so that it records the timestamps onto the final object.
+	fleetMutatingWebhookNames = []string{
+		crpRollbackMutatingWebhookName,
+		crpMutatingWebhookName,
+		crpAnnotationNormalizingMutatingWebhookName,
+		crpSpecHistoryMutatingWebhookName,
+		crpPhaseTimestampsMutatingWebhookName,
+	}
+)
+
+// MutatingWebhookOverride overrides the settings of a fleet mutating webhook, e.g., so that downstream
+// distributions can order their own mutating webhooks around the fleet ones.
+type MutatingWebhookOverride struct {
+	// Order overrides the position of the webhook. The webhooks are called in ascending order, and the webhook
+	// at index i of the default order has order i.
+	Order *int `json:"order,omitempty"`
+	// ReinvocationPolicy overrides the reinvocation policy of the webhook.
+	ReinvocationPolicy *admv1.ReinvocationPolicyType `json:"reinvocationPolicy,omitempty"`
+}
+
+// validateMutatingWebhookOverrides returns an error if any of the overrides of the fleet mutating webhooks, keyed by
+// the webhook name, targets an unknown webhook or sets an unknown reinvocation policy, or if the overrides order the
+// defaulting webhook after the annotation normalizing one.
+func validateMutatingWebhookOverrides(overrides map[string]MutatingWebhookOverride) error {
+	for name, override := range overrides {
+		if mutatingWebhookDefaultOrder(name) < 0 {
+			return fmt.Errorf("invalid mutating webhook override: unknown webhook %s", name)
+		}
+		if p := override.ReinvocationPolicy; p != nil && *p != admv1.IfNeededReinvocationPolicy && *p != admv1.NeverReinvocationPolicy {
+			return fmt.Errorf("invalid mutating webhook override of %s: unknown reinvocation policy %s", name, *p)
+		}
+	}
+	if mutatingWebhookOrder(crpMutatingWebhookName, overrides) >= mutatingWebhookOrder(crpAnnotationNormalizingMutatingWebhookName, overrides) {
+		return fmt.Errorf("invalid mutating webhook override: %s must be ordered before %s", crpMutatingWebhookName, crpAnnotationNormalizingMutatingWebhookName)
+	}
+	return nil
+}
+
+// applyMutatingWebhookOverrides applies the overrides to the webhooks and sorts them into the order the API
+// server calls them in.
+func (w *Config) applyMutatingWebhookOverrides(webhooks []admv1.MutatingWebhook) {
+	for i := range webhooks {
+		if p := w.mutatingWebhookOverrides[webhooks[i].Name].ReinvocationPolicy; p != nil {
+			webhooks[i].ReinvocationPolicy = p
+		}
+	}
+	sort.SliceStable(webhooks, func(i, j int) bool {
+		return mutatingWebhookOrder(webhooks[i].Name, w.mutatingWebhookOverrides) < mutatingWebhookOrder(webhooks[j].Name, w.mutatingWebhookOverrides)
+	})
+}
+
+// mutatingWebhookOrder returns the order of the named webhook with the overrides applied.
+func mutatingWebhookOrder(name string, overrides map[string]MutatingWebhookOverride) int {
+	if order := overrides[name].Order; order != nil {
+		return *order
+	}
+	return mutatingWebhookDefaultOrder(name)
+}
+
+// mutatingWebhookDefaultOrder returns the default order of the named webhook, or -1 if it is unknown.
+func mutatingWebhookDefaultOrder(name string) int {
+	for i, n := range fleetMutatingWebhookNames {
+		if n == name {
+			return i
+		}
+	}
+	return -1
+}
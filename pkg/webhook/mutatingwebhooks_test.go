@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	admv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/kubefleet-dev/kubefleet/cmd/hubagent/options"
+)
+
+// mutatingWebhookSummary is the name and the reinvocation policy of a built mutating webhook.
+type mutatingWebhookSummary struct {
+	Name               string
+	ReinvocationPolicy admv1.ReinvocationPolicyType
+}
+
+func TestBuildFleetMutatingWebhooksOrder(t *testing.T) {
+	url := options.WebhookClientConnectionType("url")
+	testCases := map[string]struct {
+		overrides map[string]MutatingWebhookOverride
+		want      []mutatingWebhookSummary
+	}{
+		"default order and policies": {
+			want: []mutatingWebhookSummary{
+				{Name: crpRollbackMutatingWebhookName, ReinvocationPolicy: admv1.NeverReinvocationPolicy},
+				{Name: crpMutatingWebhookName, ReinvocationPolicy: admv1.IfNeededReinvocationPolicy},
+				{Name: crpAnnotationNormalizingMutatingWebhookName, ReinvocationPolicy: admv1.IfNeededReinvocationPolicy},
+				{Name: crpSpecHistoryMutatingWebhookName, ReinvocationPolicy: admv1.NeverReinvocationPolicy},
+				{Name: crpPhaseTimestampsMutatingWebhookName, ReinvocationPolicy: admv1.NeverReinvocationPolicy},
+			},
+		},
+		"overridden order and policy": {
+			overrides: map[string]MutatingWebhookOverride{
+				crpPhaseTimestampsMutatingWebhookName:       {Order: ptr.To(-1)},
+				crpAnnotationNormalizingMutatingWebhookName: {ReinvocationPolicy: ptr.To(admv1.NeverReinvocationPolicy)},
+			},
+			want: []mutatingWebhookSummary{
+				{Name: crpPhaseTimestampsMutatingWebhookName, ReinvocationPolicy: admv1.NeverReinvocationPolicy},
+				{Name: crpRollbackMutatingWebhookName, ReinvocationPolicy: admv1.NeverReinvocationPolicy},
+				{Name: crpMutatingWebhookName, ReinvocationPolicy: admv1.IfNeededReinvocationPolicy},
+				{Name: crpAnnotationNormalizingMutatingWebhookName, ReinvocationPolicy: admv1.NeverReinvocationPolicy},
+				{Name: crpSpecHistoryMutatingWebhookName, ReinvocationPolicy: admv1.NeverReinvocationPolicy},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if err := validateMutatingWebhookOverrides(tc.overrides); err != nil {
+				t.Fatalf("validateMutatingWebhookOverrides() = %v, want no error", err)
+			}
+			w := &Config{
+				serviceNamespace:         "test-namespace",
+				servicePort:              8080,
+				serviceURL:               "test-url",
+				clientConnectionType:     &url,
+				mutatingWebhookOverrides: tc.overrides,
+			}
+			var got []mutatingWebhookSummary
+			for _, wh := range w.buildFleetMutatingWebhooks() {
+				got = append(got, mutatingWebhookSummary{Name: wh.Name, ReinvocationPolicy: ptr.Deref(wh.ReinvocationPolicy, "")})
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("buildFleetMutatingWebhooks() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateMutatingWebhookOverrides(t *testing.T) {
+	testCases := map[string]struct {
+		overrides map[string]MutatingWebhookOverride
+		wantErr   string
+	}{
+		"no overrides": {},
+		"unknown webhook": {
+			overrides: map[string]MutatingWebhookOverride{"example.mutating": {Order: ptr.To(0)}},
+			wantErr:   "unknown webhook example.mutating",
+		},
+		"unknown reinvocation policy": {
+			overrides: map[string]MutatingWebhookOverride{crpMutatingWebhookName: {ReinvocationPolicy: ptr.To(admv1.ReinvocationPolicyType("Always"))}},
+			wantErr:   "unknown reinvocation policy Always",
+		},
+		"normalizing before defaulting": {
+			overrides: map[string]MutatingWebhookOverride{crpMutatingWebhookName: {Order: ptr.To(5)}},
+			wantErr:   "must be ordered before",
+		},
+		"defaulting tied with normalizing": {
+			overrides: map[string]MutatingWebhookOverride{crpAnnotationNormalizingMutatingWebhookName: {Order: ptr.To(0)}},
+			wantErr:   "must be ordered before",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validateMutatingWebhookOverrides(tc.overrides)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateMutatingWebhookOverrides() = %v, want no error", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("validateMutatingWebhookOverrides() = %v, want error containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -18,7 +18,6 @@ package pod
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 
 	admissionv1 "k8s.io/api/admission/v1"
@@ -30,28 +29,30 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/kubefleet-dev/kubefleet/pkg/utils"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
+	"github.com/kubefleet-dev/kubefleet/pkg/webhook/validation"
 )
 
 const (
 	deniedPodResource  = "Pod creation is disallowed in the fleet hub cluster"
 	allowedPodResource = "Pod creation is allowed in the fleet hub cluster"
-	podDeniedFormat    = "Pod %s/%s creation is disallowed in the fleet hub cluster"
 )
 
 var (
 	// ValidationPath is the webhook service path which admission requests are routed to for validating Pod resources.
-	ValidationPath = fmt.Sprintf(utils.ValidationPathFmt, corev1.SchemeGroupVersion.Group, corev1.SchemeGroupVersion.Version, "pod")
+	ValidationPath = utils.RegisterWebhookPath(corev1.SchemeGroupVersion.Group, corev1.SchemeGroupVersion.Version, "pod", utils.ValidatingWebhookPathKind)
 )
 
 // Add registers the webhook for K8s bulit-in object types.
-func Add(mgr manager.Manager) error {
+func Add(mgr manager.Manager, config *validator.ConfigStore) error {
 	hookServer := mgr.GetWebhookServer()
-	hookServer.Register(ValidationPath, &webhook.Admission{Handler: &podValidator{admission.NewDecoder(mgr.GetScheme())}})
+	hookServer.Register(ValidationPath, &webhook.Admission{Handler: &podValidator{config: config}})
 	return nil
 }
 
 type podValidator struct {
-	decoder webhook.AdmissionDecoder
+	// config holds the validator settings of the requests.
+	config *validator.ConfigStore
 }
 
 // Handle podValidator denies a pod if it is not created in the system namespaces.
@@ -59,14 +60,14 @@ func (v *podValidator) Handle(_ context.Context, req admission.Request) admissio
 	namespacedName := types.NamespacedName{Name: req.Name, Namespace: req.Namespace}
 	if req.Operation == admissionv1.Create {
 		klog.V(2).InfoS("handling pod resource", "operation", req.Operation, "subResource", req.SubResource, "namespacedName", namespacedName)
-		pod := &corev1.Pod{}
-		err := v.decoder.Decode(req, pod)
+		// Only the metadata is inspected, so the pod spec, which can be huge, is not decoded.
+		pod, err := validation.DecodeObjectMetadata(req)
 		if err != nil {
 			return admission.Errored(http.StatusBadRequest, err)
 		}
 		if !utils.IsReservedNamespace(pod.Namespace) {
 			klog.V(2).InfoS(deniedPodResource, "user", req.UserInfo.Username, "groups", req.UserInfo.Groups, "operation", req.Operation, "GVK", req.RequestKind, "subResource", req.SubResource, "namespacedName", namespacedName)
-			return admission.Denied(fmt.Sprintf(podDeniedFormat, pod.Namespace, pod.Name))
+			return admission.Denied(v.config.Load().DenialMessage(validator.PodCreationMessageID, map[string]any{"namespace": pod.Namespace, "name": pod.Name}))
 		}
 	}
 	klog.V(3).InfoS(allowedPodResource, "user", req.UserInfo.Username, "groups", req.UserInfo.Groups, "operation", req.Operation, "GVK", req.RequestKind, "subResource", req.SubResource, "namespacedName", namespacedName)
@@ -18,7 +18,6 @@ package replicaset
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 
 	admissionv1 "k8s.io/api/admission/v1"
@@ -30,27 +29,29 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/kubefleet-dev/kubefleet/pkg/utils"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
+	"github.com/kubefleet-dev/kubefleet/pkg/webhook/validation"
 )
 
 const (
 	deniedReplicaSetResource  = "ReplicaSet creation is disallowed in the fleet hub cluster"
 	allowedReplicaSetResource = "ReplicaSet creation is allowed in the fleet hub cluster"
-	replicaSetDeniedFormat    = "ReplicaSet %s/%s creation is disallowed in the fleet hub cluster."
 )
 
 var (
 	// ValidationPath is the webhook service path which admission requests are routed to for validating ReplicaSet resources.
-	ValidationPath = fmt.Sprintf(utils.ValidationPathFmt, appsv1.SchemeGroupVersion.Group, appsv1.SchemeGroupVersion.Version, "replicaset")
+	ValidationPath = utils.RegisterWebhookPath(appsv1.SchemeGroupVersion.Group, appsv1.SchemeGroupVersion.Version, "replicaset", utils.ValidatingWebhookPathKind)
 )
 
 type replicaSetValidator struct {
-	decoder webhook.AdmissionDecoder
+	// config holds the validator settings of the requests.
+	config *validator.ConfigStore
 }
 
 // Add registers the webhook for K8s bulit-in object types.
-func Add(mgr manager.Manager) error {
+func Add(mgr manager.Manager, config *validator.ConfigStore) error {
 	hookServer := mgr.GetWebhookServer()
-	hookServer.Register(ValidationPath, &webhook.Admission{Handler: &replicaSetValidator{admission.NewDecoder(mgr.GetScheme())}})
+	hookServer.Register(ValidationPath, &webhook.Admission{Handler: &replicaSetValidator{config: config}})
 	return nil
 }
 
@@ -59,13 +60,14 @@ func (v *replicaSetValidator) Handle(_ context.Context, req admission.Request) a
 	namespacedName := types.NamespacedName{Name: req.Name, Namespace: req.Namespace}
 	if req.Operation == admissionv1.Create {
 		klog.V(2).InfoS("handling replicaSet resource", "operation", req.Operation, "subResource", req.SubResource, "namespacedName", namespacedName)
-		rs := &appsv1.ReplicaSet{}
-		if err := v.decoder.Decode(req, rs); err != nil {
+		// Only the metadata is inspected, so the pod template, which can be huge, is not decoded.
+		rs, err := validation.DecodeObjectMetadata(req)
+		if err != nil {
 			return admission.Errored(http.StatusBadRequest, err)
 		}
 		if !utils.IsReservedNamespace(rs.Namespace) {
 			klog.V(2).InfoS(deniedReplicaSetResource, "user", req.UserInfo.Username, "groups", req.UserInfo.Groups, "operation", req.Operation, "GVK", req.RequestKind, "subResource", req.SubResource, "namespacedName", namespacedName)
-			return admission.Denied(fmt.Sprintf(replicaSetDeniedFormat, rs.Namespace, rs.Name))
+			return admission.Denied(v.config.Load().DenialMessage(validator.ReplicaSetCreationMessageID, map[string]any{"namespace": rs.Namespace, "name": rs.Name}))
 		}
 	}
 	klog.V(3).InfoS(allowedReplicaSetResource, "user", req.UserInfo.Username, "groups", req.UserInfo.Groups, "operation", req.Operation, "GVK", req.RequestKind, "subResource", req.SubResource, "namespacedName", namespacedName)
@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replicaset
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kubefleet-dev/kubefleet/pkg/utils"
+	webhooktesting "github.com/kubefleet-dev/kubefleet/pkg/webhook/testing"
+)
+
+// typedVerdict returns whether the request is allowed when the object is decoded in full, which the metadata
+// decode of the handler must agree with.
+func typedVerdict(t *testing.T, req admission.Request) bool {
+	t.Helper()
+	obj := &appsv1.ReplicaSet{}
+	if err := admission.NewDecoder(webhooktesting.Scheme).Decode(req, obj); err != nil {
+		t.Fatalf("Decode() = %v, want no error", err)
+	}
+	return utils.IsReservedNamespace(obj.Namespace)
+}
+
+func TestHandle(t *testing.T) {
+	newReplicaSet := func(namespace string, envVars int) *appsv1.ReplicaSet {
+		containers := []corev1.Container{{Name: "app", Image: "app:latest"}}
+		for i := 0; i < envVars; i++ {
+			containers[0].Env = append(containers[0].Env, corev1.EnvVar{Name: fmt.Sprintf("ENV_VAR_%d", i), Value: "value"})
+		}
+		return &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "test-rs", Namespace: namespace}, Spec: appsv1.ReplicaSetSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: containers}}}}
+	}
+	testCases := map[string]struct {
+		req         admission.Request
+		wantAllowed bool
+	}{
+		"create in a fleet reserved namespace": {
+			req:         webhooktesting.NewCreateRequest(newReplicaSet(utils.FleetSystemNamespace, 0)),
+			wantAllowed: true,
+		},
+		"create in kube-system": {
+			req:         webhooktesting.NewCreateRequest(newReplicaSet("kube-system", 0)),
+			wantAllowed: true,
+		},
+		"create in a user namespace": {
+			req: webhooktesting.NewCreateRequest(newReplicaSet("test-ns", 0)),
+		},
+		"create of a large object in a user namespace": {
+			req: webhooktesting.NewCreateRequest(newReplicaSet("test-ns", 10000)),
+		},
+		"create of a large object in kube-system": {
+			req:         webhooktesting.NewCreateRequest(newReplicaSet("kube-system", 10000)),
+			wantAllowed: true,
+		},
+	}
+	v := &replicaSetValidator{}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := v.Handle(context.Background(), tc.req)
+			if resp.Allowed != tc.wantAllowed {
+				t.Errorf("Handle() allowed = %t, want %t: %v", resp.Allowed, tc.wantAllowed, resp.Result)
+			}
+			if want := typedVerdict(t, tc.req); resp.Allowed != want {
+				t.Errorf("Handle() allowed = %t, want %t as the typed decode", resp.Allowed, want)
+			}
+		})
+	}
+
+	t.Run("non create requests are allowed", func(t *testing.T) {
+		obj := newReplicaSet("test-ns", 0)
+		webhooktesting.AssertAllowed(t, v.Handle(context.Background(), webhooktesting.NewUpdateRequest(obj, obj)))
+	})
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"net/http"
+
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// requestLoggerHandler is the admission middleware which hands the klog logger to the wrapped handler through the
+// request context, optionally carrying the fields identifying the request.
+type requestLoggerHandler struct {
+	handler admission.Handler
+	path    string
+	// withRequestContext indicates if the logger carries the request UID, user, operation and webhook path.
+	withRequestContext bool
+	logger             klog.Logger
+}
+
+// WithRequestLogger wraps the admission handler registered at path so that it logs through the klog logger stored in
+// the request context, instead of the controller-runtime logger the webhook server stores there. If
+// withRequestContext is true, every line logged by the handler carries the UID of the admission request, which
+// correlates it with the API server audit entries, the user, the operation and the webhook path.
+func WithRequestLogger(handler admission.Handler, path string, withRequestContext bool) admission.Handler {
+	return &requestLoggerHandler{handler: handler, path: path, withRequestContext: withRequestContext, logger: klog.Background()}
+}
+
+// Handle handles the request with the wrapped handler, whose context holds the request logger.
+func (h *requestLoggerHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	logger := h.logger
+	if h.withRequestContext {
+		logger = logger.WithValues("requestUID", req.UID, "user", req.UserInfo.Username, "operation", req.Operation, "path", h.path)
+	}
+	return h.handler.Handle(klog.NewContext(ctx, logger), req)
+}
+
+// requestLoggingWebhookServer wraps every admission handler it registers with the request logger middleware.
+type requestLoggingWebhookServer struct {
+	webhook.Server
+	withRequestContext bool
+}
+
+// Register registers the handler, wrapping it with the request logger middleware if it is an admission webhook.
+func (s *requestLoggingWebhookServer) Register(path string, hook http.Handler) {
+	if wh, ok := hook.(*webhook.Admission); ok {
+		wh.Handler = WithRequestLogger(wh.Handler, path, s.withRequestContext)
+	}
+	s.Server.Register(path, hook)
+}
+
+// requestLoggingManager is a manager whose webhook server hands the request logger to the admission handlers.
+type requestLoggingManager struct {
+	manager.Manager
+	server *requestLoggingWebhookServer
+}
+
+// GetWebhookServer returns the request logging webhook server.
+func (m *requestLoggingManager) GetWebhookServer() webhook.Server {
+	return m.server
+}
+
+// withRequestLogging returns the manager which registers the webhooks with the request logger middleware. Unlike the
+// other middlewares, it is always applied so that the handlers keep logging through klog whether the request fields
+// are included or not.
+func withRequestLogging(m manager.Manager, withRequestContext bool) manager.Manager {
+	return &requestLoggingManager{Manager: m, server: &requestLoggingWebhookServer{Server: m.GetWebhookServer(), withRequestContext: withRequestContext}}
+}
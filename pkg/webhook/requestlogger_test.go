@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"k8s.io/klog/v2/textlogger"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kubefleet-dev/kubefleet/cmd/hubagent/options"
+)
+
+// loggingHandler logs like the migrated handlers, i.e., the denials at verbosity 2 and the allowed requests at
+// verbosity 3, through the logger of the request context.
+type loggingHandler struct {
+	allowed bool
+}
+
+func (h *loggingHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if h.allowed {
+		klog.FromContext(ctx).V(3).Info("allowing the request", "name", req.Name)
+		return admission.Allowed("")
+	}
+	klog.FromContext(ctx).V(2).Info("denying the request", "name", req.Name)
+	return admission.Denied("denied")
+}
+
+func TestWithRequestLogger(t *testing.T) {
+	req := admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			UID:       types.UID("test-uid"),
+			Name:      "test-crp",
+			Operation: admissionv1.Update,
+			UserInfo:  authenticationv1.UserInfo{Username: "test-user"},
+		},
+	}
+	testCases := map[string]struct {
+		allowed            bool
+		withRequestContext bool
+		wantLogged         []string
+		wantNotLogged      []string
+	}{
+		"denied request with the request context": {
+			withRequestContext: true,
+			wantLogged:         []string{`"denying the request"`, `requestUID="test-uid"`, `user="test-user"`, `operation="UPDATE"`, `path="/validate-test"`, `name="test-crp"`},
+		},
+		"denied request without the request context": {
+			wantLogged:    []string{`"denying the request"`, `name="test-crp"`},
+			wantNotLogged: []string{"requestUID", "user=", "path="},
+		},
+		"allowed request is not logged at the default verbosity": {
+			allowed:            true,
+			withRequestContext: true,
+			wantNotLogged:      []string{"allowing the request", "requestUID"},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			h := WithRequestLogger(&loggingHandler{allowed: tc.allowed}, "/validate-test", tc.withRequestContext).(*requestLoggerHandler)
+			h.logger = textlogger.NewLogger(textlogger.NewConfig(textlogger.Output(&out), textlogger.Verbosity(2)))
+			if resp := h.Handle(context.Background(), req); resp.Allowed != tc.allowed {
+				t.Errorf("Handle() allowed = %t, want %t", resp.Allowed, tc.allowed)
+			}
+			got := out.String()
+			for _, want := range tc.wantLogged {
+				if !strings.Contains(got, want) {
+					t.Errorf("Handle() logged %q, want it to contain %q", got, want)
+				}
+			}
+			for _, notWant := range tc.wantNotLogged {
+				if strings.Contains(got, notWant) {
+					t.Errorf("Handle() logged %q, want it not to contain %q", got, notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestAddToManagerLogsRequests(t *testing.T) {
+	for _, role := range options.WebhookGroups {
+		mgr := &fakeWebhookManager{server: &pathRecordingServer{}}
+		w := &Config{role: role, logRequestContext: true}
+		if err := w.AddToManager(mgr, nil, nil); err != nil {
+			t.Fatalf("AddToManager(%s) = %v, want no error", role, err)
+		}
+		for path, hook := range mgr.server.handlers {
+			wh, ok := hook.(*webhook.Admission)
+			if !ok {
+				continue
+			}
+			h, ok := wh.Handler.(*requestLoggerHandler)
+			if !ok {
+				t.Errorf("AddToManager(%s) handler at %s is not wrapped with the request logger", role, path)
+				continue
+			}
+			if h.path != path || !h.withRequestContext {
+				t.Errorf("AddToManager(%s) request logger at %s = (%s, %t), want (%s, true)", role, path, h.path, h.withRequestContext, path)
+			}
+		}
+	}
+}
@@ -32,11 +32,12 @@ import (
 	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
 	"github.com/kubefleet-dev/kubefleet/pkg/utils"
 	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
+	"github.com/kubefleet-dev/kubefleet/pkg/webhook/validation"
 )
 
 var (
 	// ValidationPath is the webhook service path which admission requests are routed to for validating resourceoverride resources.
-	ValidationPath = fmt.Sprintf(utils.ValidationPathFmt, placementv1beta1.GroupVersion.Group, placementv1beta1.GroupVersion.Version, "resourceoverride")
+	ValidationPath = utils.RegisterWebhookPath(placementv1beta1.GroupVersion.Group, placementv1beta1.GroupVersion.Version, "resourceoverride", utils.ValidatingWebhookPathKind)
 )
 
 type resourceOverrideValidator struct {
@@ -44,12 +45,18 @@ type resourceOverrideValidator struct {
 	// since we need to guarantee that a resource cannot be selected by multiple overrides.
 	client  client.Reader
 	decoder webhook.AdmissionDecoder
+	// config holds the validator settings of the requests.
+	config *validator.ConfigStore
 }
 
 // Add registers the webhook for K8s bulit-in object types.
-func Add(mgr manager.Manager) error {
+func Add(mgr manager.Manager, config *validator.ConfigStore) error {
+	decoder, err := validation.SharedDecoder(mgr.GetScheme(), &placementv1beta1.ResourceOverride{})
+	if err != nil {
+		return err
+	}
 	hookServer := mgr.GetWebhookServer()
-	hookServer.Register(ValidationPath, &webhook.Admission{Handler: &resourceOverrideValidator{mgr.GetAPIReader(), admission.NewDecoder(mgr.GetScheme())}})
+	hookServer.Register(ValidationPath, &webhook.Admission{Handler: &resourceOverrideValidator{client: mgr.GetAPIReader(), decoder: decoder, config: config}})
 	return nil
 }
 
@@ -72,7 +79,7 @@ func (v *resourceOverrideValidator) Handle(ctx context.Context, req admission.Re
 	// Check if the override count limit has been reached, if there are at most 100 resource overrides.
 	if req.Operation == admissionv1.Create && len(roList.Items) >= 100 {
 		klog.Errorf("ResourceOverride limit has been reached: at most 100 resources can be created.")
-		return admission.Denied("resourceOverride limit has been reached: at most 100 resources can be created.")
+		return admission.Denied(v.config.Load().DenialMessage(validator.ResourceOverrideLimitMessageID, map[string]any{"limit": 100}))
 	}
 
 	if err := validator.ValidateResourceOverride(ro, roList); err != nil {
@@ -20,7 +20,15 @@ package resourceplacement
 import (
 	"context"
 	"fmt"
+	"net/http"
 
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -28,31 +36,54 @@ import (
 	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
 	"github.com/kubefleet-dev/kubefleet/pkg/utils"
 	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
+	"github.com/kubefleet-dev/kubefleet/pkg/webhook/validation"
 )
 
 var (
 	// ValidationPath is the webhook service path which admission requests are routed to for validating v1beta1 RP resources.
-	ValidationPath = fmt.Sprintf(utils.ValidationPathFmt, placementv1beta1.GroupVersion.Group, placementv1beta1.GroupVersion.Version, "resourceplacement")
+	ValidationPath = utils.RegisterWebhookPath(placementv1beta1.GroupVersion.Group, placementv1beta1.GroupVersion.Version, "resourceplacement", utils.ValidatingWebhookPathKind)
 )
 
 type resourcePlacementValidator struct {
+	client  client.Reader
 	decoder webhook.AdmissionDecoder
+	// namespaceReader is used to look up the namespace of the RP being created. The lookup is skipped if it is nil.
+	namespaceReader client.Reader
+	// config holds the validator settings of the requests.
+	config *validator.ConfigStore
 }
 
 // Add registers the webhook for K8s bulit-in object types.
-func Add(mgr manager.Manager) error {
+func Add(mgr manager.Manager, config *validator.ConfigStore) error {
+	decoder, err := validation.SharedDecoder(mgr.GetScheme(), &placementv1beta1.ResourcePlacement{})
+	if err != nil {
+		return err
+	}
+	if err := validator.IndexUpdateRunsByPlacementName(context.Background(), mgr.GetFieldIndexer(), &placementv1beta1.StagedUpdateRun{}); err != nil {
+		klog.ErrorS(err, "Failed to set up the placement name index for staged update runs")
+		return err
+	}
 	hookServer := mgr.GetWebhookServer()
-	hookServer.Register(ValidationPath, &webhook.Admission{Handler: &resourcePlacementValidator{admission.NewDecoder(mgr.GetScheme())}})
+	hookServer.Register(ValidationPath, &webhook.Admission{Handler: &resourcePlacementValidator{client: mgr.GetClient(), decoder: decoder, namespaceReader: mgr.GetClient(), config: config}})
 	return nil
 }
 
+// acceptedKinds are the kinds of the ResourcePlacements the validator decodes.
+var acceptedKinds = []metav1.GroupVersionKind{utils.ResourcePlacementMetaGVK}
+
 // Handle resourcePlacementValidator handles create, update RP requests.
 func (v *resourcePlacementValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
-	return validator.HandlePlacementValidation(
+	// All the client-backed checks of the request share one lookup budget, so that a slow or partitioned hub cache
+	// cannot stall the admission.
+	config := v.config.Load()
+	ctx, cancel := config.WithLookupBudget(ctx, 0)
+	defer cancel()
+	resp := config.HandlePlacementValidation(
 		ctx,
 		req,
 		v.decoder,
 		"RP",
+		acceptedKinds,
 		// decodeFunc
 		func(req admission.Request, decoder webhook.AdmissionDecoder) (placementv1beta1.PlacementObj, error) {
 			var rp placementv1beta1.ResourcePlacement
@@ -67,7 +98,46 @@ func (v *resourcePlacementValidator) Handle(ctx context.Context, req admission.R
 		},
 		// validateFunc
 		func(obj placementv1beta1.PlacementObj) error {
-			return validator.ValidateResourcePlacement(obj.(*placementv1beta1.ResourcePlacement))
+			return config.ValidateResourcePlacement(obj.(*placementv1beta1.ResourcePlacement))
 		},
 	)
+	// The RPs in a terminating namespace can still be updated, e.g., to remove their finalizers. The requests of the
+	// unknown kinds allowed without validation cannot be decoded.
+	if !resp.Allowed || req.Operation != admissionv1.Create || !validator.IsAcceptedKind(req, acceptedKinds) {
+		return resp
+	}
+	var rp placementv1beta1.ResourcePlacement
+	if err := v.decoder.Decode(req, &rp); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if resp = v.validateNamespaceNotTerminating(ctx, config, &rp, resp); !resp.Allowed {
+		return resp
+	}
+	return config.ValidatePlacementNameCollision(ctx, v.client, &rp, resp)
+}
+
+// validateNamespaceNotTerminating denies the creation of the RP allowed by resp if its namespace is terminating, as
+// the RP would never be placed and could be left behind with its finalizer. A warning is added to resp if the
+// namespace is not found.
+func (v *resourcePlacementValidator) validateNamespaceNotTerminating(ctx context.Context, config validator.Config, rp *placementv1beta1.ResourcePlacement, resp admission.Response) admission.Response {
+	if v.namespaceReader == nil {
+		return resp
+	}
+	var ns corev1.Namespace
+	if err := v.namespaceReader.Get(ctx, types.NamespacedName{Name: rp.Namespace}, &ns); err != nil {
+		if exhausted := config.LookupBudgetExhausted(ctx, validator.PlacementNamespaceLookupCheck, err); exhausted != nil {
+			return validator.LookupUnavailableResponse(exhausted, resp)
+		}
+		if k8serrors.IsNotFound(err) {
+			klog.FromContext(ctx).V(2).Info("Namespace of the RP is not found", "resourcePlacement", klog.KObj(rp))
+			return resp.WithWarnings(fmt.Sprintf("namespace %s of the RP is not found", rp.Namespace))
+		}
+		klog.FromContext(ctx).Error(err, "Failed to get the namespace of the RP", "resourcePlacement", klog.KObj(rp))
+		return admission.Errored(http.StatusInternalServerError, fmt.Errorf("failed to get namespace %s of the RP, please retry the request: %w", rp.Namespace, err))
+	}
+	if ns.DeletionTimestamp != nil {
+		klog.FromContext(ctx).V(2).Info("Namespace of the RP is terminating, request is denied", "resourcePlacement", klog.KObj(rp))
+		return admission.Denied(config.DenialMessage(validator.NamespaceTerminatingMessageID, map[string]any{"namespace": rp.Namespace}))
+	}
+	return resp
 }
@@ -3,6 +3,7 @@ package resourceplacement
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"testing"
@@ -10,17 +11,21 @@ import (
 
 	admissionv1 "k8s.io/api/admission/v1"
 	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
 	"github.com/kubefleet-dev/kubefleet/pkg/utils"
 	"github.com/kubefleet-dev/kubefleet/pkg/utils/informer"
 	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
+	webhooktesting "github.com/kubefleet-dev/kubefleet/pkg/webhook/testing"
 	testinformer "github.com/kubefleet-dev/kubefleet/test/utils/informer"
 	"github.com/stretchr/testify/assert"
 )
@@ -32,10 +37,16 @@ var (
 		Kind:    "Deployment",
 		Name:    "test-deployment",
 	}
+	testUserInfo = authenticationv1.UserInfo{
+		Username: "test-user",
+		Groups:   []string{"system:masters"},
+	}
 	errString = "the rollout Strategy field  is invalid: maxUnavailable must be greater than or equal to 0, got `-1`"
 )
 
 func TestHandle(t *testing.T) {
+	// Every placement validation rule and the field validation pass on the allowed requests.
+	allRulesPassed := len(validator.PlacementValidationRuleNames()) + 1
 	invalidRPObject := &placementv1beta1.ResourcePlacement{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:       "test-rp",
@@ -147,24 +158,7 @@ func TestHandle(t *testing.T) {
 		},
 	}
 
-	validRPObjectBytes, err := json.Marshal(validRPObject)
-	assert.Nil(t, err)
-	invalidRPObjectBytes, err := json.Marshal(invalidRPObject)
-	assert.Nil(t, err)
-	invalidRPObjectDeletingFinalizersRemovedBytes, err := json.Marshal(invalidRPObjectDeletingFinalizersRemoved)
-	assert.Nil(t, err)
-	invalidRPObjectFinalizersRemovedBytes, err := json.Marshal(invalidRPObjectFinalizersRemoved)
-	assert.Nil(t, err)
-	updatedPlacementTypeRPObjectBytes, err := json.Marshal(updatedPlacementTypeRPObject)
-	assert.Nil(t, err)
-	validRPObjectWithTolerationsBytes, err := json.Marshal(validRPObjectWithTolerations)
-	assert.Nil(t, err)
-
-	scheme := runtime.NewScheme()
-	err = placementv1beta1.AddToScheme(scheme)
-	assert.Nil(t, err)
-	decoder := admission.NewDecoder(scheme)
-	assert.Nil(t, err)
+	decoder := admission.NewDecoder(webhooktesting.Scheme)
 
 	testCases := map[string]struct {
 		req               admission.Request
@@ -173,21 +167,7 @@ func TestHandle(t *testing.T) {
 		wantResponse      admission.Response
 	}{
 		"allow RP create": {
-			req: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Name: "test-rp",
-					Object: runtime.RawExtension{
-						Raw:    validRPObjectBytes,
-						Object: validRPObject,
-					},
-					UserInfo: authenticationv1.UserInfo{
-						Username: "test-user",
-						Groups:   []string{"system:masters"},
-					},
-					RequestKind: &utils.ClusterResourcePlacementMetaGVK,
-					Operation:   admissionv1.Create,
-				},
-			},
+			req: webhooktesting.NewCreateRequest(validRPObject, webhooktesting.WithUserInfo(testUserInfo)),
 			resourceInformer: &testinformer.FakeManager{
 				APIResources:            map[schema.GroupVersionKind]bool{utils.DeploymentGVK: true},
 				IsClusterScopedResource: false,
@@ -195,24 +175,10 @@ func TestHandle(t *testing.T) {
 			resourceValidator: resourcePlacementValidator{
 				decoder: decoder,
 			},
-			wantResponse: admission.Allowed(fmt.Sprintf(validator.AllowModifyFmt, "RP")),
+			wantResponse: admission.Allowed(validator.AllowedMessage("v1beta1 RP", allRulesPassed, 0)),
 		},
 		"deny RP create - invalid RP object": {
-			req: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Name: "test-rp",
-					Object: runtime.RawExtension{
-						Raw:    invalidRPObjectBytes,
-						Object: invalidRPObject,
-					},
-					UserInfo: authenticationv1.UserInfo{
-						Username: "test-user",
-						Groups:   []string{"system:masters"},
-					},
-					RequestKind: &utils.ClusterResourcePlacementMetaGVK,
-					Operation:   admissionv1.Create,
-				},
-			},
+			req: webhooktesting.NewCreateRequest(invalidRPObject, webhooktesting.WithUserInfo(testUserInfo)),
 			resourceInformer: &testinformer.FakeManager{
 				APIResources:            map[schema.GroupVersionKind]bool{utils.DeploymentGVK: true},
 				IsClusterScopedResource: false,
@@ -223,25 +189,7 @@ func TestHandle(t *testing.T) {
 			wantResponse: admission.Denied(fmt.Sprintf(validator.DenyCreateUpdateInvalidFmt, "RP", errString)),
 		},
 		"allow RP update - invalid old RP object, invalid new RP is deleting, finalizer removed": {
-			req: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Name: "test-rp",
-					OldObject: runtime.RawExtension{
-						Raw:    invalidRPObjectBytes,
-						Object: invalidRPObject,
-					},
-					Object: runtime.RawExtension{
-						Raw:    invalidRPObjectDeletingFinalizersRemovedBytes,
-						Object: invalidRPObjectDeletingFinalizersRemoved,
-					},
-					UserInfo: authenticationv1.UserInfo{
-						Username: "test-user",
-						Groups:   []string{"system:masters"},
-					},
-					RequestKind: &utils.ClusterResourcePlacementMetaGVK,
-					Operation:   admissionv1.Update,
-				},
-			},
+			req: webhooktesting.NewUpdateRequest(invalidRPObject, invalidRPObjectDeletingFinalizersRemoved, webhooktesting.WithUserInfo(testUserInfo)),
 			resourceInformer: &testinformer.FakeManager{
 				APIResources:            map[schema.GroupVersionKind]bool{utils.DeploymentGVK: true},
 				IsClusterScopedResource: false,
@@ -251,26 +199,8 @@ func TestHandle(t *testing.T) {
 			},
 			wantResponse: admission.Allowed(fmt.Sprintf(validator.AllowUpdateOldInvalidFmt, "RP")),
 		},
-		"deny RP update - invalid old RP, invalid new RP is not deleting, finalizer removed": {
-			req: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Name: "test-rp",
-					OldObject: runtime.RawExtension{
-						Raw:    invalidRPObjectBytes,
-						Object: invalidRPObject,
-					},
-					Object: runtime.RawExtension{
-						Raw:    invalidRPObjectFinalizersRemovedBytes,
-						Object: invalidRPObjectFinalizersRemoved,
-					},
-					UserInfo: authenticationv1.UserInfo{
-						Username: "test-user",
-						Groups:   []string{"system:masters"},
-					},
-					RequestKind: &utils.ClusterResourcePlacementMetaGVK,
-					Operation:   admissionv1.Update,
-				},
-			},
+		"allow RP update - invalid old RP, invalid new RP is not deleting, finalizer removed, spec not updated": {
+			req: webhooktesting.NewUpdateRequest(invalidRPObject, invalidRPObjectFinalizersRemoved, webhooktesting.WithUserInfo(testUserInfo)),
 			resourceInformer: &testinformer.FakeManager{
 				APIResources:            map[schema.GroupVersionKind]bool{utils.DeploymentGVK: true},
 				IsClusterScopedResource: false,
@@ -278,28 +208,10 @@ func TestHandle(t *testing.T) {
 			resourceValidator: resourcePlacementValidator{
 				decoder: decoder,
 			},
-			wantResponse: admission.Denied(fmt.Sprintf(validator.DenyUpdateOldInvalidFmt, "RP", errString)),
+			wantResponse: admission.Allowed(fmt.Sprintf(validator.AllowSpecUnchangedUpdateOldInvalidFmt, "RP")).WithWarnings(fmt.Sprintf(validator.WarnOldInvalidFmt, "RP", errString)),
 		},
 		"deny RP update - valid old RP, invalid new RP, spec updated": {
-			req: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Name: "test-rp",
-					OldObject: runtime.RawExtension{
-						Raw:    validRPObjectBytes,
-						Object: validRPObject,
-					},
-					Object: runtime.RawExtension{
-						Raw:    invalidRPObjectBytes,
-						Object: invalidRPObject,
-					},
-					UserInfo: authenticationv1.UserInfo{
-						Username: "test-user",
-						Groups:   []string{"system:masters"},
-					},
-					RequestKind: &utils.ClusterResourcePlacementMetaGVK,
-					Operation:   admissionv1.Update,
-				},
-			},
+			req: webhooktesting.NewUpdateRequest(validRPObject, invalidRPObject, webhooktesting.WithUserInfo(testUserInfo)),
 			resourceInformer: &testinformer.FakeManager{
 				APIResources:            map[schema.GroupVersionKind]bool{utils.DeploymentGVK: true},
 				IsClusterScopedResource: false,
@@ -310,25 +222,7 @@ func TestHandle(t *testing.T) {
 			wantResponse: admission.Denied(fmt.Sprintf(validator.DenyCreateUpdateInvalidFmt, "RP", errString)),
 		},
 		"deny RP update - new RP immutable placement type": {
-			req: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Name: "test-rp",
-					OldObject: runtime.RawExtension{
-						Raw:    validRPObjectBytes,
-						Object: validRPObject,
-					},
-					Object: runtime.RawExtension{
-						Raw:    updatedPlacementTypeRPObjectBytes,
-						Object: updatedPlacementTypeRPObject,
-					},
-					UserInfo: authenticationv1.UserInfo{
-						Username: "test-user",
-						Groups:   []string{"system:masters"},
-					},
-					RequestKind: &utils.ClusterResourcePlacementMetaGVK,
-					Operation:   admissionv1.Update,
-				},
-			},
+			req: webhooktesting.NewUpdateRequest(validRPObject, updatedPlacementTypeRPObject, webhooktesting.WithUserInfo(testUserInfo)),
 			resourceInformer: &testinformer.FakeManager{
 				APIResources:            map[schema.GroupVersionKind]bool{utils.DeploymentGVK: true},
 				IsClusterScopedResource: false,
@@ -339,25 +233,7 @@ func TestHandle(t *testing.T) {
 			wantResponse: admission.Denied("placement type is immutable"),
 		},
 		"deny RP update - new RP tolerations updated": {
-			req: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Name: "test-rp",
-					OldObject: runtime.RawExtension{
-						Raw:    validRPObjectWithTolerationsBytes,
-						Object: validRPObjectWithTolerations,
-					},
-					Object: runtime.RawExtension{
-						Raw:    validRPObjectBytes,
-						Object: validRPObject,
-					},
-					UserInfo: authenticationv1.UserInfo{
-						Username: "test-user",
-						Groups:   []string{"system:masters"},
-					},
-					RequestKind: &utils.ClusterResourcePlacementMetaGVK,
-					Operation:   admissionv1.Update,
-				},
-			},
+			req: webhooktesting.NewUpdateRequest(validRPObjectWithTolerations, validRPObject, webhooktesting.WithUserInfo(testUserInfo)),
 			resourceInformer: &testinformer.FakeManager{
 				APIResources:            map[schema.GroupVersionKind]bool{utils.DeploymentGVK: true},
 				IsClusterScopedResource: false,
@@ -430,3 +306,157 @@ func TestHandle(t *testing.T) {
 		})
 	}
 }
+
+func TestHandlePolicyFieldConflicts(t *testing.T) {
+	newRP := func(policy *placementv1beta1.PlacementPolicy) *placementv1beta1.ResourcePlacement {
+		return &placementv1beta1.ResourcePlacement{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-rp", Namespace: "test-ns"},
+			Spec: placementv1beta1.PlacementSpec{
+				ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{resourceSelector},
+				Policy:            policy,
+			},
+		}
+	}
+	testCases := map[string]struct {
+		policy             *placementv1beta1.PlacementPolicy
+		wantDeniedMessages []string
+	}{
+		"PickFixed with cluster names only": {
+			policy: &placementv1beta1.PlacementPolicy{PlacementType: placementv1beta1.PickFixedPlacementType, ClusterNames: []string{"member-1"}},
+		},
+		"PickFixed with affinity": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType: placementv1beta1.PickFixedPlacementType,
+				ClusterNames:  []string{"member-1"},
+				Affinity:      &placementv1beta1.Affinity{},
+			},
+			wantDeniedMessages: []string{"spec.policy.affinity: Forbidden: affinity must be nil for policy type PickFixed, only valid for PickAll/PickN placement policy types; PickFixed places the resources on exactly the clusters in clusterNames"},
+		},
+		"PickFixed with topology spread constraints": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:             placementv1beta1.PickFixedPlacementType,
+				ClusterNames:              []string{"member-1"},
+				TopologySpreadConstraints: []placementv1beta1.TopologySpreadConstraint{{TopologyKey: "region"}},
+			},
+			wantDeniedMessages: []string{"spec.policy.topologySpreadConstraints: Forbidden: topology spread constraints needs to be empty for policy type PickFixed"},
+		},
+		"PickFixed with tolerations": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType: placementv1beta1.PickFixedPlacementType,
+				ClusterNames:  []string{"member-1"},
+				Tolerations:   []placementv1beta1.Toleration{{Key: "key1", Operator: corev1.TolerationOpExists}},
+			},
+			wantDeniedMessages: []string{"spec.policy.tolerations: Forbidden: tolerations needs to be empty for policy type PickFixed"},
+		},
+		"PickAll with cluster names": {
+			policy:             &placementv1beta1.PlacementPolicy{PlacementType: placementv1beta1.PickAllPlacementType, ClusterNames: []string{"member-1"}},
+			wantDeniedMessages: []string{"spec.policy.clusterNames: Forbidden: cluster names needs to be empty for policy type PickAll, only valid for PickFixed policy type"},
+		},
+		"PickN with cluster names and topology spread constraints": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:             placementv1beta1.PickNPlacementType,
+				NumberOfClusters:          ptr.To(int32(1)),
+				ClusterNames:              []string{"member-1"},
+				TopologySpreadConstraints: []placementv1beta1.TopologySpreadConstraint{{TopologyKey: "region"}},
+			},
+			wantDeniedMessages: []string{"spec.policy.clusterNames: Forbidden: cluster names needs to be empty for policy type PickN"},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			validator.RestMapper = utils.TestMapper{}
+			validator.ResourceInformer = &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.DeploymentGVK: true},
+				IsClusterScopedResource: false,
+			}
+			v := resourcePlacementValidator{decoder: admission.NewDecoder(webhooktesting.Scheme)}
+			resp := v.Handle(context.Background(), webhooktesting.NewCreateRequest(newRP(tc.policy), webhooktesting.WithUserInfo(testUserInfo)))
+			if len(tc.wantDeniedMessages) == 0 {
+				webhooktesting.AssertAllowed(t, resp)
+				return
+			}
+			for _, want := range tc.wantDeniedMessages {
+				webhooktesting.AssertDenied(t, resp, want)
+			}
+		})
+	}
+}
+
+func TestHandleNamespaceTerminating(t *testing.T) {
+	rp := &placementv1beta1.ResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-rp", Namespace: "test-ns"},
+		Spec: placementv1beta1.PlacementSpec{
+			ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{resourceSelector},
+		},
+	}
+	activeNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-ns"}}
+	terminatingNS := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test-ns",
+			DeletionTimestamp: &metav1.Time{Time: time.Now()},
+			Finalizers:        []string{"kubernetes"},
+		},
+	}
+	testCases := map[string]struct {
+		objects      []client.Object
+		getErr       error
+		req          admission.Request
+		wantDenied   string
+		wantErrored  bool
+		wantWarnings []string
+	}{
+		"create in an active namespace": {
+			objects: []client.Object{activeNS},
+			req:     webhooktesting.NewCreateRequest(rp, webhooktesting.WithUserInfo(testUserInfo)),
+		},
+		"create in a terminating namespace": {
+			objects:    []client.Object{terminatingNS},
+			req:        webhooktesting.NewCreateRequest(rp, webhooktesting.WithUserInfo(testUserInfo)),
+			wantDenied: "the RP cannot be created in namespace test-ns as the namespace is terminating",
+		},
+		"create in a namespace which is not found": {
+			req:          webhooktesting.NewCreateRequest(rp, webhooktesting.WithUserInfo(testUserInfo)),
+			wantWarnings: []string{"namespace test-ns of the RP is not found"},
+		},
+		"create when the namespace cannot be read": {
+			objects:     []client.Object{activeNS},
+			getErr:      errors.New("connection refused"),
+			req:         webhooktesting.NewCreateRequest(rp, webhooktesting.WithUserInfo(testUserInfo)),
+			wantErrored: true,
+		},
+		"update in a terminating namespace": {
+			objects: []client.Object{terminatingNS},
+			req:     webhooktesting.NewUpdateRequest(rp, rp, webhooktesting.WithUserInfo(testUserInfo)),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			validator.RestMapper = utils.TestMapper{}
+			validator.ResourceInformer = &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.DeploymentGVK: true},
+				IsClusterScopedResource: false,
+			}
+			fakeClient := webhooktesting.NewFakeClientBuilder().WithObjects(tc.objects...).WithInterceptorFuncs(interceptor.Funcs{
+				Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					if tc.getErr != nil {
+						return tc.getErr
+					}
+					return c.Get(ctx, key, obj, opts...)
+				},
+			}).Build()
+			v := resourcePlacementValidator{decoder: admission.NewDecoder(webhooktesting.Scheme), namespaceReader: fakeClient}
+			resp := v.Handle(context.Background(), tc.req)
+			switch {
+			case tc.wantDenied != "":
+				webhooktesting.AssertDenied(t, resp, tc.wantDenied)
+			case tc.wantErrored:
+				if resp.Allowed || resp.Result.Code != http.StatusInternalServerError {
+					t.Errorf("Handle() = %+v, want an internal server error", resp.Result)
+				}
+			default:
+				webhooktesting.AssertAllowed(t, resp)
+				assert.Equal(t, tc.wantWarnings, []string(resp.Warnings), utils.TestCaseMsg, name)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"fmt"
+
+	"k8s.io/klog/v2"
+)
+
+// maxPrivilegedPort is the highest port which requires root privileges to bind.
+const maxPrivilegedPort = 1023
+
+// commonServicePorts are the webhook service ports which are always allowed. 443 is privileged but still allowed
+// for the legacy deployments running the hub agent as root; it is warned about all the same.
+var commonServicePorts = map[int32]bool{
+	443:  true,
+	8080: true,
+	8443: true,
+}
+
+// Validate returns an error if the webhook config is invalid. A privileged service port, which a security-hardened
+// deployment should not use, is logged, and denied if the privileged ports are disallowed.
+func (w *Config) Validate() error {
+	warning, err := checkServicePort(w.servicePort, w.disallowPrivilegedPorts)
+	if err != nil {
+		return err
+	}
+	if warning != "" {
+		klog.InfoS("Webhook service uses a privileged port", "servicePort", w.servicePort, "warning", warning)
+	}
+	return w.validateWebhookNames()
+}
+
+// checkServicePort returns a warning if the service port is privileged, or an error if the port is invalid or a
+// privileged port which is not common while the privileged ports are disallowed.
+func checkServicePort(port int32, disallowPrivilegedPorts bool) (string, error) {
+	if err := validatePort("service", port); err != nil {
+		return "", err
+	}
+	if port > maxPrivilegedPort {
+		return "", nil
+	}
+	if disallowPrivilegedPorts && !commonServicePorts[port] {
+		return "", fmt.Errorf("invalid webhook service port %d: privileged ports below %d are disallowed", port, maxPrivilegedPort+1)
+	}
+	return fmt.Sprintf("webhook service port %d is below %d and requires root privileges, which a security-hardened deployment should not use", port, maxPrivilegedPort+1), nil
+}
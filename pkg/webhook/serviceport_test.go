@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCheckServicePort(t *testing.T) {
+	testCases := map[string]struct {
+		port                    int32
+		disallowPrivilegedPorts bool
+		wantWarning             string
+		wantErr                 string
+	}{
+		"443 is warned about": {
+			port:        443,
+			wantWarning: "webhook service port 443 is below 1024 and requires root privileges, which a security-hardened deployment should not use",
+		},
+		"443 is allowed when the privileged ports are disallowed": {
+			port:                    443,
+			disallowPrivilegedPorts: true,
+			wantWarning:             "webhook service port 443 is below 1024 and requires root privileges, which a security-hardened deployment should not use",
+		},
+		"80 is warned about": {
+			port:        80,
+			wantWarning: "webhook service port 80 is below 1024 and requires root privileges, which a security-hardened deployment should not use",
+		},
+		"80 is denied when the privileged ports are disallowed": {
+			port:                    80,
+			disallowPrivilegedPorts: true,
+			wantErr:                 "invalid webhook service port 80: privileged ports below 1024 are disallowed",
+		},
+		"1023 is denied when the privileged ports are disallowed": {
+			port:                    1023,
+			disallowPrivilegedPorts: true,
+			wantErr:                 "invalid webhook service port 1023: privileged ports below 1024 are disallowed",
+		},
+		"1024 is not warned about": {
+			port:                    1024,
+			disallowPrivilegedPorts: true,
+		},
+		"8080 is not warned about": {
+			port:                    8080,
+			disallowPrivilegedPorts: true,
+		},
+		"8443 is not warned about": {
+			port: 8443,
+		},
+		"0 is always denied": {
+			port:    0,
+			wantErr: "invalid webhook service port 0: must be between 1 and 65535, inclusive",
+		},
+		"0 is denied when the privileged ports are disallowed": {
+			port:                    0,
+			disallowPrivilegedPorts: true,
+			wantErr:                 "invalid webhook service port 0: must be between 1 and 65535, inclusive",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			gotWarning, err := checkServicePort(tc.port, tc.disallowPrivilegedPorts)
+			gotErr := ""
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErr); diff != "" {
+				t.Errorf("checkServicePort() error mismatch (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantWarning, gotWarning); diff != "" {
+				t.Errorf("checkServicePort() warning mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	testCases := map[string]struct {
+		servicePort             int32
+		disallowPrivilegedPorts bool
+		wantErr                 bool
+	}{
+		"443 is allowed": {
+			servicePort:             443,
+			disallowPrivilegedPorts: true,
+		},
+		"80 is allowed by default": {
+			servicePort: 80,
+		},
+		"80 is denied when the privileged ports are disallowed": {
+			servicePort:             80,
+			disallowPrivilegedPorts: true,
+			wantErr:                 true,
+		},
+		"8080 is allowed": {
+			servicePort:             8080,
+			disallowPrivilegedPorts: true,
+		},
+		"0 is denied": {
+			servicePort: 0,
+			wantErr:     true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			w := &Config{servicePort: tc.servicePort, disallowPrivilegedPorts: tc.disallowPrivilegedPorts}
+			if err := w.Validate(); (err != nil) != tc.wantErr {
+				t.Errorf("Validate() = %v, want error: %t", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// responseMessage returns the status message of the response, if any.
+func responseMessage(resp admission.Response) string {
+	if resp.Result == nil {
+		return ""
+	}
+	return resp.Result.Message
+}
+
+// AssertAllowed fails the test if the response does not allow the request.
+func AssertAllowed(t testing.TB, resp admission.Response) {
+	t.Helper()
+	if !resp.Allowed {
+		t.Errorf("admission response allowed = false, want true: %q", responseMessage(resp))
+	}
+}
+
+// AssertDenied fails the test if the response allows the request, or if its message does not contain the
+// substring. Errored responses, which are also not allowed, fail the test as they are not denials.
+func AssertDenied(t testing.TB, resp admission.Response, substring string) {
+	t.Helper()
+	if resp.Allowed {
+		t.Errorf("admission response allowed = true, want false with message containing %q", substring)
+		return
+	}
+	if resp.Result != nil && resp.Result.Code != http.StatusForbidden {
+		t.Errorf("admission response code = %d, want %d (denied): %q", resp.Result.Code, http.StatusForbidden, responseMessage(resp))
+		return
+	}
+	if got := responseMessage(resp); !strings.Contains(got, substring) {
+		t.Errorf("admission response message = %q, want message containing %q", got, substring)
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// recordingTB records whether the test has been failed instead of failing it.
+type recordingTB struct {
+	testing.TB
+	failed bool
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(string, ...interface{}) {
+	r.failed = true
+}
+
+func TestAssertAllowed(t *testing.T) {
+	testCases := map[string]struct {
+		resp       admission.Response
+		wantFailed bool
+	}{
+		"allowed": {
+			resp: admission.Allowed("ok"),
+		},
+		"denied": {
+			resp:       admission.Denied("not ok"),
+			wantFailed: true,
+		},
+		"errored": {
+			resp:       admission.Errored(http.StatusBadRequest, errors.New("bad request")),
+			wantFailed: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tb := &recordingTB{TB: t}
+			AssertAllowed(tb, tc.resp)
+			if tb.failed != tc.wantFailed {
+				t.Errorf("AssertAllowed() failed = %t, want %t", tb.failed, tc.wantFailed)
+			}
+		})
+	}
+}
+
+func TestAssertDenied(t *testing.T) {
+	testCases := map[string]struct {
+		resp       admission.Response
+		substring  string
+		wantFailed bool
+	}{
+		"denied with the substring": {
+			resp:      admission.Denied("placement type is immutable"),
+			substring: "immutable",
+		},
+		"denied with any message": {
+			resp:      admission.Denied("placement type is immutable"),
+			substring: "",
+		},
+		"denied without the substring": {
+			resp:       admission.Denied("placement type is immutable"),
+			substring:  "tolerations",
+			wantFailed: true,
+		},
+		"allowed": {
+			resp:       admission.Allowed("placement type is immutable"),
+			substring:  "immutable",
+			wantFailed: true,
+		},
+		"errored": {
+			resp:       admission.Errored(http.StatusBadRequest, errors.New("placement type is immutable")),
+			substring:  "immutable",
+			wantFailed: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tb := &recordingTB{TB: t}
+			AssertDenied(tb, tc.resp, tc.substring)
+			if tb.failed != tc.wantFailed {
+				t.Errorf("AssertDenied() failed = %t, want %t", tb.failed, tc.wantFailed)
+			}
+		})
+	}
+}
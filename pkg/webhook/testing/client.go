@@ -0,0 +1,35 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
+)
+
+// NewFakeClientBuilder returns a fake client builder with Scheme and the field indexes which the webhooks register
+// with the manager, so that the client-backed checks can look objects up by index as they do against the cache.
+func NewFakeClientBuilder() *fake.ClientBuilder {
+	return fake.NewClientBuilder().WithScheme(Scheme).
+		WithIndex(&placementv1beta1.ClusterStagedUpdateRun{}, validator.UpdateRunPlacementNameIndexKey, validator.UpdateRunPlacementNameIndexer).
+		WithIndex(&placementv1beta1.StagedUpdateRun{}, validator.UpdateRunPlacementNameIndexKey, validator.UpdateRunPlacementNameIndexer).
+		WithIndex(&placementv1beta1.ClusterResourcePlacementEviction{}, validator.EvictionPlacementClusterIndexKey, validator.EvictionPlacementClusterIndexer).
+		WithIndex(&placementv1beta1.ClusterSchedulingPolicySnapshot{}, validator.PolicySnapshotPlacementIndexKey, validator.PolicySnapshotPlacementIndexer).
+		WithIndex(&placementv1beta1.ResourcePlacement{}, validator.PlacementNameIndexKey, validator.PlacementNameIndexer)
+}
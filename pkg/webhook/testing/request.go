@@ -0,0 +1,146 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package testing provides helpers for building admission requests and checking admission responses
+// in the unit tests of fleet webhook handlers.
+package testing
+
+import (
+	"encoding/json"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	clusterv1beta1 "github.com/kubefleet-dev/kubefleet/apis/cluster/v1beta1"
+	placementv1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1"
+	placementv1alpha1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1alpha1"
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+// Scheme is the scheme with the Kubernetes and fleet APIs registered, which the request builders use to
+// look up the kind of the objects. Pass it to admission.NewDecoder to decode the built requests.
+var Scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(Scheme))
+	utilruntime.Must(clusterv1beta1.AddToScheme(Scheme))
+	utilruntime.Must(placementv1alpha1.AddToScheme(Scheme))
+	utilruntime.Must(placementv1.AddToScheme(Scheme))
+	utilruntime.Must(placementv1beta1.AddToScheme(Scheme))
+}
+
+// RequestOption sets an optional field of the admission request being built.
+type RequestOption func(req *admissionv1.AdmissionRequest)
+
+// WithSubResource sets the subresource, e.g., "status", the request is made against.
+func WithSubResource(subResource string) RequestOption {
+	return func(req *admissionv1.AdmissionRequest) {
+		req.SubResource = subResource
+		req.RequestSubResource = subResource
+	}
+}
+
+// WithDryRun marks the request as a dry run.
+func WithDryRun() RequestOption {
+	return func(req *admissionv1.AdmissionRequest) {
+		req.DryRun = ptr.To(true)
+	}
+}
+
+// WithUserInfo sets the user making the request.
+func WithUserInfo(userInfo authenticationv1.UserInfo) RequestOption {
+	return func(req *admissionv1.AdmissionRequest) {
+		req.UserInfo = userInfo
+	}
+}
+
+// NewCreateRequest returns a create request for the object.
+// It panics if the object cannot be serialized with Scheme.
+func NewCreateRequest(obj client.Object, opts ...RequestOption) admission.Request {
+	req := newRequest(admissionv1.Create, obj, opts...)
+	req.Object = mustRawExtension(obj)
+	return req
+}
+
+// NewUpdateRequest returns an update request from the old object to the new object.
+// It panics if either object cannot be serialized with Scheme.
+func NewUpdateRequest(oldObj, newObj client.Object, opts ...RequestOption) admission.Request {
+	req := newRequest(admissionv1.Update, newObj, opts...)
+	req.Object = mustRawExtension(newObj)
+	req.OldObject = mustRawExtension(oldObj)
+	return req
+}
+
+// NewDeleteRequest returns a delete request for the existing object.
+// It panics if the object cannot be serialized with Scheme.
+func NewDeleteRequest(oldObj client.Object, opts ...RequestOption) admission.Request {
+	req := newRequest(admissionv1.Delete, oldObj, opts...)
+	req.OldObject = mustRawExtension(oldObj)
+	return req
+}
+
+// newRequest returns a request of the operation with the identifying fields set from the object.
+func newRequest(operation admissionv1.Operation, obj client.Object, opts ...RequestOption) admission.Request {
+	gvk := mustGVK(obj)
+	// The plural resource name is only informational in the request, so the guessed name is good enough.
+	gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+	kind := metav1.GroupVersionKind{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind}
+	resource := metav1.GroupVersionResource{Group: gvr.Group, Version: gvr.Version, Resource: gvr.Resource}
+	req := admissionv1.AdmissionRequest{
+		Name:            obj.GetName(),
+		Namespace:       obj.GetNamespace(),
+		Operation:       operation,
+		Kind:            kind,
+		RequestKind:     &kind,
+		Resource:        resource,
+		RequestResource: &resource,
+	}
+	for _, opt := range opts {
+		opt(&req)
+	}
+	return admission.Request{AdmissionRequest: req}
+}
+
+// mustGVK returns the kind of the object registered in Scheme.
+func mustGVK(obj runtime.Object) schema.GroupVersionKind {
+	gvk, err := apiutil.GVKForObject(obj, Scheme)
+	if err != nil {
+		panic(fmt.Sprintf("failed to look up the kind of %T: %v", obj, err))
+	}
+	return gvk
+}
+
+// mustRawExtension serializes the object, with its apiVersion and kind set, into a raw extension.
+func mustRawExtension(obj client.Object) runtime.RawExtension {
+	typed := obj.DeepCopyObject()
+	typed.GetObjectKind().SetGroupVersionKind(mustGVK(obj))
+	raw, err := json.Marshal(typed)
+	if err != nil {
+		panic(fmt.Sprintf("failed to serialize %T %s: %v", obj, obj.GetName(), err))
+	}
+	return runtime.RawExtension{Raw: raw, Object: obj}
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	clusterv1beta1 "github.com/kubefleet-dev/kubefleet/apis/cluster/v1beta1"
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+var (
+	testCRP = &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-crp", Generation: 2},
+		Spec: placementv1beta1.PlacementSpec{
+			Policy: &placementv1beta1.PlacementPolicy{PlacementType: placementv1beta1.PickAllPlacementType},
+		},
+	}
+	testRP = &placementv1beta1.ResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-rp", Namespace: "test-ns"},
+		Spec: placementv1beta1.PlacementSpec{
+			ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{{Group: "apps", Version: "v1", Kind: "Deployment", Name: "test"}},
+		},
+	}
+	testMemberCluster = &clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-mc"},
+	}
+	testPod = &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "test-ns"},
+	}
+	testUser = authenticationv1.UserInfo{
+		Username: "test-user",
+		Groups:   []string{"system:masters"},
+	}
+)
+
+// decode decodes the raw object of the request into a new object of the same type as the argued one.
+func decode(t *testing.T, raw []byte, like client.Object) client.Object {
+	t.Helper()
+	got := like.DeepCopyObject().(client.Object)
+	if err := admission.NewDecoder(Scheme).DecodeRaw(runtime.RawExtension{Raw: raw}, got); err != nil {
+		t.Fatalf("DecodeRaw() = %v, want no error", err)
+	}
+	// Clear the type meta set from the serialized object to compare with the argued object.
+	got.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{})
+	return got
+}
+
+func TestNewCreateRequest(t *testing.T) {
+	testCases := map[string]struct {
+		obj        client.Object
+		wantKind   metav1.GroupVersionKind
+		wantPlural string
+		wantNS     string
+	}{
+		"cluster-scoped fleet object": {
+			obj:        testCRP,
+			wantKind:   metav1.GroupVersionKind{Group: placementv1beta1.GroupVersion.Group, Version: placementv1beta1.GroupVersion.Version, Kind: placementv1beta1.ClusterResourcePlacementKind},
+			wantPlural: placementv1beta1.ClusterResourcePlacementResource,
+		},
+		"namespaced fleet object": {
+			obj:        testRP,
+			wantKind:   metav1.GroupVersionKind{Group: placementv1beta1.GroupVersion.Group, Version: placementv1beta1.GroupVersion.Version, Kind: placementv1beta1.ResourcePlacementKind},
+			wantPlural: "resourceplacements",
+			wantNS:     "test-ns",
+		},
+		"cluster object": {
+			obj:        testMemberCluster,
+			wantKind:   metav1.GroupVersionKind{Group: clusterv1beta1.GroupVersion.Group, Version: clusterv1beta1.GroupVersion.Version, Kind: "MemberCluster"},
+			wantPlural: "memberclusters",
+		},
+		"core object": {
+			obj:        testPod,
+			wantKind:   metav1.GroupVersionKind{Version: "v1", Kind: "Pod"},
+			wantPlural: "pods",
+			wantNS:     "test-ns",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := NewCreateRequest(tc.obj)
+			if req.Operation != admissionv1.Create {
+				t.Errorf("NewCreateRequest() operation = %s, want %s", req.Operation, admissionv1.Create)
+			}
+			if req.Name != tc.obj.GetName() || req.Namespace != tc.wantNS {
+				t.Errorf("NewCreateRequest() name = %s/%s, want %s/%s", req.Namespace, req.Name, tc.wantNS, tc.obj.GetName())
+			}
+			if diff := cmp.Diff(tc.wantKind, req.Kind); diff != "" {
+				t.Errorf("NewCreateRequest() kind mismatch (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(&tc.wantKind, req.RequestKind); diff != "" {
+				t.Errorf("NewCreateRequest() request kind mismatch (-want, +got):\n%s", diff)
+			}
+			if req.Resource.Resource != tc.wantPlural {
+				t.Errorf("NewCreateRequest() resource = %s, want %s", req.Resource.Resource, tc.wantPlural)
+			}
+			if req.OldObject.Raw != nil {
+				t.Errorf("NewCreateRequest() old object = %s, want none", req.OldObject.Raw)
+			}
+			var typeMeta metav1.TypeMeta
+			if err := json.Unmarshal(req.Object.Raw, &typeMeta); err != nil {
+				t.Fatalf("json.Unmarshal() = %v, want no error", err)
+			}
+			wantTypeMeta := metav1.TypeMeta{APIVersion: schema.GroupVersion{Group: tc.wantKind.Group, Version: tc.wantKind.Version}.String(), Kind: tc.wantKind.Kind}
+			if diff := cmp.Diff(wantTypeMeta, typeMeta); diff != "" {
+				t.Errorf("NewCreateRequest() serialized type meta mismatch (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.obj, decode(t, req.Object.Raw, tc.obj)); diff != "" {
+				t.Errorf("NewCreateRequest() object round trip mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewUpdateRequest(t *testing.T) {
+	newCRP := testCRP.DeepCopy()
+	newCRP.Generation = 3
+	newCRP.Spec.Policy.Tolerations = []placementv1beta1.Toleration{{Key: "key1", Value: "value1"}}
+
+	req := NewUpdateRequest(testCRP, newCRP, WithUserInfo(testUser))
+	if req.Operation != admissionv1.Update {
+		t.Errorf("NewUpdateRequest() operation = %s, want %s", req.Operation, admissionv1.Update)
+	}
+	if diff := cmp.Diff(newCRP, decode(t, req.Object.Raw, newCRP)); diff != "" {
+		t.Errorf("NewUpdateRequest() object round trip mismatch (-want, +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(testCRP, decode(t, req.OldObject.Raw, testCRP)); diff != "" {
+		t.Errorf("NewUpdateRequest() old object round trip mismatch (-want, +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(testUser, req.UserInfo); diff != "" {
+		t.Errorf("NewUpdateRequest() user info mismatch (-want, +got):\n%s", diff)
+	}
+	// The builders must not set the type meta on the argued objects.
+	if kind := newCRP.GetObjectKind().GroupVersionKind().Kind; kind != "" {
+		t.Errorf("NewUpdateRequest() set the kind of the argued object to %s, want it unchanged", kind)
+	}
+}
+
+func TestNewDeleteRequest(t *testing.T) {
+	req := NewDeleteRequest(testMemberCluster, WithDryRun())
+	if req.Operation != admissionv1.Delete {
+		t.Errorf("NewDeleteRequest() operation = %s, want %s", req.Operation, admissionv1.Delete)
+	}
+	if req.Object.Raw != nil {
+		t.Errorf("NewDeleteRequest() object = %s, want none", req.Object.Raw)
+	}
+	if diff := cmp.Diff(testMemberCluster, decode(t, req.OldObject.Raw, testMemberCluster)); diff != "" {
+		t.Errorf("NewDeleteRequest() old object round trip mismatch (-want, +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(ptr.To(true), req.DryRun); diff != "" {
+		t.Errorf("NewDeleteRequest() dry run mismatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestRequestOptions(t *testing.T) {
+	testCases := map[string]struct {
+		opts  []RequestOption
+		check func(t *testing.T, req admission.Request)
+	}{
+		"no option": {
+			check: func(t *testing.T, req admission.Request) {
+				if req.SubResource != "" || req.DryRun != nil || req.UserInfo.Username != "" {
+					t.Errorf("request = %+v, want no subresource, dry run or user info", req.AdmissionRequest)
+				}
+			},
+		},
+		"subresource": {
+			opts: []RequestOption{WithSubResource("status")},
+			check: func(t *testing.T, req admission.Request) {
+				if req.SubResource != "status" || req.RequestSubResource != "status" {
+					t.Errorf("request subresource = %q/%q, want status", req.SubResource, req.RequestSubResource)
+				}
+			},
+		},
+		"all options": {
+			opts: []RequestOption{WithSubResource("status"), WithDryRun(), WithUserInfo(testUser)},
+			check: func(t *testing.T, req admission.Request) {
+				got := fmt.Sprintf("%s/%t/%s", req.SubResource, ptr.Deref(req.DryRun, false), req.UserInfo.Username)
+				if want := "status/true/test-user"; got != want {
+					t.Errorf("request subresource/dryRun/user = %s, want %s", got, want)
+				}
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tc.check(t, NewCreateRequest(testRP, tc.opts...))
+			tc.check(t, NewUpdateRequest(testRP, testRP, tc.opts...))
+			tc.check(t, NewDeleteRequest(testRP, tc.opts...))
+		})
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var (
+	// newDecoder builds the admission decoder of a scheme, it is replaced in the tests to count the builds.
+	newDecoder = admission.NewDecoder
+	// sharedDecoders holds the admission decoder of each scheme, which is shared by all the webhook handlers.
+	sharedDecoders   = map[*runtime.Scheme]webhook.AdmissionDecoder{}
+	sharedDecodersMu sync.Mutex
+)
+
+// SharedDecoder returns the admission decoder shared by the webhook handlers served with the scheme. It also
+// decodes a synthetic object of each of the handled types so that the serializers and the caches used to decode
+// them are built when the handlers are added on startup, instead of by the first admission request, which could
+// otherwise exceed the webhook timeout.
+func SharedDecoder(scheme *runtime.Scheme, handledTypes ...runtime.Object) (webhook.AdmissionDecoder, error) {
+	sharedDecodersMu.Lock()
+	decoder, ok := sharedDecoders[scheme]
+	if !ok {
+		decoder = newDecoder(scheme)
+		sharedDecoders[scheme] = decoder
+	}
+	sharedDecodersMu.Unlock()
+	for _, obj := range handledTypes {
+		if err := warmUpDecoder(scheme, decoder, obj); err != nil {
+			return nil, err
+		}
+	}
+	return decoder, nil
+}
+
+// warmUpDecoder decodes a synthetic object of the given type with the decoder.
+func warmUpDecoder(scheme *runtime.Scheme, decoder webhook.AdmissionDecoder, obj runtime.Object) error {
+	gvks, _, err := scheme.ObjectKinds(obj)
+	if err != nil {
+		return fmt.Errorf("failed to look up the kind of %T: %w", obj, err)
+	}
+	synthetic := obj.DeepCopyObject()
+	synthetic.GetObjectKind().SetGroupVersionKind(gvks[0])
+	raw, err := json.Marshal(synthetic)
+	if err != nil {
+		return fmt.Errorf("failed to encode the synthetic %s: %w", gvks[0].Kind, err)
+	}
+	into := obj.DeepCopyObject()
+	if err := decoder.DecodeRaw(runtime.RawExtension{Raw: raw}, into); err != nil {
+		return fmt.Errorf("failed to decode the synthetic %s: %w", gvks[0].Kind, err)
+	}
+	return nil
+}
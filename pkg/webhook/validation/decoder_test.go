@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	clusterv1beta1 "github.com/kubefleet-dev/kubefleet/apis/cluster/v1beta1"
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	webhooktesting "github.com/kubefleet-dev/kubefleet/pkg/webhook/testing"
+)
+
+func TestSharedDecoder(t *testing.T) {
+	builds := 0
+	newDecoder = func(scheme *runtime.Scheme) admission.Decoder {
+		builds++
+		return admission.NewDecoder(scheme)
+	}
+	t.Cleanup(func() { newDecoder = admission.NewDecoder })
+	scheme := runtime.NewScheme()
+	utilruntime.Must(placementv1beta1.AddToScheme(scheme))
+	knownTypes := len(scheme.AllKnownTypes())
+
+	// Each webhook handler gets the decoder when it is added on startup.
+	validatingDecoder, err := SharedDecoder(scheme, &placementv1beta1.ClusterResourcePlacement{})
+	if err != nil {
+		t.Fatalf("SharedDecoder() = %v, want no error", err)
+	}
+	mutatingDecoder, err := SharedDecoder(scheme, &placementv1beta1.ClusterResourcePlacement{}, &placementv1beta1.ResourcePlacement{})
+	if err != nil {
+		t.Fatalf("SharedDecoder() = %v, want no error", err)
+	}
+	if validatingDecoder != mutatingDecoder {
+		t.Errorf("SharedDecoder() returned different decoders for the same scheme, want a shared one")
+	}
+	if builds != 1 {
+		t.Errorf("SharedDecoder() built %d decoders, want 1", builds)
+	}
+
+	// The first admission request after startup must not initialize anything lazily.
+	crp := &placementv1beta1.ClusterResourcePlacement{ObjectMeta: metav1.ObjectMeta{Name: "test-crp"}}
+	var got placementv1beta1.ClusterResourcePlacement
+	if err := validatingDecoder.Decode(webhooktesting.NewCreateRequest(crp), &got); err != nil {
+		t.Fatalf("Decode() = %v, want no error", err)
+	}
+	if got.Name != crp.Name {
+		t.Errorf("Decode() name = %s, want %s", got.Name, crp.Name)
+	}
+	if builds != 1 {
+		t.Errorf("Decode() built %d decoders after startup, want none", builds-1)
+	}
+	if gotTypes := len(scheme.AllKnownTypes()); gotTypes != knownTypes {
+		t.Errorf("Decode() registered %d types to the scheme after startup, want none", gotTypes-knownTypes)
+	}
+}
+
+func TestSharedDecoderUnregisteredType(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(placementv1beta1.AddToScheme(scheme))
+	_, err := SharedDecoder(scheme, &clusterv1beta1.MemberCluster{})
+	if err == nil || !strings.Contains(err.Error(), "failed to look up the kind of *v1beta1.MemberCluster") {
+		t.Errorf("SharedDecoder() = %v, want a kind lookup error", err)
+	}
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// DecodeObjectMetadata decodes only the type and object metadata (e.g., the name, namespace, labels and owner
+// references) of the object in the admission request. The rest of the object, such as a pod template with many
+// containers and env vars, is skipped without being allocated, so the handlers which only inspect the metadata of
+// potentially large objects should use it instead of a typed decode; the typed decode is only needed to mutate the
+// object.
+func DecodeObjectMetadata(req admission.Request) (*metav1.PartialObjectMetadata, error) {
+	if len(req.Object.Raw) == 0 {
+		return nil, errors.New("there is no content to decode")
+	}
+	obj := &metav1.PartialObjectMetadata{}
+	if err := json.Unmarshal(req.Object.Raw, obj); err != nil {
+		return nil, fmt.Errorf("failed to decode the object metadata: %w", err)
+	}
+	return obj, nil
+}
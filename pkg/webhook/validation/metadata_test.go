@@ -0,0 +1,141 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	webhooktesting "github.com/kubefleet-dev/kubefleet/pkg/webhook/testing"
+)
+
+// largeObjectMeta is the metadata of the large test objects.
+var largeObjectMeta = metav1.ObjectMeta{
+	Name:      "test-deployment",
+	Namespace: "test-ns",
+	Labels:    map[string]string{"app": "test", "kubernetes-fleet.io/parent-CRP": "test-crp"},
+	OwnerReferences: []metav1.OwnerReference{{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Name:       "test-owner",
+		UID:        "test-uid",
+		Controller: ptr.To(true),
+	}},
+}
+
+// newLargeDeployment returns a deployment whose JSON encoding is at least size bytes, with a sidecar-injected pod
+// template carrying many env vars.
+func newLargeDeployment(size int) *appsv1.Deployment {
+	deploy := &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: *largeObjectMeta.DeepCopy(),
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "app", Image: "app:latest"}, {Name: "sidecar", Image: "sidecar:latest"}},
+				},
+			},
+		},
+	}
+	// Each env var adds about 100 bytes to the encoding.
+	for i := 0; i < size/100+1; i++ {
+		container := &deploy.Spec.Template.Spec.Containers[i%2]
+		container.Env = append(container.Env, corev1.EnvVar{Name: fmt.Sprintf("ENV_VAR_%08d", i), Value: fmt.Sprintf("value-of-the-environment-variable-%08d-used-by-the-injected-sidecar", i)})
+	}
+	return deploy
+}
+
+func TestDecodeObjectMetadata(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "kube-system", GenerateName: "test-"}}
+	rs := &appsv1.ReplicaSet{ObjectMeta: *largeObjectMeta.DeepCopy()}
+	testCases := map[string]struct {
+		obj  client.Object
+		into client.Object
+	}{
+		"pod":              {obj: pod, into: &corev1.Pod{}},
+		"replica set":      {obj: rs, into: &appsv1.ReplicaSet{}},
+		"large deployment": {obj: newLargeDeployment(1 << 20), into: &appsv1.Deployment{}},
+	}
+	decoder := admission.NewDecoder(webhooktesting.Scheme)
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := webhooktesting.NewCreateRequest(tc.obj)
+			got, err := DecodeObjectMetadata(req)
+			if err != nil {
+				t.Fatalf("DecodeObjectMetadata() = %v, want no error", err)
+			}
+			if err := decoder.Decode(req, tc.into); err != nil {
+				t.Fatalf("Decode() = %v, want no error", err)
+			}
+			want := tc.into.(metav1.ObjectMetaAccessor).GetObjectMeta().(*metav1.ObjectMeta)
+			if diff := cmp.Diff(*want, got.ObjectMeta); diff != "" {
+				t.Errorf("DecodeObjectMetadata() metadata mismatch with the typed decode (-want, +got):\n%s", diff)
+			}
+			if got.GroupVersionKind() != tc.into.GetObjectKind().GroupVersionKind() {
+				t.Errorf("DecodeObjectMetadata() kind = %v, want %v", got.GroupVersionKind(), tc.into.GetObjectKind().GroupVersionKind())
+			}
+		})
+	}
+
+	t.Run("no content", func(t *testing.T) {
+		if _, err := DecodeObjectMetadata(admission.Request{}); err == nil {
+			t.Errorf("DecodeObjectMetadata() = nil, want error")
+		}
+	})
+	t.Run("invalid content", func(t *testing.T) {
+		req := webhooktesting.NewCreateRequest(pod)
+		req.Object = runtime.RawExtension{Raw: []byte(`{"metadata": [`)}
+		if _, err := DecodeObjectMetadata(req); err == nil {
+			t.Errorf("DecodeObjectMetadata() = nil, want error")
+		}
+	})
+}
+
+// BenchmarkDecodeLargeDeployment compares the allocations of the typed decode and the metadata decode of an
+// admission request for a 1MB deployment.
+func BenchmarkDecodeLargeDeployment(b *testing.B) {
+	req := webhooktesting.NewCreateRequest(newLargeDeployment(1 << 20))
+	if size := len(req.Object.Raw); size < 1<<20 {
+		b.Fatalf("deployment is %d bytes, want at least 1MB", size)
+	}
+	b.Run("typed", func(b *testing.B) {
+		decoder := admission.NewDecoder(webhooktesting.Scheme)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := decoder.Decode(req, &appsv1.Deployment{}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("metadata", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := DecodeObjectMetadata(req); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -5,9 +5,11 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"path"
 	"reflect"
 	"strings"
 
+	admissionv1 "k8s.io/api/admission/v1"
 	authenticationv1 "k8s.io/api/authentication/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -20,6 +22,7 @@ import (
 	clusterv1beta1 "github.com/kubefleet-dev/kubefleet/apis/cluster/v1beta1"
 	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
 	"github.com/kubefleet-dev/kubefleet/pkg/utils"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
 )
 
 const (
@@ -32,10 +35,17 @@ const (
 	aksSupportUser            = "aks-support"
 	serviceAccountFmt         = "system:serviceaccount:fleet-system:%s"
 
+	kubeSystemServiceAccountPrefix = "system:serviceaccount:kube-system:"
+
 	allowedModifyResource           = "user in groups is allowed to modify resource"
 	deniedModifyResource            = "user in groups is not allowed to modify resource"
 	deniedAddFleetAnnotation        = "no user is allowed to add a fleet pre-fixed annotation to an upstream member cluster"
 	deniedRemoveFleetAnnotation     = "no user is allowed to remove all fleet pre-fixed annotations from a fleet member cluster"
+	deniedModifyFleetRBAC           = "user in groups is not allowed to modify fleet-managed RBAC resource"
+	deniedModifyFleetSnapshot       = "user in groups is not allowed to modify controller-owned snapshot"
+	deniedRemoveFleetFinalizer      = "user in groups is not allowed to remove fleet finalizers"
+	deniedResourcePlacementReach    = "user in groups is not allowed to place resources outside of the request namespace"
+	deniedModifyWebhookCertSecret   = "user in groups is not allowed to modify the webhook cert secret"
 	DeniedModifyMemberClusterLabels = "users are not allowed to modify labels through hub cluster directly"
 
 	ResourceAllowedFormat      = "user: '%s' in '%s' is allowed to %s resource %+v/%s: %+v"
@@ -48,19 +58,19 @@ var (
 )
 
 // ValidateUserForFleetCRD checks to see if user is not allowed to modify fleet CRDs.
-func ValidateUserForFleetCRD(req admission.Request, whiteListedUsers []string, group string) admission.Response {
+func ValidateUserForFleetCRD(config validator.Config, req admission.Request, whiteListedUsers []string, group string) admission.Response {
 	namespacedName := types.NamespacedName{Name: req.Name, Namespace: req.Namespace}
 	userInfo := req.UserInfo
 	if checkCRDGroup(group) && !isAdminGroupUserOrWhiteListedUser(whiteListedUsers, userInfo) {
 		klog.V(2).InfoS(deniedModifyResource, "user", userInfo.Username, "groups", userInfo.Groups, "operation", req.Operation, "GVK", req.RequestKind, "subResource", req.SubResource, "namespacedName", namespacedName)
-		return admission.Denied(fmt.Sprintf(ResourceDeniedFormat, userInfo.Username, utils.GenerateGroupString(userInfo.Groups), req.Operation, req.RequestKind, req.SubResource, namespacedName))
+		return admission.Denied(resourceDeniedMessage(config, userInfo, req, namespacedName))
 	}
 	klog.V(3).InfoS(allowedModifyResource, "user", userInfo.Username, "groups", userInfo.Groups, "operation", req.Operation, "GVK", req.RequestKind, "subResource", req.SubResource, "namespacedName", namespacedName)
 	return admission.Allowed(fmt.Sprintf(ResourceAllowedFormat, userInfo.Username, utils.GenerateGroupString(userInfo.Groups), req.Operation, req.RequestKind, req.SubResource, namespacedName))
 }
 
 // ValidateUserForResource checks to see if user is allowed to modify argued resource modified by request.
-func ValidateUserForResource(req admission.Request, whiteListedUsers []string) admission.Response {
+func ValidateUserForResource(config validator.Config, req admission.Request, whiteListedUsers []string) admission.Response {
 	namespacedName := types.NamespacedName{Name: req.Name, Namespace: req.Namespace}
 	userInfo := req.UserInfo
 	if isAdminGroupUserOrWhiteListedUser(whiteListedUsers, userInfo) || isUserAuthenticatedServiceAccount(userInfo) || isUserKubeScheduler(userInfo) || isUserKubeControllerManager(userInfo) || isUserInGroup(userInfo, nodeGroup) || isAKSSupportUser(userInfo) {
@@ -68,16 +78,111 @@ func ValidateUserForResource(req admission.Request, whiteListedUsers []string) a
 		return admission.Allowed(fmt.Sprintf(ResourceAllowedFormat, userInfo.Username, utils.GenerateGroupString(userInfo.Groups), req.Operation, req.RequestKind, req.SubResource, namespacedName))
 	}
 	klog.V(2).InfoS(deniedModifyResource, "user", userInfo.Username, "groups", userInfo.Groups, "operation", req.Operation, "GVK", req.RequestKind, "subResource", req.SubResource, "namespacedName", namespacedName)
-	return admission.Denied(fmt.Sprintf(ResourceDeniedFormat, userInfo.Username, utils.GenerateGroupString(userInfo.Groups), req.Operation, req.RequestKind, req.SubResource, namespacedName))
+	return admission.Denied(resourceDeniedMessage(config, userInfo, req, namespacedName))
+}
+
+// ValidateUserForFleetRBAC checks to see if user is allowed to modify the fleet-managed Role/RoleBinding modified by request.
+// Unlike ValidateUserForResource, only the admin group users, white listed users and the users matching any of the
+// writer patterns (e.g. the hub agent and member agent service accounts) are allowed, besides the kube-system controllers
+// which clean up the RBAC objects of deleted fleet member namespaces.
+func ValidateUserForFleetRBAC(config validator.Config, req admission.Request, whiteListedUsers, writerPatterns []string) admission.Response {
+	namespacedName := types.NamespacedName{Name: req.Name, Namespace: req.Namespace}
+	userInfo := req.UserInfo
+	if isAdminGroupUserOrWhiteListedUser(whiteListedUsers, userInfo) || isUserMatchingAnyPattern(userInfo, writerPatterns) ||
+		(req.Operation == admissionv1.Delete && (isUserKubeControllerManager(userInfo) || strings.HasPrefix(userInfo.Username, kubeSystemServiceAccountPrefix))) {
+		klog.V(3).InfoS(allowedModifyResource, "user", userInfo.Username, "groups", userInfo.Groups, "operation", req.Operation, "GVK", req.RequestKind, "subResource", req.SubResource, "namespacedName", namespacedName)
+		return admission.Allowed(fmt.Sprintf(ResourceAllowedFormat, userInfo.Username, utils.GenerateGroupString(userInfo.Groups), req.Operation, req.RequestKind, req.SubResource, namespacedName))
+	}
+	klog.V(2).InfoS(deniedModifyFleetRBAC, "user", userInfo.Username, "groups", userInfo.Groups, "operation", req.Operation, "GVK", req.RequestKind, "subResource", req.SubResource, "namespacedName", namespacedName)
+	return admission.Denied(resourceDeniedMessage(config, userInfo, req, namespacedName))
+}
+
+// ValidateUserForFleetSnapshot checks to see if user is allowed to modify the controller-owned snapshots, e.g.,
+// ClusterResourceSnapshots, which record the rollout history of the placements. Only the white listed users and the
+// users matching any of the writerPatterns, i.e., the hub controllers, are allowed; unlike for other fleet resources,
+// being a cluster admin is not enough. The snapshots can still be deleted by the garbage collector.
+func ValidateUserForFleetSnapshot(config validator.Config, req admission.Request, whiteListedUsers, writerPatterns []string) admission.Response {
+	namespacedName := types.NamespacedName{Name: req.Name, Namespace: req.Namespace}
+	userInfo := req.UserInfo
+	if slices.Contains(whiteListedUsers, userInfo.Username) || isUserMatchingAnyPattern(userInfo, writerPatterns) ||
+		(req.Operation == admissionv1.Delete && (isUserKubeControllerManager(userInfo) || strings.HasPrefix(userInfo.Username, kubeSystemServiceAccountPrefix))) {
+		klog.V(3).InfoS(allowedModifyResource, "user", userInfo.Username, "groups", userInfo.Groups, "operation", req.Operation, "GVK", req.RequestKind, "subResource", req.SubResource, "namespacedName", namespacedName)
+		return admission.Allowed(fmt.Sprintf(ResourceAllowedFormat, userInfo.Username, utils.GenerateGroupString(userInfo.Groups), req.Operation, req.RequestKind, req.SubResource, namespacedName))
+	}
+	klog.V(2).InfoS(deniedModifyFleetSnapshot, "user", userInfo.Username, "groups", userInfo.Groups, "operation", req.Operation, "GVK", req.RequestKind, "subResource", req.SubResource, "namespacedName", namespacedName)
+	return admission.Denied(resourceDeniedMessage(config, userInfo, req, namespacedName))
+}
+
+// ValidateUserForWebhookCertSecret checks to see if user is allowed to update or delete the Secret holding the serving
+// certificate of the fleet webhooks. Only the white listed users and the users matching any of the writerPatterns,
+// i.e., the hub controllers, are allowed; like for the snapshots, being a cluster admin or a service account is not
+// enough as a corrupted certificate takes down the admission of the whole fleet.
+func ValidateUserForWebhookCertSecret(config validator.Config, req admission.Request, whiteListedUsers, writerPatterns []string) admission.Response {
+	namespacedName := types.NamespacedName{Name: req.Name, Namespace: req.Namespace}
+	userInfo := req.UserInfo
+	if slices.Contains(whiteListedUsers, userInfo.Username) || isUserMatchingAnyPattern(userInfo, writerPatterns) {
+		klog.V(3).InfoS(allowedModifyResource, "user", userInfo.Username, "groups", userInfo.Groups, "operation", req.Operation, "GVK", req.RequestKind, "subResource", req.SubResource, "namespacedName", namespacedName)
+		return admission.Allowed(fmt.Sprintf(ResourceAllowedFormat, userInfo.Username, utils.GenerateGroupString(userInfo.Groups), req.Operation, req.RequestKind, req.SubResource, namespacedName))
+	}
+	klog.V(2).InfoS(deniedModifyWebhookCertSecret, "user", userInfo.Username, "groups", userInfo.Groups, "operation", req.Operation, "GVK", req.RequestKind, "subResource", req.SubResource, "namespacedName", namespacedName)
+	return admission.Denied(resourceDeniedMessage(config, userInfo, req, namespacedName))
+}
+
+// ValidateFleetFinalizerRemoval checks to see if user is allowed to remove the fleet finalizers, i.e., the
+// kubernetes-fleet.io/ prefixed ones, of the ClusterResourcePlacement updated by request. The fleet controllers rely on
+// the finalizers to clean up the placed resources, hence only the white listed users and the users matching any of the
+// writerPatterns, i.e., the hub controllers, are allowed to remove them. Adding finalizers is always allowed.
+func ValidateFleetFinalizerRemoval(config validator.Config, req admission.Request, currentFinalizers, oldFinalizers, whiteListedUsers, writerPatterns []string) admission.Response {
+	namespacedName := types.NamespacedName{Name: req.Name, Namespace: req.Namespace}
+	userInfo := req.UserInfo
+	var removed []string
+	for _, finalizer := range oldFinalizers {
+		if strings.HasPrefix(finalizer, placementv1beta1.FleetPrefix) && !slices.Contains(currentFinalizers, finalizer) {
+			removed = append(removed, finalizer)
+		}
+	}
+	if len(removed) == 0 || slices.Contains(whiteListedUsers, userInfo.Username) || isUserMatchingAnyPattern(userInfo, writerPatterns) {
+		klog.V(3).InfoS(allowedModifyResource, "user", userInfo.Username, "groups", userInfo.Groups, "operation", req.Operation, "GVK", req.RequestKind, "subResource", req.SubResource, "namespacedName", namespacedName, "removedFinalizers", removed)
+		return admission.Allowed(fmt.Sprintf(ResourceAllowedFormat, userInfo.Username, utils.GenerateGroupString(userInfo.Groups), req.Operation, req.RequestKind, req.SubResource, namespacedName))
+	}
+	klog.V(2).InfoS(deniedRemoveFleetFinalizer, "user", userInfo.Username, "groups", userInfo.Groups, "operation", req.Operation, "GVK", req.RequestKind, "subResource", req.SubResource, "namespacedName", namespacedName, "removedFinalizers", removed)
+	return admission.Denied(config.DenialMessage(validator.RemoveFleetFinalizerMessageID, map[string]any{
+		"user":       userInfo.Username,
+		"groups":     utils.GenerateGroupString(userInfo.Groups),
+		"finalizers": strings.Join(removed, ", "),
+		"name":       req.Name,
+	}))
+}
+
+// ValidateResourcePlacementReach checks to see if user is allowed to create or update the ResourcePlacement of request
+// whose selectors reach beyond the request namespace, i.e., the cluster-scoped resources or the resources of the other
+// namespaces, described by reaches. The namespace admins are allowed to place the resources of their own namespace,
+// only the fleet administrators, i.e., the admin group users and the white listed users, are allowed to reach further.
+// It is the second line of defense behind the spec validation of the ResourcePlacement.
+func ValidateResourcePlacementReach(config validator.Config, req admission.Request, reaches, whiteListedUsers []string) admission.Response {
+	namespacedName := types.NamespacedName{Name: req.Name, Namespace: req.Namespace}
+	userInfo := req.UserInfo
+	if len(reaches) == 0 || isAdminGroupUserOrWhiteListedUser(whiteListedUsers, userInfo) {
+		klog.V(3).InfoS(allowedModifyResource, "user", userInfo.Username, "groups", userInfo.Groups, "operation", req.Operation, "GVK", req.RequestKind, "subResource", req.SubResource, "namespacedName", namespacedName, "reaches", reaches)
+		return admission.Allowed(fmt.Sprintf(ResourceAllowedFormat, userInfo.Username, utils.GenerateGroupString(userInfo.Groups), req.Operation, req.RequestKind, req.SubResource, namespacedName))
+	}
+	klog.V(2).InfoS(deniedResourcePlacementReach, "user", userInfo.Username, "groups", userInfo.Groups, "operation", req.Operation, "GVK", req.RequestKind, "subResource", req.SubResource, "namespacedName", namespacedName, "reaches", reaches)
+	return admission.Denied(config.DenialMessage(validator.ResourcePlacementReachMessageID, map[string]any{
+		"user":      userInfo.Username,
+		"groups":    utils.GenerateGroupString(userInfo.Groups),
+		"namespace": req.Namespace,
+		"name":      req.Name,
+		"reaches":   strings.Join(reaches, ", "),
+	}))
 }
 
 // ValidateFleetMemberClusterUpdate checks to see if user had updated the fleet member cluster resource and allows/denies the request.
-func ValidateFleetMemberClusterUpdate(currentMC, oldMC clusterv1beta1.MemberCluster, req admission.Request, whiteListedUsers []string, denyModifyMemberClusterLabels bool) admission.Response {
+func ValidateFleetMemberClusterUpdate(config validator.Config, currentMC, oldMC clusterv1beta1.MemberCluster, req admission.Request, whiteListedUsers []string, denyModifyMemberClusterLabels bool) admission.Response {
 	namespacedName := types.NamespacedName{Name: currentMC.GetName()}
 	userInfo := req.UserInfo
 	if areAllFleetAnnotationsRemoved(currentMC.Annotations, oldMC.Annotations) {
 		klog.V(2).InfoS(deniedRemoveFleetAnnotation, "user", userInfo.Username, "groups", userInfo.Groups, "operation", req.Operation, "GVK", req.RequestKind, "subResource", req.SubResource, "namespacedName", namespacedName)
-		return admission.Denied(deniedRemoveFleetAnnotation)
+		return admission.Denied(config.DenialMessage(validator.RemoveFleetAnnotationMessageID, nil))
 	}
 	// set taints field to nil.
 	currentMC.Spec.Taints = nil
@@ -91,12 +196,12 @@ func ValidateFleetMemberClusterUpdate(currentMC, oldMC clusterv1beta1.MemberClus
 	if isLabelUpdated && !isUserInGroup(userInfo, mastersGroup) && shouldDenyLabelModification(currentMC.GetLabels(), oldMC.GetLabels(), denyModifyMemberClusterLabels) {
 		// allow any user to modify kubernetes-fleet.io/* labels, but restricts other label modifications given denyModifyMemberClusterLabels is true.
 		klog.V(2).InfoS(DeniedModifyMemberClusterLabels, "user", userInfo.Username, "groups", userInfo.Groups, "operation", req.Operation, "GVK", req.RequestKind, "subResource", req.SubResource, "namespacedName", namespacedName)
-		return admission.Denied(DeniedModifyMemberClusterLabels)
+		return admission.Denied(config.DenialMessage(validator.ModifyMemberClusterLabelsMessageID, nil))
 	}
 
 	isAnnotationUpdated := isFleetAnnotationUpdated(currentMC.Annotations, oldMC.Annotations)
 	if isObjUpdated || isAnnotationUpdated {
-		return ValidateUserForResource(req, whiteListedUsers)
+		return ValidateUserForResource(config, req, whiteListedUsers)
 	}
 	// any user is allowed to modify labels, annotations, taints on fleet MC except fleet pre-fixed annotations.
 	klog.V(3).InfoS(allowedModifyResource, "user", userInfo.Username, "groups", userInfo.Groups, "operation", req.Operation, "GVK", req.RequestKind, "subResource", req.SubResource, "namespacedName", namespacedName)
@@ -104,16 +209,16 @@ func ValidateFleetMemberClusterUpdate(currentMC, oldMC clusterv1beta1.MemberClus
 }
 
 // ValidatedUpstreamMemberClusterUpdate checks to see if user had updated the upstream member cluster resource and allows/denies the request.
-func ValidatedUpstreamMemberClusterUpdate(currentMC, oldMC clusterv1beta1.MemberCluster, req admission.Request, whiteListedUsers []string) admission.Response {
+func ValidatedUpstreamMemberClusterUpdate(config validator.Config, currentMC, oldMC clusterv1beta1.MemberCluster, req admission.Request, whiteListedUsers []string) admission.Response {
 	namespacedName := types.NamespacedName{Name: currentMC.GetName()}
 	userInfo := req.UserInfo
 	if isFleetAnnotationAdded(currentMC.Annotations, oldMC.Annotations) {
 		klog.V(2).InfoS(deniedAddFleetAnnotation, "user", userInfo.Username, "groups", userInfo.Groups, "operation", req.Operation, "GVK", req.RequestKind, "subResource", req.SubResource, "namespacedName", namespacedName)
-		return admission.Denied(deniedAddFleetAnnotation)
+		return admission.Denied(config.DenialMessage(validator.AddFleetAnnotationMessageID, nil))
 	}
 	// any user is allowed to modify MC spec for upstream MC.
 	if !equality.Semantic.DeepEqual(currentMC.Status, oldMC.Status) {
-		return ValidateUserForResource(req, whiteListedUsers)
+		return ValidateUserForResource(config, req, whiteListedUsers)
 	}
 	klog.V(3).InfoS(allowedModifyResource, "user", userInfo.Username, "groups", userInfo.Groups, "operation", req.Operation, "GVK", req.RequestKind, "subResource", req.SubResource, "namespacedName", namespacedName)
 	return admission.Allowed(fmt.Sprintf(ResourceAllowedFormat, userInfo.Username, utils.GenerateGroupString(userInfo.Groups), req.Operation, req.RequestKind, req.SubResource, namespacedName))
@@ -149,6 +254,18 @@ func isAKSSupportUser(userInfo authenticationv1.UserInfo) bool {
 	return userInfo.Username == aksSupportUser
 }
 
+// isUserMatchingAnyPattern returns true if the username matches any of the shell file name patterns, e.g.,
+// system:serviceaccount:fleet-system:*.
+func isUserMatchingAnyPattern(userInfo authenticationv1.UserInfo, patterns []string) bool {
+	for _, pattern := range patterns {
+		// The patterns are validated when the hub agent starts, hence the error is ignored.
+		if matched, _ := path.Match(pattern, userInfo.Username); matched {
+			return true
+		}
+	}
+	return false
+}
+
 // isUserInGroup returns true if user belongs to the specified groupName.
 func isUserInGroup(userInfo authenticationv1.UserInfo, groupName string) bool {
 	return slices.Contains(userInfo.Groups, groupName)
@@ -259,7 +376,7 @@ func checkCRDGroup(group string) bool {
 }
 
 // ValidateMCIdentity returns admission allowed/denied based on the member cluster's identity.
-func ValidateMCIdentity(ctx context.Context, client client.Client, req admission.Request, mcName string) admission.Response {
+func ValidateMCIdentity(ctx context.Context, client client.Client, config validator.Config, req admission.Request, mcName string) admission.Response {
 	var identity string
 	namespacedName := types.NamespacedName{Name: req.Name, Namespace: req.Namespace}
 	userInfo := req.UserInfo
@@ -278,5 +395,17 @@ func ValidateMCIdentity(ctx context.Context, client client.Client, req admission
 		return admission.Allowed(fmt.Sprintf(ResourceAllowedFormat, userInfo.Username, utils.GenerateGroupString(userInfo.Groups), req.Operation, req.RequestKind, req.SubResource, namespacedName))
 	}
 	klog.V(2).InfoS(deniedModifyResource, "user", userInfo.Username, "groups", userInfo.Groups, "operation", req.Operation, "GVK", req.RequestKind, "subResource", req.SubResource, "namespacedName", namespacedName)
-	return admission.Denied(fmt.Sprintf(ResourceDeniedFormat, userInfo.Username, utils.GenerateGroupString(userInfo.Groups), req.Operation, req.RequestKind, req.SubResource, namespacedName))
+	return admission.Denied(resourceDeniedMessage(config, userInfo, req, namespacedName))
+}
+
+// resourceDeniedMessage returns the message denying the user the request on a fleet guarded resource.
+func resourceDeniedMessage(config validator.Config, userInfo authenticationv1.UserInfo, req admission.Request, namespacedName types.NamespacedName) string {
+	return config.DenialMessage(validator.ResourceDeniedMessageID, map[string]any{
+		"user":           userInfo.Username,
+		"groups":         utils.GenerateGroupString(userInfo.Groups),
+		"operation":      req.Operation,
+		"kind":           fmt.Sprintf("%+v", req.RequestKind),
+		"subResource":    req.SubResource,
+		"namespacedName": fmt.Sprintf("%+v", namespacedName),
+	})
 }
@@ -13,7 +13,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	clusterv1beta1 "github.com/kubefleet-dev/kubefleet/apis/cluster/v1beta1"
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
 	"github.com/kubefleet-dev/kubefleet/pkg/utils"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
 )
 
 func TestValidateUserForResource(t *testing.T) {
@@ -179,12 +181,149 @@ func TestValidateUserForResource(t *testing.T) {
 
 	for testName, testCase := range testCases {
 		t.Run(testName, func(t *testing.T) {
-			gotResult := ValidateUserForResource(testCase.req, testCase.whiteListedUsers)
+			gotResult := ValidateUserForResource(validator.Config{}, testCase.req, testCase.whiteListedUsers)
 			assert.Equal(t, testCase.wantResponse, gotResult, utils.TestCaseMsg, testName)
 		})
 	}
 }
 
+func TestValidateUserForFleetRBAC(t *testing.T) {
+	writerPatterns := []string{"system:serviceaccount:fleet-system:hub-agent-sa", "system:serviceaccount:fleet-member-*:member-agent-sa"}
+	namespacedName := types.NamespacedName{Name: "fleet-role-mc1", Namespace: "fleet-member-mc1"}
+	testCases := map[string]struct {
+		userInfo    authenticationv1.UserInfo
+		operation   admissionv1.Operation
+		wantAllowed bool
+	}{
+		"allow user in system:masters group": {
+			userInfo:    authenticationv1.UserInfo{Username: "test-user", Groups: []string{mastersGroup}},
+			operation:   admissionv1.Update,
+			wantAllowed: true,
+		},
+		"allow white listed user": {
+			userInfo:    authenticationv1.UserInfo{Username: "white-listed-user", Groups: []string{"system:authenticated"}},
+			operation:   admissionv1.Update,
+			wantAllowed: true,
+		},
+		"allow hub agent service account": {
+			userInfo:    authenticationv1.UserInfo{Username: "system:serviceaccount:fleet-system:hub-agent-sa", Groups: []string{serviceAccountsGroup}},
+			operation:   admissionv1.Update,
+			wantAllowed: true,
+		},
+		"allow member agent service account matching a wildcard pattern": {
+			userInfo:    authenticationv1.UserInfo{Username: "system:serviceaccount:fleet-member-mc1:member-agent-sa", Groups: []string{serviceAccountsGroup}},
+			operation:   admissionv1.Create,
+			wantAllowed: true,
+		},
+		"deny other service account": {
+			userInfo:  authenticationv1.UserInfo{Username: "system:serviceaccount:fleet-member-mc1:other-sa", Groups: []string{serviceAccountsGroup}},
+			operation: admissionv1.Update,
+		},
+		"deny other user": {
+			userInfo:  authenticationv1.UserInfo{Username: "test-user", Groups: []string{"system:authenticated"}},
+			operation: admissionv1.Delete,
+		},
+		"deny kube-controller-manager update": {
+			userInfo:  authenticationv1.UserInfo{Username: kubeControllerManagerUser, Groups: []string{"system:authenticated"}},
+			operation: admissionv1.Update,
+		},
+		"allow kube-controller-manager delete": {
+			userInfo:    authenticationv1.UserInfo{Username: kubeControllerManagerUser, Groups: []string{"system:authenticated"}},
+			operation:   admissionv1.Delete,
+			wantAllowed: true,
+		},
+		"allow kube-system controller service account delete": {
+			userInfo:    authenticationv1.UserInfo{Username: "system:serviceaccount:kube-system:namespace-controller", Groups: []string{serviceAccountsGroup}},
+			operation:   admissionv1.Delete,
+			wantAllowed: true,
+		},
+	}
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			req := admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Name:        namespacedName.Name,
+					Namespace:   namespacedName.Namespace,
+					RequestKind: &utils.RoleMetaGVK,
+					UserInfo:    tc.userInfo,
+					Operation:   tc.operation,
+				},
+			}
+			want := admission.Denied(fmt.Sprintf(ResourceDeniedFormat, tc.userInfo.Username, utils.GenerateGroupString(tc.userInfo.Groups), tc.operation, &utils.RoleMetaGVK, "", namespacedName))
+			if tc.wantAllowed {
+				want = admission.Allowed(fmt.Sprintf(ResourceAllowedFormat, tc.userInfo.Username, utils.GenerateGroupString(tc.userInfo.Groups), tc.operation, &utils.RoleMetaGVK, "", namespacedName))
+			}
+			got := ValidateUserForFleetRBAC(validator.Config{}, req, []string{"white-listed-user"}, writerPatterns)
+			assert.Equal(t, want, got, utils.TestCaseMsg, testName)
+		})
+	}
+}
+
+func TestValidateFleetFinalizerRemoval(t *testing.T) {
+	writerPatterns := []string{"system:serviceaccount:fleet-system:*"}
+	namespacedName := types.NamespacedName{Name: "test-crp"}
+	testUser := authenticationv1.UserInfo{Username: "test-user", Groups: []string{"system:authenticated"}}
+	fleetFinalizers := []string{placementv1beta1.PlacementCleanupFinalizer, placementv1beta1.SchedulerCleanupFinalizer}
+	testCases := map[string]struct {
+		userInfo          authenticationv1.UserInfo
+		currentFinalizers []string
+		oldFinalizers     []string
+		wantDenied        string
+	}{
+		"deny user removing a fleet finalizer": {
+			userInfo:          testUser,
+			currentFinalizers: []string{placementv1beta1.SchedulerCleanupFinalizer},
+			oldFinalizers:     fleetFinalizers,
+			wantDenied:        placementv1beta1.PlacementCleanupFinalizer,
+		},
+		"deny user in system:masters group removing all fleet finalizers": {
+			userInfo:          authenticationv1.UserInfo{Username: "admin", Groups: []string{mastersGroup}},
+			currentFinalizers: []string{"example.com/other"},
+			oldFinalizers:     append([]string{"example.com/other"}, fleetFinalizers...),
+			wantDenied:        placementv1beta1.PlacementCleanupFinalizer + ", " + placementv1beta1.SchedulerCleanupFinalizer,
+		},
+		"allow fleet controller removing a fleet finalizer": {
+			userInfo:          authenticationv1.UserInfo{Username: "system:serviceaccount:fleet-system:hub-agent-sa", Groups: []string{serviceAccountsGroup}},
+			currentFinalizers: []string{placementv1beta1.SchedulerCleanupFinalizer},
+			oldFinalizers:     fleetFinalizers,
+		},
+		"allow white listed user removing a fleet finalizer": {
+			userInfo:      authenticationv1.UserInfo{Username: "white-listed-user", Groups: []string{"system:authenticated"}},
+			oldFinalizers: fleetFinalizers,
+		},
+		"allow user removing a non fleet finalizer": {
+			userInfo:          testUser,
+			currentFinalizers: fleetFinalizers,
+			oldFinalizers:     append([]string{"example.com/other"}, fleetFinalizers...),
+		},
+		"allow user adding a fleet finalizer": {
+			userInfo:          testUser,
+			currentFinalizers: fleetFinalizers,
+			oldFinalizers:     []string{placementv1beta1.PlacementCleanupFinalizer},
+		},
+	}
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			req := admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Name:        namespacedName.Name,
+					RequestKind: &utils.ClusterResourcePlacementMetaGVK,
+					UserInfo:    tc.userInfo,
+					Operation:   admissionv1.Update,
+				},
+			}
+			want := admission.Allowed(fmt.Sprintf(ResourceAllowedFormat, tc.userInfo.Username, utils.GenerateGroupString(tc.userInfo.Groups), admissionv1.Update, &utils.ClusterResourcePlacementMetaGVK, "", namespacedName))
+			if tc.wantDenied != "" {
+				want = admission.Denied(fmt.Sprintf("user: '%s' in '%s' is not allowed to remove the fleet finalizer(s) %s from clusterResourcePlacement %s; "+
+					"they are removed by the fleet controllers once the placed resources are cleaned up from the member clusters",
+					tc.userInfo.Username, utils.GenerateGroupString(tc.userInfo.Groups), tc.wantDenied, namespacedName.Name))
+			}
+			got := ValidateFleetFinalizerRemoval(validator.Config{}, req, tc.currentFinalizers, tc.oldFinalizers, []string{"white-listed-user"}, writerPatterns)
+			assert.Equal(t, want, got, utils.TestCaseMsg, testName)
+		})
+	}
+}
+
 func TestValidateFleetMemberClusterUpdate(t *testing.T) {
 	testCases := map[string]struct {
 		denyModifyMemberClusterLabels bool
@@ -432,7 +571,7 @@ func TestValidateFleetMemberClusterUpdate(t *testing.T) {
 
 	for testName, testCase := range testCases {
 		t.Run(testName, func(t *testing.T) {
-			gotResult := ValidateFleetMemberClusterUpdate(*testCase.newMC, *testCase.oldMC, testCase.req, testCase.whiteListedUsers, testCase.denyModifyMemberClusterLabels)
+			gotResult := ValidateFleetMemberClusterUpdate(validator.Config{}, *testCase.newMC, *testCase.oldMC, testCase.req, testCase.whiteListedUsers, testCase.denyModifyMemberClusterLabels)
 			assert.Equal(t, testCase.wantResponse, gotResult, utils.TestCaseMsg, testName)
 		})
 	}
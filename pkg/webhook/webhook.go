@@ -26,11 +26,18 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"maps"
 	"math/big"
+	"net/http"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	admv1 "k8s.io/api/admissionregistration/v1"
 	admv1beta1 "k8s.io/api/admissionregistration/v1beta1"
 	appsv1 "k8s.io/api/apps/v1"
@@ -46,14 +53,22 @@ import (
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiErrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	clusterv1beta1 "github.com/kubefleet-dev/kubefleet/apis/cluster/v1beta1"
 	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
 	"github.com/kubefleet-dev/kubefleet/cmd/hubagent/options"
+	hubmetrics "github.com/kubefleet-dev/kubefleet/pkg/metrics/hub"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
 	"github.com/kubefleet-dev/kubefleet/pkg/webhook/clusterresourceoverride"
 	"github.com/kubefleet-dev/kubefleet/pkg/webhook/clusterresourceplacement"
 	"github.com/kubefleet-dev/kubefleet/pkg/webhook/clusterresourceplacementdisruptionbudget"
@@ -63,6 +78,7 @@ import (
 	"github.com/kubefleet-dev/kubefleet/pkg/webhook/pod"
 	"github.com/kubefleet-dev/kubefleet/pkg/webhook/replicaset"
 	"github.com/kubefleet-dev/kubefleet/pkg/webhook/resourceoverride"
+	"github.com/kubefleet-dev/kubefleet/pkg/webhook/work"
 
 	fleetnetworkingv1alpha1 "go.goms.io/fleet-networking/api/v1alpha1"
 )
@@ -74,6 +90,9 @@ const (
 	fleetGuardRailWebhookCfgName  = "fleet-guard-rail-webhook-configuration"
 	fleetMutatingWebhookCfgName   = "fleet-mutating-webhook-configuration"
 
+	mutatingWebhookConfigurationKind   = "MutatingWebhookConfiguration"
+	validatingWebhookConfigurationKind = "ValidatingWebhookConfiguration"
+
 	crdResourceName                      = "customresourcedefinitions"
 	bindingResourceName                  = "bindings"
 	configMapResourceName                = "configmaps"
@@ -104,6 +123,9 @@ const (
 	csiStorageCapacityResourceName       = "csistoragecapacities"
 	memberClusterResourceName            = "memberclusters"
 	internalMemberClusterResourceName    = "internalmemberclusters"
+	clusterResourceSnapshotResourceName  = "clusterresourcesnapshots"
+	clusterPolicySnapshotResourceName    = "clusterschedulingpolicysnapshots"
+	clusterResourcePlacementResourceName = "clusterresourceplacements"
 	endpointSliceExportResourceName      = "endpointsliceexports"
 	endpointSliceImportResourceName      = "endpointsliceimports"
 	internalServiceExportResourceName    = "internalserviceexports"
@@ -123,25 +145,161 @@ var (
 	ignoreFailurePolicy = admv1.Ignore
 	failFailurePolicy   = admv1.Fail
 	sideEffortsNone     = admv1.SideEffectClassNone
+	sideEffectsDryRun   = admv1.SideEffectClassNoneOnDryRun
 	namespacedScope     = admv1.NamespacedScope
 	clusterScope        = admv1.ClusterScope
 	shortWebhookTimeout = ptr.To(int32(1))
 	longWebhookTimeout  = ptr.To(int32(5))
+
+	// webhookConfigurationApplyBackoff is the backoff used to retry the transient errors of applying a webhook configuration.
+	webhookConfigurationApplyBackoff = wait.Backoff{
+		Steps:    5,
+		Duration: 500 * time.Millisecond,
+		Factor:   2.0,
+		Jitter:   0.1,
+	}
 )
 
-var AddToManagerFuncs []func(manager.Manager) error
-var AddToManagerFleetResourceValidator func(manager.Manager, []string, bool) error
-var AddToManagerMemberclusterValidator func(manager.Manager, bool)
+var AddToManagerFuncs []func(manager.Manager, *validator.ConfigStore) error
+var AddToManagerWorkloadFuncs []func(manager.Manager, *validator.ConfigStore) error
+var AddToManagerFleetResourceValidator func(manager.Manager, *validator.ConfigStore, []string, []string, []string, bool, *fleetresourcehandler.DelegationClient) error
+var AddToManagerMemberclusterValidator func(manager.Manager, *validator.ConfigStore, bool) error
 
-// AddToManager adds all Controllers to the Manager
-func AddToManager(m manager.Manager, whiteListedUsers []string, denyModifyMemberClusterLabels bool, networkingAgentsEnabled bool) error {
-	for _, f := range AddToManagerFuncs {
-		if err := f(m); err != nil {
+// AddToManager adds the webhook handlers belonging to the role to the Manager, which read the validator settings
+// of the webhook config on every request. The requests to the audited webhooks are written to the audit logger if
+// it is not nil, and the requests denied by the recorded webhooks are kept in the admission history if it is not nil.
+func (w *Config) AddToManager(m manager.Manager, auditLogger CloudAuditLogger, admissionHistory *AdmissionRequestStore) error {
+	m = withAuditLogging(m, auditLogger)
+	m = withAdmissionHistory(m, admissionHistory)
+	m = withRequestLogging(m, w.logRequestContext)
+	if w.role.Serves(options.WebhookRolePlacement) {
+		for _, f := range AddToManagerFuncs {
+			if err := f(m, w.validatorConfigs); err != nil {
+				return err
+			}
+		}
+		if err := AddToManagerMemberclusterValidator(m, w.validatorConfigs, w.networkingAgentsEnabled); err != nil {
 			return err
 		}
 	}
-	AddToManagerMemberclusterValidator(m, networkingAgentsEnabled)
-	return AddToManagerFleetResourceValidator(m, whiteListedUsers, denyModifyMemberClusterLabels)
+	if w.role.Serves(options.WebhookRoleWorkload) {
+		for _, f := range AddToManagerWorkloadFuncs {
+			if err := f(m, w.validatorConfigs); err != nil {
+				return err
+			}
+		}
+	}
+	if w.role.Serves(options.WebhookRoleGuardRail) {
+		return AddToManagerFleetResourceValidator(m, w.validatorConfigs, w.whiteListedUsers, w.fleetRBACWriterPatterns, w.fleetSnapshotWriterPatterns, w.denyModifyMemberClusterLabels, w.guardRailDelegation)
+	}
+	return nil
+}
+
+// Options are the settings of the webhook config, which the hub agent derives from its command line options.
+type Options struct {
+	// ServiceName is the name of the webhook service, which ServiceNames overrides for the webhook groups it names.
+	ServiceName string
+	// ServicePort is the port the webhook service exposes, which the webhook client configs point at.
+	ServicePort int32
+	// TargetPort is the port the webhook server in the container listens on.
+	TargetPort int32
+	// ClientConnectionType is how the API server reaches the webhooks; the service URL is used if it is nil.
+	ClientConnectionType *options.WebhookClientConnectionType
+	// CertDir is the directory the self-signed webhook serving certificate is generated in.
+	CertDir string
+	// Role is the group of webhooks this hub agent serves.
+	Role options.WebhookRole
+	// ServiceNames maps the webhook groups to the name of the service serving them.
+	ServiceNames map[options.WebhookRole]string
+	// AdditionalCABundle holds the PEM encoded CA certificates published in the caBundle of the webhooks besides the
+	// CA generated for the webhook's server certificate, see normalizeCABundle.
+	AdditionalCABundle []byte
+	// WebhookNameSuffix is appended, after a dash, to the names of the webhook configurations, the webhooks and the
+	// webhook configuration anchor so that the hubs sharing a cluster do not overwrite the configurations of one
+	// another. It must be a DNS-1123 label.
+	WebhookNameSuffix string
+	// EnableWebhookConfigurationAnchor makes the WebhookConfigurationAnchorName ClusterRole own the webhook configurations.
+	EnableWebhookConfigurationAnchor bool
+	// DisallowPrivilegedPorts denies the privileged service ports other than the common ones.
+	DisallowPrivilegedPorts bool
+	// ConfigIntegrity records the expected hash of every applied webhook configuration if it is not nil.
+	ConfigIntegrity *WebhookConfigIntegrity
+	// FeatureGateMatchConditions guards the MatchConditions, which require Kubernetes 1.28+.
+	FeatureGateMatchConditions bool
+	// MatchConditions are the CEL match conditions applied to every generated webhook if FeatureGateMatchConditions is set.
+	MatchConditions []admv1.MatchCondition
+	// MutatingWebhookOverrides overrides the settings of the fleet mutating webhooks, keyed by the webhook name.
+	MutatingWebhookOverrides map[string]MutatingWebhookOverride
+	// WorkloadWebhookExcludedNamespaces are the namespaces the workload webhooks skip; an empty list excludes no
+	// namespace and a nil one keeps the default kube-system, kube-node-lease and fleet-system.
+	WorkloadWebhookExcludedNamespaces []string
+
+	// EnableGuardRail enables the guard rail webhooks.
+	EnableGuardRail bool
+	// EnableWorkload allows the workload resources (pods and replicasets) to be created in the hub cluster.
+	EnableWorkload bool
+	// DenyModifyMemberClusterLabels denies the updates of the MemberCluster labels by the non-admin groups.
+	DenyModifyMemberClusterLabels bool
+	// NetworkingAgentsEnabled indicates if the fleet networking agents are enabled on the member clusters.
+	NetworkingAgentsEnabled bool
+	// WhiteListedUsers are the users the guard rail allows to modify the fleet resources.
+	WhiteListedUsers []string
+	// FleetRBACWriterPatterns are the patterns of the users allowed to write the RBAC resources of the fleet namespaces.
+	FleetRBACWriterPatterns []string
+	// FleetSnapshotWriterPatterns are the patterns of the users allowed to write the fleet snapshots.
+	FleetSnapshotWriterPatterns []string
+	// GuardRailDelegation configures the external authorizer which reviews the guard rail decisions of the delegated
+	// kinds; the delegation is disabled if it is nil.
+	GuardRailDelegation *fleetresourcehandler.DelegationConfig
+	// WebhookCertSecretName is the name of the Secret in the service namespace holding the webhook serving
+	// certificate, which the guard rail protects; it is not protected if empty. It must be a DNS-1123 subdomain.
+	WebhookCertSecretName string
+	// LogRequestContext makes every line logged by the handlers carry the request UID, user, operation and webhook path.
+	LogRequestContext bool
+
+	// TrustedServiceAccounts are the service accounts whose requests skip the advisory placement validations.
+	TrustedServiceAccounts []string
+	// ShadowValidationRules are the placement validation rules whose failures never deny the request.
+	ShadowValidationRules []string
+	// DenyPlacementNameCollisions denies the creation of placements whose name is used by a placement of the other scope.
+	DenyPlacementNameCollisions bool
+	// DenyPlacementUpdatesDuringUpdateRuns denies the spec updates of placements with unfinished staged update runs.
+	DenyPlacementUpdatesDuringUpdateRuns bool
+	// RequireSecretPropagationOptIn denies the placements which select Secrets without the annotation acknowledging it.
+	RequireSecretPropagationOptIn bool
+	// DenyCRDCoSelection denies the placements which select CustomResourceDefinitions together with their custom resources.
+	DenyCRDCoSelection bool
+	// DenyPlacementOverrideConflicts denies the CRPs placing the resources of another CRP with different overrides.
+	DenyPlacementOverrideConflicts bool
+	// AllowUnknownKinds allows the requests of the kinds the webhooks do not accept with a warning.
+	AllowUnknownKinds bool
+	// RequiredLabels maps the label keys every CRP must carry on creation to the optional regular expression their
+	// values must match.
+	RequiredLabels map[string]*regexp.Regexp
+	// MemberClusterLabelSchemas maps the label keys of MemberClusters to the format their values must have.
+	MemberClusterLabelSchemas map[string]*validator.LabelSchema
+	// IncidentWindowConfigMapName is the name of the ConfigMap in the service namespace declaring the incident
+	// windows, during which the CRP spec updates are denied; the updates are not checked if it is empty.
+	IncidentWindowConfigMapName string
+	// LogDeniedUpdateDiffs logs the diff between the old and new objects of every denied placement update.
+	LogDeniedUpdateDiffs bool
+	// EvictionTargetValidation is how the evictions targeting a cluster not selected by the placement are handled.
+	EvictionTargetValidation validator.EvictionTargetValidationMode
+	// PlacementClusterNamesValidation is how the PickFixed placements naming unavailable member clusters are handled.
+	PlacementClusterNamesValidation validator.ClusterNamesValidationMode
+	// PlacementNumberOfClustersValidation is how the PickN placements requesting more clusters than the fleet has are handled.
+	PlacementNumberOfClustersValidation validator.NumberOfClustersValidationMode
+	// PlacementPickAllFleetSizeValidation is how the PickAll placements without required cluster affinity are handled
+	// in a fleet above PlacementPickAllFleetSizeThreshold member clusters; validator.DefaultPickAllFleetSizeThreshold
+	// is used if the threshold is not positive.
+	PlacementPickAllFleetSizeValidation validator.PickAllFleetSizeValidationMode
+	PlacementPickAllFleetSizeThreshold  int
+	// LookupBudget is the time the client-backed checks of a request can spend reading the hub cluster;
+	// validator.DefaultLookupBudget is used if it is not positive.
+	LookupBudget time.Duration
+	// LookupFailurePolicies maps the client-backed checks to how the requests are handled once they exhaust the
+	// budget; the checks not in it keep their default policies.
+	LookupFailurePolicies map[string]validator.LookupFailurePolicy
 }
 
 type Config struct {
@@ -150,47 +308,273 @@ type Config struct {
 	// webhook server info
 	serviceNamespace string
 	serviceName      string
-	servicePort      int32
-	serviceURL       string
+	// servicePort is the port the webhook service exposes, which the webhook client configs point at.
+	servicePort int32
+	// targetPort is the port the webhook server in the container listens on.
+	targetPort int32
+	serviceURL string
 
 	// caPEM is a PEM encoded CA bundle which will be used to validate the webhook's server certificate.
 	caPEM []byte
+	// selfSignedCAPEM is the PEM encoded CA generated for the webhook's server certificate, which caPEM always holds.
+	selfSignedCAPEM []byte
 
+	// clientConnectionType is how the API server reaches the webhooks; the service URL is used if it is nil.
 	clientConnectionType *options.WebhookClientConnectionType
 
 	enableGuardRail bool
 
 	denyModifyMemberClusterLabels bool
 	enableWorkload                bool
+	networkingAgentsEnabled       bool
+
+	// whiteListedUsers are the users the guard rail allows to modify the fleet resources.
+	whiteListedUsers []string
+	// fleetRBACWriterPatterns are the patterns of the users allowed to write the RBAC resources of the fleet namespaces.
+	fleetRBACWriterPatterns []string
+	// fleetSnapshotWriterPatterns are the patterns of the users allowed to write the fleet snapshots.
+	fleetSnapshotWriterPatterns []string
+	// logRequestContext makes every line logged by the handlers carry the request context.
+	logRequestContext bool
+
+	// trustedServiceAccounts are the service accounts whose requests skip the advisory placement validations.
+	trustedServiceAccounts []string
+	// shadowValidationRules are the placement validation rules whose failures never deny the request.
+	shadowValidationRules []string
+	// denyPlacementNameCollisions denies the creation of placements whose name is used by a placement of the other scope.
+	denyPlacementNameCollisions bool
+	// denyPlacementUpdatesDuringUpdateRuns denies the spec updates of placements with unfinished staged update runs.
+	denyPlacementUpdatesDuringUpdateRuns bool
+	// requireSecretPropagationOptIn denies the placements which select Secrets without the annotation acknowledging it.
+	requireSecretPropagationOptIn bool
+	// denyCRDCoSelection denies the placements which select CustomResourceDefinitions together with their custom resources.
+	denyCRDCoSelection bool
+	// denyPlacementOverrideConflicts denies the CRPs placing the resources of another CRP with different overrides.
+	denyPlacementOverrideConflicts bool
+	// allowUnknownKinds allows the requests of the kinds the webhooks do not accept with a warning.
+	allowUnknownKinds bool
+	// requiredLabels maps the label keys every CRP must carry on creation to the optional regular expression their
+	// values must match.
+	requiredLabels map[string]*regexp.Regexp
+	// memberClusterLabelSchemas maps the label keys of MemberClusters to the format their values must have.
+	memberClusterLabelSchemas map[string]*validator.LabelSchema
+	// incidentWindowChecker tells if the fleet is in an incident window, during which the CRP spec updates are denied.
+	incidentWindowChecker validator.IncidentWindowChecker
+	// webhookCertSecretName is the name of the Secret in the service namespace holding the webhook serving
+	// certificate, which the guard rail protects; it is not protected if empty.
+	webhookCertSecretName string
+	// logDeniedUpdateDiffs logs the diff between the old and new objects of every denied placement update.
+	logDeniedUpdateDiffs bool
+	// evictionTargetValidation is how the evictions targeting a cluster not selected by the placement are handled.
+	evictionTargetValidation validator.EvictionTargetValidationMode
+	// placementClusterNamesValidation is how the PickFixed placements naming unavailable member clusters are handled.
+	placementClusterNamesValidation validator.ClusterNamesValidationMode
+	// placementNumberOfClustersValidation is how the PickN placements requesting more clusters than the fleet has are handled.
+	placementNumberOfClustersValidation validator.NumberOfClustersValidationMode
+	// placementPickAllFleetSizeValidation is how the PickAll placements without required cluster affinity are handled
+	// in a fleet above placementPickAllFleetSizeThreshold member clusters.
+	placementPickAllFleetSizeValidation validator.PickAllFleetSizeValidationMode
+	placementPickAllFleetSizeThreshold  int
+	// lookupBudget is the time the client-backed checks of a request can spend reading the hub cluster.
+	lookupBudget time.Duration
+	// lookupFailurePolicies maps the client-backed checks to how the requests are handled once they exhaust the budget.
+	lookupFailurePolicies map[string]validator.LookupFailurePolicy
+	// validatorConfigs holds the validator settings the webhook handlers read on every request, which start as the
+	// ones derived from the fields above and are replaced whenever the webhook config ConfigMap changes.
+	validatorConfigs *validator.ConfigStore
+
+	// role is the group of webhooks this hub agent serves.
+	role options.WebhookRole
+	// serviceNames maps each webhook group to the name of the service serving it.
+	serviceNames map[options.WebhookRole]string
+	// mutatingWebhookOverrides overrides the settings of the fleet mutating webhooks, keyed by the webhook name.
+	mutatingWebhookOverrides map[string]MutatingWebhookOverride
+	// workloadWebhookExcludedNamespaces are the namespaces the workload webhooks skip; the defaults are used if nil.
+	workloadWebhookExcludedNamespaces []string
+	// featureGateMatchConditions guards the match conditions, which require Kubernetes 1.28+.
+	featureGateMatchConditions bool
+	// matchConditions are applied to every generated webhook if featureGateMatchConditions is set.
+	matchConditions []admv1.MatchCondition
+	// guardRailDelegation reviews the guard rail decisions of the delegated kinds, it is nil if the delegation is disabled.
+	guardRailDelegation *fleetresourcehandler.DelegationClient
+
+	// webhookCache caches the webhooks built from the rule-affecting fields above.
+	webhookCache *webhookCache
+	// applyStatus tracks whether the webhook configurations are applied.
+	applyStatus *webhookConfigurationApplyStatus
+	// integrity records the expected hashes of the applied webhook configurations if it is set.
+	integrity *WebhookConfigIntegrity
+	// webhookConfigurationAnchor indicates if the webhook configurations are owned by the anchor ClusterRole.
+	webhookConfigurationAnchor bool
+	// webhookNameSuffix is appended to the names of the webhook configurations and the webhooks in them.
+	webhookNameSuffix string
+	// disallowPrivilegedPorts denies the privileged service ports other than the common ones.
+	disallowPrivilegedPorts bool
 }
 
-func NewWebhookConfig(mgr manager.Manager, webhookServiceName string, port int32, clientConnectionType *options.WebhookClientConnectionType, certDir string, enableGuardRail bool, denyModifyMemberClusterLabels bool, enableWorkload bool) (*Config, error) {
+// NewWebhookConfig returns the webhook config built from the options, and generates the self-signed certificate
+// of the webhook server in the cert directory. It returns an error if any of the options is invalid.
+func NewWebhookConfig(mgr manager.Manager, opts Options) (*Config, error) {
+	if err := validatePort("service", opts.ServicePort); err != nil {
+		return nil, err
+	}
+	if err := validatePort("target", opts.TargetPort); err != nil {
+		return nil, err
+	}
 	// We assume the Pod namespace should be passed to env through downward API in the Pod spec.
 	namespace := os.Getenv("POD_NAMESPACE")
 	if namespace == "" {
 		return nil, errors.New("fail to obtain Pod namespace from POD_NAMESPACE")
 	}
+	if err := validateWebhookNameSuffix(opts.WebhookNameSuffix); err != nil {
+		return nil, err
+	}
+	if err := validateWebhookCertSecretName(opts.WebhookCertSecretName); err != nil {
+		return nil, err
+	}
+	if err := validateMatchConditions(opts.MatchConditions); err != nil {
+		return nil, err
+	}
+	if err := validateMutatingWebhookOverrides(opts.MutatingWebhookOverrides); err != nil {
+		return nil, err
+	}
+	excludedNamespaces, err := normalizeWorkloadWebhookExcludedNamespaces(opts.WorkloadWebhookExcludedNamespaces)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateLookupFailurePolicies(opts.LookupFailurePolicies); err != nil {
+		return nil, err
+	}
+	if mode := opts.PlacementNumberOfClustersValidation; mode != "" && !slices.Contains(validator.NumberOfClustersValidationModes, mode) {
+		return nil, fmt.Errorf("invalid placement number of clusters validation mode %q, must be one of %v", mode, validator.NumberOfClustersValidationModes)
+	}
+	if mode := opts.PlacementPickAllFleetSizeValidation; mode != "" && !slices.Contains(validator.PickAllFleetSizeValidationModes, mode) {
+		return nil, fmt.Errorf("invalid placement PickAll fleet size validation mode %q, must be one of %v", mode, validator.PickAllFleetSizeValidationModes)
+	}
+	var guardRailDelegation *fleetresourcehandler.DelegationClient
+	if opts.GuardRailDelegation != nil {
+		if guardRailDelegation, err = fleetresourcehandler.NewDelegationClient(*opts.GuardRailDelegation); err != nil {
+			return nil, fmt.Errorf("invalid guard rail delegation config: %w", err)
+		}
+	}
+	// Groups without a designated service are served by the single webhook service.
+	webhookServiceName := opts.ServiceName
+	groupServiceNames := make(map[options.WebhookRole]string, len(options.WebhookGroups))
+	for _, group := range options.WebhookGroups {
+		groupServiceNames[group] = webhookServiceName
+		if name := opts.ServiceNames[group]; name != "" {
+			groupServiceNames[group] = name
+		}
+	}
+	// A hub agent serving a single group is reached through the service designated to the group.
+	if name, ok := groupServiceNames[opts.Role]; ok {
+		webhookServiceName = name
+	}
+	var incidentWindowChecker validator.IncidentWindowChecker
+	if opts.IncidentWindowConfigMapName != "" {
+		// The ConfigMaps are not cached except for the webhook config one, so the incident windows are read from the API server.
+		incidentWindowChecker = validator.NewConfigMapIncidentWindowChecker(mgr.GetAPIReader(), namespace, opts.IncidentWindowConfigMapName)
+	}
 	w := Config{
-		mgr:                           mgr,
-		servicePort:                   port,
-		serviceNamespace:              namespace,
-		serviceName:                   webhookServiceName,
-		serviceURL:                    fmt.Sprintf("https://%s.%s.svc.cluster.local:%d", webhookServiceName, namespace, port),
-		clientConnectionType:          clientConnectionType,
-		enableGuardRail:               enableGuardRail,
-		denyModifyMemberClusterLabels: denyModifyMemberClusterLabels,
-		enableWorkload:                enableWorkload,
-	}
-	caPEM, err := w.genCertificate(certDir)
+		mgr:                                  mgr,
+		servicePort:                          opts.ServicePort,
+		targetPort:                           opts.TargetPort,
+		serviceNamespace:                     namespace,
+		serviceName:                          webhookServiceName,
+		serviceURL:                           buildServiceURL(webhookServiceName, namespace, opts.ServicePort),
+		role:                                 opts.Role,
+		serviceNames:                         groupServiceNames,
+		clientConnectionType:                 opts.ClientConnectionType,
+		enableGuardRail:                      opts.EnableGuardRail,
+		denyModifyMemberClusterLabels:        opts.DenyModifyMemberClusterLabels,
+		enableWorkload:                       opts.EnableWorkload,
+		networkingAgentsEnabled:              opts.NetworkingAgentsEnabled,
+		whiteListedUsers:                     opts.WhiteListedUsers,
+		fleetRBACWriterPatterns:              opts.FleetRBACWriterPatterns,
+		fleetSnapshotWriterPatterns:          opts.FleetSnapshotWriterPatterns,
+		logRequestContext:                    opts.LogRequestContext,
+		trustedServiceAccounts:               opts.TrustedServiceAccounts,
+		shadowValidationRules:                opts.ShadowValidationRules,
+		denyPlacementNameCollisions:          opts.DenyPlacementNameCollisions,
+		denyPlacementUpdatesDuringUpdateRuns: opts.DenyPlacementUpdatesDuringUpdateRuns,
+		requireSecretPropagationOptIn:        opts.RequireSecretPropagationOptIn,
+		denyCRDCoSelection:                   opts.DenyCRDCoSelection,
+		denyPlacementOverrideConflicts:       opts.DenyPlacementOverrideConflicts,
+		allowUnknownKinds:                    opts.AllowUnknownKinds,
+		requiredLabels:                       opts.RequiredLabels,
+		memberClusterLabelSchemas:            opts.MemberClusterLabelSchemas,
+		incidentWindowChecker:                incidentWindowChecker,
+		webhookCertSecretName:                opts.WebhookCertSecretName,
+		logDeniedUpdateDiffs:                 opts.LogDeniedUpdateDiffs,
+		evictionTargetValidation:             opts.EvictionTargetValidation,
+		placementClusterNamesValidation:      opts.PlacementClusterNamesValidation,
+		placementNumberOfClustersValidation:  opts.PlacementNumberOfClustersValidation,
+		placementPickAllFleetSizeValidation:  opts.PlacementPickAllFleetSizeValidation,
+		placementPickAllFleetSizeThreshold:   opts.PlacementPickAllFleetSizeThreshold,
+		lookupBudget:                         opts.LookupBudget,
+		lookupFailurePolicies:                maps.Clone(opts.LookupFailurePolicies),
+		mutatingWebhookOverrides:             opts.MutatingWebhookOverrides,
+		workloadWebhookExcludedNamespaces:    excludedNamespaces,
+		featureGateMatchConditions:           opts.FeatureGateMatchConditions,
+		matchConditions:                      slices.Clone(opts.MatchConditions),
+		guardRailDelegation:                  guardRailDelegation,
+		webhookCache:                         &webhookCache{},
+		applyStatus:                          &webhookConfigurationApplyStatus{},
+		integrity:                            opts.ConfigIntegrity,
+		webhookConfigurationAnchor:           opts.EnableWebhookConfigurationAnchor,
+		webhookNameSuffix:                    opts.WebhookNameSuffix,
+		disallowPrivilegedPorts:              opts.DisallowPrivilegedPorts,
+	}
+	w.validatorConfigs = validator.NewConfigStore(w.validatorConfig())
+	caPEM, err := w.genCertificate(opts.CertDir)
 	if err != nil {
 		return nil, err
 	}
-	w.caPEM = caPEM
-	return &w, err
+	w.selfSignedCAPEM = caPEM
+	if w.caPEM, err = buildCABundle(caPEM, opts.AdditionalCABundle); err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// validatePort returns an error if the port is out of the valid port range.
+func validatePort(name string, port int32) error {
+	if errs := validation.IsValidPortNum(int(port)); len(errs) > 0 {
+		return fmt.Errorf("invalid webhook %s port %d: %s", name, port, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// validatorConfig returns the settings of the fleet validators derived from the webhook config.
+func (w *Config) validatorConfig() validator.Config {
+	return validator.Config{
+		TrustedServiceAccounts:          w.trustedServiceAccounts,
+		ShadowValidationRules:           w.shadowValidationRules,
+		FleetNamespace:                  w.serviceNamespace,
+		DenyPlacementNameCollisions:     w.denyPlacementNameCollisions,
+		DenySpecUpdatesDuringUpdateRuns: w.denyPlacementUpdatesDuringUpdateRuns,
+		RequireSecretPropagationOptIn:   w.requireSecretPropagationOptIn,
+		DenyCRDCoSelection:              w.denyCRDCoSelection,
+		DenyPlacementOverrideConflicts:  w.denyPlacementOverrideConflicts,
+		AllowUnknownKinds:               w.allowUnknownKinds,
+		RequiredLabels:                  w.requiredLabels,
+		LabelSchemas:                    w.memberClusterLabelSchemas,
+		LogDeniedUpdateDiffs:            w.logDeniedUpdateDiffs,
+		EvictionTargetValidation:        w.evictionTargetValidation,
+		ClusterNamesValidation:          w.placementClusterNamesValidation,
+		NumberOfClustersValidation:      w.placementNumberOfClustersValidation,
+		PickAllFleetSizeValidation:      w.placementPickAllFleetSizeValidation,
+		PickAllFleetSizeThreshold:       w.placementPickAllFleetSizeThreshold,
+		LookupBudget:                    w.lookupBudget,
+		LookupFailurePolicies:           w.lookupFailurePolicies,
+		IncidentWindowChecker:           w.incidentWindowChecker,
+		WebhookCertSecretName:           w.webhookCertSecretName,
+	}
 }
 
+// Start applies the webhook configurations holding the webhooks served by the role of this hub agent.
 func (w *Config) Start(ctx context.Context) error {
-	klog.V(2).InfoS("setting up webhooks in apiserver from the leader")
+	klog.V(2).InfoS("setting up webhooks in apiserver", "role", w.role)
 	if err := w.createFleetWebhookConfiguration(ctx); err != nil {
 		klog.ErrorS(err, "unable to setup webhook configurations in apiserver")
 		return err
@@ -198,39 +582,183 @@ func (w *Config) Start(ctx context.Context) error {
 	return nil
 }
 
-// createFleetWebhookConfiguration creates the ValidatingWebhookConfiguration object for the webhook.
+// NeedLeaderElection implements LeaderElectionRunnable interface. Every hub agent applies the webhook configurations
+// of its own role, which the hub agents serving the other roles never touch; the hub agents sharding the webhooks
+// across services would otherwise only get the configurations of the role the leader serves applied.
+func (w *Config) NeedLeaderElection() bool {
+	return false
+}
+
+// webhookConfigurationApply is a webhook configuration to apply, identified by its kind and name.
+type webhookConfigurationApply struct {
+	kind string
+	name string
+	// hash is the hash of the desired configuration, see webhookConfigurationHash.
+	hash  string
+	apply func(ctx context.Context) error
+}
+
+// desiredWebhookConfigurations returns the webhook configurations holding the webhooks served by the role of this
+// hub agent, along with the hashes of the desired configurations.
+func (w *Config) desiredWebhookConfigurations() ([]webhookConfigurationApply, error) {
+	var applies []webhookConfigurationApply
+	if webhooks := w.buildFleetMutatingWebhooks(); len(webhooks) > 0 {
+		name := w.webhookConfigurationName(fleetMutatingWebhookCfgName)
+		hash, err := webhookConfigurationHash(mutatingWebhookConfigurationKind, name, webhooks)
+		if err != nil {
+			return nil, err
+		}
+		applies = append(applies, webhookConfigurationApply{kind: mutatingWebhookConfigurationKind, name: name, hash: hash, apply: func(ctx context.Context) error {
+			return w.createMutatingWebhookConfiguration(ctx, webhooks, name, hash)
+		}})
+	}
+	appendValidating := func(name string, webhooks []admv1.ValidatingWebhook) error {
+		if len(webhooks) == 0 {
+			return nil
+		}
+		name = w.webhookConfigurationName(name)
+		hash, err := webhookConfigurationHash(validatingWebhookConfigurationKind, name, webhooks)
+		if err != nil {
+			return err
+		}
+		applies = append(applies, webhookConfigurationApply{kind: validatingWebhookConfigurationKind, name: name, hash: hash, apply: func(ctx context.Context) error {
+			return w.createValidatingWebhookConfiguration(ctx, webhooks, name, hash)
+		}})
+		return nil
+	}
+	if err := appendValidating(fleetValidatingWebhookCfgName, w.buildFleetValidatingWebhooks()); err != nil {
+		return nil, err
+	}
+	if w.enableGuardRail {
+		if err := appendValidating(fleetGuardRailWebhookCfgName, w.buildFleetGuardRailValidatingWebhooks()); err != nil {
+			return nil, err
+		}
+	}
+	return applies, nil
+}
+
+// createFleetWebhookConfiguration creates the webhook configurations holding the webhooks served by the role of this
+// hub agent. All the configurations are attempted even if some fail so that the cluster is protected by as many
+// webhooks as possible; transient errors are retried with backoff and the failures are returned as an aggregate.
 func (w *Config) createFleetWebhookConfiguration(ctx context.Context) error {
-	if err := w.createMutatingWebhookConfiguration(ctx, w.buildFleetMutatingWebhooks(), fleetMutatingWebhookCfgName); err != nil {
+	applies, err := w.desiredWebhookConfigurations()
+	if err != nil {
+		err = fmt.Errorf("failed to hash the desired webhook configurations: %w", err)
+		w.applyStatus.set(err)
 		return err
 	}
-	if err := w.createValidatingWebhookConfiguration(ctx, w.buildFleetValidatingWebhooks(), fleetValidatingWebhookCfgName); err != nil {
-		return err
+
+	var errs []error
+	for _, a := range applies {
+		err := retry.OnError(webhookConfigurationApplyBackoff, isTransientAPIError, func() error {
+			return a.apply(ctx)
+		})
+		applied := 1.0
+		if err != nil {
+			applied = 0
+			klog.ErrorS(err, "Failed to apply the webhook configuration", "kind", a.kind, "name", a.name)
+			errs = append(errs, fmt.Errorf("failed to apply the %s %s: %w", a.kind, a.name, err))
+		} else {
+			klog.V(2).InfoS("Applied the webhook configuration", "kind", a.kind, "name", a.name, "hash", a.hash)
+			hubmetrics.FleetWebhookConfigurationHash.DeletePartialMatch(prometheus.Labels{"kind": a.kind, "name": a.name})
+			hubmetrics.FleetWebhookConfigurationHash.WithLabelValues(a.kind, a.name, a.hash, hubAgentVersion).Set(1)
+			if w.integrity != nil {
+				if err := w.integrity.record(ctx, a.kind, a.name); err != nil {
+					klog.ErrorS(err, "Failed to record the expected hash of the webhook configuration, its integrity is not checked", "kind", a.kind, "name", a.name)
+				}
+			}
+		}
+		hubmetrics.FleetWebhookConfigurationApplied.WithLabelValues(a.kind, a.name).Set(applied)
 	}
-	if w.enableGuardRail {
-		if err := w.createValidatingWebhookConfiguration(ctx, w.buildFleetGuardRailValidatingWebhooks(), fleetGuardRailWebhookCfgName); err != nil {
-			return err
+	err = apiErrors.NewAggregate(errs)
+	w.applyStatus.set(err)
+	return err
+}
+
+// isTransientAPIError returns true if the error returned by the API server is worth retrying.
+func isTransientAPIError(err error) bool {
+	return apierrors.IsServerTimeout(err) || apierrors.IsTimeout(err) || apierrors.IsTooManyRequests(err) ||
+		apierrors.IsServiceUnavailable(err) || apierrors.IsInternalError(err) || apierrors.IsConflict(err)
+}
+
+// webhookConfigurationApplyStatus tracks the result of the last attempt to apply the webhook configurations.
+type webhookConfigurationApplyStatus struct {
+	mu        sync.RWMutex
+	attempted bool
+	err       error
+}
+
+func (s *webhookConfigurationApplyStatus) set(err error) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attempted, s.err = true, err
+}
+
+// ReadinessChecker returns a readiness check which fails if the webhook configurations were applied by this hub
+// agent and any of them failed. The check passes before the configurations are applied.
+func (w *Config) ReadinessChecker() healthz.Checker {
+	return func(_ *http.Request) error {
+		s := w.applyStatus
+		if s == nil {
+			return nil
 		}
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		if s.attempted && s.err != nil {
+			return fmt.Errorf("not all the webhook configurations are applied: %w", s.err)
+		}
+		return nil
 	}
-	return nil
+}
+
+// webhookConfigurationName returns the name of the webhook configuration for the role of this hub agent, with the
+// webhook name suffix appended. Hub agents serving a single webhook group each manage their own configurations so
+// that they do not overwrite the caBundle of one another.
+func (w *Config) webhookConfigurationName(name string) string {
+	if w.role != "" && w.role != options.WebhookRoleAll {
+		name = fmt.Sprintf("%s-%s", name, w.role)
+	}
+	return suffixedName(name, w.webhookNameSuffix)
 }
 
 // createMutatingWebhookConfiguration creates the MutatingWebhookConfiguration object for the webhook.
-func (w *Config) createMutatingWebhookConfiguration(ctx context.Context, webhooks []admv1.MutatingWebhook, configName string) error {
+func (w *Config) createMutatingWebhookConfiguration(ctx context.Context, webhooks []admv1.MutatingWebhook, configName, hash string) error {
 	mutatingWebhookConfig := admv1.MutatingWebhookConfiguration{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: configName,
 			Labels: map[string]string{
 				"admissions.enforcer/disabled": "true",
 			},
+			Annotations: map[string]string{
+				WebhookConfigurationHashAnnotation: hash,
+			},
 		},
 		Webhooks: webhooks,
 	}
+	if err := w.stampWebhookConfiguration(ctx, &mutatingWebhookConfig); err != nil {
+		return err
+	}
 
 	if err := w.mgr.GetClient().Create(ctx, &mutatingWebhookConfig); err != nil {
 		if !apierrors.IsAlreadyExists(err) {
 			return err
 		}
-		klog.V(2).InfoS("mutating webhook configuration exists, need to overwrite", "name", configName)
+		var existing admv1.MutatingWebhookConfiguration
+		if err := w.mgr.GetClient().Get(ctx, client.ObjectKey{Name: configName}, &existing); err != nil {
+			return err
+		}
+		caBundles := make([][]byte, 0, len(existing.Webhooks))
+		for _, wh := range existing.Webhooks {
+			caBundles = append(caBundles, wh.ClientConfig.CABundle)
+		}
+		if isWebhookConfigurationUpToDate(&existing, hash, caBundles, w.caPEM) {
+			klog.V(2).InfoS("mutating webhook configuration is up to date", "name", configName, "hash", hash)
+			return w.adoptWebhookConfiguration(ctx, &existing, &mutatingWebhookConfig)
+		}
+		klog.V(2).InfoS("mutating webhook configuration exists, need to overwrite", "name", configName, "appliedHash", existing.Annotations[WebhookConfigurationHashAnnotation], "desiredHash", hash)
 		if err := w.mgr.GetClient().Delete(ctx, &mutatingWebhookConfig); err != nil {
 			return err
 		}
@@ -244,12 +772,34 @@ func (w *Config) createMutatingWebhookConfiguration(ctx context.Context, webhook
 	return nil
 }
 
-// buildFleetMutatingWebhooks returns a slice of fleet mutating webhook objects.
-func (w *Config) buildFleetMutatingWebhooks() []admv1.MutatingWebhook {
+// newFleetMutatingWebhooks builds a fresh slice of fleet mutating webhook objects in the order the API server
+// calls them, see fleetMutatingWebhookNames. The defaulting and the annotation normalizing webhooks are reinvoked
+// if a later mutation, e.g., of a downstream webhook, changes the object so that their mutations are not undone.
+func (w *Config) newFleetMutatingWebhooks() []admv1.MutatingWebhook {
+	if !w.role.Serves(options.WebhookRolePlacement) {
+		return nil
+	}
 	webHooks := []admv1.MutatingWebhook{
 		{
-			Name:                    "fleet.clusterresourceplacementv1beta1.mutating",
-			ClientConfig:            w.createClientConfig(clusterresourceplacement.MutatingPath),
+			Name:                    crpRollbackMutatingWebhookName,
+			ClientConfig:            w.createClientConfig(options.WebhookRolePlacement, clusterresourceplacement.RollbackMutatingPath),
+			FailurePolicy:           &ignoreFailurePolicy,
+			SideEffects:             &sideEffortsNone,
+			AdmissionReviewVersions: admissionReviewVersions,
+			Rules: []admv1.RuleWithOperations{
+				{
+					Operations: []admv1.OperationType{
+						admv1.Update,
+					},
+					Rule: createRule([]string{placementv1beta1.GroupVersion.Group}, []string{placementv1beta1.GroupVersion.Version}, []string{placementv1beta1.ClusterResourcePlacementResource}, &clusterScope),
+				},
+			},
+			TimeoutSeconds:     longWebhookTimeout,
+			ReinvocationPolicy: &reinvokeNever,
+		},
+		{
+			Name:                    crpMutatingWebhookName,
+			ClientConfig:            w.createClientConfig(options.WebhookRolePlacement, clusterresourceplacement.MutatingPath),
 			FailurePolicy:           &ignoreFailurePolicy,
 			SideEffects:             &sideEffortsNone,
 			AdmissionReviewVersions: admissionReviewVersions,
@@ -262,19 +812,76 @@ func (w *Config) buildFleetMutatingWebhooks() []admv1.MutatingWebhook {
 					Rule: createRule([]string{placementv1beta1.GroupVersion.Group}, []string{placementv1beta1.GroupVersion.Version}, []string{placementv1beta1.ClusterResourcePlacementResource}, &clusterScope),
 				},
 			},
-			TimeoutSeconds: longWebhookTimeout,
+			TimeoutSeconds:     longWebhookTimeout,
+			ReinvocationPolicy: &reinvokeIfNeeded,
+		},
+		{
+			Name:                    crpAnnotationNormalizingMutatingWebhookName,
+			ClientConfig:            w.createClientConfig(options.WebhookRolePlacement, clusterresourceplacement.AnnotationNormalizingMutatingPath),
+			FailurePolicy:           &ignoreFailurePolicy,
+			SideEffects:             &sideEffortsNone,
+			AdmissionReviewVersions: admissionReviewVersions,
+			Rules: []admv1.RuleWithOperations{
+				{
+					Operations: []admv1.OperationType{
+						admv1.Create,
+						admv1.Update,
+					},
+					Rule: createRule([]string{placementv1beta1.GroupVersion.Group}, []string{placementv1beta1.GroupVersion.Version}, []string{placementv1beta1.ClusterResourcePlacementResource}, &clusterScope),
+				},
+			},
+			TimeoutSeconds:     shortWebhookTimeout,
+			ReinvocationPolicy: &reinvokeIfNeeded,
+		},
+		{
+			Name:                    crpSpecHistoryMutatingWebhookName,
+			ClientConfig:            w.createClientConfig(options.WebhookRolePlacement, clusterresourceplacement.SpecHistoryMutatingPath),
+			FailurePolicy:           &ignoreFailurePolicy,
+			SideEffects:             &sideEffortsNone,
+			AdmissionReviewVersions: admissionReviewVersions,
+			Rules: []admv1.RuleWithOperations{
+				{
+					Operations: []admv1.OperationType{
+						admv1.Update,
+					},
+					Rule: createRule([]string{placementv1beta1.GroupVersion.Group}, []string{placementv1beta1.GroupVersion.Version}, []string{placementv1beta1.ClusterResourcePlacementResource}, &clusterScope),
+				},
+			},
+			TimeoutSeconds:     shortWebhookTimeout,
+			ReinvocationPolicy: &reinvokeNever,
+		},
+		{
+			Name:                    crpPhaseTimestampsMutatingWebhookName,
+			ClientConfig:            w.createClientConfig(options.WebhookRolePlacement, clusterresourceplacement.PhaseTimestampsMutatingPath),
+			FailurePolicy:           &ignoreFailurePolicy,
+			SideEffects:             &sideEffortsNone,
+			AdmissionReviewVersions: admissionReviewVersions,
+			Rules: []admv1.RuleWithOperations{
+				{
+					Operations: []admv1.OperationType{
+						admv1.Update,
+					},
+					Rule: createRule([]string{placementv1beta1.GroupVersion.Group}, []string{placementv1beta1.GroupVersion.Version}, []string{placementv1beta1.ClusterResourcePlacementResource}, &clusterScope),
+				},
+			},
+			TimeoutSeconds:     shortWebhookTimeout,
+			ReinvocationPolicy: &reinvokeNever,
 		},
 	}
-	return webHooks
+	w.applyMutatingWebhookOverrides(webHooks)
+	return w.suffixMutatingWebhookNames(w.applyMutatingMatchConditions(webHooks))
 }
 
-func (w *Config) createValidatingWebhookConfiguration(ctx context.Context, webhooks []admv1.ValidatingWebhook, configName string) error {
+func (w *Config) createValidatingWebhookConfiguration(ctx context.Context, webhooks []admv1.ValidatingWebhook, configName, hash string) error {
 	validatingWebhookConfig := admv1.ValidatingWebhookConfiguration{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: configName,
 			Labels: map[string]string{
 				"admissions.enforcer/disabled": "true",
 			},
+			Annotations: map[string]string{
+				WebhookConfigurationHashAnnotation: hash,
+			},
 		},
 		Webhooks: webhooks,
 	}
@@ -284,12 +891,27 @@ func (w *Config) createValidatingWebhookConfiguration(ctx context.Context, webho
 	if err := bindWebhookConfigToFleetSystem(ctx, w.mgr.GetClient(), &validatingWebhookConfig); err != nil {
 		return err
 	}
+	if err := w.stampWebhookConfiguration(ctx, &validatingWebhookConfig); err != nil {
+		return err
+	}
 
 	if err := w.mgr.GetClient().Create(ctx, &validatingWebhookConfig); err != nil {
 		if !apierrors.IsAlreadyExists(err) {
 			return err
 		}
-		klog.V(2).InfoS("validating webhook configuration exists, need to overwrite", "name", configName)
+		var existing admv1.ValidatingWebhookConfiguration
+		if err := w.mgr.GetClient().Get(ctx, client.ObjectKey{Name: configName}, &existing); err != nil {
+			return err
+		}
+		caBundles := make([][]byte, 0, len(existing.Webhooks))
+		for _, wh := range existing.Webhooks {
+			caBundles = append(caBundles, wh.ClientConfig.CABundle)
+		}
+		if isWebhookConfigurationUpToDate(&existing, hash, caBundles, w.caPEM) {
+			klog.V(2).InfoS("validating webhook configuration is up to date", "name", configName, "hash", hash)
+			return w.adoptWebhookConfiguration(ctx, &existing, &validatingWebhookConfig)
+		}
+		klog.V(2).InfoS("validating webhook configuration exists, need to overwrite", "name", configName, "appliedHash", existing.Annotations[WebhookConfigurationHashAnnotation], "desiredHash", hash)
 		// Here we simply use delete/create pattern to implement full overwrite
 		if err := w.mgr.GetClient().Delete(ctx, &validatingWebhookConfig); err != nil {
 			return err
@@ -304,18 +926,19 @@ func (w *Config) createValidatingWebhookConfiguration(ctx context.Context, webho
 	return nil
 }
 
-// buildValidatingWebHooks returns a slice of fleet validating webhook objects.
-func (w *Config) buildFleetValidatingWebhooks() []admv1.ValidatingWebhook {
+// newFleetValidatingWebhooks builds a fresh slice of fleet validating webhook objects.
+func (w *Config) newFleetValidatingWebhooks() []admv1.ValidatingWebhook {
 	var webHooks []admv1.ValidatingWebhook
 
 	// When enableWorkload is true, skip pod and replicaset validating webhooks to allow workloads
-	if !w.enableWorkload {
+	if !w.enableWorkload && w.role.Serves(options.WebhookRoleWorkload) {
 		webHooks = append(webHooks, admv1.ValidatingWebhook{
 			Name:                    "fleet.pod.validating",
-			ClientConfig:            w.createClientConfig(pod.ValidationPath),
+			ClientConfig:            w.createClientConfig(options.WebhookRoleWorkload, pod.ValidationPath),
 			FailurePolicy:           &failFailurePolicy,
 			SideEffects:             &sideEffortsNone,
 			AdmissionReviewVersions: admissionReviewVersions,
+			NamespaceSelector:       w.workloadNamespaceSelector(),
 			Rules: []admv1.RuleWithOperations{
 				{
 					Operations: []admv1.OperationType{admv1.Create},
@@ -327,10 +950,11 @@ func (w *Config) buildFleetValidatingWebhooks() []admv1.ValidatingWebhook {
 
 		webHooks = append(webHooks, admv1.ValidatingWebhook{
 			Name:                    "fleet.replicaset.validating",
-			ClientConfig:            w.createClientConfig(replicaset.ValidationPath),
+			ClientConfig:            w.createClientConfig(options.WebhookRoleWorkload, replicaset.ValidationPath),
 			FailurePolicy:           &failFailurePolicy,
 			SideEffects:             &sideEffortsNone,
 			AdmissionReviewVersions: admissionReviewVersions,
+			NamespaceSelector:       w.workloadNamespaceSelector(),
 			Rules: []admv1.RuleWithOperations{
 				{
 					Operations: []admv1.OperationType{admv1.Create},
@@ -341,9 +965,13 @@ func (w *Config) buildFleetValidatingWebhooks() []admv1.ValidatingWebhook {
 		})
 	}
 
+	if !w.role.Serves(options.WebhookRolePlacement) {
+		return w.applyValidatingMatchConditions(webHooks)
+	}
+
 	webHooks = append(webHooks, admv1.ValidatingWebhook{
 		Name:                    "fleet.clusterresourceplacementv1beta1.validating",
-		ClientConfig:            w.createClientConfig(clusterresourceplacement.ValidationPath),
+		ClientConfig:            w.createClientConfig(options.WebhookRolePlacement, clusterresourceplacement.ValidationPath),
 		FailurePolicy:           &failFailurePolicy,
 		SideEffects:             &sideEffortsNone,
 		AdmissionReviewVersions: admissionReviewVersions,
@@ -356,10 +984,26 @@ func (w *Config) buildFleetValidatingWebhooks() []admv1.ValidatingWebhook {
 		TimeoutSeconds: longWebhookTimeout,
 	})
 
+	// The CRP spec is archived on a best effort basis, the deletion is never blocked on it.
+	webHooks = append(webHooks, admv1.ValidatingWebhook{
+		Name:                    "fleet.clusterresourceplacementv1beta1archiving.validating",
+		ClientConfig:            w.createClientConfig(options.WebhookRolePlacement, clusterresourceplacement.ArchivingValidationPath),
+		FailurePolicy:           &ignoreFailurePolicy,
+		SideEffects:             &sideEffectsDryRun,
+		AdmissionReviewVersions: admissionReviewVersions,
+		Rules: []admv1.RuleWithOperations{
+			{
+				Operations: []admv1.OperationType{admv1.Delete},
+				Rule:       createRule([]string{placementv1beta1.GroupVersion.Group}, []string{placementv1beta1.GroupVersion.Version}, []string{placementv1beta1.ClusterResourcePlacementResource}, &clusterScope),
+			},
+		},
+		TimeoutSeconds: longWebhookTimeout,
+	})
+
 	webHooks = append(webHooks,
 		admv1.ValidatingWebhook{
 			Name:                    "fleet.membercluster.validating",
-			ClientConfig:            w.createClientConfig(membercluster.ValidationPath),
+			ClientConfig:            w.createClientConfig(options.WebhookRolePlacement, membercluster.ValidationPath),
 			FailurePolicy:           &failFailurePolicy,
 			SideEffects:             &sideEffortsNone,
 			AdmissionReviewVersions: admissionReviewVersions,
@@ -371,7 +1015,7 @@ func (w *Config) buildFleetValidatingWebhooks() []admv1.ValidatingWebhook {
 		},
 		admv1.ValidatingWebhook{
 			Name:                    "fleet.clusterresourceoverride.validating",
-			ClientConfig:            w.createClientConfig(clusterresourceoverride.ValidationPath),
+			ClientConfig:            w.createClientConfig(options.WebhookRolePlacement, clusterresourceoverride.ValidationPath),
 			FailurePolicy:           &failFailurePolicy,
 			SideEffects:             &sideEffortsNone,
 			AdmissionReviewVersions: admissionReviewVersions,
@@ -383,7 +1027,7 @@ func (w *Config) buildFleetValidatingWebhooks() []admv1.ValidatingWebhook {
 		},
 		admv1.ValidatingWebhook{
 			Name:                    "fleet.resourceoverride.validating",
-			ClientConfig:            w.createClientConfig(resourceoverride.ValidationPath),
+			ClientConfig:            w.createClientConfig(options.WebhookRolePlacement, resourceoverride.ValidationPath),
 			FailurePolicy:           &failFailurePolicy,
 			SideEffects:             &sideEffortsNone,
 			AdmissionReviewVersions: admissionReviewVersions,
@@ -395,19 +1039,19 @@ func (w *Config) buildFleetValidatingWebhooks() []admv1.ValidatingWebhook {
 		},
 		admv1.ValidatingWebhook{
 			Name:                    "fleet.clusterresourceplacementeviction.validating",
-			ClientConfig:            w.createClientConfig(clusterresourceplacementeviction.ValidationPath),
+			ClientConfig:            w.createClientConfig(options.WebhookRolePlacement, clusterresourceplacementeviction.ValidationPath),
 			FailurePolicy:           &failFailurePolicy,
 			SideEffects:             &sideEffortsNone,
 			AdmissionReviewVersions: admissionReviewVersions,
 			Rules: []admv1.RuleWithOperations{{
-				Operations: []admv1.OperationType{admv1.Create},
+				Operations: []admv1.OperationType{admv1.Create, admv1.Update},
 				Rule:       createRule([]string{placementv1beta1.GroupVersion.Group}, []string{placementv1beta1.GroupVersion.Version}, []string{evictionName}, &clusterScope),
 			}},
 			TimeoutSeconds: longWebhookTimeout,
 		},
 		admv1.ValidatingWebhook{
 			Name:                    "fleet.clusterresourceplacementdisruptionbudget.validating",
-			ClientConfig:            w.createClientConfig(clusterresourceplacementdisruptionbudget.ValidationPath),
+			ClientConfig:            w.createClientConfig(options.WebhookRolePlacement, clusterresourceplacementdisruptionbudget.ValidationPath),
 			FailurePolicy:           &failFailurePolicy,
 			SideEffects:             &sideEffortsNone,
 			AdmissionReviewVersions: admissionReviewVersions,
@@ -417,13 +1061,28 @@ func (w *Config) buildFleetValidatingWebhooks() []admv1.ValidatingWebhook {
 			}},
 			TimeoutSeconds: longWebhookTimeout,
 		},
+		admv1.ValidatingWebhook{
+			Name:                    "fleet.work.validating",
+			ClientConfig:            w.createClientConfig(options.WebhookRolePlacement, work.ValidationPath),
+			FailurePolicy:           &ignoreFailurePolicy,
+			SideEffects:             &sideEffortsNone,
+			AdmissionReviewVersions: admissionReviewVersions,
+			Rules: []admv1.RuleWithOperations{{
+				Operations: []admv1.OperationType{admv1.Create, admv1.Update},
+				Rule:       createRule([]string{placementv1beta1.GroupVersion.Group}, []string{placementv1beta1.GroupVersion.Version}, []string{workResourceName}, &namespacedScope),
+			}},
+			TimeoutSeconds: longWebhookTimeout,
+		},
 	)
 
-	return webHooks
+	return w.suffixValidatingWebhookNames(w.applyValidatingMatchConditions(webHooks))
 }
 
-// buildFleetGuardRailValidatingWebhooks returns a slice of fleet guard rail validating webhook objects.
-func (w *Config) buildFleetGuardRailValidatingWebhooks() []admv1.ValidatingWebhook {
+// newFleetGuardRailValidatingWebhooks builds a fresh slice of fleet guard rail validating webhook objects.
+func (w *Config) newFleetGuardRailValidatingWebhooks() []admv1.ValidatingWebhook {
+	if !w.role.Serves(options.WebhookRoleGuardRail) {
+		return nil
+	}
 	// MatchLabels/MatchExpressions values are ANDed to select resources.
 	fleetMemberNamespaceSelector := &metav1.LabelSelector{
 		MatchExpressions: []metav1.LabelSelectorRequirement{
@@ -457,6 +1116,9 @@ func (w *Config) buildFleetGuardRailValidatingWebhooks() []admv1.ValidatingWebho
 		admv1.Update,
 		admv1.Delete,
 	}
+	fleetManagedObjectSelector := &metav1.LabelSelector{
+		MatchLabels: map[string]string{utils.LabelFleetObj: utils.LabelFleetObjValue},
+	}
 	cuOperations := []admv1.OperationType{
 		admv1.Create,
 		admv1.Update,
@@ -547,7 +1209,7 @@ func (w *Config) buildFleetGuardRailValidatingWebhooks() []admv1.ValidatingWebho
 	guardRailWebhookConfigurations := []admv1.ValidatingWebhook{
 		{
 			Name:                    "fleet.customresourcedefinition.guardrail.validating",
-			ClientConfig:            w.createClientConfig(fleetresourcehandler.ValidationPath),
+			ClientConfig:            w.createClientConfig(options.WebhookRoleGuardRail, fleetresourcehandler.ValidationPath),
 			FailurePolicy:           &ignoreFailurePolicy,
 			SideEffects:             &sideEffortsNone,
 			AdmissionReviewVersions: admissionReviewVersions,
@@ -561,7 +1223,7 @@ func (w *Config) buildFleetGuardRailValidatingWebhooks() []admv1.ValidatingWebho
 		},
 		{
 			Name:                    "fleet.membercluster.guardrail.validating",
-			ClientConfig:            w.createClientConfig(fleetresourcehandler.ValidationPath),
+			ClientConfig:            w.createClientConfig(options.WebhookRoleGuardRail, fleetresourcehandler.ValidationPath),
 			FailurePolicy:           &ignoreFailurePolicy,
 			SideEffects:             &sideEffortsNone,
 			AdmissionReviewVersions: admissionReviewVersions,
@@ -573,9 +1235,54 @@ func (w *Config) buildFleetGuardRailValidatingWebhooks() []admv1.ValidatingWebho
 			},
 			TimeoutSeconds: shortWebhookTimeout,
 		},
+		{
+			Name:                    "fleet.snapshot.guardrail.validating",
+			ClientConfig:            w.createClientConfig(options.WebhookRoleGuardRail, fleetresourcehandler.ValidationPath),
+			FailurePolicy:           &ignoreFailurePolicy,
+			SideEffects:             &sideEffortsNone,
+			AdmissionReviewVersions: admissionReviewVersions,
+			// Only the snapshots themselves are matched; the writes to their status subresources are left untouched.
+			Rules: []admv1.RuleWithOperations{
+				{
+					Operations: cudOperations,
+					Rule:       createRule([]string{placementv1beta1.GroupVersion.Group}, []string{placementv1beta1.GroupVersion.Version}, []string{clusterResourceSnapshotResourceName, clusterPolicySnapshotResourceName}, &clusterScope),
+				},
+			},
+			TimeoutSeconds: shortWebhookTimeout,
+		},
+		{
+			Name:                    "fleet.clusterresourceplacement.guardrail.validating",
+			ClientConfig:            w.createClientConfig(options.WebhookRoleGuardRail, fleetresourcehandler.ValidationPath),
+			FailurePolicy:           &ignoreFailurePolicy,
+			SideEffects:             &sideEffortsNone,
+			AdmissionReviewVersions: admissionReviewVersions,
+			// Only the updates are matched as they are the only way to remove the fleet finalizers.
+			Rules: []admv1.RuleWithOperations{
+				{
+					Operations: []admv1.OperationType{admv1.Update},
+					Rule:       createRule([]string{placementv1beta1.GroupVersion.Group}, []string{placementv1beta1.GroupVersion.Version}, []string{clusterResourcePlacementResourceName}, &clusterScope),
+				},
+			},
+			TimeoutSeconds: shortWebhookTimeout,
+		},
+		{
+			Name:                    "fleet.resourceplacement.guardrail.validating",
+			ClientConfig:            w.createClientConfig(options.WebhookRoleGuardRail, fleetresourcehandler.ValidationPath),
+			FailurePolicy:           &ignoreFailurePolicy,
+			SideEffects:             &sideEffortsNone,
+			AdmissionReviewVersions: admissionReviewVersions,
+			// The namespace admins may only place the resources of their own namespaces.
+			Rules: []admv1.RuleWithOperations{
+				{
+					Operations: cuOperations,
+					Rule:       createRule([]string{placementv1beta1.GroupVersion.Group}, []string{placementv1beta1.GroupVersion.Version}, []string{placementv1beta1.ResourcePlacementResource}, &namespacedScope),
+				},
+			},
+			TimeoutSeconds: shortWebhookTimeout,
+		},
 		{
 			Name:                    "fleet.fleetmembernamespacedresources.guardrail.validating",
-			ClientConfig:            w.createClientConfig(fleetresourcehandler.ValidationPath),
+			ClientConfig:            w.createClientConfig(options.WebhookRoleGuardRail, fleetresourcehandler.ValidationPath),
 			FailurePolicy:           &ignoreFailurePolicy,
 			SideEffects:             &sideEffortsNone,
 			AdmissionReviewVersions: admissionReviewVersions,
@@ -583,9 +1290,25 @@ func (w *Config) buildFleetGuardRailValidatingWebhooks() []admv1.ValidatingWebho
 			Rules:                   namespacedResourcesRules,
 			TimeoutSeconds:          shortWebhookTimeout,
 		},
+		{
+			Name:                    "fleet.fleetmanagedrbac.guardrail.validating",
+			ClientConfig:            w.createClientConfig(options.WebhookRoleGuardRail, fleetresourcehandler.ValidationPath),
+			FailurePolicy:           &ignoreFailurePolicy,
+			SideEffects:             &sideEffortsNone,
+			AdmissionReviewVersions: admissionReviewVersions,
+			NamespaceSelector:       fleetMemberNamespaceSelector,
+			ObjectSelector:          fleetManagedObjectSelector,
+			Rules: []admv1.RuleWithOperations{
+				{
+					Operations: cudOperations,
+					Rule:       createRule([]string{rbacv1.SchemeGroupVersion.Group}, []string{rbacv1.SchemeGroupVersion.Version}, []string{roleResourceName, roleBindingResourceName}, &namespacedScope),
+				},
+			},
+			TimeoutSeconds: shortWebhookTimeout,
+		},
 		{
 			Name:                    "fleet.fleetsystemnamespacedresources.guardrail.validating",
-			ClientConfig:            w.createClientConfig(fleetresourcehandler.ValidationPath),
+			ClientConfig:            w.createClientConfig(options.WebhookRoleGuardRail, fleetresourcehandler.ValidationPath),
 			FailurePolicy:           &ignoreFailurePolicy,
 			SideEffects:             &sideEffortsNone,
 			AdmissionReviewVersions: admissionReviewVersions,
@@ -595,7 +1318,7 @@ func (w *Config) buildFleetGuardRailValidatingWebhooks() []admv1.ValidatingWebho
 		},
 		{
 			Name:                    "fleet.kubenamespacedresources.guardrail.validating",
-			ClientConfig:            w.createClientConfig(fleetresourcehandler.ValidationPath),
+			ClientConfig:            w.createClientConfig(options.WebhookRoleGuardRail, fleetresourcehandler.ValidationPath),
 			FailurePolicy:           &ignoreFailurePolicy,
 			SideEffects:             &sideEffortsNone,
 			AdmissionReviewVersions: admissionReviewVersions,
@@ -605,7 +1328,7 @@ func (w *Config) buildFleetGuardRailValidatingWebhooks() []admv1.ValidatingWebho
 		},
 		{
 			Name:                    "fleet.namespace.guardrail.validating",
-			ClientConfig:            w.createClientConfig(fleetresourcehandler.ValidationPath),
+			ClientConfig:            w.createClientConfig(options.WebhookRoleGuardRail, fleetresourcehandler.ValidationPath),
 			FailurePolicy:           &ignoreFailurePolicy,
 			SideEffects:             &sideEffortsNone,
 			AdmissionReviewVersions: admissionReviewVersions,
@@ -619,22 +1342,34 @@ func (w *Config) buildFleetGuardRailValidatingWebhooks() []admv1.ValidatingWebho
 		},
 	}
 
-	return guardRailWebhookConfigurations
+	guardRailWebhookConfigurations = w.applyValidatingMatchConditions(guardRailWebhookConfigurations)
+	if w.webhookCertSecretName != "" {
+		guardRailWebhookConfigurations = append(guardRailWebhookConfigurations, w.newWebhookCertSecretGuardRailWebhook())
+	}
+	return w.suffixValidatingWebhookNames(guardRailWebhookConfigurations)
 }
 
-// createClientConfig generates the client configuration with either service ref or URL for the argued interface.
-func (w *Config) createClientConfig(validationPath string) admv1.WebhookClientConfig {
+// createClientConfig generates the client configuration with either service ref or URL for the argued interface,
+// pointing at the service which serves the argued webhook group. The URL is used if the connection type is not set.
+func (w *Config) createClientConfig(group options.WebhookRole, validationPath string) admv1.WebhookClientConfig {
+	if !slices.Contains(utils.AllWebhookPaths(), validationPath) {
+		klog.ErrorS(nil, "Webhook path is not registered, no handler may serve the webhook", "path", validationPath)
+	}
+	serviceName, serviceURL := w.serviceName, w.serviceURL
+	if name, ok := w.serviceNames[group]; ok && name != w.serviceName {
+		serviceName, serviceURL = name, buildServiceURL(name, w.serviceNamespace, w.servicePort)
+	}
 	serviceRef := admv1.ServiceReference{
 		Namespace: w.serviceNamespace,
-		Name:      w.serviceName,
+		Name:      serviceName,
 		Port:      ptr.To(w.servicePort),
 	}
-	serviceEndpoint := w.serviceURL + validationPath
+	serviceEndpoint := serviceURL + validationPath
 	serviceRef.Path = ptr.To(validationPath)
 	config := admv1.WebhookClientConfig{
 		CABundle: w.caPEM,
 	}
-	switch *w.clientConnectionType {
+	switch ptr.Deref(w.clientConnectionType, options.URL) {
 	case options.Service:
 		config.Service = &serviceRef
 	case options.URL:
@@ -643,6 +1378,11 @@ func (w *Config) createClientConfig(validationPath string) admv1.WebhookClientCo
 	return config
 }
 
+// buildServiceURL returns the in-cluster URL of the webhook service.
+func buildServiceURL(serviceName, namespace string, port int32) string {
+	return fmt.Sprintf("https://%s.%s.svc.cluster.local:%d", serviceName, namespace, port)
+}
+
 // genCertificate generates the serving cerficiate for the webhook server.
 func (w *Config) genCertificate(certDir string) ([]byte, error) {
 	caPEM, certPEM, keyPEM, err := w.genSelfSignedCert()
@@ -706,6 +1446,18 @@ func (w *Config) genSelfSignedCert() (caPEMByte, certPEMByte, keyPEMByte []byte,
 		fmt.Sprintf("%s.%s.svc", w.serviceName, w.serviceNamespace),
 		fmt.Sprintf("%s.%s.svc.cluster.local", w.serviceName, w.serviceNamespace),
 	}
+	// The certificate must also be valid for the services of all the webhook groups this hub agent serves.
+	for _, group := range options.WebhookGroups {
+		name, ok := w.serviceNames[group]
+		if !ok || name == w.serviceName || !w.role.Serves(group) {
+			continue
+		}
+		dnsName := fmt.Sprintf("%s.%s.svc", name, w.serviceNamespace)
+		if slices.Contains(dnsNames, dnsName) {
+			continue
+		}
+		dnsNames = append(dnsNames, dnsName, fmt.Sprintf("%s.%s.svc.cluster.local", name, w.serviceNamespace))
+	}
 	// server cert config
 	cert := &x509.Certificate{
 		DNSNames:     dnsNames,
@@ -798,22 +1550,22 @@ func genCertAndKeyFile(certData, keyData []byte, certDir string) error {
 	return nil
 }
 
-// bindWebhookConfigToFleetSystem sets the OwnerReference of the argued ValidatingWebhookConfiguration to the cluster scoped fleet-system namespace.
-func bindWebhookConfigToFleetSystem(ctx context.Context, k8Client client.Client, validatingWebhookConfig *admv1.ValidatingWebhookConfiguration) error {
+// bindWebhookConfigToFleetSystem sets the OwnerReference of the argued cluster scoped object to the cluster scoped fleet-system namespace.
+func bindWebhookConfigToFleetSystem(ctx context.Context, k8Client client.Client, obj client.Object) error {
 	var fleetNs corev1.Namespace
 	if err := k8Client.Get(ctx, client.ObjectKey{Name: "fleet-system"}, &fleetNs); err != nil {
 		return err
 	}
 
 	ownerRef := metav1.OwnerReference{
-		APIVersion:         fleetNs.GroupVersionKind().GroupVersion().String(),
-		Kind:               fleetNs.Kind,
+		APIVersion:         corev1.SchemeGroupVersion.String(),
+		Kind:               "Namespace",
 		Name:               fleetNs.GetName(),
 		UID:                fleetNs.GetUID(),
 		BlockOwnerDeletion: ptr.To(false),
 	}
 
-	validatingWebhookConfig.OwnerReferences = []metav1.OwnerReference{ownerRef}
+	obj.SetOwnerReferences([]metav1.OwnerReference{ownerRef})
 	return nil
 }
 
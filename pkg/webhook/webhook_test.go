@@ -1,15 +1,48 @@
 package webhook
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	admv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
 	"github.com/kubefleet-dev/kubefleet/cmd/hubagent/options"
+	hubmetrics "github.com/kubefleet-dev/kubefleet/pkg/metrics/hub"
 	"github.com/kubefleet-dev/kubefleet/pkg/utils"
+	"github.com/kubefleet-dev/kubefleet/pkg/webhook/clusterresourceplacement"
+	"github.com/kubefleet-dev/kubefleet/pkg/webhook/fleetresourcehandler"
+	webhooktesting "github.com/kubefleet-dev/kubefleet/pkg/webhook/testing"
 )
 
 func TestBuildFleetMutatingWebhooks(t *testing.T) {
@@ -25,7 +58,37 @@ func TestBuildFleetMutatingWebhooks(t *testing.T) {
 				serviceURL:           "test-url",
 				clientConnectionType: &url,
 			},
-			wantLength: 1,
+			wantLength: 5,
+		},
+		"placement role": {
+			config: Config{
+				serviceNamespace:     "test-namespace",
+				servicePort:          8080,
+				serviceURL:           "test-url",
+				clientConnectionType: &url,
+				role:                 options.WebhookRolePlacement,
+			},
+			wantLength: 5,
+		},
+		"guard rail role": {
+			config: Config{
+				serviceNamespace:     "test-namespace",
+				servicePort:          8080,
+				serviceURL:           "test-url",
+				clientConnectionType: &url,
+				role:                 options.WebhookRoleGuardRail,
+			},
+			wantLength: 0,
+		},
+		"workload role": {
+			config: Config{
+				serviceNamespace:     "test-namespace",
+				servicePort:          8080,
+				serviceURL:           "test-url",
+				clientConnectionType: &url,
+				role:                 options.WebhookRoleWorkload,
+			},
+			wantLength: 0,
 		},
 	}
 
@@ -52,7 +115,15 @@ func TestBuildFleetValidatingWebhooks(t *testing.T) {
 				serviceURL:           "test-url",
 				clientConnectionType: &url,
 			},
-			wantLength: 8,
+			wantLength: 10,
+		},
+		"nil client connection type": {
+			config: Config{
+				serviceNamespace: "test-namespace",
+				servicePort:      8080,
+				serviceURL:       "test-url",
+			},
+			wantLength: 10,
 		},
 		"enable workload": {
 			config: Config{
@@ -62,7 +133,58 @@ func TestBuildFleetValidatingWebhooks(t *testing.T) {
 				clientConnectionType: &url,
 				enableWorkload:       true,
 			},
-			wantLength: 6,
+			wantLength: 8,
+		},
+		"all role": {
+			config: Config{
+				serviceNamespace:     "test-namespace",
+				servicePort:          8080,
+				serviceURL:           "test-url",
+				clientConnectionType: &url,
+				role:                 options.WebhookRoleAll,
+			},
+			wantLength: 10,
+		},
+		"placement role": {
+			config: Config{
+				serviceNamespace:     "test-namespace",
+				servicePort:          8080,
+				serviceURL:           "test-url",
+				clientConnectionType: &url,
+				role:                 options.WebhookRolePlacement,
+			},
+			wantLength: 8,
+		},
+		"guard rail role": {
+			config: Config{
+				serviceNamespace:     "test-namespace",
+				servicePort:          8080,
+				serviceURL:           "test-url",
+				clientConnectionType: &url,
+				role:                 options.WebhookRoleGuardRail,
+			},
+			wantLength: 0,
+		},
+		"workload role": {
+			config: Config{
+				serviceNamespace:     "test-namespace",
+				servicePort:          8080,
+				serviceURL:           "test-url",
+				clientConnectionType: &url,
+				role:                 options.WebhookRoleWorkload,
+			},
+			wantLength: 2,
+		},
+		"workload role, enable workload": {
+			config: Config{
+				serviceNamespace:     "test-namespace",
+				servicePort:          8080,
+				serviceURL:           "test-url",
+				clientConnectionType: &url,
+				role:                 options.WebhookRoleWorkload,
+				enableWorkload:       true,
+			},
+			wantLength: 0,
 		},
 	}
 
@@ -74,6 +196,56 @@ func TestBuildFleetValidatingWebhooks(t *testing.T) {
 	}
 }
 
+// TestBuildFleetValidatingWebhooksWithoutConnectionType verifies that the webhooks are reached through the service
+// URL if the client connection type is not set.
+func TestBuildFleetValidatingWebhooksWithoutConnectionType(t *testing.T) {
+	config := &Config{
+		serviceNamespace: "test-namespace",
+		servicePort:      8080,
+		serviceURL:       "test-url",
+	}
+	webhooks := config.buildFleetValidatingWebhooks()
+	if len(webhooks) == 0 {
+		t.Fatalf("buildFleetValidatingWebhooks() returned no webhooks")
+	}
+	for _, wh := range webhooks {
+		if wh.ClientConfig.URL == nil || !strings.HasPrefix(*wh.ClientConfig.URL, "test-url/") || wh.ClientConfig.Service != nil {
+			t.Errorf("buildFleetValidatingWebhooks() webhook %s client config = %+v, want the service URL", wh.Name, wh.ClientConfig)
+		}
+	}
+}
+
+// TestBuildFleetValidatingWebhooksOrder verifies that the validating webhooks are always built in the same order, as
+// a different order would make every hub agent replica rewrite the ValidatingWebhookConfiguration.
+func TestBuildFleetValidatingWebhooksOrder(t *testing.T) {
+	url := options.WebhookClientConnectionType("url")
+	webhookNames := func() []string {
+		// A fresh config is used every time so that the webhooks are not served from the cache.
+		config := &Config{
+			serviceNamespace:     "test-namespace",
+			servicePort:          8080,
+			serviceURL:           "test-url",
+			clientConnectionType: &url,
+			role:                 options.WebhookRoleAll,
+		}
+		var names []string
+		for _, wh := range config.buildFleetValidatingWebhooks() {
+			names = append(names, wh.Name)
+		}
+		return names
+	}
+
+	want := webhookNames()
+	if len(want) == 0 {
+		t.Fatalf("buildFleetValidatingWebhooks() returned no webhooks")
+	}
+	for i := 0; i < 100; i++ {
+		if diff := cmp.Diff(want, webhookNames()); diff != "" {
+			t.Fatalf("buildFleetValidatingWebhooks() order mismatch on call %d (-want, +got):\n%s", i, diff)
+		}
+	}
+}
+
 func TestBuildFleetGuardRailValidatingWebhooks(t *testing.T) {
 	url := options.WebhookClientConnectionType("url")
 	testCases := map[string]struct {
@@ -87,7 +259,37 @@ func TestBuildFleetGuardRailValidatingWebhooks(t *testing.T) {
 				serviceURL:           "test-url",
 				clientConnectionType: &url,
 			},
-			wantLength: 6,
+			wantLength: 10,
+		},
+		"placement role": {
+			config: Config{
+				serviceNamespace:     "test-namespace",
+				servicePort:          8080,
+				serviceURL:           "test-url",
+				clientConnectionType: &url,
+				role:                 options.WebhookRolePlacement,
+			},
+			wantLength: 0,
+		},
+		"guard rail role": {
+			config: Config{
+				serviceNamespace:     "test-namespace",
+				servicePort:          8080,
+				serviceURL:           "test-url",
+				clientConnectionType: &url,
+				role:                 options.WebhookRoleGuardRail,
+			},
+			wantLength: 10,
+		},
+		"workload role": {
+			config: Config{
+				serviceNamespace:     "test-namespace",
+				servicePort:          8080,
+				serviceURL:           "test-url",
+				clientConnectionType: &url,
+				role:                 options.WebhookRoleWorkload,
+			},
+			wantLength: 0,
 		},
 	}
 
@@ -99,30 +301,77 @@ func TestBuildFleetGuardRailValidatingWebhooks(t *testing.T) {
 	}
 }
 
+func TestBuildFleetGuardRailValidatingWebhooksFleetManagedRBAC(t *testing.T) {
+	url := options.WebhookClientConnectionType("url")
+	w := &Config{
+		serviceNamespace:     "test-namespace",
+		servicePort:          8080,
+		serviceURL:           "test-url",
+		clientConnectionType: &url,
+	}
+	webhooks := w.buildFleetGuardRailValidatingWebhooks()
+	var got *admv1.ValidatingWebhook
+	for i := range webhooks {
+		if webhooks[i].Name == "fleet.fleetmanagedrbac.guardrail.validating" {
+			got = &webhooks[i]
+		}
+	}
+	if got == nil {
+		t.Fatalf("buildFleetGuardRailValidatingWebhooks() has no fleet-managed RBAC webhook")
+	}
+	wantNamespaceSelector := &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{
+				Key:      placementv1beta1.FleetResourceLabelKey,
+				Operator: metav1.LabelSelectorOpIn,
+				Values:   []string{"true"},
+			},
+		},
+	}
+	if diff := cmp.Diff(wantNamespaceSelector, got.NamespaceSelector); diff != "" {
+		t.Errorf("fleet-managed RBAC webhook namespaceSelector mismatch (-want, +got):\n%s", diff)
+	}
+	wantObjectSelector := &metav1.LabelSelector{MatchLabels: map[string]string{utils.LabelFleetObj: utils.LabelFleetObjValue}}
+	if diff := cmp.Diff(wantObjectSelector, got.ObjectSelector); diff != "" {
+		t.Errorf("fleet-managed RBAC webhook objectSelector mismatch (-want, +got):\n%s", diff)
+	}
+	wantRules := []admv1.RuleWithOperations{
+		{
+			Operations: []admv1.OperationType{admv1.Create, admv1.Update, admv1.Delete},
+			Rule:       createRule([]string{rbacv1.SchemeGroupVersion.Group}, []string{rbacv1.SchemeGroupVersion.Version}, []string{roleResourceName, roleBindingResourceName}, &namespacedScope),
+		},
+	}
+	if diff := cmp.Diff(wantRules, got.Rules); diff != "" {
+		t.Errorf("fleet-managed RBAC webhook rules mismatch (-want, +got):\n%s", diff)
+	}
+	if want := "test-url" + fleetresourcehandler.ValidationPath; *got.ClientConfig.URL != want {
+		t.Errorf("fleet-managed RBAC webhook URL = %s, want %s", *got.ClientConfig.URL, want)
+	}
+}
+
 func TestNewWebhookConfig(t *testing.T) {
 	tests := []struct {
-		name                          string
-		mgr                           manager.Manager
-		webhookServiceName            string
-		port                          int32
-		clientConnectionType          *options.WebhookClientConnectionType
-		certDir                       string
-		enableGuardRail               bool
-		denyModifyMemberClusterLabels bool
-		enableWorkload                bool
-		want                          *Config
-		wantErr                       bool
+		name    string
+		mgr     manager.Manager
+		opts    Options
+		want    *Config
+		wantErr bool
 	}{
 		{
-			name:                          "valid input",
-			mgr:                           nil,
-			webhookServiceName:            "test-webhook",
-			port:                          8080,
-			clientConnectionType:          nil,
-			certDir:                       "/tmp/cert",
-			enableGuardRail:               true,
-			denyModifyMemberClusterLabels: true,
-			enableWorkload:                false,
+			name: "valid input",
+			mgr:  nil,
+			opts: Options{
+				ServiceName:                   "test-webhook",
+				ServicePort:                   8080,
+				TargetPort:                    8080,
+				ClientConnectionType:          nil,
+				CertDir:                       "/tmp/cert",
+				Role:                          options.WebhookRoleAll,
+				EnableGuardRail:               true,
+				DenyModifyMemberClusterLabels: true,
+				EnableWorkload:                false,
+				TrustedServiceAccounts:        []string{"system:serviceaccount:fleet-system:hub-agent-sa"},
+			},
 			want: &Config{
 				serviceNamespace:              "test-namespace",
 				serviceName:                   "test-webhook",
@@ -134,12 +383,37 @@ func TestNewWebhookConfig(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:    "service port out of range",
+			opts:    Options{ServiceName: "test-webhook", ServicePort: 0, TargetPort: 9443, CertDir: "/tmp/cert"},
+			wantErr: true,
+		},
+		{
+			name:    "target port out of range",
+			opts:    Options{ServiceName: "test-webhook", ServicePort: 443, TargetPort: -1, CertDir: "/tmp/cert"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid webhook name suffix",
+			opts:    Options{ServiceName: "test-webhook", ServicePort: 443, TargetPort: 9443, CertDir: "/tmp/cert", WebhookNameSuffix: "Dev"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid placement number of clusters validation mode",
+			opts:    Options{ServiceName: "test-webhook", ServicePort: 443, TargetPort: 9443, CertDir: "/tmp/cert", PlacementNumberOfClustersValidation: "deny"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid placement PickAll fleet size validation mode",
+			opts:    Options{ServiceName: "test-webhook", ServicePort: 443, TargetPort: 9443, CertDir: "/tmp/cert", PlacementPickAllFleetSizeValidation: "deny"},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Setenv("POD_NAMESPACE", "test-namespace")
 			defer t.Setenv("POD_NAMESPACE", "")
-			got, err := NewWebhookConfig(tt.mgr, tt.webhookServiceName, tt.port, tt.clientConnectionType, tt.certDir, tt.enableGuardRail, tt.denyModifyMemberClusterLabels, tt.enableWorkload)
+			got, err := NewWebhookConfig(tt.mgr, tt.opts)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("NewWebhookConfig() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -158,6 +432,533 @@ func TestNewWebhookConfig(t *testing.T) {
 			if diff := cmp.Diff(tt.want, got, opts...); diff != "" {
 				t.Errorf("NewWebhookConfig() mismatch (-want +got):\n%s", diff)
 			}
+			if diff := cmp.Diff(tt.opts.TrustedServiceAccounts, got.validatorConfigs.Load().TrustedServiceAccounts); diff != "" {
+				t.Errorf("NewWebhookConfig() validator trusted service accounts mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewWebhookConfigGuardRailDelegation(t *testing.T) {
+	testCases := map[string]struct {
+		delegation   *fleetresourcehandler.DelegationConfig
+		wantErr      bool
+		wantDelegate bool
+	}{
+		"no delegation": {},
+		"invalid delegation": {
+			delegation: &fleetresourcehandler.DelegationConfig{URL: "http://authorizer.example.com"},
+			wantErr:    true,
+		},
+		"delegated namespaces": {
+			delegation: &fleetresourcehandler.DelegationConfig{
+				URL:           "https://authorizer.example.com",
+				Timeout:       time.Second,
+				Kinds:         []schema.GroupKind{{Kind: "Namespace"}},
+				FailurePolicy: fleetresourcehandler.DelegationFallbackLocal,
+			},
+			wantDelegate: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("POD_NAMESPACE", "fleet-system")
+			w, err := NewWebhookConfig(nil, Options{
+				ServiceName:         "fleetwebhook",
+				ServicePort:         443,
+				TargetPort:          9443,
+				CertDir:             t.TempDir(),
+				Role:                options.WebhookRoleAll,
+				GuardRailDelegation: tc.delegation,
+			})
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("NewWebhookConfig() = %v, want error %t", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if got := w.guardRailDelegation != nil && w.guardRailDelegation.Delegates(schema.GroupKind{Kind: "Namespace"}); got != tc.wantDelegate {
+				t.Errorf("NewWebhookConfig() delegates namespaces = %t, want %t", got, tc.wantDelegate)
+			}
+		})
+	}
+}
+
+func TestNewWebhookConfigPorts(t *testing.T) {
+	url := options.URL
+	service := options.Service
+	testCases := map[string]struct {
+		connectionType *options.WebhookClientConnectionType
+		wantURL        string
+		wantPort       *int32
+	}{
+		"url connection points at the service port": {
+			connectionType: &url,
+			wantURL:        "https://fleetwebhook.fleet-system.svc.cluster.local:443" + clusterresourceplacement.ValidationPath,
+		},
+		"service connection points at the service port": {
+			connectionType: &service,
+			wantPort:       ptr.To(int32(443)),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("POD_NAMESPACE", "fleet-system")
+			w, err := NewWebhookConfig(nil, Options{
+				ServiceName:          "fleetwebhook",
+				ServicePort:          443,
+				TargetPort:           9443,
+				ClientConnectionType: tc.connectionType,
+				CertDir:              t.TempDir(),
+				Role:                 options.WebhookRoleAll,
+			})
+			if err != nil {
+				t.Fatalf("NewWebhookConfig() = %v, want no error", err)
+			}
+			if w.servicePort != 443 || w.targetPort != 9443 {
+				t.Errorf("NewWebhookConfig() servicePort/targetPort = %d/%d, want 443/9443", w.servicePort, w.targetPort)
+			}
+			clientConfig := w.createClientConfig(options.WebhookRolePlacement, clusterresourceplacement.ValidationPath)
+			if diff := cmp.Diff(tc.wantURL, ptr.Deref(clientConfig.URL, "")); diff != "" {
+				t.Errorf("createClientConfig() URL mismatch (-want +got):\n%s", diff)
+			}
+			var gotPort *int32
+			if clientConfig.Service != nil {
+				gotPort = clientConfig.Service.Port
+			}
+			if diff := cmp.Diff(tc.wantPort, gotPort); diff != "" {
+				t.Errorf("createClientConfig() service port mismatch (-want +got):\n%s", diff)
+			}
+			if state := w.debugState(); state.ServicePort != 443 || state.TargetPort != 9443 {
+				t.Errorf("debugState() servicePort/targetPort = %d/%d, want 443/9443", state.ServicePort, state.TargetPort)
+			}
+		})
+	}
+}
+
+func TestNewWebhookConfigTLSBootstrap(t *testing.T) {
+	t.Setenv("POD_NAMESPACE", "fleet-system")
+	certDir := filepath.Join(t.TempDir(), "cert")
+	w, err := NewWebhookConfig(nil, Options{
+		ServiceName: "fleetwebhook",
+		ServicePort: 443,
+		TargetPort:  9443,
+		CertDir:     certDir,
+		Role:        options.WebhookRoleAll,
+	})
+	if err != nil {
+		t.Fatalf("NewWebhookConfig() = %v, want no error", err)
+	}
+
+	// The webhook server serves the certificate files generated in the cert dir.
+	serverCert, err := tls.LoadX509KeyPair(filepath.Join(certDir, fleetWebhookCertFileName), filepath.Join(certDir, fleetWebhookKeyFileName))
+	if err != nil {
+		t.Fatalf("LoadX509KeyPair() = %v, want no error", err)
+	}
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		_, _ = rw.Write([]byte("ok"))
+	}))
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{serverCert}, MinVersion: tls.VersionTLS12}
+	server.StartTLS()
+	defer server.Close()
+
+	// The API server trusts only the caBundle of the generated webhook configurations.
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(w.caPEM) {
+		t.Fatalf("AppendCertsFromPEM() = false, want the generated CA to be parsed")
+	}
+	for _, serverName := range []string{"fleetwebhook.fleet-system.svc", "fleetwebhook.fleet-system.svc.cluster.local"} {
+		t.Run(serverName, func(t *testing.T) {
+			httpClient := &http.Client{Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{RootCAs: roots, ServerName: serverName, MinVersion: tls.VersionTLS12},
+			}}
+			resp, err := httpClient.Get(server.URL)
+			if err != nil {
+				t.Fatalf("Get() = %v, want the TLS handshake to succeed", err)
+			}
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("ReadAll() = %v, want no error", err)
+			}
+			if resp.StatusCode != http.StatusOK || string(body) != "ok" {
+				t.Errorf("Get() = %d %q, want 200 \"ok\"", resp.StatusCode, body)
+			}
+		})
+	}
+
+	// The handshake fails for a name the serving certificate is not valid for.
+	httpClient := &http.Client{Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{RootCAs: roots, ServerName: "other.fleet-system.svc", MinVersion: tls.VersionTLS12},
+	}}
+	if resp, err := httpClient.Get(server.URL); err == nil {
+		resp.Body.Close()
+		t.Errorf("Get() with the server name other.fleet-system.svc = nil, want a TLS handshake error")
+	}
+}
+
+func TestNewWebhookConfigServiceNames(t *testing.T) {
+	service := options.Service
+	testCases := map[string]struct {
+		role             options.WebhookRole
+		serviceNames     map[options.WebhookRole]string
+		wantServiceName  string
+		wantServiceNames map[options.WebhookRole]string
+		wantConfigName   string
+	}{
+		"all role defaults every group to the single service": {
+			role:            options.WebhookRoleAll,
+			wantServiceName: "fleetwebhook",
+			wantServiceNames: map[options.WebhookRole]string{
+				options.WebhookRolePlacement: "fleetwebhook",
+				options.WebhookRoleGuardRail: "fleetwebhook",
+				options.WebhookRoleWorkload:  "fleetwebhook",
+			},
+			wantConfigName: fleetValidatingWebhookCfgName,
+		},
+		"guard rail role uses its designated service": {
+			role: options.WebhookRoleGuardRail,
+			serviceNames: map[options.WebhookRole]string{
+				options.WebhookRolePlacement: "fleetwebhook-placement",
+				options.WebhookRoleGuardRail: "fleetwebhook-guardrail",
+			},
+			wantServiceName: "fleetwebhook-guardrail",
+			wantServiceNames: map[options.WebhookRole]string{
+				options.WebhookRolePlacement: "fleetwebhook-placement",
+				options.WebhookRoleGuardRail: "fleetwebhook-guardrail",
+				options.WebhookRoleWorkload:  "fleetwebhook",
+			},
+			wantConfigName: fleetValidatingWebhookCfgName + "-guardrail",
+		},
+		"workload role without a designated service": {
+			role: options.WebhookRoleWorkload,
+			serviceNames: map[options.WebhookRole]string{
+				options.WebhookRolePlacement: "fleetwebhook-placement",
+			},
+			wantServiceName: "fleetwebhook",
+			wantServiceNames: map[options.WebhookRole]string{
+				options.WebhookRolePlacement: "fleetwebhook-placement",
+				options.WebhookRoleGuardRail: "fleetwebhook",
+				options.WebhookRoleWorkload:  "fleetwebhook",
+			},
+			wantConfigName: fleetValidatingWebhookCfgName + "-workload",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("POD_NAMESPACE", "test-namespace")
+			got, err := NewWebhookConfig(nil, Options{
+				ServiceName:          "fleetwebhook",
+				ServicePort:          8080,
+				TargetPort:           8080,
+				ClientConnectionType: &service,
+				CertDir:              t.TempDir(),
+				Role:                 tc.role,
+				ServiceNames:         tc.serviceNames,
+				EnableGuardRail:      true,
+			})
+			if err != nil {
+				t.Fatalf("NewWebhookConfig() = %v, want no error", err)
+			}
+			if got.serviceName != tc.wantServiceName {
+				t.Errorf("NewWebhookConfig() serviceName = %s, want %s", got.serviceName, tc.wantServiceName)
+			}
+			if diff := cmp.Diff(tc.wantServiceNames, got.serviceNames); diff != "" {
+				t.Errorf("NewWebhookConfig() serviceNames mismatch (-want +got):\n%s", diff)
+			}
+			if gotConfigName := got.webhookConfigurationName(fleetValidatingWebhookCfgName); gotConfigName != tc.wantConfigName {
+				t.Errorf("webhookConfigurationName() = %s, want %s", gotConfigName, tc.wantConfigName)
+			}
+			// Every webhook must point at the service designated to its group.
+			for _, webhook := range append(got.buildFleetValidatingWebhooks(), got.buildFleetGuardRailValidatingWebhooks()...) {
+				if webhook.ClientConfig.Service.Name != tc.wantServiceName {
+					t.Errorf("webhook %s service = %s, want %s", webhook.Name, webhook.ClientConfig.Service.Name, tc.wantServiceName)
+				}
+			}
+		})
+	}
+}
+
+// pathRecordingServer is a webhook server which records the registered paths and handlers.
+type pathRecordingServer struct {
+	webhook.Server
+	paths    []string
+	handlers map[string]http.Handler
+}
+
+func (s *pathRecordingServer) Register(path string, hook http.Handler) {
+	s.paths = append(s.paths, path)
+	if s.handlers == nil {
+		s.handlers = make(map[string]http.Handler)
+	}
+	s.handlers[path] = hook
+}
+
+// fakeIndexer is a field indexer which accepts any index.
+type fakeIndexer struct{}
+
+func (fakeIndexer) IndexField(_ context.Context, _ client.Object, _ string, _ client.IndexerFunc) error {
+	return nil
+}
+
+// fakeWebhookManager is a manager which provides only what the webhook handlers need on registration.
+type fakeWebhookManager struct {
+	manager.Manager
+	server *pathRecordingServer
+	client client.Client
+}
+
+func (m *fakeWebhookManager) GetWebhookServer() webhook.Server     { return m.server }
+func (m *fakeWebhookManager) GetScheme() *runtime.Scheme           { return webhooktesting.Scheme }
+func (m *fakeWebhookManager) GetClient() client.Client             { return m.client }
+func (m *fakeWebhookManager) GetAPIReader() client.Reader          { return nil }
+func (m *fakeWebhookManager) GetRESTMapper() meta.RESTMapper       { return nil }
+func (m *fakeWebhookManager) GetFieldIndexer() client.FieldIndexer { return fakeIndexer{} }
+func (m *fakeWebhookManager) GetEventRecorderFor(_ string) record.EventRecorder {
+	return &record.FakeRecorder{}
+}
+
+// registeredPaths returns the handler paths AddToManager registers for the role.
+func registeredPaths(t *testing.T, role options.WebhookRole) []string {
+	t.Helper()
+	mgr := &fakeWebhookManager{server: &pathRecordingServer{}}
+	w := &Config{role: role}
+	if err := w.AddToManager(mgr, nil, nil); err != nil {
+		t.Fatalf("AddToManager(%s) = %v, want no error", role, err)
+	}
+	return mgr.server.paths
+}
+
+// configuredPaths returns the paths the webhooks built for the role point at.
+func configuredPaths(role options.WebhookRole) []string {
+	service := options.Service
+	w := &Config{
+		serviceNamespace:     "test-namespace",
+		serviceName:          "test-webhook",
+		servicePort:          8080,
+		clientConnectionType: &service,
+		role:                 role,
+	}
+	var paths []string
+	for _, webhook := range w.newFleetMutatingWebhooks() {
+		paths = append(paths, *webhook.ClientConfig.Service.Path)
+	}
+	for _, webhook := range w.newFleetValidatingWebhooks() {
+		paths = append(paths, *webhook.ClientConfig.Service.Path)
+	}
+	for _, webhook := range w.newFleetGuardRailValidatingWebhooks() {
+		paths = append(paths, *webhook.ClientConfig.Service.Path)
+	}
+	return paths
+}
+
+func TestWebhookRolesRegisterDisjointPaths(t *testing.T) {
+	roleByPath := make(map[string]options.WebhookRole)
+	var allRolePaths []string
+	for _, role := range options.WebhookGroups {
+		paths := registeredPaths(t, role)
+		if len(paths) == 0 {
+			t.Errorf("AddToManager(%s) registered no handler, want at least one", role)
+		}
+		for _, path := range paths {
+			if otherRole, ok := roleByPath[path]; ok {
+				t.Errorf("path %s is registered by both role %s and role %s", path, otherRole, role)
+			}
+			roleByPath[path] = role
+		}
+		allRolePaths = append(allRolePaths, paths...)
+
+		// Every webhook configured by a role must be served by the handlers of the same role.
+		for _, path := range configuredPaths(role) {
+			if !slices.Contains(paths, path) {
+				t.Errorf("webhook path %s configured by role %s is not registered by the role", path, role)
+			}
+		}
+	}
+
+	// Together the roles must register exactly the handlers of a hub agent serving all the webhooks.
+	sortStrings := cmpopts.SortSlices(func(a, b string) bool { return a < b })
+	if diff := cmp.Diff(registeredPaths(t, options.WebhookRoleAll), allRolePaths, sortStrings); diff != "" {
+		t.Errorf("registered paths of all roles mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// TestWebhookRolesApplyTheirOwnConfigurations verifies that the hub agents serving each webhook role apply the
+// configurations of their role without leader election and without overwriting the configurations of one another.
+func TestWebhookRolesApplyTheirOwnConfigurations(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := newCleanupTestClient(t)
+	want := map[string]string{}
+	for _, role := range options.WebhookGroups {
+		w := newHashTestConfig()
+		w.role = role
+		w.enableWorkload = true
+		w.mgr = &fakeWebhookManager{client: fakeClient}
+		if w.NeedLeaderElection() {
+			t.Errorf("NeedLeaderElection() of role %s = true, want false", role)
+		}
+		for name, hash := range desiredHashes(t, w) {
+			want[name] = hash
+		}
+		if err := w.Start(ctx); err != nil {
+			t.Fatalf("Start() of role %s = %v, want no error", role, err)
+		}
+	}
+
+	got := map[string]string{}
+	mutatingList := &admv1.MutatingWebhookConfigurationList{}
+	if err := fakeClient.List(ctx, mutatingList); err != nil {
+		t.Fatalf("List() = %v, want no error", err)
+	}
+	for _, obj := range mutatingList.Items {
+		got[obj.Name] = obj.Annotations[WebhookConfigurationHashAnnotation]
+	}
+	validatingList := &admv1.ValidatingWebhookConfigurationList{}
+	if err := fakeClient.List(ctx, validatingList); err != nil {
+		t.Fatalf("List() = %v, want no error", err)
+	}
+	for _, obj := range validatingList.Items {
+		got[obj.Name] = obj.Annotations[WebhookConfigurationHashAnnotation]
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("applied webhook configuration hashes mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// TestWebhookPathsMatchRegistry verifies that the handlers are registered at exactly the paths in the webhook path
+// registry and that the webhooks are only configured at the registered paths.
+func TestWebhookPathsMatchRegistry(t *testing.T) {
+	want := utils.AllWebhookPaths()
+	sortStrings := cmpopts.SortSlices(func(a, b string) bool { return a < b })
+	if diff := cmp.Diff(want, registeredPaths(t, options.WebhookRoleAll), sortStrings); diff != "" {
+		t.Errorf("registered handler paths mismatch (-want +got):\n%s", diff)
+	}
+	for _, path := range configuredPaths(options.WebhookRoleAll) {
+		if !slices.Contains(want, path) {
+			t.Errorf("webhook path %s is configured but not in the webhook path registry", path)
+		}
+	}
+}
+
+func TestCreateFleetWebhookConfiguration(t *testing.T) {
+	originalBackoff := webhookConfigurationApplyBackoff
+	webhookConfigurationApplyBackoff = wait.Backoff{Steps: 3, Duration: time.Millisecond}
+	t.Cleanup(func() { webhookConfigurationApplyBackoff = originalBackoff })
+
+	fleetNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "fleet-system"}}
+	internalErr := apierrors.NewInternalError(errors.New("etcd is unavailable"))
+	forbiddenErr := apierrors.NewForbidden(admv1.Resource("validatingwebhookconfigurations"), fleetValidatingWebhookCfgName, errors.New("denied"))
+	testCases := map[string]struct {
+		// createErrs are the errors returned by the successive creations of the named configurations.
+		createErrs         map[string][]error
+		wantApplied        map[string]float64
+		wantErrContains    []string
+		wantErrNotContains []string
+	}{
+		"all configurations are applied": {
+			wantApplied: map[string]float64{
+				fleetMutatingWebhookCfgName:   1,
+				fleetValidatingWebhookCfgName: 1,
+				fleetGuardRailWebhookCfgName:  1,
+			},
+		},
+		"transient error is retried": {
+			createErrs: map[string][]error{fleetValidatingWebhookCfgName: {internalErr, internalErr}},
+			wantApplied: map[string]float64{
+				fleetMutatingWebhookCfgName:   1,
+				fleetValidatingWebhookCfgName: 1,
+				fleetGuardRailWebhookCfgName:  1,
+			},
+		},
+		"failure of one configuration does not stop the others": {
+			createErrs: map[string][]error{fleetValidatingWebhookCfgName: {forbiddenErr}},
+			wantApplied: map[string]float64{
+				fleetMutatingWebhookCfgName:   1,
+				fleetValidatingWebhookCfgName: 0,
+				fleetGuardRailWebhookCfgName:  1,
+			},
+			wantErrContains:    []string{"failed to apply the ValidatingWebhookConfiguration " + fleetValidatingWebhookCfgName},
+			wantErrNotContains: []string{fleetMutatingWebhookCfgName, fleetGuardRailWebhookCfgName},
+		},
+		"transient errors exhaust the retries": {
+			createErrs: map[string][]error{fleetMutatingWebhookCfgName: {internalErr, internalErr, internalErr}},
+			wantApplied: map[string]float64{
+				fleetMutatingWebhookCfgName:   0,
+				fleetValidatingWebhookCfgName: 1,
+				fleetGuardRailWebhookCfgName:  1,
+			},
+			wantErrContains: []string{"failed to apply the MutatingWebhookConfiguration " + fleetMutatingWebhookCfgName},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			createErrs := map[string][]error{}
+			for k, v := range tc.createErrs {
+				createErrs[k] = slices.Clone(v)
+			}
+			scheme := runtime.NewScheme()
+			if err := clientgoscheme.AddToScheme(scheme); err != nil {
+				t.Fatalf("AddToScheme() = %v, want no error", err)
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(fleetNamespace).WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					if errs := createErrs[obj.GetName()]; len(errs) > 0 {
+						createErrs[obj.GetName()] = errs[1:]
+						return errs[0]
+					}
+					return c.Create(ctx, obj, opts...)
+				},
+			}).Build()
+			url := options.URL
+			w := &Config{
+				mgr:                  &fakeWebhookManager{client: fakeClient},
+				serviceNamespace:     "fleet-system",
+				serviceName:          "fleetwebhook",
+				servicePort:          443,
+				serviceURL:           "https://fleetwebhook.fleet-system.svc.cluster.local:443",
+				clientConnectionType: &url,
+				enableGuardRail:      true,
+				webhookCache:         &webhookCache{},
+				applyStatus:          &webhookConfigurationApplyStatus{},
+			}
+			checker := w.ReadinessChecker()
+			if err := checker(nil); err != nil {
+				t.Errorf("ReadinessChecker() before the apply = %v, want no error", err)
+			}
+
+			err := w.createFleetWebhookConfiguration(context.Background())
+			if len(tc.wantErrContains) == 0 && err != nil {
+				t.Errorf("createFleetWebhookConfiguration() = %v, want no error", err)
+			}
+			for _, want := range tc.wantErrContains {
+				if err == nil || !strings.Contains(err.Error(), want) {
+					t.Errorf("createFleetWebhookConfiguration() = %v, want error containing %q", err, want)
+				}
+			}
+			for _, notWant := range tc.wantErrNotContains {
+				if err != nil && strings.Contains(err.Error(), notWant) {
+					t.Errorf("createFleetWebhookConfiguration() = %v, want error not containing %q", err, notWant)
+				}
+			}
+			if gotErr := checker(nil); (gotErr != nil) != (err != nil) {
+				t.Errorf("ReadinessChecker() = %v, want error %t", gotErr, err != nil)
+			}
+
+			for configName, want := range tc.wantApplied {
+				kind := validatingWebhookConfigurationKind
+				var obj client.Object = &admv1.ValidatingWebhookConfiguration{}
+				if configName == fleetMutatingWebhookCfgName {
+					kind = mutatingWebhookConfigurationKind
+					obj = &admv1.MutatingWebhookConfiguration{}
+				}
+				getErr := fakeClient.Get(context.Background(), client.ObjectKey{Name: configName}, obj)
+				if exists := getErr == nil; exists != (want == 1) {
+					t.Errorf("Get(%s) = %v, want the configuration to exist: %t", configName, getErr, want == 1)
+				}
+				if got := testutil.ToFloat64(hubmetrics.FleetWebhookConfigurationApplied.WithLabelValues(kind, configName)); got != want {
+					t.Errorf("FleetWebhookConfigurationApplied(%s) = %v, want %v", configName, got, want)
+				}
+			}
 		})
 	}
 }
@@ -0,0 +1,137 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+
+	admv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/klog/v2"
+)
+
+// webhookCache holds the fleet webhooks last built from the Config, along with the hash of their rules, so that a
+// change of the rules is told apart from a caBundle rotation, which only re-stamps the caBundle.
+// The cached slices are never handed out; the builds return deep copies of them.
+type webhookCache struct {
+	mu sync.Mutex
+	// ruleHash is the hash of the cached webhooks without their caBundle.
+	ruleHash   string
+	mutating   []admv1.MutatingWebhook
+	validating []admv1.ValidatingWebhook
+	guardRail  []admv1.ValidatingWebhook
+}
+
+// ruleHash returns a hash of the webhooks generated from the Config, leaving the caBundle out as it is stamped onto
+// the webhooks on every build.
+func (w *Config) ruleHash() (string, error) {
+	return webhooksRuleHash(w.newFleetMutatingWebhooks(), w.newFleetValidatingWebhooks(), w.newFleetGuardRailValidatingWebhooks())
+}
+
+// webhooksRuleHash returns a hash of the webhooks without their caBundle.
+func webhooksRuleHash(mutating []admv1.MutatingWebhook, validating, guardRail []admv1.ValidatingWebhook) (string, error) {
+	mutating = stampMutatingWebhooks(mutating, nil)
+	validating = stampValidatingWebhooks(validating, nil)
+	guardRail = stampValidatingWebhooks(guardRail, nil)
+	b, err := json.Marshal([]any{mutating, validating, guardRail})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// cachedWebhooks returns the cache of the webhooks, replacing the cached webhooks first if the rules of the webhooks
+// generated from the Config have changed since they were last built. It returns nil if the cache cannot be used.
+func (w *Config) cachedWebhooks() *webhookCache {
+	mutating := w.newFleetMutatingWebhooks()
+	validating := w.newFleetValidatingWebhooks()
+	guardRail := w.newFleetGuardRailValidatingWebhooks()
+	hash, err := webhooksRuleHash(mutating, validating, guardRail)
+	if err != nil {
+		klog.ErrorS(err, "failed to hash the webhook rules, building the webhooks without cache")
+		return nil
+	}
+	if w.webhookCache == nil {
+		w.webhookCache = &webhookCache{}
+	}
+	c := w.webhookCache
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ruleHash != hash {
+		klog.V(2).InfoS("webhook rules changed, replacing the cached fleet webhooks", "ruleHash", hash)
+		c.mutating = mutating
+		c.validating = validating
+		c.guardRail = guardRail
+		c.ruleHash = hash
+	}
+	return &webhookCache{
+		ruleHash:   c.ruleHash,
+		mutating:   c.mutating,
+		validating: c.validating,
+		guardRail:  c.guardRail,
+	}
+}
+
+// buildFleetMutatingWebhooks returns a slice of fleet mutating webhook objects.
+func (w *Config) buildFleetMutatingWebhooks() []admv1.MutatingWebhook {
+	cache := w.cachedWebhooks()
+	if cache == nil {
+		return w.newFleetMutatingWebhooks()
+	}
+	return stampMutatingWebhooks(cache.mutating, w.caPEM)
+}
+
+// buildFleetValidatingWebhooks returns a slice of fleet validating webhook objects.
+func (w *Config) buildFleetValidatingWebhooks() []admv1.ValidatingWebhook {
+	cache := w.cachedWebhooks()
+	if cache == nil {
+		return w.newFleetValidatingWebhooks()
+	}
+	return stampValidatingWebhooks(cache.validating, w.caPEM)
+}
+
+// buildFleetGuardRailValidatingWebhooks returns a slice of fleet guard rail validating webhook objects.
+func (w *Config) buildFleetGuardRailValidatingWebhooks() []admv1.ValidatingWebhook {
+	cache := w.cachedWebhooks()
+	if cache == nil {
+		return w.newFleetGuardRailValidatingWebhooks()
+	}
+	return stampValidatingWebhooks(cache.guardRail, w.caPEM)
+}
+
+// stampMutatingWebhooks returns a deep copy of the cached mutating webhooks with the caBundle set.
+func stampMutatingWebhooks(cached []admv1.MutatingWebhook, caPEM []byte) []admv1.MutatingWebhook {
+	webhooks := make([]admv1.MutatingWebhook, len(cached))
+	for i := range cached {
+		cached[i].DeepCopyInto(&webhooks[i])
+		webhooks[i].ClientConfig.CABundle = caPEM
+	}
+	return webhooks
+}
+
+// stampValidatingWebhooks returns a deep copy of the cached validating webhooks with the caBundle set.
+func stampValidatingWebhooks(cached []admv1.ValidatingWebhook, caPEM []byte) []admv1.ValidatingWebhook {
+	webhooks := make([]admv1.ValidatingWebhook, len(cached))
+	for i := range cached {
+		cached[i].DeepCopyInto(&webhooks[i])
+		webhooks[i].ClientConfig.CABundle = caPEM
+	}
+	return webhooks
+}
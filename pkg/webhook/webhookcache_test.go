@@ -0,0 +1,156 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	admv1 "k8s.io/api/admissionregistration/v1"
+
+	"github.com/kubefleet-dev/kubefleet/cmd/hubagent/options"
+)
+
+func newTestCacheConfig() *Config {
+	url := options.URL
+	return &Config{
+		serviceNamespace:     "test-namespace",
+		serviceName:          "test-webhook",
+		servicePort:          8080,
+		serviceURL:           "test-url",
+		clientConnectionType: &url,
+		caPEM:                []byte("ca-1"),
+	}
+}
+
+// mustMarshal serializes the argued object, failing the test on error.
+func mustMarshal(t *testing.T, obj interface{}) string {
+	t.Helper()
+	b, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatalf("json.Marshal() = %v, want no error", err)
+	}
+	return string(b)
+}
+
+func TestCachedWebhooksEqualFreshBuilds(t *testing.T) {
+	testCases := map[string]struct {
+		mutate func(w *Config)
+	}{
+		"caBundle rotated": {
+			mutate: func(w *Config) {
+				w.caPEM = []byte("ca-2")
+			},
+		},
+		"enable workload": {
+			mutate: func(w *Config) {
+				w.enableWorkload = true
+			},
+		},
+		"service port changed": {
+			mutate: func(w *Config) {
+				w.servicePort = 9443
+			},
+		},
+		"client connection type changed": {
+			mutate: func(w *Config) {
+				service := options.Service
+				w.clientConnectionType = &service
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			w := newTestCacheConfig()
+			// Warm up the cache.
+			w.buildFleetMutatingWebhooks()
+			w.buildFleetValidatingWebhooks()
+			w.buildFleetGuardRailValidatingWebhooks()
+
+			tc.mutate(w)
+
+			if diff := cmp.Diff(mustMarshal(t, w.newFleetMutatingWebhooks()), mustMarshal(t, w.buildFleetMutatingWebhooks())); diff != "" {
+				t.Errorf("buildFleetMutatingWebhooks() mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(mustMarshal(t, w.newFleetValidatingWebhooks()), mustMarshal(t, w.buildFleetValidatingWebhooks())); diff != "" {
+				t.Errorf("buildFleetValidatingWebhooks() mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(mustMarshal(t, w.newFleetGuardRailValidatingWebhooks()), mustMarshal(t, w.buildFleetGuardRailValidatingWebhooks())); diff != "" {
+				t.Errorf("buildFleetGuardRailValidatingWebhooks() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCachedWebhooksRuleHash(t *testing.T) {
+	w := newTestCacheConfig()
+	w.buildFleetValidatingWebhooks()
+	gotHash := w.webhookCache.ruleHash
+
+	// Rotating the caBundle must not invalidate the cache.
+	w.caPEM = []byte("ca-2")
+	w.buildFleetValidatingWebhooks()
+	if w.webhookCache.ruleHash != gotHash {
+		t.Errorf("ruleHash after caBundle rotation = %s, want %s", w.webhookCache.ruleHash, gotHash)
+	}
+
+	w.enableWorkload = true
+	w.buildFleetValidatingWebhooks()
+	if w.webhookCache.ruleHash == gotHash {
+		t.Errorf("ruleHash after enableWorkload changed = %s, want a different hash", w.webhookCache.ruleHash)
+	}
+}
+
+func TestCachedWebhooksAreNotShared(t *testing.T) {
+	w := newTestCacheConfig()
+	first := w.buildFleetValidatingWebhooks()
+	first[0].Name = "modified"
+	first[0].ClientConfig.CABundle = []byte("modified")
+	first[0].Rules[0].Operations[0] = "modified"
+	first[0].Rules[0].Rule.Resources[0] = "modified"
+	first[0].MatchConditions = append(first[0].MatchConditions, admv1.MatchCondition{Name: "modified", Expression: "true"})
+	mutating := w.buildFleetMutatingWebhooks()
+	mutating[0].Rules[0].Rule.Resources[0] = "modified"
+
+	second := w.buildFleetValidatingWebhooks()
+	if diff := cmp.Diff(mustMarshal(t, w.newFleetValidatingWebhooks()), mustMarshal(t, second)); diff != "" {
+		t.Errorf("buildFleetValidatingWebhooks() after modifying a previous build mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(mustMarshal(t, w.newFleetMutatingWebhooks()), mustMarshal(t, w.buildFleetMutatingWebhooks())); diff != "" {
+		t.Errorf("buildFleetMutatingWebhooks() after modifying a previous build mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func BenchmarkBuildFleetValidatingWebhooks(b *testing.B) {
+	b.Run("fresh", func(b *testing.B) {
+		w := newTestCacheConfig()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			w.newFleetValidatingWebhooks()
+			w.newFleetGuardRailValidatingWebhooks()
+		}
+	})
+	b.Run("cached", func(b *testing.B) {
+		w := newTestCacheConfig()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			w.buildFleetValidatingWebhooks()
+			w.buildFleetGuardRailValidatingWebhooks()
+		}
+	})
+}
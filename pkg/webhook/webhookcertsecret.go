@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	admv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/kubefleet-dev/kubefleet/cmd/hubagent/options"
+	"github.com/kubefleet-dev/kubefleet/pkg/webhook/fleetresourcehandler"
+)
+
+const (
+	// webhookCertSecretGuardRailWebhookName is the name of the guard rail webhook protecting the Secret which holds
+	// the webhook serving certificate.
+	webhookCertSecretGuardRailWebhookName = "fleet.webhookcertsecret.guardrail.validating"
+	// webhookCertSecretMatchConditionName is the name of the match condition scoping the webhook to the Secret.
+	webhookCertSecretMatchConditionName = "fleet-webhook-cert-secret-name"
+)
+
+// validateWebhookCertSecretName returns an error if the non-empty name of the Secret holding the webhook serving
+// certificate is not a DNS-1123 subdomain. The guard rail denies the updates and deletions of the Secret unless they
+// are made by the hub controllers, i.e., the users matching the fleet snapshot writer patterns, or the white listed
+// users, since anyone able to corrupt the Secret could take down the admission of the whole fleet.
+func validateWebhookCertSecretName(name string) error {
+	if name == "" {
+		return nil
+	}
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return fmt.Errorf("invalid webhook cert secret name %q: %s", name, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// newWebhookCertSecretGuardRailWebhook returns the guard rail webhook reviewing the updates and deletions of the
+// Secret holding the webhook serving certificate. The namespace selector scopes it to the service namespace. As the
+// label selectors cannot match an object by name, the API server is told to skip the other Secrets by a match
+// condition on the name if the match conditions are enabled; otherwise the guard rail lets them through itself.
+// The webhook fails open like the other guard rails so that a broken certificate can still be replaced.
+func (w *Config) newWebhookCertSecretGuardRailWebhook() admv1.ValidatingWebhook {
+	webhook := admv1.ValidatingWebhook{
+		Name:                    webhookCertSecretGuardRailWebhookName,
+		ClientConfig:            w.createClientConfig(options.WebhookRoleGuardRail, fleetresourcehandler.ValidationPath),
+		FailurePolicy:           &ignoreFailurePolicy,
+		SideEffects:             &sideEffortsNone,
+		AdmissionReviewVersions: admissionReviewVersions,
+		NamespaceSelector: &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{
+					Key:      corev1.LabelMetadataName,
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   []string{w.serviceNamespace},
+				},
+			},
+		},
+		Rules: []admv1.RuleWithOperations{
+			{
+				Operations: []admv1.OperationType{admv1.Update, admv1.Delete},
+				Rule:       createRule([]string{corev1.SchemeGroupVersion.Group}, []string{corev1.SchemeGroupVersion.Version}, []string{secretResourceName}, &namespacedScope),
+			},
+		},
+		TimeoutSeconds: shortWebhookTimeout,
+	}
+	// The configured match conditions are kept, the name condition is dropped if the API server would not accept it.
+	if conditions := w.effectiveMatchConditions(); conditions != nil && len(conditions) < maxMatchConditions {
+		webhook.MatchConditions = append(slices.Clone(conditions), admv1.MatchCondition{
+			Name:       webhookCertSecretMatchConditionName,
+			Expression: fmt.Sprintf("request.name == %q", w.webhookCertSecretName),
+		})
+	} else if conditions != nil {
+		webhook.MatchConditions = slices.Clone(conditions)
+	}
+	return webhook
+}
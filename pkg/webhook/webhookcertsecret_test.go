@@ -0,0 +1,146 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	admv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubefleet-dev/kubefleet/cmd/hubagent/options"
+)
+
+func TestWebhookCertSecretGuardRailWebhook(t *testing.T) {
+	url := options.WebhookClientConnectionType("url")
+	configured := []admv1.MatchCondition{{Name: "exclude-leases", Expression: `request.resource.resource != "leases"`}}
+	testCases := map[string]struct {
+		secretName          string
+		featureGate         bool
+		wantWebhook         bool
+		wantMatchConditions []admv1.MatchCondition
+	}{
+		"no secret name": {
+			featureGate: true,
+		},
+		"secret name without match conditions": {
+			secretName:  "fleet-webhook-server-cert",
+			wantWebhook: true,
+		},
+		"secret name with match conditions": {
+			secretName:  "fleet-webhook-server-cert",
+			featureGate: true,
+			wantWebhook: true,
+			wantMatchConditions: append(configured, admv1.MatchCondition{
+				Name:       webhookCertSecretMatchConditionName,
+				Expression: `request.name == "fleet-webhook-server-cert"`,
+			}),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			w := &Config{
+				serviceNamespace:     "hub-namespace",
+				servicePort:          8080,
+				serviceURL:           "test-url",
+				clientConnectionType: &url,
+				enableGuardRail:      true,
+				role:                 options.WebhookRoleAll,
+			}
+			withoutSecret := len(w.buildFleetGuardRailValidatingWebhooks())
+			w.featureGateMatchConditions = tc.featureGate
+			w.matchConditions = configured
+			w.webhookCertSecretName = tc.secretName
+
+			webhooks := w.buildFleetGuardRailValidatingWebhooks()
+			var got *admv1.ValidatingWebhook
+			for i := range webhooks {
+				if webhooks[i].Name == webhookCertSecretGuardRailWebhookName {
+					got = &webhooks[i]
+				} else if tc.featureGate && !cmp.Equal(configured, webhooks[i].MatchConditions) {
+					t.Errorf("webhook %s match conditions = %v, want only the configured ones", webhooks[i].Name, webhooks[i].MatchConditions)
+				}
+			}
+			if !tc.wantWebhook {
+				if got != nil {
+					t.Fatalf("buildFleetGuardRailValidatingWebhooks() built %s without a secret name, want no webhook", webhookCertSecretGuardRailWebhookName)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("buildFleetGuardRailValidatingWebhooks() did not build %s", webhookCertSecretGuardRailWebhookName)
+			}
+			if len(webhooks) != withoutSecret+1 {
+				t.Errorf("buildFleetGuardRailValidatingWebhooks() built %d webhooks, want %d", len(webhooks), withoutSecret+1)
+			}
+			wantNamespaceSelector := &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: corev1.LabelMetadataName, Operator: metav1.LabelSelectorOpIn, Values: []string{"hub-namespace"}},
+				},
+			}
+			if diff := cmp.Diff(wantNamespaceSelector, got.NamespaceSelector); diff != "" {
+				t.Errorf("namespace selector mismatch (-want, +got):\n%s", diff)
+			}
+			if got.ObjectSelector != nil {
+				t.Errorf("object selector = %v, want nil", got.ObjectSelector)
+			}
+			wantRules := []admv1.RuleWithOperations{
+				{
+					Operations: []admv1.OperationType{admv1.Update, admv1.Delete},
+					Rule:       createRule([]string{""}, []string{"v1"}, []string{"secrets"}, &namespacedScope),
+				},
+			}
+			if diff := cmp.Diff(wantRules, got.Rules); diff != "" {
+				t.Errorf("rules mismatch (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantMatchConditions, got.MatchConditions); diff != "" {
+				t.Errorf("match conditions mismatch (-want, +got):\n%s", diff)
+			}
+			if got.FailurePolicy == nil || *got.FailurePolicy != admv1.Ignore {
+				t.Errorf("failure policy = %v, want Ignore", got.FailurePolicy)
+			}
+		})
+	}
+}
+
+func TestWebhookCertSecretName(t *testing.T) {
+	if err := validateWebhookCertSecretName("Fleet_Cert"); err == nil {
+		t.Errorf("validateWebhookCertSecretName(Fleet_Cert) = nil, want an error")
+	}
+	if err := validateWebhookCertSecretName("fleet-webhook-server-cert"); err != nil {
+		t.Fatalf("validateWebhookCertSecretName() = %v, want no error", err)
+	}
+
+	w := newHashTestConfig()
+	before, err := w.ruleHash()
+	if err != nil {
+		t.Fatalf("ruleHash() = %v, want no error", err)
+	}
+	w.webhookCertSecretName = "fleet-webhook-server-cert"
+	if got := w.validatorConfig().WebhookCertSecretName; got != "fleet-webhook-server-cert" {
+		t.Errorf("validatorConfig() WebhookCertSecretName = %q, want fleet-webhook-server-cert", got)
+	}
+	after, err := w.ruleHash()
+	if err != nil {
+		t.Fatalf("ruleHash() = %v, want no error", err)
+	}
+	if before == after {
+		t.Errorf("ruleHash() = %s before and after setting the secret name, want different hashes", after)
+	}
+}
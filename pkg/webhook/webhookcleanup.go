@@ -0,0 +1,165 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	admv1 "k8s.io/api/admissionregistration/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+const (
+	// FleetWebhookConfigurationLabel is the label stamped on every webhook configuration generated by the hub agent,
+	// so that the configurations can be found and deleted once fleet is uninstalled.
+	FleetWebhookConfigurationLabel = placementv1beta1.FleetPrefix + "webhook-configuration"
+	// FleetWebhookConfigurationLabelValue is the value of the FleetWebhookConfigurationLabel.
+	FleetWebhookConfigurationLabelValue = "true"
+
+	// WebhookConfigurationAnchorName is the name of the cluster scoped ClusterRole which owns the webhook
+	// configurations if the anchor is enabled. The ClusterRole has no rules and grants nothing; deleting it garbage
+	// collects all the webhook configurations at once.
+	WebhookConfigurationAnchorName = "fleet-webhook-configuration-anchor"
+)
+
+// stampWebhookConfiguration labels the argued webhook configuration with the FleetWebhookConfigurationLabel, and the
+// FleetWebhookNameSuffixLabel if the webhook names carry a suffix, and, if the anchor is enabled, replaces its owner
+// references with the one of the anchor.
+func (w *Config) stampWebhookConfiguration(ctx context.Context, webhookConfig client.Object) error {
+	webhookConfig.SetLabels(setFleetWebhookConfigurationLabels(webhookConfig.GetLabels(), w.webhookNameSuffix))
+	if !w.webhookConfigurationAnchor {
+		return nil
+	}
+	anchor, err := ensureWebhookConfigurationAnchor(ctx, w.mgr.GetClient(), w.webhookNameSuffix)
+	if err != nil {
+		return fmt.Errorf("failed to ensure the webhook configuration anchor: %w", err)
+	}
+	webhookConfig.SetOwnerReferences([]metav1.OwnerReference{{
+		APIVersion:         rbacv1.SchemeGroupVersion.String(),
+		Kind:               "ClusterRole",
+		Name:               anchor.GetName(),
+		UID:                anchor.GetUID(),
+		BlockOwnerDeletion: ptr.To(false),
+	}})
+	return nil
+}
+
+// adoptWebhookConfiguration updates the labels and owner references of an existing webhook configuration which is
+// otherwise up to date, so that the configurations applied by an earlier hub agent are cleaned up as well.
+func (w *Config) adoptWebhookConfiguration(ctx context.Context, existing, desired client.Object) error {
+	if hasFleetWebhookConfigurationLabels(existing.GetLabels(), w.webhookNameSuffix) &&
+		equality.Semantic.DeepEqual(existing.GetOwnerReferences(), desired.GetOwnerReferences()) {
+		return nil
+	}
+	existing.SetLabels(setFleetWebhookConfigurationLabels(existing.GetLabels(), w.webhookNameSuffix))
+	existing.SetOwnerReferences(desired.GetOwnerReferences())
+	if err := w.mgr.GetClient().Update(ctx, existing); err != nil {
+		return err
+	}
+	klog.V(2).InfoS("successfully adopted webhook configuration", "name", existing.GetName())
+	return nil
+}
+
+// ensureWebhookConfigurationAnchor returns the WebhookConfigurationAnchorName ClusterRole, with the webhook name
+// suffix appended, and creates it bound to the fleet-system namespace if it does not exist.
+func ensureWebhookConfigurationAnchor(ctx context.Context, k8Client client.Client, suffix string) (*rbacv1.ClusterRole, error) {
+	name := suffixedName(WebhookConfigurationAnchorName, suffix)
+	var anchor rbacv1.ClusterRole
+	err := k8Client.Get(ctx, client.ObjectKey{Name: name}, &anchor)
+	if err == nil {
+		return &anchor, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	anchor = rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: setFleetWebhookConfigurationLabels(nil, suffix),
+		},
+	}
+	// Deleting the fleet-system namespace garbage collects the anchor, and the anchor the webhook configurations.
+	if err := bindWebhookConfigToFleetSystem(ctx, k8Client, &anchor); err != nil {
+		return nil, err
+	}
+	if err := k8Client.Create(ctx, &anchor); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return nil, err
+		}
+		if err := k8Client.Get(ctx, client.ObjectKey{Name: name}, &anchor); err != nil {
+			return nil, err
+		}
+		return &anchor, nil
+	}
+	klog.V(2).InfoS("successfully created webhook configuration anchor", "name", name)
+	return &anchor, nil
+}
+
+// CleanupWebhookConfigurations deletes all the webhook configurations labeled with the FleetWebhookConfigurationLabel
+// and the webhook configuration anchor generated by the hub agents with the webhook name suffix; the ones of the
+// other hubs sharing the cluster are kept. The validating configurations fail closed, so they block unrelated writes
+// once the webhook service is gone if they are left behind after fleet is uninstalled.
+func CleanupWebhookConfigurations(ctx context.Context, k8Client client.Client, suffix string) error {
+	if err := validateWebhookNameSuffix(suffix); err != nil {
+		return err
+	}
+	labelSelector, err := fleetWebhookConfigurationSelector(suffix)
+	if err != nil {
+		return err
+	}
+	selector := client.MatchingLabelsSelector{Selector: labelSelector}
+	var errs []error
+	var validatingConfigs admv1.ValidatingWebhookConfigurationList
+	if err := k8Client.List(ctx, &validatingConfigs, selector); err != nil {
+		errs = append(errs, fmt.Errorf("failed to list the validating webhook configurations: %w", err))
+	}
+	for i := range validatingConfigs.Items {
+		errs = append(errs, deleteWebhookConfiguration(ctx, k8Client, &validatingConfigs.Items[i], validatingWebhookConfigurationKind))
+	}
+	var mutatingConfigs admv1.MutatingWebhookConfigurationList
+	if err := k8Client.List(ctx, &mutatingConfigs, selector); err != nil {
+		errs = append(errs, fmt.Errorf("failed to list the mutating webhook configurations: %w", err))
+	}
+	for i := range mutatingConfigs.Items {
+		errs = append(errs, deleteWebhookConfiguration(ctx, k8Client, &mutatingConfigs.Items[i], mutatingWebhookConfigurationKind))
+	}
+	anchor := rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: suffixedName(WebhookConfigurationAnchorName, suffix)}}
+	errs = append(errs, deleteWebhookConfiguration(ctx, k8Client, &anchor, "ClusterRole"))
+	return errors.Join(errs...)
+}
+
+// deleteWebhookConfiguration deletes the argued object, ignoring the not found error.
+func deleteWebhookConfiguration(ctx context.Context, k8Client client.Client, obj client.Object, kind string) error {
+	if err := k8Client.Delete(ctx, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to delete the %s %s: %w", kind, obj.GetName(), err)
+	}
+	klog.V(2).InfoS("successfully deleted webhook configuration", "kind", kind, "name", obj.GetName())
+	return nil
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	admv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newCleanupTestClient returns a fake client holding the fleet-system namespace and the argued objects.
+func newCleanupTestClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() = %v, want no error", err)
+	}
+	fleetNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "fleet-system", UID: "fleet-system-uid"}}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(objs, fleetNamespace)...).Build()
+}
+
+// ownerKinds returns the kind/name of each owner reference of the argued object.
+func ownerKinds(obj client.Object) []string {
+	var kinds []string
+	for _, ref := range obj.GetOwnerReferences() {
+		kinds = append(kinds, ref.Kind+"/"+ref.Name)
+	}
+	return kinds
+}
+
+func TestCreateFleetWebhookConfigurationStampsLabel(t *testing.T) {
+	testCases := map[string]struct {
+		anchor              bool
+		existing            []client.Object
+		wantMutatingOwners  []string
+		wantValidatingOwner []string
+	}{
+		"anchor disabled": {
+			wantValidatingOwner: []string{"Namespace/fleet-system"},
+		},
+		"anchor enabled": {
+			anchor:              true,
+			wantMutatingOwners:  []string{"ClusterRole/" + WebhookConfigurationAnchorName},
+			wantValidatingOwner: []string{"ClusterRole/" + WebhookConfigurationAnchorName},
+		},
+		"up to date configuration applied without the label is adopted": {
+			anchor:              true,
+			existing:            []client.Object{&admv1.MutatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: fleetMutatingWebhookCfgName}}},
+			wantMutatingOwners:  []string{"ClusterRole/" + WebhookConfigurationAnchorName},
+			wantValidatingOwner: []string{"ClusterRole/" + WebhookConfigurationAnchorName},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			w := newHashTestConfig()
+			w.webhookConfigurationAnchor = tc.anchor
+			var existing []client.Object
+			for _, obj := range tc.existing {
+				// The existing configuration carries the desired hash, so it is not overwritten.
+				obj.SetAnnotations(map[string]string{WebhookConfigurationHashAnnotation: desiredHashes(t, w)[obj.GetName()]})
+				existing = append(existing, obj)
+			}
+			fakeClient := newCleanupTestClient(t, existing...)
+			w.mgr = &fakeWebhookManager{client: fakeClient}
+
+			if err := w.createFleetWebhookConfiguration(context.Background()); err != nil {
+				t.Fatalf("createFleetWebhookConfiguration() = %v, want no error", err)
+			}
+
+			var mutating admv1.MutatingWebhookConfiguration
+			if err := fakeClient.Get(context.Background(), client.ObjectKey{Name: fleetMutatingWebhookCfgName}, &mutating); err != nil {
+				t.Fatalf("Get() = %v, want no error", err)
+			}
+			var validatingConfigs admv1.ValidatingWebhookConfigurationList
+			if err := fakeClient.List(context.Background(), &validatingConfigs); err != nil {
+				t.Fatalf("List() = %v, want no error", err)
+			}
+			if len(validatingConfigs.Items) == 0 {
+				t.Fatalf("createFleetWebhookConfiguration() created no validating webhook configuration")
+			}
+			objs := []client.Object{&mutating}
+			for i := range validatingConfigs.Items {
+				objs = append(objs, &validatingConfigs.Items[i])
+			}
+			for _, obj := range objs {
+				if got := obj.GetLabels()[FleetWebhookConfigurationLabel]; got != FleetWebhookConfigurationLabelValue {
+					t.Errorf("%s label %s = %q, want %q", obj.GetName(), FleetWebhookConfigurationLabel, got, FleetWebhookConfigurationLabelValue)
+				}
+				want := tc.wantValidatingOwner
+				if obj == &mutating {
+					want = tc.wantMutatingOwners
+				}
+				if diff := cmp.Diff(want, ownerKinds(obj)); diff != "" {
+					t.Errorf("%s owner references mismatch (-want, +got):\n%s", obj.GetName(), diff)
+				}
+			}
+
+			var anchor rbacv1.ClusterRole
+			err := fakeClient.Get(context.Background(), client.ObjectKey{Name: WebhookConfigurationAnchorName}, &anchor)
+			if gotAnchor := err == nil; gotAnchor != tc.anchor {
+				t.Errorf("createFleetWebhookConfiguration() created the anchor: %t (err: %v), want %t", gotAnchor, err, tc.anchor)
+			}
+		})
+	}
+}
+
+func TestEnsureWebhookConfigurationAnchor(t *testing.T) {
+	fakeClient := newCleanupTestClient(t)
+	anchor, err := ensureWebhookConfigurationAnchor(context.Background(), fakeClient, "")
+	if err != nil {
+		t.Fatalf("ensureWebhookConfigurationAnchor() = %v, want no error", err)
+	}
+	if diff := cmp.Diff([]string{"Namespace/fleet-system"}, ownerKinds(anchor)); diff != "" {
+		t.Errorf("ensureWebhookConfigurationAnchor() owner references mismatch (-want, +got):\n%s", diff)
+	}
+	if got := anchor.Labels[FleetWebhookConfigurationLabel]; got != FleetWebhookConfigurationLabelValue {
+		t.Errorf("ensureWebhookConfigurationAnchor() label %s = %q, want %q", FleetWebhookConfigurationLabel, got, FleetWebhookConfigurationLabelValue)
+	}
+	if len(anchor.Rules) != 0 {
+		t.Errorf("ensureWebhookConfigurationAnchor() rules = %v, want none", anchor.Rules)
+	}
+
+	again, err := ensureWebhookConfigurationAnchor(context.Background(), fakeClient, "")
+	if err != nil {
+		t.Fatalf("ensureWebhookConfigurationAnchor() = %v, want no error", err)
+	}
+	if again.ResourceVersion != anchor.ResourceVersion {
+		t.Errorf("ensureWebhookConfigurationAnchor() resource version = %s, want the existing anchor %s", again.ResourceVersion, anchor.ResourceVersion)
+	}
+}
+
+func TestCleanupWebhookConfigurations(t *testing.T) {
+	fleetLabels := map[string]string{FleetWebhookConfigurationLabel: FleetWebhookConfigurationLabelValue}
+	testCases := map[string]struct {
+		objs      []client.Object
+		wantGone  []client.Object
+		wantKept  []client.Object
+		wantError bool
+	}{
+		"labeled configurations and the anchor are deleted": {
+			objs: []client.Object{
+				&admv1.ValidatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: fleetValidatingWebhookCfgName, Labels: fleetLabels}},
+				&admv1.ValidatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: fleetGuardRailWebhookCfgName, Labels: fleetLabels}},
+				&admv1.MutatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: fleetMutatingWebhookCfgName, Labels: fleetLabels}},
+				&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: WebhookConfigurationAnchorName, Labels: fleetLabels}},
+				&admv1.ValidatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: "other-validating"}},
+				&admv1.MutatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: "other-mutating"}},
+			},
+			wantGone: []client.Object{
+				&admv1.ValidatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: fleetValidatingWebhookCfgName}},
+				&admv1.ValidatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: fleetGuardRailWebhookCfgName}},
+				&admv1.MutatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: fleetMutatingWebhookCfgName}},
+				&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: WebhookConfigurationAnchorName}},
+			},
+			wantKept: []client.Object{
+				&admv1.ValidatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: "other-validating"}},
+				&admv1.MutatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: "other-mutating"}},
+			},
+		},
+		"nothing to clean up": {
+			objs: []client.Object{
+				&admv1.ValidatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: "other-validating"}},
+			},
+			wantKept: []client.Object{
+				&admv1.ValidatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: "other-validating"}},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			fakeClient := newCleanupTestClient(t, tc.objs...)
+			if err := CleanupWebhookConfigurations(context.Background(), fakeClient, ""); err != nil {
+				t.Fatalf("CleanupWebhookConfigurations() = %v, want no error", err)
+			}
+			for _, obj := range tc.wantGone {
+				if err := fakeClient.Get(context.Background(), client.ObjectKeyFromObject(obj), obj); !apierrors.IsNotFound(err) {
+					t.Errorf("Get(%s) = %v, want not found", obj.GetName(), err)
+				}
+			}
+			for _, obj := range tc.wantKept {
+				if err := fakeClient.Get(context.Background(), client.ObjectKeyFromObject(obj), obj); err != nil {
+					t.Errorf("Get(%s) = %v, want no error", obj.GetName(), err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"fmt"
+	"strings"
+
+	admv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+// FleetWebhookNameSuffixLabel is the label stamped on the webhook configurations and the anchor generated by a hub
+// agent with a webhook name suffix, holding the suffix, so that the hubs sharing a cluster only manage their own.
+const FleetWebhookNameSuffixLabel = placementv1beta1.FleetPrefix + "webhook-name-suffix"
+
+// validateWebhookNameSuffix returns an error if the non-empty suffix is not a DNS-1123 label. The length of the
+// suffixed names is checked by Validate.
+func validateWebhookNameSuffix(suffix string) error {
+	if suffix == "" {
+		return nil
+	}
+	if errs := validation.IsDNS1123Label(suffix); len(errs) > 0 {
+		return fmt.Errorf("invalid webhook name suffix %q: %s", suffix, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// suffixedName returns the name with the suffix appended after a dash, or the name as is if the suffix is empty.
+func suffixedName(name, suffix string) string {
+	if suffix == "" {
+		return name
+	}
+	return fmt.Sprintf("%s-%s", name, suffix)
+}
+
+// suffixMutatingWebhookNames appends the webhook name suffix to the names of the freshly built mutating webhooks.
+func (w *Config) suffixMutatingWebhookNames(webhooks []admv1.MutatingWebhook) []admv1.MutatingWebhook {
+	for i := range webhooks {
+		webhooks[i].Name = suffixedName(webhooks[i].Name, w.webhookNameSuffix)
+	}
+	return webhooks
+}
+
+// suffixValidatingWebhookNames appends the webhook name suffix to the names of the freshly built validating webhooks.
+func (w *Config) suffixValidatingWebhookNames(webhooks []admv1.ValidatingWebhook) []admv1.ValidatingWebhook {
+	for i := range webhooks {
+		webhooks[i].Name = suffixedName(webhooks[i].Name, w.webhookNameSuffix)
+	}
+	return webhooks
+}
+
+// validateWebhookNames returns an error if any name generated with the webhook name suffix is not a DNS-1123
+// subdomain, e.g., because the suffix makes it too long.
+func (w *Config) validateWebhookNames() error {
+	if w.webhookNameSuffix == "" {
+		return nil
+	}
+	names := []string{
+		suffixedName(WebhookConfigurationAnchorName, w.webhookNameSuffix),
+		w.webhookConfigurationName(fleetMutatingWebhookCfgName),
+		w.webhookConfigurationName(fleetValidatingWebhookCfgName),
+		w.webhookConfigurationName(fleetGuardRailWebhookCfgName),
+	}
+	for _, webhook := range w.newFleetMutatingWebhooks() {
+		names = append(names, webhook.Name)
+	}
+	for _, webhook := range append(w.newFleetValidatingWebhooks(), w.newFleetGuardRailValidatingWebhooks()...) {
+		names = append(names, webhook.Name)
+	}
+	for _, name := range names {
+		if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+			return fmt.Errorf("invalid webhook name %q with the suffix %q: %s", name, w.webhookNameSuffix, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+// setFleetWebhookConfigurationLabels sets the labels identifying the webhook configurations generated by the hub
+// agents with the suffix on the argued labels, which are returned.
+func setFleetWebhookConfigurationLabels(objLabels map[string]string, suffix string) map[string]string {
+	if objLabels == nil {
+		objLabels = map[string]string{}
+	}
+	objLabels[FleetWebhookConfigurationLabel] = FleetWebhookConfigurationLabelValue
+	if suffix != "" {
+		objLabels[FleetWebhookNameSuffixLabel] = suffix
+	}
+	return objLabels
+}
+
+// hasFleetWebhookConfigurationLabels returns true if the argued labels identify a webhook configuration generated
+// by the hub agents with the suffix.
+func hasFleetWebhookConfigurationLabels(objLabels map[string]string, suffix string) bool {
+	gotSuffix, found := objLabels[FleetWebhookNameSuffixLabel]
+	return objLabels[FleetWebhookConfigurationLabel] == FleetWebhookConfigurationLabelValue && gotSuffix == suffix && found == (suffix != "")
+}
+
+// fleetWebhookConfigurationSelector returns the selector of the webhook configurations generated by the hub agents
+// with the suffix; the ones generated without a suffix do not carry the FleetWebhookNameSuffixLabel.
+func fleetWebhookConfigurationSelector(suffix string) (labels.Selector, error) {
+	fleetRequirement, err := labels.NewRequirement(FleetWebhookConfigurationLabel, selection.Equals, []string{FleetWebhookConfigurationLabelValue})
+	if err != nil {
+		return nil, err
+	}
+	suffixRequirement, err := labels.NewRequirement(FleetWebhookNameSuffixLabel, selection.DoesNotExist, nil)
+	if suffix != "" {
+		suffixRequirement, err = labels.NewRequirement(FleetWebhookNameSuffixLabel, selection.Equals, []string{suffix})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return labels.NewSelector().Add(*fleetRequirement, *suffixRequirement), nil
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	admv1 "k8s.io/api/admissionregistration/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// webhookNames returns the names of the webhook configurations, and of the webhooks in them, built by the argued
+// config, keyed by the kind of the object named.
+func webhookNames(t *testing.T, w *Config) map[string]string {
+	t.Helper()
+	applies, err := w.desiredWebhookConfigurations()
+	if err != nil {
+		t.Fatalf("desiredWebhookConfigurations() = %v, want no error", err)
+	}
+	names := map[string]string{}
+	for _, a := range applies {
+		names[a.name] = "configuration"
+	}
+	for _, webhook := range w.buildFleetMutatingWebhooks() {
+		names[webhook.Name] = "webhook"
+	}
+	for _, webhook := range append(w.buildFleetValidatingWebhooks(), w.buildFleetGuardRailValidatingWebhooks()...) {
+		names[webhook.Name] = "webhook"
+	}
+	return names
+}
+
+func TestWebhookNameSuffixBuilders(t *testing.T) {
+	dev := newHashTestConfig()
+	dev.webhookNameSuffix = "dev"
+	stage := newHashTestConfig()
+	stage.webhookNameSuffix = "stage"
+
+	devNames, stageNames := webhookNames(t, dev), webhookNames(t, stage)
+	for name, kind := range devNames {
+		if !strings.HasSuffix(name, "-dev") {
+			t.Errorf("dev %s name %q, want the -dev suffix", kind, name)
+		}
+		if _, found := stageNames[name]; found {
+			t.Errorf("%s name %q is built with both the dev and stage suffixes, want disjoint names", kind, name)
+		}
+	}
+	for name, kind := range stageNames {
+		if !strings.HasSuffix(name, "-stage") {
+			t.Errorf("stage %s name %q, want the -stage suffix", kind, name)
+		}
+	}
+	if len(devNames) != len(webhookNames(t, newHashTestConfig())) {
+		t.Errorf("dev built %d names, want as many as without a suffix", len(devNames))
+	}
+
+	devHash, err := dev.ruleHash()
+	if err != nil {
+		t.Fatalf("ruleHash() = %v, want no error", err)
+	}
+	stageHash, err := stage.ruleHash()
+	if err != nil {
+		t.Fatalf("ruleHash() = %v, want no error", err)
+	}
+	if devHash == stageHash {
+		t.Errorf("ruleHash() = %s for both suffixes, want different hashes", devHash)
+	}
+}
+
+func TestValidateWebhookNameSuffix(t *testing.T) {
+	testCases := map[string]struct {
+		suffix    string
+		wantError bool
+	}{
+		"no suffix": {},
+		"dns label": {
+			suffix: "hub-1",
+		},
+		"upper case": {
+			suffix:    "Hub1",
+			wantError: true,
+		},
+		"dot": {
+			suffix:    "hub.1",
+			wantError: true,
+		},
+		"leading dash": {
+			suffix:    "-hub",
+			wantError: true,
+		},
+		"too long for a label": {
+			suffix:    strings.Repeat("a", 64),
+			wantError: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validateWebhookNameSuffix(tc.suffix)
+			if gotError := err != nil; gotError != tc.wantError {
+				t.Errorf("validateWebhookNameSuffix(%q) = %v, want error %t", tc.suffix, err, tc.wantError)
+			}
+		})
+	}
+}
+
+func TestValidateWebhookNames(t *testing.T) {
+	testCases := map[string]struct {
+		suffix    string
+		wantError bool
+	}{
+		"no suffix": {},
+		"short suffix": {
+			suffix: "dev",
+		},
+		"longest label": {
+			suffix: strings.Repeat("a", 63),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			w := newHashTestConfig()
+			w.webhookNameSuffix = tc.suffix
+			err := w.Validate()
+			if gotError := err != nil; gotError != tc.wantError {
+				t.Errorf("Validate() = %v, want error %t", err, tc.wantError)
+			}
+		})
+	}
+
+	// The label check of validateWebhookNameSuffix is bypassed to show that the total length of the names is checked.
+	w := newHashTestConfig()
+	w.webhookNameSuffix = strings.Repeat("a", 250)
+	if err := w.Validate(); err == nil {
+		t.Errorf("Validate() with a %d character suffix = nil, want an error", len(w.webhookNameSuffix))
+	}
+}
+
+func TestWebhookNameSuffixReconcilers(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := newCleanupTestClient(t)
+	configs := map[string]*Config{}
+	for _, suffix := range []string{"dev", "stage"} {
+		w := newHashTestConfig()
+		w.webhookNameSuffix = suffix
+		w.webhookConfigurationAnchor = true
+		w.mgr = &fakeWebhookManager{client: fakeClient}
+		configs[suffix] = w
+	}
+	for suffix, w := range configs {
+		if err := w.createFleetWebhookConfiguration(ctx); err != nil {
+			t.Fatalf("createFleetWebhookConfiguration() with the %s suffix = %v, want no error", suffix, err)
+		}
+	}
+
+	// Every configuration is labeled with, and owned by the anchor of, the suffix it is named with.
+	var mutatingConfigs admv1.MutatingWebhookConfigurationList
+	if err := fakeClient.List(ctx, &mutatingConfigs); err != nil {
+		t.Fatalf("List() = %v, want no error", err)
+	}
+	var validatingConfigs admv1.ValidatingWebhookConfigurationList
+	if err := fakeClient.List(ctx, &validatingConfigs); err != nil {
+		t.Fatalf("List() = %v, want no error", err)
+	}
+	var objs []client.Object
+	for i := range mutatingConfigs.Items {
+		objs = append(objs, &mutatingConfigs.Items[i])
+	}
+	for i := range validatingConfigs.Items {
+		objs = append(objs, &validatingConfigs.Items[i])
+	}
+	perSuffix := map[string]int{}
+	for _, obj := range objs {
+		suffix := obj.GetLabels()[FleetWebhookNameSuffixLabel]
+		if !strings.HasSuffix(obj.GetName(), "-"+suffix) {
+			t.Errorf("%s label %s = %q, want the suffix of its name", obj.GetName(), FleetWebhookNameSuffixLabel, suffix)
+		}
+		if got, want := ownerKinds(obj), "ClusterRole/"+suffixedName(WebhookConfigurationAnchorName, suffix); len(got) != 1 || got[0] != want {
+			t.Errorf("%s owner references = %v, want [%s]", obj.GetName(), got, want)
+		}
+		perSuffix[suffix]++
+	}
+	if perSuffix["dev"] == 0 || perSuffix["dev"] != perSuffix["stage"] {
+		t.Fatalf("configurations per suffix = %v, want as many non zero for dev and stage", perSuffix)
+	}
+
+	// Reconciling the dev configurations again does not touch the stage ones.
+	stageBefore := map[string]string{}
+	for _, obj := range objs {
+		if obj.GetLabels()[FleetWebhookNameSuffixLabel] == "stage" {
+			stageBefore[obj.GetName()] = obj.GetResourceVersion()
+		}
+	}
+	configs["dev"].webhookCache = &webhookCache{}
+	if err := configs["dev"].createFleetWebhookConfiguration(ctx); err != nil {
+		t.Fatalf("createFleetWebhookConfiguration() with the dev suffix = %v, want no error", err)
+	}
+
+	// Cleaning up the dev configurations keeps the stage ones and their anchor.
+	if err := CleanupWebhookConfigurations(ctx, fakeClient, "dev"); err != nil {
+		t.Fatalf("CleanupWebhookConfigurations(dev) = %v, want no error", err)
+	}
+	for _, obj := range objs {
+		err := fakeClient.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+		switch obj.GetLabels()[FleetWebhookNameSuffixLabel] {
+		case "dev":
+			if !apierrors.IsNotFound(err) {
+				t.Errorf("Get(%s) = %v, want not found", obj.GetName(), err)
+			}
+		default:
+			if err != nil {
+				t.Fatalf("Get(%s) = %v, want no error", obj.GetName(), err)
+			}
+			if obj.GetResourceVersion() != stageBefore[obj.GetName()] {
+				t.Errorf("%s resource version = %s, want %s untouched by the dev hub", obj.GetName(), obj.GetResourceVersion(), stageBefore[obj.GetName()])
+			}
+		}
+	}
+	var anchor rbacv1.ClusterRole
+	if err := fakeClient.Get(ctx, client.ObjectKey{Name: suffixedName(WebhookConfigurationAnchorName, "dev")}, &anchor); !apierrors.IsNotFound(err) {
+		t.Errorf("Get(dev anchor) = %v, want not found", err)
+	}
+	if err := fakeClient.Get(ctx, client.ObjectKey{Name: suffixedName(WebhookConfigurationAnchorName, "stage")}, &anchor); err != nil {
+		t.Errorf("Get(stage anchor) = %v, want no error", err)
+	}
+
+	// Cleaning up without a suffix does not touch the configurations generated with one.
+	if err := CleanupWebhookConfigurations(ctx, fakeClient, ""); err != nil {
+		t.Fatalf("CleanupWebhookConfigurations() = %v, want no error", err)
+	}
+	for _, obj := range objs {
+		if _, found := stageBefore[obj.GetName()]; !found {
+			continue
+		}
+		if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			t.Errorf("Get(%s) = %v, want the stage configuration kept", obj.GetName(), err)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package work provides a validating webhook for the work custom resource in the KubeFleet API group.
+package work
+
+import (
+	"context"
+	"net/http"
+
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
+	"github.com/kubefleet-dev/kubefleet/pkg/webhook/validation"
+)
+
+var (
+	// ValidationPath is the webhook service path which admission requests are routed to for validating work resources.
+	ValidationPath = utils.RegisterWebhookPath(placementv1beta1.GroupVersion.Group, placementv1beta1.GroupVersion.Version, "work", utils.ValidatingWebhookPathKind)
+)
+
+type workValidator struct {
+	decoder webhook.AdmissionDecoder
+	// config holds the validator settings of the requests.
+	config *validator.ConfigStore
+}
+
+// Add registers the webhook for K8s bulit-in object types.
+func Add(mgr manager.Manager, config *validator.ConfigStore) error {
+	decoder, err := validation.SharedDecoder(mgr.GetScheme(), &placementv1beta1.Work{})
+	if err != nil {
+		return err
+	}
+	hookServer := mgr.GetWebhookServer()
+	hookServer.Register(ValidationPath, &webhook.Admission{Handler: &workValidator{decoder: decoder, config: config}})
+	return nil
+}
+
+// Handle workValidator checks to see if the manifests of the work are within the limits.
+func (v *workValidator) Handle(_ context.Context, req admission.Request) admission.Response {
+	var work placementv1beta1.Work
+	klog.V(2).InfoS("Validating webhook handling work", "operation", req.Operation, "work", klog.KRef(req.Namespace, req.Name))
+	if err := v.decoder.Decode(req, &work); err != nil {
+		klog.ErrorS(err, "Failed to decode work object for validating fields", "userName", req.UserInfo.Username, "groups", req.UserInfo.Groups, "work", klog.KRef(req.Namespace, req.Name))
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if err := v.config.Load().ValidateWork(&work, len(req.Object.Raw), req.UserInfo); err != nil {
+		klog.V(2).ErrorS(err, "Work has invalid manifests, request is denied", "operation", req.Operation, "work", klog.KObj(&work), "userName", req.UserInfo.Username)
+		return admission.Denied(err.Error())
+	}
+
+	klog.V(2).InfoS("Work has valid manifests", "work", klog.KObj(&work))
+	return admission.Allowed("work has valid manifests")
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package work
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	"github.com/kubefleet-dev/kubefleet/pkg/utils/validator"
+	webhooktesting "github.com/kubefleet-dev/kubefleet/pkg/webhook/testing"
+)
+
+// newWork returns a Work in the namespace of member-1 embedding the raw manifests.
+func newWork(manifests ...string) *placementv1beta1.Work {
+	work := &placementv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{Name: "crp-work", Namespace: "fleet-member-member-1"},
+	}
+	for _, manifest := range manifests {
+		work.Spec.Workload.Manifests = append(work.Spec.Workload.Manifests, placementv1beta1.Manifest{RawExtension: runtime.RawExtension{Raw: []byte(manifest)}})
+	}
+	return work
+}
+
+func TestHandle(t *testing.T) {
+	configMap := `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"app","namespace":"app"}}`
+	oversizedConfigMap := `{"apiVersion":"v1","kind":"ConfigMap","data":{"key":"` + strings.Repeat("v", 1024) + `"}}`
+	hubAgent := authenticationv1.UserInfo{Username: "system:serviceaccount:fleet-system:hub-agent"}
+	config := validator.NewConfigStore(validator.Config{
+		WorkLimits:         validator.WorkLimits{MaxManifests: 2, MaxManifestSizeBytes: 1024, MaxSizeBytes: 2048},
+		HubAgentWorkLimits: validator.WorkLimits{MaxManifests: 4, MaxManifestSizeBytes: 2048, MaxSizeBytes: 4096},
+		HubAgentIdentities: []string{hubAgent.Username},
+	})
+
+	testCases := map[string]struct {
+		req         admission.Request
+		wantDenied  string
+		wantErrored bool
+	}{
+		"create with valid manifests": {
+			req: webhooktesting.NewCreateRequest(newWork(configMap, configMap)),
+		},
+		"update with valid manifests": {
+			req: webhooktesting.NewUpdateRequest(newWork(configMap), newWork(configMap, configMap)),
+		},
+		"create with too many manifests": {
+			req:        webhooktesting.NewCreateRequest(newWork(configMap, configMap, configMap)),
+			wantDenied: "the work has 3 manifests, which exceeds the limit of 2 manifests",
+		},
+		"update with too many manifests": {
+			req:        webhooktesting.NewUpdateRequest(newWork(configMap), newWork(configMap, configMap, configMap)),
+			wantDenied: "the work has 3 manifests, which exceeds the limit of 2 manifests",
+		},
+		"hub agent creates a work with more manifests": {
+			req: webhooktesting.NewCreateRequest(newWork(configMap, configMap, configMap, configMap), webhooktesting.WithUserInfo(hubAgent)),
+		},
+		"create with an oversized manifest": {
+			req:        webhooktesting.NewCreateRequest(newWork(configMap, oversizedConfigMap)),
+			wantDenied: "the size of manifest 1 is 1080 bytes, which exceeds the limit of 1024 bytes",
+		},
+		"hub agent creates a work with a larger manifest": {
+			req: webhooktesting.NewCreateRequest(newWork(oversizedConfigMap), webhooktesting.WithUserInfo(hubAgent)),
+		},
+		"create an oversized work": {
+			req:        webhooktesting.NewCreateRequest(newWork(oversizedConfigMap[:1000]+`"}}`, oversizedConfigMap[:1000]+`"}}`)),
+			wantDenied: "which exceeds the limit of 2048 bytes",
+		},
+		"create with a manifest without a kind": {
+			req:        webhooktesting.NewCreateRequest(newWork(configMap, `{"apiVersion":"v1","metadata":{"name":"app"}}`)),
+			wantDenied: "manifest 1 is invalid: the kind of the manifest is not set",
+		},
+		"create with a manifest which is not an object": {
+			req:        webhooktesting.NewCreateRequest(newWork(`"ConfigMap"`)),
+			wantDenied: "manifest 0 is invalid: the manifest is not an object",
+		},
+		"create with an undecodable work": {
+			req: func() admission.Request {
+				req := webhooktesting.NewCreateRequest(newWork(configMap))
+				req.Object = runtime.RawExtension{Raw: []byte("{")}
+				return req
+			}(),
+			wantErrored: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			v := &workValidator{decoder: admission.NewDecoder(webhooktesting.Scheme), config: config}
+			resp := v.Handle(context.Background(), tc.req)
+			switch {
+			case tc.wantErrored:
+				if resp.Allowed || resp.Result == nil || resp.Result.Code != http.StatusBadRequest {
+					t.Errorf("Handle() = %+v, want an errored response with code %d", resp.Result, http.StatusBadRequest)
+				}
+			case tc.wantDenied != "":
+				webhooktesting.AssertDenied(t, resp, tc.wantDenied)
+			default:
+				webhooktesting.AssertAllowed(t, resp)
+			}
+		})
+	}
+}
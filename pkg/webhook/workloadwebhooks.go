@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubefleet-dev/kubefleet/pkg/utils"
+)
+
+// defaultWorkloadWebhookExcludedNamespaces are the namespaces the workload webhooks skip by default. Intercepting
+// the pods of these namespaces could deadlock the bootstrap of the hub cluster while the webhook is down.
+var defaultWorkloadWebhookExcludedNamespaces = []string{"kube-system", "kube-node-lease", utils.FleetSystemNamespace}
+
+// normalizeWorkloadWebhookExcludedNamespaces returns the namespaces the workload webhooks skip instead of the default
+// kube-system, kube-node-lease and fleet-system, sorted and deduplicated. An empty list excludes no namespace and a
+// nil one keeps the defaults. It returns an error if any of the names is not a legal namespace name.
+func normalizeWorkloadWebhookExcludedNamespaces(namespaces []string) ([]string, error) {
+	for _, ns := range namespaces {
+		if errs := apimachineryvalidation.ValidateNamespaceName(ns, false); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid workload webhook excluded namespace %q: %s", ns, strings.Join(errs, ", "))
+		}
+	}
+	if namespaces == nil {
+		return nil, nil
+	}
+	// Keep the list sorted and unique so that the generated webhooks, and their hash, are stable.
+	excluded := slices.Clone(namespaces)
+	slices.Sort(excluded)
+	return slices.Compact(excluded), nil
+}
+
+// workloadNamespaceSelector returns the namespace selector of the workload webhooks, which skips the excluded
+// namespaces. It returns nil, i.e., every namespace is matched, if no namespace is excluded.
+func (w *Config) workloadNamespaceSelector() *metav1.LabelSelector {
+	excluded := w.workloadWebhookExcludedNamespaces
+	if excluded == nil {
+		excluded = defaultWorkloadWebhookExcludedNamespaces
+	}
+	if len(excluded) == 0 {
+		return nil
+	}
+	return &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{
+				Key:      corev1.LabelMetadataName,
+				Operator: metav1.LabelSelectorOpNotIn,
+				Values:   slices.Clone(excluded),
+			},
+		},
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	admv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubefleet-dev/kubefleet/cmd/hubagent/options"
+)
+
+func TestWorkloadWebhookNamespaceSelector(t *testing.T) {
+	url := options.WebhookClientConnectionType("url")
+	notIn := func(namespaces ...string) *metav1.LabelSelector {
+		return &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "kubernetes.io/metadata.name", Operator: metav1.LabelSelectorOpNotIn, Values: namespaces},
+			},
+		}
+	}
+	testCases := map[string]struct {
+		excluded     []string
+		wantSelector *metav1.LabelSelector
+	}{
+		"default excluded namespaces": {
+			wantSelector: notIn("kube-system", "kube-node-lease", "fleet-system"),
+		},
+		"configured excluded namespaces are sorted and deduped": {
+			excluded:     []string{"monitoring", "kube-system", "monitoring"},
+			wantSelector: notIn("kube-system", "monitoring"),
+		},
+		"no excluded namespace": {
+			excluded: []string{},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			excluded, err := normalizeWorkloadWebhookExcludedNamespaces(tc.excluded)
+			if err != nil {
+				t.Fatalf("normalizeWorkloadWebhookExcludedNamespaces() = %v, want no error", err)
+			}
+			w := &Config{
+				serviceNamespace:                  "test-namespace",
+				servicePort:                       8080,
+				serviceURL:                        "test-url",
+				clientConnectionType:              &url,
+				role:                              options.WebhookRoleAll,
+				workloadWebhookExcludedNamespaces: excluded,
+			}
+
+			// Only the namespaced workload webhooks get the selector.
+			want := map[string]*metav1.LabelSelector{}
+			got := map[string]*metav1.LabelSelector{}
+			for _, wh := range w.buildFleetValidatingWebhooks() {
+				want[wh.Name] = nil
+				got[wh.Name] = wh.NamespaceSelector
+			}
+			want["fleet.pod.validating"] = tc.wantSelector
+			want["fleet.replicaset.validating"] = tc.wantSelector
+			for _, wh := range w.buildFleetMutatingWebhooks() {
+				want[wh.Name] = nil
+				got[wh.Name] = wh.NamespaceSelector
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("webhook namespace selectors mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWorkloadWebhookExcludedNamespacesChangeTheWebhooks(t *testing.T) {
+	url := options.WebhookClientConnectionType("url")
+	w := &Config{
+		serviceNamespace:     "test-namespace",
+		servicePort:          8080,
+		serviceURL:           "test-url",
+		clientConnectionType: &url,
+		role:                 options.WebhookRoleWorkload,
+	}
+	selectors := func(webhooks []admv1.ValidatingWebhook) []*metav1.LabelSelector {
+		var got []*metav1.LabelSelector
+		for _, wh := range webhooks {
+			got = append(got, wh.NamespaceSelector)
+		}
+		return got
+	}
+	before := selectors(w.buildFleetValidatingWebhooks())
+	w.workloadWebhookExcludedNamespaces = []string{"monitoring"}
+	// The cached webhooks must be rebuilt with the new selector.
+	if diff := cmp.Diff(before, selectors(w.buildFleetValidatingWebhooks())); diff == "" {
+		t.Errorf("buildFleetValidatingWebhooks() returned the same namespace selectors after the excluded namespaces changed")
+	}
+}
+
+func TestNormalizeWorkloadWebhookExcludedNamespaces(t *testing.T) {
+	testCases := map[string]struct {
+		namespaces []string
+		wantErr    bool
+	}{
+		"valid namespaces": {
+			namespaces: []string{"kube-system", "team-a"},
+		},
+		"upper case namespace": {
+			namespaces: []string{"Kube-System"},
+			wantErr:    true,
+		},
+		"empty namespace": {
+			namespaces: []string{""},
+			wantErr:    true,
+		},
+		"namespace with a slash": {
+			namespaces: []string{"team/a"},
+			wantErr:    true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := normalizeWorkloadWebhookExcludedNamespaces(tc.namespaces); (err != nil) != tc.wantErr {
+				t.Errorf("normalizeWorkloadWebhookExcludedNamespaces() = %v, want error %t", err, tc.wantErr)
+			}
+		})
+	}
+}